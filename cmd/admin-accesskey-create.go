@@ -50,6 +50,7 @@ var adminAccesskeyCreateFlags = []cli.Flag{
 		Name:  "expiry",
 		Usage: "expiry date for the access key",
 	},
+	credentialsOutputFlag,
 }
 
 var adminAccesskeyCreateCmd = cli.Command{
@@ -86,6 +87,9 @@ EXAMPLES:
 
   6. Create a new access key pair for user 'tester' with a custom name and description
 	 {{.Prompt}} {{.HelpName}} myminio/ tester --name "Tester's Access Key" --description "Access key for tester"
+
+  7. Create a new access key pair for user 'tester' and save it to be imported with 'mc alias import'
+     {{.Prompt}} {{.HelpName}} myminio/ tester --output json > tester.json
 `,
 }
 
@@ -33,13 +33,24 @@ import (
 	"github.com/minio/pkg/v3/console"
 )
 
+var adminClusterBucketExportFlags = []cli.Flag{
+	cli.StringFlag{
+		Name:  "bucket",
+		Usage: "export the metadata of this bucket only",
+	},
+	cli.StringFlag{
+		Name:  "out, o",
+		Usage: "path of the zip file, defaults to TARGET-BUCKET-metadata.zip",
+	},
+}
+
 var adminClusterBucketExportCmd = cli.Command{
 	Name:            "export",
 	Usage:           "backup bucket metadata to a zip file",
 	Action:          mainClusterBucketExport,
 	OnUsageError:    onUsageError,
 	Before:          setGlobalsFromContext,
-	Flags:           globalFlags,
+	Flags:           append(adminClusterBucketExportFlags, globalFlags...),
 	HideHelpCommand: true,
 	CustomHelpTemplate: `NAME:
   {{.HelpName}} - {{.Usage}}
@@ -53,6 +64,9 @@ FLAGS:
 EXAMPLES:
   1. Save metadata of all buckets to a zip file.
      {{.Prompt}} {{.HelpName}} myminio
+
+  2. Save metadata of the bucket 'mybucket' to 'mybucket-meta.zip', to import it on another cluster.
+     {{.Prompt}} {{.HelpName}} myminio --bucket mybucket --out mybucket-meta.zip
 `,
 }
 
@@ -83,6 +97,12 @@ func mainClusterBucketExport(ctx *cli.Context) error {
 	aliasedURL = filepath.ToSlash(aliasedURL)
 	aliasedURL = filepath.Clean(aliasedURL)
 	_, bucket := url2Alias(aliasedURL)
+	if b := ctx.String("bucket"); b != "" {
+		if bucket != "" && bucket != b {
+			fatalIf(errInvalidArgument().Trace(aliasedURL, b), "--bucket differs from the bucket of `"+aliasedURL+"`.")
+		}
+		bucket = b
+	}
 	r, e := client.ExportBucketMetadata(context.Background(), bucket)
 	fatalIf(probe.NewError(e).Trace(aliasedURL), "Unable to export bucket metadata.")
 
@@ -102,6 +122,9 @@ func mainClusterBucketExport(ctx *cli.Context) error {
 	tmpFile.Close()
 	// We use 4 bytes of the 32 bytes to identify the file.
 	downloadPath := fmt.Sprintf("%s-%s-metadata.%s", aliasedURL, bucket, ext)
+	if out := ctx.String("out"); out != "" {
+		downloadPath = out
+	}
 	// Create necessary directories.
 	dir := filepath.Dir(downloadPath)
 	if e := os.MkdirAll(dir, 0o755); e != nil {
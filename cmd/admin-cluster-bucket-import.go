@@ -35,13 +35,20 @@ import (
 	"github.com/minio/pkg/v3/console"
 )
 
+var adminClusterBucketImportFlags = []cli.Flag{
+	cli.StringFlag{
+		Name:  "bucket",
+		Usage: "import the metadata of this bucket only",
+	},
+}
+
 var adminClusterBucketImportCmd = cli.Command{
 	Name:            "import",
 	Usage:           "restore bucket metadata from a zip file",
 	Action:          mainClusterBucketImport,
 	OnUsageError:    onUsageError,
 	Before:          setGlobalsFromContext,
-	Flags:           globalFlags,
+	Flags:           append(adminClusterBucketImportFlags, globalFlags...),
 	HideHelpCommand: true,
 	CustomHelpTemplate: `NAME:
   {{.HelpName}} - {{.Usage}}
@@ -55,6 +62,9 @@ FLAGS:
 EXAMPLES:
   1. Recover bucket metadata for all buckets from previously saved bucket metadata backup.
      {{.Prompt}} {{.HelpName}} myminio /backups/myminio-bucket-metadata.zip
+
+  2. Restore the metadata of the bucket 'mybucket' exported from another cluster.
+     {{.Prompt}} {{.HelpName}} myminio --bucket mybucket mybucket-meta.zip
 `,
 }
 
@@ -108,6 +118,12 @@ func mainClusterBucketImport(ctx *cli.Context) error {
 	aliasedURL = filepath.ToSlash(aliasedURL)
 	aliasedURL = filepath.Clean(aliasedURL)
 	_, bucket := url2Alias(aliasedURL)
+	if b := ctx.String("bucket"); b != "" {
+		if bucket != "" && bucket != b {
+			fatalIf(errInvalidArgument().Trace(aliasedURL, b), "--bucket differs from the bucket of `"+aliasedURL+"`.")
+		}
+		bucket = b
+	}
 
 	rpt, e := client.ImportBucketMetadata(context.Background(), bucket, f)
 	fatalIf(probe.NewError(e).Trace(aliasedURL), "Unable to import bucket metadata.")
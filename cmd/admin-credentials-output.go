@@ -0,0 +1,126 @@
+// Copyright (c) 2015-2024 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/minio/cli"
+	"github.com/minio/mc/pkg/probe"
+)
+
+// Formats of the credentials printed by --output.
+const (
+	credentialsOutputEnv      = "env"
+	credentialsOutputJSON     = "json"
+	credentialsOutputAliasCmd = "alias-cmd"
+)
+
+var credentialsOutputFlag = cli.StringFlag{
+	Name:  "output",
+	Usage: "print the new credentials as 'env' export lines, 'json' for 'mc alias import' or an 'alias-cmd' command",
+}
+
+// checkCredentialsOutputSyntax validates --output.
+func checkCredentialsOutputSyntax(ctx *cli.Context) {
+	switch format := ctx.String("output"); format {
+	case "", credentialsOutputEnv, credentialsOutputJSON, credentialsOutputAliasCmd:
+	default:
+		fatalIf(errInvalidArgument().Trace(format), "`--output` must be one of 'env', 'json' or 'alias-cmd'.")
+	}
+}
+
+var invalidAliasCharsRegex = regexp.MustCompile("[^a-zA-Z0-9-_]")
+
+// credentialsAlias returns the name of the alias set by --output alias-cmd,
+// the access key prefixed by the alias of the server.
+func credentialsAlias(alias, accessKey string) string {
+	return alias + "-" + invalidAliasCharsRegex.ReplaceAllString(accessKey, "-")
+}
+
+// credentialsOutput returns the new credentials of the server of
+// aliasedURL as ready to use snippets in format.
+func credentialsOutput(format, aliasedURL, accessKey, secretKey string, expiry *time.Time) (string, *probe.Error) {
+	alias, _, hostCfg, err := expandAlias(aliasedURL)
+	if err != nil {
+		return "", err.Trace(aliasedURL)
+	}
+	if hostCfg == nil {
+		return "", errInvalidAliasedURL(aliasedURL).Trace(aliasedURL)
+	}
+	if expiry != nil && (expiry.IsZero() || expiry.Equal(timeSentinel)) {
+		expiry = nil
+	}
+
+	switch format {
+	case credentialsOutputEnv:
+		lines := []string{
+			"export AWS_ACCESS_KEY_ID=" + shellQuote(accessKey),
+			"export AWS_SECRET_ACCESS_KEY=" + shellQuote(secretKey),
+			"export AWS_ENDPOINT_URL=" + shellQuote(hostCfg.URL),
+		}
+		return strings.Join(lines, "\n"), nil
+	case credentialsOutputJSON:
+		cfg := aliasConfigV10{
+			URL:       hostCfg.URL,
+			AccessKey: accessKey,
+			SecretKey: secretKey,
+			API:       hostCfg.API,
+			Path:      hostCfg.Path,
+			Expiry:    expiry,
+		}
+		if cfg.API == "" {
+			cfg.API = "S3v4"
+		}
+		if cfg.Path == "" {
+			cfg.Path = "auto"
+		}
+		buf, e := json.MarshalIndent(cfg, "", "  ")
+		if e != nil {
+			return "", probe.NewError(e)
+		}
+		return string(buf), nil
+	case credentialsOutputAliasCmd:
+		cmd := fmt.Sprintf("mc alias set %s %s %s %s", credentialsAlias(alias, accessKey), shellQuote(hostCfg.URL), shellQuote(accessKey), shellQuote(secretKey))
+		if hostCfg.API != "" {
+			cmd += " --api " + hostCfg.API
+		}
+		if hostCfg.Path != "" {
+			cmd += " --path " + hostCfg.Path
+		}
+		return cmd, nil
+	}
+	return "", errInvalidArgument().Trace(format)
+}
+
+// printCredentialsOutput prints the credentials when --output is set and
+// reports whether they were printed.
+func printCredentialsOutput(ctx *cli.Context, aliasedURL, accessKey, secretKey string, expiry *time.Time) bool {
+	format := ctx.String("output")
+	if format == "" {
+		return false
+	}
+	out, err := credentialsOutput(format, aliasedURL, accessKey, secretKey, expiry)
+	fatalIf(err, "Unable to print the credentials.")
+	fmt.Println(out)
+	return true
+}
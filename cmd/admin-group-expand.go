@@ -0,0 +1,326 @@
+// Copyright (c) 2015-2024 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	json "github.com/minio/colorjson"
+	"github.com/minio/madmin-go/v3"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/pkg/v3/console"
+)
+
+var adminGroupExpandFlags = []cli.Flag{
+	cli.BoolFlag{
+		Name:  "ldap",
+		Usage: "expand an LDAP group using the policy mappings known to the server",
+	},
+}
+
+var adminGroupExpandCmd = cli.Command{
+	Name:         "expand",
+	Usage:        "list all users of a group, including nested groups, with their effective policies",
+	Action:       mainAdminGroupExpand,
+	OnUsageError: onUsageError,
+	Before:       setGlobalsFromContext,
+	Flags:        append(adminGroupExpandFlags, globalFlags...),
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} TARGET GROUPNAME
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+DESCRIPTION:
+  Members of a group which are groups themselves are expanded recursively. The
+  effective policies of a user are the policies attached to the user and to all
+  the enabled groups the user is a member of.
+
+  LDAP group members are not stored by the server, with --ldap only users which
+  have policy mappings or have logged in at least once are listed.
+
+EXAMPLES:
+  1. List all users of group 'allcents' with their effective policies.
+     {{.Prompt}} {{.HelpName}} myminio allcents
+
+  2. List all users of an LDAP group.
+     {{.Prompt}} {{.HelpName}} myminio --ldap "cn=projecta,ou=groups,ou=swengg,dc=min,dc=io"
+`,
+}
+
+// groupExpandUser is a user of an expanded group.
+type groupExpandUser struct {
+	User     string   `json:"user"`
+	Status   string   `json:"status,omitempty"`
+	Via      []string `json:"via,omitempty"`
+	Policies []string `json:"policies"`
+}
+
+// groupExpandMessage is the flattened membership of a group.
+type groupExpandMessage struct {
+	Status      string            `json:"status"`
+	GroupName   string            `json:"groupName"`
+	GroupPolicy []string          `json:"groupPolicy,omitempty"`
+	Users       []groupExpandUser `json:"users"`
+}
+
+func (m groupExpandMessage) String() string {
+	lines := []string{
+		console.Colorize("GroupMessage", "Group: "+m.GroupName),
+		console.Colorize("GroupMessage", "Policy: "+strings.Join(m.GroupPolicy, ",")),
+		console.Colorize("GroupMessage", fmt.Sprintf("Users: %d", len(m.Users))),
+	}
+	width := 0
+	for _, u := range m.Users {
+		width = max(width, len(u.User))
+	}
+	for _, u := range m.Users {
+		line := "  " + console.Colorize("GroupExpandUser", fmt.Sprintf("%-*s", width, u.User)) + "  " + strings.Join(u.Policies, ",")
+		if len(u.Via) > 0 {
+			line += console.Colorize("GroupExpandVia", " (via "+strings.Join(u.Via, ", ")+")")
+		}
+		if u.Status == string(madmin.AccountDisabled) {
+			line += console.Colorize("GroupExpandDisabled", " [disabled]")
+		}
+		lines = append(lines, line)
+	}
+	return strings.Join(lines, "\n")
+}
+
+func (m groupExpandMessage) JSON() string {
+	m.Status = "success"
+	jsonMessageBytes, e := json.MarshalIndent(m, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+
+	return string(jsonMessageBytes)
+}
+
+// splitPolicies splits a comma separated list of policies.
+func splitPolicies(s string) (policies []string) {
+	for _, p := range strings.Split(s, ",") {
+		if p = strings.TrimSpace(p); p != "" {
+			policies = append(policies, p)
+		}
+	}
+	return policies
+}
+
+// mergePolicies returns the sorted union of all policies.
+func mergePolicies(lists ...[]string) []string {
+	set := make(map[string]struct{})
+	for _, l := range lists {
+		for _, p := range l {
+			set[p] = struct{}{}
+		}
+	}
+	policies := make([]string, 0, len(set))
+	for p := range set {
+		policies = append(policies, p)
+	}
+	sort.Strings(policies)
+	return policies
+}
+
+// flattenGroupMembers walks the members of group, members which are groups
+// are expanded recursively. It returns every user found with the nested
+// groups they were found through, cycles between groups are ignored.
+func flattenGroupMembers(group string, members func(group string) (users, groups []string, err *probe.Error)) (map[string][]string, *probe.Error) {
+	users := make(map[string][]string)
+	visited := map[string]bool{group: true}
+
+	var walk func(group string, nested bool) *probe.Error
+	walk = func(group string, nested bool) *probe.Error {
+		groupUsers, subGroups, err := members(group)
+		if err != nil {
+			return err.Trace(group)
+		}
+		for _, u := range groupUsers {
+			via := users[u]
+			if nested && !slices.Contains(via, group) {
+				via = append(via, group)
+			}
+			users[u] = via
+		}
+		for _, g := range subGroups {
+			if visited[g] {
+				continue
+			}
+			visited[g] = true
+			if err := walk(g, true); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if err := walk(group, false); err != nil {
+		return nil, err
+	}
+	return users, nil
+}
+
+// expandGroup flattens a builtin group and computes the effective policies of its users.
+func expandGroup(client *madmin.AdminClient, group string) (msg groupExpandMessage, err *probe.Error) {
+	allGroups, e := client.ListGroups(globalContext)
+	if e != nil {
+		return msg, probe.NewError(e)
+	}
+	isGroup := make(map[string]bool, len(allGroups))
+	for _, g := range allGroups {
+		isGroup[g] = true
+	}
+
+	descs := make(map[string]*madmin.GroupDesc)
+	describe := func(group string) (*madmin.GroupDesc, *probe.Error) {
+		if gd, ok := descs[group]; ok {
+			return gd, nil
+		}
+		gd, e := client.GetGroupDescription(globalContext, group)
+		if e != nil {
+			return nil, probe.NewError(e)
+		}
+		descs[group] = gd
+		return gd, nil
+	}
+
+	root, err := describe(group)
+	if err != nil {
+		return msg, err.Trace(group)
+	}
+	users, err := flattenGroupMembers(group, func(group string) (users, groups []string, err *probe.Error) {
+		gd, err := describe(group)
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, m := range gd.Members {
+			if isGroup[m] {
+				groups = append(groups, m)
+			} else {
+				users = append(users, m)
+			}
+		}
+		return users, groups, nil
+	})
+	if err != nil {
+		return msg, err
+	}
+
+	msg = groupExpandMessage{GroupName: group, GroupPolicy: splitPolicies(root.Policy)}
+	for user, via := range users {
+		info, e := client.GetUserInfo(globalContext, user)
+		if e != nil {
+			return msg, probe.NewError(e).Trace(user)
+		}
+		policies := [][]string{splitPolicies(info.PolicyName)}
+		for _, g := range info.MemberOf {
+			gd, err := describe(g)
+			if err != nil {
+				return msg, err.Trace(g)
+			}
+			if gd.Status == string(madmin.GroupDisabled) {
+				continue
+			}
+			policies = append(policies, splitPolicies(gd.Policy))
+		}
+		msg.Users = append(msg.Users, groupExpandUser{
+			User:     user,
+			Status:   string(info.Status),
+			Via:      via,
+			Policies: mergePolicies(policies...),
+		})
+	}
+	return msg, nil
+}
+
+// expandLDAPGroup lists the LDAP users known to be members of group, LDAP
+// membership can only be resolved through the policy mappings of the server.
+func expandLDAPGroup(client *madmin.AdminClient, group string) (msg groupExpandMessage, err *probe.Error) {
+	entities, e := client.GetLDAPPolicyEntities(globalContext, madmin.PolicyEntitiesQuery{})
+	if e != nil {
+		return msg, probe.NewError(e)
+	}
+
+	msg = groupExpandMessage{GroupName: group}
+	for _, gm := range entities.GroupMappings {
+		if strings.EqualFold(gm.Group, group) {
+			msg.GroupPolicy = mergePolicies(gm.Policies)
+		}
+	}
+	for _, um := range entities.UserMappings {
+		member := false
+		policies := [][]string{um.Policies}
+		for _, gm := range um.MemberOfMappings {
+			if strings.EqualFold(gm.Group, group) {
+				member = true
+			}
+			policies = append(policies, gm.Policies)
+		}
+		if member {
+			msg.Users = append(msg.Users, groupExpandUser{
+				User:     um.User,
+				Policies: mergePolicies(policies...),
+			})
+		}
+	}
+	return msg, nil
+}
+
+// checkAdminGroupExpandSyntax - validate all the passed arguments
+func checkAdminGroupExpandSyntax(ctx *cli.Context) {
+	if len(ctx.Args()) != 2 {
+		showCommandHelpAndExit(ctx, 1) // last argument is exit code
+	}
+}
+
+// mainAdminGroupExpand is the handle for "mc admin group expand" command.
+func mainAdminGroupExpand(ctx *cli.Context) error {
+	checkAdminGroupExpandSyntax(ctx)
+
+	console.SetColor("GroupMessage", color.New(color.FgGreen))
+	console.SetColor("GroupExpandUser", color.New(color.Bold))
+	console.SetColor("GroupExpandVia", color.New(color.FgCyan))
+	console.SetColor("GroupExpandDisabled", color.New(color.FgRed))
+
+	// Get the alias parameter from cli
+	args := ctx.Args()
+	aliasedURL := args.Get(0)
+
+	// Create a new MinIO Admin Client
+	client, err := newAdminClient(aliasedURL)
+	fatalIf(err, "Unable to initialize admin connection.")
+
+	group := args.Get(1)
+	var msg groupExpandMessage
+	if ctx.Bool("ldap") {
+		msg, err = expandLDAPGroup(client, group)
+	} else {
+		msg, err = expandGroup(client, group)
+	}
+	fatalIf(err.Trace(args...), "Unable to expand group")
+
+	sort.Slice(msg.Users, func(i, j int) bool { return msg.Users[i].User < msg.Users[j].User })
+	printMsg(msg)
+	return nil
+}
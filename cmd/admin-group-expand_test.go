@@ -0,0 +1,61 @@
+// Copyright (c) 2015-2024 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/minio/mc/pkg/probe"
+)
+
+func TestFlattenGroupMembers(t *testing.T) {
+	groups := map[string][]string{
+		"eng":      {"alice", "backend", "frontend"},
+		"backend":  {"bob", "carol", "eng"},
+		"frontend": {"carol", "dave"},
+	}
+	members := func(group string) (users, subGroups []string, err *probe.Error) {
+		for _, m := range groups[group] {
+			if _, ok := groups[m]; ok {
+				subGroups = append(subGroups, m)
+			} else {
+				users = append(users, m)
+			}
+		}
+		return users, subGroups, nil
+	}
+
+	users, err := flattenGroupMembers("eng", members)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := map[string][]string{
+		"alice": nil,
+		"bob":   {"backend"},
+		"carol": {"backend", "frontend"},
+		"dave":  {"frontend"},
+	}
+	if !reflect.DeepEqual(users, expected) {
+		t.Fatalf("expected %v, got %v", expected, users)
+	}
+
+	if policies := mergePolicies(splitPolicies("readwrite, diagnostics"), []string{"readonly", "readwrite"}); !reflect.DeepEqual(policies, []string{"diagnostics", "readonly", "readwrite"}) {
+		t.Fatalf("unexpected merged policies %v", policies)
+	}
+}
@@ -23,6 +23,7 @@ var adminGroupSubcommands = []cli.Command{
 	adminGroupAddCmd,
 	adminGroupRemoveCmd,
 	adminGroupInfoCmd,
+	adminGroupExpandCmd,
 	adminGroupListCmd,
 	adminGroupEnableCmd,
 	adminGroupDisableCmd,
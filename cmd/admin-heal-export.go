@@ -0,0 +1,193 @@
+// Copyright (c) 2015-2024 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bufio"
+	"encoding/csv"
+	gojson "encoding/json"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/minio/mc/pkg/probe"
+)
+
+const (
+	healExportCSV  = "csv"
+	healExportJSON = "json"
+)
+
+// healExportState is the state of the drives of an exported item.
+type healExportState struct {
+	Color     string `json:"color"`
+	Online    int    `json:"online"`
+	Missing   int    `json:"missing"`
+	Corrupted int    `json:"corrupted"`
+	Offline   int    `json:"offline"`
+}
+
+func newHealExportState(s healDrivesState) healExportState {
+	return healExportState{
+		Color:     s.Color,
+		Online:    s.Online,
+		Missing:   s.Missing,
+		Corrupted: s.Corrupted,
+		Offline:   s.Offline,
+	}
+}
+
+// healExportRecord is a single heal result item written to the export file.
+type healExportRecord struct {
+	Time   time.Time `json:"time"`
+	Type   string    `json:"type"`
+	Name   string    `json:"name"`
+	Size   int64     `json:"size"`
+	Error  string    `json:"error,omitempty"`
+	Detail string    `json:"detail,omitempty"`
+	Healed bool      `json:"healed"`
+	// Number of drives the item was written to while healing.
+	DrivesHealed int `json:"drivesHealed"`
+	// Estimated number of bytes written to the healed drives.
+	BytesHealed int64           `json:"bytesHealed"`
+	Before      healExportState `json:"before"`
+	After       healExportState `json:"after"`
+}
+
+var healExportCSVHeader = []string{
+	"time", "type", "name", "size", "healed", "drives_healed", "bytes_healed",
+	"before_color", "before_online", "before_missing", "before_corrupted", "before_offline",
+	"after_color", "after_online", "after_missing", "after_corrupted", "after_offline",
+	"error", "detail",
+}
+
+// newHealExportRecord computes the data movement of a heal result item.
+func newHealExportRecord(h *hri, now time.Time) healExportRecord {
+	r := newHealResultRecord(h)
+	rec := healExportRecord{
+		Time:   now,
+		Type:   r.Type,
+		Name:   r.Name,
+		Size:   r.Size,
+		Error:  r.Error,
+		Detail: r.Detail,
+		Before: newHealExportState(r.Before),
+		After:  newHealExportState(r.After),
+	}
+	if n := r.After.Online - r.Before.Online; n > 0 {
+		rec.Healed = true
+		rec.DrivesHealed = n
+		// Each drive holds a single shard of the object.
+		if h.DataBlocks > 0 {
+			rec.BytesHealed = (r.Size + int64(h.DataBlocks) - 1) / int64(h.DataBlocks) * int64(n)
+		}
+	}
+	return rec
+}
+
+func (r healExportRecord) csvRow() []string {
+	itoa := strconv.Itoa
+	return []string{
+		r.Time.UTC().Format(time.RFC3339), r.Type, r.Name, strconv.FormatInt(r.Size, 10),
+		strconv.FormatBool(r.Healed), itoa(r.DrivesHealed), strconv.FormatInt(r.BytesHealed, 10),
+		r.Before.Color, itoa(r.Before.Online), itoa(r.Before.Missing), itoa(r.Before.Corrupted), itoa(r.Before.Offline),
+		r.After.Color, itoa(r.After.Online), itoa(r.After.Missing), itoa(r.After.Corrupted), itoa(r.After.Offline),
+		r.Error, r.Detail,
+	}
+}
+
+// healResultExporter writes every heal result item of a heal sequence to
+// a CSV or JSON lines file. All methods are safe on a nil exporter.
+type healResultExporter struct {
+	file *os.File
+	buf  *bufio.Writer
+	csv  *csv.Writer
+	json *gojson.Encoder
+}
+
+// healExportFormat returns the export format, guessed from the file
+// extension unless explicitly set.
+func healExportFormat(path, format string) (string, *probe.Error) {
+	if format == "" {
+		if strings.EqualFold(filepath.Ext(path), ".csv") {
+			return healExportCSV, nil
+		}
+		return healExportJSON, nil
+	}
+	switch strings.ToLower(format) {
+	case healExportCSV:
+		return healExportCSV, nil
+	case healExportJSON:
+		return healExportJSON, nil
+	}
+	return "", errInvalidArgument().Trace(format)
+}
+
+func newHealResultExporter(path, format string) (*healResultExporter, *probe.Error) {
+	f, e := os.Create(path)
+	if e != nil {
+		return nil, probe.NewError(e)
+	}
+	x := &healResultExporter{file: f, buf: bufio.NewWriter(f)}
+	if format == healExportCSV {
+		x.csv = csv.NewWriter(x.buf)
+		if e = x.csv.Write(healExportCSVHeader); e != nil {
+			f.Close()
+			return nil, probe.NewError(e)
+		}
+	} else {
+		x.json = gojson.NewEncoder(x.buf)
+	}
+	return x, nil
+}
+
+// export writes a single heal result item.
+func (x *healResultExporter) export(h *hri) {
+	if x == nil {
+		return
+	}
+	rec := newHealExportRecord(h, time.Now())
+	var e error
+	if x.csv != nil {
+		e = x.csv.Write(rec.csvRow())
+	} else {
+		e = x.json.Encode(rec)
+	}
+	fatalIf(probe.NewError(e).Trace(x.file.Name()), "Unable to export heal result.")
+}
+
+// Close flushes the exported records to disk.
+func (x *healResultExporter) Close() *probe.Error {
+	if x == nil {
+		return nil
+	}
+	if x.csv != nil {
+		x.csv.Flush()
+		if e := x.csv.Error(); e != nil {
+			x.file.Close()
+			return probe.NewError(e)
+		}
+	}
+	if e := x.buf.Flush(); e != nil {
+		x.file.Close()
+		return probe.NewError(e)
+	}
+	return probe.NewError(x.file.Close())
+}
@@ -97,6 +97,9 @@ type uiData struct {
 	// channel to receive a prompt string to indicate activity on
 	// the terminal
 	CurChan (<-chan string)
+
+	// Export writes every heal result item when set
+	Export *healResultExporter
 }
 
 func (ui *uiData) updateStats(i madmin.HealResultItem) error {
@@ -245,66 +248,64 @@ func (ui *uiData) printStatsQuietly() {
 	console.PrintC(healedStr)
 }
 
-func (ui *uiData) printItemsJSON(s *madmin.HealTaskStatus) (err error) {
-	type healRec struct {
-		Status string `json:"status"`
-		Error  string `json:"error,omitempty"`
-		Detail string `json:"detail,omitempty"`
-		Type   string `json:"type"`
-		Name   string `json:"name"`
-		Before struct {
-			Color     string                 `json:"color"`
-			Offline   int                    `json:"offline"`
-			Online    int                    `json:"online"`
-			Missing   int                    `json:"missing"`
-			Corrupted int                    `json:"corrupted"`
-			Drives    []madmin.HealDriveInfo `json:"drives"`
-		} `json:"before"`
-		After struct {
-			Color     string                 `json:"color"`
-			Offline   int                    `json:"offline"`
-			Online    int                    `json:"online"`
-			Missing   int                    `json:"missing"`
-			Corrupted int                    `json:"corrupted"`
-			Drives    []madmin.HealDriveInfo `json:"drives"`
-		} `json:"after"`
-		Size int64 `json:"size"`
-	}
-	makeHR := func(h *hri) (r healRec) {
-		r.Status = "success"
-		r.Type, r.Name = h.getHRTypeAndName()
+// healDrivesState is the state of the drives of a healed item.
+type healDrivesState struct {
+	Color     string                 `json:"color"`
+	Offline   int                    `json:"offline"`
+	Online    int                    `json:"online"`
+	Missing   int                    `json:"missing"`
+	Corrupted int                    `json:"corrupted"`
+	Drives    []madmin.HealDriveInfo `json:"drives"`
+}
 
-		var b, a col
-		var err error
-		switch h.Type {
-		case madmin.HealItemBucket:
-			b, a, err = h.getBucketHCCChange()
-		case madmin.HealItemMetadata, madmin.HealItemBucketMetadata:
-			b, a, err = h.getReplicatedFileHCCChange()
-		default:
-			if h.Type == madmin.HealItemObject {
-				r.Size = h.ObjectSize
-			}
-			b, a, err = h.getObjectHCCChange()
-		}
-		if err != nil {
-			r.Error = err.Error()
+// healResultRecord is a single heal result item, before and after healing.
+type healResultRecord struct {
+	Status string          `json:"status"`
+	Error  string          `json:"error,omitempty"`
+	Detail string          `json:"detail,omitempty"`
+	Type   string          `json:"type"`
+	Name   string          `json:"name"`
+	Before healDrivesState `json:"before"`
+	After  healDrivesState `json:"after"`
+	Size   int64           `json:"size"`
+}
+
+func newHealResultRecord(h *hri) (r healResultRecord) {
+	r.Status = "success"
+	r.Type, r.Name = h.getHRTypeAndName()
+
+	var b, a col
+	var err error
+	switch h.Type {
+	case madmin.HealItemBucket:
+		b, a, err = h.getBucketHCCChange()
+	case madmin.HealItemMetadata, madmin.HealItemBucketMetadata:
+		b, a, err = h.getReplicatedFileHCCChange()
+	default:
+		if h.Type == madmin.HealItemObject {
+			r.Size = h.ObjectSize
 		}
-		r.Detail = h.Detail
-		r.Before.Color = strings.ToLower(string(b))
-		r.After.Color = strings.ToLower(string(a))
-		r.Before.Online, r.After.Online = h.GetOnlineCounts()
-		r.Before.Missing, r.After.Missing = h.GetMissingCounts()
-		r.Before.Corrupted, r.After.Corrupted = h.GetCorruptedCounts()
-		r.Before.Offline, r.After.Offline = h.GetOfflineCounts()
-		r.Before.Drives = h.Before.Drives
-		r.After.Drives = h.After.Drives
-		return r
+		b, a, err = h.getObjectHCCChange()
+	}
+	if err != nil {
+		r.Error = err.Error()
 	}
+	r.Detail = h.Detail
+	r.Before.Color = strings.ToLower(string(b))
+	r.After.Color = strings.ToLower(string(a))
+	r.Before.Online, r.After.Online = h.GetOnlineCounts()
+	r.Before.Missing, r.After.Missing = h.GetMissingCounts()
+	r.Before.Corrupted, r.After.Corrupted = h.GetCorruptedCounts()
+	r.Before.Offline, r.After.Offline = h.GetOfflineCounts()
+	r.Before.Drives = h.Before.Drives
+	r.After.Drives = h.After.Drives
+	return r
+}
 
+func (ui *uiData) printItemsJSON(s *madmin.HealTaskStatus) (err error) {
 	for _, item := range s.Items {
 		h := newHRI(&item)
-		jsonBytes, e := json.MarshalIndent(makeHR(h), "", " ")
+		jsonBytes, e := json.MarshalIndent(newHealResultRecord(h), "", " ")
 		fatalIf(probe.NewError(e), "Unable to marshal to JSON.")
 		console.Println(string(jsonBytes))
 	}
@@ -388,6 +389,9 @@ func (ui *uiData) UpdateDisplay(s *madmin.HealTaskStatus) {
 	for _, i := range s.Items {
 		ui.updateStats(i)
 	}
+	for _, i := range s.Items {
+		ui.Export.export(newHRI(&i))
+	}
 
 	// Update display
 	switch {
@@ -106,6 +106,14 @@ var adminHealFlags = []cli.Flag{
 		Name:  "all-drives, a",
 		Usage: "select all drives for verbose printing",
 	},
+	cli.StringFlag{
+		Name:  "export",
+		Usage: "export per-object heal results of a heal sequence to a file",
+	},
+	cli.StringFlag{
+		Name:  "export-format",
+		Usage: "format of the exported heal results (csv/json), guessed from the file extension by default",
+	},
 }
 
 var adminHealCmd = cli.Command{
@@ -128,6 +136,9 @@ FLAGS:
 EXAMPLES:
   1. Monitor healing status on a running server at alias 'myminio':
      {{.Prompt}} {{.HelpName}} myminio/
+
+  2. Heal bucket 'mybucket' and export the result of every healed object to a CSV file for auditing:
+     {{.Prompt}} {{.HelpName}} myminio/mybucket --export heal-results.csv
 `,
 }
 
@@ -142,6 +153,14 @@ func checkAdminHealSyntax(ctx *cli.Context) {
 	if scanArg != scanNormalMode && scanArg != scanDeepMode {
 		showCommandHelpAndExit(ctx, 1) // last argument is exit code
 	}
+
+	if ctx.IsSet("export-format") {
+		if !ctx.IsSet("export") {
+			fatalIf(errInvalidArgument(), "--export-format requires --export.")
+		}
+		_, err := healExportFormat(ctx.String("export"), ctx.String("export-format"))
+		fatalIf(err, "Invalid export format, only csv and json are supported.")
+	}
 }
 
 // stopHealMessage is container for stop heal success and failure messages.
@@ -689,6 +708,9 @@ func mainAdminHeal(ctx *cli.Context) error {
 	// Return the background heal status when the user
 	// doesn't pass a bucket or --recursive flag.
 	if bucket == "" && !ctx.Bool("recursive") {
+		if ctx.IsSet("export") {
+			fatalIf(errInvalidArgument(), "--export requires a heal sequence, pass a bucket or --recursive.")
+		}
 		bgHealStatus, e := adminClnt.BackgroundHealStatus(globalContext)
 		fatalIf(probe.NewError(e), "Unable to get background heal status.")
 		if ctx.Bool("verbose") {
@@ -752,6 +774,13 @@ func mainAdminHeal(ctx *cli.Context) error {
 		}
 	}
 
+	var exporter *healResultExporter
+	if exportPath := ctx.String("export"); exportPath != "" {
+		format, _ := healExportFormat(exportPath, ctx.String("export-format"))
+		exporter, err = newHealResultExporter(exportPath, format)
+		fatalIf(err.Trace(exportPath), "Unable to create heal results export file.")
+	}
+
 	healStart, _, e := adminClnt.Heal(globalContext, bucket, prefix, opts, "", forceStart, false)
 	fatalIf(probe.NewError(e), "Unable to start healing.")
 
@@ -765,9 +794,11 @@ func mainAdminHeal(ctx *cli.Context) error {
 		ObjectsByOnlineDrives: make(map[int]int64),
 		HealthCols:            make(map[col]int64),
 		CurChan:               cursorAnimate(),
+		Export:                exporter,
 	}
 
 	res, e := ui.DisplayAndFollowHealStatus(aliasedURL)
+	fatalIf(exporter.Close().Trace(ctx.String("export")), "Unable to save exported heal results.")
 	if e != nil {
 		if res.FailureDetail != "" {
 			data, _ := json.MarshalIndent(res, "", " ")
@@ -0,0 +1,210 @@
+// Copyright (c) 2015-2024 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"io"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	json "github.com/minio/colorjson"
+	"github.com/minio/madmin-go/v3"
+)
+
+// adminInfoSchemaVersion is the version of the JSON document printed by
+// `mc admin info --json`. It must be bumped whenever an existing field is
+// renamed, removed or changes its meaning; adding fields does not need it.
+const adminInfoSchemaVersion = "1"
+
+// adminInfoInventoryDeadline bounds the time spent collecting the hardware
+// inventory of the nodes.
+const adminInfoInventoryDeadline = 30 * time.Second
+
+// adminInfoInventory holds the hardware identity of every node, gathered
+// from the health information API.
+type adminInfoInventory struct {
+	Error string            `json:"error,omitempty"`
+	Nodes []adminInfoNodeHW `json:"nodes,omitempty"`
+}
+
+// adminInfoNodeHW describes the hardware of a single node.
+type adminInfoNodeHW struct {
+	Endpoint     string             `json:"endpoint"`
+	Error        string             `json:"error,omitempty"`
+	Vendor       string             `json:"vendor,omitempty"`
+	Product      string             `json:"product,omitempty"`
+	SerialNumber string             `json:"serialNumber,omitempty"`
+	UUID         string             `json:"uuid,omitempty"`
+	Drives       []adminInfoDriveHW `json:"drives,omitempty"`
+	NICs         []adminInfoNICHW   `json:"nics,omitempty"`
+}
+
+// adminInfoDriveHW describes the hardware behind a drive of a node.
+type adminInfoDriveHW struct {
+	Path     string `json:"path"`
+	UUID     string `json:"uuid,omitempty"`
+	Device   string `json:"device,omitempty"`
+	Model    string `json:"model,omitempty"`
+	Revision string `json:"revision,omitempty"`
+	Pool     int    `json:"pool"`
+	Set      int    `json:"set"`
+	Index    int    `json:"index"`
+}
+
+// adminInfoNICHW describes a network interface of a node.
+type adminInfoNICHW struct {
+	Interface       string `json:"interface"`
+	Driver          string `json:"driver,omitempty"`
+	FirmwareVersion string `json:"firmwareVersion,omitempty"`
+}
+
+// fetchAdminInfoInventory collects the hardware inventory of the servers
+// in info. Failures are reported in the returned inventory, they must not
+// prevent the rest of the information from being printed.
+func fetchAdminInfoInventory(ctx context.Context, client *madmin.AdminClient, info madmin.InfoMessage) *adminInfoInventory {
+	ctx, cancel := context.WithTimeout(ctx, adminInfoInventoryDeadline)
+	defer cancel()
+
+	types := []madmin.HealthDataType{
+		madmin.HealthDataTypeSysDriveHw,
+		madmin.HealthDataTypeSysNet,
+		madmin.HealthDataTypeSysOsInfo,
+	}
+	resp, version, e := client.ServerHealthInfo(ctx, types, adminInfoInventoryDeadline, "")
+	if e != nil {
+		return &adminInfoInventory{Error: e.Error()}
+	}
+	defer resp.Body.Close()
+	if version != madmin.HealthInfoVersion {
+		return &adminInfoInventory{Error: "hardware inventory is not supported by this server version"}
+	}
+
+	var health madmin.HealthInfo
+	decoder := json.NewDecoder(resp.Body)
+	for {
+		if e = decoder.Decode(&health); e != nil {
+			if errors.Is(e, io.EOF) {
+				break
+			}
+			return &adminInfoInventory{Error: e.Error()}
+		}
+	}
+	if health.Error != "" {
+		return &adminInfoInventory{Error: health.Error}
+	}
+	return buildAdminInfoInventory(info, health.Sys)
+}
+
+// buildAdminInfoInventory merges the drives known to the cluster with the
+// hardware reported by each node. Nodes and drives are sorted so that the
+// output is stable between runs.
+func buildAdminInfoInventory(info madmin.InfoMessage, sys madmin.SysInfo) *adminInfoInventory {
+	partitions := make(map[string][]madmin.Partition)
+	for _, p := range sys.Partitions {
+		partitions[p.Addr] = append(partitions[p.Addr], p.Partitions...)
+	}
+	nodeErrs := make(map[string]string)
+	for _, p := range sys.Partitions {
+		if p.Error != "" {
+			nodeErrs[p.Addr] = p.Error
+		}
+	}
+	nics := make(map[string][]adminInfoNICHW)
+	for _, n := range sys.NetInfo {
+		if n.Error != "" {
+			nodeErrs[n.Addr] = n.Error
+			continue
+		}
+		nics[n.Addr] = append(nics[n.Addr], adminInfoNICHW{
+			Interface:       n.Interface,
+			Driver:          n.Driver,
+			FirmwareVersion: n.FirmwareVersion,
+		})
+	}
+	products := make(map[string]madmin.ProductInfo)
+	for _, p := range sys.ProductInfo {
+		products[p.Addr] = p
+	}
+
+	inv := &adminInfoInventory{}
+	for _, srv := range info.Servers {
+		node := adminInfoNodeHW{
+			Endpoint: srv.Endpoint,
+			Error:    nodeErrs[srv.Endpoint],
+			NICs:     nics[srv.Endpoint],
+		}
+		if p, ok := products[srv.Endpoint]; ok {
+			node.Vendor = p.Vendor
+			node.Product = p.Name
+			node.SerialNumber = p.SerialNumber
+			node.UUID = p.UUID
+		}
+		for _, disk := range srv.Disks {
+			drive := adminInfoDriveHW{
+				Path:  disk.DrivePath,
+				UUID:  disk.UUID,
+				Model: disk.Model,
+				Pool:  disk.PoolIndex,
+				Set:   disk.SetIndex,
+				Index: disk.DiskIndex,
+			}
+			if p, ok := drivePartition(partitions[srv.Endpoint], disk.DrivePath); ok {
+				drive.Device = p.Device
+				drive.Revision = p.Revision
+				if p.Model != "" {
+					drive.Model = p.Model
+				}
+			}
+			node.Drives = append(node.Drives, drive)
+		}
+		sort.Slice(node.Drives, func(i, j int) bool {
+			return node.Drives[i].Path < node.Drives[j].Path
+		})
+		sort.Slice(node.NICs, func(i, j int) bool {
+			return node.NICs[i].Interface < node.NICs[j].Interface
+		})
+		inv.Nodes = append(inv.Nodes, node)
+	}
+	sort.Slice(inv.Nodes, func(i, j int) bool {
+		return inv.Nodes[i].Endpoint < inv.Nodes[j].Endpoint
+	})
+	return inv
+}
+
+// drivePartition returns the partition mounted on drivePath, or on its
+// closest parent directory.
+func drivePartition(parts []madmin.Partition, drivePath string) (found madmin.Partition, ok bool) {
+	drivePath = filepath.Clean(drivePath)
+	for _, p := range parts {
+		if p.Mountpoint == "" {
+			continue
+		}
+		mnt := filepath.Clean(p.Mountpoint)
+		if drivePath != mnt && !strings.HasPrefix(drivePath, strings.TrimSuffix(mnt, "/")+"/") {
+			continue
+		}
+		if !ok || len(mnt) > len(filepath.Clean(found.Mountpoint)) {
+			found, ok = p, true
+		}
+	}
+	return found, ok
+}
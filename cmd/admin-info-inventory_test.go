@@ -0,0 +1,86 @@
+// Copyright (c) 2015-2024 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"testing"
+
+	"github.com/minio/madmin-go/v3"
+)
+
+func TestBuildAdminInfoInventory(t *testing.T) {
+	info := madmin.InfoMessage{
+		Servers: []madmin.ServerProperties{
+			{
+				Endpoint: "node2:9000",
+				Disks: []madmin.Disk{
+					{DrivePath: "/mnt/disk2/minio", UUID: "u3", DiskIndex: 1},
+					{DrivePath: "/mnt/disk1", UUID: "u2"},
+				},
+			},
+			{
+				Endpoint: "node1:9000",
+				Disks:    []madmin.Disk{{DrivePath: "/data", UUID: "u1", Model: "fallback"}},
+			},
+		},
+	}
+	sys := madmin.SysInfo{
+		Partitions: []madmin.Partitions{
+			{
+				NodeCommon: madmin.NodeCommon{Addr: "node2:9000"},
+				Partitions: []madmin.Partition{
+					{Device: "/dev/sda1", Mountpoint: "/", Model: "root"},
+					{Device: "/dev/sdb", Mountpoint: "/mnt/disk1", Model: "m1", Revision: "r1"},
+					{Device: "/dev/sdc", Mountpoint: "/mnt/disk2", Model: "m2", Revision: "r2"},
+				},
+			},
+		},
+		NetInfo: []madmin.NetInfo{
+			{NodeCommon: madmin.NodeCommon{Addr: "node2:9000"}, Interface: "eth1", Driver: "ixgbe"},
+			{NodeCommon: madmin.NodeCommon{Addr: "node2:9000"}, Interface: "eth0", Driver: "virtio"},
+			{NodeCommon: madmin.NodeCommon{Addr: "node1:9000", Error: "no such device"}},
+		},
+		ProductInfo: []madmin.ProductInfo{
+			{NodeCommon: madmin.NodeCommon{Addr: "node2:9000"}, Vendor: "acme", Name: "box", SerialNumber: "SN2"},
+		},
+	}
+
+	inv := buildAdminInfoInventory(info, sys)
+	if len(inv.Nodes) != 2 {
+		t.Fatalf("expected 2 nodes, got %d", len(inv.Nodes))
+	}
+	n1, n2 := inv.Nodes[0], inv.Nodes[1]
+	if n1.Endpoint != "node1:9000" || n1.Error != "no such device" || n1.Drives[0].Model != "fallback" {
+		t.Errorf("unexpected node1: %+v", n1)
+	}
+	if n2.SerialNumber != "SN2" || n2.Vendor != "acme" || n2.Product != "box" {
+		t.Errorf("unexpected node2 product: %+v", n2)
+	}
+	if len(n2.NICs) != 2 || n2.NICs[0].Interface != "eth0" {
+		t.Errorf("unexpected node2 NICs: %+v", n2.NICs)
+	}
+	want := []adminInfoDriveHW{
+		{Path: "/mnt/disk1", UUID: "u2", Device: "/dev/sdb", Model: "m1", Revision: "r1"},
+		{Path: "/mnt/disk2/minio", UUID: "u3", Device: "/dev/sdc", Model: "m2", Revision: "r2", Index: 1},
+	}
+	for i, d := range n2.Drives {
+		if d != want[i] {
+			t.Errorf("drive %d: expected %+v, got %+v", i, want[i], d)
+		}
+	}
+}
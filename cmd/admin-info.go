@@ -62,6 +62,9 @@ FLAGS:
 EXAMPLES:
   1. Get server information of the 'play' MinIO server.
      {{.Prompt}} {{.HelpName}} play/
+
+  2. Get server information as JSON, including the hardware inventory of every node.
+     {{.Prompt}} {{.HelpName}} --json play/
 `,
 }
 
@@ -131,9 +134,11 @@ func endpointToPools(endpoint string, c clusterInfo) (pools []int) {
 
 // Wrap "Info" message together with fields "Status" and "Error"
 type clusterStruct struct {
-	Status string             `json:"status"`
-	Error  string             `json:"error,omitempty"`
-	Info   madmin.InfoMessage `json:"info,omitempty"`
+	SchemaVersion string              `json:"schemaVersion"`
+	Status        string              `json:"status"`
+	Error         string              `json:"error,omitempty"`
+	Info          madmin.InfoMessage  `json:"info,omitempty"`
+	Inventory     *adminInfoInventory `json:"inventory,omitempty"`
 
 	onlyOffline bool
 }
@@ -388,7 +393,8 @@ func mainAdminInfo(ctx *cli.Context) error {
 	fatalIf(err, "Unable to initialize admin connection.")
 
 	clusterInfo := clusterStruct{
-		onlyOffline: ctx.Bool("offline"),
+		SchemaVersion: adminInfoSchemaVersion,
+		onlyOffline:   ctx.Bool("offline"),
 	}
 
 	// Fetch info of all servers (cluster or single server)
@@ -402,6 +408,9 @@ func mainAdminInfo(ctx *cli.Context) error {
 	}
 
 	clusterInfo.Info = admInfo
+	if globalJSON && e == nil {
+		clusterInfo.Inventory = fetchAdminInfoInventory(globalContext, client, admInfo)
+	}
 	printMsg(clusterInfo)
 
 	return nil
@@ -0,0 +1,160 @@
+// Copyright (c) 2015-2024 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	json "github.com/minio/colorjson"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/pkg/v3/console"
+)
+
+var adminKMSKeyTestFlags = []cli.Flag{
+	cli.StringFlag{
+		Name:  "key-id",
+		Usage: "master key to test, the default master key of the server if not set",
+	},
+	cli.IntFlag{
+		Name:  "count, c",
+		Usage: "number of round-trips",
+		Value: 1,
+	},
+}
+
+var adminKMSKeyTestCmd = cli.Command{
+	Name:         "test",
+	Usage:        "test a KMS master key with generate data key, encrypt and decrypt round-trips",
+	Action:       mainAdminKMSKeyTest,
+	OnUsageError: onUsageError,
+	Before:       setGlobalsFromContext,
+	Flags:        append(adminKMSKeyTestFlags, globalFlags...),
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} [FLAGS] TARGET
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+DESCRIPTION:
+  Each round-trip makes the server generate a data key with the master key and decrypt it
+  again, as it does for the objects of a bucket encrypted with SSE-KMS. The latency is the
+  one seen by mc, it includes the round-trip between mc and the server.
+
+EXAMPLES:
+  1. Test the default master key of a MinIO server/cluster.
+     $ {{.HelpName}} play
+
+  2. Test the master key 'k1' with 10 round-trips and report the latency.
+     $ {{.HelpName}} play --key-id k1 --count 10
+`,
+}
+
+// kmsKeyTestMsg is the result of the round-trips of a key test.
+type kmsKeyTestMsg struct {
+	Status        string        `json:"status"`
+	KeyID         string        `json:"keyId"`
+	Count         int           `json:"count"`
+	Failed        int           `json:"failed"`
+	EncryptionErr string        `json:"encryptionError,omitempty"`
+	DecryptionErr string        `json:"decryptionError,omitempty"`
+	Min           time.Duration `json:"min"`
+	Avg           time.Duration `json:"avg"`
+	Max           time.Duration `json:"max"`
+}
+
+func (s kmsKeyTestMsg) JSON() string {
+	s.Status = "success"
+	if s.Failed > 0 {
+		s.Status = "error"
+	}
+	kmsBytes, e := json.MarshalIndent(s, "", "    ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+
+	return string(kmsBytes)
+}
+
+func (s kmsKeyTestMsg) String() string {
+	msg := fmt.Sprintf("Key: %s\n", s.KeyID)
+	if s.Failed == 0 {
+		msg += fmt.Sprintf("   - Round-trips: %s %d of %d\n", console.Colorize("StatusSuccess", "✔"), s.Count, s.Count)
+	} else {
+		msg += fmt.Sprintf("   - Round-trips: %s %d of %d failed\n", console.Colorize("StatusError", "✗"), s.Failed, s.Count)
+	}
+	if s.EncryptionErr != "" {
+		msg += fmt.Sprintf("   - Encryption: %s\n", s.EncryptionErr)
+	}
+	if s.DecryptionErr != "" {
+		msg += fmt.Sprintf("   - Decryption: %s\n", s.DecryptionErr)
+	}
+	msg += fmt.Sprintf("   - Latency: min %s, avg %s, max %s\n",
+		s.Min.Round(time.Microsecond), s.Avg.Round(time.Microsecond), s.Max.Round(time.Microsecond))
+	return msg
+}
+
+// mainAdminKMSKeyTest is the handler for the "mc admin kms key test" command.
+func mainAdminKMSKeyTest(ctx *cli.Context) error {
+	if len(ctx.Args()) != 1 {
+		showCommandHelpAndExit(ctx, 1) // last argument is exit code
+	}
+	count := ctx.Int("count")
+	if count < 1 {
+		fatalIf(errInvalidArgument().Trace(ctx.String("count")), "--count must be at least 1.")
+	}
+
+	console.SetColor("StatusSuccess", color.New(color.FgGreen, color.Bold))
+	console.SetColor("StatusError", color.New(color.FgRed, color.Bold))
+
+	client, err := newAdminClient(ctx.Args().Get(0))
+	fatalIf(err, "Unable to get a configured admin connection.")
+
+	msg := kmsKeyTestMsg{KeyID: ctx.String("key-id"), Count: count}
+	var total time.Duration
+	for i := 0; i < count; i++ {
+		start := time.Now()
+		status, e := client.GetKeyStatus(globalContext, msg.KeyID)
+		latency := time.Since(start)
+		fatalIf(probe.NewError(e), "Unable to test the master key.")
+
+		msg.KeyID = status.KeyID
+		if status.EncryptionErr != "" || status.DecryptionErr != "" {
+			msg.Failed++
+			msg.EncryptionErr = status.EncryptionErr
+			msg.DecryptionErr = status.DecryptionErr
+		}
+		total += latency
+		if msg.Min == 0 || latency < msg.Min {
+			msg.Min = latency
+		}
+		if latency > msg.Max {
+			msg.Max = latency
+		}
+	}
+	msg.Avg = total / time.Duration(count)
+
+	printMsg(msg)
+	if msg.Failed > 0 {
+		return exitStatus(globalErrorExitStatus)
+	}
+	return nil
+}
@@ -23,11 +23,12 @@ var adminKMSKeySubcommands = []cli.Command{
 	adminKMSCreateKeyCmd,
 	adminKMSKeyStatusCmd,
 	adminKMSKeyListCmd,
+	adminKMSKeyTestCmd,
 }
 
 var adminKMSKeyCmd = cli.Command{
 	Name:            "key",
-	Usage:           "manage KMS master keys: Request key status information",
+	Usage:           "manage KMS master keys",
 	Action:          mainAdminKMSKey,
 	Before:          setGlobalsFromContext,
 	Flags:           globalFlags,
@@ -0,0 +1,173 @@
+// Copyright (c) 2015-2024 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	json "github.com/minio/colorjson"
+	"github.com/minio/madmin-go/v3"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/pkg/v3/console"
+)
+
+var adminLockClearFlags = []cli.Flag{
+	cli.BoolFlag{
+		Name:  "yes, y",
+		Usage: "confirms releasing the locks without prompting",
+	},
+	cli.BoolFlag{
+		Name:  "force",
+		Usage: "release the locks even if they are not listed among the active locks",
+	},
+}
+
+var adminLockClearCmd = cli.Command{
+	Name:         "clear",
+	Usage:        "forcibly release locks left behind on a resource",
+	Action:       mainAdminLockClear,
+	OnUsageError: onUsageError,
+	Before:       setGlobalsFromContext,
+	Flags:        append(adminLockClearFlags, globalFlags...),
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} [FLAGS] TARGET RESOURCE [RESOURCE...]
+
+RESOURCE:
+  Resources are named as listed by 'mc support top locks', i.e. BUCKET/OBJECT.
+
+  Releasing a lock still held by a running request can corrupt the object
+  it protects, only clear locks orphaned after a node crash.
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+EXAMPLES:
+  1. Release the lock left behind on 'mybucket/myobject'.
+     {{.Prompt}} {{.HelpName}} myminio/ mybucket/myobject
+
+  2. Release the locks on two objects without prompting for confirmation.
+     {{.Prompt}} {{.HelpName}} --yes myminio/ mybucket/a.txt mybucket/b.txt
+`,
+}
+
+// lockClearMessage is printed once the locks are released.
+type lockClearMessage struct {
+	Status    string   `json:"status"`
+	Resources []string `json:"resources"`
+}
+
+func (m lockClearMessage) String() string {
+	return console.Colorize("LockClear", fmt.Sprintf("Released the locks on %s.", strings.Join(m.Resources, ", ")))
+}
+
+func (m lockClearMessage) JSON() string {
+	jsonMessageBytes, e := json.MarshalIndent(m, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+	return string(jsonMessageBytes)
+}
+
+// locksOnResources returns the locks held on any of the resources.
+func locksOnResources(locks madmin.LockEntries, resources []string) (held madmin.LockEntries, missing []string) {
+	found := make(map[string]bool, len(resources))
+	for _, lock := range locks {
+		for _, resource := range resources {
+			if lock.Resource == resource {
+				held = append(held, lock)
+				found[resource] = true
+			}
+		}
+	}
+	for _, resource := range resources {
+		if !found[resource] {
+			missing = append(missing, resource)
+		}
+	}
+	return held, missing
+}
+
+func checkAdminLockClearSyntax(ctx *cli.Context) {
+	if len(ctx.Args()) < 2 {
+		showCommandHelpAndExit(ctx, 1) // last argument is exit code
+	}
+}
+
+// mainAdminLockClear is the handle for "mc admin lock clear" command.
+func mainAdminLockClear(ctx *cli.Context) error {
+	checkAdminLockClearSyntax(ctx)
+
+	console.SetColor("LockClear", color.New(color.FgGreen, color.Bold))
+	console.SetColor("StaleLock", color.New(color.FgRed, color.Bold))
+	console.SetColor("StuckLock", color.New(color.FgYellow, color.Bold))
+	console.SetColor("Lock", color.New(color.FgBlue, color.Bold))
+	console.SetColor("Headers", color.New(color.FgGreen, color.Bold))
+
+	args := ctx.Args()
+	aliasedURL := args.Get(0)
+	resources := make([]string, 0, len(args)-1)
+	for _, resource := range args.Tail() {
+		resources = append(resources, strings.Trim(resource, "/"))
+	}
+
+	// Create a new MinIO Admin Client
+	client, err := newAdminClient(aliasedURL)
+	fatalIf(err, "Unable to initialize admin connection.")
+
+	// Show the locks about to be released, refuse to go further if
+	// some of them are not held unless --force is specified.
+	entries, e := client.TopLocksWithOpts(globalContext, madmin.TopLockOpts{
+		Count: 10000,
+		Stale: true,
+	})
+	fatalIf(probe.NewError(e), "Unable to get server locks list.")
+	held, missing := locksOnResources(entries, resources)
+	if len(missing) > 0 && !ctx.Bool("force") {
+		fatalIf(errInvalidArgument().Trace(missing...),
+			"No active lock found on `"+strings.Join(missing, "`, `")+"`, use --force to release it anyway.")
+	}
+
+	if !ctx.Bool("yes") {
+		if !isTerminal() {
+			fatalIf(errInvalidArgument().Trace(resources...), "--yes is required when not running in a terminal.")
+		}
+		if len(held) > 0 {
+			printLocks(held, 0)
+			fmt.Println()
+		}
+		fmt.Printf("You are about to release %d lock(s) on %s, please confirm [y/N]: ", len(held), strings.Join(resources, ", "))
+		answer, e := bufio.NewReader(os.Stdin).ReadString('\n')
+		fatalIf(probe.NewError(e), "Unable to parse user input.")
+		if answer = strings.ToLower(strings.TrimSpace(answer)); answer != "y" && answer != "yes" {
+			fmt.Println("Lock clear aborted!")
+			return nil
+		}
+	}
+
+	e = client.ForceUnlock(globalContext, resources...)
+	fatalIf(probe.NewError(e).Trace(resources...), "Unable to release the locks.")
+
+	printMsg(lockClearMessage{Status: "success", Resources: resources})
+	return nil
+}
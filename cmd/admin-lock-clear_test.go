@@ -0,0 +1,40 @@
+// Copyright (c) 2015-2024 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/minio/madmin-go/v3"
+)
+
+func TestLocksOnResources(t *testing.T) {
+	locks := madmin.LockEntries{
+		{Resource: "bucket/a", ID: "1"},
+		{Resource: "bucket/b", ID: "2"},
+		{Resource: "bucket/a", ID: "3"},
+	}
+	held, missing := locksOnResources(locks, []string{"bucket/a", "bucket/c"})
+	if len(held) != 2 || held[0].ID != "1" || held[1].ID != "3" {
+		t.Fatalf("unexpected held locks %+v", held)
+	}
+	if !reflect.DeepEqual(missing, []string{"bucket/c"}) {
+		t.Fatalf("unexpected missing resources %v", missing)
+	}
+}
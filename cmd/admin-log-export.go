@@ -0,0 +1,383 @@
+// Copyright (c) 2015-2024 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/minio/cli"
+	"github.com/minio/mc/pkg/probe"
+)
+
+// Flags shared by commands that can forward records to external collectors.
+var logExportFlags = []cli.Flag{
+	cli.StringFlag{
+		Name:  "syslog",
+		Usage: "forward records to a syslog server, e.g. udp://localhost:514, tcp://localhost:601 or unix:///dev/log",
+	},
+	cli.StringFlag{
+		Name:  "otlp",
+		Usage: "forward records to the OTLP/HTTP endpoint of an OpenTelemetry collector, port 4318 by default, e.g. localhost:4318. OTLP/gRPC is not supported",
+	},
+}
+
+const (
+	// Maximum number of records sent in a single OTLP request.
+	otlpMaxBatch = 256
+	// Interval after which pending OTLP records are flushed.
+	otlpFlushInterval = time.Second
+	// Default port of the OTLP/HTTP receivers.
+	otlpHTTPPort = "4318"
+	// Default port of the OTLP/gRPC receivers. OTLP/gRPC is out of scope,
+	// collectors receive OTLP/HTTP as well.
+	otlpGRPCPort = "4317"
+)
+
+// exportRecord is a single log or trace record forwarded to a collector.
+type exportRecord struct {
+	Time     time.Time
+	Severity string
+	Body     string
+	Attrs    map[string]string
+}
+
+// recordExporter forwards records to an external collector.
+type recordExporter interface {
+	Export(rec exportRecord)
+	Close() error
+}
+
+// recordExporters fans out a record to all configured exporters.
+type recordExporters []recordExporter
+
+func (e recordExporters) Export(rec exportRecord) {
+	for _, exp := range e {
+		exp.Export(rec)
+	}
+}
+
+func (e recordExporters) Close() (err error) {
+	for _, exp := range e {
+		if e := exp.Close(); e != nil && err == nil {
+			err = e
+		}
+	}
+	return err
+}
+
+// newRecordExporters returns exporters configured through logExportFlags,
+// nil if none were requested.
+func newRecordExporters(ctx *cli.Context, serviceName string) (recordExporters, *probe.Error) {
+	var exporters recordExporters
+	if addr := ctx.String("syslog"); addr != "" {
+		exp, err := newSyslogExporter(addr, serviceName)
+		if err != nil {
+			return nil, err.Trace(addr)
+		}
+		exporters = append(exporters, exp)
+	}
+	if endpoint := ctx.String("otlp"); endpoint != "" {
+		exp, err := newOTLPExporter(endpoint, serviceName)
+		if err != nil {
+			exporters.Close()
+			return nil, err.Trace(endpoint)
+		}
+		exporters = append(exporters, exp)
+	}
+	return exporters, nil
+}
+
+// syslogExporter writes RFC 5424 formatted records to a syslog server.
+type syslogExporter struct {
+	mu       sync.Mutex
+	conn     net.Conn
+	stream   bool
+	hostname string
+	appName  string
+}
+
+func newSyslogExporter(addr, appName string) (*syslogExporter, *probe.Error) {
+	network, address := "udp", addr
+	if u, e := url.Parse(addr); e == nil && u.Scheme != "" {
+		network = u.Scheme
+		address = u.Host
+		if network == "unix" || network == "unixgram" {
+			address = u.Path
+		}
+	}
+
+	var conn net.Conn
+	var e error
+	switch network {
+	case "udp", "tcp":
+		conn, e = net.Dial(network, address)
+	case "unix", "unixgram":
+		// Local syslog daemons listen on datagram sockets, fallback to streams.
+		if conn, e = net.Dial("unixgram", address); e != nil {
+			network = "unix"
+			conn, e = net.Dial(network, address)
+		}
+	default:
+		return nil, probe.NewError(fmt.Errorf("unsupported syslog network `%s`", network))
+	}
+	if e != nil {
+		return nil, probe.NewError(e)
+	}
+
+	hostname, _ := os.Hostname()
+	if hostname == "" {
+		hostname = "-"
+	}
+	return &syslogExporter{
+		conn:     conn,
+		stream:   network == "tcp" || network == "unix",
+		hostname: hostname,
+		appName:  appName,
+	}, nil
+}
+
+// syslogSeverity maps a record severity to a syslog severity level.
+func syslogSeverity(severity string) int {
+	switch strings.ToUpper(severity) {
+	case "FATAL":
+		return 2
+	case "ERROR":
+		return 3
+	case "WARNING":
+		return 4
+	case "INFO":
+		return 6
+	}
+	return 5
+}
+
+// formatSyslog formats a record as an RFC 5424 message using the 'local0' facility.
+func formatSyslog(rec exportRecord, hostname, appName string) string {
+	const facilityLocal0 = 16
+	pri := facilityLocal0*8 + syslogSeverity(rec.Severity)
+	return fmt.Sprintf("<%d>1 %s %s %s - - - %s", pri, rec.Time.UTC().Format(time.RFC3339Nano), hostname, appName, rec.Body)
+}
+
+func (s *syslogExporter) Export(rec exportRecord) {
+	msg := formatSyslog(rec, s.hostname, s.appName)
+	if s.stream {
+		// Octet counting framing, see RFC 6587.
+		msg = strconv.Itoa(len(msg)) + " " + msg
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, e := s.conn.Write([]byte(msg))
+	errorIf(probe.NewError(e), "Unable to forward record to syslog.")
+}
+
+func (s *syslogExporter) Close() error {
+	return s.conn.Close()
+}
+
+// otlpExporter sends records to an OpenTelemetry collector using the OTLP/HTTP JSON encoding.
+type otlpExporter struct {
+	endpoint    string
+	serviceName string
+	client      *http.Client
+	recordCh    chan exportRecord
+	doneCh      chan struct{}
+	// Number of records dropped because the collector is too slow.
+	dropped atomic.Uint64
+}
+
+func newOTLPExporter(endpoint, serviceName string) (*otlpExporter, *probe.Error) {
+	if !strings.Contains(endpoint, "://") {
+		endpoint = "http://" + endpoint
+	}
+	u, e := url.Parse(endpoint)
+	if e != nil {
+		return nil, probe.NewError(e)
+	}
+	switch u.Port() {
+	case "":
+		u.Host = net.JoinHostPort(u.Hostname(), otlpHTTPPort)
+	case otlpGRPCPort:
+		return nil, probe.NewError(fmt.Errorf("port %s is the OTLP/gRPC port, OTLP/gRPC is not supported, use the OTLP/HTTP receiver of the collector on port %s", otlpGRPCPort, otlpHTTPPort))
+	}
+	if u.Path == "" || u.Path == "/" {
+		u.Path = "/v1/logs"
+	}
+
+	exp := &otlpExporter{
+		endpoint:    u.String(),
+		serviceName: serviceName,
+		client:      httpClient(10 * time.Second),
+		recordCh:    make(chan exportRecord, 4*otlpMaxBatch),
+		doneCh:      make(chan struct{}),
+	}
+	go exp.run()
+	return exp, nil
+}
+
+func (o *otlpExporter) run() {
+	defer close(o.doneCh)
+
+	ticker := time.NewTicker(otlpFlushInterval)
+	defer ticker.Stop()
+
+	batch := make([]exportRecord, 0, otlpMaxBatch)
+	flush := func() {
+		if len(batch) == 0 {
+			return
+		}
+		errorIf(o.send(batch).Trace(o.endpoint), "Unable to forward records to OTLP collector.")
+		batch = batch[:0]
+	}
+	for {
+		select {
+		case rec, ok := <-o.recordCh:
+			if !ok {
+				flush()
+				return
+			}
+			batch = append(batch, rec)
+			if len(batch) == otlpMaxBatch {
+				flush()
+			}
+		case <-ticker.C:
+			flush()
+		}
+	}
+}
+
+type otlpValue struct {
+	StringValue string `json:"stringValue"`
+}
+
+type otlpKeyValue struct {
+	Key   string    `json:"key"`
+	Value otlpValue `json:"value"`
+}
+
+type otlpLogRecord struct {
+	TimeUnixNano string         `json:"timeUnixNano"`
+	SeverityText string         `json:"severityText,omitempty"`
+	Body         otlpValue      `json:"body"`
+	Attributes   []otlpKeyValue `json:"attributes,omitempty"`
+}
+
+type otlpLogsRequest struct {
+	ResourceLogs []otlpResourceLogs `json:"resourceLogs"`
+}
+
+type otlpResource struct {
+	Attributes []otlpKeyValue `json:"attributes"`
+}
+
+type otlpScope struct {
+	Name    string `json:"name"`
+	Version string `json:"version,omitempty"`
+}
+
+type otlpResourceLogs struct {
+	Resource  otlpResource    `json:"resource"`
+	ScopeLogs []otlpScopeLogs `json:"scopeLogs"`
+}
+
+type otlpScopeLogs struct {
+	Scope      otlpScope       `json:"scope"`
+	LogRecords []otlpLogRecord `json:"logRecords"`
+}
+
+// otlpAttributes converts a map into sorted OTLP attributes.
+func otlpAttributes(attrs map[string]string) []otlpKeyValue {
+	kvs := make([]otlpKeyValue, 0, len(attrs))
+	for k, v := range attrs {
+		if v == "" {
+			continue
+		}
+		kvs = append(kvs, otlpKeyValue{Key: k, Value: otlpValue{StringValue: v}})
+	}
+	sort.Slice(kvs, func(i, j int) bool { return kvs[i].Key < kvs[j].Key })
+	return kvs
+}
+
+// newOTLPLogsRequest builds an OTLP logs export request for a batch of records.
+func newOTLPLogsRequest(serviceName string, batch []exportRecord) otlpLogsRequest {
+	scope := otlpScopeLogs{
+		Scope:      otlpScope{Name: "mc", Version: ReleaseTag},
+		LogRecords: make([]otlpLogRecord, 0, len(batch)),
+	}
+	for _, rec := range batch {
+		scope.LogRecords = append(scope.LogRecords, otlpLogRecord{
+			TimeUnixNano: strconv.FormatInt(rec.Time.UnixNano(), 10),
+			SeverityText: rec.Severity,
+			Body:         otlpValue{StringValue: rec.Body},
+			Attributes:   otlpAttributes(rec.Attrs),
+		})
+	}
+
+	return otlpLogsRequest{ResourceLogs: []otlpResourceLogs{{
+		Resource:  otlpResource{Attributes: otlpAttributes(map[string]string{"service.name": serviceName})},
+		ScopeLogs: []otlpScopeLogs{scope},
+	}}}
+}
+
+func (o *otlpExporter) send(batch []exportRecord) *probe.Error {
+	body, e := json.Marshal(newOTLPLogsRequest(o.serviceName, batch))
+	if e != nil {
+		return probe.NewError(e)
+	}
+	resp, e := o.client.Post(o.endpoint, "application/json", bytes.NewReader(body))
+	if e != nil {
+		return probe.NewError(e)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		return probe.NewError(fmt.Errorf("collector responded with %s", resp.Status))
+	}
+	return nil
+}
+
+// Export queues a record, it is dropped when the queue is full so that a
+// slow collector does not stall the records printed by mc.
+func (o *otlpExporter) Export(rec exportRecord) {
+	select {
+	case o.recordCh <- rec:
+	default:
+		o.dropped.Add(1)
+	}
+}
+
+func (o *otlpExporter) Close() error {
+	close(o.recordCh)
+	<-o.doneCh
+	if dropped := o.dropped.Load(); dropped > 0 {
+		errorIf(probe.NewError(fmt.Errorf("%d record(s) dropped", dropped)).Trace(o.endpoint),
+			"OTLP collector too slow to receive all records.")
+	}
+	return nil
+}
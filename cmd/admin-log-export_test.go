@@ -0,0 +1,70 @@
+// Copyright (c) 2015-2024 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"testing"
+	"time"
+)
+
+func TestOTLPExporterEndpoint(t *testing.T) {
+	testCases := []struct {
+		endpoint string
+		want     string
+		wantErr  bool
+	}{
+		{"localhost", "http://localhost:4318/v1/logs", false},
+		{"localhost:4318", "http://localhost:4318/v1/logs", false},
+		{"https://collector.example.com/custom/logs", "https://collector.example.com:4318/custom/logs", false},
+		{"[::1]:9000", "http://[::1]:9000/v1/logs", false},
+		{"localhost:4317", "", true},
+	}
+	for i, testCase := range testCases {
+		exp, err := newOTLPExporter(testCase.endpoint, "mc")
+		if testCase.wantErr != (err != nil) {
+			t.Fatalf("Test %d: expected error %v, got %v", i+1, testCase.wantErr, err)
+		}
+		if err != nil {
+			continue
+		}
+		exp.Close()
+		if exp.endpoint != testCase.want {
+			t.Fatalf("Test %d: expected %s, got %s", i+1, testCase.want, exp.endpoint)
+		}
+	}
+}
+
+func TestOTLPExporterDropsWhenFull(t *testing.T) {
+	// No goroutine drains the queue, as with a stalled collector.
+	exp := &otlpExporter{recordCh: make(chan exportRecord, 2)}
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 5; i++ {
+			exp.Export(exportRecord{Time: time.Now(), Body: "record"})
+		}
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Export blocked on a full queue")
+	}
+	if dropped := exp.dropped.Load(); dropped != 3 {
+		t.Fatalf("expected 3 dropped records, got %d", dropped)
+	}
+}
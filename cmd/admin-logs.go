@@ -52,7 +52,7 @@ var adminLogsCmd = cli.Command{
 	OnUsageError:    onUsageError,
 	Action:          mainAdminLogs,
 	Before:          setGlobalsFromContext,
-	Flags:           append(logsShowFlags, globalFlags...),
+	Flags:           append(append(logsShowFlags, logExportFlags...), globalFlags...),
 	HideHelpCommand: true,
 	CustomHelpTemplate: `NAME:
   {{.HelpName}} - {{.Usage}}
@@ -68,6 +68,12 @@ EXAMPLES:
      {{.Prompt}} {{.HelpName}} --last 5 myminio node1
   3. Show application errors in logs for a MinIO server with alias 'myminio'
      {{.Prompt}} {{.HelpName}} --type application myminio
+
+  4. Forward logs for a MinIO server with alias 'myminio' to a local syslog daemon
+     {{.Prompt}} {{.HelpName}} --syslog unix:///dev/log myminio
+
+  5. Forward logs for a MinIO server with alias 'myminio' to an OpenTelemetry collector
+     {{.Prompt}} {{.HelpName}} --otlp localhost:4318 myminio
 `,
 }
 
@@ -164,6 +170,39 @@ func (l logMessage) String() string {
 	return fmt.Sprintf("%s\n", logMsg)
 }
 
+// exportRecord converts the log entry into a record for external collectors.
+func (l logMessage) exportRecord() exportRecord {
+	rec := exportRecord{
+		Time:     time.Now(),
+		Severity: string(l.LogKind),
+		Body:     l.ConsoleMsg,
+		Attrs: map[string]string{
+			"node":         l.NodeName,
+			"deploymentID": l.DeploymentID,
+			"requestID":    l.RequestID,
+			"remoteHost":   l.RemoteHost,
+			"userAgent":    l.UserAgent,
+		},
+	}
+	if tm, e := time.Parse(time.RFC3339Nano, l.Time); e == nil {
+		rec.Time = tm
+	}
+	if rec.Body == "" {
+		rec.Body = l.Message
+	}
+	if l.Trace != nil && rec.Body == "" {
+		rec.Body = l.Trace.Message
+	}
+	if l.API != nil {
+		rec.Attrs["api"] = l.API.Name
+		if l.API.Args != nil {
+			rec.Attrs["bucket"] = l.API.Args.Bucket
+			rec.Attrs["object"] = l.API.Args.Object
+		}
+	}
+	return rec
+}
+
 // mainAdminLogs - the entry function of admin logs
 func mainAdminLogs(ctx *cli.Context) error {
 	// Check for command syntax
@@ -196,6 +235,10 @@ func mainAdminLogs(ctx *cli.Context) error {
 		return nil
 	}
 
+	exporters, err := newRecordExporters(ctx, "minio")
+	fatalIf(err, "Unable to initialize log exporters.")
+	defer exporters.Close()
+
 	ctxt, cancel := context.WithCancel(globalContext)
 	defer cancel()
 
@@ -210,7 +253,14 @@ func mainAdminLogs(ctx *cli.Context) error {
 			logInfo.NodeName = ""
 		}
 		if logInfo.DeploymentID != "" {
-			printMsg(logMessage{LogInfo: logInfo})
+			msg := logMessage{LogInfo: logInfo}
+			if len(exporters) > 0 {
+				exporters.Export(msg.exportRecord())
+				if globalQuiet {
+					continue
+				}
+			}
+			printMsg(msg)
 		}
 	}
 	return nil
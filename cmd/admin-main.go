@@ -57,6 +57,7 @@ var adminCmdSubcommands = []cli.Command{
 	adminRebalanceCmd,
 	adminLogsCmd,
 	adminAccesskeyCmd,
+	adminLockCmd,
 }
 
 var adminCmd = cli.Command{
@@ -0,0 +1,141 @@
+// Copyright (c) 2015-2024 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/olekukonko/tablewriter"
+	"github.com/prometheus/prom2json"
+)
+
+// prometheusSampleSuffixes are appended to the name of histograms and
+// summaries in the names of their samples.
+var prometheusSampleSuffixes = []string{"_bucket", "_sum", "_count"}
+
+// matchPrometheusName returns true if filter matches the name of a metric,
+// or the name of the histogram or summary of a sample.
+func matchPrometheusName(filter *regexp.Regexp, name string) bool {
+	if filter.MatchString(name) {
+		return true
+	}
+	for _, suffix := range prometheusSampleSuffixes {
+		if family, ok := strings.CutSuffix(name, suffix); ok && filter.MatchString(family) {
+			return true
+		}
+	}
+	return false
+}
+
+// filterPrometheusText copies the metrics of r in the Prometheus text
+// format matching filter to w.
+func filterPrometheusText(r io.Reader, w io.Writer, filter *regexp.Regexp) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	bw := bufio.NewWriter(w)
+	for scanner.Scan() {
+		line := scanner.Text()
+		var name string
+		if comment, ok := strings.CutPrefix(line, "#"); ok {
+			// Only keep the HELP and TYPE comments of the metrics.
+			fields := strings.Fields(comment)
+			if len(fields) < 2 || fields[0] != "HELP" && fields[0] != "TYPE" {
+				continue
+			}
+			name = fields[1]
+		} else {
+			name = line
+			if i := strings.IndexAny(line, "{ "); i >= 0 {
+				name = line[:i]
+			}
+		}
+		if name == "" || !matchPrometheusName(filter, name) {
+			continue
+		}
+		bw.WriteString(line)
+		bw.WriteByte('\n')
+	}
+	if e := scanner.Err(); e != nil {
+		return e
+	}
+	return bw.Flush()
+}
+
+// filterPrometheusFamilies returns the metrics matching filter, sorted by name.
+func filterPrometheusFamilies(families []*prom2json.Family, filter *regexp.Regexp) []*prom2json.Family {
+	filtered := make([]*prom2json.Family, 0, len(families))
+	for _, family := range families {
+		if filter == nil || filter.MatchString(family.Name) {
+			filtered = append(filtered, family)
+		}
+	}
+	sort.Slice(filtered, func(i, j int) bool { return filtered[i].Name < filtered[j].Name })
+	return filtered
+}
+
+// prometheusLabels formats labels as name="value" pairs sorted by name.
+func prometheusLabels(labels map[string]string) string {
+	pairs := make([]string, 0, len(labels))
+	for name, value := range labels {
+		pairs = append(pairs, fmt.Sprintf("%s=%q", name, value))
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, ",")
+}
+
+// prometheusFamiliesTable renders metrics as a table of their samples,
+// histograms and summaries by their count and sum.
+func prometheusFamiliesTable(families []*prom2json.Family) string {
+	var s strings.Builder
+	table := tablewriter.NewWriter(&s)
+	table.SetAutoWrapText(false)
+	table.SetAutoFormatHeaders(true)
+	table.SetHeaderAlignment(tablewriter.ALIGN_LEFT)
+	table.SetAlignment(tablewriter.ALIGN_LEFT)
+	table.SetCenterSeparator("")
+	table.SetColumnSeparator("")
+	table.SetRowSeparator("")
+	table.SetHeaderLine(false)
+	table.SetBorder(false)
+	table.SetTablePadding("\t") // pad with tabs
+	table.SetNoWhiteSpace(true)
+
+	table.SetHeader([]string{"NAME", "LABELS", "VALUE"})
+	for _, family := range families {
+		rows := make([][]string, 0, len(family.Metrics))
+		for _, metric := range family.Metrics {
+			switch m := metric.(type) {
+			case prom2json.Metric:
+				rows = append(rows, []string{family.Name, prometheusLabels(m.Labels), m.Value})
+			case prom2json.Summary:
+				rows = append(rows, []string{family.Name, prometheusLabels(m.Labels), "count=" + m.Count + " sum=" + m.Sum})
+			case prom2json.Histogram:
+				rows = append(rows, []string{family.Name, prometheusLabels(m.Labels), "count=" + m.Count + " sum=" + m.Sum})
+			}
+		}
+		sort.Slice(rows, func(i, j int) bool { return rows[i][1] < rows[j][1] })
+		table.AppendBulk(rows)
+	}
+	table.Render()
+	return s.String()
+}
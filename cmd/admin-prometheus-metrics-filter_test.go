@@ -0,0 +1,94 @@
+// Copyright (c) 2015-2024 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bytes"
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/minio/madmin-go/v3"
+)
+
+const testPrometheusMetrics = `# HELP minio_bucket_usage_object_total Total number of objects
+# TYPE minio_bucket_usage_object_total gauge
+minio_bucket_usage_object_total{bucket="photos",server="127.0.0.1:9000"} 42
+minio_bucket_usage_object_total{bucket="logs",server="127.0.0.1:9000"} 7
+# HELP minio_bucket_requests_ttfb_seconds Time to first byte
+# TYPE minio_bucket_requests_ttfb_seconds histogram
+minio_bucket_requests_ttfb_seconds_bucket{api="GetObject",le="0.05"} 3
+minio_bucket_requests_ttfb_seconds_bucket{api="GetObject",le="+Inf"} 4
+minio_bucket_requests_ttfb_seconds_sum{api="GetObject"} 0.5
+minio_bucket_requests_ttfb_seconds_count{api="GetObject"} 4
+# HELP minio_node_uptime Uptime
+# TYPE minio_node_uptime gauge
+minio_node_uptime 120
+`
+
+func TestFilterPrometheusText(t *testing.T) {
+	var out bytes.Buffer
+	filter := regexp.MustCompile("^(?:minio_bucket_.*)$")
+	if e := filterPrometheusText(strings.NewReader(testPrometheusMetrics), &out, filter); e != nil {
+		t.Fatal(e)
+	}
+	if strings.Contains(out.String(), "minio_node_uptime") {
+		t.Errorf("unexpected metric in %q", out.String())
+	}
+	if got := strings.Count(out.String(), "\n"); got != 10 {
+		t.Errorf("expected 10 lines, got %d: %q", got, out.String())
+	}
+
+	// Samples of histograms are kept when their name matches.
+	out.Reset()
+	filter = regexp.MustCompile("^(?:minio_bucket_requests_ttfb_seconds)$")
+	if e := filterPrometheusText(strings.NewReader(testPrometheusMetrics), &out, filter); e != nil {
+		t.Fatal(e)
+	}
+	if got := strings.Count(out.String(), "\n"); got != 6 {
+		t.Errorf("expected 6 lines, got %d: %q", got, out.String())
+	}
+}
+
+func TestPrometheusFamiliesTable(t *testing.T) {
+	families, e := madmin.ParsePrometheusResults(strings.NewReader(testPrometheusMetrics))
+	if e != nil {
+		t.Fatal(e)
+	}
+	families = filterPrometheusFamilies(families, regexp.MustCompile("^(?:minio_bucket_.*)$"))
+	if len(families) != 2 || families[0].Name != "minio_bucket_requests_ttfb_seconds" {
+		t.Fatalf("unexpected families %v", families)
+	}
+
+	lines := strings.Split(strings.TrimSpace(prometheusFamiliesTable(families)), "\n")
+	expected := [][]string{
+		{"NAME", "LABELS", "VALUE"},
+		{"minio_bucket_requests_ttfb_seconds", `api="GetObject"`, "count=4", "sum=0.5"},
+		{"minio_bucket_usage_object_total", `bucket="logs",server="127.0.0.1:9000"`, "7"},
+		{"minio_bucket_usage_object_total", `bucket="photos",server="127.0.0.1:9000"`, "42"},
+	}
+	if len(lines) != len(expected) {
+		t.Fatalf("expected %d lines, got %q", len(expected), lines)
+	}
+	for i, line := range lines {
+		if got := strings.Fields(line); !reflect.DeepEqual(got, expected[i]) {
+			t.Errorf("line %d: expected %q, got %q", i+1, expected[i], got)
+		}
+	}
+}
@@ -22,6 +22,7 @@ import (
 	"io"
 	"net/http"
 	"os"
+	"regexp"
 	"strings"
 	"time"
 
@@ -37,6 +38,15 @@ var metricsFlags = append(metricsV3Flags,
 		Name:  "api-version",
 		Usage: "version of metrics api to use. valid values are ['v2', 'v3']. defaults to 'v2' if not specified.",
 		Value: "v2",
+	},
+	cli.StringFlag{
+		Name:  "filter",
+		Usage: "print only the metrics whose name matches a regular expression",
+	},
+	cli.StringFlag{
+		Name:  "format",
+		Usage: "output format of the metrics, 'text' as exposed by the server or 'table'",
+		Value: "text",
 	})
 
 var metricsV2SubSystems = set.CreateStringSet("node", "bucket", "cluster", "resource")
@@ -98,6 +108,9 @@ EXAMPLES (v3):
   12. Scanner metrics
       {{.Prompt}} {{.HelpName}} play scanner --api-version v3
 
+  13. Usage metrics of all buckets as a table
+      {{.Prompt}} {{.HelpName}} play cluster --api-version v3 --filter 'minio_cluster_usage_buckets.*' --format table
+
 EXAMPLES (v2):
   1. Metrics reported cluster wide.
      {{.Prompt}} {{.HelpName}} play
@@ -110,6 +123,9 @@ EXAMPLES (v2):
 
   4. Resource metrics.
      {{.Prompt}} {{.HelpName}} play resource
+
+  5. Bucket usage metrics as a table.
+     {{.Prompt}} {{.HelpName}} play bucket --filter 'minio_bucket_usage.*' --format table
 `,
 }
 
@@ -119,6 +135,8 @@ type prometheusMetricsReq struct {
 	aliasURL  string
 	token     string
 	subsystem string
+	filter    *regexp.Regexp
+	format    string
 }
 
 // checkSupportMetricsSyntax - validate arguments passed by a user
@@ -126,6 +144,9 @@ func checkSupportMetricsSyntax(ctx *cli.Context) {
 	if len(ctx.Args()) == 0 || len(ctx.Args()) > 2 {
 		showCommandHelpAndExit(ctx, 1) // last argument is exit code
 	}
+	if format := ctx.String("format"); format != "text" && format != "table" {
+		fatalIf(errInvalidArgument().Trace(format), "`--format` must be either 'text' or 'table'.")
+	}
 }
 
 func fetchMetrics(metricsURL string, token string) (*http.Response, error) {
@@ -171,7 +192,7 @@ func printPrometheusMetricsV2(ctx *cli.Context, req prometheusMetricsReq) error
 	defer resp.Body.Close()
 
 	if resp.StatusCode == http.StatusOK {
-		printMsg(prometheusMetricsReader{Reader: resp.Body})
+		printMsg(prometheusMetricsReader{Reader: resp.Body, filter: req.filter, format: req.format})
 		return nil
 	}
 
@@ -183,15 +204,25 @@ func (pm prometheusMetricsReader) JSON() string {
 	results, e := madmin.ParsePrometheusResults(pm.Reader)
 	fatalIf(probe.NewError(e), "Unable to parse Prometheus metrics.")
 
-	jsonMessageBytes, e := json.MarshalIndent(results, "", " ")
+	jsonMessageBytes, e := json.MarshalIndent(filterPrometheusFamilies(results, pm.filter), "", " ")
 	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
 	return string(jsonMessageBytes)
 }
 
 // String - returns the string representation of the prometheus metrics
 func (pm prometheusMetricsReader) String() string {
-	_, e := io.Copy(os.Stdout, pm.Reader)
+	if pm.format == "table" {
+		results, e := madmin.ParsePrometheusResults(pm.Reader)
+		fatalIf(probe.NewError(e), "Unable to parse Prometheus metrics.")
+		return strings.TrimSuffix(prometheusFamiliesTable(filterPrometheusFamilies(results, pm.filter)), "\n")
+	}
 
+	var e error
+	if pm.filter != nil {
+		e = filterPrometheusText(pm.Reader, os.Stdout, pm.filter)
+	} else {
+		_, e = io.Copy(os.Stdout, pm.Reader)
+	}
 	fatalIf(probe.NewError(e), "Unable to read Prometheus metrics.")
 
 	return ""
@@ -200,6 +231,8 @@ func (pm prometheusMetricsReader) String() string {
 // prometheusMetricsReader mirrors the MetricFamily proto message.
 type prometheusMetricsReader struct {
 	Reader io.Reader
+	filter *regexp.Regexp
+	format string
 }
 
 func mainSupportMetrics(ctx *cli.Context) error {
@@ -231,6 +264,13 @@ func mainSupportMetrics(ctx *cli.Context) error {
 		aliasURL:  hostConfig.URL,
 		token:     token,
 		subsystem: metricsSubSystem,
+		format:    ctx.String("format"),
+	}
+	if filter := ctx.String("filter"); filter != "" {
+		// Like Prometheus label matchers, the expression must match the whole name.
+		re, e := regexp.Compile("^(?:" + filter + ")$")
+		fatalIf(probe.NewError(e).Trace(filter), "Unable to parse --filter.")
+		metricsReq.filter = re
 	}
 
 	switch apiVer {
@@ -18,8 +18,10 @@
 package cmd
 
 import (
+	"fmt"
 	"strings"
 
+	"github.com/dustin/go-humanize"
 	"github.com/fatih/color"
 	"github.com/minio/cli"
 	json "github.com/minio/colorjson"
@@ -33,6 +35,10 @@ var adminReplicateAddFlags = []cli.Flag{
 		Name:  "replicate-ilm-expiry",
 		Usage: "replicate ILM expiry rules",
 	},
+	cli.StringSliceFlag{
+		Name:  "bandwidth",
+		Usage: "default bandwidth limit per bucket of the replication to the sites, as LIMIT for all sites or ALIAS=LIMIT for a site (e.g. 100MiB)",
+	},
 }
 
 var adminReplicateAddCmd = cli.Command{
@@ -58,6 +64,10 @@ EXAMPLES:
 
   2. Add a site for cluster-level replication with replication of ILM expiry rules:
      {{.Prompt}} {{.HelpName}} minio1 minio2 --replicate-ilm-expiry
+
+  3. Add sites for cluster-level replication, limiting the replication to 100MiB/s per bucket and to
+     20MiB/s per bucket towards minio3 behind a WAN link. The limits are shown by 'mc admin replicate info'.
+     {{.Prompt}} {{.HelpName}} minio1 minio2 minio3 --bandwidth 100MiB --bandwidth minio3=20MiB
 `,
 }
 
@@ -82,6 +92,62 @@ func (m successMessage) String() string {
 	return console.Colorize("UserMessage", strings.Join(messages, "\n"))
 }
 
+type replicateBandwidthMessage struct {
+	Status       string `json:"status"`
+	Site         string `json:"site"`
+	DeploymentID string `json:"deploymentID"`
+	Bandwidth    uint64 `json:"bandwidth"`
+}
+
+func (m replicateBandwidthMessage) JSON() string {
+	m.Status = "success"
+	bs, e := json.MarshalIndent(m, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+	return string(bs)
+}
+
+func (m replicateBandwidthMessage) String() string {
+	return console.Colorize("UserMessage", fmt.Sprintf("Replication bandwidth limit of `%s` set to %s/s per bucket.", m.Site, humanize.IBytes(m.Bandwidth)))
+}
+
+// parseSiteBandwidths returns the bandwidth limit of the sites from the
+// values of --bandwidth, a limit without a site applies to all sites.
+func parseSiteBandwidths(values, sites []string) (map[string]uint64, error) {
+	bandwidths := make(map[string]uint64, len(sites))
+	// Limits of all sites first, limits of a site override them.
+	for _, v := range values {
+		if strings.Contains(v, "=") {
+			continue
+		}
+		limit, e := getBandwidthInBytes(v)
+		if e != nil {
+			return nil, e
+		}
+		for _, site := range sites {
+			bandwidths[site] = limit
+		}
+	}
+	for _, v := range values {
+		site, limitStr, ok := strings.Cut(v, "=")
+		if !ok {
+			continue
+		}
+		found := false
+		for _, s := range sites {
+			found = found || s == site
+		}
+		if !found {
+			return nil, fmt.Errorf("unknown site `%s` in bandwidth `%s`", site, v)
+		}
+		limit, e := getBandwidthInBytes(limitStr)
+		if e != nil {
+			return nil, e
+		}
+		bandwidths[site] = limit
+	}
+	return bandwidths, nil
+}
+
 func mainAdminReplicateAdd(ctx *cli.Context) error {
 	{
 		// Check argument count
@@ -94,6 +160,9 @@ func mainAdminReplicateAdd(ctx *cli.Context) error {
 
 	console.SetColor("UserMessage", color.New(color.FgGreen))
 
+	bandwidths, e := parseSiteBandwidths(ctx.StringSlice("bandwidth"), ctx.Args())
+	fatalIf(probe.NewError(e).Trace(ctx.StringSlice("bandwidth")...), "Invalid bandwidth limit.")
+
 	// Get the alias parameter from cli
 	args := ctx.Args()
 	aliasedURL := args.Get(0)
@@ -123,5 +192,37 @@ func mainAdminReplicateAdd(ctx *cli.Context) error {
 
 	printMsg(successMessage(res))
 
+	if len(bandwidths) == 0 || !res.Success {
+		return nil
+	}
+	// Limits are set before the initial sync saturates the links.
+	info, e := client.SiteReplicationInfo(globalContext)
+	fatalIf(probe.NewError(e).Trace(args...), "Unable to get cluster replication information")
+	for _, site := range ctx.Args() {
+		limit, ok := bandwidths[site]
+		if !ok {
+			continue
+		}
+		var deploymentID string
+		for _, peer := range info.Sites {
+			if peer.Name == site {
+				deploymentID = peer.DeploymentID
+			}
+		}
+		if deploymentID == "" {
+			fatalIf(errDummy().Trace(site), "Unable to find the deployment ID of site `"+site+"`.")
+		}
+		_, e = client.SiteReplicationEdit(globalContext, madmin.PeerInfo{
+			DeploymentID:     deploymentID,
+			DefaultBandwidth: madmin.BucketBandwidth{Limit: limit, IsSet: true},
+		}, madmin.SREditOptions{})
+		fatalIf(probe.NewError(e).Trace(site), "Unable to set the replication bandwidth limit of site `"+site+"`.")
+		printMsg(replicateBandwidthMessage{
+			Site:         site,
+			DeploymentID: deploymentID,
+			Bandwidth:    limit,
+		})
+	}
+
 	return nil
 }
@@ -0,0 +1,48 @@
+// Copyright (c) 2015-2024 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseSiteBandwidths(t *testing.T) {
+	sites := []string{"minio1", "minio2", "minio3"}
+	testCases := []struct {
+		values   []string
+		expected map[string]uint64
+		ok       bool
+	}{
+		{nil, map[string]uint64{}, true},
+		{[]string{"100MiB"}, map[string]uint64{"minio1": 100 << 20, "minio2": 100 << 20, "minio3": 100 << 20}, true},
+		{[]string{"minio3=20MiB", "100MiB"}, map[string]uint64{"minio1": 100 << 20, "minio2": 100 << 20, "minio3": 20 << 20}, true},
+		{[]string{"minio2=1G"}, map[string]uint64{"minio2": 1000000000}, true},
+		{[]string{"minio4=1G"}, nil, false},
+		{[]string{"fast"}, nil, false},
+	}
+	for i, testCase := range testCases {
+		bandwidths, e := parseSiteBandwidths(testCase.values, sites)
+		if (e == nil) != testCase.ok {
+			t.Fatalf("Test %d: expected ok %v, got %v", i+1, testCase.ok, e)
+		}
+		if e == nil && !reflect.DeepEqual(bandwidths, testCase.expected) {
+			t.Fatalf("Test %d: expected %v, got %v", i+1, testCase.expected, bandwidths)
+		}
+	}
+}
@@ -242,7 +242,7 @@ func mainAdminScannerInfo(ctx *cli.Context) error {
 			ui.Send(metrics)
 			lastTime = metrics.Aggregated.Scanner.CollectedAt
 		}
-		os.Exit(0)
+		cli.OsExiter(0)
 	}
 
 	// Create a new MinIO Admin Client
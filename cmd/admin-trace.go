@@ -194,7 +194,7 @@ var adminTraceCmd = cli.Command{
 	Action:          mainAdminTrace,
 	OnUsageError:    onUsageError,
 	Before:          setGlobalsFromContext,
-	Flags:           append(adminTraceFlags, globalFlags...),
+	Flags:           append(append(adminTraceFlags, logExportFlags...), globalFlags...),
 	HideHelpCommand: true,
 	CustomHelpTemplate: `NAME:
   {{.HelpName}} - {{.Usage}}
@@ -239,6 +239,9 @@ EXAMPLES:
   
   8. Show trace only for requests operations duration greater than 5ms
      {{.Prompt}} {{.HelpName}} --response-duration 5ms myminio
+
+  9. Forward failed requests to an OpenTelemetry collector without printing them
+     {{.Prompt}} {{.HelpName}} --errors --otlp localhost:4318 --quiet myminio
 `,
 }
 
@@ -616,18 +619,53 @@ func mainAdminTrace(ctx *cli.Context) error {
 		}
 		return nil
 	}
+	exporters, err := newRecordExporters(ctx, "minio")
+	fatalIf(err, "Unable to initialize trace exporters.")
+	defer exporters.Close()
+
 	for traceInfo := range traceCh {
 		if traceInfo.Err != nil {
 			fatalIf(probe.NewError(traceInfo.Err), "Unable to listen to http trace")
 		}
-		if mopts.matches(traceInfo) {
-			printTrace(verbose, traceInfo)
+		if !mopts.matches(traceInfo) {
+			continue
+		}
+		if len(exporters) > 0 {
+			exporters.Export(traceExportRecord(traceInfo))
+			if globalQuiet {
+				continue
+			}
 		}
+		printTrace(verbose, traceInfo)
 	}
 
 	return nil
 }
 
+// traceExportRecord converts a trace into a record for external collectors.
+func traceExportRecord(ti madmin.ServiceTraceInfo) exportRecord {
+	s := shortTrace(ti)
+	severity := "INFO"
+	if s.Error != "" || s.StatusCode >= http.StatusBadRequest {
+		severity = "ERROR"
+	}
+	body, e := json.Marshal(s)
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+	return exportRecord{
+		Time:     s.Time,
+		Severity: severity,
+		Body:     string(body),
+		Attrs: map[string]string{
+			"node":     s.Host,
+			"type":     s.Type,
+			"api":      s.FuncName,
+			"path":     s.Path,
+			"client":   s.Client,
+			"duration": s.Duration.String(),
+		},
+	}
+}
+
 // Short trace record
 type shortTraceMsg struct {
 	Status     string            `json:"status"`
@@ -37,7 +37,7 @@ var adminUserAddCmd = cli.Command{
 	Action:       mainAdminUserAdd,
 	OnUsageError: onUsageError,
 	Before:       setGlobalsFromContext,
-	Flags:        globalFlags,
+	Flags:        append([]cli.Flag{credentialsOutputFlag}, globalFlags...),
 	CustomHelpTemplate: `NAME:
   {{.HelpName}} - {{.Usage}}
 
@@ -72,6 +72,11 @@ EXAMPLES:
   4. Add a new user 'foobar' to MinIO server, then attach IAM policy "writeonly".
      {{.Prompt}} {{.HelpName}} myminio foobar foo12345 
      {{.Prompt}} mc admin policy attach myminio writeonly --user foobar
+
+  5. Add a new user 'foobar' to MinIO server and print the environment variables of its credentials.
+     {{.DisableHistory}}
+     {{.Prompt}} {{.HelpName}} myminio foobar foo12345 --output env
+     {{.EnableHistory}}
 `,
 }
 
@@ -81,6 +86,7 @@ func checkAdminUserAddSyntax(ctx *cli.Context) {
 	if argsNr > 3 || argsNr < 1 {
 		showCommandHelpAndExit(ctx, 1)
 	}
+	checkCredentialsOutputSyntax(ctx)
 }
 
 // userGroup container for content message structure
@@ -203,6 +209,9 @@ func mainAdminUserAdd(ctx *cli.Context) error {
 
 	fatalIf(probe.NewError(client.AddUser(globalContext, accessKey, secretKey)).Trace(args...), "Unable to add new user")
 
+	if printCredentialsOutput(ctx, aliasedURL, accessKey, secretKey, nil) {
+		return nil
+	}
 	printMsg(userMessage{
 		op:         ctx.Command.Name,
 		AccessKey:  accessKey,
@@ -66,6 +66,7 @@ var adminUserSvcAcctAddFlags = []cli.Flag{
 		Name:  "expiry",
 		Usage: "time of expiration for the service account",
 	},
+	credentialsOutputFlag,
 }
 
 var adminUserSvcAcctAddCmd = cli.Command{
@@ -102,6 +103,9 @@ EXAMPLES:
 
   5. Add a new service account to MinIO server with specified expiry date in the future for user 'foobar'.
      {{.Prompt}} {{.HelpName}} myminio foobar --expiry 2023-06-24T10:00:00-07:00
+
+  6. Add a new service account for user 'foobar' and set an alias for it.
+     {{.Prompt}} eval "$({{.HelpName}} myminio foobar --output alias-cmd)"
 `,
 }
 
@@ -110,6 +114,7 @@ func checkAdminUserSvcAcctAddSyntax(ctx *cli.Context) {
 	if len(ctx.Args()) != 2 {
 		showCommandHelpAndExit(ctx, 1)
 	}
+	checkCredentialsOutputSyntax(ctx)
 }
 
 // acctMessage container for content message structure
@@ -353,6 +358,9 @@ func mainAdminUserSvcAcctAdd(ctx *cli.Context) error {
 	creds, e := client.AddServiceAccount(globalContext, opts)
 	fatalIf(probe.NewError(e).Trace(args...), "Unable to add a new service account.")
 
+	if printCredentialsOutput(ctx, aliasedURL, creds.AccessKey, creds.SecretKey, &creds.Expiration) {
+		return nil
+	}
 	printMsg(acctMessage{
 		op:            svcAccOpAdd,
 		AccessKey:     creds.AccessKey,
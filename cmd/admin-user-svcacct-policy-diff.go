@@ -0,0 +1,201 @@
+// Copyright (c) 2015-2024 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	json "github.com/minio/colorjson"
+	"github.com/minio/madmin-go/v3"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/pkg/v3/console"
+	"github.com/minio/pkg/v3/policy"
+)
+
+var adminUserSvcAcctPolicyDiffFlags = []cli.Flag{
+	cli.BoolFlag{
+		Name:  "apply",
+		Usage: "replace the embedded policy with the provided policy file if they differ",
+	},
+}
+
+var adminUserSvcAcctPolicyDiffCmd = cli.Command{
+	Name:         "diff",
+	Usage:        "show differences between the embedded policy of a service account and a policy file",
+	Action:       mainAdminUserSvcAcctPolicyDiff,
+	OnUsageError: onUsageError,
+	Before:       setGlobalsFromContext,
+	Flags:        append(adminUserSvcAcctPolicyDiffFlags, globalFlags...),
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} ALIAS SERVICE-ACCOUNT POLICY-FILE
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+EXAMPLES:
+  1. Show how the embedded policy of the service account 'J123C4ZXEQN8RK6ND35I' differs from '/tmp/policy.json'.
+     {{.Prompt}} {{.HelpName}} myminio/ J123C4ZXEQN8RK6ND35I /tmp/policy.json
+
+  2. Show the differences and replace the embedded policy with '/tmp/policy.json'.
+     {{.Prompt}} {{.HelpName}} myminio/ J123C4ZXEQN8RK6ND35I /tmp/policy.json --apply
+`,
+}
+
+// svcAcctPolicyDiffMessage container for service account policy diff messages
+type svcAcctPolicyDiffMessage struct {
+	Status     string   `json:"status"`
+	AccessKey  string   `json:"accessKey"`
+	PolicyFile string   `json:"policyFile"`
+	Changed    bool     `json:"changed"`
+	Applied    bool     `json:"applied"`
+	Diff       []string `json:"diff,omitempty"`
+
+	diff []diffLine
+}
+
+// String colorized service account policy diff message
+func (m svcAcctPolicyDiffMessage) String() string {
+	if !m.Changed {
+		return console.Colorize("SvcAcctPolicyDiff", fmt.Sprintf("Embedded policy of `%s` matches `%s`.", m.AccessKey, m.PolicyFile))
+	}
+
+	var s strings.Builder
+	for _, line := range m.diff {
+		switch line.op {
+		case diffLineRemoved:
+			s.WriteString(console.Colorize("DiffOnlyInFirst", line.String()))
+		case diffLineAdded:
+			s.WriteString(console.Colorize("DiffOnlyInSecond", line.String()))
+		default:
+			s.WriteString(line.String())
+		}
+		s.WriteString("\n")
+	}
+	if m.Applied {
+		s.WriteString(console.Colorize("SvcAcctPolicyDiff", fmt.Sprintf("Updated embedded policy of `%s` from `%s`.", m.AccessKey, m.PolicyFile)))
+	}
+	return strings.TrimSuffix(s.String(), "\n")
+}
+
+// JSON jsonified service account policy diff message
+func (m svcAcctPolicyDiffMessage) JSON() string {
+	m.Status = "success"
+	for _, line := range m.diff {
+		if line.op != diffLineEqual {
+			m.Diff = append(m.Diff, line.String())
+		}
+	}
+	jsonMessageBytes, e := json.MarshalIndent(m, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+
+	return string(jsonMessageBytes)
+}
+
+// checkAdminUserSvcAcctPolicyDiffSyntax - validate all the passed arguments
+func checkAdminUserSvcAcctPolicyDiffSyntax(ctx *cli.Context) {
+	if len(ctx.Args()) != 3 {
+		showCommandHelpAndExit(ctx, 1)
+	}
+}
+
+// formatPolicyLines parses a policy document and returns its canonical
+// indented form split into lines, so that formatting differences are ignored.
+func formatPolicyLines(buf []byte) ([]string, error) {
+	if len(bytes.TrimSpace(buf)) == 0 {
+		return nil, nil
+	}
+	p, e := policy.ParseConfig(bytes.NewReader(buf))
+	if e != nil {
+		return nil, e
+	}
+	out, e := json.MarshalIndent(p, "", " ")
+	if e != nil {
+		return nil, e
+	}
+	return strings.Split(string(out), "\n"), nil
+}
+
+// mainAdminUserSvcAcctPolicyDiff is the handle for "mc admin user svcacct policy diff" command.
+func mainAdminUserSvcAcctPolicyDiff(ctx *cli.Context) error {
+	checkAdminUserSvcAcctPolicyDiffSyntax(ctx)
+
+	console.SetColor("SvcAcctPolicyDiff", color.New(color.FgGreen))
+	console.SetColor("DiffOnlyInFirst", color.New(color.FgRed))
+	console.SetColor("DiffOnlyInSecond", color.New(color.FgGreen))
+
+	// Get the alias parameter from cli
+	args := ctx.Args()
+	aliasedURL := args.Get(0)
+	svcAccount := args.Get(1)
+	policyPath := args.Get(2)
+
+	buf, e := os.ReadFile(policyPath)
+	fatalIf(probe.NewError(e).Trace(policyPath), "Unable to open the policy document.")
+	fileLines, e := formatPolicyLines(buf)
+	fatalIf(probe.NewError(e).Trace(policyPath), "Unable to parse the policy document.")
+	if len(fileLines) == 0 {
+		fatalIf(errInvalidArgument().Trace(policyPath), "Policy document is empty.")
+	}
+
+	// Create a new MinIO Admin Client
+	client, err := newAdminClient(aliasedURL)
+	fatalIf(err, "Unable to initialize admin connection.")
+
+	svcInfo, e := client.InfoServiceAccount(globalContext, svcAccount)
+	fatalIf(probe.NewError(e).Trace(args...), "Unable to get information of the specified service account")
+
+	// An empty embedded policy means the parent user policy applies.
+	embeddedLines, e := formatPolicyLines([]byte(svcInfo.Policy))
+	fatalIf(probe.NewError(e).Trace(args...), "Unable to parse the embedded policy.")
+
+	diff := lineDiff(embeddedLines, fileLines)
+	changed := false
+	for _, line := range diff {
+		if line.op != diffLineEqual {
+			changed = true
+			break
+		}
+	}
+
+	applied := false
+	if changed && ctx.Bool("apply") {
+		e = client.UpdateServiceAccount(globalContext, svcAccount, madmin.UpdateServiceAccountReq{
+			NewPolicy: buf,
+		})
+		fatalIf(probe.NewError(e).Trace(args...), "Unable to update the embedded policy of the specified service account")
+		applied = true
+	}
+
+	printMsg(svcAcctPolicyDiffMessage{
+		AccessKey:  svcAccount,
+		PolicyFile: policyPath,
+		Changed:    changed,
+		Applied:    applied,
+		diff:       diff,
+	})
+
+	return nil
+}
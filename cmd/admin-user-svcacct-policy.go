@@ -0,0 +1,41 @@
+// Copyright (c) 2015-2024 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import "github.com/minio/cli"
+
+var adminUserSvcAcctPolicySubcommands = []cli.Command{
+	adminUserSvcAcctPolicyDiffCmd,
+}
+
+var adminUserSvcAcctPolicyCmd = cli.Command{
+	Name:            "policy",
+	Usage:           "manage the embedded policy of service accounts",
+	Action:          mainAdminUserSvcAcctPolicy,
+	Before:          setGlobalsFromContext,
+	Flags:           globalFlags,
+	Subcommands:     adminUserSvcAcctPolicySubcommands,
+	HideHelpCommand: true,
+}
+
+// mainAdminUserSvcAcctPolicy is the handle for "mc admin user svcacct policy" command.
+func mainAdminUserSvcAcctPolicy(ctx *cli.Context) error {
+	commandNotFound(ctx, adminUserSvcAcctPolicySubcommands)
+	return nil
+	// Sub-commands like "diff" have their own main.
+}
@@ -27,6 +27,7 @@ var adminUserSvcAcctSubcommands = []cli.Command{
 	adminUserSvcAcctSetCmd,
 	adminUserSvcAcctEnableCmd,
 	adminUserSvcAcctDisableCmd,
+	adminUserSvcAcctPolicyCmd,
 }
 
 var adminUserSvcAcctCmd = cli.Command{
@@ -70,6 +70,9 @@ func exportAlias(alias string) {
 	if !ok {
 		fatalIf(errInvalidArgument().Trace(alias), "Unable to export credentials")
 	}
+	if cfg.isExpired() {
+		fatalIf(errAliasExpired(alias, *cfg.Expiry), "Unable to export credentials")
+	}
 
 	buf, e := json.Marshal(cfg)
 	fatalIf(probe.NewError(e).Trace(alias), "Unable to export credentials")
@@ -0,0 +1,371 @@
+// Copyright (c) 2015-2024 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/go-ini/ini"
+	"github.com/minio/cli"
+	json "github.com/minio/colorjson"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/pkg/v3/console"
+)
+
+var aliasImportAWSFlags = []cli.Flag{
+	cli.StringSliceFlag{
+		Name:  "profile",
+		Usage: "import only the given AWS profile (multiple profiles can be provided), all profiles are imported by default",
+	},
+	cli.StringFlag{
+		Name:  "prefix",
+		Usage: "prefix the names of the imported aliases",
+	},
+	cli.StringFlag{
+		Name:   "credentials-file",
+		Usage:  "path of the AWS shared credentials file, defaults to ~/.aws/credentials",
+		EnvVar: "AWS_SHARED_CREDENTIALS_FILE",
+	},
+	cli.StringFlag{
+		Name:   "config-file",
+		Usage:  "path of the AWS config file, defaults to ~/.aws/config",
+		EnvVar: "AWS_CONFIG_FILE",
+	},
+	cli.BoolFlag{
+		Name:  "overwrite",
+		Usage: "replace existing aliases with the same name",
+	},
+}
+
+var aliasImportAWSCmd = cli.Command{
+	Name:            "import-aws",
+	Usage:           "create aliases from the profiles of the AWS CLI configuration",
+	Action:          mainAliasImportAWS,
+	OnUsageError:    onUsageError,
+	Before:          setGlobalsFromContext,
+	Flags:           append(aliasImportAWSFlags, globalFlags...),
+	HideHelpCommand: true,
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} [FLAGS]
+
+  Profiles are read from the AWS shared credentials and config files, each one
+  becomes an alias of the same name pointing to the S3 endpoint of its region,
+  or to its endpoint_url. Profiles assuming a role with role_arn, from a
+  source_profile or a web_identity_token_file, become aliases obtaining
+  temporary credentials from AWS STS. Profiles using AWS SSO, credential_process
+  or credential_source cannot be imported and are skipped.
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+EXAMPLES:
+  1. Import all the profiles of ~/.aws/credentials and ~/.aws/config.
+     {{.Prompt}} {{.HelpName}}
+
+  2. Import the 'prod' and 'staging' profiles as 'aws-prod' and 'aws-staging'.
+     {{.Prompt}} {{.HelpName}} --profile prod --profile staging --prefix aws-
+
+  3. Import the profiles of another AWS configuration, replacing existing aliases.
+     {{.Prompt}} {{.HelpName}} --credentials-file ./credentials --config-file ./config --overwrite
+`,
+}
+
+// aliasImportAWSMessage reports the import of one AWS profile.
+type aliasImportAWSMessage struct {
+	Status  string `json:"status"`
+	Profile string `json:"profile"`
+	Alias   string `json:"alias,omitempty"`
+	URL     string `json:"URL,omitempty"`
+	Reason  string `json:"reason,omitempty"`
+}
+
+func (m aliasImportAWSMessage) String() string {
+	if m.Status != "success" {
+		return console.Colorize("AliasImportAWSSkipped", fmt.Sprintf("Skipped AWS profile `%s`: %s.", m.Profile, m.Reason))
+	}
+	return console.Colorize("AliasMessage", fmt.Sprintf("Imported AWS profile `%s` as `%s` (%s).", m.Profile, m.Alias, m.URL))
+}
+
+func (m aliasImportAWSMessage) JSON() string {
+	jsonMessageBytes, e := json.MarshalIndent(m, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+
+	return string(jsonMessageBytes)
+}
+
+// awsProfile holds the settings of an AWS CLI profile used by mc.
+type awsProfile struct {
+	AccessKey            string
+	SecretKey            string
+	SessionToken         string
+	Region               string
+	EndpointURL          string
+	RoleARN              string
+	RoleSessionName      string
+	SourceProfile        string
+	WebIdentityTokenFile string
+	DurationSeconds      string
+	// Settings mc cannot use, the profile is skipped when set.
+	SSO               bool
+	CredentialProcess string
+	CredentialSource  string
+}
+
+// parseAWSProfiles parses the AWS shared credentials and config files,
+// the settings of the credentials file take precedence.
+func parseAWSProfiles(credentials, config []byte) (map[string]*awsProfile, error) {
+	profiles := make(map[string]*awsProfile)
+	load := func(data []byte, isConfig bool) error {
+		if len(data) == 0 {
+			return nil
+		}
+		f, e := ini.Load(data)
+		if e != nil {
+			return e
+		}
+		for _, section := range f.Sections() {
+			name := section.Name()
+			if name == ini.DefaultSection && len(section.Keys()) == 0 {
+				continue
+			}
+			if isConfig && name != "default" {
+				// Profiles of the config file are named "profile NAME",
+				// other sections like sso-session are not profiles.
+				var found bool
+				if name, found = strings.CutPrefix(name, "profile "); !found {
+					continue
+				}
+				name = strings.TrimSpace(name)
+			}
+			p := profiles[name]
+			if p == nil {
+				p = &awsProfile{}
+				profiles[name] = p
+			}
+			for _, key := range section.Keys() {
+				value := key.Value()
+				switch key.Name() {
+				case "aws_access_key_id":
+					p.AccessKey = value
+				case "aws_secret_access_key":
+					p.SecretKey = value
+				case "aws_session_token":
+					p.SessionToken = value
+				case "region":
+					p.Region = value
+				case "endpoint_url":
+					p.EndpointURL = value
+				case "role_arn":
+					p.RoleARN = value
+				case "role_session_name":
+					p.RoleSessionName = value
+				case "source_profile":
+					p.SourceProfile = value
+				case "web_identity_token_file":
+					p.WebIdentityTokenFile = value
+				case "duration_seconds":
+					p.DurationSeconds = value
+				case "sso_session", "sso_start_url":
+					p.SSO = true
+				case "credential_process":
+					p.CredentialProcess = value
+				case "credential_source":
+					p.CredentialSource = value
+				}
+			}
+		}
+		return nil
+	}
+	// Load the config file first for the credentials file to override it.
+	if e := load(config, true); e != nil {
+		return nil, fmt.Errorf("unable to parse the AWS config file: %w", e)
+	}
+	if e := load(credentials, false); e != nil {
+		return nil, fmt.Errorf("unable to parse the AWS credentials file: %w", e)
+	}
+	return profiles, nil
+}
+
+// awsServiceURL returns the endpoint of an AWS service in region.
+func awsServiceURL(service, region string) string {
+	if region == "" || region == "us-east-1" {
+		return "https://" + service + ".amazonaws.com"
+	}
+	return "https://" + service + "." + region + ".amazonaws.com"
+}
+
+// awsProfileAlias returns the alias configuration equivalent to the
+// AWS profile name, or an error explaining why it cannot be imported.
+func awsProfileAlias(profiles map[string]*awsProfile, name string) (aliasConfigV10, error) {
+	p := profiles[name]
+	switch {
+	case p == nil:
+		return aliasConfigV10{}, errors.New("profile not found")
+	case p.SSO:
+		return aliasConfigV10{}, errors.New("AWS SSO credentials cannot be used by mc")
+	case p.CredentialProcess != "":
+		return aliasConfigV10{}, errors.New("credential_process is not supported by mc")
+	case p.CredentialSource != "":
+		return aliasConfigV10{}, errors.New("credential_source is not supported by mc")
+	}
+
+	cfg := aliasConfigV10{
+		URL:          p.EndpointURL,
+		AccessKey:    p.AccessKey,
+		SecretKey:    p.SecretKey,
+		SessionToken: p.SessionToken,
+		API:          "S3v4",
+		Path:         "auto",
+	}
+	if cfg.URL == "" {
+		cfg.URL = awsServiceURL("s3", p.Region)
+	}
+
+	if p.RoleARN != "" {
+		cfg.STS = &aliasSTSConfig{
+			Endpoint:             awsServiceURL("sts", p.Region),
+			RoleARN:              p.RoleARN,
+			RoleSessionName:      p.RoleSessionName,
+			WebIdentityTokenFile: p.WebIdentityTokenFile,
+		}
+		if p.DurationSeconds != "" {
+			cfg.STS.Duration = p.DurationSeconds + "s"
+		}
+		if p.WebIdentityTokenFile == "" {
+			source := profiles[p.SourceProfile]
+			switch {
+			case p.SourceProfile == "":
+				return aliasConfigV10{}, errors.New("role_arn requires a source_profile or a web_identity_token_file")
+			case source == nil:
+				return aliasConfigV10{}, fmt.Errorf("source profile `%s` not found", p.SourceProfile)
+			case source.RoleARN != "":
+				return aliasConfigV10{}, fmt.Errorf("source profile `%s` assumes a role, chained roles are not supported by mc", p.SourceProfile)
+			}
+			cfg.AccessKey = source.AccessKey
+			cfg.SecretKey = source.SecretKey
+			cfg.SessionToken = source.SessionToken
+		}
+		if cfg.AccessKey == "" && p.WebIdentityTokenFile == "" {
+			return aliasConfigV10{}, fmt.Errorf("source profile `%s` has no credentials", p.SourceProfile)
+		}
+		return cfg, nil
+	}
+
+	if cfg.AccessKey == "" || cfg.SecretKey == "" {
+		return aliasConfigV10{}, errors.New("no credentials found")
+	}
+	return cfg, nil
+}
+
+// awsProfileAliasName returns the alias name of the AWS profile name,
+// characters not allowed in alias names are replaced by '-'.
+func awsProfileAliasName(prefix, name string) string {
+	return prefix + regexp.MustCompile("[^a-zA-Z0-9-_]").ReplaceAllString(name, "-")
+}
+
+// readAWSFile reads an AWS configuration file, a missing file is empty.
+func readAWSFile(path, defaultName string) ([]byte, *probe.Error) {
+	if path == "" {
+		homeDir, e := os.UserHomeDir()
+		if e != nil {
+			return nil, probe.NewError(e)
+		}
+		path = filepath.Join(homeDir, ".aws", defaultName)
+	}
+	data, e := os.ReadFile(path)
+	if e != nil && !os.IsNotExist(e) {
+		return nil, probe.NewError(e).Trace(path)
+	}
+	return data, nil
+}
+
+func mainAliasImportAWS(cliCtx *cli.Context) error {
+	if cliCtx.Args().Present() {
+		showCommandHelpAndExit(cliCtx, 1)
+	}
+
+	console.SetColor("AliasMessage", color.New(color.FgGreen))
+	console.SetColor("AliasImportAWSSkipped", color.New(color.FgYellow))
+
+	credentials, err := readAWSFile(cliCtx.String("credentials-file"), "credentials")
+	fatalIf(err, "Unable to read the AWS credentials file.")
+	config, err := readAWSFile(cliCtx.String("config-file"), "config")
+	fatalIf(err, "Unable to read the AWS config file.")
+
+	profiles, e := parseAWSProfiles(credentials, config)
+	fatalIf(probe.NewError(e), "Unable to read the AWS profiles.")
+
+	// Skipping profiles is an error only when they were asked for.
+	names := cliCtx.StringSlice("profile")
+	requested := len(names) > 0
+	if !requested {
+		if len(profiles) == 0 {
+			fatalIf(errDummy().Trace(), "No AWS profiles found.")
+		}
+		for name := range profiles {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+	}
+
+	mcCfg, err := loadMcConfig()
+	fatalIf(err.Trace(globalMCConfigVersion), "Unable to load config `"+mustGetMcConfigPath()+"`.")
+
+	var cErr error
+	var imported int
+	for _, name := range names {
+		msg := aliasImportAWSMessage{Profile: name, Alias: awsProfileAliasName(cliCtx.String("prefix"), name)}
+		cfg, e := awsProfileAlias(profiles, name)
+		switch {
+		case e != nil:
+			msg.Reason = e.Error()
+		case !isValidAlias(msg.Alias):
+			msg.Reason = "`" + msg.Alias + "` is not a valid alias name"
+		case mcCfg.Aliases[msg.Alias].URL != "" && !cliCtx.Bool("overwrite"):
+			msg.Reason = "alias `" + msg.Alias + "` already exists, use --overwrite to replace it"
+		default:
+			mcCfg.Aliases[msg.Alias] = cfg
+			msg.Status = "success"
+			msg.URL = cfg.URL
+			imported++
+		}
+		if msg.Status != "success" {
+			msg.Status = "skipped"
+			msg.Alias = ""
+			if requested {
+				cErr = exitStatus(globalErrorExitStatus)
+			}
+		}
+		printMsg(msg)
+	}
+
+	if imported > 0 {
+		fatalIf(saveMcConfig(mcCfg).Trace(), "Unable to save aliases to `"+mustGetMcConfigPath()+"`.")
+	}
+	return cErr
+}
@@ -128,6 +128,9 @@ func buildAliasMessage(alias string, deprecated bool, aliasCfg *aliasConfigV10)
 		SecretKey:   aliasCfg.SecretKey,
 		API:         aliasCfg.API,
 		Src:         aliasCfg.Src,
+		Proxy:       redactProxy(aliasCfg.Proxy),
+		Expiry:      aliasCfg.Expiry,
+		STS:         aliasCfg.STS,
 	}
 
 	if deprecated {
@@ -18,6 +18,9 @@
 package cmd
 
 import (
+	"fmt"
+	"time"
+
 	"github.com/minio/cli"
 	json "github.com/minio/colorjson"
 	"github.com/minio/mc/pkg/probe"
@@ -42,6 +45,7 @@ var aliasSubcommands = []cli.Command{
 	aliasListCmd,
 	aliasRemoveCmd,
 	aliasImportCmd,
+	aliasImportAWSCmd,
 	aliasExportCmd,
 }
 
@@ -66,14 +70,18 @@ func mainAlias(ctx *cli.Context) error {
 type aliasMessage struct {
 	op          string
 	prettyPrint bool
-	Status      string `json:"status"`
-	Alias       string `json:"alias"`
-	URL         string `json:"URL"`
-	AccessKey   string `json:"accessKey,omitempty"`
-	SecretKey   string `json:"secretKey,omitempty"`
-	API         string `json:"api,omitempty"`
-	Path        string `json:"path,omitempty"`
-	Src         string `json:"src,omitempty"`
+	Status      string          `json:"status"`
+	Alias       string          `json:"alias"`
+	URL         string          `json:"URL"`
+	AccessKey   string          `json:"accessKey,omitempty"`
+	SecretKey   string          `json:"secretKey,omitempty"`
+	API         string          `json:"api,omitempty"`
+	Path        string          `json:"path,omitempty"`
+	Src         string          `json:"src,omitempty"`
+	Proxy       string          `json:"proxy,omitempty"`
+	Expiry      *time.Time      `json:"expiry,omitempty"`
+	STS         *aliasSTSConfig `json:"sts,omitempty"`
+	Purged      int             `json:"purged,omitempty"`
 	// Deprecated field, replaced by Path
 	Lookup string `json:"lookup,omitempty"`
 }
@@ -85,26 +93,50 @@ func (h aliasMessage) String() string {
 	switch h.op {
 	case "list":
 		// Create a new pretty table with cols configuration
-		t := newPrettyRecord(2,
-			Row{"Alias", "Alias"},
-			Row{"URL", "URL"},
-			Row{"AccessKey", "AccessKey"},
-			Row{"SecretKey", "SecretKey"},
-			Row{"API", "API"},
-			Row{"Path", "Path"},
-			Row{"Src", "Src"},
-		)
+		rows := []Row{
+			{"Alias", "Alias"},
+			{"URL", "URL"},
+			{"AccessKey", "AccessKey"},
+			{"SecretKey", "SecretKey"},
+			{"API", "API"},
+			{"Path", "Path"},
+			{"Src", "Src"},
+		}
 		// Handle deprecated lookup
 		path := h.Path
 		if path == "" {
 			path = h.Lookup
 		}
-		return t.buildRecord(h.Alias, h.URL, h.AccessKey, h.SecretKey, h.API, path, h.Src)
+		contents := []string{h.Alias, h.URL, h.AccessKey, h.SecretKey, h.API, path, h.Src}
+		if h.Proxy != "" {
+			rows = append(rows, Row{"Proxy", "Proxy"})
+			contents = append(contents, h.Proxy)
+		}
+		if h.STS != nil {
+			rows = append(rows, Row{"STS", "STS"})
+			contents = append(contents, h.STS.String())
+		}
+		if h.Expiry != nil {
+			expiry := h.Expiry.Local().Format(printDate)
+			if time.Now().After(*h.Expiry) {
+				expiry += " (expired)"
+			}
+			rows = append(rows, Row{"Expiry", "Expiry"})
+			contents = append(contents, expiry)
+		}
+		t := newPrettyRecord(2, rows...)
+		return t.buildRecord(contents...)
 	case "remove":
+		if h.Purged > 0 {
+			return console.Colorize("AliasMessage", fmt.Sprintf("Removed `%s` successfully, purged %d saved shares, mirror states and copy checkpoints.", h.Alias, h.Purged))
+		}
 		return console.Colorize("AliasMessage", "Removed `"+h.Alias+"` successfully.")
 	case "add": // add is deprecated
 		fallthrough
 	case "set":
+		if h.Expiry != nil {
+			return console.Colorize("AliasMessage", "Added `"+h.Alias+"` successfully, it expires on "+h.Expiry.Local().Format(printDate)+".")
+		}
 		return console.Colorize("AliasMessage", "Added `"+h.Alias+"` successfully.")
 	case "import":
 		return console.Colorize("AliasMessage", "Imported `"+h.Alias+"` successfully.")
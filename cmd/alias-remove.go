@@ -18,11 +18,25 @@
 package cmd
 
 import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
 	"github.com/fatih/color"
 	"github.com/minio/cli"
+	"github.com/minio/mc/pkg/probe"
 	"github.com/minio/pkg/v3/console"
 )
 
+var aliasRemoveFlags = []cli.Flag{
+	cli.BoolFlag{
+		Name:  "purge",
+		Usage: "also remove saved shares, mirror resume states and copy checkpoints referencing the alias",
+	},
+}
+
 var aliasRemoveCmd = cli.Command{
 	Name:      "remove",
 	ShortName: "rm",
@@ -31,7 +45,7 @@ var aliasRemoveCmd = cli.Command{
 		return mainAliasRemove(ctx)
 	},
 	Before:          setGlobalsFromContext,
-	Flags:           globalFlags,
+	Flags:           append(aliasRemoveFlags, globalFlags...),
 	HideHelpCommand: true,
 	OnUsageError:    onUsageError,
 	CustomHelpTemplate: `NAME:
@@ -47,6 +61,9 @@ EXAMPLES:
   1. Remove "goodisk" alias from the configuration.
      {{.Prompt}} {{.HelpName}} goodisk
 
+  2. Remove "goodisk" alias along with saved shares, mirror states and copy checkpoints referencing it.
+     {{.Prompt}} {{.HelpName}} goodisk --purge
+
 `,
 }
 
@@ -74,12 +91,82 @@ func mainAliasRemove(ctx *cli.Context) error {
 	args := ctx.Args()
 	alias := args.Get(0)
 
-	aliasMsg := removeAlias(alias) // Remove an alias
+	purge := ctx.Bool("purge")
+	aliasMsg, aliasURL := removeAlias(alias) // Remove an alias
 	aliasMsg.op = "remove"
+
+	stale, err := aliasStaleState(alias, aliasURL, purge)
+	fatalIf(err.Trace(alias), "Unable to clean up local state referencing the alias `"+alias+"`.")
+	if purge {
+		aliasMsg.Purged = stale
+	} else if stale > 0 && !globalJSON && !globalQuiet {
+		console.Infof("Found %d saved shares, mirror states or copy checkpoints referencing `%s`, use '--purge' to remove them.\n", stale, alias)
+	}
+
 	printMsg(aliasMsg)
 	return nil
 }
 
+// aliasURLInUse returns true if any configured alias points to aliasURL.
+func aliasURLInUse(aliasURL string) bool {
+	conf, err := loadMcConfig()
+	fatalIf(err.Trace(globalMCConfigVersion), "Unable to load config version `"+globalMCConfigVersion+"`.")
+
+	for _, cfg := range conf.Aliases {
+		if strings.TrimSuffix(cfg.URL, "/") == strings.TrimSuffix(aliasURL, "/") {
+			return true
+		}
+	}
+	return false
+}
+
+// aliasStaleState returns the number of locally saved records referencing
+// the alias, which are also removed when purge is set.
+func aliasStaleState(alias, aliasURL string, purge bool) (int, *probe.Error) {
+	stale, err := aliasStaleMirrorStates(alias, purge)
+	if err != nil {
+		return stale, err
+	}
+	checkpoints, err := aliasStaleCPCheckpoints(alias, purge)
+	stale += checkpoints
+	if err != nil {
+		return stale, err
+	}
+
+	// Other aliases may point to the same endpoint, their shares must be kept.
+	if aliasURL == "" || aliasURLInUse(aliasURL) || !isShareDirExists() {
+		return stale, nil
+	}
+
+	prefix := strings.TrimSuffix(aliasURL, "/") + "/"
+	for _, file := range []string{getShareUploadsFile(), getShareDownloadsFile()} {
+		shareDB := newShareDBV1()
+		if err := shareDB.Load(file); err != nil {
+			// Nothing shared yet.
+			continue
+		}
+
+		var shareURLs []string
+		for shareURL, entry := range shareDB.Shares {
+			if strings.HasPrefix(entry.URL, prefix) {
+				shareURLs = append(shareURLs, shareURL)
+			}
+		}
+		stale += len(shareURLs)
+		if !purge || len(shareURLs) == 0 {
+			continue
+		}
+
+		for _, shareURL := range shareURLs {
+			shareDB.Delete(shareURL)
+		}
+		if err := shareDB.Save(file); err != nil {
+			return stale, err.Trace(file)
+		}
+	}
+	return stale, nil
+}
+
 // aliasMustExist confirms that a given alias is present in Aliases array, returns error if not found
 
 func aliasMustExist(alias string) {
@@ -89,19 +176,79 @@ func aliasMustExist(alias string) {
 	}
 }
 
-// removeAlias - removes an alias.
-func removeAlias(alias string) aliasMessage {
+// removeAlias - removes an alias, returns the URL the alias pointed to.
+func removeAlias(alias string) (aliasMessage, string) {
 	conf, err := loadMcConfig()
 	fatalIf(err.Trace(globalMCConfigVersion), "Unable to load config version `"+globalMCConfigVersion+"`.")
 
 	// check if alias is valid
 	aliasMustExist(alias)
 
+	aliasURL := conf.Aliases[alias].URL
+
 	// Remove the alias from the config.
 	delete(conf.Aliases, alias)
 
 	err = saveMcConfig(conf)
 	fatalIf(err.Trace(alias), "Unable to save the delete alias in config version `"+globalMCConfigVersion+"`.")
 
-	return aliasMessage{Alias: alias}
+	return aliasMessage{Alias: alias}, aliasURL
+}
+
+// aliasStaleMirrorStates returns the number of mirror resume states saved
+// in the default location whose source or target is on the alias.
+func aliasStaleMirrorStates(alias string, purge bool) (int, *probe.Error) {
+	files, e := filepath.Glob(filepath.Join(mustGetMcConfigDir(), globalSessionDir, "mirror-*.json"))
+	if e != nil {
+		return 0, probe.NewError(e)
+	}
+
+	onAlias := func(aliasedURL string) bool {
+		return strings.SplitN(filepath.ToSlash(aliasedURL), "/", 2)[0] == alias
+	}
+
+	stale := 0
+	for _, file := range files {
+		buf, e := os.ReadFile(file)
+		if e != nil {
+			continue
+		}
+		var state mirrorState
+		if e = json.Unmarshal(buf, &state); e != nil {
+			continue
+		}
+		if !onAlias(state.Source) && !onAlias(state.Target) {
+			continue
+		}
+		stale++
+		if purge {
+			if e = os.Remove(file); e != nil {
+				return stale, probe.NewError(e).Trace(file)
+			}
+		}
+	}
+	return stale, nil
+}
+
+// aliasStaleCPCheckpoints returns the number of copy checkpoints saved in
+// the config dir whose sources or target are on the alias.
+func aliasStaleCPCheckpoints(alias string, purge bool) (int, *probe.Error) {
+	infos, err := listCPCheckpoints()
+	if err != nil {
+		return 0, err
+	}
+
+	var ids []string
+	for _, info := range infos {
+		if slices.ContainsFunc(info.Args, func(aliasedURL string) bool {
+			return strings.SplitN(filepath.ToSlash(aliasedURL), "/", 2)[0] == alias
+		}) {
+			ids = append(ids, info.ID)
+		}
+	}
+	if !purge || len(ids) == 0 {
+		return len(ids), nil
+	}
+	_, err = purgeCPCheckpoints(ids)
+	return len(ids), err
 }
@@ -0,0 +1,54 @@
+// Copyright (c) 2015-2024 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseAliasExpiry(t *testing.T) {
+	now := time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)
+	testCases := []struct {
+		expire   string
+		expected time.Time
+		ok       bool
+	}{
+		{"8h", now.Add(8 * time.Hour), true},
+		{"7d", now.Add(7 * 24 * time.Hour), true},
+		{"1h30m", now.Add(90 * time.Minute), true},
+		{"0s", time.Time{}, false},
+		{"-1h", time.Time{}, false},
+		{"tomorrow", time.Time{}, false},
+	}
+	for i, testCase := range testCases {
+		expiry, err := parseAliasExpiry(testCase.expire, now)
+		if !testCase.ok {
+			if err == nil {
+				t.Errorf("Test %d: expected an error, got %v", i+1, expiry)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("Test %d: unexpected error %v", i+1, err)
+		}
+		if !expiry.Equal(testCase.expected) {
+			t.Errorf("Test %d: expected %v, got %v", i+1, testCase.expected, *expiry)
+		}
+	}
+}
@@ -0,0 +1,190 @@
+// Copyright (c) 2015-2024 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	gojson "encoding/json"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	json "github.com/minio/colorjson"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/pkg/v3/console"
+)
+
+// anonymousDryRunMessage is the bucket policy that set or set-json would
+// apply and its differences with the current policy.
+type anonymousDryRunMessage struct {
+	Status    string                 `json:"status"`
+	Operation string                 `json:"operation"`
+	Bucket    string                 `json:"bucket"`
+	Perms     accessPerms            `json:"permission"`
+	DryRun    bool                   `json:"dryRun"`
+	Changed   bool                   `json:"changed"`
+	Policy    map[string]interface{} `json:"policy,omitempty"`
+	Diff      []string               `json:"diff,omitempty"`
+
+	policyLines []string
+	diff        []diffLine
+}
+
+// String colorized anonymous dry run message.
+func (m anonymousDryRunMessage) String() string {
+	var s strings.Builder
+	if len(m.policyLines) == 0 {
+		s.WriteString(console.Colorize("Anonymous", "Resulting policy of `"+m.Bucket+"` is empty."))
+	} else {
+		s.WriteString(console.Colorize("Anonymous", "Resulting policy of `"+m.Bucket+"`:"))
+		s.WriteString("\n" + strings.Join(m.policyLines, "\n"))
+	}
+	s.WriteString("\n\n")
+	if !m.Changed {
+		s.WriteString(console.Colorize("Anonymous", "No changes to the current policy."))
+		return s.String()
+	}
+	s.WriteString(console.Colorize("Anonymous", "Changes to the current policy:"))
+	for _, line := range m.diff {
+		s.WriteString("\n")
+		switch line.op {
+		case diffLineRemoved:
+			s.WriteString(console.Colorize("DiffOnlyInFirst", line.String()))
+		case diffLineAdded:
+			s.WriteString(console.Colorize("DiffOnlyInSecond", line.String()))
+		default:
+			s.WriteString(line.String())
+		}
+	}
+	return s.String()
+}
+
+// JSON jsonified anonymous dry run message.
+func (m anonymousDryRunMessage) JSON() string {
+	m.Status = "success"
+	for _, line := range m.diff {
+		if line.op != diffLineEqual {
+			m.Diff = append(m.Diff, line.String())
+		}
+	}
+	jsonMessageBytes, e := json.MarshalIndent(m, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+	return string(jsonMessageBytes)
+}
+
+// formatBucketPolicyLines returns the indented form of a bucket policy
+// split into lines, keys are sorted so that formatting differences are
+// ignored.
+func formatBucketPolicyLines(policyStr string) (lines []string, parsed map[string]interface{}, e error) {
+	if strings.TrimSpace(policyStr) == "" {
+		return nil, nil, nil
+	}
+	if e = gojson.Unmarshal([]byte(policyStr), &parsed); e != nil {
+		return nil, nil, e
+	}
+	out, e := gojson.MarshalIndent(parsed, "", " ")
+	if e != nil {
+		return nil, nil, e
+	}
+	return strings.Split(string(out), "\n"), parsed, nil
+}
+
+// doAccessDryRun returns the current bucket policy and the policy that
+// set or set-json would apply.
+func doAccessDryRun(ctx context.Context, operation, targetURL string, perms accessPerms) (current, updated string, err *probe.Error) {
+	clnt, err := newClient(targetURL)
+	if err != nil {
+		return "", "", err.Trace(targetURL)
+	}
+	s3Clnt, ok := clnt.(*S3Client)
+	if !ok {
+		return "", "", probe.NewError(APINotImplemented{API: "SetAccess", APIType: "filesystem"})
+	}
+	if operation == "set" {
+		return s3Clnt.accessPolicies(ctx, accessPermToString(perms))
+	}
+
+	updatedBytes, err := readAccessJSON(targetURL, string(perms))
+	if err != nil {
+		return "", "", err
+	}
+	bucket, _ := s3Clnt.url2BucketAndObject()
+	if bucket == "" {
+		return "", "", probe.NewError(BucketNameEmpty{})
+	}
+	current, e := s3Clnt.api.GetBucketPolicy(ctx, bucket)
+	if e != nil {
+		return "", "", probe.NewError(e).Trace(targetURL)
+	}
+	return current, string(updatedBytes), nil
+}
+
+// Run anonymous set or set-json with --dry-run.
+func runAnonymousDryRunCmd(args cli.Args) {
+	ctx, cancelAnonymous := context.WithCancel(globalContext)
+	defer cancelAnonymous()
+
+	console.SetColor("DiffOnlyInFirst", color.New(color.FgRed))
+	console.SetColor("DiffOnlyInSecond", color.New(color.FgGreen))
+
+	operation := args.First()
+	perms := accessPerms(args.Get(1))
+	targetURL := args.Get(2)
+	switch {
+	case operation == "set" && !perms.isValidAccessPERM():
+		fatalIf(errDummy().Trace(), "Invalid access permission: `"+string(perms)+"`.")
+	case operation == "set-json" && !perms.isValidAccessFile():
+		fatalIf(errDummy().Trace(), "Invalid access file: `"+string(perms)+"`.")
+	}
+
+	current, updated, err := doAccessDryRun(ctx, operation, targetURL, perms)
+	if err != nil {
+		switch err.ToGoError().(type) {
+		case APINotImplemented:
+			fatalIf(err.Trace(), "Unable to "+operation+" anonymous of a non S3 url `"+targetURL+"`.")
+		default:
+			fatalIf(err.Trace(targetURL, string(perms)),
+				"Unable to "+operation+" anonymous `"+string(perms)+"` for `"+targetURL+"`.")
+		}
+	}
+
+	currentLines, _, e := formatBucketPolicyLines(current)
+	fatalIf(probe.NewError(e).Trace(targetURL), "Unable to parse the current bucket policy.")
+	updatedLines, updatedPolicy, e := formatBucketPolicyLines(updated)
+	fatalIf(probe.NewError(e).Trace(targetURL, string(perms)), "Unable to parse the anonymous policy.")
+
+	diff := lineDiff(currentLines, updatedLines)
+	changed := false
+	for _, line := range diff {
+		if line.op != diffLineEqual {
+			changed = true
+			break
+		}
+	}
+
+	printMsg(anonymousDryRunMessage{
+		Operation:   operation,
+		Bucket:      targetURL,
+		Perms:       perms,
+		DryRun:      true,
+		Changed:     changed,
+		Policy:      updatedPolicy,
+		policyLines: updatedLines,
+		diff:        diff,
+	})
+}
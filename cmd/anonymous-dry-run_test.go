@@ -0,0 +1,54 @@
+// Copyright (c) 2015-2024 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFormatBucketPolicyLines(t *testing.T) {
+	lines, parsed, e := formatBucketPolicyLines("  ")
+	if e != nil || lines != nil || parsed != nil {
+		t.Fatalf("expected no lines for an empty policy, got %v, %v, %v", lines, parsed, e)
+	}
+
+	a, _, e := formatBucketPolicyLines(`{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Action":["s3:GetObject"]}]}`)
+	if e != nil {
+		t.Fatal(e)
+	}
+	b, _, e := formatBucketPolicyLines(`{
+  "Statement": [{"Action": ["s3:GetObject"], "Effect": "Allow"}],
+  "Version": "2012-10-17"
+}`)
+	if e != nil {
+		t.Fatal(e)
+	}
+	if !reflect.DeepEqual(a, b) {
+		t.Fatalf("expected the same lines for differently formatted policies, got %q and %q", a, b)
+	}
+	for _, line := range lineDiff(a, b) {
+		if line.op != diffLineEqual {
+			t.Fatalf("unexpected change %q", line)
+		}
+	}
+
+	if _, _, e = formatBucketPolicyLines("{"); e == nil {
+		t.Fatal("expected an error for an invalid policy")
+	}
+}
@@ -37,6 +37,10 @@ var anonymousFlags = []cli.Flag{
 		Name:  "recursive, r",
 		Usage: "list recursively",
 	},
+	cli.BoolFlag{
+		Name:  "dry-run",
+		Usage: "show the resulting bucket policy and its differences with the current policy without applying it",
+	},
 }
 
 // Manage anonymous access to buckets and objects.
@@ -93,6 +97,9 @@ EXAMPLES:
 
   9. List public object URLs recursively.
      {{.Prompt}} {{.HelpName}} --recursive links s3/shared/
+
+  10. Review the bucket policy resulting from making a prefix public without applying it.
+      {{.Prompt}} {{.HelpName}} --dry-run set download s3/shared/reports
 `,
 }
 
@@ -260,13 +267,9 @@ func doSetAccess(ctx context.Context, targetURL string, targetPERMS accessPerms)
 	return nil
 }
 
-// doSetAccessJSON do set access JSON.
-func doSetAccessJSON(ctx context.Context, targetURL string, targetPERMS accessPerms) *probe.Error {
-	clnt, err := newClient(targetURL)
-	if err != nil {
-		return err.Trace(targetURL)
-	}
-	fileReader, e := os.Open(string(targetPERMS))
+// readAccessJSON reads the anonymous JSON file of set-json.
+func readAccessJSON(targetURL, file string) ([]byte, *probe.Error) {
+	fileReader, e := os.Open(file)
 	if e != nil {
 		fatalIf(probe.NewError(e).Trace(), "Unable to set anonymous for `"+targetURL+"`.")
 	}
@@ -277,13 +280,24 @@ func doSetAccessJSON(ctx context.Context, targetURL string, targetPERMS accessPe
 
 	n, e := io.ReadFull(fileReader, configBuf)
 	if e == nil {
-		return probe.NewError(bytes.ErrTooLarge).Trace(targetURL)
+		return nil, probe.NewError(bytes.ErrTooLarge).Trace(targetURL)
 	}
 	if e != io.ErrUnexpectedEOF {
-		return probe.NewError(e).Trace(targetURL)
+		return nil, probe.NewError(e).Trace(targetURL)
 	}
+	return configBuf[:n], nil
+}
 
-	configBytes := configBuf[:n]
+// doSetAccessJSON do set access JSON.
+func doSetAccessJSON(ctx context.Context, targetURL string, targetPERMS accessPerms) *probe.Error {
+	clnt, err := newClient(targetURL)
+	if err != nil {
+		return err.Trace(targetURL)
+	}
+	configBytes, err := readAccessJSON(targetURL, string(targetPERMS))
+	if err != nil {
+		return err
+	}
 	if err = clnt.SetAccess(ctx, string(configBytes), true); err != nil {
 		return err.Trace(targetURL, string(targetPERMS))
 	}
@@ -485,6 +499,18 @@ func mainAnonymous(ctx *cli.Context) error {
 	// Additional command speific theme customization.
 	console.SetColor("Anonymous", color.New(color.FgGreen, color.Bold))
 
+	if ctx.Bool("dry-run") {
+		switch ctx.Args().First() {
+		case "set", "set-json":
+			// anonymous --dry-run set [private|public|download|upload] alias/bucket/prefix
+			// anonymous --dry-run set-json path-to-anonymous-json-file alias/bucket/prefix
+			runAnonymousDryRunCmd(ctx.Args())
+			return nil
+		default:
+			fatalIf(errInvalidArgument().Trace(ctx.Args()...), "--dry-run is only supported by set and set-json.")
+		}
+	}
+
 	switch ctx.Args().First() {
 	case "set", "set-json", "get", "get-json":
 		// anonymous set [private|public|download|upload] alias/bucket/prefix
@@ -240,12 +240,15 @@ var completeCmds = map[string]complete.Predictor{
 	"/diff":      complete.PredictOr(s3Completer, fsCompleter),
 	"/find":      complete.PredictOr(s3Completer, fsCompleter),
 	"/mirror":    complete.PredictOr(s3Completer, fsCompleter),
+	"/sync":      complete.PredictOr(s3Completer, fsCompleter),
 	"/pipe":      complete.PredictOr(s3Completer, fsCompleter),
 	"/stat":      complete.PredictOr(s3Completer, fsCompleter),
 	"/watch":     complete.PredictOr(s3Completer, fsCompleter),
 	"/anonymous": complete.PredictOr(s3Completer, fsCompleter),
 	"/tree":      complete.PredictOr(s3Complete{deepLevel: 2}, fsCompleter),
 	"/du":        complete.PredictOr(s3Complete{deepLevel: 2}, fsCompleter),
+	"/verify":    complete.PredictOr(s3Completer, fsCompleter),
+	"/checksum":  complete.PredictOr(s3Completer, fsCompleter),
 
 	"/retention/set":   s3Completer,
 	"/retention/clear": s3Completer,
@@ -255,12 +258,19 @@ var completeCmds = map[string]complete.Predictor{
 	"/legalhold/clear": s3Completer,
 	"/legalhold/info":  s3Completer,
 
+	"/bench/put":   s3Complete{deepLevel: 2},
+	"/bench/get":   s3Complete{deepLevel: 2},
+	"/bench/mixed": s3Complete{deepLevel: 2},
+
+	"/verify-manifest": complete.PredictOr(s3Completer, fsCompleter),
+
 	"/sql": s3Completer,
 	"/mb":  aliasCompleter,
 
 	"/event/add":    s3Complete{deepLevel: 2},
 	"/event/list":   s3Complete{deepLevel: 2},
 	"/event/remove": s3Complete{deepLevel: 2},
+	"/event/replay": s3Complete{deepLevel: 2},
 
 	"/encrypt/set":   s3Complete{deepLevel: 2},
 	"/encrypt/info":  s3Complete{deepLevel: 2},
@@ -296,21 +306,24 @@ var completeCmds = map[string]complete.Predictor{
 	"/share/list":     nil,
 	"/share/upload":   s3Completer,
 
-	"/ilm/list":    s3Complete{deepLevel: 2},
-	"/ilm/add":     s3Complete{deepLevel: 2},
-	"/ilm/edit":    s3Complete{deepLevel: 2},
-	"/ilm/remove":  s3Complete{deepLevel: 2},
-	"/ilm/export":  s3Complete{deepLevel: 2},
-	"/ilm/import":  s3Complete{deepLevel: 2},
-	"/ilm/restore": s3Completer,
-
-	"/ilm/rule/list":    s3Complete{deepLevel: 2},
-	"/ilm/rule/add":     s3Complete{deepLevel: 2},
-	"/ilm/rule/edit":    s3Complete{deepLevel: 2},
-	"/ilm/rule/remove":  s3Complete{deepLevel: 2},
-	"/ilm/rule/export":  s3Complete{deepLevel: 2},
-	"/ilm/rule/import":  s3Complete{deepLevel: 2},
-	"/ilm/rule/restore": s3Completer,
+	"/ilm/list":   s3Complete{deepLevel: 2},
+	"/ilm/add":    s3Complete{deepLevel: 2},
+	"/ilm/edit":   s3Complete{deepLevel: 2},
+	"/ilm/remove": s3Complete{deepLevel: 2},
+	"/ilm/export": s3Complete{deepLevel: 2},
+	"/ilm/import": s3Complete{deepLevel: 2},
+
+	"/ilm/restore":        s3Completer,
+	"/ilm/restore/status": s3Completer,
+
+	"/ilm/rule/list":     s3Complete{deepLevel: 2},
+	"/ilm/rule/add":      s3Complete{deepLevel: 2},
+	"/ilm/rule/edit":     s3Complete{deepLevel: 2},
+	"/ilm/rule/remove":   s3Complete{deepLevel: 2},
+	"/ilm/rule/export":   s3Complete{deepLevel: 2},
+	"/ilm/rule/import":   s3Complete{deepLevel: 2},
+	"/ilm/rule/simulate": s3Complete{deepLevel: 2},
+	"/ilm/rule/restore":  s3Completer,
 
 	"/undo": s3Completer,
 
@@ -346,10 +359,13 @@ var completeCmds = map[string]complete.Predictor{
 	"/admin/inspect":   s3Completer,
 	"/admin/top/locks": aliasCompleter,
 	"/admin/top/api":   aliasCompleter,
+	"/admin/top/net":   aliasCompleter,
 
 	"/admin/scanner/status": aliasCompleter,
 	"/admin/scanner/trace":  aliasCompleter,
 
+	"/admin/lock/clear": aliasCompleter,
+
 	"/admin/service/stop":     aliasCompleter,
 	"/admin/service/restart":  aliasCompleter,
 	"/admin/service/freeze":   aliasCompleter,
@@ -420,14 +436,15 @@ var completeCmds = map[string]complete.Predictor{
 	"/admin/user/info":    aliasCompleter,
 	"/admin/user/policy":  aliasCompleter,
 
-	"/admin/user/svcacct/add":     aliasCompleter,
-	"/admin/user/svcacct/list":    aliasCompleter,
-	"/admin/user/svcacct/remove":  aliasCompleter,
-	"/admin/user/svcacct/info":    aliasCompleter,
-	"/admin/user/svcacct/edit":    aliasCompleter,
-	"/admin/user/svcacct/set":     aliasCompleter,
-	"/admin/user/svcacct/enable":  aliasCompleter,
-	"/admin/user/svcacct/disable": aliasCompleter,
+	"/admin/user/svcacct/add":         aliasCompleter,
+	"/admin/user/svcacct/list":        aliasCompleter,
+	"/admin/user/svcacct/remove":      aliasCompleter,
+	"/admin/user/svcacct/info":        aliasCompleter,
+	"/admin/user/svcacct/edit":        aliasCompleter,
+	"/admin/user/svcacct/set":         aliasCompleter,
+	"/admin/user/svcacct/enable":      aliasCompleter,
+	"/admin/user/svcacct/disable":     aliasCompleter,
+	"/admin/user/svcacct/policy/diff": aliasCompleter,
 
 	"/admin/user/sts/info": aliasCompleter,
 
@@ -437,6 +454,7 @@ var completeCmds = map[string]complete.Predictor{
 	"/admin/group/list":    aliasCompleter,
 	"/admin/group/remove":  aliasCompleter,
 	"/admin/group/info":    aliasCompleter,
+	"/admin/group/expand":  aliasCompleter,
 
 	"/admin/bucket/remote/add":    aliasCompleter,
 	"/admin/bucket/remote/edit":   aliasCompleter,
@@ -447,6 +465,7 @@ var completeCmds = map[string]complete.Predictor{
 	"/admin/kms/key/create": aliasCompleter,
 	"/admin/kms/key/status": aliasCompleter,
 	"/admin/kms/key/list":   aliasCompleter,
+	"/admin/kms/key/test":   aliasCompleter,
 
 	"/admin/subnet/health":   aliasCompleter,
 	"/admin/subnet/register": aliasCompleter,
@@ -480,11 +499,12 @@ var completeCmds = map[string]complete.Predictor{
 	"/admin/cluster/iam/export":    aliasCompleter,
 	"/admin/cluster/iam/import":    aliasCompleter,
 
-	"/alias/set":    nil,
-	"/alias/list":   aliasCompleter,
-	"/alias/remove": aliasCompleter,
-	"/alias/import": nil,
-	"/alias/export": aliasCompleter,
+	"/alias/set":        nil,
+	"/alias/list":       aliasCompleter,
+	"/alias/remove":     aliasCompleter,
+	"/alias/import":     nil,
+	"/alias/import-aws": nil,
+	"/alias/export":     aliasCompleter,
 
 	"/support/callhome":     aliasCompleter,
 	"/support/register":     aliasCompleter,
@@ -511,6 +531,10 @@ var completeCmds = map[string]complete.Predictor{
 
 	"/update":         nil,
 	"/ready":          aliasCompleter,
+	"/run":            nil,
+	"/serve":          nil,
+	"/compat":         s3Complete{deepLevel: 2},
+	"/scan":           s3Complete{deepLevel: 2},
 	"/ping":           aliasCompleter,
 	"/od":             nil,
 	"/batch/generate": aliasCompleter,
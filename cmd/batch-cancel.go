@@ -20,10 +20,12 @@ package cmd
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/fatih/color"
 	"github.com/minio/cli"
 	json "github.com/minio/colorjson"
+	"github.com/minio/madmin-go/v3"
 	"github.com/minio/mc/pkg/probe"
 	"github.com/minio/pkg/v3/console"
 )
@@ -33,6 +35,14 @@ var batchCancelFlags = []cli.Flag{
 		Name:  "id",
 		Usage: "job id",
 	},
+	cli.BoolFlag{
+		Name:  "wait",
+		Usage: "wait until the job has fully stopped",
+	},
+	cli.DurationFlag{
+		Name:  "timeout",
+		Usage: "maximum duration to wait for the job to stop, requires --wait",
+	},
 }
 
 var batchCancelCmd = cli.Command{
@@ -46,7 +56,7 @@ var batchCancelCmd = cli.Command{
   {{.HelpName}} - {{.Usage}}
 
 USAGE:
-  {{.HelpName}} TARGET JOBFILE
+  {{.HelpName}} TARGET JOBID
 
 FLAGS:
   {{range .VisibleFlags}}{{.}}
@@ -54,6 +64,9 @@ FLAGS:
 EXAMPLES:
   1. Cancel ongoing batch job:
      {{.Prompt}} {{.HelpName}} myminio <job-id>
+
+  2. Cancel ongoing batch job and wait up to 5 minutes until it has fully stopped:
+     {{.Prompt}} {{.HelpName}} myminio <job-id> --wait --timeout 5m
 `,
 }
 
@@ -82,6 +95,38 @@ func checkBatchCancelSyntax(ctx *cli.Context) {
 	if len(ctx.Args()) != 2 {
 		showCommandHelpAndExit(ctx, 1) // last argument is exit code
 	}
+	if ctx.IsSet("timeout") && !ctx.Bool("wait") {
+		fatalIf(errInvalidArgument().Trace(ctx.Args()...), "--timeout requires --wait.")
+	}
+}
+
+// waitBatchJobStopped polls the server until the job is no longer listed
+// as an active job.
+func waitBatchJobStopped(ctx context.Context, client *madmin.AdminClient, jobID string) error {
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+
+	for {
+		res, e := client.ListBatchJobs(ctx, &madmin.ListBatchJobsFilter{})
+		if e != nil {
+			return e
+		}
+		active := false
+		for _, job := range res.Jobs {
+			if job.ID == jobID {
+				active = true
+				break
+			}
+		}
+		if !active {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
 }
 
 // mainBatchCancel is the handle for "mc batch cancel" command.
@@ -103,6 +148,17 @@ func mainBatchCancel(ctx *cli.Context) error {
 	e := adminClient.CancelBatchJob(ctxt, jobID)
 	fatalIf(probe.NewError(e), "Unable to cancel job")
 
+	if ctx.Bool("wait") {
+		waitCtx := ctxt
+		if timeout := ctx.Duration("timeout"); timeout > 0 {
+			var waitCancel context.CancelFunc
+			waitCtx, waitCancel = context.WithTimeout(ctxt, timeout)
+			defer waitCancel()
+		}
+		e = waitBatchJobStopped(waitCtx, adminClient, jobID)
+		fatalIf(probe.NewError(e), "Unable to wait for job `%s` to stop", jobID)
+	}
+
 	printMsg(batchCancelMessage{
 		Status: "Canceled",
 		JobID:  jobID,
@@ -20,6 +20,7 @@ package cmd
 import (
 	"context"
 	"strings"
+	"sync"
 
 	humanize "github.com/dustin/go-humanize"
 	"github.com/minio/cli"
@@ -62,10 +63,59 @@ EXAMPLES:
 `,
 }
 
+// Number of batch job states fetched at the same time.
+const batchListStatusWorkers = 8
+
+// batchJobEntry is a listed batch job along with its current state
+type batchJobEntry struct {
+	madmin.BatchJobResult
+	State string `json:"state"`
+}
+
 // batchListMessage container for file batchList messages
 type batchListMessage struct {
-	Status string                  `json:"status"`
-	Jobs   []madmin.BatchJobResult `json:"jobs"`
+	Status string          `json:"status"`
+	Jobs   []batchJobEntry `json:"jobs"`
+}
+
+// batchJobState returns a human readable state of a job from its last metric.
+func batchJobState(m madmin.JobMetric) string {
+	switch {
+	case m.Complete:
+		return "completed"
+	case m.Failed:
+		return "failed"
+	case m.RetryAttempts > 0:
+		return "retrying"
+	}
+	return "running"
+}
+
+// batchJobStates fetches the state of the jobs, a few at a time. The state
+// of a job whose status cannot be fetched is 'unknown'.
+func batchJobStates(ctx context.Context, client *madmin.AdminClient, jobs []madmin.BatchJobResult) []batchJobEntry {
+	entries := make([]batchJobEntry, len(jobs))
+	idxCh := make(chan int)
+	var wg sync.WaitGroup
+	for i := 0; i < min(batchListStatusWorkers, len(jobs)); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for idx := range idxCh {
+				state := "unknown"
+				if st, e := client.BatchJobStatus(ctx, jobs[idx].ID); e == nil {
+					state = batchJobState(st.LastMetric)
+				}
+				entries[idx] = batchJobEntry{BatchJobResult: jobs[idx], State: state}
+			}
+		}()
+	}
+	for idx := range jobs {
+		idxCh <- idx
+	}
+	close(idxCh)
+	wg.Wait()
+	return entries
 }
 
 // String colorized batchList message
@@ -90,13 +140,14 @@ func (c batchListMessage) String() string {
 	table.SetTablePadding("\t") // pad with tabs
 	table.SetNoWhiteSpace(true)
 
-	table.SetHeader([]string{"ID", "TYPE", "USER", "STARTED"})
+	table.SetHeader([]string{"ID", "TYPE", "STATE", "USER", "STARTED"})
 	data := make([][]string, 0, 4)
 
 	for _, job := range c.Jobs {
 		data = append(data, []string{
 			job.ID,
 			string(job.Type),
+			job.State,
 			job.User,
 			humanize.Time(job.Started),
 		})
@@ -146,7 +197,7 @@ func mainBatchList(ctx *cli.Context) error {
 
 	printMsg(batchListMessage{
 		Status: "success",
-		Jobs:   res.Jobs,
+		Jobs:   batchJobStates(ctxt, adminClient, res.Jobs),
 	})
 	return nil
 }
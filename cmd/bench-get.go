@@ -0,0 +1,64 @@
+// Copyright (c) 2015-2024 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"math/rand"
+
+	"github.com/minio/cli"
+)
+
+var benchGetCmd = cli.Command{
+	Name:         "get",
+	Usage:        "benchmark downloads of objects",
+	Action:       mainBenchGet,
+	OnUsageError: onUsageError,
+	Before:       setGlobalsFromContext,
+	Flags:        append(append(benchFlags, benchReadFlags...), globalFlags...),
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} [FLAGS] TARGET
+{{if .VisibleFlags}}
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}{{end}}
+DESCRIPTION:
+  Upload a set of objects of random data to the bucket TARGET, then download them at random with
+  concurrent requests for the duration of the benchmark, and report the throughput, the latency
+  percentiles and the errors of the downloads. Objects are created under a random 'mc-bench-' prefix
+  which is removed at the end of the benchmark.
+
+EXAMPLES:
+  1. Benchmark downloads of 1MiB objects from a bucket with 64 concurrent requests for 2 minutes.
+     {{.Prompt}} {{.HelpName}} --size 1MiB --concurrency 64 --duration 2m myminio/mybucket
+
+  2. Benchmark downloads from a set of 1000 objects of 16MiB.
+     {{.Prompt}} {{.HelpName}} --size 16MiB --objects 1000 myminio/mybucket
+`,
+}
+
+// mainBenchGet is the handle for "mc bench get" command.
+func mainBenchGet(cliCtx *cli.Context) error {
+	opts := parseBenchSyntax(cliCtx, true)
+	runBench(cliCtx, opts, func(*rand.Rand) string {
+		return benchOpGet
+	})
+	return nil
+}
@@ -0,0 +1,170 @@
+// Copyright (c) 2015-2024 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"math/rand"
+	"time"
+
+	"github.com/dustin/go-humanize"
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/pkg/v3/console"
+)
+
+var benchSubcommands = []cli.Command{
+	benchPutCmd,
+	benchGetCmd,
+	benchMixedCmd,
+}
+
+var benchCmd = cli.Command{
+	Name:            "bench",
+	Usage:           "run a client side performance benchmark",
+	Action:          mainBench,
+	Before:          setGlobalsFromContext,
+	Flags:           globalFlags,
+	HideHelpCommand: true,
+	Subcommands:     benchSubcommands,
+}
+
+// Flags common to all the bench subcommands.
+var benchFlags = []cli.Flag{
+	cli.StringFlag{
+		Name:  "size",
+		Usage: "size of the objects, e.g. 64KiB, 1MiB",
+		Value: "1MiB",
+	},
+	cli.IntFlag{
+		Name:  "concurrency",
+		Usage: "number of concurrent requests",
+		Value: 64,
+	},
+	cli.DurationFlag{
+		Name:  "duration",
+		Usage: "duration of the benchmark",
+		Value: time.Minute,
+	},
+	cli.BoolFlag{
+		Name:  "no-cleanup",
+		Usage: "keep the objects created by the benchmark",
+	},
+}
+
+// Flags of the bench subcommands reading objects.
+var benchReadFlags = []cli.Flag{
+	cli.IntFlag{
+		Name:  "objects",
+		Usage: "number of objects uploaded before the benchmark and read by GET requests",
+		Value: 100,
+	},
+}
+
+func mainBench(ctx *cli.Context) error {
+	commandNotFound(ctx, benchSubcommands)
+	return nil
+}
+
+// benchSetColors sets the colors of the bench output.
+func benchSetColors() {
+	console.SetColor("BenchHeader", color.New(color.Bold))
+	console.SetColor("BenchNoErrors", color.New(color.FgGreen))
+	console.SetColor("BenchErrors", color.New(color.FgRed, color.Bold))
+	console.SetColor("BenchProgress", color.New(color.FgCyan))
+}
+
+// parseBenchSyntax validates the arguments of a bench subcommand and
+// returns the options of the benchmark.
+func parseBenchSyntax(cliCtx *cli.Context, withReads bool) benchOptions {
+	if len(cliCtx.Args()) != 1 {
+		showCommandHelpAndExit(cliCtx, 1) // last argument is exit code
+	}
+
+	size, e := humanize.ParseBytes(cliCtx.String("size"))
+	if e != nil || size == 0 {
+		fatalIf(errInvalidArgument().Trace(cliCtx.String("size")), "Invalid --size, it must be a positive size such as 1MiB.")
+	}
+	opts := benchOptions{
+		size:        int64(size),
+		concurrency: cliCtx.Int("concurrency"),
+		duration:    cliCtx.Duration("duration"),
+		noCleanup:   cliCtx.Bool("no-cleanup"),
+	}
+	if opts.concurrency < 1 {
+		fatalIf(errInvalidArgument().Trace(cliCtx.String("concurrency")), "Invalid --concurrency, it must be at least 1.")
+	}
+	if opts.duration <= 0 {
+		fatalIf(errInvalidArgument().Trace(cliCtx.String("duration")), "Invalid --duration, it must be positive.")
+	}
+	if withReads {
+		opts.objects = cliCtx.Int("objects")
+		if opts.objects < 1 {
+			fatalIf(errInvalidArgument().Trace(cliCtx.String("objects")), "Invalid --objects, it must be at least 1.")
+		}
+	}
+	return opts
+}
+
+// runBench runs a benchmark of the operations chosen by nextOp on the
+// bucket of the argument of cliCtx and prints its results.
+func runBench(cliCtx *cli.Context, opts benchOptions, nextOp func(r *rand.Rand) string) {
+	benchSetColors()
+
+	ctx, cancelBench := context.WithCancel(globalContext)
+	defer cancelBench()
+
+	targetURL := cliCtx.Args().Get(0)
+	clnt, err := newClient(targetURL)
+	fatalIf(err.Trace(targetURL), "Unable to initialize target `"+targetURL+"`.")
+	s3Clnt, ok := clnt.(*S3Client)
+	if !ok {
+		fatalIf(errInvalidArgument().Trace(targetURL), "`"+targetURL+"` is not an S3 bucket.")
+	}
+	b, err := newBenchmark(s3Clnt, opts)
+	fatalIf(err.Trace(targetURL), "Unable to initialize the benchmark of `"+targetURL+"`.")
+	exists, e := b.api.BucketExists(ctx, b.bucket)
+	fatalIf(probe.NewError(e).Trace(targetURL), "Unable to check the bucket of `"+targetURL+"`.")
+	if !exists {
+		fatalIf(errInvalidArgument().Trace(targetURL), "Bucket `"+b.bucket+"` does not exist.")
+	}
+
+	defer func() {
+		if opts.noCleanup {
+			return
+		}
+		// Remove the objects even when the benchmark is interrupted.
+		errorIf(b.cleanup(context.Background()).Trace(targetURL), "Unable to remove the objects created by the benchmark under `"+b.prefix+"`.")
+	}()
+
+	if opts.objects > 0 {
+		if err = b.prepare(ctx); err != nil {
+			errorIf(err.Trace(targetURL), "Unable to upload the objects of the benchmark.")
+			return
+		}
+	}
+	results, elapsed := b.run(ctx, nextOp)
+	printMsg(benchMessage{
+		Target:      targetURL,
+		Size:        opts.size,
+		Concurrency: opts.concurrency,
+		Elapsed:     elapsed,
+		Results:     results,
+	})
+}
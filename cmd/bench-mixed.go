@@ -0,0 +1,79 @@
+// Copyright (c) 2015-2024 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"math/rand"
+
+	"github.com/minio/cli"
+)
+
+var benchMixedFlags = []cli.Flag{
+	cli.IntFlag{
+		Name:  "get-percent",
+		Usage: "percentage of GET requests, the other requests are PUT requests",
+		Value: 50,
+	},
+}
+
+var benchMixedCmd = cli.Command{
+	Name:         "mixed",
+	Usage:        "benchmark a mix of uploads and downloads of objects",
+	Action:       mainBenchMixed,
+	OnUsageError: onUsageError,
+	Before:       setGlobalsFromContext,
+	Flags:        append(append(append(benchMixedFlags, benchFlags...), benchReadFlags...), globalFlags...),
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} [FLAGS] TARGET
+{{if .VisibleFlags}}
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}{{end}}
+DESCRIPTION:
+  Upload a set of objects of random data to the bucket TARGET, then run a mix of downloads of these
+  objects and uploads of new objects with concurrent requests for the duration of the benchmark, and
+  report the throughput, the latency percentiles and the errors of each operation. Objects are created
+  under a random 'mc-bench-' prefix which is removed at the end of the benchmark.
+
+EXAMPLES:
+  1. Benchmark an even mix of uploads and downloads of 1MiB objects for 2 minutes.
+     {{.Prompt}} {{.HelpName}} --size 1MiB --concurrency 64 --duration 2m myminio/mybucket
+
+  2. Benchmark a read heavy workload with 80% of downloads.
+     {{.Prompt}} {{.HelpName}} --get-percent 80 myminio/mybucket
+`,
+}
+
+// mainBenchMixed is the handle for "mc bench mixed" command.
+func mainBenchMixed(cliCtx *cli.Context) error {
+	opts := parseBenchSyntax(cliCtx, true)
+	opts.getPercent = cliCtx.Int("get-percent")
+	if opts.getPercent < 0 || opts.getPercent > 100 {
+		fatalIf(errInvalidArgument().Trace(cliCtx.String("get-percent")), "Invalid --get-percent, it must be between 0 and 100.")
+	}
+	runBench(cliCtx, opts, func(r *rand.Rand) string {
+		if r.Intn(100) < opts.getPercent {
+			return benchOpGet
+		}
+		return benchOpPut
+	})
+	return nil
+}
@@ -0,0 +1,422 @@
+// Copyright (c) 2015-2024 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	crand "crypto/rand"
+	"fmt"
+	"io"
+	"math"
+	"math/rand"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"text/tabwriter"
+	"time"
+
+	"github.com/dustin/go-humanize"
+	"github.com/google/uuid"
+	json "github.com/minio/colorjson"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/pkg/v3/console"
+)
+
+// Interval between two refreshes of the bench progress line.
+const benchProgressInterval = time.Second
+
+// Operations of a benchmark.
+const (
+	benchOpPut = "PUT"
+	benchOpGet = "GET"
+)
+
+// benchOptions are the parameters of a benchmark.
+type benchOptions struct {
+	size        int64
+	concurrency int
+	duration    time.Duration
+	// Number of objects uploaded before a GET or mixed benchmark.
+	objects int
+	// Percentage of GET operations of a mixed benchmark.
+	getPercent int
+	noCleanup  bool
+}
+
+// benchLatency is the distribution of the latencies of an operation.
+type benchLatency struct {
+	Min time.Duration `json:"min"`
+	Avg time.Duration `json:"avg"`
+	P50 time.Duration `json:"p50"`
+	P90 time.Duration `json:"p90"`
+	P99 time.Duration `json:"p99"`
+	Max time.Duration `json:"max"`
+}
+
+// benchOpResult is the result of the benchmark of an operation.
+type benchOpResult struct {
+	Operation   string       `json:"operation"`
+	Ops         uint64       `json:"ops"`
+	Errors      uint64       `json:"errors"`
+	Bytes       uint64       `json:"bytes"`
+	OpsRate     float64      `json:"opsPerSecond"`
+	BytesRate   float64      `json:"bytesPerSecond"`
+	Latency     benchLatency `json:"latency"`
+	LastError   string       `json:"lastError,omitempty"`
+	latencies   []time.Duration
+	lastErrorAt time.Time
+}
+
+// benchMessage is the result of a benchmark.
+type benchMessage struct {
+	Status      string          `json:"status"`
+	Target      string          `json:"target"`
+	Size        int64           `json:"size"`
+	Concurrency int             `json:"concurrency"`
+	Elapsed     time.Duration   `json:"elapsed"`
+	Results     []benchOpResult `json:"results"`
+}
+
+// JSON jsonified bench message.
+func (b benchMessage) JSON() string {
+	b.Status = "success"
+	benchJSONBytes, e := json.MarshalIndent(b, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+	return string(benchJSONBytes)
+}
+
+// String colorized bench message.
+func (b benchMessage) String() string {
+	var s strings.Builder
+	fmt.Fprintf(&s, "Benchmark of `%s`: %s objects, %d concurrent requests, %s.\n\n",
+		b.Target, humanize.IBytes(uint64(b.Size)), b.Concurrency, b.Elapsed.Round(time.Second))
+
+	w := tabwriter.NewWriter(&s, 1, 8, 3, ' ', 0)
+	fmt.Fprintln(w, console.Colorize("BenchHeader", "OPERATION\tOPS\tOPS/S\tTHROUGHPUT\tERRORS\tAVG\tP50\tP90\tP99\tMAX"))
+	for _, r := range b.Results {
+		errs := console.Colorize("BenchNoErrors", humanize.Comma(int64(r.Errors)))
+		if r.Errors > 0 {
+			errs = console.Colorize("BenchErrors", humanize.Comma(int64(r.Errors)))
+		}
+		fmt.Fprintf(w, "%s\t%s\t%.1f\t%s/s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			r.Operation, humanize.Comma(int64(r.Ops)), r.OpsRate, humanize.IBytes(uint64(r.BytesRate)), errs,
+			benchRound(r.Latency.Avg), benchRound(r.Latency.P50), benchRound(r.Latency.P90),
+			benchRound(r.Latency.P99), benchRound(r.Latency.Max))
+	}
+	w.Flush()
+	for _, r := range b.Results {
+		if r.LastError != "" {
+			fmt.Fprintf(&s, "\n%s", console.Colorize("BenchErrors", "Last "+r.Operation+" error: "+r.LastError))
+		}
+	}
+	return strings.TrimSuffix(s.String(), "\n")
+}
+
+// benchRound rounds a latency for display.
+func benchRound(d time.Duration) time.Duration {
+	switch {
+	case d >= time.Second:
+		return d.Round(time.Millisecond)
+	case d >= time.Millisecond:
+		return d.Round(10 * time.Microsecond)
+	}
+	return d.Round(time.Microsecond)
+}
+
+// benchPercentile returns the p-th percentile (0-100) of sorted latencies
+// using the nearest rank method.
+func benchPercentile(sorted []time.Duration, p float64) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+	rank := int(math.Ceil(p/100*float64(len(sorted)))) - 1
+	if rank < 0 {
+		rank = 0
+	}
+	return sorted[rank]
+}
+
+// finish computes the rates and the latency distribution of the
+// operations recorded during elapsed.
+func (r *benchOpResult) finish(elapsed time.Duration) {
+	if elapsed > 0 {
+		r.OpsRate = float64(r.Ops) / elapsed.Seconds()
+		r.BytesRate = float64(r.Bytes) / elapsed.Seconds()
+	}
+	if len(r.latencies) == 0 {
+		return
+	}
+	sort.Slice(r.latencies, func(i, j int) bool { return r.latencies[i] < r.latencies[j] })
+	var total time.Duration
+	for _, l := range r.latencies {
+		total += l
+	}
+	r.Latency = benchLatency{
+		Min: r.latencies[0],
+		Avg: total / time.Duration(len(r.latencies)),
+		P50: benchPercentile(r.latencies, 50),
+		P90: benchPercentile(r.latencies, 90),
+		P99: benchPercentile(r.latencies, 99),
+		Max: r.latencies[len(r.latencies)-1],
+	}
+}
+
+// merge adds the operations recorded by a worker to r.
+func (r *benchOpResult) merge(o *benchOpResult) {
+	r.Ops += o.Ops
+	r.Errors += o.Errors
+	r.Bytes += o.Bytes
+	r.latencies = append(r.latencies, o.latencies...)
+	if o.lastErrorAt.After(r.lastErrorAt) {
+		r.LastError, r.lastErrorAt = o.LastError, o.lastErrorAt
+	}
+}
+
+// record records the outcome of an operation which took latency.
+func (r *benchOpResult) record(latency time.Duration, size int64, e error) {
+	if e != nil {
+		r.Errors++
+		r.LastError, r.lastErrorAt = e.Error(), time.Now()
+		return
+	}
+	r.Ops++
+	r.Bytes += uint64(size)
+	r.latencies = append(r.latencies, latency)
+}
+
+// benchmark generates load on a bucket with concurrent workers.
+type benchmark struct {
+	api    *minio.Client
+	bucket string
+	// Prefix of all the objects created by the benchmark.
+	prefix string
+	opts   benchOptions
+	data   []byte
+	ops    atomic.Uint64
+}
+
+// newBenchmark returns a benchmark of the bucket of clnt, objects are
+// created under a random prefix inside the prefix of clnt.
+func newBenchmark(clnt *S3Client, opts benchOptions) (*benchmark, *probe.Error) {
+	bucket, object := clnt.url2BucketAndObject()
+	if bucket == "" {
+		return nil, errInvalidArgument().Trace(clnt.GetURL().String())
+	}
+	if object != "" && !strings.HasSuffix(object, "/") {
+		object += "/"
+	}
+	data := make([]byte, opts.size)
+	if _, e := crand.Read(data); e != nil {
+		return nil, probe.NewError(e)
+	}
+	return &benchmark{
+		api:    clnt.api,
+		bucket: bucket,
+		prefix: object + "mc-bench-" + uuid.NewString()[:8] + "/",
+		opts:   opts,
+		data:   data,
+	}, nil
+}
+
+// put uploads an object.
+func (b *benchmark) put(ctx context.Context, object string) error {
+	_, e := b.api.PutObject(ctx, b.bucket, b.prefix+object, bytes.NewReader(b.data), int64(len(b.data)), minio.PutObjectOptions{
+		ContentType: "application/octet-stream",
+	})
+	return e
+}
+
+// get downloads an object and returns the number of bytes read.
+func (b *benchmark) get(ctx context.Context, object string) (int64, error) {
+	obj, e := b.api.GetObject(ctx, b.bucket, b.prefix+object, minio.GetObjectOptions{})
+	if e != nil {
+		return 0, e
+	}
+	defer obj.Close()
+	return io.Copy(io.Discard, obj)
+}
+
+// benchPreparedObject returns the name of the i-th object uploaded
+// before the benchmark.
+func benchPreparedObject(i int) string {
+	return fmt.Sprintf("prepared/%d", i)
+}
+
+// prepare uploads the objects read by GET operations.
+func (b *benchmark) prepare(ctx context.Context) *probe.Error {
+	var (
+		wg      sync.WaitGroup
+		next    atomic.Int64
+		errOnce sync.Once
+		err     error
+	)
+	for w := 0; w < b.opts.concurrency; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := int(next.Add(1)) - 1; i < b.opts.objects; i = int(next.Add(1)) - 1 {
+				if e := b.put(ctx, benchPreparedObject(i)); e != nil {
+					errOnce.Do(func() { err = e })
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	return probe.NewError(err)
+}
+
+// run runs a benchmark of operations chosen by nextOp until the
+// duration of the benchmark elapses or ctx is canceled.
+func (b *benchmark) run(ctx context.Context, nextOp func(r *rand.Rand) string) (results []benchOpResult, elapsed time.Duration) {
+	ctx, cancel := context.WithTimeout(ctx, b.opts.duration)
+	defer cancel()
+
+	workerResults := make([]map[string]*benchOpResult, b.opts.concurrency)
+	start := time.Now()
+	stopProgress := b.showProgress(start)
+
+	var wg sync.WaitGroup
+	for w := 0; w < b.opts.concurrency; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			rng := rand.New(rand.NewSource(time.Now().UnixNano() + int64(w)))
+			res := map[string]*benchOpResult{}
+			workerResults[w] = res
+			for n := 0; ctx.Err() == nil; n++ {
+				op := nextOp(rng)
+				var (
+					size int64
+					e    error
+				)
+				opStart := time.Now()
+				switch op {
+				case benchOpPut:
+					size = int64(len(b.data))
+					e = b.put(ctx, fmt.Sprintf("put/%d/%d", w, n))
+				case benchOpGet:
+					size, e = b.get(ctx, benchPreparedObject(rng.Intn(b.opts.objects)))
+				}
+				latency := time.Since(opStart)
+				if ctx.Err() != nil {
+					// The operation was interrupted by the end of the benchmark.
+					return
+				}
+				if res[op] == nil {
+					res[op] = &benchOpResult{Operation: op}
+				}
+				res[op].record(latency, size, e)
+				b.ops.Add(1)
+			}
+		}(w)
+	}
+	wg.Wait()
+	elapsed = time.Since(start)
+	stopProgress()
+
+	merged := map[string]*benchOpResult{}
+	for _, res := range workerResults {
+		for op, r := range res {
+			if merged[op] == nil {
+				merged[op] = &benchOpResult{Operation: op}
+			}
+			merged[op].merge(r)
+		}
+	}
+	for _, op := range []string{benchOpPut, benchOpGet} {
+		if r, ok := merged[op]; ok {
+			r.finish(elapsed)
+			results = append(results, *r)
+		}
+	}
+	return results, elapsed
+}
+
+// showProgress displays the number of operations done since start until
+// the returned function is called, nothing is displayed with --quiet or
+// --json.
+func (b *benchmark) showProgress(start time.Time) (stop func()) {
+	if globalQuiet || globalJSON {
+		return func() {}
+	}
+	line := func() string {
+		elapsed := time.Since(start)
+		return fmt.Sprintf("Benchmarking: %s operations, %s ops/s, elapsed %s/%s",
+			humanize.Comma(int64(b.ops.Load())), humanize.Comma(int64(float64(b.ops.Load())/elapsed.Seconds())),
+			elapsed.Round(time.Second), b.opts.duration)
+	}
+	doneCh := make(chan struct{})
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		ticker := time.NewTicker(benchProgressInterval)
+		defer ticker.Stop()
+		printed := false
+		for {
+			select {
+			case <-doneCh:
+				if printed {
+					// Clear the progress line.
+					console.PrintC("\r" + strings.Repeat(" ", len(line())+8) + "\r")
+				}
+				return
+			case <-ticker.C:
+				console.PrintC("\r" + console.Colorize("BenchProgress", line()))
+				printed = true
+			}
+		}
+	}()
+	return func() {
+		close(doneCh)
+		wg.Wait()
+	}
+}
+
+// cleanup removes all the objects created by the benchmark.
+func (b *benchmark) cleanup(ctx context.Context) *probe.Error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var listErr error
+	objectsCh := make(chan minio.ObjectInfo)
+	go func() {
+		defer close(objectsCh)
+		for obj := range b.api.ListObjects(ctx, b.bucket, minio.ListObjectsOptions{Prefix: b.prefix, Recursive: true}) {
+			if obj.Err != nil {
+				listErr = obj.Err
+				return
+			}
+			select {
+			case objectsCh <- obj:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	for e := range b.api.RemoveObjects(ctx, b.bucket, objectsCh, minio.RemoveObjectsOptions{}) {
+		if e.Err != nil {
+			return probe.NewError(e.Err)
+		}
+	}
+	return probe.NewError(listErr)
+}
@@ -0,0 +1,87 @@
+// Copyright (c) 2015-2024 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestBenchPercentile(t *testing.T) {
+	var sorted []time.Duration
+	for i := 1; i <= 100; i++ {
+		sorted = append(sorted, time.Duration(i)*time.Millisecond)
+	}
+	testCases := []struct {
+		p    float64
+		want time.Duration
+	}{
+		{0, time.Millisecond},
+		{50, 50 * time.Millisecond},
+		{90, 90 * time.Millisecond},
+		{99, 99 * time.Millisecond},
+		{100, 100 * time.Millisecond},
+	}
+	for _, tc := range testCases {
+		if got := benchPercentile(sorted, tc.p); got != tc.want {
+			t.Errorf("p%v: expected %v, got %v", tc.p, tc.want, got)
+		}
+	}
+	if got := benchPercentile(nil, 50); got != 0 {
+		t.Errorf("expected 0 for no samples, got %v", got)
+	}
+}
+
+func TestBenchOpResult(t *testing.T) {
+	w1 := &benchOpResult{Operation: benchOpPut}
+	w1.record(30*time.Millisecond, 100, nil)
+	w1.record(10*time.Millisecond, 100, nil)
+	w1.record(5*time.Millisecond, 0, errors.New("first error"))
+
+	w2 := &benchOpResult{Operation: benchOpPut}
+	w2.record(20*time.Millisecond, 100, nil)
+	w2.record(40*time.Millisecond, 100, nil)
+	w2.record(5*time.Millisecond, 0, errors.New("last error"))
+
+	r := &benchOpResult{Operation: benchOpPut}
+	r.merge(w1)
+	r.merge(w2)
+	r.finish(2 * time.Second)
+
+	if r.Ops != 4 || r.Errors != 2 || r.Bytes != 400 {
+		t.Fatalf("expected 4 ops, 2 errors and 400 bytes, got %d ops, %d errors and %d bytes", r.Ops, r.Errors, r.Bytes)
+	}
+	if r.OpsRate != 2 || r.BytesRate != 200 {
+		t.Errorf("expected 2 ops/s and 200 bytes/s, got %v ops/s and %v bytes/s", r.OpsRate, r.BytesRate)
+	}
+	want := benchLatency{
+		Min: 10 * time.Millisecond,
+		Avg: 25 * time.Millisecond,
+		P50: 20 * time.Millisecond,
+		P90: 40 * time.Millisecond,
+		P99: 40 * time.Millisecond,
+		Max: 40 * time.Millisecond,
+	}
+	if r.Latency != want {
+		t.Errorf("expected latency %+v, got %+v", want, r.Latency)
+	}
+	if r.LastError != "last error" {
+		t.Errorf("expected the last error, got %q", r.LastError)
+	}
+}
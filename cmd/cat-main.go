@@ -55,6 +55,10 @@ var catFlags = []cli.Flag{
 		Name:  "tail",
 		Usage: "tail number of bytes at ending of file",
 	},
+	cli.Int64Flag{
+		Name:  "length",
+		Usage: "number of bytes to display from the start offset",
+	},
 	cli.IntFlag{
 		Name:  "part-number",
 		Usage: "download only a specific part number",
@@ -68,7 +72,7 @@ var catCmd = cli.Command{
 	Action:       mainCat,
 	OnUsageError: onUsageError,
 	Before:       setGlobalsFromContext,
-	Flags:        append(append(catFlags, encCFlag), globalFlags...),
+	Flags:        append(append(catFlags, encCFlags...), globalFlags...),
 	CustomHelpTemplate: `NAME:
   {{.HelpName}} - {{.Usage}}
 
@@ -101,6 +105,12 @@ EXAMPLES:
 
   7. Display the content of a particular object version
      {{.Prompt}} {{.HelpName}} --vid "3ddac055-89a7-40fa-8cd3-530a5581b6b8" play/my-bucket/my-object
+
+  8. Stream 1MiB of an object starting at byte 4096 to a pipeline.
+     {{.Prompt}} {{.HelpName}} --offset 4096 --length 1048576 play/my-bucket/my-object | xxd
+
+  9. Display the content of an encrypted object, reading the key from a file to keep it out of the shell history.
+     {{.Prompt}} {{.HelpName}} --enc-c-file "play/my-bucket/=$HOME/.mc/keys/my-bucket.key" play/my-bucket/my-object
 `,
 }
 
@@ -166,6 +176,7 @@ type catOpts struct {
 	timeRef   time.Time
 	startO    int64
 	tailO     int64
+	lengthO   int64
 	partN     int
 	isZip     bool
 	stdinMode bool
@@ -202,21 +213,25 @@ func parseCatSyntax(ctx *cli.Context) catOpts {
 	o.isZip = ctx.Bool("zip")
 	o.startO = ctx.Int64("offset")
 	o.tailO = ctx.Int64("tail")
+	o.lengthO = ctx.Int64("length")
 	o.partN = ctx.Int("part-number")
 	if o.tailO != 0 && o.startO != 0 {
 		fatalIf(errInvalidArgument().Trace(), "You cannot specify both --tail and --offset")
 	}
-	if o.tailO < 0 || o.startO < 0 {
-		fatalIf(errInvalidArgument().Trace(), "You cannot specify negative --tail or --offset")
+	if o.tailO < 0 || o.startO < 0 || o.lengthO < 0 {
+		fatalIf(errInvalidArgument().Trace(), "You cannot specify negative --tail, --offset or --length")
+	}
+	if ctx.IsSet("length") && o.lengthO == 0 {
+		fatalIf(errInvalidArgument().Trace(), "You cannot specify a zero --length")
 	}
-	if o.isZip && (o.tailO != 0 || o.startO != 0) {
-		fatalIf(errInvalidArgument().Trace(), "You cannot combine --zip with --tail or --offset")
+	if o.isZip && (o.tailO != 0 || o.startO != 0 || o.lengthO != 0) {
+		fatalIf(errInvalidArgument().Trace(), "You cannot combine --zip with --tail, --offset or --length")
 	}
-	if o.stdinMode && (o.isZip || o.startO != 0 || o.tailO != 0) {
-		fatalIf(errInvalidArgument().Trace(), "You cannot use --zip --tail or --offset with stdin")
+	if o.stdinMode && (o.isZip || o.startO != 0 || o.tailO != 0 || o.lengthO != 0) {
+		fatalIf(errInvalidArgument().Trace(), "You cannot use --zip --tail --offset or --length with stdin")
 	}
-	if (o.tailO != 0 || o.startO != 0) && o.partN > 0 {
-		fatalIf(errInvalidArgument().Trace(), "You cannot use --part-number with --tail or --offset")
+	if (o.tailO != 0 || o.startO != 0 || o.lengthO != 0) && o.partN > 0 {
+		fatalIf(errInvalidArgument().Trace(), "You cannot use --part-number with --tail, --offset or --length")
 	}
 
 	return o
@@ -263,6 +278,9 @@ func catURL(ctx context.Context, sourceURL string, encKeyDB map[string][]prefixS
 					err := probe.NewError(fmt.Errorf("specified offset (%d) bigger than file (%d)", o.startO, content.Size))
 					return err.Trace(sourceURL)
 				}
+				if o.lengthO > 0 && o.lengthO < size {
+					size = o.lengthO
+				}
 			}
 			if o.partN != 0 {
 				size = int64(-1)
@@ -270,7 +288,7 @@ func catURL(ctx context.Context, sourceURL string, encKeyDB map[string][]prefixS
 		} else {
 			return err.Trace(sourceURL)
 		}
-		gopts := GetOptions{VersionID: versionID, Zip: o.isZip, RangeStart: o.startO, PartNumber: o.partN}
+		gopts := GetOptions{VersionID: versionID, Zip: o.isZip, RangeStart: o.startO, RangeLength: o.lengthO, PartNumber: o.partN}
 		if reader, err = getSourceStreamFromURL(ctx, sourceURL, encKeyDB, getSourceOpts{
 			GetOptions: gopts,
 			preserve:   false,
@@ -0,0 +1,391 @@
+// Copyright (c) 2015-2024 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"hash"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/dustin/go-humanize"
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	json "github.com/minio/colorjson"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/pkg/v3/console"
+)
+
+var checksumFlags = []cli.Flag{
+	cli.StringFlag{
+		Name:  "part-size",
+		Usage: "part size of the multipart upload to compute the checksums of, detected from the object by default",
+	},
+	cli.StringFlag{
+		Name:  "version-id, vid",
+		Usage: "compute the checksums of a specific object version",
+	},
+}
+
+// Compute and compare the checksums of objects and local files.
+var checksumCmd = cli.Command{
+	Name:         "checksum",
+	Usage:        "compute and compare the checksums of an object and a local file",
+	Action:       mainChecksum,
+	OnUsageError: onUsageError,
+	Before:       setGlobalsFromContext,
+	Flags:        append(append(checksumFlags, encCFlags...), globalFlags...),
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} [FLAGS] TARGET [FILE]
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+CHECKSUMS:
+  The checksums of an object are those stored by the server, SHA256, CRC32C, CRC32 or SHA1,
+  and its ETag. The checksums of a file are computed as the server computes them for an
+  object uploaded with the same parts: the ETag is the MD5 of the content, or the MD5 of
+  the MD5 of the parts followed by their count, and so are the other checksums.
+
+  With FILE, its checksums are computed with the parts of the TARGET object and compared
+  to those of the object. The part layout is read from the object when the server lists
+  its parts, it is otherwise the layout mc uses for uploads unless --part-size is given.
+
+EXAMPLES:
+  1. Display the checksums stored for an object.
+     {{.Prompt}} {{.HelpName}} myminio/mybucket/backup.tar
+
+  2. Compare a local file with an object uploaded in multiple parts.
+     {{.Prompt}} {{.HelpName}} myminio/mybucket/backup.tar /mnt/backups/backup.tar
+
+  3. Compute the checksums a local file gets when uploaded in parts of 64MiB.
+     {{.Prompt}} {{.HelpName}} --part-size 64MiB /mnt/backups/backup.tar
+
+  4. Compare a local file with an object uploaded by a tool using 8MiB parts.
+     {{.Prompt}} {{.HelpName}} --part-size 8MiB s3/mybucket/backup.tar /mnt/backups/backup.tar
+`,
+}
+
+// checksumValue is one checksum of an object and/or a file.
+type checksumValue struct {
+	Type   string `json:"type"`
+	Object string `json:"object,omitempty"`
+	File   string `json:"file,omitempty"`
+	Match  *bool  `json:"match,omitempty"`
+}
+
+// checksumMessage container for the checksums of an object and/or a file.
+type checksumMessage struct {
+	Status    string          `json:"status"`
+	Object    string          `json:"object,omitempty"`
+	File      string          `json:"file,omitempty"`
+	Size      int64           `json:"size"`
+	Parts     int             `json:"parts,omitempty"`
+	PartSize  int64           `json:"partSize,omitempty"`
+	Checksums []checksumValue `json:"checksums"`
+}
+
+// String colorized checksum message.
+func (m checksumMessage) String() string {
+	var b strings.Builder
+	layout := humanize.IBytes(uint64(m.Size))
+	if m.Parts > 0 {
+		layout += fmt.Sprintf(", %d parts of %s", m.Parts, humanize.IBytes(uint64(m.PartSize)))
+	}
+	if m.Object != "" {
+		fmt.Fprintf(&b, "Object   : %s (%s)\n", m.Object, layout)
+	}
+	if m.File != "" {
+		fmt.Fprintf(&b, "File     : %s", m.File)
+		if m.Object == "" {
+			fmt.Fprintf(&b, " (%s)", layout)
+		}
+		b.WriteString("\n")
+	}
+	for _, sum := range m.Checksums {
+		switch {
+		case sum.Match == nil && sum.Object != "":
+			fmt.Fprintf(&b, "%-8s : %s\n", sum.Type, sum.Object)
+		case sum.Match == nil:
+			fmt.Fprintf(&b, "%-8s : %s\n", sum.Type, sum.File)
+		case *sum.Match:
+			fmt.Fprintf(&b, "%-8s : %s %s\n", sum.Type, sum.Object, console.Colorize("ChecksumMatch", "(match)"))
+		default:
+			fmt.Fprintf(&b, "%-8s : %s %s\n", sum.Type, sum.Object, console.Colorize("ChecksumMismatch", "(mismatch, file has "+sum.File+")"))
+		}
+	}
+	switch m.Status {
+	case verifyStatusOK:
+		b.WriteString(console.Colorize("ChecksumMatch", "The checksums of the object and the file match."))
+	case verifyStatusMismatch:
+		b.WriteString(console.Colorize("ChecksumMismatch", "The checksums of the object and the file differ."))
+	}
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+// JSON jsonified checksum message.
+func (m checksumMessage) JSON() string {
+	jsonMessageBytes, e := json.MarshalIndent(m, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+
+	return string(jsonMessageBytes)
+}
+
+// checksumPartSizes splits size in parts of partSize bytes, the last
+// part holds the remaining bytes.
+func checksumPartSizes(size, partSize int64) []int64 {
+	if partSize <= 0 {
+		return nil
+	}
+	var sizes []int64
+	for size > partSize {
+		sizes = append(sizes, partSize)
+		size -= partSize
+	}
+	return append(sizes, size)
+}
+
+// guessPartSize returns the part size of an object of size bytes uploaded
+// in parts, trying the layout of mc uploads and then parts rounded to MiB.
+func guessPartSize(size int64, parts int) (int64, bool) {
+	if parts <= 0 || size < int64(parts) {
+		return 0, false
+	}
+	if count, partSize, _, e := minio.OptimalPartInfo(size, 0); e == nil && count == parts {
+		return partSize, true
+	}
+	partSize := (size + int64(parts) - 1) / int64(parts)
+	if rounded := (partSize + humanize.MiByte - 1) / humanize.MiByte * humanize.MiByte; len(checksumPartSizes(size, rounded)) == parts {
+		return rounded, true
+	}
+	return partSize, len(checksumPartSizes(size, partSize)) == parts
+}
+
+// fileChecksumsWithParts computes the checksums of a file as an S3 server
+// computes them for an object uploaded with parts of partSizes.
+func fileChecksumsWithParts(filePath string, partSizes []int64) (map[string]string, *probe.Error) {
+	if len(partSizes) == 0 {
+		return fileChecksums(filePath)
+	}
+	f, e := os.Open(filePath)
+	if e != nil {
+		return nil, probe.NewError(e)
+	}
+	defer f.Close()
+
+	partSums := make(map[string][][]byte, len(compareChecksumTypes))
+	for _, partSize := range partSizes {
+		hashes := make([]hash.Hash, len(compareChecksumTypes))
+		writers := make([]io.Writer, len(compareChecksumTypes))
+		for i, t := range compareChecksumTypes {
+			hashes[i] = newChecksumHash(t)
+			writers[i] = hashes[i]
+		}
+		if _, e = io.CopyN(io.MultiWriter(writers...), f, partSize); e != nil {
+			return nil, probe.NewError(e).Trace(filePath)
+		}
+		for i, t := range compareChecksumTypes {
+			partSums[t] = append(partSums[t], hashes[i].Sum(nil))
+		}
+	}
+
+	sums := make(map[string]string, len(partSums))
+	for t, s := range partSums {
+		sums[t] = compositeChecksum(t, s)
+	}
+	return sums, nil
+}
+
+// objectPartSizes returns the sizes of the parts of an object, when the
+// server lists them.
+func (c *S3Client) objectPartSizes(ctx context.Context, content *ClientContent, parts int) []int64 {
+	bucket, object := c.splitPath(content.URL.Path)
+	attrs, e := c.api.GetObjectAttributes(ctx, bucket, object, minio.ObjectAttributesOptions{
+		VersionID: content.VersionID,
+		MaxParts:  parts,
+	})
+	if e != nil || len(attrs.ObjectParts.Parts) != parts {
+		return nil
+	}
+	sizes := make([]int64, 0, parts)
+	for _, part := range attrs.ObjectParts.Parts {
+		sizes = append(sizes, int64(part.Size))
+	}
+	return sizes
+}
+
+// compareChecksums fills the checksums of msg with those known for the
+// object and the file, and sets its status from the comparison.
+func compareChecksums(msg *checksumMessage, objectSums, fileSums map[string]string) {
+	msg.Status = verifyStatusSkipped
+	for _, t := range compareChecksumTypes {
+		objectSum, fileSum := objectSums[t], fileSums[t]
+		if t == "ETag" && objectSum != "" && !md5ETagRegex.MatchString(objectSum) {
+			// The ETag of encrypted objects is not their MD5.
+			objectSum = ""
+		}
+		if objectSum == "" && (objectSums != nil || fileSum == "") {
+			continue
+		}
+		sum := checksumValue{Type: t, Object: objectSum, File: fileSum}
+		if objectSum != "" && fileSum != "" {
+			match := objectSum == fileSum
+			sum.Match = &match
+			if !match {
+				msg.Status = verifyStatusMismatch
+			} else if msg.Status != verifyStatusMismatch {
+				msg.Status = verifyStatusOK
+			}
+		}
+		msg.Checksums = append(msg.Checksums, sum)
+	}
+}
+
+func checkChecksumSyntax(cliCtx *cli.Context) {
+	if len(cliCtx.Args()) < 1 || len(cliCtx.Args()) > 2 {
+		showCommandHelpAndExit(cliCtx, 1)
+	}
+	if partSize := cliCtx.String("part-size"); partSize != "" {
+		if size, e := humanize.ParseBytes(partSize); e != nil || size == 0 {
+			fatalIf(errInvalidArgument().Trace(partSize), "Unable to parse --part-size, a positive size is expected.")
+		}
+	}
+}
+
+// mainChecksum is the handle for "mc checksum" command.
+func mainChecksum(cliCtx *cli.Context) error {
+	ctx, cancelChecksum := context.WithCancel(globalContext)
+	defer cancelChecksum()
+
+	checkChecksumSyntax(cliCtx)
+
+	console.SetColor("ChecksumMatch", color.New(color.FgGreen, color.Bold))
+	console.SetColor("ChecksumMismatch", color.New(color.FgRed, color.Bold))
+
+	encKeyDB, err := validateAndCreateEncryptionKeys(cliCtx)
+	fatalIf(err, "Unable to parse encryption keys.")
+
+	var partSize int64
+	if s := cliCtx.String("part-size"); s != "" {
+		size, _ := humanize.ParseBytes(s)
+		partSize = int64(size)
+	}
+
+	args := cliCtx.Args()
+	targetURL := args.Get(0)
+	clnt, err := newClient(targetURL)
+	fatalIf(err.Trace(targetURL), "Unable to initialize target `"+targetURL+"`.")
+
+	s3Clnt, isObject := clnt.(*S3Client)
+	if !isObject {
+		if len(args) > 1 {
+			fatalIf(errInvalidArgument().Trace(args...), "TARGET must be an object to be compared with FILE.")
+		}
+		st, e := os.Stat(targetURL)
+		fatalIf(probe.NewError(e).Trace(targetURL), "Unable to stat `"+targetURL+"`.")
+		msg := checksumMessage{File: targetURL, Size: st.Size()}
+		partSizes := checksumPartSizes(st.Size(), partSize)
+		if len(partSizes) > 0 {
+			msg.Parts, msg.PartSize = len(partSizes), partSize
+		}
+		sums, err := fileChecksumsWithParts(targetURL, partSizes)
+		fatalIf(err.Trace(targetURL), "Unable to compute the checksums of `"+targetURL+"`.")
+		compareChecksums(&msg, nil, sums)
+		msg.Status = "success"
+		printMsg(msg)
+		return nil
+	}
+
+	alias, _ := url2Alias(targetURL)
+	sse := getSSE(targetURL, encKeyDB[alias])
+	content, err := clnt.Stat(ctx, StatOptions{versionID: cliCtx.String("version-id"), sse: sse})
+	fatalIf(err.Trace(targetURL), "Unable to stat `"+targetURL+"`.")
+	if content.Type.IsDir() {
+		fatalIf(errInvalidArgument().Trace(targetURL), "`"+targetURL+"` is a folder.")
+	}
+	objectSums, err := s3Clnt.objectChecksums(ctx, content, sse)
+	fatalIf(err.Trace(targetURL), "Unable to read the checksums of `"+targetURL+"`.")
+
+	msg := checksumMessage{Object: targetURL, Size: content.Size}
+	if i := strings.LastIndexByte(objectSums["ETag"], '-'); i > 0 {
+		msg.Parts, _ = strconv.Atoi(objectSums["ETag"][i+1:])
+	}
+
+	if len(args) == 1 {
+		if msg.Parts > 0 {
+			msg.PartSize, _ = guessPartSize(content.Size, msg.Parts)
+		}
+		for _, t := range compareChecksumTypes {
+			if objectSums[t] != "" {
+				msg.Checksums = append(msg.Checksums, checksumValue{Type: t, Object: objectSums[t]})
+			}
+		}
+		msg.Status = "success"
+		printMsg(msg)
+		return nil
+	}
+
+	filePath := args.Get(1)
+	msg.File = filePath
+	st, e := os.Stat(filePath)
+	fatalIf(probe.NewError(e).Trace(filePath), "Unable to stat `"+filePath+"`.")
+	if st.Size() != content.Size {
+		fatalIf(errDummy().Trace(filePath), fmt.Sprintf("`%s` is %d bytes but `%s` is %d bytes.", filePath, st.Size(), targetURL, content.Size))
+	}
+
+	// Compute the checksums of the file with the parts of the object.
+	var partSizes []int64
+	if msg.Parts > 0 {
+		switch {
+		case partSize > 0:
+			partSizes = checksumPartSizes(content.Size, partSize)
+		default:
+			if partSizes = s3Clnt.objectPartSizes(ctx, content, msg.Parts); partSizes == nil {
+				guess, ok := guessPartSize(content.Size, msg.Parts)
+				if !ok {
+					fatalIf(errDummy().Trace(targetURL), fmt.Sprintf("Unable to find the part size of the %d parts of `%s`, please use --part-size.", msg.Parts, targetURL))
+				}
+				partSizes = checksumPartSizes(content.Size, guess)
+			}
+		}
+		if len(partSizes) != msg.Parts {
+			fatalIf(errInvalidArgument().Trace(cliCtx.String("part-size")), fmt.Sprintf("`%s` has %d parts, but %d parts are computed with --part-size.", targetURL, msg.Parts, len(partSizes)))
+		}
+		msg.PartSize = partSizes[0]
+	}
+
+	fileSums, err := fileChecksumsWithParts(filePath, partSizes)
+	fatalIf(err.Trace(filePath), "Unable to compute the checksums of `"+filePath+"`.")
+	compareChecksums(&msg, objectSums, fileSums)
+
+	printMsg(msg)
+	switch msg.Status {
+	case verifyStatusMismatch:
+		return exitStatus(globalErrorExitStatus)
+	case verifyStatusSkipped:
+		fatalIf(errDummy().Trace(targetURL), "No checksum of `"+targetURL+"` can be compared with `"+filePath+"`.")
+	}
+	return nil
+}
@@ -0,0 +1,91 @@
+// Copyright (c) 2015-2024 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"crypto/md5"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestChecksumPartSizes(t *testing.T) {
+	testCases := []struct {
+		size, partSize int64
+		expected       []int64
+	}{
+		{10, 0, nil},
+		{10, 4, []int64{4, 4, 2}},
+		{8, 4, []int64{4, 4}},
+		{3, 4, []int64{3}},
+	}
+	for i, tc := range testCases {
+		if got := checksumPartSizes(tc.size, tc.partSize); !reflect.DeepEqual(got, tc.expected) {
+			t.Errorf("test %d: expected %v, got %v", i+1, tc.expected, got)
+		}
+	}
+
+	const mib = 1 << 20
+	if partSize, ok := guessPartSize(40*mib+1, 3); !ok || partSize != 16*mib {
+		t.Errorf("expected parts of 16MiB, got %d (%v)", partSize, ok)
+	}
+	if partSize, ok := guessPartSize(20*mib, 4); !ok || partSize != 5*mib {
+		t.Errorf("expected parts of 5MiB, got %d (%v)", partSize, ok)
+	}
+	if _, ok := guessPartSize(2, 3); ok {
+		t.Error("expected no part size for 3 parts of 2 bytes")
+	}
+}
+
+func TestFileChecksumsWithParts(t *testing.T) {
+	data := []byte("0123456789")
+	filePath := filepath.Join(t.TempDir(), "file")
+	if e := os.WriteFile(filePath, data, 0o600); e != nil {
+		t.Fatal(e)
+	}
+
+	sums, err := fileChecksumsWithParts(filePath, []int64{4, 4, 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The ETag of a multipart object is the MD5 of the MD5 of its parts.
+	var partSums []byte
+	for _, part := range [][]byte{data[:4], data[4:8], data[8:]} {
+		sum := md5.Sum(part)
+		partSums = append(partSums, sum[:]...)
+	}
+	sum := md5.Sum(partSums)
+	if expected := hex.EncodeToString(sum[:]) + "-3"; sums["ETag"] != expected {
+		t.Errorf("expected ETag %s, got %s", expected, sums["ETag"])
+	}
+
+	sums, err = fileChecksumsWithParts(filePath, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sum = md5.Sum(data)
+	if expected := hex.EncodeToString(sum[:]); sums["ETag"] != expected {
+		t.Errorf("expected ETag %s, got %s", expected, sums["ETag"])
+	}
+
+	if _, err = fileChecksumsWithParts(filePath, []int64{8, 8}); err == nil {
+		t.Error("expected an error for parts larger than the file")
+	}
+}
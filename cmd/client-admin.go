@@ -117,7 +117,7 @@ func newAdminClient(aliasedURL string) (*madmin.AdminClient, *probe.Error) {
 }
 
 func newAnonymousClient(aliasedURL string) (*madmin.AnonymousClient, *probe.Error) {
-	_, urlStrFull, aliasCfg, err := expandAlias(aliasedURL)
+	alias, urlStrFull, aliasCfg, err := expandAlias(aliasedURL)
 	if err != nil {
 		return nil, err.Trace(aliasedURL)
 	}
@@ -148,16 +148,22 @@ func newAnonymousClient(aliasedURL string) (*madmin.AnonymousClient, *probe.Erro
 		return nil, probe.NewError(e)
 	}
 
+	proxyFunc := ieproxy.GetProxyFunc()
+	if proxy := getAliasProxy(alias, aliasCfg); proxy != "" {
+		proxyFunc = getProxyFunc(proxy)
+	}
+	tlsConfig := &tls.Config{
+		RootCAs:            globalRootCAs,
+		MinVersion:         tls.VersionTLS12,
+		InsecureSkipVerify: globalInsecure,
+	}
+
 	// Set custom transport
-	var transport http.RoundTripper = &http.Transport{
-		Proxy:       ieproxy.GetProxyFunc(),
-		DialContext: newCustomDialContext(&Config{}),
-		DialTLSContext: newCustomDialTLSContext(&tls.Config{
-			RootCAs:            globalRootCAs,
-			MinVersion:         tls.VersionTLS12,
-			InsecureSkipVerify: globalInsecure,
-		}),
-		MaxIdleConnsPerHost:   256,
+	tr := &http.Transport{
+		Proxy:                 proxyFunc,
+		DialContext:           newCustomDialContext(&Config{}),
+		DialTLSContext:        newCustomDialTLSContext(tlsConfig),
+		TLSClientConfig:       tlsConfig,
 		IdleConnTimeout:       90 * time.Second,
 		TLSHandshakeTimeout:   10 * time.Second,
 		ExpectContinueTimeout: 10 * time.Second,
@@ -169,6 +175,8 @@ func newAnonymousClient(aliasedURL string) (*madmin.AnonymousClient, *probe.Erro
 		//    https://golang.org/src/net/http/transport.go?h=roundTrip#L1843
 		DisableCompression: true,
 	}
+	tuneTransportPool(tr)
+	var transport http.RoundTripper = tr
 	if globalDebug {
 		transport = httptracer.GetNewTraceTransport(newTraceV4(), transport)
 	}
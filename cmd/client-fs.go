@@ -146,6 +146,12 @@ func (f *fsClient) Select(_ context.Context, _ string, _ encrypt.ServerSide, _ S
 
 // Watches for all fs events on an input path.
 func (f *fsClient) Watch(_ context.Context, options WatchOptions) (*WatchObject, *probe.Error) {
+	if options.Source != "" {
+		return nil, probe.NewError(APINotImplemented{
+			API:     "Watch with an event source",
+			APIType: "filesystem",
+		})
+	}
 	eventChan := make(chan []EventInfo)
 	errorChan := make(chan *probe.Error)
 	doneChan := make(chan struct{})
@@ -640,6 +646,12 @@ func (f *fsClient) Get(_ context.Context, opts GetOptions) (io.ReadCloser, *Clie
 		content.Metadata[metadataKey] = fileAttr
 	}
 
+	if opts.RangeLength > 0 {
+		return struct {
+			io.Reader
+			io.Closer
+		}{io.LimitReader(fileData, opts.RangeLength), fileData}, content, nil
+	}
 	return fileData, content, nil
 }
 
@@ -820,7 +832,7 @@ func (f *fsClient) List(_ context.Context, opts ListOptions) <-chan *ClientConte
 
 	if opts.Recursive {
 		if opts.ShowDir == DirNone {
-			go f.listRecursiveInRoutine(contentCh)
+			go f.listRecursiveInRoutine(contentCh, opts.WithSymlinks)
 		} else {
 			go f.listDirOpt(contentCh, opts.Incomplete, opts.WithMetadata, opts.ShowDir)
 		}
@@ -1089,7 +1101,7 @@ func (f *fsClient) listDirOpt(contentCh chan *ClientContent, isIncomplete, _ boo
 	}
 }
 
-func (f *fsClient) listRecursiveInRoutine(contentCh chan *ClientContent) {
+func (f *fsClient) listRecursiveInRoutine(contentCh chan *ClientContent, withSymlinks bool) {
 	// close channels upon return.
 	defer close(contentCh)
 	var dirName string
@@ -1155,6 +1167,20 @@ func (f *fsClient) listRecursiveInRoutine(contentCh chan *ClientContent) {
 			return e
 		}
 		if fi.Mode()&os.ModeSymlink == os.ModeSymlink {
+			if withSymlinks {
+				target, e := os.Readlink(fp)
+				if e != nil {
+					// Ignore any errors for symlink
+					return nil
+				}
+				contentCh <- &ClientContent{
+					URL:     *newClientURL(fp),
+					Time:    fi.ModTime(),
+					Type:    fi.Mode(),
+					Symlink: target,
+				}
+				return nil
+			}
 			fi, e = os.Stat(fp)
 			if e != nil {
 				// Ignore any errors for symlink
@@ -0,0 +1,143 @@
+// Copyright (c) 2015-2024 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"encoding/xml"
+	"html"
+	"io"
+	"mime"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+const (
+	// Error code of the S3 error replacing an HTML page.
+	htmlErrorCode = "UnexpectedHTMLResponse"
+	// Maximum number of bytes of an HTML page read to describe it.
+	htmlErrorMaxBody = 4 << 10
+	// Maximum length of the page snippet in the error message.
+	htmlErrorMaxSnippet = 256
+)
+
+var (
+	htmlTitleRegexp = regexp.MustCompile(`(?is)<title[^>]*>(.*?)</title>`)
+	htmlNoiseRegexp = regexp.MustCompile(`(?is)<(script|style)[^>]*>.*?</(script|style)>|<!--.*?-->`)
+	htmlTagRegexp   = regexp.MustCompile(`(?s)<[^>]*>`)
+)
+
+// htmlErrorResponse is the S3 error sent to the clients in place of an HTML page.
+type htmlErrorResponse struct {
+	XMLName xml.Name `xml:"Error"`
+	Code    string   `xml:"Code"`
+	Message string   `xml:"Message"`
+}
+
+// htmlErrorTransport replaces HTML error pages received instead of S3 or
+// admin API responses, typically served by a proxy or a login page in
+// front of the server, with an S3 error describing the page. Without it
+// the clients fail with a bare XML or JSON parse error. Successful
+// responses are never rewritten, they may be HTML objects.
+type htmlErrorTransport struct {
+	transport http.RoundTripper
+}
+
+func (t htmlErrorTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	res, err := t.transport.RoundTrip(req)
+	if err != nil || req.Method == http.MethodHead || res.StatusCode < http.StatusMultipleChoices || isS3Response(res.Header) {
+		return res, err
+	}
+
+	contentType := res.Header.Get("Content-Type")
+	if contentType == "" {
+		// Sniff the page when the content type is not provided.
+		br := bufio.NewReader(res.Body)
+		peek, _ := br.Peek(512)
+		res.Body = struct {
+			io.Reader
+			io.Closer
+		}{br, res.Body}
+		if !strings.HasPrefix(http.DetectContentType(peek), "text/html") {
+			return res, nil
+		}
+	} else if mediaType, _, _ := mime.ParseMediaType(contentType); mediaType != "text/html" {
+		return res, nil
+	}
+
+	var pageReader io.Reader = res.Body
+	if strings.EqualFold(res.Header.Get("Content-Encoding"), "gzip") {
+		if zr, e := gzip.NewReader(res.Body); e == nil {
+			pageReader = zr
+		}
+	}
+	page, _ := io.ReadAll(io.LimitReader(pageReader, htmlErrorMaxBody))
+	res.Body.Close()
+
+	msg := "received HTML error page from endpoint (likely proxy/login page): " + res.Status
+	if contentType != "" {
+		msg += ", Content-Type: " + contentType
+	}
+	if snippet := htmlSnippet(page); snippet != "" {
+		msg += ", body: " + strconv.Quote(snippet)
+	}
+	body, e := xml.Marshal(htmlErrorResponse{Code: htmlErrorCode, Message: msg})
+	if e != nil {
+		return nil, e
+	}
+
+	res.Header = res.Header.Clone()
+	res.Header.Set("Content-Type", "application/xml")
+	res.Header.Set("Content-Length", strconv.Itoa(len(body)))
+	res.Header.Del("Content-Encoding")
+	res.ContentLength = int64(len(body))
+	res.Body = io.NopCloser(bytes.NewReader(body))
+	return res, nil
+}
+
+// isS3Response returns true if the response headers were set by an S3
+// compatible server, such responses are never rewritten.
+func isS3Response(h http.Header) bool {
+	for k := range h {
+		k = strings.ToLower(k)
+		if strings.HasPrefix(k, "x-amz-") || strings.HasPrefix(k, "x-minio-") || strings.HasPrefix(k, "x-goog-") {
+			return true
+		}
+	}
+	return false
+}
+
+// htmlSnippet returns the title of an HTML page, or the beginning of its
+// text when it has no title.
+func htmlSnippet(page []byte) string {
+	text := ""
+	if m := htmlTitleRegexp.FindSubmatch(page); m != nil {
+		text = string(m[1])
+	} else {
+		text = htmlTagRegexp.ReplaceAllString(htmlNoiseRegexp.ReplaceAllString(string(page), " "), " ")
+	}
+	text = strings.Join(strings.Fields(html.UnescapeString(text)), " ")
+	if r := []rune(text); len(r) > htmlErrorMaxSnippet {
+		text = string(r[:htmlErrorMaxSnippet]) + "..."
+	}
+	return text
+}
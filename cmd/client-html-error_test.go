@@ -0,0 +1,89 @@
+// Copyright (c) 2015-2024 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"encoding/xml"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestHTMLErrorTransport(t *testing.T) {
+	testCases := []struct {
+		contentType string
+		amzHeader   bool
+		status      int
+		body        string
+		wantStatus  int
+		wantMessage string
+	}{
+		// Login page served by a proxy.
+		{"text/html; charset=utf-8", false, http.StatusUnauthorized, "<html><head><title>Sign in</title></head><body>login</body></html>", http.StatusUnauthorized, `401 Unauthorized, Content-Type: text/html; charset=utf-8, body: "Sign in"`},
+		// Proxy error page without a title.
+		{"text/html", false, http.StatusBadGateway, "<html><body><h1>Bad   gateway</h1><script>x()</script></body></html>", http.StatusBadGateway, `502 Bad Gateway, Content-Type: text/html, body: "Bad gateway"`},
+		// HTML page without a content type.
+		{"", false, http.StatusServiceUnavailable, "<!DOCTYPE html><html><title>Maintenance</title></html>", http.StatusServiceUnavailable, `503 Service Unavailable, body: "Maintenance"`},
+		// S3 error, not rewritten.
+		{"application/xml", false, http.StatusNotFound, "<Error><Code>NoSuchKey</Code></Error>", http.StatusNotFound, ""},
+		// HTML object served by an S3 server, not rewritten.
+		{"text/html", true, http.StatusOK, "<html><title>index</title></html>", http.StatusOK, ""},
+		// HTML object served by a gateway removing the S3 headers, not rewritten.
+		{"text/html", false, http.StatusOK, "<html><title>index</title></html>", http.StatusOK, ""},
+		{"", false, http.StatusOK, "<!DOCTYPE html><html><title>index</title></html>", http.StatusOK, ""},
+	}
+
+	for i, testCase := range testCases {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.Header()["Content-Type"] = []string{testCase.contentType}
+			if testCase.amzHeader {
+				w.Header().Set("X-Amz-Request-Id", "1234")
+			}
+			w.WriteHeader(testCase.status)
+			io.WriteString(w, testCase.body)
+		}))
+
+		client := &http.Client{Transport: htmlErrorTransport{transport: http.DefaultTransport}}
+		res, e := client.Get(server.URL)
+		if e != nil {
+			t.Fatalf("Test %d: unexpected error %v", i+1, e)
+		}
+		body, _ := io.ReadAll(res.Body)
+		res.Body.Close()
+		server.Close()
+
+		if res.StatusCode != testCase.wantStatus {
+			t.Errorf("Test %d: expected status %d, got %d", i+1, testCase.wantStatus, res.StatusCode)
+		}
+		if testCase.wantMessage == "" {
+			if string(body) != testCase.body {
+				t.Errorf("Test %d: expected body to be unchanged, got %s", i+1, body)
+			}
+			continue
+		}
+		var errResp htmlErrorResponse
+		if e = xml.Unmarshal(body, &errResp); e != nil {
+			t.Fatalf("Test %d: unable to parse error %v", i+1, e)
+		}
+		if errResp.Code != htmlErrorCode || !strings.HasSuffix(errResp.Message, testCase.wantMessage) {
+			t.Errorf("Test %d: unexpected error %s: %s", i+1, errResp.Code, errResp.Message)
+		}
+	}
+}
@@ -34,6 +34,7 @@ import (
 	"path/filepath"
 	"regexp"
 	"sort"
+	"strconv"
 	"strings"
 	"sync"
 	"sync/atomic"
@@ -64,6 +65,14 @@ type S3Client struct {
 	targetURL    *ClientURL
 	api          *minio.Client
 	virtualStyle bool
+	// Transport of the alias used by api, requests not sent by api
+	// go through it as well.
+	transport http.RoundTripper
+
+	// DeleteObjects requests sent in parallel by Remove and
+	// number of objects deleted by each request.
+	removeWorkers   int
+	removeBatchSize int
 }
 
 const (
@@ -112,7 +121,7 @@ func newCustomDialContext(c *Config) dialContext {
 			}
 		}
 
-		conn, err := dialer.DialContext(ctx, network, addr)
+		conn, err := dialIPFamily(ctx, dialer, network, addr)
 		if err != nil {
 			return nil, err
 		}
@@ -146,7 +155,28 @@ func newCustomDialTLSContext(tlsConf *tls.Config) dialContext {
 				addr = ip.String()
 			}
 		}
-		return dialer.DialContext(ctx, network, addr)
+		if globalIPFamily == ipFamilyAuto && globalHappyEyeballsDelay == 0 {
+			return dialer.DialContext(ctx, network, addr)
+		}
+
+		conn, err := dialIPFamily(ctx, dialer.NetDialer, network, addr)
+		if err != nil {
+			return nil, err
+		}
+		config := dialer.Config
+		if config.ServerName == "" {
+			config = config.Clone()
+			config.ServerName, _, err = net.SplitHostPort(addr)
+			if err != nil {
+				config.ServerName = addr
+			}
+		}
+		tlsConn := tls.Client(conn, config)
+		if err = tlsConn.HandshakeContext(ctx); err != nil {
+			conn.Close()
+			return nil, err
+		}
+		return tlsConn, nil
 	}
 }
 
@@ -163,6 +193,8 @@ func getConfigHash(config *Config) uint32 {
 	// Generate a hash out of s3Conf.
 	confHash := fnv.New32a()
 	confHash.Write([]byte(hostName + config.AccessKey + config.SecretKey + config.SessionToken))
+	// Clients probing a server with another signature or bucket lookup must not be shared.
+	confHash.Write([]byte(config.Signature + strconv.Itoa(int(config.Lookup))))
 	confSum := confHash.Sum32()
 	return confSum
 }
@@ -210,6 +242,7 @@ var useTrailingHeaders atomic.Bool
 // newFactory encloses New function with client cache.
 func newFactory() func(config *Config) (Client, *probe.Error) {
 	clientCache := make(map[uint32]*minio.Client)
+	transportCache := make(map[uint32]http.RoundTripper)
 	var mutex sync.Mutex
 
 	// Return New function.
@@ -279,10 +312,12 @@ func newFactory() func(config *Config) (Client, *probe.Error) {
 
 			// Cache the new MinIO Client with hash of config as key.
 			clientCache[confSum] = api
+			transportCache[confSum] = transport
 		}
 
 		// Store the new api object.
 		s3Clnt.api = api
+		s3Clnt.transport = transportCache[confSum]
 
 		return s3Clnt, nil
 	}
@@ -297,20 +332,23 @@ func (c *S3Client) GetURL() ClientURL {
 	return c.targetURL.Clone()
 }
 
-// AddNotificationConfig - Add bucket notification
-func (c *S3Client) AddNotificationConfig(ctx context.Context, arn string, events []string, prefix, suffix string, ignoreExisting bool) *probe.Error {
+// AddNotificationConfig - Add bucket notification. Adding a configuration
+// identical to an existing one is not an error, changed is false then. With
+// replace, the configurations of the same ARN sharing any of the events are
+// replaced by the new one instead of overlapping with it.
+func (c *S3Client) AddNotificationConfig(ctx context.Context, arn string, events []string, prefix, suffix string, ignoreExisting, replace bool) (changed bool, err *probe.Error) {
 	bucket, _ := c.url2BucketAndObject()
 
-	accountArn, err := notification.NewArnFromString(arn)
-	if err != nil {
-		return probe.NewError(invalidArgumentErr(err)).Untrace()
+	accountArn, e := notification.NewArnFromString(arn)
+	if e != nil {
+		return false, probe.NewError(invalidArgumentErr(e)).Untrace()
 	}
 	nc := notification.NewConfig(accountArn)
 
 	// Get any enabled notification.
 	mb, e := c.api.GetBucketNotification(ctx, bucket)
 	if e != nil {
-		return probe.NewError(e)
+		return false, probe.NewError(e)
 	}
 
 	// Configure events
@@ -331,7 +369,7 @@ func (c *S3Client) AddNotificationConfig(ctx context.Context, arn string, events
 			nc.AddEvents(notification.EventType("s3:Scanner:ManyVersions"))
 			nc.AddEvents(notification.EventType("s3:Scanner:BigPrefix"))
 		default:
-			return errInvalidArgument().Trace(events...)
+			return false, errInvalidArgument().Trace(events...)
 		}
 	}
 	if prefix != "" {
@@ -341,31 +379,109 @@ func (c *S3Client) AddNotificationConfig(ctx context.Context, arn string, events
 		nc.AddFilterSuffix(suffix)
 	}
 
+	// keep tells whether an existing configuration of the ARN stays, it
+	// reports an identical configuration through unchanged.
+	unchanged := false
+	keep := func(cfg notification.Config) bool {
+		if notificationConfigEqual(cfg, nc) {
+			unchanged = true
+		}
+		return !replace || unchanged || !notificationEventsOverlap(cfg, nc)
+	}
+
 	switch accountArn.Service {
 	case "sns":
+		var topics []notification.TopicConfig
+		for _, t := range mb.TopicConfigs {
+			if t.Topic != accountArn.String() || keep(t.Config) {
+				topics = append(topics, t)
+			}
+		}
+		if unchanged {
+			return false, nil
+		}
+		mb.TopicConfigs = topics
 		if !mb.AddTopic(nc) {
-			return errInvalidArgument().Trace("Overlapping Topic configs")
+			return false, errInvalidArgument().Trace("Overlapping Topic configs")
 		}
 	case "sqs":
+		var queues []notification.QueueConfig
+		for _, q := range mb.QueueConfigs {
+			if q.Queue != accountArn.String() || keep(q.Config) {
+				queues = append(queues, q)
+			}
+		}
+		if unchanged {
+			return false, nil
+		}
+		mb.QueueConfigs = queues
 		if !mb.AddQueue(nc) {
-			return errInvalidArgument().Trace("Overlapping Queue configs")
+			return false, errInvalidArgument().Trace("Overlapping Queue configs")
 		}
 	case "lambda":
+		var lambdas []notification.LambdaConfig
+		for _, l := range mb.LambdaConfigs {
+			if l.Lambda != accountArn.String() || keep(l.Config) {
+				lambdas = append(lambdas, l)
+			}
+		}
+		if unchanged {
+			return false, nil
+		}
+		mb.LambdaConfigs = lambdas
 		if !mb.AddLambda(nc) {
-			return errInvalidArgument().Trace("Overlapping lambda configs")
+			return false, errInvalidArgument().Trace("Overlapping lambda configs")
 		}
 	default:
-		return errInvalidArgument().Trace(accountArn.Service)
+		return false, errInvalidArgument().Trace(accountArn.Service)
 	}
 
 	// Set the new bucket configuration
-	if err := c.api.SetBucketNotification(ctx, bucket, mb); err != nil {
-		if ignoreExisting && strings.Contains(err.Error(), "An object key name filtering rule defined with overlapping prefixes, overlapping suffixes, or overlapping combinations of prefixes and suffixes for the same event types") {
-			return nil
+	if e := c.api.SetBucketNotification(ctx, bucket, mb); e != nil {
+		if ignoreExisting && strings.Contains(e.Error(), "An object key name filtering rule defined with overlapping prefixes, overlapping suffixes, or overlapping combinations of prefixes and suffixes for the same event types") {
+			return false, nil
 		}
-		return probe.NewError(err)
+		return false, probe.NewError(e)
 	}
-	return nil
+	return true, nil
+}
+
+// notificationFilters returns the prefix and suffix filters of cfg, the
+// names of the rules are compared without case since AWS S3 returns them
+// capitalized.
+func notificationFilters(cfg notification.Config) (prefix, suffix string) {
+	if cfg.Filter == nil {
+		return "", ""
+	}
+	for _, rule := range cfg.Filter.S3Key.FilterRules {
+		switch strings.ToLower(rule.Name) {
+		case "prefix":
+			prefix = rule.Value
+		case "suffix":
+			suffix = rule.Value
+		}
+	}
+	return prefix, suffix
+}
+
+// notificationConfigEqual tells whether a and b have the same events and
+// filters.
+func notificationConfigEqual(a, b notification.Config) bool {
+	aPrefix, aSuffix := notificationFilters(a)
+	bPrefix, bSuffix := notificationFilters(b)
+	return aPrefix == bPrefix && aSuffix == bSuffix && notification.EqualEventTypeList(a.Events, b.Events)
+}
+
+// notificationEventsOverlap tells whether a and b share any event.
+func notificationEventsOverlap(a, b notification.Config) bool {
+	for _, ea := range a.Events {
+		for _, eb := range b.Events {
+			if ea == eb {
+				return true
+			}
+		}
+	}
+	return false
 }
 
 // RemoveNotificationConfig - Remove bucket notification
@@ -786,6 +902,8 @@ func (c *S3Client) notificationToEventsInfo(ninfo notification.Info) []EventInfo
 				UserAgent:    record.Source.UserAgent,
 			}
 		}
+		eventsInfo[i].ETag = record.S3.Object.ETag
+		eventsInfo[i].VersionID = record.S3.Object.VersionID
 	}
 	return eventsInfo
 }
@@ -828,6 +946,13 @@ func (c *S3Client) Watch(ctx context.Context, options WatchOptions) (*WatchObjec
 		}
 	}
 
+	if object != "" && options.Prefix == "" {
+		options.Prefix = object
+	}
+	if options.Source != "" {
+		return c.watchEventSource(ctx, bucket, options, events)
+	}
+
 	wo := &WatchObject{
 		EventInfoChan: make(chan []EventInfo),
 		ErrorChan:     make(chan *probe.Error),
@@ -838,9 +963,6 @@ func (c *S3Client) Watch(ctx context.Context, options WatchOptions) (*WatchObjec
 
 	var eventsCh <-chan notification.Info
 	if bucket != "" {
-		if object != "" && options.Prefix == "" {
-			options.Prefix = object
-		}
 		eventsCh = c.api.ListenBucketNotification(listenCtx, bucket, options.Prefix, options.Suffix, events)
 	} else {
 		eventsCh = c.api.ListenNotification(listenCtx, "", "", events)
@@ -892,8 +1014,12 @@ func (c *S3Client) Get(ctx context.Context, opts GetOptions) (io.ReadCloser, *Cl
 	if opts.Zip {
 		o.Set("x-minio-extract", "true")
 	}
-	if opts.RangeStart != 0 {
-		err := o.SetRange(opts.RangeStart, 0)
+	if opts.RangeStart != 0 || opts.RangeLength > 0 {
+		var rangeEnd int64
+		if opts.RangeLength > 0 {
+			rangeEnd = opts.RangeStart + opts.RangeLength - 1
+		}
+		err := o.SetRange(opts.RangeStart, rangeEnd)
 		if err != nil {
 			return nil, nil, probe.NewError(err)
 		}
@@ -926,7 +1052,10 @@ func (c *S3Client) Get(ctx context.Context, opts GetOptions) (io.ReadCloser, *Cl
 // Copy - copy object, uses server side copy API. Also uses an abstracted API
 // such that large file sizes will be copied in multipart manner on server
 // side.
-func (c *S3Client) Copy(ctx context.Context, source string, opts CopyOptions, progress io.Reader) *probe.Error {
+func (c *S3Client) Copy(ctx context.Context, source string, opts CopyOptions, progress io.Reader) (err *probe.Error) {
+	ctx, span := startSpan(ctx, "copy", map[string]string{"source": source, "target": c.targetURL.String()})
+	defer func() { span.End(err) }()
+
 	dstBucket, dstObject := c.url2BucketAndObject()
 	if dstBucket == "" {
 		return probe.NewError(BucketNameEmpty{})
@@ -979,7 +1108,7 @@ func (c *S3Client) Copy(ctx context.Context, source string, opts CopyOptions, pr
 
 	// Assign metadata after irrelevant parts are delete above
 	destOpts.UserMetadata = metadata
-	destOpts.ReplaceMetadata = len(metadata) > 0
+	destOpts.ReplaceMetadata = len(metadata) > 0 || opts.replaceMetadata
 
 	var e error
 	if opts.disableMultipart || opts.size < 64*1024*1024 {
@@ -1014,7 +1143,13 @@ func (c *S3Client) Copy(ctx context.Context, source string, opts CopyOptions, pr
 }
 
 // Put - upload an object with custom metadata.
-func (c *S3Client) Put(ctx context.Context, reader io.Reader, size int64, progress io.Reader, putOpts PutOptions) (int64, *probe.Error) {
+func (c *S3Client) Put(ctx context.Context, reader io.Reader, size int64, progress io.Reader, putOpts PutOptions) (n int64, err *probe.Error) {
+	ctx, span := startSpan(ctx, "put", map[string]string{"target": c.targetURL.String()})
+	defer func() {
+		span.SetAttr("size", strconv.FormatInt(n, 10))
+		span.End(err)
+	}()
+
 	bucket, object := c.url2BucketAndObject()
 	if bucket == "" {
 		return 0, probe.NewError(BucketNameEmpty{})
@@ -1056,6 +1191,20 @@ func (c *S3Client) Put(ctx context.Context, reader io.Reader, size int64, progre
 		delete(metadata, "Content-Language")
 	}
 
+	var expires time.Time
+	expiresStr, ok := metadata["Expires"]
+	if ok {
+		delete(metadata, "Expires")
+		if t, e := http.ParseTime(expiresStr); e == nil {
+			expires = t.UTC()
+		}
+	}
+
+	websiteRedirectLocation, ok := metadata["X-Amz-Website-Redirect-Location"]
+	if ok {
+		delete(metadata, "X-Amz-Website-Redirect-Location")
+	}
+
 	var tagsMap map[string]string
 	tagsHdr, ok := metadata["X-Amz-Tagging"]
 	if ok {
@@ -1084,22 +1233,24 @@ func (c *S3Client) Put(ctx context.Context, reader io.Reader, size int64, progre
 	}
 
 	opts := minio.PutObjectOptions{
-		UserMetadata:          metadata,
-		UserTags:              tagsMap,
-		Progress:              progress,
-		ContentType:           contentType,
-		CacheControl:          cacheControl,
-		ContentDisposition:    contentDisposition,
-		ContentEncoding:       contentEncoding,
-		ContentLanguage:       contentLanguage,
-		StorageClass:          strings.ToUpper(putOpts.storageClass),
-		ServerSideEncryption:  putOpts.sse,
-		SendContentMd5:        putOpts.md5,
-		Checksum:              putOpts.checksum,
-		DisableMultipart:      putOpts.disableMultipart,
-		PartSize:              putOpts.multipartSize,
-		NumThreads:            putOpts.multipartThreads,
-		ConcurrentStreamParts: putOpts.concurrentStream, // if enabled honors NumThreads for piped() uploads
+		UserMetadata:            metadata,
+		UserTags:                tagsMap,
+		Progress:                progress,
+		ContentType:             contentType,
+		CacheControl:            cacheControl,
+		ContentDisposition:      contentDisposition,
+		ContentEncoding:         contentEncoding,
+		ContentLanguage:         contentLanguage,
+		Expires:                 expires,
+		WebsiteRedirectLocation: websiteRedirectLocation,
+		StorageClass:            strings.ToUpper(putOpts.storageClass),
+		ServerSideEncryption:    putOpts.sse,
+		SendContentMd5:          putOpts.md5,
+		Checksum:                putOpts.checksum,
+		DisableMultipart:        putOpts.disableMultipart,
+		PartSize:                putOpts.multipartSize,
+		NumThreads:              putOpts.multipartThreads,
+		ConcurrentStreamParts:   putOpts.concurrentStream, // if enabled honors NumThreads for piped() uploads
 	}
 
 	if !retainUntilDate.IsZero() && !retainUntilDate.Equal(timeSentinel) {
@@ -1199,6 +1350,67 @@ type RemoveResult struct {
 	Err        *probe.Error
 }
 
+// Maximum number of objects deleted by a DeleteObjects request.
+const maxRemoveBatchSize = 1000
+
+// SetRemoveConcurrency sets the number of DeleteObjects requests sent in
+// parallel by Remove and the number of objects deleted by each request.
+func (c *S3Client) SetRemoveConcurrency(workers, batchSize int) {
+	c.removeWorkers = workers
+	c.removeBatchSize = batchSize
+}
+
+// removeObjects removes the objects received on objectsCh, objects are
+// grouped in batches of removeBatchSize objects and up to removeWorkers
+// batches are removed in parallel.
+func (c *S3Client) removeObjects(ctx context.Context, bucket string, objectsCh <-chan minio.ObjectInfo, opts minio.RemoveObjectsOptions) <-chan minio.RemoveObjectResult {
+	batchSize := c.removeBatchSize
+	if batchSize <= 0 || batchSize > maxRemoveBatchSize {
+		batchSize = maxRemoveBatchSize
+	}
+	if c.removeWorkers <= 1 && batchSize == maxRemoveBatchSize {
+		return c.api.RemoveObjectsWithResult(ctx, bucket, objectsCh, opts)
+	}
+
+	resultCh := make(chan minio.RemoveObjectResult)
+	batchCh := make(chan []minio.ObjectInfo)
+	var wg sync.WaitGroup
+	for i := 0; i < max(c.removeWorkers, 1); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for batch := range batchCh {
+				batchObjectsCh := make(chan minio.ObjectInfo, len(batch))
+				for _, object := range batch {
+					batchObjectsCh <- object
+				}
+				close(batchObjectsCh)
+				for result := range c.api.RemoveObjectsWithResult(ctx, bucket, batchObjectsCh, opts) {
+					resultCh <- result
+				}
+			}
+		}()
+	}
+
+	go func() {
+		batch := make([]minio.ObjectInfo, 0, batchSize)
+		for object := range objectsCh {
+			batch = append(batch, object)
+			if len(batch) == batchSize {
+				batchCh <- batch
+				batch = make([]minio.ObjectInfo, 0, batchSize)
+			}
+		}
+		if len(batch) > 0 {
+			batchCh <- batch
+		}
+		close(batchCh)
+		wg.Wait()
+		close(resultCh)
+	}()
+	return resultCh
+}
+
 // Remove - remove object or bucket(s).
 func (c *S3Client) Remove(ctx context.Context, isIncomplete, isRemoveBucket, isBypass, isForceDel bool, contentCh <-chan *ClientContent) <-chan RemoveResult {
 	resultCh := make(chan RemoveResult)
@@ -1211,6 +1423,7 @@ func (c *S3Client) Remove(ctx context.Context, isIncomplete, isRemoveBucket, isB
 		GovernanceBypass: isBypass,
 	}
 
+	ctx, span := startSpan(ctx, "remove", map[string]string{"target": c.targetURL.String()})
 	go func() {
 		defer close(resultCh)
 
@@ -1273,7 +1486,7 @@ func (c *S3Client) Remove(ctx context.Context, isIncomplete, isRemoveBucket, isB
 					if isIncomplete {
 						statusCh = c.removeIncompleteObjects(ctx, bucket, objectsCh)
 					} else {
-						statusCh = c.api.RemoveObjectsWithResult(ctx, bucket, objectsCh, opts)
+						statusCh = c.removeObjects(ctx, bucket, objectsCh, opts)
 					}
 				}
 
@@ -1311,7 +1524,7 @@ func (c *S3Client) Remove(ctx context.Context, isIncomplete, isRemoveBucket, isB
 					if isIncomplete {
 						statusCh = c.removeIncompleteObjects(ctx, bucket, objectsCh)
 					} else {
-						statusCh = c.api.RemoveObjectsWithResult(ctx, bucket, objectsCh, opts)
+						statusCh = c.removeObjects(ctx, bucket, objectsCh, opts)
 					}
 					prevBucket = bucket
 				}
@@ -1392,7 +1605,10 @@ func (c *S3Client) Remove(ctx context.Context, isIncomplete, isRemoveBucket, isB
 			}
 		}
 	}()
-	return resultCh
+	// A nil done channel never drops results, as Remove always sends them.
+	return endSpanOnClose(span, nil, resultCh, func(result RemoveResult) *probe.Error {
+		return result.Err
+	})
 }
 
 // MakeBucket - make a new bucket.
@@ -1518,7 +1734,7 @@ func (c *S3Client) GetAccess(ctx context.Context) (string, string, *probe.Error)
 
 // SetAccess set access policy permissions.
 func (c *S3Client) SetAccess(ctx context.Context, bucketPolicy string, isJSON bool) *probe.Error {
-	bucket, object := c.url2BucketAndObject()
+	bucket, _ := c.url2BucketAndObject()
 	if bucket == "" {
 		return probe.NewError(BucketNameEmpty{})
 	}
@@ -1528,31 +1744,43 @@ func (c *S3Client) SetAccess(ctx context.Context, bucketPolicy string, isJSON bo
 		}
 		return nil
 	}
-	policyStr, e := c.api.GetBucketPolicy(ctx, bucket)
-	if e != nil {
+	_, updated, err := c.accessPolicies(ctx, bucketPolicy)
+	if err != nil {
+		return err
+	}
+	if e := c.api.SetBucketPolicy(ctx, bucket, updated); e != nil {
 		return probe.NewError(e)
 	}
+	return nil
+}
+
+// accessPolicies - returns the current bucket policy and the policy after
+// setting the anonymous access of the object prefix, the updated policy
+// is empty when no statements are left.
+func (c *S3Client) accessPolicies(ctx context.Context, bucketPolicy string) (current, updated string, err *probe.Error) {
+	bucket, object := c.url2BucketAndObject()
+	if bucket == "" {
+		return "", "", probe.NewError(BucketNameEmpty{})
+	}
+	current, e := c.api.GetBucketPolicy(ctx, bucket)
+	if e != nil {
+		return "", "", probe.NewError(e)
+	}
 	p := policy.BucketAccessPolicy{Version: "2012-10-17"}
-	if policyStr != "" {
-		if e = json.Unmarshal([]byte(policyStr), &p); e != nil {
-			return probe.NewError(e)
+	if current != "" {
+		if e = json.Unmarshal([]byte(current), &p); e != nil {
+			return "", "", probe.NewError(e)
 		}
 	}
 	p.Statements = policy.SetPolicy(p.Statements, policy.BucketPolicy(bucketPolicy), bucket, object)
 	if len(p.Statements) == 0 {
-		if e = c.api.SetBucketPolicy(ctx, bucket, ""); e != nil {
-			return probe.NewError(e)
-		}
-		return nil
+		return current, "", nil
 	}
 	policyB, e := json.Marshal(p)
 	if e != nil {
-		return probe.NewError(e)
-	}
-	if e = c.api.SetBucketPolicy(ctx, bucket, string(policyB)); e != nil {
-		return probe.NewError(e)
+		return "", "", probe.NewError(e)
 	}
-	return nil
+	return current, string(policyB), nil
 }
 
 // listObjectWrapper - select ObjectList mode depending on arguments
@@ -1609,6 +1837,41 @@ func (c *S3Client) statIncompleteUpload(ctx context.Context, bucket, object stri
 	return nil, probe.NewError(ObjectMissing{})
 }
 
+// StatRaw - send a 'HEAD' on an object and return the response status and
+// headers exactly as sent by the server, including vendor specific headers.
+func (c *S3Client) StatRaw(ctx context.Context, opts StatOptions) (string, http.Header, *probe.Error) {
+	bucket, object := c.url2BucketAndObject()
+	if bucket == "" || object == "" {
+		return "", nil, probe.NewError(ObjectNameEmpty{})
+	}
+
+	reqParams := make(url.Values)
+	if opts.versionID != "" {
+		reqParams.Set("versionId", opts.versionID)
+	}
+	headers := make(http.Header)
+	if opts.sse != nil && opts.sse.Type() == encrypt.SSEC {
+		opts.sse.Marshal(headers)
+	}
+
+	presignedURL, e := c.api.PresignHeader(ctx, http.MethodHead, bucket, object, 5*time.Minute, reqParams, headers)
+	if e != nil {
+		return "", nil, probe.NewError(e)
+	}
+	req, e := http.NewRequestWithContext(ctx, http.MethodHead, presignedURL.String(), nil)
+	if e != nil {
+		return "", nil, probe.NewError(e)
+	}
+	req.Header = headers
+	// Sent through the transport of the alias, with its CAs, proxy and bandwidth limits.
+	resp, e := (&http.Client{Transport: c.transport, Timeout: globalConnReadDeadline}).Do(req)
+	if e != nil {
+		return "", nil, probe.NewError(e)
+	}
+	resp.Body.Close()
+	return resp.Status, resp.Header, nil
+}
+
 // Stat - send a 'HEAD' on a bucket or object to fetch its metadata. It also returns
 // a DIR type content if a prefix does exist in the server.
 func (c *S3Client) Stat(ctx context.Context, opts StatOptions) (*ClientContent, *probe.Error) {
@@ -1892,6 +2155,10 @@ func (c *S3Client) List(ctx context.Context, opts ListOptions) <-chan *ClientCon
 	c.Lock()
 	defer c.Unlock()
 
+	ctx, span := startSpan(ctx, "list", map[string]string{
+		"target":    c.targetURL.String(),
+		"recursive": strconv.FormatBool(opts.Recursive),
+	})
 	contentCh := make(chan *ClientContent)
 	go func() {
 		defer close(contentCh)
@@ -1902,7 +2169,9 @@ func (c *S3Client) List(ctx context.Context, opts ListOptions) <-chan *ClientCon
 		}
 	}()
 
-	return contentCh
+	return endSpanOnClose(span, ctx.Done(), contentCh, func(content *ClientContent) *probe.Error {
+		return content.Err
+	})
 }
 
 // versionedList returns objects versions if the S3 backend supports versioning,
@@ -2248,6 +2517,10 @@ func (c *S3Client) objectInfo2ClientContent(bucket string, entry minio.ObjectInf
 	content.StorageClass = entry.StorageClass
 	content.IsDeleteMarker = entry.IsDeleteMarker
 	content.IsLatest = entry.IsLatest
+	// The XML tags of minio.Owner are swapped, DisplayName holds
+	// the ID of the owner and ID holds its display name.
+	content.OwnerID = entry.Owner.DisplayName
+	content.OwnerName = entry.Owner.ID
 	content.Restore = entry.Restore
 	content.Metadata = map[string]string{}
 	content.UserMetadata = map[string]string{}
@@ -21,12 +21,19 @@ package cmd
 import (
 	"bytes"
 	"context"
+	"encoding/xml"
+	"fmt"
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"sort"
 	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
 
 	minio "github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/notification"
 	checkv1 "gopkg.in/check.v1"
 )
 
@@ -80,7 +87,8 @@ type objectHandler struct {
 }
 
 func (h objectHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
-	if ak := r.Header.Get("Authorization"); len(ak) == 0 {
+	// Requests are signed in the Authorization header or presigned.
+	if ak := r.Header.Get("Authorization"); len(ak) == 0 && r.URL.Query().Get("X-Amz-Signature") == "" {
 		w.WriteHeader(http.StatusForbidden)
 		return
 	}
@@ -275,6 +283,162 @@ func (s *TestSuite) TestObjectOperations(c *checkv1.C) {
 	}
 }
 
+// headCountingTransport counts the HEAD requests sent through an alias
+// transport.
+type headCountingTransport struct {
+	requests atomic.Int32
+}
+
+func (t *headCountingTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	if r.Method == http.MethodHead {
+		t.requests.Add(1)
+	}
+	return http.DefaultTransport.RoundTrip(r)
+}
+
+// Test stat --raw goes through the transport of the alias.
+func (s *TestSuite) TestStatRaw(c *checkv1.C) {
+	object := objectHandler{
+		resource: "/bucket/object",
+		data:     []byte("Hello, World"),
+	}
+	server := httptest.NewServer(object)
+	defer server.Close()
+
+	transport := &headCountingTransport{}
+	conf := new(Config)
+	conf.HostURL = server.URL + object.resource
+	conf.AccessKey = "WLGDGYAQYIGI833EV05A"
+	conf.SecretKey = "BYvgJM101sHngl2uzjXS/OBF/aMxAN06JrJ3qJlF"
+	conf.Signature = "S3v4"
+	conf.Transport = transport
+	s3c, err := S3New(conf)
+	c.Assert(err, checkv1.IsNil)
+
+	status, headers, err := s3c.(*S3Client).StatRaw(context.Background(), StatOptions{})
+	c.Assert(err, checkv1.IsNil)
+	c.Assert(status, checkv1.Equals, "200 OK")
+	c.Assert(headers.Get("ETag"), checkv1.Equals, "9af2f8218b150c351ad802c6f3d66abe")
+	c.Assert(headers.Get("Content-Length"), checkv1.Equals, strconv.Itoa(len(object.data)))
+	c.Assert(transport.requests.Load(), checkv1.Equals, int32(1))
+
+	s3c, err = S3New(&Config{
+		HostURL:   server.URL + "/bucket/missing",
+		AccessKey: conf.AccessKey,
+		SecretKey: conf.SecretKey,
+		Signature: conf.Signature,
+		Transport: transport,
+	})
+	c.Assert(err, checkv1.IsNil)
+	status, _, err = s3c.(*S3Client).StatRaw(context.Background(), StatOptions{})
+	c.Assert(err, checkv1.IsNil)
+	c.Assert(status, checkv1.Equals, "404 Not Found")
+	c.Assert(transport.requests.Load(), checkv1.Equals, int32(2))
+}
+
+// deleteObjectsHandler is an http.Handler answering DeleteObjects requests,
+// it records the number of objects of every request and fails to delete
+// the objects named "denied-*".
+type deleteObjectsHandler struct {
+	mu         sync.Mutex
+	batchSizes []int
+}
+
+func (h *deleteObjectsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if _, ok := r.URL.Query()["location"]; ok {
+		w.Write([]byte("<LocationConstraint xmlns=\"http://doc.s3.amazonaws.com/2006-03-01\"></LocationConstraint>"))
+		return
+	}
+	if _, ok := r.URL.Query()["delete"]; !ok || r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	var request struct {
+		Objects []struct {
+			Key string
+		} `xml:"Object"`
+	}
+	if e := xml.NewDecoder(r.Body).Decode(&request); e != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	h.mu.Lock()
+	h.batchSizes = append(h.batchSizes, len(request.Objects))
+	h.mu.Unlock()
+
+	var response bytes.Buffer
+	response.WriteString("<DeleteResult xmlns=\"http://s3.amazonaws.com/doc/2006-03-01/\">")
+	for _, object := range request.Objects {
+		if strings.HasPrefix(object.Key, "denied-") {
+			fmt.Fprintf(&response, "<Error><Key>%s</Key><Code>AccessDenied</Code><Message>Access Denied.</Message></Error>", object.Key)
+			continue
+		}
+		fmt.Fprintf(&response, "<Deleted><Key>%s</Key></Deleted>", object.Key)
+	}
+	response.WriteString("</DeleteResult>")
+	w.Write(response.Bytes())
+}
+
+// Test objects are removed in batches and all results are delivered.
+func (s *TestSuite) TestRemoveObjects(c *checkv1.C) {
+	testCases := []struct {
+		workers, batchSize, objects int
+		batchSizes                  []int
+	}{
+		// A single worker with full batches leaves the batching to minio-go.
+		{1, 0, 10, []int{10}},
+		{1, maxRemoveBatchSize, 2*maxRemoveBatchSize + 1, []int{1, maxRemoveBatchSize, maxRemoveBatchSize}},
+		{1, 3, 10, []int{1, 3, 3, 3}},
+		{4, 3, 10, []int{1, 3, 3, 3}},
+		{4, 0, 2500, []int{500, maxRemoveBatchSize, maxRemoveBatchSize}},
+		{8, 1, 5, []int{1, 1, 1, 1, 1}},
+		{4, 3, 0, nil},
+	}
+	for i, tc := range testCases {
+		handler := &deleteObjectsHandler{}
+		server := httptest.NewServer(handler)
+
+		conf := new(Config)
+		conf.HostURL = server.URL + "/bucket"
+		conf.AccessKey = "WLGDGYAQYIGI833EV05A"
+		conf.SecretKey = "BYvgJM101sHngl2uzjXS/OBF/aMxAN06JrJ3qJlF"
+		conf.Signature = "S3v4"
+		clnt, err := S3New(conf)
+		c.Assert(err, checkv1.IsNil)
+		s3c := clnt.(*S3Client)
+		s3c.SetRemoveConcurrency(tc.workers, tc.batchSize)
+
+		objectsCh := make(chan minio.ObjectInfo)
+		go func() {
+			defer close(objectsCh)
+			for j := 0; j < tc.objects; j++ {
+				key := fmt.Sprintf("object-%d", j)
+				if j%4 == 0 {
+					key = fmt.Sprintf("denied-%d", j)
+				}
+				objectsCh <- minio.ObjectInfo{Key: key}
+			}
+		}()
+
+		removed := make(map[string]bool)
+		var denied int
+		for result := range s3c.removeObjects(context.Background(), "bucket", objectsCh, minio.RemoveObjectsOptions{}) {
+			c.Assert(removed[result.ObjectName], checkv1.Equals, false, checkv1.Commentf("test %d: %s delivered twice", i+1, result.ObjectName))
+			removed[result.ObjectName] = true
+			if result.Err != nil {
+				c.Assert(strings.HasPrefix(result.ObjectName, "denied-"), checkv1.Equals, true, checkv1.Commentf("test %d: %v", i+1, result.Err))
+				denied++
+			}
+		}
+		server.Close()
+
+		c.Assert(len(removed), checkv1.Equals, tc.objects, checkv1.Commentf("test %d", i+1))
+		c.Assert(denied, checkv1.Equals, (tc.objects+3)/4, checkv1.Commentf("test %d", i+1))
+		sort.Ints(handler.batchSizes)
+		c.Assert(handler.batchSizes, checkv1.DeepEquals, tc.batchSizes, checkv1.Commentf("test %d", i+1))
+	}
+}
+
 var testSelectCompressionTypeCases = []struct {
 	opts            SelectObjectOpts
 	object          string
@@ -299,3 +463,26 @@ func (s *TestSuite) TestSelectCompressionType(c *checkv1.C) {
 		c.Assert(cType, checkv1.DeepEquals, test.compressionType)
 	}
 }
+
+func (s *TestSuite) TestNotificationConfigEqual(c *checkv1.C) {
+	arn := notification.NewArn("aws", "sqs", "us-east-1", "1", "q")
+	nc := notification.NewConfig(arn)
+	nc.AddEvents(notification.ObjectCreatedAll, notification.ObjectRemovedAll)
+	nc.AddFilterPrefix("photos/")
+
+	// AWS S3 returns capitalized filter names.
+	existing := notification.Config{
+		Events: []notification.EventType{notification.ObjectRemovedAll, notification.ObjectCreatedAll},
+		Filter: &notification.Filter{S3Key: notification.S3Key{
+			FilterRules: []notification.FilterRule{{Name: "Prefix", Value: "photos/"}},
+		}},
+	}
+	c.Assert(notificationConfigEqual(existing, nc), checkv1.Equals, true)
+	c.Assert(notificationEventsOverlap(existing, nc), checkv1.Equals, true)
+
+	existing.Filter.S3Key.FilterRules[0].Value = "videos/"
+	c.Assert(notificationConfigEqual(existing, nc), checkv1.Equals, false)
+
+	existing.Events = []notification.EventType{notification.ObjectAccessedAll}
+	c.Assert(notificationEventsOverlap(existing, nc), checkv1.Equals, false)
+}
@@ -56,12 +56,13 @@ const (
 
 // GetOptions holds options of the GET operation
 type GetOptions struct {
-	SSE        encrypt.ServerSide
-	VersionID  string
-	Zip        bool
-	RangeStart int64
-	PartNumber int
-	Preserve   bool
+	SSE         encrypt.ServerSide
+	VersionID   string
+	Zip         bool
+	RangeStart  int64
+	RangeLength int64
+	PartNumber  int
+	Preserve    bool
 }
 
 // PutOptions holds options for PUT operation
@@ -108,6 +109,10 @@ type ListOptions struct {
 	TimeRef           time.Time
 	ShowDir           DirOpt
 	Count             int
+
+	// List symbolic links of local folders instead of following them,
+	// their target is returned in ClientContent.Symlink.
+	WithSymlinks bool
 }
 
 // CopyOptions holds options for copying operation
@@ -119,6 +124,7 @@ type CopyOptions struct {
 	disableMultipart bool
 	isPreserve       bool
 	storageClass     string
+	replaceMetadata  bool
 }
 
 // Client - client interface
@@ -225,6 +231,9 @@ type ClientContent struct {
 	ETag         string
 	Expires      time.Time
 
+	// Target of a symbolic link, see ListOptions.WithSymlinks.
+	Symlink string
+
 	Expiration       time.Time
 	ExpirationRuleID string
 
@@ -238,6 +247,8 @@ type ClientContent struct {
 	IsDeleteMarker    bool
 	IsLatest          bool
 	ReplicationStatus string
+	OwnerID           string
+	OwnerName         string
 
 	Restore *minio.RestoreInfo
 
@@ -261,6 +272,8 @@ type Config struct {
 	ConnWriteDeadline time.Duration
 	UploadLimit       int64
 	DownloadLimit     int64
+	Proxy             string
+	STS               *aliasSTSConfig
 	Transport         http.RoundTripper
 }
 
@@ -291,6 +304,14 @@ func (config *Config) getCredsChain() ([]credentials.Provider, *probe.Error) {
 			Endpoint: stsEndpointURL.String(),
 		}
 		credsChain = append(credsChain, credsSts)
+	} else if config.STS != nil {
+		// credentials are entirely managed by the STS endpoint
+		// configured for the alias, they are refreshed on expiry.
+		credsSts, err := config.getSTSProvider()
+		if err != nil {
+			return nil, err.Trace(config.Alias)
+		}
+		return []credentials.Provider{credsSts}, nil
 	}
 
 	signType := credentials.SignatureV4
@@ -311,6 +332,69 @@ func (config *Config) getCredsChain() ([]credentials.Provider, *probe.Error) {
 	return credsChain, nil
 }
 
+// getSTSEndpoint returns the STS endpoint configured for the alias,
+// defaults to the alias URL.
+func (config *Config) getSTSEndpoint() string {
+	if config.STS == nil {
+		return ""
+	}
+	if config.STS.Endpoint != "" {
+		return config.STS.Endpoint
+	}
+	return config.HostURL
+}
+
+// getSTSProvider returns an AssumeRoleWithWebIdentity provider when a web
+// identity token file is configured, an AssumeRole provider otherwise.
+func (config *Config) getSTSProvider() (credentials.Provider, *probe.Error) {
+	stsEndpointURL, e := url.Parse(config.getSTSEndpoint())
+	if e != nil {
+		return nil, probe.NewError(fmt.Errorf("Error parsing sts endpoint: %w", e))
+	}
+	var durationSeconds int
+	if config.STS.Duration != "" {
+		duration, e := ParseDuration(config.STS.Duration)
+		if e != nil {
+			return nil, probe.NewError(e)
+		}
+		durationSeconds = int(time.Duration(duration).Seconds())
+	}
+	client := &http.Client{
+		Transport: config.getTransport(),
+	}
+	if tokenFile := config.STS.WebIdentityTokenFile; tokenFile != "" {
+		return &credentials.STSWebIdentity{
+			Client:      client,
+			STSEndpoint: stsEndpointURL.String(),
+			// the token file is read again on every refresh, it is
+			// usually rotated by an external agent.
+			GetWebIDTokenExpiry: func() (*credentials.WebIdentityToken, error) {
+				token, e := os.ReadFile(tokenFile)
+				if e != nil {
+					return nil, e
+				}
+				return &credentials.WebIdentityToken{
+					Token:  strings.TrimSpace(string(token)),
+					Expiry: durationSeconds,
+				}, nil
+			},
+			RoleARN: config.STS.RoleARN,
+		}, nil
+	}
+	return &credentials.STSAssumeRole{
+		Client:      client,
+		STSEndpoint: stsEndpointURL.String(),
+		Options: credentials.STSAssumeRoleOptions{
+			AccessKey:       config.AccessKey,
+			SecretKey:       config.SecretKey,
+			SessionToken:    config.SessionToken,
+			RoleARN:         config.STS.RoleARN,
+			RoleSessionName: config.STS.RoleSessionName,
+			DurationSeconds: durationSeconds,
+		},
+	}, nil
+}
+
 // getTransport returns a corresponding *http.Transport for the *Config
 // set withS3v2 bool to true to add traceV2 tracer.
 func (config *Config) getTransport() http.RoundTripper {
@@ -328,9 +412,58 @@ func (config *Config) isTLS() bool {
 		}
 		return isHostTLS(config) || stsEndpointURL.Scheme == "https"
 	}
+	if stsEndpoint := config.getSTSEndpoint(); stsEndpoint != "" {
+		stsEndpointURL, err := url.Parse(stsEndpoint)
+		if err != nil {
+			return false
+		}
+		return isHostTLS(config) || stsEndpointURL.Scheme == "https"
+	}
 	return isHostTLS(config)
 }
 
+// parseProxyURL - parses and validates the proxy URL of an alias.
+func parseProxyURL(proxy string) (*url.URL, *probe.Error) {
+	proxyURL, e := url.Parse(proxy)
+	if e != nil {
+		return nil, probe.NewError(e)
+	}
+	switch proxyURL.Scheme {
+	case "http", "https", "socks5", "socks5h":
+	default:
+		return nil, probe.NewError(fmt.Errorf("unsupported proxy scheme `%s`, valid options are `[http, https, socks5, socks5h]`", proxyURL.Scheme))
+	}
+	if proxyURL.Host == "" {
+		return nil, probe.NewError(fmt.Errorf("missing host in proxy `%s`", proxy))
+	}
+	return proxyURL, nil
+}
+
+// redactProxy hides the password of a proxy URL before displaying it.
+func redactProxy(proxy string) string {
+	proxyURL, e := url.Parse(proxy)
+	if e != nil {
+		return proxy
+	}
+	return proxyURL.Redacted()
+}
+
+// getProxyFunc returns the proxy selection function of a transport. The
+// proxy of the alias is used for all requests when set, otherwise the
+// proxy is picked from the HTTP_PROXY, HTTPS_PROXY and NO_PROXY variables.
+func getProxyFunc(proxy string) func(*http.Request) (*url.URL, error) {
+	if proxy == "" {
+		return http.ProxyFromEnvironment
+	}
+	proxyURL, err := parseProxyURL(proxy)
+	if err != nil {
+		return func(*http.Request) (*url.URL, error) {
+			return nil, err.ToGoError()
+		}
+	}
+	return http.ProxyURL(proxyURL)
+}
+
 func (config *Config) initTransport(withS3v2 bool) {
 	var transport http.RoundTripper
 
@@ -340,9 +473,8 @@ func (config *Config) initTransport(withS3v2 bool) {
 		transport = config.Transport
 	} else {
 		tr := &http.Transport{
-			Proxy:                 http.ProxyFromEnvironment,
+			Proxy:                 getProxyFunc(config.Proxy),
 			DialContext:           newCustomDialContext(config),
-			MaxIdleConnsPerHost:   1024,
 			WriteBufferSize:       32 << 10, // 32KiB moving up from 4KiB default
 			ReadBufferSize:        32 << 10, // 32KiB moving up from 4KiB default
 			IdleConnTimeout:       90 * time.Second,
@@ -356,12 +488,17 @@ func (config *Config) initTransport(withS3v2 bool) {
 			//    https://golang.org/src/net/http/transport.go?h=roundTrip#L1843
 			DisableCompression: true,
 		}
+		tuneTransportPool(tr)
 		if useTLS {
-			tr.DialTLSContext = newCustomDialTLSContext(&tls.Config{
+			tlsConfig := &tls.Config{
 				RootCAs:            globalRootCAs,
 				MinVersion:         tls.VersionTLS12,
 				InsecureSkipVerify: config.Insecure,
-			})
+			}
+			tr.DialTLSContext = newCustomDialTLSContext(tlsConfig)
+			// Connections tunneled through a proxy are not dialed by
+			// DialTLSContext, the transport does the handshake itself.
+			tr.TLSClientConfig = tlsConfig
 
 			// Because we create a custom TLSClientConfig, we have to opt-in to HTTP/2.
 			// See https://github.com/golang/go/issues/14275
@@ -376,6 +513,11 @@ func (config *Config) initTransport(withS3v2 bool) {
 	}
 
 	transport = limiter.New(config.UploadLimit, config.DownloadLimit, transport)
+	transport = htmlErrorTransport{transport: transport}
+
+	if globalTelemetry != nil {
+		transport = telemetryTransport{transport: transport}
+	}
 
 	if config.Debug {
 		if strings.EqualFold(config.Signature, "S3v4") {
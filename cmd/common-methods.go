@@ -35,6 +35,7 @@ import (
 	"github.com/minio/mc/pkg/probe"
 	"github.com/minio/minio-go/v7"
 	"github.com/minio/minio-go/v7/pkg/encrypt"
+	"github.com/minio/minio-go/v7/pkg/tags"
 	"github.com/minio/pkg/v3/env"
 )
 
@@ -263,6 +264,44 @@ func filterMetadata(metadata map[string]string) map[string]string {
 	return newMetadata
 }
 
+// setExpiresMetadata - adds the Expires header of an object to metadata,
+// S3 returns it outside of the object metadata.
+func setExpiresMetadata(metadata map[string]string, expires time.Time) {
+	if expires.IsZero() || expires.Equal(timeSentinel) {
+		return
+	}
+	if _, ok := metadata["Expires"]; !ok {
+		metadata["Expires"] = expires.UTC().Format(http.TimeFormat)
+	}
+}
+
+// stripMetadata - removes the headers in names from metadata, user
+// metadata can be named with or without the X-Amz-Meta- prefix.
+func stripMetadata(metadata map[string]string, names []string) {
+	for _, name := range names {
+		name = http.CanonicalHeaderKey(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+		for k := range metadata {
+			k1 := http.CanonicalHeaderKey(k)
+			if k1 == name || k1 == http.CanonicalHeaderKey("X-Amz-Meta-"+name) {
+				delete(metadata, k)
+			}
+		}
+	}
+}
+
+// parseStripMetadata - parses the comma separated headers of --strip-metadata.
+func parseStripMetadata(list string) (names []string) {
+	for _, name := range strings.Split(list, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
 // getAllMetadata - returns a map of user defined function
 // by combining the usermetadata of object and values passed by attr keyword
 func getAllMetadata(ctx context.Context, sourceAlias, sourceURLStr string, srcSSE encrypt.ServerSide, urls URLs) (map[string]string, *probe.Error) {
@@ -280,6 +319,7 @@ func getAllMetadata(ctx context.Context, sourceAlias, sourceURLStr string, srcSS
 	for k, v := range st.Metadata {
 		metadata[http.CanonicalHeaderKey(k)] = v
 	}
+	setExpiresMetadata(metadata, st.Expires)
 
 	for k, v := range urls.TargetContent.UserMetadata {
 		metadata[http.CanonicalHeaderKey(k)] = v
@@ -345,17 +385,36 @@ func uploadSourceToTargetURL(ctx context.Context, uploadOpts uploadSourceToTarge
 		legalHold = uploadOpts.urls.TargetContent.LegalHold
 	}
 
+	var tagging string
+	if uploadOpts.preserveTags || uploadOpts.preserveRetention {
+		var srcMode, srcUntil, srcLegalHold string
+		tagging, srcMode, srcUntil, srcLegalHold, err = getTagsAndRetention(ctx, sourceAlias, sourceURL.String(), sourceVersion,
+			uploadOpts.preserveTags, uploadOpts.preserveRetention)
+		if err != nil {
+			return uploadOpts.urls.WithError(err.Trace(sourceURL.String()))
+		}
+		// The retention and legal hold set for the target win.
+		if mode == "" {
+			mode, until = srcMode, srcUntil
+		}
+		if legalHold == "" {
+			legalHold = srcLegalHold
+		}
+	}
+
 	for k, v := range uploadOpts.urls.SourceContent.UserMetadata {
 		metadata[http.CanonicalHeaderKey(k)] = v
 	}
 	for k, v := range uploadOpts.urls.SourceContent.Metadata {
 		metadata[http.CanonicalHeaderKey(k)] = v
 	}
+	setExpiresMetadata(metadata, uploadOpts.urls.SourceContent.Expires)
 
 	// Optimize for server side copy if the host is same.
 	if sourceAlias == targetAlias && !uploadOpts.isZip && !uploadOpts.urls.checksum.IsSet() {
-		// preserve new metadata and save existing ones.
-		if uploadOpts.preserve {
+		// preserve new metadata and save existing ones, all of them are
+		// needed as well to replace them without the stripped headers.
+		if uploadOpts.preserve || len(uploadOpts.stripMetadata) > 0 || len(uploadOpts.attrTransform) > 0 {
 			currentMetadata, err := getAllMetadata(ctx, sourceAlias, sourceURL.String(), srcSSE, uploadOpts.urls)
 			if err != nil {
 				return uploadOpts.urls.WithError(err.Trace(sourceURL.String()))
@@ -375,6 +434,9 @@ func uploadSourceToTargetURL(ctx context.Context, uploadOpts uploadSourceToTarge
 			metadata[http.CanonicalHeaderKey(k)] = v
 		}
 
+		stripMetadata(metadata, uploadOpts.stripMetadata)
+		transformMetadata(metadata, uploadOpts.attrTransform)
+
 		sourcePath := filepath.ToSlash(sourceURL.Path)
 		if uploadOpts.urls.SourceContent.RetentionEnabled {
 			err = putTargetRetention(ctx, targetAlias, targetURL.String(), metadata)
@@ -388,6 +450,7 @@ func uploadSourceToTargetURL(ctx context.Context, uploadOpts uploadSourceToTarge
 			disableMultipart: uploadOpts.urls.DisableMultipart,
 			isPreserve:       uploadOpts.preserve,
 			storageClass:     uploadOpts.urls.TargetContent.StorageClass,
+			replaceMetadata:  len(uploadOpts.stripMetadata) > 0 || len(uploadOpts.attrTransform) > 0,
 		}
 
 		err = copySourceToTargetURL(ctx, targetAlias, targetURL.String(), sourcePath, sourceVersion, mode, until,
@@ -449,6 +512,7 @@ func uploadSourceToTargetURL(ctx context.Context, uploadOpts uploadSourceToTarge
 		for k, v := range content.Metadata {
 			metadata[k] = v
 		}
+		setExpiresMetadata(metadata, content.Expires)
 
 		// Get metadata from target content as well
 		for k, v := range uploadOpts.urls.TargetContent.Metadata {
@@ -460,6 +524,12 @@ func uploadSourceToTargetURL(ctx context.Context, uploadOpts uploadSourceToTarge
 			metadata[http.CanonicalHeaderKey(k)] = v
 		}
 
+		stripMetadata(metadata, uploadOpts.stripMetadata)
+		transformMetadata(metadata, uploadOpts.attrTransform)
+		if tagging != "" {
+			metadata["X-Amz-Tagging"] = tagging
+		}
+
 		var e error
 		var multipartSize uint64
 		var multipartThreads int
@@ -513,6 +583,60 @@ func uploadSourceToTargetURL(ctx context.Context, uploadOpts uploadSourceToTarge
 	return uploadOpts.urls.WithError(nil)
 }
 
+// getTagsAndRetention returns the tags, the retention and the legal hold
+// of an object to set them on its copy. Local files and the objects of
+// buckets without object lock have none.
+func getTagsAndRetention(ctx context.Context, alias, urlStr, versionID string, withTags, withRetention bool) (tagging, mode, until, legalHold string, err *probe.Error) {
+	clnt, err := newClientFromAlias(alias, urlStr)
+	if err != nil {
+		return "", "", "", "", err.Trace(alias, urlStr)
+	}
+	if _, ok := clnt.(*S3Client); !ok {
+		return "", "", "", "", nil
+	}
+
+	if withTags {
+		tagsMap, err := clnt.GetTags(ctx, versionID)
+		if err != nil {
+			return "", "", "", "", err.Trace(urlStr)
+		}
+		if len(tagsMap) > 0 {
+			t, e := tags.NewTags(tagsMap, true)
+			if e != nil {
+				return "", "", "", "", probe.NewError(e).Trace(urlStr)
+			}
+			tagging = t.String()
+		}
+	}
+
+	if withRetention {
+		noObjectLock := func(err *probe.Error) bool {
+			errResp := minio.ToErrorResponse(err.ToGoError())
+			switch errResp.Code {
+			case "NoSuchObjectLockConfiguration", "ObjectLockConfigurationNotFoundError", "InvalidRequest":
+				return true
+			}
+			return errResp.StatusCode == http.StatusNotImplemented
+		}
+		retentionMode, retainUntil, err := clnt.GetObjectRetention(ctx, versionID)
+		if err != nil && !noObjectLock(err) {
+			return "", "", "", "", err.Trace(urlStr)
+		}
+		if retentionMode.IsValid() && !retainUntil.IsZero() {
+			mode = string(retentionMode)
+			until = retainUntil.UTC().Format(time.RFC3339)
+		}
+		lhold, err := clnt.GetObjectLegalHold(ctx, versionID)
+		if err != nil && !noObjectLock(err) {
+			return "", "", "", "", err.Trace(urlStr)
+		}
+		if lhold == minio.LegalHoldEnabled {
+			legalHold = string(lhold)
+		}
+	}
+	return tagging, mode, until, legalHold, nil
+}
+
 // newClientFromAlias gives a new client interface for matching
 // alias entry in the mc config file. If no matching host config entry
 // is found, fs client is returned.
@@ -575,8 +699,12 @@ type uploadSourceToTargetURLOpts struct {
 	progress            io.Reader
 	encKeyDB            map[string][]prefixSSEPair
 	preserve, isZip     bool
+	preserveTags        bool
+	preserveRetention   bool
 	multipartSize       string
 	multipartThreads    string
 	updateProgressTotal bool
 	ifNotExists         bool
+	stripMetadata       []string
+	attrTransform       []metadataRule
 }
@@ -0,0 +1,62 @@
+// Copyright (c) 2015-2024 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestStripMetadata(t *testing.T) {
+	metadata := map[string]string{
+		"Content-Type":                    "text/plain",
+		"Expires":                         "Wed, 21 Oct 2026 07:28:00 GMT",
+		"X-Amz-Meta-Owner":                "alice",
+		"X-Amz-Meta-Project":              "mc",
+		"X-Amz-Website-Redirect-Location": "/index.html",
+	}
+	stripMetadata(metadata, parseStripMetadata(" expires, owner ,x-amz-website-redirect-location,"))
+	expected := map[string]string{
+		"Content-Type":       "text/plain",
+		"X-Amz-Meta-Project": "mc",
+	}
+	if !reflect.DeepEqual(metadata, expected) {
+		t.Fatalf("expected %v, got %v", expected, metadata)
+	}
+}
+
+func TestSetExpiresMetadata(t *testing.T) {
+	metadata := map[string]string{}
+	setExpiresMetadata(metadata, time.Time{})
+	if len(metadata) != 0 {
+		t.Fatalf("expected no Expires for a zero time, got %v", metadata)
+	}
+
+	expires := time.Date(2026, time.October, 21, 9, 28, 0, 0, time.FixedZone("CEST", 2*60*60))
+	setExpiresMetadata(metadata, expires)
+	if v := metadata["Expires"]; v != "Wed, 21 Oct 2026 07:28:00 GMT" {
+		t.Fatalf("unexpected Expires %q", v)
+	}
+
+	// An Expires set by the user is kept.
+	setExpiresMetadata(metadata, expires.Add(time.Hour))
+	if v := metadata["Expires"]; v != "Wed, 21 Oct 2026 07:28:00 GMT" {
+		t.Fatalf("unexpected Expires %q", v)
+	}
+}
@@ -0,0 +1,264 @@
+// Copyright (c) 2015-2024 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/google/uuid"
+	"github.com/minio/cli"
+	json "github.com/minio/colorjson"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/pkg/v3/console"
+)
+
+var compatFlags = []cli.Flag{
+	cli.DurationFlag{
+		Name:  "listen-timeout",
+		Usage: "time to wait for an error of the bucket notification listener",
+		Value: 2 * time.Second,
+	},
+}
+
+// Probe the features supported by a server.
+var compatCmd = cli.Command{
+	Name:         "compat",
+	Usage:        "list the features supported by a server",
+	Action:       mainCompat,
+	OnUsageError: onUsageError,
+	Before:       setGlobalsFromContext,
+	Flags:        append(compatFlags, globalFlags...),
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} [FLAGS] TARGET
+{{if .VisibleFlags}}
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}{{end}}
+DESCRIPTION:
+  Compat probes a server with read-only requests and lists the features mc can use with it. Bucket
+  features are probed on the bucket of TARGET, or on the first bucket of the server if TARGET is an alias.
+
+LEGEND:
+  ✔ - feature is supported.
+  ✗ - feature is not supported by the server.
+  ? - support could not be determined, e.g. access is denied.
+
+EXAMPLES:
+  1. List the features supported by a server.
+     {{.Prompt}} {{.HelpName}} myminio
+
+  2. List the features supported by a server for a bucket, in JSON format.
+     {{.Prompt}} {{.HelpName}} --json myminio/mybucket
+`,
+}
+
+// Support of a feature by a server.
+const (
+	compatSupported   = "supported"
+	compatUnsupported = "unsupported"
+	compatUnknown     = "unknown"
+)
+
+// compatFeature is the support of a feature by a server.
+type compatFeature struct {
+	Name    string `json:"name"`
+	Support string `json:"support"`
+	Reason  string `json:"reason,omitempty"`
+}
+
+type compatMessage struct {
+	Status   string          `json:"status"`
+	Alias    string          `json:"alias"`
+	Bucket   string          `json:"bucket,omitempty"`
+	Features []compatFeature `json:"features"`
+}
+
+func (c compatMessage) JSON() string {
+	c.Status = "success"
+	jsonMessageBytes, e := json.MarshalIndent(c, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+	return string(jsonMessageBytes)
+}
+
+func (c compatMessage) String() string {
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf("Alias: %s\n", c.Alias))
+	if c.Bucket != "" {
+		b.WriteString(fmt.Sprintf("Bucket: %s\n", c.Bucket))
+	}
+	for _, f := range c.Features {
+		var st string
+		switch f.Support {
+		case compatSupported:
+			st = console.Colorize("CompatSupported", "✔")
+		case compatUnsupported:
+			st = console.Colorize("CompatUnsupported", "✗")
+		default:
+			st = console.Colorize("CompatUnknown", "?")
+		}
+		if f.Reason != "" {
+			st += " (" + f.Reason + ")"
+		}
+		b.WriteString(fmt.Sprintf("   - %-20s %s\n", f.Name, st))
+	}
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+// compatSupport returns the support of a feature from the error of the
+// request probing it. Errors of missing configurations or objects show
+// the server implements the API.
+func compatSupport(e error) (support, reason string) {
+	if e == nil {
+		return compatSupported, ""
+	}
+	errResp := minio.ToErrorResponse(e)
+	switch {
+	case errResp.Code == "NotImplemented", errResp.Code == "XNotImplemented",
+		errResp.Code == "APINotSupported", errResp.StatusCode == http.StatusNotImplemented:
+		return compatUnsupported, ""
+	case errResp.Code == "AccessDenied":
+		return compatUnknown, "access denied"
+	case errResp.Code == "NoSuchBucket":
+		return compatUnknown, "bucket not found"
+	case errResp.Code != "":
+		return compatSupported, ""
+	}
+	return compatUnknown, e.Error()
+}
+
+// probeCompat probes the features of the server of clnt, bucket features
+// are probed on bucket if not empty.
+func probeCompat(ctx context.Context, clnt *S3Client, bucket string, listenTimeout time.Duration) []compatFeature {
+	api := clnt.api
+	bucketProbes := []struct {
+		name  string
+		probe func() error
+	}{
+		{"versioning", func() error {
+			_, e := api.GetBucketVersioning(ctx, bucket)
+			return e
+		}},
+		{"object-lock", func() error {
+			_, _, _, _, e := api.GetObjectLockConfig(ctx, bucket)
+			return e
+		}},
+		{"tagging", func() error {
+			_, e := api.GetBucketTagging(ctx, bucket)
+			return e
+		}},
+		{"replication", func() error {
+			_, e := api.GetBucketReplication(ctx, bucket)
+			return e
+		}},
+		{"checksums", func() error {
+			// A missing object is enough to know if the API is implemented.
+			_, e := api.GetObjectAttributes(ctx, bucket, "mc-compat-probe-"+uuid.NewString(), minio.ObjectAttributesOptions{})
+			return e
+		}},
+	}
+
+	var features []compatFeature
+	for _, p := range bucketProbes {
+		f := compatFeature{Name: p.name, Support: compatUnknown, Reason: "no bucket to probe"}
+		if bucket != "" {
+			f.Support, f.Reason = compatSupport(p.probe())
+		}
+		features = append(features, f)
+	}
+
+	// Listening to notifications is specific to MinIO, errors are returned
+	// right away while a supported listener waits for events.
+	listen := compatFeature{Name: "listen-notification"}
+	lctx, cancel := context.WithTimeout(ctx, listenTimeout)
+	defer cancel()
+	select {
+	case info, ok := <-api.ListenBucketNotification(lctx, bucket, "", "", []string{"s3:ObjectCreated:*"}):
+		if ok && info.Err != nil {
+			listen.Support, listen.Reason = compatSupport(info.Err)
+			if listen.Support == compatSupported {
+				// Any other error than a missing API means it is unusable.
+				listen.Support, listen.Reason = compatUnknown, info.Err.Error()
+			}
+		} else {
+			listen.Support = compatSupported
+		}
+	case <-lctx.Done():
+		listen.Support = compatSupported
+	}
+	features = append(features, listen)
+
+	// Extraction of zip members is only implemented by MinIO servers.
+	zipExtract := compatFeature{Name: "zip-extract", Support: listen.Support}
+	if listen.Support == compatUnknown {
+		zipExtract.Reason = "unable to detect a MinIO server"
+	}
+	features = append(features, zipExtract)
+	return features
+}
+
+// mainCompat is the handle for "mc compat" command.
+func mainCompat(cliCtx *cli.Context) error {
+	if len(cliCtx.Args()) != 1 {
+		showCommandHelpAndExit(cliCtx, 1) // last argument is exit code
+	}
+
+	console.SetColor("CompatSupported", color.New(color.FgGreen, color.Bold))
+	console.SetColor("CompatUnsupported", color.New(color.FgRed, color.Bold))
+	console.SetColor("CompatUnknown", color.New(color.FgYellow, color.Bold))
+
+	ctx, cancelCompat := context.WithCancel(globalContext)
+	defer cancelCompat()
+
+	aliasedURL := cliCtx.Args().Get(0)
+	alias, _, _ := mustExpandAlias(aliasedURL)
+	if alias == "" {
+		fatalIf(errInvalidAliasedURL(aliasedURL), "No such alias `"+aliasedURL+"` found.")
+	}
+
+	client, err := newClient(aliasedURL)
+	fatalIf(err.Trace(aliasedURL), "Unable to initialize target `"+aliasedURL+"`.")
+	s3Client, ok := client.(*S3Client)
+	if !ok {
+		fatalIf(errDummy().Trace(), "The provided url doesn't point to a S3 server.")
+	}
+
+	bucket, _ := s3Client.url2BucketAndObject()
+	if bucket == "" {
+		buckets, e := s3Client.api.ListBuckets(ctx)
+		fatalIf(probe.NewError(e).Trace(aliasedURL), "Unable to list buckets of `"+aliasedURL+"`.")
+		if len(buckets) > 0 {
+			bucket = buckets[0].Name
+		}
+	}
+
+	printMsg(compatMessage{
+		Alias:    alias,
+		Bucket:   bucket,
+		Features: probeCompat(ctx, s3Client, bucket, cliCtx.Duration("listen-timeout")),
+	})
+	return nil
+}
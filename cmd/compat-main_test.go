@@ -0,0 +1,47 @@
+// Copyright (c) 2015-2024 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/minio/minio-go/v7"
+)
+
+func TestCompatSupport(t *testing.T) {
+	testCases := []struct {
+		err     error
+		support string
+	}{
+		{nil, compatSupported},
+		{minio.ErrorResponse{Code: "NoSuchTagSet", StatusCode: http.StatusNotFound}, compatSupported},
+		{minio.ErrorResponse{Code: "ReplicationConfigurationNotFoundError", StatusCode: http.StatusNotFound}, compatSupported},
+		{minio.ErrorResponse{Code: "NotImplemented", StatusCode: http.StatusNotImplemented}, compatUnsupported},
+		{minio.ErrorResponse{StatusCode: http.StatusNotImplemented}, compatUnsupported},
+		{minio.ErrorResponse{Code: "AccessDenied", StatusCode: http.StatusForbidden}, compatUnknown},
+		{minio.ErrorResponse{Code: "NoSuchBucket", StatusCode: http.StatusNotFound}, compatUnknown},
+		{errors.New("connection refused"), compatUnknown},
+	}
+	for i, tc := range testCases {
+		if support, _ := compatSupport(tc.err); support != tc.support {
+			t.Errorf("Test %d: expected %s, got %s", i+1, tc.support, support)
+		}
+	}
+}
@@ -19,6 +19,7 @@ package cmd
 
 import (
 	"sync"
+	"time"
 
 	"github.com/minio/mc/pkg/probe"
 	"github.com/minio/pkg/v3/quick"
@@ -44,9 +45,43 @@ type aliasConfigV10 struct {
 	SessionToken string `json:"sessionToken,omitempty"`
 	API          string `json:"api"`
 	Path         string `json:"path"`
-	License      string `json:"license,omitempty"`
-	APIKey       string `json:"apiKey,omitempty"`
-	Src          string `json:"src,omitempty"`
+	Proxy        string `json:"proxy,omitempty"`
+	// Expiry of the credentials, the alias cannot be used after it.
+	Expiry         *time.Time `json:"expiry,omitempty"`
+	RemoveOnExpiry bool       `json:"removeOnExpiry,omitempty"`
+	// STS configuration, credentials are obtained from the STS
+	// endpoint instead of using the access and secret keys directly.
+	STS     *aliasSTSConfig `json:"sts,omitempty"`
+	License string          `json:"license,omitempty"`
+	APIKey  string          `json:"apiKey,omitempty"`
+	Src     string          `json:"src,omitempty"`
+}
+
+// aliasSTSConfig STS configuration of an alias.
+type aliasSTSConfig struct {
+	Endpoint             string `json:"endpoint,omitempty"`
+	RoleARN              string `json:"roleArn,omitempty"`
+	RoleSessionName      string `json:"roleSessionName,omitempty"`
+	WebIdentityTokenFile string `json:"webIdentityTokenFile,omitempty"`
+	Duration             string `json:"duration,omitempty"`
+}
+
+// String describes how the temporary credentials are obtained.
+func (s aliasSTSConfig) String() string {
+	action := "AssumeRole"
+	if s.WebIdentityTokenFile != "" {
+		action = "AssumeRoleWithWebIdentity (" + s.WebIdentityTokenFile + ")"
+	}
+	if s.RoleARN != "" {
+		action += " " + s.RoleARN
+	}
+	if s.Endpoint != "" {
+		action += " via " + s.Endpoint
+	}
+	if s.Duration != "" {
+		action += " for " + s.Duration
+	}
+	return action
 }
 
 // configV10 config version.
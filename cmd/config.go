@@ -26,6 +26,7 @@ import (
 	"regexp"
 	"runtime"
 	"strings"
+	"time"
 
 	"github.com/minio/mc/pkg/probe"
 	"github.com/minio/pkg/v3/env"
@@ -119,6 +120,10 @@ func newMcConfig() *configV10 {
 func loadMcConfigFactory() func() (*configV10, *probe.Error) {
 	// Load once and cache in a closure.
 	cfgCache, err := loadConfigV10()
+	if err == nil && pruneExpiredAliases(cfgCache, time.Now()) {
+		// Expired credentials never stay on disk.
+		errorIf(saveConfigV10(cfgCache).Trace(mustGetMcConfigPath()), "Unable to remove the credentials of expired aliases.")
+	}
 
 	// loadMcConfig - reads configuration file and returns config.
 	return func() (*configV10, *probe.Error) {
@@ -335,12 +340,59 @@ func expandAlias(aliasedURL string) (alias, urlStr string, aliasCfg *aliasConfig
 
 	// Find the matching alias entry and expand the URL.
 	if aliasCfg = mustGetHostConfig(alias); aliasCfg != nil {
+		checkAliasExpiry(alias, aliasCfg)
 		return alias, urlJoinPath(aliasCfg.URL, path), aliasCfg, nil
 	}
 
 	return "", aliasedURL, nil, nil // No matching entry found. Return original URL as is.
 }
 
+// isExpired returns true if the credentials of the alias expired.
+func (a aliasConfigV10) isExpired() bool {
+	return a.Expiry != nil && time.Now().After(*a.Expiry)
+}
+
+// pruneExpiredAliases removes the aliases of conf which expired before now
+// and were set with --remove-on-expiry, and the credentials of the other
+// expired aliases, kept to explain why they cannot be used. Returns true
+// if conf was changed.
+func pruneExpiredAliases(conf *configV10, now time.Time) (changed bool) {
+	for alias, aliasCfg := range conf.Aliases {
+		if aliasCfg.Expiry == nil || !now.After(*aliasCfg.Expiry) {
+			continue
+		}
+		if aliasCfg.RemoveOnExpiry {
+			delete(conf.Aliases, alias)
+			changed = true
+			continue
+		}
+		if aliasCfg.AccessKey != "" || aliasCfg.SecretKey != "" || aliasCfg.SessionToken != "" {
+			aliasCfg.AccessKey, aliasCfg.SecretKey, aliasCfg.SessionToken = "", "", ""
+			conf.Aliases[alias] = aliasCfg
+			changed = true
+		}
+	}
+	return changed
+}
+
+// checkAliasExpiry refuses to use an expired alias, the alias is
+// removed from the configuration if it was set with --remove-on-expiry.
+func checkAliasExpiry(alias string, aliasCfg *aliasConfigV10) {
+	if !aliasCfg.isExpired() {
+		return
+	}
+	err := errAliasExpired(alias, *aliasCfg.Expiry)
+	if !aliasCfg.RemoveOnExpiry {
+		fatalIf(err, "Unable to use alias `"+alias+"`, set it again with 'mc alias set'.")
+	}
+	conf, e := loadMcConfig()
+	fatalIf(e.Trace(alias), "Unable to load config `"+mustGetMcConfigPath()+"`.")
+	delete(conf.Aliases, alias)
+	e = saveMcConfig(conf)
+	fatalIf(e.Trace(alias), "Unable to remove expired alias `"+alias+"`.")
+	fatalIf(err, "Unable to use alias `"+alias+"`, it has been removed.")
+}
+
 // mustExpandAlias expands aliased URL if any match is found, returns as is otherwise.
 func mustExpandAlias(aliasedURL string) (alias, urlStr string, aliasCfg *aliasConfigV10) {
 	alias, urlStr, aliasCfg, _ = expandAlias(aliasedURL)
@@ -17,7 +17,24 @@
 
 package cmd
 
-import "testing"
+import (
+	"encoding/json"
+	"os"
+	"testing"
+	"time"
+)
+
+// useTestMcConfig loads an empty configuration from a temporary folder
+// until the end of the test, for tests looking up aliases.
+func useTestMcConfig(t *testing.T) {
+	configDir, load := mcCustomConfigDir, loadMcConfig
+	t.Cleanup(func() {
+		setMcConfigDir(configDir)
+		loadMcConfig = load
+	})
+	setMcConfigDir(t.TempDir())
+	loadMcConfig = loadMcConfigFactory()
+}
 
 // Tests valid host URL functionality.
 func TestParseEnvURLStr(t *testing.T) {
@@ -142,3 +159,50 @@ func TestParseEnvURLStrInvalid(t *testing.T) {
 		t.Fatalf("Expected failure")
 	}
 }
+
+func TestPruneExpiredAliases(t *testing.T) {
+	useTestMcConfig(t)
+
+	past, future := time.Now().Add(-time.Hour), time.Now().Add(time.Hour)
+	conf := newMcConfig()
+	conf.Aliases["removed"] = aliasConfigV10{URL: "http://localhost:9000", AccessKey: "minio", SecretKey: "removed123", Expiry: &past, RemoveOnExpiry: true}
+	conf.Aliases["expired"] = aliasConfigV10{URL: "http://localhost:9000", AccessKey: "minio", SecretKey: "expired123", SessionToken: "token", Expiry: &past}
+	conf.Aliases["valid"] = aliasConfigV10{URL: "http://localhost:9000", AccessKey: "minio", SecretKey: "valid123", Expiry: &future, RemoveOnExpiry: true}
+	conf.Aliases["forever"] = aliasConfigV10{URL: "http://localhost:9000", AccessKey: "minio", SecretKey: "forever123"}
+	// Loading the saved configuration prunes the expired aliases.
+	if err := saveMcConfig(conf); err != nil {
+		t.Fatal(err)
+	}
+
+	data, e := os.ReadFile(mustGetMcConfigPath())
+	if e != nil {
+		t.Fatal(e)
+	}
+	saved := newMcConfig()
+	if e = json.Unmarshal(data, saved); e != nil {
+		t.Fatal(e)
+	}
+	loaded, err := loadMcConfig()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for name, conf := range map[string]*configV10{"saved": saved, "loaded": loaded} {
+		if _, ok := conf.Aliases["removed"]; ok {
+			t.Errorf("%s: expected alias `removed` to be removed", name)
+		}
+		expired, ok := conf.Aliases["expired"]
+		if !ok || expired.Expiry == nil {
+			t.Errorf("%s: expected alias `expired` to be kept with its expiry", name)
+		}
+		if expired.AccessKey != "" || expired.SecretKey != "" || expired.SessionToken != "" {
+			t.Errorf("%s: expected the credentials of alias `expired` to be removed, got %+v", name, expired)
+		}
+		if conf.Aliases["valid"].SecretKey != "valid123" || conf.Aliases["forever"].SecretKey != "forever123" {
+			t.Errorf("%s: expected the aliases which did not expire to be unchanged", name)
+		}
+	}
+	if pruneExpiredAliases(loaded, time.Now()) {
+		t.Error("expected nothing left to prune")
+	}
+}
@@ -0,0 +1,440 @@
+// Copyright (c) 2015-2024 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bufio"
+	"crypto/rand"
+	"encoding/hex"
+	gojson "encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	humanize "github.com/dustin/go-humanize"
+	"github.com/minio/cli"
+	json "github.com/minio/colorjson"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/pkg/v3/console"
+)
+
+const (
+	// Version of the cp checkpoint file format.
+	cpCheckpointVersion = "1"
+	// Interval at which the list of copied objects is flushed to disk.
+	cpCheckpointFlushInterval = time.Second
+)
+
+// cpCheckpointInfo is saved in the config dir when a recursive copy with
+// --checkpoint starts, it records how the copy was invoked so that it can
+// be resumed. EncFlags lists the encryption flags of the copy, their keys
+// are not saved and must be given again on resume.
+type cpCheckpointInfo struct {
+	Version      string    `json:"version"`
+	ID           string    `json:"id"`
+	WorkDir      string    `json:"workDir"`
+	Flags        []string  `json:"flags,omitempty"`
+	EncFlags     []string  `json:"encFlags,omitempty"`
+	Args         []string  `json:"args"`
+	Created      time.Time `json:"created"`
+	Updated      time.Time `json:"updated"`
+	Prepared     bool      `json:"prepared"`
+	TotalObjects int64     `json:"totalObjects"`
+	TotalBytes   int64     `json:"totalBytes"`
+	Copied       int64     `json:"copied"`
+	CopiedBytes  int64     `json:"copiedBytes"`
+	LastCopied   string    `json:"lastCopied,omitempty"`
+}
+
+// cpCheckpoint tracks the objects copied by a recursive copy, every copied
+// source is appended to a log which is used to skip it on resume. All
+// methods are safe on a nil checkpoint.
+type cpCheckpoint struct {
+	mu     sync.Mutex
+	info   cpCheckpointInfo
+	copied map[string]struct{}
+	log    *os.File
+	logBuf *bufio.Writer
+	doneCh chan struct{}
+}
+
+func cpCheckpointDir() string {
+	return filepath.Join(mustGetMcConfigDir(), globalSessionDir)
+}
+
+func cpCheckpointInfoFile(id string) string {
+	return filepath.Join(cpCheckpointDir(), "cp-"+id+".json")
+}
+
+func cpCheckpointLogFile(id string) string {
+	return filepath.Join(cpCheckpointDir(), "cp-"+id+".log")
+}
+
+// cpCheckpointEncFlags returns the names of the encryption flags whose
+// values are keys, they are never saved in a checkpoint.
+func cpCheckpointEncFlags() map[string]bool {
+	names := make(map[string]bool)
+	for _, f := range encFlags {
+		names[strings.Split(f.GetName(), ",")[0]] = true
+	}
+	// Only the paths of the key files are given.
+	delete(names, "enc-c-file")
+	return names
+}
+
+// cpCheckpointFlags returns the command line flags of a copy which was
+// explicitly set, global flags are not saved and apply on resume. The
+// encryption flags holding keys are not saved, their names are returned
+// in encFlags.
+func cpCheckpointFlags(cliCtx *cli.Context) (flags, encFlags []string) {
+	skip := map[string]bool{"resume": true, "list-resumable": true, "purge-resumable": true}
+	for _, f := range globalFlags {
+		skip[strings.Split(f.GetName(), ",")[0]] = true
+	}
+	isEncFlag := cpCheckpointEncFlags()
+	for _, name := range cliCtx.FlagNames() {
+		if skip[name] || !cliCtx.IsSet(name) {
+			continue
+		}
+		if isEncFlag[name] {
+			encFlags = append(encFlags, name)
+			continue
+		}
+		switch v := cliCtx.Generic(name).(type) {
+		case *cli.StringSlice:
+			for _, s := range *v {
+				flags = append(flags, "--"+name+"="+s)
+			}
+		case flag.Value:
+			flags = append(flags, "--"+name+"="+v.String())
+		}
+	}
+	return flags, encFlags
+}
+
+// newCPCheckpoint creates the checkpoint of a new recursive copy.
+func newCPCheckpoint(cliCtx *cli.Context) (*cpCheckpoint, *probe.Error) {
+	var b [4]byte
+	if _, e := rand.Read(b[:]); e != nil {
+		return nil, probe.NewError(e)
+	}
+	workDir, e := os.Getwd()
+	if e != nil {
+		return nil, probe.NewError(e)
+	}
+	now := time.Now().UTC()
+	flags, encFlags := cpCheckpointFlags(cliCtx)
+	c := &cpCheckpoint{
+		info: cpCheckpointInfo{
+			Version:  cpCheckpointVersion,
+			ID:       hex.EncodeToString(b[:]),
+			WorkDir:  workDir,
+			Flags:    flags,
+			EncFlags: encFlags,
+			Args:     cliCtx.Args(),
+			Created:  now,
+			Updated:  now,
+		},
+		copied: make(map[string]struct{}),
+	}
+	if e := os.MkdirAll(cpCheckpointDir(), 0o700); e != nil {
+		return nil, probe.NewError(e)
+	}
+	if err := c.save(); err != nil {
+		return nil, err
+	}
+	return c, c.openLog()
+}
+
+// loadCPCheckpointInfo reads the checkpoint info of a copy.
+func loadCPCheckpointInfo(id string) (info cpCheckpointInfo, err *probe.Error) {
+	buf, e := os.ReadFile(cpCheckpointInfoFile(id))
+	if e != nil {
+		if os.IsNotExist(e) {
+			return info, probe.NewError(fmt.Errorf("no resumable copy with id `%s`", id))
+		}
+		return info, probe.NewError(e)
+	}
+	if e = gojson.Unmarshal(buf, &info); e != nil {
+		return info, probe.NewError(e)
+	}
+	if info.Version != cpCheckpointVersion {
+		return info, probe.NewError(fmt.Errorf("unsupported checkpoint version `%s`", info.Version))
+	}
+	return info, nil
+}
+
+// loadCPCheckpoint loads a checkpoint with the list of objects already copied.
+func loadCPCheckpoint(id string) (*cpCheckpoint, *probe.Error) {
+	info, err := loadCPCheckpointInfo(id)
+	if err != nil {
+		return nil, err
+	}
+	c := &cpCheckpoint{info: info, copied: make(map[string]struct{})}
+	f, e := os.Open(cpCheckpointLogFile(id))
+	if e != nil && !os.IsNotExist(e) {
+		return nil, probe.NewError(e)
+	}
+	if e == nil {
+		scanner := bufio.NewScanner(f)
+		scanner.Buffer(make([]byte, 64<<10), 1<<20)
+		for scanner.Scan() {
+			c.copied[scanner.Text()] = struct{}{}
+		}
+		f.Close()
+		if e = scanner.Err(); e != nil {
+			return nil, probe.NewError(e)
+		}
+	}
+	return c, c.openLog()
+}
+
+// listCPCheckpoints returns all resumable copies, oldest first.
+func listCPCheckpoints() ([]cpCheckpointInfo, *probe.Error) {
+	files, e := filepath.Glob(filepath.Join(cpCheckpointDir(), "cp-*.json"))
+	if e != nil {
+		return nil, probe.NewError(e)
+	}
+	var infos []cpCheckpointInfo
+	for _, file := range files {
+		id := strings.TrimSuffix(strings.TrimPrefix(filepath.Base(file), "cp-"), ".json")
+		info, err := loadCPCheckpointInfo(id)
+		if err != nil {
+			errorIf(err.Trace(file), "Unable to read copy checkpoint.")
+			continue
+		}
+		infos = append(infos, info)
+	}
+	sort.Slice(infos, func(i, j int) bool { return infos[i].Created.Before(infos[j].Created) })
+	return infos, nil
+}
+
+// purgeCPCheckpoints removes the checkpoints of the given copies, or all
+// of them if no id is given.
+func purgeCPCheckpoints(ids []string) ([]string, *probe.Error) {
+	if len(ids) == 0 {
+		infos, err := listCPCheckpoints()
+		if err != nil {
+			return nil, err
+		}
+		for _, info := range infos {
+			ids = append(ids, info.ID)
+		}
+	}
+	var purged []string
+	for _, id := range ids {
+		if _, err := loadCPCheckpointInfo(id); err != nil {
+			return purged, err.Trace(id)
+		}
+		if e := os.Remove(cpCheckpointLogFile(id)); e != nil && !os.IsNotExist(e) {
+			return purged, probe.NewError(e).Trace(id)
+		}
+		if e := os.Remove(cpCheckpointInfoFile(id)); e != nil {
+			return purged, probe.NewError(e).Trace(id)
+		}
+		purged = append(purged, id)
+	}
+	return purged, nil
+}
+
+// cliContext rebuilds the command line context of the checkpointed copy,
+// the encryption keys which are not saved are taken from cliCtx.
+func (c *cpCheckpoint) cliContext(cliCtx *cli.Context) (*cli.Context, *probe.Error) {
+	set := flag.NewFlagSet(cliCtx.Command.Name, flag.ContinueOnError)
+	for _, f := range cliCtx.Command.Flags {
+		f.Apply(set)
+	}
+	args := append([]string{}, c.info.Flags...)
+	for _, name := range c.info.EncFlags {
+		if !cliCtx.IsSet(name) {
+			return nil, probe.NewError(fmt.Errorf("the copy used --%s, its keys must be given again with --resume", name))
+		}
+	}
+	for name := range cpCheckpointEncFlags() {
+		for _, v := range cliCtx.StringSlice(name) {
+			args = append(args, "--"+name+"="+v)
+		}
+	}
+	args = append(args, "--")
+	if e := set.Parse(append(args, c.info.Args...)); e != nil {
+		return nil, probe.NewError(e)
+	}
+	ctx := cli.NewContext(cliCtx.App, set, cliCtx.Parent())
+	ctx.Command = cliCtx.Command
+	return ctx, nil
+}
+
+func (c *cpCheckpoint) openLog() *probe.Error {
+	f, e := os.OpenFile(cpCheckpointLogFile(c.info.ID), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+	if e != nil {
+		return probe.NewError(e)
+	}
+	c.log = f
+	c.logBuf = bufio.NewWriter(f)
+	c.doneCh = make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(cpCheckpointFlushInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				c.mu.Lock()
+				c.logBuf.Flush()
+				c.mu.Unlock()
+			case <-c.doneCh:
+				return
+			}
+		}
+	}()
+
+	// Save the progress before exiting on a signal.
+	setShutdownHandler(func(os.Signal) bool {
+		c.close(false)
+		return false
+	})
+	return nil
+}
+
+// save writes the checkpoint info file, the caller must hold the lock or
+// own the checkpoint exclusively.
+func (c *cpCheckpoint) save() *probe.Error {
+	c.info.Updated = time.Now().UTC()
+	buf, e := gojson.MarshalIndent(c.info, "", " ")
+	if e != nil {
+		return probe.NewError(e)
+	}
+	return probe.NewError(os.WriteFile(cpCheckpointInfoFile(c.info.ID), buf, 0o600))
+}
+
+// isCopied returns true if the source was copied before the copy was interrupted.
+func (c *cpCheckpoint) isCopied(source string) bool {
+	if c == nil {
+		return false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	_, ok := c.copied[source]
+	return ok
+}
+
+// prepared records the totals once all objects to copy were listed.
+func (c *cpCheckpoint) prepared(totalObjects, totalBytes int64) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.info.Prepared = true
+	c.info.TotalObjects = totalObjects
+	c.info.TotalBytes = totalBytes
+	errorIf(c.save().Trace(c.info.ID), "Unable to save copy checkpoint.")
+}
+
+// done records a successfully copied object.
+func (c *cpCheckpoint) done(cpURLs URLs) {
+	if c == nil || c.log == nil {
+		return
+	}
+	source := cpURLs.SourceContent.URL.String()
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, ok := c.copied[source]; ok {
+		return
+	}
+	c.copied[source] = struct{}{}
+	c.logBuf.WriteString(source + "\n")
+	c.info.Copied++
+	c.info.CopiedBytes += cpURLs.SourceContent.Size
+	c.info.LastCopied = source
+}
+
+// close flushes the checkpoint, it is removed when the copy completed.
+func (c *cpCheckpoint) close(completed bool) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.log == nil {
+		return
+	}
+	close(c.doneCh)
+	c.logBuf.Flush()
+	c.log.Close()
+	c.log = nil
+
+	if completed {
+		os.Remove(cpCheckpointLogFile(c.info.ID))
+		os.Remove(cpCheckpointInfoFile(c.info.ID))
+		return
+	}
+	errorIf(c.save().Trace(c.info.ID), "Unable to save copy checkpoint.")
+	if !globalJSON {
+		console.Eraseline()
+		console.Infof("Copy can be resumed with `mc cp --resume %s`.\n", c.info.ID)
+	}
+}
+
+// cpCheckpointPurgeMessage is a checkpoint removed by 'mc cp --purge-resumable'.
+type cpCheckpointPurgeMessage struct {
+	Status string `json:"status"`
+	ID     string `json:"id"`
+}
+
+func (m cpCheckpointPurgeMessage) String() string {
+	return console.Colorize("CopyCheckpoint", fmt.Sprintf("Removed resumable copy `%s`.", m.ID))
+}
+
+func (m cpCheckpointPurgeMessage) JSON() string {
+	m.Status = "success"
+	b, e := json.MarshalIndent(m, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+	return string(b)
+}
+
+// cpCheckpointMessage is a resumable copy listed by 'mc cp --list-resumable'.
+type cpCheckpointMessage struct {
+	Status string `json:"status"`
+	cpCheckpointInfo
+}
+
+func (m cpCheckpointMessage) String() string {
+	progress := fmt.Sprintf("%d object(s), %s copied", m.Copied, humanize.IBytes(uint64(m.CopiedBytes)))
+	if m.Prepared {
+		progress = fmt.Sprintf("%d/%d object(s), %s/%s copied", m.Copied, m.TotalObjects,
+			humanize.IBytes(uint64(m.CopiedBytes)), humanize.IBytes(uint64(m.TotalBytes)))
+	}
+	msg := console.Colorize("CopyCheckpoint", fmt.Sprintf("%s  [%s] %s", m.ID, m.Updated.Local().Format(printDate), progress)) +
+		"\n    " + strings.Join(append(append([]string{}, m.Flags...), m.Args...), " ")
+	if len(m.EncFlags) > 0 {
+		msg += "\n    requires the keys of --" + strings.Join(m.EncFlags, ", --")
+	}
+	return msg
+}
+
+func (m cpCheckpointMessage) JSON() string {
+	m.Status = "success"
+	b, e := json.MarshalIndent(m, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+	return string(b)
+}
@@ -0,0 +1,185 @@
+// Copyright (c) 2015-2024 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/minio/cli"
+)
+
+// runCPCheckpointTestCmd parses args with the flags of cp and returns the
+// context of the command.
+func runCPCheckpointTestCmd(t *testing.T, args ...string) *cli.Context {
+	t.Helper()
+	var cliCtx *cli.Context
+	cmd := cpCmd
+	cmd.Before = nil
+	cmd.Action = func(ctx *cli.Context) error {
+		cliCtx = ctx
+		return nil
+	}
+	app := cli.NewApp()
+	app.Commands = []cli.Command{cmd}
+	if e := app.Run(append([]string{"mc", "cp"}, args...)); e != nil {
+		t.Fatal(e)
+	}
+	return cliCtx
+}
+
+func TestCPCheckpointFlags(t *testing.T) {
+	cliCtx := runCPCheckpointTestCmd(t, "--recursive", "--checkpoint",
+		"--enc-c", "myminio/b/=MDEyMzQ1Njc4OTAxMjM0NTY3ODkwMTIzNDU2Nzg5MDA",
+		"--enc-kms", "myminio/c/=my-key", "--enc-c-file", "myminio/d/=/keys/d",
+		"--json", "src/", "myminio/b/")
+
+	flags, encFlags := cpCheckpointFlags(cliCtx)
+	sort.Strings(flags)
+	sort.Strings(encFlags)
+	if want := []string{"--checkpoint=true", "--enc-c-file=myminio/d/=/keys/d", "--recursive=true"}; !reflect.DeepEqual(flags, want) {
+		t.Fatalf("expected flags %v, got %v", want, flags)
+	}
+	if want := []string{"enc-c", "enc-kms"}; !reflect.DeepEqual(encFlags, want) {
+		t.Fatalf("expected encryption flags %v, got %v", want, encFlags)
+	}
+	for _, f := range flags {
+		if strings.Contains(f, "MDEy") || strings.Contains(f, "my-key") {
+			t.Fatalf("key saved in %s", f)
+		}
+	}
+}
+
+func TestCPCheckpointResume(t *testing.T) {
+	defer setMcConfigDir(mcCustomConfigDir)
+	setMcConfigDir(t.TempDir())
+
+	const key = "myminio/b/=MDEyMzQ1Njc4OTAxMjM0NTY3ODkwMTIzNDU2Nzg5MDA"
+	c, err := newCPCheckpoint(runCPCheckpointTestCmd(t, "--recursive", "--checkpoint", "--enc-c", key, "src/", "myminio/b/"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.done(URLs{SourceContent: &ClientContent{URL: *newClientURL("src/a"), Size: 3}})
+	c.close(false)
+
+	infos, err := listCPCheckpoints()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(infos) != 1 || infos[0].ID != c.info.ID || infos[0].Copied != 1 {
+		t.Fatalf("expected the checkpoint to be listed, got %+v", infos)
+	}
+	buf, e := os.ReadFile(cpCheckpointInfoFile(c.info.ID))
+	if e != nil {
+		t.Fatal(e)
+	}
+	if strings.Contains(string(buf), "MDEy") {
+		t.Fatalf("key saved in the checkpoint %s", buf)
+	}
+
+	resumed, err := loadCPCheckpoint(c.info.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resumed.close(false)
+	if !resumed.isCopied("src/a") || resumed.isCopied("src/b") {
+		t.Fatal("expected only src/a to be copied")
+	}
+	if _, err = resumed.cliContext(runCPCheckpointTestCmd(t, "--resume", c.info.ID)); err == nil {
+		t.Fatal("expected the keys to be required on resume")
+	}
+	cliCtx, err := resumed.cliContext(runCPCheckpointTestCmd(t, "--resume", c.info.ID, "--enc-c", key))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !cliCtx.Bool("recursive") || !reflect.DeepEqual(cliCtx.StringSlice("enc-c"), []string{key}) ||
+		!reflect.DeepEqual([]string(cliCtx.Args()), []string{"src/", "myminio/b/"}) {
+		t.Fatalf("unexpected resumed copy: recursive %v, keys %v, args %v", cliCtx.Bool("recursive"), cliCtx.StringSlice("enc-c"), cliCtx.Args())
+	}
+}
+
+func TestCPCheckpointCompletedAndPurge(t *testing.T) {
+	defer setMcConfigDir(mcCustomConfigDir)
+	setMcConfigDir(t.TempDir())
+
+	completed, err := newCPCheckpoint(runCPCheckpointTestCmd(t, "--recursive", "--checkpoint", "src/", "dst/"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	completed.close(true)
+	if _, e := os.Stat(cpCheckpointInfoFile(completed.info.ID)); !os.IsNotExist(e) {
+		t.Fatalf("expected the checkpoint of a completed copy to be removed, got %v", e)
+	}
+
+	var ids []string
+	for i := 0; i < 2; i++ {
+		c, err := newCPCheckpoint(runCPCheckpointTestCmd(t, "--recursive", "--checkpoint", "src/", "dst/"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		c.close(false)
+		ids = append(ids, c.info.ID)
+	}
+	if _, err = purgeCPCheckpoints([]string{"unknown"}); err == nil {
+		t.Fatal("expected an error for an unknown checkpoint")
+	}
+	purged, err := purgeCPCheckpoints(ids[:1])
+	if err != nil || !reflect.DeepEqual(purged, ids[:1]) {
+		t.Fatalf("expected %v to be purged, got %v, %v", ids[:1], purged, err)
+	}
+	if purged, err = purgeCPCheckpoints(nil); err != nil || !reflect.DeepEqual(purged, ids[1:]) {
+		t.Fatalf("expected %v to be purged, got %v, %v", ids[1:], purged, err)
+	}
+	if infos, _ := listCPCheckpoints(); len(infos) != 0 {
+		t.Fatalf("expected no checkpoint left, got %+v", infos)
+	}
+}
+
+func TestAliasStaleCPCheckpoints(t *testing.T) {
+	defer setMcConfigDir(mcCustomConfigDir)
+	setMcConfigDir(t.TempDir())
+
+	var ids []string
+	for _, args := range [][]string{{"src/", "myminio/b/"}, {"myminio/a/", "other/b/"}, {"src/", "other/b/"}} {
+		c, err := newCPCheckpoint(runCPCheckpointTestCmd(t, append([]string{"--recursive", "--checkpoint"}, args...)...))
+		if err != nil {
+			t.Fatal(err)
+		}
+		c.close(false)
+		ids = append(ids, c.info.ID)
+	}
+
+	if stale, err := aliasStaleCPCheckpoints("myminio", false); err != nil || stale != 2 {
+		t.Fatalf("expected 2 checkpoints on myminio, got %d, %v", stale, err)
+	}
+	if stale, err := aliasStaleCPCheckpoints("myminio", true); err != nil || stale != 2 {
+		t.Fatalf("expected 2 checkpoints on myminio to be purged, got %d, %v", stale, err)
+	}
+	for i, id := range ids {
+		_, e := os.Stat(cpCheckpointInfoFile(id))
+		if kept := e == nil; kept != (i == 2) {
+			t.Errorf("checkpoint %s: expected kept %v, got %v", id, i == 2, kept)
+		}
+		if _, e = os.Stat(cpCheckpointLogFile(id)); e == nil && i != 2 {
+			t.Errorf("checkpoint %s: expected its log to be removed", id)
+		}
+	}
+}
@@ -0,0 +1,147 @@
+// Copyright (c) 2015-2024 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"io"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/minio/cli"
+)
+
+// Flags of cp supported by a fan-out, along with the encryption and the
+// global flags. The other flags select or transform several sources, or
+// are not implemented for several targets.
+var cpFanOutFlags = []string{
+	"fanout", "version-id", "storage-class", "attr", "tags", "md5", "checksum", "disable-multipart",
+	"require-versioned", "require-locked",
+}
+
+// cpFanOutUnsupportedFlag returns the first flag set on the command line
+// which a fan-out does not support, an empty string if there is none.
+func cpFanOutUnsupportedFlag(cliCtx *cli.Context) string {
+	supported := make(map[string]bool)
+	for _, flag := range cpFanOutFlags {
+		supported[flag] = true
+	}
+	for _, flag := range append(append([]cli.Flag{}, encFlags...), globalFlags...) {
+		supported[strings.Split(flag.GetName(), ",")[0]] = true
+	}
+	for _, flag := range cliCtx.FlagNames() {
+		if !supported[flag] && cliCtx.IsSet(flag) {
+			return flag
+		}
+	}
+	return ""
+}
+
+// checkCopyFanOutSyntax validates the arguments of cp --fanout.
+func checkCopyFanOutSyntax(cliCtx *cli.Context) {
+	args := cliCtx.Args()
+	if len(args) < 3 {
+		fatalIf(errInvalidArgument().Trace(args...), "--fanout requires one SOURCE and at least two TARGETs.")
+	}
+	if flag := cpFanOutUnsupportedFlag(cliCtx); flag != "" {
+		fatalIf(errInvalidArgument().Trace(flag), "--fanout cannot be used with --"+flag+".")
+	}
+	if args[0] == "-" {
+		fatalIf(errInvalidArgument().Trace(args...), "Use `mc pipe TARGET [TARGET...]` to copy from the standard input to several targets.")
+	}
+}
+
+// fanOutTargetURL returns the URL of the object written for targetURL, the
+// name of the source is kept when targetURL is a folder or a bucket.
+func fanOutTargetURL(ctx context.Context, sourceURL, targetURL string) string {
+	if !strings.HasSuffix(targetURL, "/") && !strings.HasSuffix(targetURL, string(filepath.Separator)) {
+		_, content, err := url2Stat(ctx, url2StatOptions{urlStr: targetURL})
+		if err != nil || !content.Type.IsDir() {
+			return targetURL
+		}
+		targetURL += "/"
+	}
+	return targetURL + path.Base(filepath.ToSlash(sourceURL))
+}
+
+// mainCopyFanOut copies the source to all the targets, it is read only
+// once and streamed to all of them at the same time.
+func mainCopyFanOut(ctx context.Context, cliCtx *cli.Context) error {
+	checkCopyFanOutSyntax(cliCtx)
+	checkCopyTargetsProtection(ctx, cliCtx, cliCtx.Args()[1:])
+
+	encKeyDB, err := validateAndCreateEncryptionKeys(cliCtx)
+	fatalIf(err, "Unable to parse encryption keys.")
+
+	meta := map[string]string{}
+	if attr := cliCtx.String("attr"); attr != "" {
+		meta, err = getMetaDataEntry(attr)
+		fatalIf(err.Trace(attr), "Unable to parse --attr value")
+	}
+	if tags := cliCtx.String("tags"); tags != "" {
+		meta["X-Amz-Tagging"] = tags
+	}
+	md5, checksum := parseChecksum(cliCtx)
+	disableMultipart := cliCtx.Bool("disable-multipart")
+
+	args := cliCtx.Args()
+	sourceURL := args[0]
+	targetURLs := make([]string, 0, len(args)-1)
+	for _, targetURL := range args[1:] {
+		targetURLs = append(targetURLs, fanOutTargetURL(ctx, sourceURL, targetURL))
+	}
+
+	reader, content, err := getSourceStreamMetadataFromURL(ctx, sourceURL, cliCtx.String("version-id"), time.Time{}, encKeyDB, false)
+	fatalIf(err.Trace(sourceURL), "Unable to read from `"+sourceURL+"`.")
+	defer reader.Close()
+	if content.Type.IsDir() {
+		fatalIf(errInvalidArgument().Trace(sourceURL), "`"+sourceURL+"` is a folder, --fanout copies a single object.")
+	}
+
+	putOpts := func(targetURL string) PutOptions {
+		alias, _ := url2Alias(targetURL)
+		metadata := make(map[string]string, len(meta))
+		for k, v := range meta {
+			metadata[k] = v
+		}
+		return PutOptions{
+			sse:              getSSE(targetURL, encKeyDB[alias]),
+			storageClass:     cliCtx.String("storage-class"),
+			metadata:         metadata,
+			md5:              md5,
+			checksum:         checksum,
+			disableMultipart: disableMultipart,
+		}
+	}
+
+	var pg ProgressReader
+	if !globalQuiet && !globalJSON {
+		pg = newProgressBar(content.Size)
+	} else {
+		pg = newAccounter(content.Size)
+	}
+	err = pipeFanOut(io.TeeReader(reader, progressWriter{pg}), content.Size, targetURLs, putOpts)
+	if err != nil {
+		showLastProgressBar(pg, err.ToGoError())
+		return exitStatus(globalErrorExitStatus)
+	}
+	showLastProgressBar(pg, nil)
+	return nil
+}
@@ -0,0 +1,94 @@
+// Copyright (c) 2015-2024 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/minio/cli"
+)
+
+func TestCPFanOutUnsupportedFlag(t *testing.T) {
+	testCases := []struct {
+		args []string
+		flag string
+	}{
+		{[]string{"--fanout", "a", "b", "c"}, ""},
+		{[]string{"--fanout", "--storage-class", "REDUCED_REDUNDANCY", "--attr", "k=v", "--tags", "t=v", "a", "b", "c"}, ""},
+		{[]string{"--fanout", "--vid", "v1", "--checksum", "CRC32C", "--disable-multipart", "a", "b", "c"}, ""},
+		{[]string{"--fanout", "--enc-kms", "b/=key", "--enc-c-file", "c/=/keys/c", "--require-versioned", "a", "b", "c"}, ""},
+		{[]string{"--fanout", "--json", "--quiet", "--limit-upload", "1MiB", "--insecure", "a", "b", "c"}, ""},
+		{[]string{"--fanout", "-r", "a", "b", "c"}, "recursive"},
+		{[]string{"--fanout", "--verify", "a", "b", "c"}, "verify"},
+		{[]string{"--fanout", "--checkpoint", "a", "b", "c"}, "checkpoint"},
+		{[]string{"--fanout", "--symlinks", "skip", "a", "b", "c"}, "symlinks"},
+		{[]string{"--fanout", "--unicode-normalize", "nfc", "a", "b", "c"}, "unicode-normalize"},
+		{[]string{"--fanout", "--manifest", "m.sha256", "a", "b", "c"}, "manifest"},
+		{[]string{"--fanout", "--" + lhFlag, "ON", "a", "b", "c"}, lhFlag},
+	}
+	for i, tc := range testCases {
+		if flag := cpFanOutUnsupportedFlag(runCPCheckpointTestCmd(t, tc.args...)); flag != tc.flag {
+			t.Errorf("test %d: %v: expected %q, got %q", i+1, tc.args, tc.flag, flag)
+		}
+	}
+}
+
+func TestMainCopyFanOut(t *testing.T) {
+	// Targets are looked up in the configuration.
+	useTestMcConfig(t)
+	defer func(quiet bool) { globalQuiet = quiet }(globalQuiet)
+	globalQuiet = true
+
+	dir := t.TempDir()
+	source := filepath.Join(dir, "release.tar.gz")
+	data := []byte("release data")
+	if e := os.WriteFile(source, data, 0o600); e != nil {
+		t.Fatal(e)
+	}
+	prod, dr := filepath.Join(dir, "prod"), filepath.Join(dir, "dr")
+	for _, d := range []string{prod, dr} {
+		if e := os.Mkdir(d, 0o700); e != nil {
+			t.Fatal(e)
+		}
+	}
+	renamed := filepath.Join(dir, "renamed.tar.gz")
+
+	cmd := cpCmd
+	cmd.Before = nil
+	cmd.Action = func(cliCtx *cli.Context) error {
+		return mainCopyFanOut(context.Background(), cliCtx)
+	}
+	app := cli.NewApp()
+	app.Commands = []cli.Command{cmd}
+	// A folder keeps the name of the source, with or without a trailing separator.
+	if e := app.Run([]string{"mc", "cp", "--fanout", source, prod + string(filepath.Separator), dr, renamed}); e != nil {
+		t.Fatal(e)
+	}
+	for _, target := range []string{filepath.Join(prod, "release.tar.gz"), filepath.Join(dr, "release.tar.gz"), renamed} {
+		got, e := os.ReadFile(target)
+		if e != nil {
+			t.Fatal(e)
+		}
+		if string(got) != string(data) {
+			t.Errorf("%s: expected %q, got %q", target, data, got)
+		}
+	}
+}
@@ -22,6 +22,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"os"
 	"path/filepath"
 	"strings"
 
@@ -60,6 +61,10 @@ var (
 			Name:  "storage-class, sc",
 			Usage: "set storage class for new object(s) on target",
 		},
+		cli.StringFlag{
+			Name:  "sc-rule",
+			Usage: "set storage class for new object(s) on target by size, e.g. '<1MiB=STANDARD,>=1MiB=REDUCED_REDUNDANCY'",
+		},
 		cli.StringFlag{
 			Name:  "attr",
 			Usage: "add custom metadata for the object",
@@ -68,6 +73,14 @@ var (
 			Name:  "preserve, a",
 			Usage: "preserve filesystem attributes (mode, ownership, timestamps)",
 		},
+		cli.StringFlag{
+			Name:  "strip-metadata",
+			Usage: "comma separated list of headers and metadata not copied to the target, e.g. 'Expires,X-Amz-Meta-Owner'",
+		},
+		cli.StringFlag{
+			Name:  "attr-transform",
+			Usage: "comma separated rules transforming the metadata of each object, e.g. 'del:X-Amz-Meta-Temp,set:Cache-Control=max-age=3600,rename:X-Old=X-New'",
+		},
 		cli.BoolFlag{
 			Name:  "disable-multipart",
 			Usage: "disable multipart upload feature",
@@ -97,7 +110,64 @@ var (
 			Name:  "zip",
 			Usage: "Extract from remote zip file (MinIO server source only)",
 		},
+		cli.StringFlag{
+			Name:  "zip-create",
+			Usage: "create a single zip object with the given name under TARGET from all sources",
+		},
 		checksumFlag,
+		cli.StringFlag{
+			Name:  "resume",
+			Usage: "resume an interrupted recursive copy by its id",
+		},
+		cli.BoolFlag{
+			Name:  "list-resumable",
+			Usage: "list interrupted recursive copies which can be resumed",
+		},
+		cli.BoolFlag{
+			Name:  "purge-resumable",
+			Usage: "remove the checkpoints of the given interrupted copies, or of all of them",
+		},
+		cli.BoolFlag{
+			Name:  "checkpoint",
+			Usage: "save the progress of a recursive copy so that it can be resumed with --resume when interrupted",
+		},
+		cli.BoolFlag{
+			Name:  "if-newer",
+			Usage: "copy only if the source is newer than the target",
+		},
+		cli.BoolFlag{
+			Name:  "if-size-differ",
+			Usage: "copy only if the source and the target sizes differ",
+		},
+		cli.BoolFlag{
+			Name:  "if-not-exists",
+			Usage: "copy only if the target does not exist",
+		},
+		cli.BoolFlag{
+			Name:  "verify",
+			Usage: "compare the checksums of the source and the target after each copy, the copy fails on mismatch",
+		},
+		cli.BoolFlag{
+			Name:  "fanout",
+			Usage: "copy SOURCE to all the TARGETs, reading it only once",
+		},
+		cli.StringFlag{
+			Name:  "unicode-normalize",
+			Usage: "normalize the names of the copied objects to 'nfc' or 'nfd', 'none' keeps them unchanged",
+		},
+		cli.StringFlag{
+			Name:  "symlinks",
+			Usage: "with --recursive, 'follow' copies the files symbolic links point to, 'skip' ignores links and 'preserve' re-creates them, recording their target in the metadata of objects",
+			Value: symlinksFollow,
+		},
+		cli.BoolFlag{
+			Name:  "require-versioned",
+			Usage: "abort unless the target bucket has versioning enabled",
+		},
+		cli.BoolFlag{
+			Name:  "require-locked",
+			Usage: "abort unless the target bucket has object lock enabled",
+		},
 	}
 )
 
@@ -117,20 +187,31 @@ var cpCmd = cli.Command{
 	Action:       mainCopy,
 	OnUsageError: onUsageError,
 	Before:       setGlobalsFromContext,
-	Flags:        append(append(cpFlags, encFlags...), globalFlags...),
+	Flags:        append(append(append(cpFlags, transferManifestFlags...), encFlags...), globalFlags...),
 	CustomHelpTemplate: `NAME:
   {{.HelpName}} - {{.Usage}}
 
 USAGE:
   {{.HelpName}} [FLAGS] SOURCE [SOURCE...] TARGET
+  {{.HelpName}} --fanout [FLAGS] SOURCE TARGET [TARGET...]
+  {{.HelpName}} --resume ID [ENCRYPTION FLAGS]
+  {{.HelpName}} --list-resumable
+  {{.HelpName}} --purge-resumable [ID...]
 
 FLAGS:
   {{range .VisibleFlags}}{{.}}
   {{end}}
 
+DESCRIPTION:
+  Recursive copies with --checkpoint save their progress in the configuration directory, an
+  interrupted copy can be resumed with --resume, objects already copied are skipped. Encryption
+  keys are not saved, the flags giving them must be given again with --resume. The progress is
+  removed once the copy completes or with --purge-resumable.
+
 ENVIRONMENT VARIABLES:
   MC_ENC_KMS: KMS encryption key in the form of (alias/prefix=key).
   MC_ENC_S3: S3 encryption key in the form of (alias/prefix=key).
+  MC_ENC_CONTEXT: SSE-KMS encryption context in the form of (key=value).
 
 EXAMPLES:
   01. Copy a list of objects from local file system to Amazon S3 cloud storage.
@@ -172,24 +253,69 @@ EXAMPLES:
   13. Copy a text file to an object storage and assign REDUCED_REDUNDANCY storage-class to the uploaded object.
       {{.Prompt}} {{.HelpName}} --storage-class REDUCED_REDUNDANCY myobject.txt play/mybucket
 
-  14. Copy a text file to an object storage and preserve the file system attribute as metadata.
+  14. Copy a local folder recursively, objects smaller than 1MiB use STANDARD storage-class and larger ones REDUCED_REDUNDANCY.
+      {{.Prompt}} {{.HelpName}} --recursive --sc-rule '<1MiB=STANDARD,>=1MiB=REDUCED_REDUNDANCY' dataset/ play/mybucket/dataset/
+
+  15. Copy a text file to an object storage and preserve the file system attribute as metadata.
       {{.Prompt}} {{.HelpName}} -a myobject.txt play/mybucket
 
-  15. Copy a text file to an object storage with object lock mode set to 'GOVERNANCE' with retention duration 1 day.
+  16. Copy a text file to an object storage with object lock mode set to 'GOVERNANCE' with retention duration 1 day.
       {{.Prompt}} {{.HelpName}} --retention-mode governance --retention-duration 1d locked.txt play/locked-bucket/
 
-  16. Copy a text file to an object storage with legal-hold enabled.
+  17. Copy a text file to an object storage with legal-hold enabled.
       {{.Prompt}} {{.HelpName}} --legal-hold on locked.txt play/locked-bucket/
 
-  17. Copy a text file to an object storage and disable multipart upload feature.
+  18. Copy a text file to an object storage and disable multipart upload feature.
       {{.Prompt}} {{.HelpName}} --disable-multipart myobject.txt play/mybucket
 
-  18. Roll back 10 days in the past to copy the content of 'mybucket'
+  19. Roll back 10 days in the past to copy the content of 'mybucket'
       {{.Prompt}} {{.HelpName}} --rewind 10d -r play/mybucket/ /tmp/dest/
 
-  19. Set tags to the uploaded objects
+  20. Set tags to the uploaded objects
       {{.Prompt}} {{.HelpName}} -r --tags "category=prod&type=backup" ./data/ play/another-bucket/
 
+  21. Copy a folder so that the copy can be resumed if interrupted, list interrupted copies and
+      resume one of them.
+      {{.Prompt}} {{.HelpName}} --recursive --checkpoint ~/Pictures play/mybucket/pictures/
+      {{.Prompt}} {{.HelpName}} --list-resumable
+      {{.Prompt}} {{.HelpName}} --resume 4f1c9a2e
+
+  22. Archive a local folder recursively into a single zip object "play/mybucket/backups/logs.zip".
+      {{.Prompt}} {{.HelpName}} --recursive --zip-create logs.zip /var/log/app/ play/mybucket/backups/
+
+  23. Copy only the files which are newer than the objects of the target or whose sizes differ.
+      {{.Prompt}} {{.HelpName}} --recursive --if-newer --if-size-differ ~/Documents play/mybucket/docs/
+
+  24. Copy only the files which do not exist in the target.
+      {{.Prompt}} {{.HelpName}} --recursive --if-not-exists ~/Documents play/mybucket/docs/
+
+  25. Copy objects between servers with their metadata, except for the Expires header and the owner metadata.
+      {{.Prompt}} {{.HelpName}} --recursive --strip-metadata "Expires,X-Amz-Meta-Owner" s3/mybucket/ play/mybucket/
+
+  26. Copy a release artifact to the production and disaster recovery sites, the file is read only once.
+      {{.Prompt}} {{.HelpName}} --fanout release.tar.gz prod/releases/ dr/releases/
+
+  27. Recursively copy a macOS folder to a bucket, using precomposed (NFC) object names.
+      {{.Prompt}} {{.HelpName}} --recursive --unicode-normalize nfc ~/Documents s3/documents
+
+  28. Migrate a bucket to another server, verifying the checksum of every copied object.
+      {{.Prompt}} {{.HelpName}} --recursive --verify s3/records/ myminio/records/
+
+  29. Copy a bucket to another bucket, encrypting the objects with a KMS key and an encryption context.
+      {{.Prompt}} {{.HelpName}} --recursive --enc-kms "myminio/reports/=my-key" --enc-context project=alpha --enc-context owner=finance s3/reports/ myminio/reports/
+
+  30. Copy a local folder to another disk, re-creating its symbolic links instead of copying the files they point to.
+      {{.Prompt}} {{.HelpName}} --recursive --symlinks preserve ~/projects /mnt/backup/projects
+
+  31. Copy audit records only if the target bucket is versioned and has object lock enabled.
+      {{.Prompt}} {{.HelpName}} --recursive --require-versioned --require-locked audit/2024/ myminio/compliance/2024/
+
+  32. Copy objects removing a temporary metadata and setting their Cache-Control header.
+      {{.Prompt}} {{.HelpName}} --recursive --attr-transform "del:X-Amz-Meta-Temp,set:Cache-Control=max-age=3600" s3/mybucket/ play/mybucket/
+
+  33. Back up a folder writing a manifest of the copied objects signed with a minisign key, verify it later with 'mc verify-manifest'.
+      {{.Prompt}} {{.HelpName}} --recursive --manifest backup.sha256 --manifest-sign-key ~/.minisign/minisign.key ~/records/ myminio/backups/records/
+
 `,
 }
 
@@ -258,6 +384,10 @@ func doCopy(ctx context.Context, copyOpts doCopyOpts) URLs {
 		})
 	}
 
+	if copyOpts.cpURLs.SourceContent.Symlink != "" {
+		return copySymlink(ctx, copyOpts.cpURLs)
+	}
+
 	urls := uploadSourceToTargetURL(ctx, uploadSourceToTargetURLOpts{
 		urls:                copyOpts.cpURLs,
 		progress:            copyOpts.pg,
@@ -268,7 +398,15 @@ func doCopy(ctx context.Context, copyOpts doCopyOpts) URLs {
 		multipartThreads:    copyOpts.multipartThreads,
 		updateProgressTotal: copyOpts.updateProgressTotal,
 		ifNotExists:         copyOpts.ifNotExists,
+		stripMetadata:       copyOpts.stripMetadata,
+		attrTransform:       copyOpts.attrTransform,
 	})
+	if copyOpts.verify && urls.Error == nil {
+		urls.Error = verifyCopy(ctx, copyOpts.cpURLs, copyOpts.encryptionKeys)
+	}
+	if copyOpts.manifest != nil && urls.Error == nil {
+		urls.Error = copyOpts.manifest.add(ctx, copyOpts.cpURLs, copyOpts.encryptionKeys)
+	}
 	if copyOpts.isMvCmd && urls.Error == nil {
 		rmManager.add(ctx, sourceAlias, sourceURL.String())
 	}
@@ -276,6 +414,49 @@ func doCopy(ctx context.Context, copyOpts doCopyOpts) URLs {
 	return urls
 }
 
+// copyConditions are the conditions of --if-newer, --if-size-differ and
+// --if-not-exists, an object is copied if any of the set conditions is met.
+type copyConditions struct {
+	ifNewer, ifSizeDiffer, ifNotExists bool
+}
+
+func (c copyConditions) isSet() bool {
+	return c.ifNewer || c.ifSizeDiffer || c.ifNotExists
+}
+
+// skip returns whether the source does not need to be copied over the
+// target, target is nil if it does not exist.
+func (c copyConditions) skip(source, target *ClientContent) bool {
+	if target == nil {
+		return false
+	}
+	if c.ifNewer && source.Time.After(target.Time) {
+		return false
+	}
+	if c.ifSizeDiffer && source.Size != target.Size {
+		return false
+	}
+	return true
+}
+
+// skipCopy stats the target of cpURLs and returns whether it is skipped
+// by the copy conditions.
+func skipCopy(ctx context.Context, cpURLs URLs, conds copyConditions) (bool, *probe.Error) {
+	targetClnt, err := newClientFromAlias(cpURLs.TargetAlias, cpURLs.TargetContent.URL.String())
+	if err != nil {
+		return false, err
+	}
+	target, err := targetClnt.Stat(ctx, StatOptions{})
+	if err != nil {
+		switch err.ToGoError().(type) {
+		case ObjectMissing, PathNotFound:
+			return false, nil
+		}
+		return false, err
+	}
+	return conds.skip(cpURLs.SourceContent, target), nil
+}
+
 // doCopyFake - Perform a fake copy to update the progress bar appropriately.
 func doCopyFake(cpURLs URLs, pg Progress) URLs {
 	if progressReader, ok := pg.(*progressBar); ok {
@@ -302,10 +483,14 @@ func printCopyURLsError(cpURLs *URLs) {
 	}
 }
 
-func doCopySession(ctx context.Context, cancelCopy context.CancelFunc, cli *cli.Context, encryptionKeys map[string][]prefixSSEPair, isMvCmd bool) error {
+func doCopySession(ctx context.Context, cancelCopy context.CancelFunc, cli *cli.Context, encryptionKeys map[string][]prefixSSEPair, isMvCmd bool, checkpoint *cpCheckpoint) error {
 	var isCopied func(string) bool
 	var totalObjects, totalBytes int64
 
+	if checkpoint != nil {
+		isCopied = checkpoint.isCopied
+	}
+
 	cpURLsCh := make(chan URLs, 10000)
 	errSeen := false
 
@@ -330,6 +515,23 @@ func doCopySession(ctx context.Context, cancelCopy context.CancelFunc, cli *cli.
 	rewind := cli.String("rewind")
 	versionID := cli.String("version-id")
 	md5, checksum := parseChecksum(cli)
+	conds := copyConditions{
+		ifNewer:      cli.Bool("if-newer"),
+		ifSizeDiffer: cli.Bool("if-size-differ"),
+		ifNotExists:  cli.Bool("if-not-exists"),
+	}
+	var scRules storageClassRules
+	if rules := cli.String("sc-rule"); rules != "" {
+		var err *probe.Error
+		scRules, err = parseStorageClassRules(rules)
+		fatalIf(err, "Unable to parse storage class rules.")
+	}
+	var manifest *transferManifest
+	if manifestPath := cli.String("manifest"); manifestPath != "" {
+		var err *probe.Error
+		manifest, err = newTransferManifest(manifestPath, cli.String("manifest-sign-key"), targetURL)
+		fatalIf(err, "Unable to initialize the manifest.")
+	}
 	if withLock {
 		// The Content-MD5 header is required for any request to upload an object with a retention period configured using Amazon S3 Object Lock.
 		md5, checksum = true, minio.ChecksumNone
@@ -338,15 +540,17 @@ func doCopySession(ctx context.Context, cancelCopy context.CancelFunc, cli *cli.
 	go func() {
 		totalBytes := int64(0)
 		opts := prepareCopyURLsOpts{
-			sourceURLs:  sourceURLs,
-			targetURL:   targetURL,
-			isRecursive: isRecursive,
-			encKeyDB:    encryptionKeys,
-			olderThan:   olderThan,
-			newerThan:   newerThan,
-			timeRef:     parseRewindFlag(rewind),
-			versionID:   versionID,
-			isZip:       cli.Bool("zip"),
+			sourceURLs:       sourceURLs,
+			targetURL:        targetURL,
+			isRecursive:      isRecursive,
+			encKeyDB:         encryptionKeys,
+			olderThan:        olderThan,
+			newerThan:        newerThan,
+			timeRef:          parseRewindFlag(rewind),
+			versionID:        versionID,
+			isZip:            cli.Bool("zip"),
+			unicodeNormalize: cli.String("unicode-normalize"),
+			symlinks:         cli.String("symlinks"),
 		}
 
 		for cpURLs := range prepareCopyURLs(ctx, opts) {
@@ -361,6 +565,9 @@ func doCopySession(ctx context.Context, cancelCopy context.CancelFunc, cli *cli.
 			totalObjects++
 			cpURLsCh <- cpURLs
 		}
+		if !errSeen {
+			checkpoint.prepared(totalObjects, totalBytes)
+		}
 		close(cpURLsCh)
 	}()
 
@@ -400,6 +607,8 @@ func doCopySession(ctx context.Context, cancelCopy context.CancelFunc, cli *cli.
 				// Check and handle storage class if passed in command line args
 				if storageClass := cli.String("storage-class"); storageClass != "" {
 					cpURLs.TargetContent.StorageClass = storageClass
+				} else if scRules != nil {
+					cpURLs.TargetContent.StorageClass = scRules.storageClass(cpURLs.SourceContent.Size)
 				}
 
 				if rm := cli.String(rmFlag); rm != "" {
@@ -420,6 +629,8 @@ func doCopySession(ctx context.Context, cancelCopy context.CancelFunc, cli *cli.
 
 				preserve := cli.Bool("preserve")
 				isZip := cli.Bool("zip")
+				stripMetadata := parseStripMetadata(cli.String("strip-metadata"))
+				attrTransform, _ := parseMetadataTransform(cli.String("attr-transform"))
 				if cli.String("attr") != "" {
 					userMetaMap, _ := getMetaDataEntry(cli.String("attr"))
 					for metadataKey, metaDataVal := range userMetaMap {
@@ -439,6 +650,16 @@ func doCopySession(ctx context.Context, cancelCopy context.CancelFunc, cli *cli.
 				} else {
 					// Print the copy resume summary once in start
 					parallel.queueTask(func() URLs {
+						if conds.isSet() {
+							skip, err := skipCopy(ctx, cpURLs, conds)
+							if err != nil {
+								cpURLs.Error = err.Trace(cpURLs.TargetContent.URL.String())
+								return cpURLs
+							}
+							if skip {
+								return doCopyFake(cpURLs, pg)
+							}
+						}
 						return doCopy(ctx, doCopyOpts{
 							cpURLs:         cpURLs,
 							pg:             pg,
@@ -446,6 +667,11 @@ func doCopySession(ctx context.Context, cancelCopy context.CancelFunc, cli *cli.
 							isMvCmd:        isMvCmd,
 							preserve:       preserve,
 							isZip:          isZip,
+							ifNotExists:    conds.ifNotExists && !conds.ifNewer && !conds.ifSizeDiffer,
+							stripMetadata:  stripMetadata,
+							attrTransform:  attrTransform,
+							verify:         cli.Bool("verify"),
+							manifest:       manifest,
 						})
 					}, cpURLs.SourceContent.Size)
 				}
@@ -474,6 +700,7 @@ loop:
 			}
 			if cpURLs.Error == nil {
 				cpAllFilesErr = false
+				checkpoint.done(cpURLs)
 			} else {
 
 				// Set exit status for any copy error
@@ -535,6 +762,13 @@ loop:
 		retErr = exitStatus(globalErrorExitStatus)
 	}
 
+	if manifest != nil {
+		if err := manifest.close(); err != nil {
+			errorIf(err, "Unable to write the manifest.")
+			retErr = exitStatus(globalErrorExitStatus)
+		}
+	}
+	checkpoint.close(retErr == nil && !errSeen && globalContext.Err() == nil)
 	return retErr
 }
 
@@ -543,8 +777,64 @@ func mainCopy(cliCtx *cli.Context) error {
 	ctx, cancelCopy := context.WithCancel(globalContext)
 	defer cancelCopy()
 
-	checkCopySyntax(cliCtx)
 	console.SetColor("Copy", color.New(color.FgGreen, color.Bold))
+	console.SetColor("CopyCheckpoint", color.New(color.FgCyan, color.Bold))
+
+	if cliCtx.Bool("list-resumable") {
+		if len(cliCtx.Args()) > 0 || cliCtx.IsSet("resume") || cliCtx.Bool("purge-resumable") {
+			showCommandHelpAndExit(cliCtx, 1) // last argument is exit code.
+		}
+		checkpoints, err := listCPCheckpoints()
+		fatalIf(err, "Unable to list resumable copies.")
+		for _, info := range checkpoints {
+			printMsg(cpCheckpointMessage{cpCheckpointInfo: info})
+		}
+		return nil
+	}
+
+	if cliCtx.Bool("purge-resumable") {
+		if cliCtx.IsSet("resume") {
+			showCommandHelpAndExit(cliCtx, 1) // last argument is exit code.
+		}
+		purged, err := purgeCPCheckpoints(cliCtx.Args())
+		for _, id := range purged {
+			printMsg(cpCheckpointPurgeMessage{ID: id})
+		}
+		fatalIf(err, "Unable to remove resumable copy.")
+		return nil
+	}
+
+	var checkpoint *cpCheckpoint
+	if id := cliCtx.String("resume"); id != "" {
+		if len(cliCtx.Args()) > 0 {
+			fatalIf(errInvalidArgument().Trace(cliCtx.Args()...), "--resume cannot be used with SOURCE and TARGET arguments.")
+		}
+		var err *probe.Error
+		checkpoint, err = loadCPCheckpoint(id)
+		fatalIf(err.Trace(id), "Unable to resume copy.")
+		cliCtx, err = checkpoint.cliContext(cliCtx)
+		fatalIf(err.Trace(id), "Unable to resume copy.")
+		fatalIf(probe.NewError(os.Chdir(checkpoint.info.WorkDir)).Trace(checkpoint.info.WorkDir), "Unable to resume copy.")
+	}
+
+	if cliCtx.Bool("fanout") {
+		return mainCopyFanOut(ctx, cliCtx)
+	}
+
+	checkCopySyntax(cliCtx)
+	checkCopyTargetsProtection(ctx, cliCtx, cliCtx.Args()[len(cliCtx.Args())-1:])
+
+	if checkpoint == nil && cliCtx.Bool("checkpoint") {
+		if !cliCtx.Bool("recursive") {
+			fatalIf(errInvalidArgument().Trace(cliCtx.Args()...), "--checkpoint requires --recursive.")
+		}
+		var err *probe.Error
+		checkpoint, err = newCPCheckpoint(cliCtx)
+		errorIf(err, "Unable to save copy checkpoint, the copy will not be resumable.")
+		if err != nil {
+			checkpoint = nil
+		}
+	}
 
 	var err *probe.Error
 
@@ -555,7 +845,11 @@ func mainCopy(cliCtx *cli.Context) error {
 	}
 	fatalIf(err, "SSE Error")
 
-	return doCopySession(ctx, cancelCopy, cliCtx, encryptionKeyMap, false)
+	if cliCtx.String("zip-create") != "" {
+		return doCopyZipCreate(ctx, cliCtx, encryptionKeyMap)
+	}
+
+	return doCopySession(ctx, cancelCopy, cliCtx, encryptionKeyMap, false, checkpoint)
 }
 
 type doCopyOpts struct {
@@ -567,4 +861,8 @@ type doCopyOpts struct {
 	multipartSize            string
 	multipartThreads         string
 	ifNotExists              bool
+	stripMetadata            []string
+	attrTransform            []metadataRule
+	verify                   bool
+	manifest                 *transferManifest
 }
@@ -20,6 +20,7 @@ package cmd
 import (
 	"reflect"
 	"testing"
+	"time"
 )
 
 func TestParseMetaData(t *testing.T) {
@@ -74,3 +75,28 @@ func TestParseMetaData(t *testing.T) {
 		}
 	}
 }
+
+func TestCopyConditionsSkip(t *testing.T) {
+	now := time.Now()
+	source := &ClientContent{Size: 10, Time: now}
+	testCases := []struct {
+		conds  copyConditions
+		target *ClientContent
+		skip   bool
+	}{
+		{copyConditions{ifNotExists: true}, nil, false},
+		{copyConditions{ifNotExists: true}, &ClientContent{Size: 5, Time: now.Add(-time.Hour)}, true},
+		{copyConditions{ifNewer: true}, &ClientContent{Size: 10, Time: now.Add(-time.Hour)}, false},
+		{copyConditions{ifNewer: true}, &ClientContent{Size: 5, Time: now}, true},
+		{copyConditions{ifSizeDiffer: true}, &ClientContent{Size: 5, Time: now}, false},
+		{copyConditions{ifSizeDiffer: true}, &ClientContent{Size: 10, Time: now.Add(-time.Hour)}, true},
+		{copyConditions{ifNewer: true, ifSizeDiffer: true}, &ClientContent{Size: 10, Time: now.Add(-time.Hour)}, false},
+		{copyConditions{ifNewer: true, ifSizeDiffer: true}, &ClientContent{Size: 5, Time: now.Add(time.Hour)}, false},
+		{copyConditions{ifNewer: true, ifSizeDiffer: true}, &ClientContent{Size: 10, Time: now.Add(time.Hour)}, true},
+	}
+	for i, tc := range testCases {
+		if skip := tc.conds.skip(source, tc.target); skip != tc.skip {
+			t.Errorf("Test %d: expected skip %v, got %v", i+1, tc.skip, skip)
+		}
+	}
+}
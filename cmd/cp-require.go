@@ -0,0 +1,101 @@
+// Copyright (c) 2015-2024 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/minio/cli"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/minio-go/v7"
+)
+
+// missingCopyProtections returns the protections required by
+// --require-versioned and --require-locked that a bucket lacks.
+func missingCopyProtections(versioned, locked, requireVersioned, requireLocked bool) []string {
+	var missing []string
+	if requireVersioned && !versioned {
+		missing = append(missing, "versioning")
+	}
+	if requireLocked && !locked {
+		missing = append(missing, "object lock")
+	}
+	return missing
+}
+
+// checkCopyTargetProtection makes sure the bucket of targetURL has the
+// protections required by --require-versioned and --require-locked,
+// before anything is copied to it.
+func checkCopyTargetProtection(ctx context.Context, targetURL string, requireVersioned, requireLocked bool) *probe.Error {
+	if !requireVersioned && !requireLocked {
+		return nil
+	}
+
+	clnt, err := newClient(targetURL)
+	if err != nil {
+		return err.Trace(targetURL)
+	}
+	s3Clnt, ok := clnt.(*S3Client)
+	if !ok {
+		return probe.NewError(APINotImplemented{
+			API:     "GetBucketVersioning",
+			APIType: "filesystem",
+		}).Trace(targetURL)
+	}
+	bucket, _ := s3Clnt.url2BucketAndObject()
+	if bucket == "" {
+		return probe.NewError(BucketNameEmpty{}).Trace(targetURL)
+	}
+
+	var versioned, locked bool
+	if requireVersioned {
+		cfg, e := s3Clnt.api.GetBucketVersioning(ctx, bucket)
+		if e != nil {
+			return probe.NewError(e).Trace(targetURL)
+		}
+		versioned = cfg.Enabled()
+	}
+	if requireLocked {
+		status, _, _, _, e := s3Clnt.api.GetObjectLockConfig(ctx, bucket)
+		if e != nil {
+			switch minio.ToErrorResponse(e).Code {
+			case "NoSuchObjectLockConfiguration", "ObjectLockConfigurationNotFoundError":
+			default:
+				return probe.NewError(e).Trace(targetURL)
+			}
+		}
+		locked = status == "Enabled"
+	}
+
+	if missing := missingCopyProtections(versioned, locked, requireVersioned, requireLocked); len(missing) > 0 {
+		return probe.NewError(fmt.Errorf("Bucket `%s` does not have %s enabled", bucket, strings.Join(missing, " and "))).Trace(targetURL)
+	}
+	return nil
+}
+
+// checkCopyTargetsProtection exits if any of targetURLs lacks the
+// protections required on the command line.
+func checkCopyTargetsProtection(ctx context.Context, cliCtx *cli.Context, targetURLs []string) {
+	requireVersioned, requireLocked := cliCtx.Bool("require-versioned"), cliCtx.Bool("require-locked")
+	for _, targetURL := range targetURLs {
+		err := checkCopyTargetProtection(ctx, targetURL, requireVersioned, requireLocked)
+		fatalIf(err, "Refusing to copy to `"+targetURL+"`.")
+	}
+}
@@ -0,0 +1,43 @@
+// Copyright (c) 2015-2024 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestMissingCopyProtections(t *testing.T) {
+	testCases := []struct {
+		versioned, locked               bool
+		requireVersioned, requireLocked bool
+		expected                        []string
+	}{
+		{false, false, false, false, nil},
+		{false, false, true, false, []string{"versioning"}},
+		{true, false, true, true, []string{"object lock"}},
+		{false, false, true, true, []string{"versioning", "object lock"}},
+		{true, true, true, true, nil},
+	}
+	for i, tc := range testCases {
+		got := missingCopyProtections(tc.versioned, tc.locked, tc.requireVersioned, tc.requireLocked)
+		if !reflect.DeepEqual(got, tc.expected) {
+			t.Errorf("test %d: expected %v, got %v", i+1, tc.expected, got)
+		}
+	}
+}
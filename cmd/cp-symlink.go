@@ -0,0 +1,80 @@
+// Copyright (c) 2015-2024 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+
+	"github.com/minio/mc/pkg/probe"
+)
+
+// symlinkTargetMetadataKey records the target of a symbolic link copied
+// to object storage with --symlinks=preserve.
+const symlinkTargetMetadataKey = "X-Amz-Meta-Mc-Symlink-Target"
+
+// Handling of the symbolic links of local folders by --symlinks.
+const (
+	symlinksFollow   = "follow"
+	symlinksSkip     = "skip"
+	symlinksPreserve = "preserve"
+)
+
+func isValidSymlinksMode(mode string) bool {
+	switch mode {
+	case symlinksFollow, symlinksSkip, symlinksPreserve:
+		return true
+	}
+	return false
+}
+
+// copySymlink re-creates the symbolic link listed in the source of urls.
+// Local targets get the same link, object storage targets get an empty
+// object with the link target in its metadata.
+func copySymlink(ctx context.Context, urls URLs) URLs {
+	linkTarget := urls.SourceContent.Symlink
+	targetURL := urls.TargetContent.URL
+
+	if targetURL.Type == fileSystem {
+		path := targetURL.Path
+		if e := os.MkdirAll(filepath.Dir(path), 0o777); e != nil {
+			return urls.WithError(probe.NewError(e).Trace(path))
+		}
+		// Overwrite existing files and links, as copying a file would.
+		if st, e := os.Lstat(path); e == nil && !st.IsDir() {
+			if e = os.Remove(path); e != nil {
+				return urls.WithError(probe.NewError(e).Trace(path))
+			}
+		}
+		if e := os.Symlink(linkTarget, path); e != nil {
+			return urls.WithError(probe.NewError(e).Trace(path))
+		}
+		return urls.WithError(nil)
+	}
+
+	_, err := putTargetStream(ctx, urls.TargetAlias, targetURL.String(), "", "", "",
+		bytes.NewReader(nil), 0, nil, PutOptions{
+			metadata: map[string]string{symlinkTargetMetadataKey: linkTarget},
+		})
+	if err != nil {
+		return urls.WithError(err.Trace(targetURL.String()))
+	}
+	return urls.WithError(nil)
+}
@@ -0,0 +1,62 @@
+// Copyright (c) 2015-2024 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestCopySymlinkLocal(t *testing.T) {
+	dir := t.TempDir()
+	target := filepath.Join(dir, "nested", "link")
+
+	// Twice, the second copy must replace the first link.
+	for _, linkTarget := range []string{"a.txt", "../b.txt"} {
+		urls := copySymlink(context.Background(), URLs{
+			SourceContent: &ClientContent{Symlink: linkTarget},
+			TargetContent: &ClientContent{URL: *newClientURL(target)},
+		})
+		if urls.Error != nil {
+			t.Fatal(urls.Error)
+		}
+		got, e := os.Readlink(target)
+		if e != nil {
+			t.Fatal(e)
+		}
+		if got != linkTarget {
+			t.Fatalf("expected link to %q, got %q", linkTarget, got)
+		}
+	}
+}
+
+func TestIsValidSymlinksMode(t *testing.T) {
+	for mode, valid := range map[string]bool{
+		"follow":   true,
+		"skip":     true,
+		"preserve": true,
+		"":         false,
+		"copy":     false,
+	} {
+		if got := isValidSymlinksMode(mode); got != valid {
+			t.Errorf("isValidSymlinksMode(%q) = %v, expected %v", mode, got, valid)
+		}
+	}
+}
@@ -20,8 +20,10 @@ package cmd
 import (
 	"fmt"
 	"runtime"
+	"strings"
 
 	"github.com/minio/cli"
+	"github.com/minio/mc/pkg/probe"
 )
 
 func checkCopySyntax(cliCtx *cli.Context) {
@@ -45,10 +47,47 @@ func checkCopySyntax(cliCtx *cli.Context) {
 		fatalIf(errDummy().Trace(cliCtx.Args()...), "Unable to pass --version flag with multiple copy sources arguments.")
 	}
 
+	if form := cliCtx.String("unicode-normalize"); !isValidUnicodeNormalize(form) {
+		fatalIf(errInvalidArgument().Trace(form), "`--unicode-normalize` must be one of 'nfc', 'nfd' or 'none'.")
+	}
+
+	if _, e := parseMetadataTransform(cliCtx.String("attr-transform")); e != nil {
+		fatalIf(probe.NewError(e).Trace(cliCtx.String("attr-transform")), "Invalid `--attr-transform`.")
+	}
+
+	if mode := cliCtx.String("symlinks"); !isValidSymlinksMode(mode) {
+		fatalIf(errInvalidArgument().Trace(mode), "`--symlinks` must be one of 'follow', 'skip' or 'preserve'.")
+	} else if mode != symlinksFollow && !cliCtx.Bool("recursive") {
+		fatalIf(errInvalidArgument().Trace(mode), "`--symlinks` requires `--recursive`.")
+	}
+
+	if cliCtx.Bool("verify") && (isZip || cliCtx.String("zip-create") != "") {
+		fatalIf(errDummy().Trace(cliCtx.Args()...), "--verify cannot be used with --zip or --zip-create.")
+	}
+
+	checkTransferManifestSyntax(cliCtx)
+	if cliCtx.String("manifest") != "" && cliCtx.String("zip-create") != "" {
+		fatalIf(errDummy().Trace(cliCtx.Args()...), "--manifest cannot be used with --zip-create.")
+	}
+
 	if isZip && cliCtx.String("rewind") != "" {
 		fatalIf(errDummy().Trace(cliCtx.Args()...), "--zip and --rewind cannot be used together")
 	}
 
+	if archive := cliCtx.String("zip-create"); archive != "" {
+		if isZip {
+			fatalIf(errDummy().Trace(cliCtx.Args()...), "--zip and --zip-create cannot be used together.")
+		}
+		for _, flag := range []string{"resume", "checkpoint", "rewind", "version-id", "older-than", "newer-than", "sc-rule", "tags", rmFlag, rdFlag, lhFlag, "if-newer", "if-size-differ", "if-not-exists"} {
+			if cliCtx.IsSet(flag) {
+				fatalIf(errDummy().Trace(cliCtx.Args()...), fmt.Sprintf("--zip-create cannot be used with --%s.", flag))
+			}
+		}
+		if strings.HasSuffix(archive, "/") || strings.HasPrefix(archive, "/") {
+			fatalIf(errInvalidArgument().Trace(archive), "--zip-create expects an object name.")
+		}
+	}
+
 	// Check if bucket name is passed for URL type arguments.
 	url := newClientURL(tgtURL)
 	if url.Host != "" {
@@ -65,6 +104,14 @@ func checkCopySyntax(cliCtx *cli.Context) {
 		fatalIf(errInvalidArgument().Trace(), fmt.Sprintf("Both object retention flags `--%s` and `--%s` are required.\n", rdFlag, rmFlag))
 	}
 
+	if cliCtx.String("storage-class") != "" && cliCtx.String("sc-rule") != "" {
+		fatalIf(errInvalidArgument().Trace(), "--storage-class and --sc-rule cannot be used together.")
+	}
+	if rules := cliCtx.String("sc-rule"); rules != "" {
+		_, err := parseStorageClassRules(rules)
+		fatalIf(err.Trace(rules), "Invalid storage class rules.")
+	}
+
 	// Preserve functionality not supported for windows
 	if cliCtx.Bool("preserve") && runtime.GOOS == "windows" {
 		fatalIf(errInvalidArgument().Trace(), "Permissions are not preserved on windows platform.")
@@ -55,6 +55,7 @@ const (
 // functions to accurately report failure causes.
 func guessCopyURLType(ctx context.Context, o prepareCopyURLsOpts) (*copyURLsContent, *probe.Error) {
 	cc := new(copyURLsContent)
+	cc.unicodeNormalize = o.unicodeNormalize
 
 	// Extract alias before fiddling with the clientURL.
 	cc.sourceURL = o.sourceURLs[0]
@@ -176,7 +177,7 @@ func prepareCopyURLsTypeB(ctx context.Context, cc copyURLsContent, o prepareCopy
 func makeCopyContentTypeB(cc copyURLsContent) URLs {
 	// All OK.. We can proceed. Type B: source is a file, target is a folder and exists.
 	targetURLParse := newClientURL(cc.targetURL)
-	targetURLParse.Path = filepath.ToSlash(filepath.Join(targetURLParse.Path, filepath.Base(cc.sourceContent.URL.Path)))
+	targetURLParse.Path = filepath.ToSlash(filepath.Join(targetURLParse.Path, normalizeKey(cc.unicodeNormalize, filepath.Base(cc.sourceContent.URL.Path))))
 	cc.targetURL = targetURLParse.String()
 	return makeCopyContentTypeA(cc)
 }
@@ -228,14 +229,19 @@ func prepareCopyURLsTypeC(ctx context.Context, cc copyURLsContent, o prepareCopy
 	go func(sourceClient Client, cc copyURLsContent, o prepareCopyURLsOpts, copyURLsCh chan URLs) {
 		defer close(copyURLsCh)
 
-		for sourceContent := range sourceClient.List(ctx, ListOptions{Recursive: o.isRecursive, TimeRef: o.timeRef, ShowDir: DirNone, ListZip: o.isZip}) {
+		withSymlinks := o.symlinks == symlinksSkip || o.symlinks == symlinksPreserve
+		for sourceContent := range sourceClient.List(ctx, ListOptions{Recursive: o.isRecursive, TimeRef: o.timeRef, ShowDir: DirNone, ListZip: o.isZip, WithSymlinks: withSymlinks}) {
 			if sourceContent.Err != nil {
 				// Listing failed.
 				copyURLsCh <- URLs{Error: sourceContent.Err.Trace(sourceClient.GetURL().String())}
 				continue
 			}
 
-			if !sourceContent.Type.IsRegular() {
+			isSymlink := sourceContent.Symlink != ""
+			if isSymlink && o.symlinks == symlinksSkip {
+				continue
+			}
+			if !sourceContent.Type.IsRegular() && !isSymlink {
 				// Source is not a regular file. Skip it for copy.
 				continue
 			}
@@ -260,7 +266,7 @@ func makeCopyContentTypeC(cc copyURLsContent, sourceClientURL ClientURL) URLs {
 		sourcePrefix := filepath.ToSlash(sourceClientURL.Path[:pathSeparatorIndex])
 		newSourceSuffix = strings.TrimPrefix(newSourceSuffix, sourcePrefix)
 	}
-	newTargetURL := urlJoinPath(cc.targetURL, newSourceSuffix)
+	newTargetURL := urlJoinPath(cc.targetURL, normalizeKey(cc.unicodeNormalize, newSourceSuffix))
 	cc.targetURL = newTargetURL
 	return makeCopyContentTypeA(cc)
 }
@@ -316,6 +322,9 @@ type prepareCopyURLsOpts struct {
 	versionID               string
 	isZip                   bool
 	ignoreBucketExistsCheck bool
+	unicodeNormalize        string
+	// Handling of symbolic links of local sources, see --symlinks.
+	symlinks string
 }
 
 type copyURLsContent struct {
@@ -327,6 +336,8 @@ type copyURLsContent struct {
 	sourceURL       string
 	copyType        copyURLsType
 	sourceVersionID string
+	// Normalization form of the object names created on the target.
+	unicodeNormalize string
 }
 
 // prepareCopyURLs - prepares target and source clientURLs for copying.
@@ -0,0 +1,99 @@
+// Copyright (c) 2015-2024 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"path/filepath"
+
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/minio-go/v7/pkg/encrypt"
+)
+
+// verifyCopy compares the checksums of the source and the target of a
+// copy once it completed. Checksums of multipart and encrypted objects
+// cannot be compared with those of the source, both are then read back
+// and hashed.
+func verifyCopy(ctx context.Context, cpURLs URLs, encKeyDB map[string][]prefixSSEPair) *probe.Error {
+	sourceAlias, targetAlias := cpURLs.SourceAlias, cpURLs.TargetAlias
+	sourceContent := cpURLs.SourceContent
+	targetContent := &ClientContent{URL: cpURLs.TargetContent.URL}
+	sourcePath := filepath.ToSlash(filepath.Join(sourceAlias, sourceContent.URL.Path))
+	targetPath := filepath.ToSlash(filepath.Join(targetAlias, targetContent.URL.Path))
+	srcSSE := getSSE(sourcePath, encKeyDB[sourceAlias])
+	tgtSSE := getSSE(targetPath, encKeyDB[targetAlias])
+
+	sourceClnt, err := newClientFromAlias(sourceAlias, sourceContent.URL.String())
+	if err != nil {
+		return err.Trace(sourcePath)
+	}
+	targetClnt, err := newClientFromAlias(targetAlias, targetContent.URL.String())
+	if err != nil {
+		return err.Trace(targetPath)
+	}
+
+	srcSums, err := verifyChecksums(ctx, sourceClnt, sourceContent, srcSSE)
+	if err != nil {
+		return err.Trace(sourcePath)
+	}
+	tgtSums, err := verifyChecksums(ctx, targetClnt, targetContent, tgtSSE)
+	if err != nil {
+		return err.Trace(targetPath)
+	}
+	if equal, ok := checksumsEqual(srcSums, tgtSums); ok && equal {
+		return nil
+	}
+
+	srcSum, err := readBackChecksum(ctx, sourceClnt, sourceContent.VersionID, srcSSE)
+	if err != nil {
+		return err.Trace(sourcePath)
+	}
+	tgtSum, err := readBackChecksum(ctx, targetClnt, "", tgtSSE)
+	if err != nil {
+		return err.Trace(targetPath)
+	}
+	if srcSum != tgtSum {
+		return errChecksumMismatch(sourcePath, targetPath)
+	}
+	return nil
+}
+
+// verifyChecksums returns the checksums of an object or of a file.
+func verifyChecksums(ctx context.Context, clnt Client, content *ClientContent, sse encrypt.ServerSide) (map[string]string, *probe.Error) {
+	if c, ok := clnt.(*S3Client); ok {
+		return c.objectChecksums(ctx, content, sse)
+	}
+	return contentChecksums(ctx, clnt, content)
+}
+
+// readBackChecksum reads an object or a file and returns its SHA256.
+func readBackChecksum(ctx context.Context, clnt Client, versionID string, sse encrypt.ServerSide) (string, *probe.Error) {
+	reader, _, err := clnt.Get(ctx, GetOptions{VersionID: versionID, SSE: sse})
+	if err != nil {
+		return "", err
+	}
+	defer reader.Close()
+	h := sha256.New()
+	if _, e := io.Copy(h, reader); e != nil {
+		return "", probe.NewError(e)
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
@@ -0,0 +1,107 @@
+// Copyright (c) 2015-2024 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestVerifyCopy(t *testing.T) {
+	useTestMcConfig(t)
+
+	dir := t.TempDir()
+	source := filepath.Join(dir, "source")
+	if e := os.WriteFile(source, []byte("hello world"), 0o644); e != nil {
+		t.Fatal(e)
+	}
+
+	testCases := []struct {
+		target   string
+		content  string
+		mismatch bool
+		fail     bool
+	}{
+		// Identical copy.
+		{"same", "hello world", false, false},
+		// Corrupted copy, also read back and hashed.
+		{"corrupted", "hello wOrld", true, true},
+		{"truncated", "hello", true, true},
+		// Missing copy.
+		{"missing", "", false, true},
+	}
+	for i, testCase := range testCases {
+		target := filepath.Join(dir, testCase.target)
+		if testCase.target != "missing" {
+			if e := os.WriteFile(target, []byte(testCase.content), 0o644); e != nil {
+				t.Fatal(e)
+			}
+		}
+
+		err := verifyCopy(context.Background(), URLs{
+			SourceContent: &ClientContent{URL: *newClientURL(source), Size: 11},
+			TargetContent: &ClientContent{URL: *newClientURL(target)},
+		}, nil)
+		if !testCase.fail {
+			if err != nil {
+				t.Errorf("Test %d: unexpected error %v", i+1, err)
+			}
+			continue
+		}
+		if err == nil {
+			t.Errorf("Test %d: expected an error", i+1)
+			continue
+		}
+		var mismatchErr checksumMismatchErr
+		if mismatch := errors.As(err.ToGoError(), &mismatchErr); mismatch != testCase.mismatch {
+			t.Errorf("Test %d: expected a checksum mismatch %v, got %v", i+1, testCase.mismatch, err)
+		}
+	}
+}
+
+func TestReadBackChecksum(t *testing.T) {
+	useTestMcConfig(t)
+
+	file := filepath.Join(t.TempDir(), "object")
+	if e := os.WriteFile(file, []byte("hello world"), 0o644); e != nil {
+		t.Fatal(e)
+	}
+	clnt, err := newClientFromAlias("", file)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sum, err := readBackChecksum(context.Background(), clnt, "", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := sha256.Sum256([]byte("hello world"))
+	if sum != hex.EncodeToString(want[:]) {
+		t.Fatalf("expected %x, got %s", want, sum)
+	}
+
+	os.Remove(file)
+	if _, err = readBackChecksum(context.Background(), clnt, "", nil); err == nil {
+		t.Fatal("expected an error reading back a missing file")
+	}
+}
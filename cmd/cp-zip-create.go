@@ -0,0 +1,383 @@
+// Copyright (c) 2015-2024 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/minio/cli"
+	"github.com/minio/mc/pkg/probe"
+)
+
+// Metadata keys of the index of a zip archive created by cp --zip-create.
+const (
+	zipIndexEntriesKey   = "Mc-Zip-Entries"
+	zipIndexDirectoryKey = "Mc-Zip-Directory"
+	zipIndexMembersKey   = "Mc-Zip-Index"
+
+	// Maximum length of the members index, S3 limits the
+	// user metadata of an object to 2KiB.
+	zipIndexMembersMaxLen = 1024
+)
+
+// zipCreateEntry is a source object added to the archive.
+type zipCreateEntry struct {
+	alias   string
+	content *ClientContent
+	name    string
+}
+
+// zipIndex describes where the members and the central directory of
+// an archive are, for clients reading the members with ranged GETs.
+type zipIndex struct {
+	directoryOffset int64
+	directorySize   int64
+	members         []zipIndexMember
+}
+
+// zipIndexMember is the range of the local header and data of a member.
+type zipIndexMember struct {
+	name   string
+	offset int64
+	size   int64
+}
+
+// metadata returns the index as user metadata of the archive, the
+// members are only listed if the index fits in the metadata.
+func (z zipIndex) metadata() map[string]string {
+	metadata := map[string]string{
+		zipIndexEntriesKey:   strconv.Itoa(len(z.members)),
+		zipIndexDirectoryKey: fmt.Sprintf("%d-%d", z.directoryOffset, z.directorySize),
+	}
+	members := make([]string, 0, len(z.members))
+	length := 0
+	for _, m := range z.members {
+		member := fmt.Sprintf("%s:%d-%d", url.QueryEscape(m.name), m.offset, m.size)
+		length += len(member) + 1
+		if length > zipIndexMembersMaxLen {
+			return metadata
+		}
+		members = append(members, member)
+	}
+	metadata[zipIndexMembersKey] = strings.Join(members, ",")
+	return metadata
+}
+
+// countingWriter counts the bytes written to the archive, and keeps
+// the bytes written once tail is set.
+type countingWriter struct {
+	w    io.Writer
+	n    int64
+	tail *bytes.Buffer
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	n, e := c.w.Write(p)
+	c.n += int64(n)
+	if c.tail != nil {
+		c.tail.Write(p[:n])
+	}
+	return n, e
+}
+
+// parseZipDirectory returns the index of an archive of size bytes from
+// its tail, which must hold the whole central directory.
+func parseZipDirectory(tail []byte, size int64) (index zipIndex, e error) {
+	const (
+		directoryEndLen     = 22
+		directory64LocLen   = 20
+		directoryHeaderLen  = 46
+		directoryHeaderSig  = 0x02014b50
+		directoryEndSig     = 0x06054b50
+		directory64EndSig   = 0x06064b50
+		zip64ExtraID        = 0x0001
+		uint32Max           = 0xffffffff
+		errCorruptDirectory = "unable to parse the zip central directory"
+	)
+	le := binary.LittleEndian
+	tailOffset := size - int64(len(tail))
+	if len(tail) < directoryEndLen {
+		return index, errors.New(errCorruptDirectory)
+	}
+	end := tail[len(tail)-directoryEndLen:]
+	if le.Uint32(end) != directoryEndSig {
+		return index, errors.New(errCorruptDirectory)
+	}
+	index.directorySize = int64(le.Uint32(end[12:]))
+	index.directoryOffset = int64(le.Uint32(end[16:]))
+	if uint32(index.directoryOffset) == uint32Max || uint32(index.directorySize) == uint32Max {
+		// zip64 archive, the end record is located by the locator.
+		loc := len(tail) - directoryEndLen - directory64LocLen
+		if loc < 0 {
+			return index, errors.New(errCorruptDirectory)
+		}
+		end64 := int(int64(le.Uint64(tail[loc+8:])) - tailOffset)
+		if end64 < 0 || end64+56 > len(tail) || le.Uint32(tail[end64:]) != directory64EndSig {
+			return index, errors.New(errCorruptDirectory)
+		}
+		index.directorySize = int64(le.Uint64(tail[end64+40:]))
+		index.directoryOffset = int64(le.Uint64(tail[end64+48:]))
+	}
+
+	p := int(index.directoryOffset - tailOffset)
+	if p < 0 || p+int(index.directorySize) > len(tail) {
+		return index, errors.New(errCorruptDirectory)
+	}
+	directory := tail[p : p+int(index.directorySize)]
+	for len(directory) >= directoryHeaderLen && le.Uint32(directory) == directoryHeaderSig {
+		nameLen := int(le.Uint16(directory[28:]))
+		extraLen := int(le.Uint16(directory[30:]))
+		commentLen := int(le.Uint16(directory[32:]))
+		recordLen := directoryHeaderLen + nameLen + extraLen + commentLen
+		if len(directory) < recordLen {
+			return index, errors.New(errCorruptDirectory)
+		}
+		member := zipIndexMember{
+			name:   string(directory[directoryHeaderLen : directoryHeaderLen+nameLen]),
+			offset: int64(le.Uint32(directory[42:])),
+		}
+		if uint32(member.offset) == uint32Max {
+			// The offset is the last value of the zip64 extra field
+			// written by archive/zip, after the sizes.
+			extra := directory[directoryHeaderLen+nameLen : directoryHeaderLen+nameLen+extraLen]
+			for len(extra) >= 4 {
+				id, n := le.Uint16(extra), int(le.Uint16(extra[2:]))
+				if len(extra) < 4+n {
+					break
+				}
+				if id == zip64ExtraID && n >= 8 {
+					member.offset = int64(le.Uint64(extra[4+n-8:]))
+				}
+				extra = extra[4+n:]
+			}
+		}
+		index.members = append(index.members, member)
+		directory = directory[recordLen:]
+	}
+	// The members are contiguous, each one ends where the next one starts.
+	for i := range index.members {
+		next := index.directoryOffset
+		if i+1 < len(index.members) {
+			next = index.members[i+1].offset
+		}
+		index.members[i].size = next - index.members[i].offset
+	}
+	return index, nil
+}
+
+// progressWriter advances the progress with the bytes read from the sources.
+type progressWriter struct {
+	progress io.Reader
+}
+
+func (p progressWriter) Write(b []byte) (int, error) {
+	return p.progress.Read(b)
+}
+
+// listZipCreateEntries lists the source objects to add to the archive, the
+// member names are relative to the source, prefixed with the base name of
+// the source when it doesn't end with a separator like cp does.
+func listZipCreateEntries(ctx context.Context, sourceURLs []string, isRecursive bool, encKeyDB map[string][]prefixSSEPair) ([]zipCreateEntry, *probe.Error) {
+	var entries []zipCreateEntry
+	for _, sourceURL := range sourceURLs {
+		alias, _ := url2Alias(sourceURL)
+		clnt, err := newClient(sourceURL)
+		if err != nil {
+			return nil, err.Trace(sourceURL)
+		}
+		content, err := clnt.Stat(ctx, StatOptions{sse: getSSE(sourceURL, encKeyDB[alias])})
+		if err != nil {
+			return nil, err.Trace(sourceURL)
+		}
+		if !content.Type.IsDir() {
+			entries = append(entries, zipCreateEntry{alias: alias, content: content, name: path.Base(content.URL.Path)})
+			continue
+		}
+		if !isRecursive {
+			return nil, errInvalidArgument().Trace(sourceURL, "is a folder, use --recursive")
+		}
+
+		separator := string(clnt.GetURL().Separator)
+		basePath := clnt.GetURL().Path
+		if !strings.HasSuffix(basePath, separator) {
+			basePath = strings.TrimSuffix(basePath, path.Base(basePath))
+		}
+		for content := range clnt.List(ctx, ListOptions{Recursive: true, ShowDir: DirNone}) {
+			if content.Err != nil {
+				return nil, content.Err.Trace(sourceURL)
+			}
+			if content.Type.IsDir() {
+				continue
+			}
+			name := strings.TrimPrefix(content.URL.Path, basePath)
+			name = strings.TrimPrefix(strings.ReplaceAll(name, separator, "/"), "/")
+			entries = append(entries, zipCreateEntry{alias: alias, content: content, name: name})
+		}
+	}
+	return entries, nil
+}
+
+// writeZipArchive writes the entries to w as a zip archive and returns its index.
+func writeZipArchive(ctx context.Context, w io.Writer, entries []zipCreateEntry, encKeyDB map[string][]prefixSSEPair, progress io.Reader) (index zipIndex, err *probe.Error) {
+	cw := &countingWriter{w: w}
+	zw := zip.NewWriter(cw)
+	for _, entry := range entries {
+		sourceURL := entry.content.URL.String()
+		reader, _, err := getSourceStream(ctx, entry.alias, sourceURL, getSourceOpts{
+			GetOptions: GetOptions{
+				SSE:       getSSE(path.Join(entry.alias, entry.content.URL.Path), encKeyDB[entry.alias]),
+				VersionID: entry.content.VersionID,
+			},
+		})
+		if err != nil {
+			return index, err.Trace(sourceURL)
+		}
+
+		header := &zip.FileHeader{
+			Name:     entry.name,
+			Method:   zip.Deflate,
+			Modified: entry.content.Time,
+		}
+		fw, e := zw.CreateHeader(header)
+		if e == nil {
+			var r io.Reader = reader
+			if progress != nil {
+				r = io.TeeReader(reader, progressWriter{progress})
+			}
+			_, e = io.Copy(fw, r)
+		}
+		reader.Close()
+		if e != nil {
+			return index, probe.NewError(e).Trace(sourceURL)
+		}
+	}
+	// Keep the central directory written on close to build the index.
+	if e := zw.Flush(); e != nil {
+		return index, probe.NewError(e)
+	}
+	cw.tail = &bytes.Buffer{}
+	if e := zw.Close(); e != nil {
+		return index, probe.NewError(e)
+	}
+	index, e := parseZipDirectory(cw.tail.Bytes(), cw.n)
+	if e != nil {
+		return index, probe.NewError(e)
+	}
+	return index, nil
+}
+
+// doCopyZipCreate streams the sources into a single zip archive named
+// archiveName under the target. The index of the archive is saved in its
+// metadata once uploaded, with a server-side copy of the archive onto
+// itself, since the index is only known after the archive is streamed.
+func doCopyZipCreate(ctx context.Context, cliCtx *cli.Context, encKeyDB map[string][]prefixSSEPair) error {
+	args := cliCtx.Args()
+	sourceURLs := args[:len(args)-1]
+	archiveURL := urlJoinPath(args[len(args)-1], cliCtx.String("zip-create"))
+
+	entries, err := listZipCreateEntries(ctx, sourceURLs, cliCtx.Bool("recursive"), encKeyDB)
+	fatalIf(err, "Unable to list the sources of the archive.")
+
+	var totalBytes int64
+	for _, entry := range entries {
+		totalBytes += entry.content.Size
+	}
+	var pg ProgressReader
+	if !globalQuiet && !globalJSON {
+		pg = newProgressBar(totalBytes).SetCaption(archiveURL + ":")
+	} else {
+		pg = newAccounter(totalBytes)
+		for _, entry := range entries {
+			printMsg(copyMessage{
+				Source:     path.Join(entry.alias, entry.content.URL.Path),
+				Target:     archiveURL + "/" + entry.name,
+				Size:       entry.content.Size,
+				TotalCount: int64(len(entries)),
+				TotalSize:  totalBytes,
+			})
+		}
+	}
+
+	alias, archiveURLFull, _, err := expandAlias(archiveURL)
+	fatalIf(err.Trace(archiveURL), "Unable to initialize target `"+archiveURL+"`.")
+	targetClnt, err := newClientFromAlias(alias, archiveURLFull)
+	fatalIf(err.Trace(archiveURL), "Unable to initialize target `"+archiveURL+"`.")
+
+	metadata := map[string]string{"Content-Type": "application/zip"}
+	if attr := cliCtx.String("attr"); attr != "" {
+		userMetaMap, _ := getMetaDataEntry(attr)
+		for k, v := range userMetaMap {
+			metadata[k] = v
+		}
+	}
+	sse := getSSE(archiveURL, encKeyDB[alias])
+
+	pr, pw := io.Pipe()
+	indexCh := make(chan zipIndex, 1)
+	go func() {
+		index, err := writeZipArchive(ctx, pw, entries, encKeyDB, pg)
+		if err != nil {
+			pw.CloseWithError(err.ToGoError())
+			return
+		}
+		indexCh <- index
+		pw.Close()
+	}()
+	_, err = targetClnt.Put(ctx, pr, -1, nil, PutOptions{
+		metadata:     metadata,
+		sse:          sse,
+		storageClass: cliCtx.String("storage-class"),
+	})
+	pr.CloseWithError(io.ErrClosedPipe)
+	fatalIf(err.Trace(archiveURL), "Unable to create the archive `"+archiveURL+"`.")
+	index := <-indexCh
+
+	if s3Clnt, ok := targetClnt.(*S3Client); ok {
+		content, err := s3Clnt.Stat(ctx, StatOptions{sse: sse})
+		fatalIf(err.Trace(archiveURL), "Unable to save the index of the archive `"+archiveURL+"`.")
+		for k, v := range index.metadata() {
+			metadata[k] = v
+		}
+		err = s3Clnt.Copy(ctx, s3Clnt.GetURL().Path, CopyOptions{
+			versionID:    content.VersionID,
+			size:         content.Size,
+			srcSSE:       sse,
+			tgtSSE:       sse,
+			metadata:     metadata,
+			storageClass: cliCtx.String("storage-class"),
+		}, nil)
+		fatalIf(err.Trace(archiveURL), "Unable to save the index of the archive `"+archiveURL+"`.")
+	}
+
+	if progressReader, ok := pg.(*progressBar); ok {
+		progressReader.Finish()
+	} else if accntReader, ok := pg.(*accounter); ok {
+		printMsg(accntReader.Stat())
+	}
+	return nil
+}
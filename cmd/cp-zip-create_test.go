@@ -0,0 +1,74 @@
+// Copyright (c) 2015-2024 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/binary"
+	"strings"
+	"testing"
+)
+
+func TestParseZipDirectory(t *testing.T) {
+	names := []string{"a.txt", "dir/b.txt", "dir/sub/c.bin"}
+
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for i, name := range names {
+		w, e := zw.CreateHeader(&zip.FileHeader{Name: name, Method: zip.Deflate})
+		if e != nil {
+			t.Fatal(e)
+		}
+		w.Write([]byte(strings.Repeat(name, 100*(i+1))))
+	}
+	if e := zw.Close(); e != nil {
+		t.Fatal(e)
+	}
+	archive := buf.Bytes()
+
+	// Only the end of the archive is needed.
+	tail := archive[len(archive)/2:]
+	index, e := parseZipDirectory(tail, int64(len(archive)))
+	if e != nil {
+		t.Fatal(e)
+	}
+	if len(index.members) != len(names) {
+		t.Fatalf("expected %d members, got %d", len(names), len(index.members))
+	}
+	if index.directoryOffset+index.directorySize > int64(len(archive)) {
+		t.Fatalf("invalid central directory %d-%d", index.directoryOffset, index.directorySize)
+	}
+	var end int64
+	for i, m := range index.members {
+		if m.name != names[i] || m.offset != end {
+			t.Fatalf("member %d: unexpected %s at %d", i, m.name, m.offset)
+		}
+		if binary.LittleEndian.Uint32(archive[m.offset:]) != 0x04034b50 {
+			t.Fatalf("member %d: no local header at %d", i, m.offset)
+		}
+		end = m.offset + m.size
+	}
+	if end != index.directoryOffset {
+		t.Fatalf("members end at %d, central directory starts at %d", end, index.directoryOffset)
+	}
+
+	if _, e = parseZipDirectory(archive[:len(archive)-1], int64(len(archive)-1)); e == nil {
+		t.Fatal("expected an error for a truncated archive")
+	}
+}
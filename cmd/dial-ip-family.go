@@ -0,0 +1,143 @@
+// Copyright (c) 2015-2024 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"time"
+)
+
+// Values of the --ip-family flag.
+const (
+	ipFamilyAuto    = "auto"
+	ipFamilyV4      = "4"
+	ipFamilyV6      = "6"
+	ipFamilyPrefer4 = "prefer-4"
+	ipFamilyPrefer6 = "prefer-6"
+)
+
+// parseIPFamily validates the value of --ip-family.
+func parseIPFamily(family string) (string, error) {
+	switch family {
+	case "", ipFamilyAuto:
+		return ipFamilyAuto, nil
+	case ipFamilyV4, ipFamilyV6, ipFamilyPrefer4, ipFamilyPrefer6:
+		return family, nil
+	}
+	return "", fmt.Errorf("invalid IP family %s, expected one of auto, 4, 6, prefer-4 or prefer-6", family)
+}
+
+// dialIPFamily dials addr with the IP family set by --ip-family. The
+// addresses of the preferred family are dialed first, the other family
+// is dialed if they fail or do not connect before the happy eyeballs
+// delay, the first connection established wins.
+func dialIPFamily(ctx context.Context, dialer *net.Dialer, network, addr string) (net.Conn, error) {
+	dialer.FallbackDelay = globalHappyEyeballsDelay
+	if network != "tcp" {
+		return dialer.DialContext(ctx, network, addr)
+	}
+	switch globalIPFamily {
+	case ipFamilyV4:
+		return dialer.DialContext(ctx, "tcp4", addr)
+	case ipFamilyV6:
+		return dialer.DialContext(ctx, "tcp6", addr)
+	case ipFamilyPrefer4:
+		return dialPreferred(ctx, dialer, "tcp4", "tcp6", addr)
+	case ipFamilyPrefer6:
+		return dialPreferred(ctx, dialer, "tcp6", "tcp4", addr)
+	}
+	return dialer.DialContext(ctx, network, addr)
+}
+
+// dialPreferred races the dial of addr over the primary network with the
+// dial over the fallback network, started after the fallback delay of
+// dialer or as soon as the primary one fails. A negative delay disables
+// the race, the fallback network is only dialed on failure.
+func dialPreferred(ctx context.Context, dialer *net.Dialer, primary, fallback, addr string) (net.Conn, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	type dialResult struct {
+		conn    net.Conn
+		err     error
+		primary bool
+	}
+	results := make(chan dialResult, 2)
+	dial := func(network string, primary bool) {
+		conn, err := dialer.DialContext(ctx, network, addr)
+		results <- dialResult{conn: conn, err: err, primary: primary}
+	}
+
+	var fallbackTimer <-chan time.Time
+	if delay := dialer.FallbackDelay; delay >= 0 {
+		if delay == 0 {
+			// Same default as the standard library.
+			delay = 300 * time.Millisecond
+		}
+		timer := time.NewTimer(delay)
+		defer timer.Stop()
+		fallbackTimer = timer.C
+	}
+
+	go dial(primary, true)
+	pending, fallbackStarted := 1, false
+	startFallback := func() {
+		if !fallbackStarted {
+			fallbackStarted = true
+			pending++
+			go dial(fallback, false)
+		}
+	}
+
+	var primaryErr, fallbackErr error
+	for {
+		select {
+		case <-fallbackTimer:
+			fallbackTimer = nil
+			startFallback()
+		case res := <-results:
+			pending--
+			if res.err == nil {
+				if pending > 0 {
+					// The losing dial is canceled, close its connection
+					// if it was established in the meantime.
+					go func() {
+						if lost := <-results; lost.conn != nil {
+							lost.conn.Close()
+						}
+					}()
+				}
+				return res.conn, nil
+			}
+			if res.primary {
+				primaryErr = res.err
+				startFallback()
+			} else {
+				fallbackErr = res.err
+			}
+			if pending == 0 {
+				if primaryErr != nil {
+					return nil, primaryErr
+				}
+				return nil, fallbackErr
+			}
+		}
+	}
+}
@@ -0,0 +1,70 @@
+// Copyright (c) 2015-2024 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+)
+
+func TestDialPreferred(t *testing.T) {
+	l, e := net.Listen("tcp4", "127.0.0.1:0")
+	if e != nil {
+		t.Fatal(e)
+	}
+	defer l.Close()
+	go func() {
+		for {
+			conn, e := l.Accept()
+			if e != nil {
+				return
+			}
+			conn.Close()
+		}
+	}()
+	_, port, _ := net.SplitHostPort(l.Addr().String())
+	addr := net.JoinHostPort("127.0.0.1", port)
+
+	// The IPv6 dial of an IPv4 address fails, IPv4 is dialed right away
+	// with or without the fallback race.
+	for _, delay := range []time.Duration{0, -1} {
+		conn, e := dialPreferred(context.Background(), &net.Dialer{FallbackDelay: delay}, "tcp6", "tcp4", addr)
+		if e != nil {
+			t.Fatalf("delay %v: unexpected error %v", delay, e)
+		}
+		conn.Close()
+	}
+
+	// Neither IPv6 dial succeeds, the error of the preferred family is returned.
+	if _, e = dialPreferred(context.Background(), &net.Dialer{}, "tcp6", "tcp6", addr); e == nil {
+		t.Fatal("expected an error")
+	}
+}
+
+func TestParseIPFamily(t *testing.T) {
+	for family, expected := range map[string]string{"": ipFamilyAuto, "auto": ipFamilyAuto, "4": ipFamilyV4, "prefer-6": ipFamilyPrefer6} {
+		if got, e := parseIPFamily(family); e != nil || got != expected {
+			t.Fatalf("%q: expected %q, got %q (%v)", family, expected, got, e)
+		}
+	}
+	if _, e := parseIPFamily("ipv4"); e == nil {
+		t.Fatal("expected an error")
+	}
+}
@@ -20,6 +20,7 @@ package cmd
 import (
 	"context"
 	"fmt"
+	"path/filepath"
 	"strings"
 	"time"
 
@@ -32,7 +33,18 @@ import (
 
 // diff specific flags.
 var (
-	diffFlags = []cli.Flag{}
+	diffFlags = []cli.Flag{
+		cli.StringFlag{
+			Name:  "compare",
+			Usage: "compare objects by 'size' and modification time, or by 'checksum'",
+			Value: compareSize,
+		},
+		cli.IntFlag{
+			Name:  "workers",
+			Usage: "number of top level prefixes listed and compared at the same time, differences are not sorted with more than one",
+			Value: 1,
+		},
+	}
 )
 
 // Compute differences in object name, size, and date between two buckets.
@@ -48,17 +60,30 @@ var diffCmd = cli.Command{
 
 USAGE:
   {{.HelpName}} [FLAGS] SOURCE TARGET
+  {{.HelpName}} [FLAGS] FIRST SECOND ANCESTOR
 
 FLAGS:
   {{range .VisibleFlags}}{{.}}
   {{end}}
 DESCRIPTION:
-  Diff only calculates differences in object name, size and time. It *DOES NOT* compare objects' contents.
+  Diff only calculates differences in object name, size and time. It *DOES NOT* compare objects' contents,
+  unless '--compare checksum' is set to compare objects of the same size by their SHA256, CRC32C, CRC32,
+  SHA1 checksum or their ETag.
 
 LEGEND:
   < - object is only in source.
   > - object is only in destination.
-  ! - newer object is in source.
+  ! - newer object is in source, or object content differs with '--compare checksum'.
+
+THREE-WAY DIFF:
+  With ANCESTOR, the objects of two sites updated independently, like the sites of an
+  active-active mirror after a network partition, are compared with their common ancestor.
+  ANCESTOR is a folder or a bucket, or a snapshot saved with 'mc ls --recursive --json'.
+  Objects are compared by size and ETag, or modification time when they have no ETag.
+
+  < - object was created, modified or deleted on the first site only.
+  > - object was created, modified or deleted on the second site only.
+  ! - object changed differently on both sites, it is in conflict.
 
 EXAMPLES:
   1. Compare a local folder with a folder on Amazon S3 cloud storage.
@@ -66,6 +91,17 @@ EXAMPLES:
 
   2. Compare two folders on a local filesystem.
      {{.Prompt}} {{.HelpName}} ~/Photos /Media/Backup/Photos
+
+  3. Compare the content of two buckets by checksum.
+     {{.Prompt}} {{.HelpName}} --compare checksum play/photos s3/photos
+
+  4. Find the objects which diverged between two active-active sites since a snapshot
+     taken before the network partition.
+     {{.Prompt}} mc ls --recursive --json site1/photos > photos-snapshot.json
+     {{.Prompt}} {{.HelpName}} site1/photos site2/photos photos-snapshot.json
+
+  5. Compare two large buckets listing and comparing 16 top level prefixes at the same time.
+     {{.Prompt}} {{.HelpName}} --workers 16 play/photos s3/photos
 `,
 }
 
@@ -96,6 +132,8 @@ func (d diffMessage) String() string {
 		msg = console.Colorize("DiffMetadata", "! "+d.SecondURL)
 	case differInAASourceMTime:
 		msg = console.Colorize("DiffMMSourceMTime", "! "+d.SecondURL)
+	case differInChecksum:
+		msg = console.Colorize("DiffChecksum", "! "+d.SecondURL)
 	case differInNone:
 		msg = console.Colorize("DiffInNone", "= "+d.FirstURL)
 	default:
@@ -115,7 +153,7 @@ func (d diffMessage) JSON() string {
 }
 
 func checkDiffSyntax(ctx context.Context, cliCtx *cli.Context, encKeyDB map[string][]prefixSSEPair) {
-	if len(cliCtx.Args()) != 2 {
+	if len(cliCtx.Args()) != 2 && len(cliCtx.Args()) != 3 {
 		showCommandHelpAndExit(cliCtx, 1) // last argument is exit code
 	}
 	for _, arg := range cliCtx.Args() {
@@ -123,6 +161,18 @@ func checkDiffSyntax(ctx context.Context, cliCtx *cli.Context, encKeyDB map[stri
 			fatalIf(errInvalidArgument().Trace(cliCtx.Args()...), "Unable to validate empty argument.")
 		}
 	}
+	if mode := cliCtx.String("compare"); !isValidCompareMode(mode) {
+		fatalIf(errInvalidArgument().Trace(mode), "`--compare` must be either 'size' or 'checksum'.")
+	}
+	if len(cliCtx.Args()) == 3 && cliCtx.IsSet("compare") {
+		fatalIf(errInvalidArgument().Trace(cliCtx.Args()...), "`--compare` cannot be used with a common ancestor.")
+	}
+	if cliCtx.Int("workers") < 1 {
+		fatalIf(errInvalidArgument().Trace(cliCtx.Args()...), "`--workers` must be at least 1.")
+	}
+	if len(cliCtx.Args()) == 3 && cliCtx.IsSet("workers") {
+		fatalIf(errInvalidArgument().Trace(cliCtx.Args()...), "`--workers` cannot be used with a common ancestor.")
+	}
 	URLs := cliCtx.Args()
 	firstURL := URLs[0]
 	secondURL := URLs[1]
@@ -156,7 +206,7 @@ func checkDiffSyntax(ctx context.Context, cliCtx *cli.Context, encKeyDB map[stri
 }
 
 // doDiffMain runs the diff.
-func doDiffMain(ctx context.Context, firstURL, secondURL string) error {
+func doDiffMain(ctx context.Context, firstURL, secondURL, compare string, workers int) error {
 	// Source and targets are always directories
 	sourceSeparator := string(newClientURL(firstURL).Separator)
 	if !strings.HasSuffix(firstURL, sourceSeparator) {
@@ -171,6 +221,19 @@ func doDiffMain(ctx context.Context, firstURL, secondURL string) error {
 	firstAlias, firstURL, _ := mustExpandAlias(firstURL)
 	secondAlias, secondURL, _ := mustExpandAlias(secondURL)
 
+	// The prefixes compared by the workers are joined to the URLs,
+	// they need the absolute path of a filesystem.
+	if firstAlias == "" {
+		if absURL, e := filepath.Abs(firstURL); e == nil {
+			firstURL = absURL + sourceSeparator
+		}
+	}
+	if secondAlias == "" {
+		if absURL, e := filepath.Abs(secondURL); e == nil {
+			secondURL = absURL + targetSeparator
+		}
+	}
+
 	firstClient, err := newClientFromAlias(firstAlias, firstURL)
 	if err != nil {
 		fatalIf(err.Trace(firstAlias, firstURL, secondAlias, secondURL),
@@ -184,7 +247,7 @@ func doDiffMain(ctx context.Context, firstURL, secondURL string) error {
 	}
 
 	// Diff first and second urls.
-	for diffMsg := range bucketObjectDifference(ctx, firstClient, secondClient) {
+	for diffMsg := range bucketObjectDifference(ctx, firstAlias, firstURL, firstClient, secondAlias, secondURL, secondClient, compare, workers) {
 		if diffMsg.Error != nil {
 			errorIf(diffMsg.Error, "Unable to calculate objects difference.")
 			// Ignore error and proceed to next object.
@@ -216,10 +279,15 @@ func mainDiff(cliCtx *cli.Context) error {
 	console.SetColor("DiffSize", color.New(color.FgYellow, color.Bold))
 	console.SetColor("DiffMetadata", color.New(color.FgYellow, color.Bold))
 	console.SetColor("DiffMMSourceMTime", color.New(color.FgYellow, color.Bold))
+	console.SetColor("DiffChecksum", color.New(color.FgYellow, color.Bold))
+	console.SetColor("DiffConflict", color.New(color.FgRed, color.Bold))
 
 	URLs := cliCtx.Args()
 	firstURL := URLs.Get(0)
 	secondURL := URLs.Get(1)
+	if ancestorURL := URLs.Get(2); ancestorURL != "" {
+		return doDiff3Main(ctx, firstURL, secondURL, ancestorURL)
+	}
 
-	return doDiffMain(ctx, firstURL, secondURL)
+	return doDiffMain(ctx, firstURL, secondURL, cliCtx.String("compare"), cliCtx.Int("workers"))
 }
@@ -0,0 +1,282 @@
+// Copyright (c) 2015-2024 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bufio"
+	"context"
+	gojson "encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	json "github.com/minio/colorjson"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/pkg/v3/console"
+)
+
+// Classes of the objects which diverged in a three-way diff.
+const (
+	diverged3WayFirst    = "first-newer"
+	diverged3WaySecond   = "second-newer"
+	diverged3WayConflict = "conflict"
+)
+
+// Changes of an object since the common ancestor.
+const (
+	change3WayCreated  = "created"
+	change3WayModified = "modified"
+	change3WayDeleted  = "deleted"
+)
+
+// diff3State is the state of an object on one side of a three-way diff.
+type diff3State struct {
+	Size    int64     `json:"size"`
+	ETag    string    `json:"etag,omitempty"`
+	ModTime time.Time `json:"lastModified"`
+}
+
+// sameDiff3State returns true if both states are the same object, or the
+// object is missing from both. Objects are compared by ETag when both
+// have one, by modification time otherwise, to the second unless both
+// times are more precise.
+func sameDiff3State(a, b *diff3State) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	if a.Size != b.Size {
+		return false
+	}
+	if a.ETag != "" && b.ETag != "" {
+		return a.ETag == b.ETag
+	}
+	if a.ModTime.Nanosecond() != 0 && b.ModTime.Nanosecond() != 0 {
+		return a.ModTime.Equal(b.ModTime)
+	}
+	return a.ModTime.Truncate(time.Second).Equal(b.ModTime.Truncate(time.Second))
+}
+
+// diff3Change returns how an object changed since the ancestor.
+func diff3Change(ancestor, state *diff3State) string {
+	switch {
+	case ancestor == nil:
+		return change3WayCreated
+	case state == nil:
+		return change3WayDeleted
+	}
+	return change3WayModified
+}
+
+// diff3Message reports an object which diverged between the first and
+// the second site since their common ancestor.
+type diff3Message struct {
+	Status       string      `json:"status"`
+	Key          string      `json:"key"`
+	Diff         string      `json:"diff"`
+	FirstChange  string      `json:"firstChange,omitempty"`
+	SecondChange string      `json:"secondChange,omitempty"`
+	First        *diff3State `json:"first,omitempty"`
+	Second       *diff3State `json:"second,omitempty"`
+	Ancestor     *diff3State `json:"ancestor,omitempty"`
+}
+
+// String colorized three-way diff message.
+func (d diff3Message) String() string {
+	switch d.Diff {
+	case diverged3WayFirst:
+		return console.Colorize("DiffOnlyInFirst", "< "+d.Key+" ("+d.FirstChange+" on first)")
+	case diverged3WaySecond:
+		return console.Colorize("DiffOnlyInSecond", "> "+d.Key+" ("+d.SecondChange+" on second)")
+	}
+	return console.Colorize("DiffConflict", "! "+d.Key+" ("+d.FirstChange+" on first, "+d.SecondChange+" on second)")
+}
+
+// JSON jsonified three-way diff message.
+func (d diff3Message) JSON() string {
+	d.Status = "success"
+	jsonBytes, e := json.MarshalIndent(d, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+	return string(jsonBytes)
+}
+
+// diff3SummaryMessage counts the objects which diverged by class.
+type diff3SummaryMessage struct {
+	Status      string `json:"status"`
+	FirstNewer  int    `json:"firstNewer"`
+	SecondNewer int    `json:"secondNewer"`
+	Conflicts   int    `json:"conflicts"`
+}
+
+// String colorized three-way diff summary.
+func (s diff3SummaryMessage) String() string {
+	return console.Colorize("DiffMessage", fmt.Sprintf("%d changed on first, %d changed on second, %d conflicts.", s.FirstNewer, s.SecondNewer, s.Conflicts))
+}
+
+// JSON jsonified three-way diff summary.
+func (s diff3SummaryMessage) JSON() string {
+	s.Status = "success"
+	jsonBytes, e := json.MarshalIndent(s, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+	return string(jsonBytes)
+}
+
+// classifyDiff3 compares the first and second states of an object with
+// their common ancestor, ok is false when the object did not diverge.
+func classifyDiff3(key string, first, second, ancestor *diff3State) (msg diff3Message, ok bool) {
+	if sameDiff3State(first, second) {
+		return msg, false
+	}
+	msg = diff3Message{Key: key, First: first, Second: second, Ancestor: ancestor}
+	firstChanged := !sameDiff3State(first, ancestor)
+	secondChanged := !sameDiff3State(second, ancestor)
+	if firstChanged {
+		msg.FirstChange = diff3Change(ancestor, first)
+	}
+	if secondChanged {
+		msg.SecondChange = diff3Change(ancestor, second)
+	}
+	switch {
+	case firstChanged && !secondChanged:
+		msg.Diff = diverged3WayFirst
+	case secondChanged && !firstChanged:
+		msg.Diff = diverged3WaySecond
+	default:
+		msg.Diff = diverged3WayConflict
+	}
+	return msg, true
+}
+
+// listDiff3States lists the objects under urlStr by their path relative to it.
+func listDiff3States(ctx context.Context, urlStr string) (map[string]*diff3State, *probe.Error) {
+	separator := string(newClientURL(urlStr).Separator)
+	if !strings.HasSuffix(urlStr, separator) {
+		urlStr += separator
+	}
+	alias, expandedURL, _ := mustExpandAlias(urlStr)
+	clnt, err := newClientFromAlias(alias, expandedURL)
+	if err != nil {
+		return nil, err.Trace(urlStr)
+	}
+	prefix := clnt.GetURL().Path
+
+	states := make(map[string]*diff3State)
+	for content := range clnt.List(ctx, ListOptions{Recursive: true, ShowDir: DirNone}) {
+		if content.Err != nil {
+			// A missing folder or bucket has no objects.
+			if _, ok := content.Err.ToGoError().(PathNotFound); ok {
+				continue
+			}
+			return nil, content.Err.Trace(urlStr)
+		}
+		if content.Type.IsDir() {
+			continue
+		}
+		key := strings.TrimPrefix(content.URL.Path, prefix)
+		key = strings.TrimPrefix(strings.ReplaceAll(key, separator, "/"), "/")
+		states[key] = &diff3State{
+			Size:    content.Size,
+			ETag:    strings.Trim(content.ETag, "\""),
+			ModTime: content.Time,
+		}
+	}
+	return states, nil
+}
+
+// readDiff3Manifest reads the objects of a snapshot saved with
+// 'mc ls --recursive --json'.
+func readDiff3Manifest(manifest string) (map[string]*diff3State, *probe.Error) {
+	f, e := os.Open(manifest)
+	if e != nil {
+		return nil, probe.NewError(e).Trace(manifest)
+	}
+	defer f.Close()
+
+	states := make(map[string]*diff3State)
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		if strings.TrimSpace(scanner.Text()) == "" {
+			continue
+		}
+		var content contentMessage
+		if e = gojson.Unmarshal(scanner.Bytes(), &content); e != nil {
+			return nil, probe.NewError(e).Trace(fmt.Sprintf("%s:%d", manifest, line))
+		}
+		if content.Filetype == "folder" || content.IsDeleteMarker || content.Key == "" {
+			continue
+		}
+		states[content.Key] = &diff3State{
+			Size:    content.Size,
+			ETag:    strings.Trim(content.ETag, "\""),
+			ModTime: content.Time,
+		}
+	}
+	if e = scanner.Err(); e != nil {
+		return nil, probe.NewError(e).Trace(manifest)
+	}
+	return states, nil
+}
+
+// doDiff3Main compares two sites with their common ancestor, a folder
+// or a snapshot saved with 'mc ls --recursive --json'.
+func doDiff3Main(ctx context.Context, firstURL, secondURL, ancestorURL string) error {
+	first, err := listDiff3States(ctx, firstURL)
+	fatalIf(err, "Unable to list `%s`.", firstURL)
+	second, err := listDiff3States(ctx, secondURL)
+	fatalIf(err, "Unable to list `%s`.", secondURL)
+
+	var ancestor map[string]*diff3State
+	if st, e := os.Stat(ancestorURL); e == nil && st.Mode().IsRegular() {
+		ancestor, err = readDiff3Manifest(ancestorURL)
+	} else {
+		ancestor, err = listDiff3States(ctx, ancestorURL)
+	}
+	fatalIf(err, "Unable to read the common ancestor `%s`.", ancestorURL)
+
+	keys := make([]string, 0, len(first)+len(second))
+	for _, states := range []map[string]*diff3State{first, second, ancestor} {
+		for key := range states {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	var summary diff3SummaryMessage
+	for i, key := range keys {
+		if i > 0 && keys[i-1] == key {
+			continue
+		}
+		msg, ok := classifyDiff3(key, first[key], second[key], ancestor[key])
+		if !ok {
+			continue
+		}
+		switch msg.Diff {
+		case diverged3WayFirst:
+			summary.FirstNewer++
+		case diverged3WaySecond:
+			summary.SecondNewer++
+		default:
+			summary.Conflicts++
+		}
+		printMsg(msg)
+	}
+	printMsg(summary)
+	return nil
+}
@@ -0,0 +1,67 @@
+// Copyright (c) 2015-2024 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"testing"
+	"time"
+)
+
+func TestClassifyDiff3(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	v1 := &diff3State{Size: 1, ETag: "a", ModTime: now}
+	v2 := &diff3State{Size: 2, ETag: "b", ModTime: now.Add(time.Hour)}
+	v3 := &diff3State{Size: 3, ETag: "c", ModTime: now.Add(2 * time.Hour)}
+
+	testCases := []struct {
+		first, second, ancestor   *diff3State
+		diff                      string
+		firstChange, secondChange string
+	}{
+		{v1, v1, v1, "", "", ""},
+		{v2, v2, v1, "", "", ""},
+		{nil, nil, v1, "", "", ""},
+		{v2, v1, v1, diverged3WayFirst, change3WayModified, ""},
+		{v1, v2, v1, diverged3WaySecond, "", change3WayModified},
+		{nil, v1, v1, diverged3WayFirst, change3WayDeleted, ""},
+		{v1, nil, nil, diverged3WayFirst, change3WayCreated, ""},
+		{v2, v3, v1, diverged3WayConflict, change3WayModified, change3WayModified},
+		{nil, v2, v1, diverged3WayConflict, change3WayDeleted, change3WayModified},
+		{v1, v2, nil, diverged3WayConflict, change3WayCreated, change3WayCreated},
+	}
+	for i, tc := range testCases {
+		msg, ok := classifyDiff3("key", tc.first, tc.second, tc.ancestor)
+		if ok != (tc.diff != "") {
+			t.Fatalf("test %d: expected diverged %v, got %v", i+1, tc.diff != "", ok)
+		}
+		if msg.Diff != tc.diff || msg.FirstChange != tc.firstChange || msg.SecondChange != tc.secondChange {
+			t.Errorf("test %d: expected %s (%s/%s), got %s (%s/%s)", i+1,
+				tc.diff, tc.firstChange, tc.secondChange, msg.Diff, msg.FirstChange, msg.SecondChange)
+		}
+	}
+
+	// Files without ETags are compared by modification time, to the
+	// second when one of them comes from an object.
+	file := &diff3State{Size: 1, ModTime: now.Add(500 * time.Millisecond)}
+	if !sameDiff3State(file, &diff3State{Size: 1, ETag: "a", ModTime: now}) {
+		t.Error("expected a file and an object modified in the same second to be the same")
+	}
+	if sameDiff3State(file, &diff3State{Size: 1, ModTime: now.Add(700 * time.Millisecond)}) {
+		t.Error("expected files modified at different times to differ")
+	}
+}
@@ -0,0 +1,196 @@
+// Copyright (c) 2015-2024 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"crypto/md5"
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"hash/crc32"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/encrypt"
+)
+
+// Values of the --compare flag of mirror and diff.
+const (
+	compareSize     = "size"
+	compareChecksum = "checksum"
+)
+
+// Checksums compared by --compare checksum, in order of preference,
+// the first one known for both objects decides if they are equal.
+var compareChecksumTypes = []string{"SHA256", "CRC32C", "CRC32", "SHA1", "ETag"}
+
+// isValidCompareMode returns true if mode is a valid --compare value.
+func isValidCompareMode(mode string) bool {
+	return mode == compareSize || mode == compareChecksum
+}
+
+// checksumComparer compares the checksums of the objects listed by
+// the source and target clients of a mirror or a diff.
+type checksumComparer struct {
+	ctx                    context.Context
+	sourceClnt, targetClnt Client
+}
+
+// equal returns whether src and tgt have the same checksum, ok is false
+// when their checksums cannot be compared.
+func (c *checksumComparer) equal(src, tgt *ClientContent) (equal, ok bool) {
+	srcSums, err := contentChecksums(c.ctx, c.sourceClnt, src)
+	if err != nil {
+		return false, false
+	}
+	tgtSums, err := contentChecksums(c.ctx, c.targetClnt, tgt)
+	if err != nil {
+		return false, false
+	}
+	return checksumsEqual(srcSums, tgtSums)
+}
+
+// checksumsEqual compares the first checksum type known in both sets.
+func checksumsEqual(a, b map[string]string) (equal, ok bool) {
+	for _, t := range compareChecksumTypes {
+		if a[t] != "" && b[t] != "" {
+			return a[t] == b[t], true
+		}
+	}
+	return false, false
+}
+
+// contentChecksums returns the checksums of an object listed by clnt.
+func contentChecksums(ctx context.Context, clnt Client, content *ClientContent) (map[string]string, *probe.Error) {
+	switch c := clnt.(type) {
+	case *S3Client:
+		return c.objectChecksums(ctx, content, nil)
+	case *fsClient:
+		return fileChecksums(content.URL.Path)
+	}
+	return nil, probe.NewError(APINotImplemented{API: "Checksums", APIType: clnt.GetURL().String()})
+}
+
+// objectChecksums returns the checksums of an object. The checksums of
+// a multipart object are checksums of the checksums of its parts, they
+// are only equal to those of an object uploaded with the same parts.
+func (c *S3Client) objectChecksums(ctx context.Context, content *ClientContent, sse encrypt.ServerSide) (map[string]string, *probe.Error) {
+	bucket, object := c.splitPath(content.URL.Path)
+	sums := make(map[string]string)
+	attrs, e := c.api.GetObjectAttributes(ctx, bucket, object, minio.ObjectAttributesOptions{
+		VersionID:            content.VersionID,
+		ServerSideEncryption: sse,
+	})
+	if e == nil {
+		suffix := ""
+		if attrs.ObjectParts.PartsCount > 0 {
+			suffix = fmt.Sprintf("-%d", attrs.ObjectParts.PartsCount)
+		}
+		for t, v := range map[string]string{
+			"SHA256": attrs.Checksum.ChecksumSHA256,
+			"CRC32C": attrs.Checksum.ChecksumCRC32C,
+			"CRC32":  attrs.Checksum.ChecksumCRC32,
+			"SHA1":   attrs.Checksum.ChecksumSHA1,
+		} {
+			if v != "" {
+				sums[t] = v + suffix
+			}
+		}
+		sums["ETag"] = strings.Trim(attrs.ETag, "\"")
+		return sums, nil
+	}
+
+	// GetObjectAttributes is not supported by all servers, fall back
+	// to the checksums returned with the object.
+	opts := minio.StatObjectOptions{Checksum: true, ServerSideEncryption: sse}
+	opts.VersionID = content.VersionID
+	info, e := c.api.StatObject(ctx, bucket, object, opts)
+	if e != nil {
+		return nil, probe.NewError(e)
+	}
+	for t, v := range map[string]string{
+		"SHA256": info.ChecksumSHA256,
+		"CRC32C": info.ChecksumCRC32C,
+		"CRC32":  info.ChecksumCRC32,
+		"SHA1":   info.ChecksumSHA1,
+	} {
+		if v != "" {
+			sums[t] = v
+		}
+	}
+	sums["ETag"] = strings.Trim(info.ETag, "\"")
+	return sums, nil
+}
+
+// fileChecksums computes the checksums of a file as an S3 server computes
+// them for an object uploaded in a single part.
+func fileChecksums(filePath string) (map[string]string, *probe.Error) {
+	f, e := os.Open(filePath)
+	if e != nil {
+		return nil, probe.NewError(e)
+	}
+	defer f.Close()
+
+	hashes := make(map[string]hash.Hash, len(compareChecksumTypes))
+	writers := make([]io.Writer, 0, len(compareChecksumTypes))
+	for _, t := range compareChecksumTypes {
+		hashes[t] = newChecksumHash(t)
+		writers = append(writers, hashes[t])
+	}
+	if _, e = io.Copy(io.MultiWriter(writers...), f); e != nil {
+		return nil, probe.NewError(e)
+	}
+
+	sums := make(map[string]string, len(hashes))
+	for t, h := range hashes {
+		sums[t] = encodeChecksum(t, h.Sum(nil))
+	}
+	return sums, nil
+}
+
+// newChecksumHash returns the hash computing a checksum type, the ETag
+// of an object uploaded in a single part is its MD5.
+func newChecksumHash(checksumType string) hash.Hash {
+	switch checksumType {
+	case "SHA256":
+		return sha256.New()
+	case "CRC32C":
+		return crc32.New(crc32.MakeTable(crc32.Castagnoli))
+	case "CRC32":
+		return crc32.NewIEEE()
+	case "SHA1":
+		return sha1.New()
+	}
+	return md5.New()
+}
+
+// encodeChecksum encodes a checksum as returned by S3, in hex for an
+// ETag and in base64 otherwise.
+func encodeChecksum(checksumType string, sum []byte) string {
+	if checksumType == "ETag" {
+		return hex.EncodeToString(sum)
+	}
+	return base64.StdEncoding.EncodeToString(sum)
+}
@@ -0,0 +1,68 @@
+// Copyright (c) 2015-2024 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestChecksumsEqual(t *testing.T) {
+	testCases := []struct {
+		a, b      map[string]string
+		equal, ok bool
+	}{
+		{map[string]string{"ETag": "abc"}, map[string]string{"ETag": "abc"}, true, true},
+		{map[string]string{"ETag": "abc"}, map[string]string{"ETag": "abd"}, false, true},
+		// The preferred checksum decides over the ETag.
+		{map[string]string{"CRC32C": "x", "ETag": "abc"}, map[string]string{"CRC32C": "x", "ETag": "abd"}, true, true},
+		{map[string]string{"SHA256": "x", "ETag": "abc"}, map[string]string{"CRC32C": "x", "ETag": "abc"}, true, true},
+		{map[string]string{"SHA256": "x"}, map[string]string{"CRC32C": "x"}, false, false},
+		{nil, map[string]string{"ETag": "abc"}, false, false},
+	}
+	for i, tc := range testCases {
+		equal, ok := checksumsEqual(tc.a, tc.b)
+		if equal != tc.equal || ok != tc.ok {
+			t.Errorf("Test %d: expected (%v, %v), got (%v, %v)", i+1, tc.equal, tc.ok, equal, ok)
+		}
+	}
+}
+
+func TestFileChecksums(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "object")
+	if e := os.WriteFile(file, []byte("hello world"), 0o600); e != nil {
+		t.Fatal(e)
+	}
+	sums, err := fileChecksums(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := map[string]string{
+		"SHA256": "uU0nuZNNPgilLlLX2n2r+sSE7+N6U4DukIj3rOLvzek=",
+		"CRC32C": "yZRlqg==",
+		"CRC32":  "DUoRhQ==",
+		"SHA1":   "Kq5sNclPz7QV2+lfQIuc6R7oRu0=",
+		"ETag":   "5eb63bbbe01eeed093cb22bb8f5acdc3",
+	}
+	for k, v := range expected {
+		if sums[k] != v {
+			t.Errorf("%s: expected %s, got %s", k, v, sums[k])
+		}
+	}
+}
@@ -19,7 +19,9 @@ package cmd
 
 import (
 	"context"
+	"sort"
 	"strings"
+	"sync"
 	"time"
 	"unicode/utf8"
 
@@ -27,7 +29,6 @@ import (
 
 	"github.com/minio/mc/pkg/probe"
 	"github.com/minio/minio-go/v7"
-	"golang.org/x/text/unicode/norm"
 )
 
 // differType difference in type.
@@ -42,6 +43,7 @@ const (
 	differInFirst                    // only in source (FIRST)
 	differInSecond                   // only in target (SECOND)
 	differInAASourceMTime            // differs in active-active source modtime
+	differInChecksum                 // differs in checksum
 )
 
 func (d differType) String() string {
@@ -54,6 +56,8 @@ func (d differType) String() string {
 		return "metadata"
 	case differInAASourceMTime:
 		return "mm-source-mtime"
+	case differInChecksum:
+		return "checksum"
 	case differInType:
 		return "type"
 	case differInFirst:
@@ -161,22 +165,193 @@ func metadataEqual(m1, m2 map[string]string) bool {
 	return true
 }
 
-func bucketObjectDifference(ctx context.Context, sourceClnt, targetClnt Client) (diffCh chan diffMessage) {
-	return objectDifference(ctx, sourceClnt, targetClnt, mirrorOptions{
-		isMetadata: false,
-	})
+// Number of listed entries read ahead of the comparison on each side, it
+// bounds the memory used by a difference whatever the size of the buckets.
+const differenceReadAhead = 1000
+
+// readAhead keeps listing contentCh while the entries already listed are
+// compared, so both sides of a difference are listed concurrently and a
+// slow side does not stall the listing of the other one. At most
+// differenceReadAhead entries are buffered.
+func readAhead(ctx context.Context, contentCh <-chan *ClientContent) <-chan *ClientContent {
+	bufferedCh := make(chan *ClientContent, differenceReadAhead)
+	go func() {
+		defer close(bufferedCh)
+		for content := range contentCh {
+			select {
+			case <-ctx.Done():
+				// Unblock the listing.
+				for range contentCh {
+				}
+				return
+			case bufferedCh <- content:
+			}
+		}
+	}()
+	return bufferedCh
+}
+
+// bucketObjectDifference compares the objects of two buckets, the top
+// level prefixes are compared by up to workers at the same time.
+func bucketObjectDifference(ctx context.Context, sourceAlias, sourceURL string, sourceClnt Client, targetAlias, targetURL string, targetClnt Client, compare string, workers int) (diffCh chan diffMessage) {
+	opts := mirrorOptions{
+		isMetadata:  false,
+		compare:     compare,
+		listWorkers: workers,
+	}
+	if workers > 1 {
+		return parallelObjectDifference(ctx, sourceAlias, sourceURL, sourceClnt, targetAlias, targetURL, targetClnt, opts)
+	}
+	return objectDifference(ctx, sourceClnt, targetClnt, opts)
 }
 
 func objectDifference(ctx context.Context, sourceClnt, targetClnt Client, opts mirrorOptions) (diffCh chan diffMessage) {
+	if opts.compare == compareChecksum {
+		opts.checksums = &checksumComparer{ctx: ctx, sourceClnt: sourceClnt, targetClnt: targetClnt}
+	}
+
 	sourceURL := sourceClnt.GetURL().String()
-	sourceCh := sourceClnt.List(ctx, ListOptions{Recursive: true, WithMetadata: opts.isMetadata, ShowDir: DirNone})
+	sourceCh := readAhead(ctx, sourceClnt.List(ctx, ListOptions{Recursive: true, WithMetadata: opts.listWithMetadata(), ShowDir: DirNone}))
 
 	targetURL := targetClnt.GetURL().String()
-	targetCh := targetClnt.List(ctx, ListOptions{Recursive: true, WithMetadata: opts.isMetadata, ShowDir: DirNone})
+	targetCh := readAhead(ctx, targetClnt.List(ctx, ListOptions{Recursive: true, WithMetadata: opts.listWithMetadata(), ShowDir: DirNone}))
 
 	return difference(sourceURL, sourceCh, targetURL, targetCh, opts, false)
 }
 
+// parallelObjectDifference compares source and target like objectDifference
+// but lists every top level prefix separately, up to opts.listWorkers
+// prefixes are listed and compared at the same time. Differences of
+// different prefixes are interleaved and not sent in lexical order.
+func parallelObjectDifference(ctx context.Context, sourceAlias, sourceURL string, sourceClnt Client, targetAlias, targetURL string, targetClnt Client, opts mirrorOptions) (diffCh chan diffMessage) {
+	diffCh = make(chan diffMessage, 10000)
+	if opts.compare == compareChecksum {
+		opts.checksums = &checksumComparer{ctx: ctx, sourceClnt: sourceClnt, targetClnt: targetClnt}
+	}
+
+	type prefixSides struct {
+		source, target bool
+	}
+	var (
+		mu       sync.Mutex
+		prefixes = make(map[string]*prefixSides)
+		wg       sync.WaitGroup
+	)
+
+	// splitTopLevel lists the top level of clnt, prefixes are recorded
+	// and objects are sent on the returned channel.
+	splitTopLevel := func(clnt Client, isSource bool) <-chan *ClientContent {
+		objectsCh := make(chan *ClientContent)
+		separator := string(clnt.GetURL().Separator)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer close(objectsCh)
+			for content := range clnt.List(ctx, ListOptions{WithMetadata: opts.listWithMetadata(), ShowDir: DirNone}) {
+				if content.Err == nil && content.Type.IsDir() {
+					name := strings.TrimSuffix(content.URL.Path, separator)
+					name = name[strings.LastIndex(name, separator)+1:] + separator
+					mu.Lock()
+					sides, ok := prefixes[name]
+					if !ok {
+						sides = &prefixSides{}
+						prefixes[name] = sides
+					}
+					if isSource {
+						sides.source = true
+					} else {
+						sides.target = true
+					}
+					mu.Unlock()
+					continue
+				}
+				select {
+				case <-ctx.Done():
+					return
+				case objectsCh <- content:
+				}
+			}
+		}()
+		return objectsCh
+	}
+
+	listPrefix := func(alias, urlStr string) <-chan *ClientContent {
+		clnt, err := newClientFromAlias(alias, urlStr)
+		if err != nil {
+			contentCh := make(chan *ClientContent, 1)
+			contentCh <- &ClientContent{Err: err.Trace(alias, urlStr)}
+			close(contentCh)
+			return contentCh
+		}
+		return readAhead(ctx, clnt.List(ctx, ListOptions{Recursive: true, WithMetadata: opts.listWithMetadata(), ShowDir: DirNone}))
+	}
+
+	emptyListing := func() <-chan *ClientContent {
+		contentCh := make(chan *ClientContent)
+		close(contentCh)
+		return contentCh
+	}
+
+	go func() {
+		defer close(diffCh)
+
+		// Objects at the top level are compared while the prefixes are collected.
+		sourceObjectsCh := splitTopLevel(sourceClnt, true)
+		targetObjectsCh := splitTopLevel(targetClnt, false)
+		for diffMsg := range difference(sourceClnt.GetURL().String(), sourceObjectsCh, targetClnt.GetURL().String(), targetObjectsCh, opts, false) {
+			diffCh <- diffMsg
+		}
+		// The comparison may stop early, drain the listings.
+		for range sourceObjectsCh {
+		}
+		for range targetObjectsCh {
+		}
+		wg.Wait()
+
+		names := make([]string, 0, len(prefixes))
+		for name, sides := range prefixes {
+			if !sides.source && opts.sourceListingOnly {
+				continue
+			}
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		namesCh := make(chan string)
+		var workers sync.WaitGroup
+		for i := 0; i < opts.listWorkers; i++ {
+			workers.Add(1)
+			go func() {
+				defer workers.Done()
+				for name := range namesCh {
+					sourcePrefixURL := urlJoinPath(sourceURL, name)
+					targetPrefixURL := urlJoinPath(targetURL, name)
+					sourceCh, targetCh := emptyListing(), emptyListing()
+					if prefixes[name].source {
+						sourceCh = listPrefix(sourceAlias, sourcePrefixURL)
+					}
+					if prefixes[name].target {
+						targetCh = listPrefix(targetAlias, targetPrefixURL)
+					}
+					for diffMsg := range difference(sourcePrefixURL, sourceCh, targetPrefixURL, targetCh, opts, false) {
+						diffCh <- diffMsg
+					}
+				}
+			}()
+		}
+		for _, name := range names {
+			if ctx.Err() != nil {
+				break
+			}
+			namesCh <- name
+		}
+		close(namesCh)
+		workers.Wait()
+	}()
+
+	return diffCh
+}
+
 func bucketDifference(ctx context.Context, sourceClnt, targetClnt Client, opts mirrorOptions) (diffCh chan diffMessage) {
 	sourceURL := sourceClnt.GetURL().String()
 	sourceCh := make(chan *ClientContent)
@@ -305,8 +480,8 @@ func differenceInternal(sourceURL string,
 		// Normalize to avoid situations where multiple byte representations are possible.
 		// e.g. 'ä' can be represented as precomposed U+00E4 (UTF-8 0xc3a4) or decomposed
 		// U+0061 U+0308 (UTF-8 0x61cc88).
-		normalizedCurrent := norm.NFC.String(current)
-		normalizedExpected := norm.NFC.String(expected)
+		normalizedCurrent := compareKey(opts.unicodeNormalize, current)
+		normalizedExpected := compareKey(opts.unicodeNormalize, expected)
 
 		if normalizedExpected > normalizedCurrent {
 			diffCh <- diffMessage{
@@ -341,6 +516,19 @@ func differenceInternal(sourceURL string,
 					firstContent:  srcCtnt,
 					secondContent: tgtCtnt,
 				}
+			} else if equal, ok := opts.checksumsEqual(srcCtnt, tgtCtnt); ok {
+				// Objects with the same checksum are never copied again, objects
+				// with different checksums are only copied from the newest one
+				// in active-active mode.
+				if !equal && (!opts.activeActive || activeActiveModTimeUpdated(srcCtnt, tgtCtnt)) {
+					diffCh <- diffMessage{
+						FirstURL:      srcCtnt.URL.String(),
+						SecondURL:     tgtCtnt.URL.String(),
+						Diff:          differInChecksum,
+						firstContent:  srcCtnt,
+						secondContent: tgtCtnt,
+					}
+				}
 			} else if activeActiveModTimeUpdated(srcCtnt, tgtCtnt) {
 				diffCh <- diffMessage{
 					FirstURL:      srcCtnt.URL.String(),
@@ -18,6 +18,11 @@
 package cmd
 
 import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
 	"testing"
 )
 
@@ -61,3 +66,129 @@ func TestExcludeOptions(t *testing.T) {
 		}
 	}
 }
+
+func TestReadAhead(t *testing.T) {
+	listing := func(n int) chan *ClientContent {
+		contentCh := make(chan *ClientContent)
+		go func() {
+			defer close(contentCh)
+			for i := 0; i < n; i++ {
+				contentCh <- &ClientContent{Size: int64(i)}
+			}
+		}()
+		return contentCh
+	}
+
+	n := 2*differenceReadAhead + 1
+	var i int64
+	for content := range readAhead(context.Background(), listing(n)) {
+		if content.Size != i {
+			t.Fatalf("expected entry %d, got %d", i, content.Size)
+		}
+		i++
+	}
+	if i != int64(n) {
+		t.Fatalf("expected %d entries, got %d", n, i)
+	}
+
+	// A canceled comparison drains the listing, which is not blocked.
+	ctx, cancel := context.WithCancel(context.Background())
+	contentCh := listing(n)
+	bufferedCh := readAhead(ctx, contentCh)
+	<-bufferedCh
+	cancel()
+	for range bufferedCh {
+	}
+	if _, ok := <-contentCh; ok {
+		t.Fatal("expected the listing to be drained")
+	}
+}
+
+func TestParallelObjectDifference(t *testing.T) {
+	// Prefixes are listed with clients of their alias, local paths have
+	// none but the configuration is still looked up.
+	useTestMcConfig(t)
+
+	writeFiles := func(dir string, files map[string]string) {
+		for name, data := range files {
+			file := filepath.Join(dir, filepath.FromSlash(name))
+			if e := os.MkdirAll(filepath.Dir(file), 0o700); e != nil {
+				t.Fatal(e)
+			}
+			if e := os.WriteFile(file, []byte(data), 0o600); e != nil {
+				t.Fatal(e)
+			}
+		}
+	}
+	// Directories end with a separator, as the URLs given by diff and mirror.
+	sourceDir, targetDir := t.TempDir()+string(filepath.Separator), t.TempDir()+string(filepath.Separator)
+	writeFiles(sourceDir, map[string]string{
+		"a.txt":       "same",
+		"b/c.txt":     "same",
+		"b/d.txt":     "source",
+		"b/e/f.txt":   "only in source",
+		"g/h.txt":     "only in source",
+		"i.txt":       "only in source",
+		"j/k/l.txt":   "same",
+		"m-n.txt":     "size",
+		"m/o.txt":     "same",
+		"p/q/r/s.txt": "only in source",
+	})
+	writeFiles(targetDir, map[string]string{
+		"a.txt":     "same",
+		"b/c.txt":   "same",
+		"b/d.txt":   "target size",
+		"j/k/l.txt": "same",
+		"m-n.txt":   "size differs",
+		"m/o.txt":   "same",
+		"t/u.txt":   "only in target",
+		"v.txt":     "only in target",
+	})
+
+	// diffs returns the differences sorted, parallel listings send them
+	// in no particular order.
+	diffs := func(diffCh chan diffMessage) []diffMessage {
+		var msgs []diffMessage
+		for msg := range diffCh {
+			if msg.Error != nil {
+				t.Fatal(msg.Error)
+			}
+			msgs = append(msgs, diffMessage{FirstURL: msg.FirstURL, SecondURL: msg.SecondURL, Diff: msg.Diff})
+		}
+		sort.Slice(msgs, func(i, j int) bool {
+			if msgs[i].FirstURL != msgs[j].FirstURL {
+				return msgs[i].FirstURL < msgs[j].FirstURL
+			}
+			return msgs[i].SecondURL < msgs[j].SecondURL
+		})
+		return msgs
+	}
+	newClients := func() (Client, Client) {
+		sourceClnt, err := newClientFromAlias("", sourceDir)
+		if err != nil {
+			t.Fatal(err)
+		}
+		targetClnt, err := newClientFromAlias("", targetDir)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return sourceClnt, targetClnt
+	}
+
+	for _, sourceListingOnly := range []bool{false, true} {
+		opts := mirrorOptions{compare: compareSize, sourceListingOnly: sourceListingOnly}
+		sourceClnt, targetClnt := newClients()
+		want := diffs(objectDifference(context.Background(), sourceClnt, targetClnt, opts))
+		if len(want) == 0 {
+			t.Fatal("expected differences")
+		}
+		for _, workers := range []int{1, 2, 8} {
+			opts.listWorkers = workers
+			sourceClnt, targetClnt = newClients()
+			got := diffs(parallelObjectDifference(context.Background(), "", sourceDir, sourceClnt, "", targetDir, targetClnt, opts))
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("source listing only %v, %d workers: expected %+v, got %+v", sourceListingOnly, workers, want, got)
+			}
+		}
+	}
+}
@@ -22,6 +22,8 @@ import (
 	"fmt"
 	"net/url"
 	"path"
+	"path/filepath"
+	"sort"
 	"strings"
 	"time"
 
@@ -31,6 +33,7 @@ import (
 	json "github.com/minio/colorjson"
 	"github.com/minio/mc/pkg/probe"
 	"github.com/minio/pkg/v3/console"
+	"github.com/minio/pkg/v3/wildcard"
 )
 
 // du specific flags.
@@ -50,7 +53,19 @@ var (
 		},
 		cli.BoolFlag{
 			Name:  "versions",
-			Usage: "include all object versions",
+			Usage: "include all object versions, with a breakdown of current, non-current versions and delete markers",
+		},
+		cli.BoolFlag{
+			Name:  "current-only",
+			Usage: "only count the current version of objects, this is the default unless --versions is specified",
+		},
+		cli.StringSliceFlag{
+			Name:  "exclude",
+			Usage: "exclude object(s) that match specified object name pattern, relative to TARGET",
+		},
+		cli.IntFlag{
+			Name:  "top",
+			Usage: "only print the N largest prefixes and objects, up to --depth levels below TARGET",
 		},
 	}
 )
@@ -85,21 +100,121 @@ EXAMPLES:
 
   4. Summarize disk usage of 'jazz-songs' bucket with all objects versions
      {{.Prompt}} {{.HelpName}} --versions s3/jazz-songs/
+
+  5. Summarize disk usage of each folder of 'jazz-songs' bucket starting with 'louis'.
+     {{.Prompt}} {{.HelpName}} 's3/jazz-songs/louis*/'
+
+  6. Print the 10 largest folders and objects directly under 'jazz-songs' bucket.
+     {{.Prompt}} {{.HelpName}} --top 10 s3/jazz-songs/
+
+  7. Print the 20 largest folders and objects at any level of 'jazz-songs' bucket, counting all versions.
+     {{.Prompt}} {{.HelpName}} --top 20 --recursive --versions s3/jazz-songs/
+
+  8. Summarize disk usage of 'jazz-songs' bucket, ignoring temporary files and the 'drafts' folder.
+     {{.Prompt}} {{.HelpName}} --exclude "*.tmp" --exclude "drafts/*" s3/jazz-songs/
 `,
 }
 
+// duVersions breaks down the usage of a prefix listed with --versions.
+type duVersions struct {
+	Current        int64 `json:"current"`
+	CurrentSize    int64 `json:"currentSize"`
+	NonCurrent     int64 `json:"nonCurrent"`
+	NonCurrentSize int64 `json:"nonCurrentSize"`
+	DeleteMarkers  int64 `json:"deleteMarkers"`
+}
+
+// duUsage is the disk usage of a prefix or an object.
+type duUsage struct {
+	Size     int64
+	Objects  int64
+	Versions *duVersions
+}
+
+// add counts content in the usage, delete markers only count
+// in the versions breakdown.
+func (u *duUsage) add(content *ClientContent, withVersions bool) {
+	if withVersions {
+		if u.Versions == nil {
+			u.Versions = &duVersions{}
+		}
+		switch {
+		case content.IsDeleteMarker:
+			u.Versions.DeleteMarkers++
+		case content.IsLatest:
+			u.Versions.Current++
+			u.Versions.CurrentSize += content.Size
+		default:
+			u.Versions.NonCurrent++
+			u.Versions.NonCurrentSize += content.Size
+		}
+	}
+	if !content.IsDeleteMarker {
+		u.Size += content.Size
+		u.Objects++
+	}
+}
+
+// merge adds the usage of a sub prefix.
+func (u *duUsage) merge(v duUsage) {
+	u.Size += v.Size
+	u.Objects += v.Objects
+	if v.Versions != nil {
+		if u.Versions == nil {
+			u.Versions = &duVersions{}
+		}
+		u.Versions.Current += v.Versions.Current
+		u.Versions.CurrentSize += v.Versions.CurrentSize
+		u.Versions.NonCurrent += v.Versions.NonCurrent
+		u.Versions.NonCurrentSize += v.Versions.NonCurrentSize
+		u.Versions.DeleteMarkers += v.Versions.DeleteMarkers
+	}
+}
+
+// duOptions are the options shared by du and duTop.
+type duOptions struct {
+	timeRef      time.Time
+	withVersions bool
+	excludes     []string
+	// root is the path of the summarized folder, --exclude patterns
+	// are matched against names relative to it.
+	root string
+}
+
+// excluded returns true when the content at urlPath matches one of
+// the --exclude patterns, folders end with a '/'.
+func (o duOptions) excluded(urlPath string, isDir bool) bool {
+	if len(o.excludes) == 0 {
+		return false
+	}
+	relPath := strings.TrimPrefix(filepath.ToSlash(urlPath), filepath.ToSlash(o.root))
+	if isDir && !strings.HasSuffix(relPath, "/") {
+		relPath += "/"
+	}
+	for _, pattern := range o.excludes {
+		if wildcard.Match(pattern, relPath) {
+			return true
+		}
+	}
+	return false
+}
+
 // Structured message depending on the type of console.
 type duMessage struct {
-	Prefix     string `json:"prefix"`
-	Size       int64  `json:"size"`
-	Objects    int64  `json:"objects"`
-	Status     string `json:"status"`
-	IsVersions bool   `json:"isVersions"`
+	Prefix     string      `json:"prefix"`
+	Size       int64       `json:"size"`
+	Objects    int64       `json:"objects"`
+	Status     string      `json:"status"`
+	IsVersions bool        `json:"isVersions"`
+	Versions   *duVersions `json:"versions,omitempty"`
+}
+
+func duHumanSize(size int64) string {
+	return strings.Join(strings.Fields(humanize.IBytes(uint64(size))), "")
 }
 
 // Colorized message for console printing.
 func (r duMessage) String() string {
-	humanSize := strings.Join(strings.Fields(humanize.IBytes(uint64(r.Size))), "")
 	cnt := fmt.Sprintf("%d object", r.Objects)
 	if r.IsVersions {
 		cnt = fmt.Sprintf("%d version", r.Objects)
@@ -107,9 +222,14 @@ func (r duMessage) String() string {
 	if r.Objects != 1 {
 		cnt += "s" // pluralize
 	}
-	return fmt.Sprintf("%s\t%s\t%s", console.Colorize("Size", humanSize),
+	msg := fmt.Sprintf("%s\t%s\t%s", console.Colorize("Size", duHumanSize(r.Size)),
 		console.Colorize("Objects", cnt),
 		console.Colorize("Prefix", r.Prefix))
+	if v := r.Versions; v != nil {
+		msg += console.Colorize("Versions", fmt.Sprintf("\t(current: %s in %d, non-current: %s in %d, delete markers: %d)",
+			duHumanSize(v.CurrentSize), v.Current, duHumanSize(v.NonCurrentSize), v.NonCurrent, v.DeleteMarkers))
+	}
+	return msg
 }
 
 // JSON'ified message for scripting.
@@ -119,7 +239,7 @@ func (r duMessage) JSON() string {
 	return string(msgBytes)
 }
 
-func du(ctx context.Context, urlStr string, timeRef time.Time, withVersions bool, depth int) (sz, objs int64, err error) {
+func du(ctx context.Context, urlStr string, opts duOptions, depth int) (usage duUsage, err error) {
 	targetAlias, targetURL, _ := mustExpandAlias(urlStr)
 
 	if !strings.HasSuffix(targetURL, "/") {
@@ -129,7 +249,7 @@ func du(ctx context.Context, urlStr string, timeRef time.Time, withVersions bool
 	clnt, pErr := newClientFromAlias(targetAlias, targetURL)
 	if pErr != nil {
 		errorIf(pErr.Trace(urlStr), "Failed to summarize disk usage `%s`.", urlStr)
-		return 0, 0, exitStatus(globalErrorExitStatus) // End of journey.
+		return usage, exitStatus(globalErrorExitStatus) // End of journey.
 	}
 
 	// No disk usage details below this level,
@@ -137,15 +257,20 @@ func du(ctx context.Context, urlStr string, timeRef time.Time, withVersions bool
 	recursive := depth == 1
 
 	targetAbsolutePath := path.Clean(clnt.GetURL().String())
+	if opts.root == "" {
+		opts.root = clnt.GetURL().Path
+		if !strings.HasSuffix(opts.root, "/") {
+			opts.root += "/"
+		}
+	}
 
 	contentCh := clnt.List(ctx, ListOptions{
-		TimeRef:           timeRef,
-		WithOlderVersions: withVersions,
+		TimeRef:           opts.timeRef,
+		WithOlderVersions: opts.withVersions,
+		WithDeleteMarkers: opts.withVersions,
 		Recursive:         recursive,
 		ShowDir:           DirFirst,
 	})
-	size := int64(0)
-	objects := int64(0)
 	for content := range contentCh {
 		if content.Err != nil {
 			switch content.Err.ToGoError().(type) {
@@ -157,12 +282,15 @@ func du(ctx context.Context, urlStr string, timeRef time.Time, withVersions bool
 				continue
 			}
 			errorIf(content.Err.Trace(urlStr), "Failed to find disk usage of `%s` recursively.", urlStr)
-			return 0, 0, exitStatus(globalErrorExitStatus)
+			return usage, exitStatus(globalErrorExitStatus)
 		}
 
 		if content.URL.Path == targetAbsolutePath {
 			continue
 		}
+		if opts.excluded(content.URL.Path, content.Type.IsDir()) {
+			continue
+		}
 
 		if content.Type.IsDir() && !recursive {
 			depth := depth
@@ -174,17 +302,13 @@ func du(ctx context.Context, urlStr string, timeRef time.Time, withVersions bool
 			if targetAlias != "" {
 				subDirAlias = targetAlias + "/" + content.URL.Path
 			}
-			used, n, err := du(ctx, subDirAlias, timeRef, withVersions, depth)
+			used, err := du(ctx, subDirAlias, opts, depth)
 			if err != nil {
-				return 0, 0, err
-			}
-			size += used
-			objects += n
-		} else {
-			if !content.IsDeleteMarker && !content.Type.IsDir() {
-				size += content.Size
-				objects++
+				return usage, err
 			}
+			usage.merge(used)
+		} else if !content.Type.IsDir() {
+			usage.add(content, opts.withVersions)
 		}
 	}
 
@@ -196,14 +320,117 @@ func du(ctx context.Context, urlStr string, timeRef time.Time, withVersions bool
 
 		printMsg(duMessage{
 			Prefix:     strings.Trim(u.Path, "/"),
-			Size:       size,
-			Objects:    objects,
+			Size:       usage.Size,
+			Objects:    usage.Objects,
 			Status:     "success",
-			IsVersions: withVersions,
+			IsVersions: opts.withVersions,
+			Versions:   usage.Versions,
 		})
 	}
 
-	return size, objects, nil
+	return usage, nil
+}
+
+// duTopEntries returns the names under which an object is counted by
+// --top: the object itself and its parent prefixes, up to depth levels
+// below the summarized folder, all levels for a negative depth.
+// Prefixes end with a '/'.
+func duTopEntries(relPath string, depth int) (entries []string) {
+	parts := strings.Split(relPath, "/")
+	for i := 1; i <= len(parts); i++ {
+		if depth > 0 && i > depth {
+			break
+		}
+		entry := strings.Join(parts[:i], "/")
+		if i < len(parts) {
+			entry += "/"
+		}
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+// duTop prints the n largest prefixes and objects of a folder, it does a
+// single recursive listing and sums up the usage of every entry.
+func duTop(ctx context.Context, urlStr string, opts duOptions, depth, n int) error {
+	targetAlias, targetURL, _ := mustExpandAlias(urlStr)
+
+	if !strings.HasSuffix(targetURL, "/") {
+		targetURL += "/"
+	}
+
+	clnt, pErr := newClientFromAlias(targetAlias, targetURL)
+	if pErr != nil {
+		errorIf(pErr.Trace(urlStr), "Failed to summarize disk usage `%s`.", urlStr)
+		return exitStatus(globalErrorExitStatus)
+	}
+
+	targetPath := clnt.GetURL().Path
+	if !strings.HasSuffix(targetPath, "/") {
+		targetPath += "/"
+	}
+	opts.root = targetPath
+
+	usages := make(map[string]*duUsage)
+	for content := range clnt.List(ctx, ListOptions{
+		TimeRef:           opts.timeRef,
+		WithOlderVersions: opts.withVersions,
+		WithDeleteMarkers: opts.withVersions,
+		Recursive:         true,
+		ShowDir:           DirNone,
+	}) {
+		if content.Err != nil {
+			switch content.Err.ToGoError().(type) {
+			case BrokenSymlink, TooManyLevelsSymlink, PathNotFound, ObjectOnGlacier:
+				continue
+			case PathInsufficientPermission:
+				errorIf(content.Err.Trace(clnt.GetURL().String()), "Unable to list folder.")
+				continue
+			}
+			errorIf(content.Err.Trace(urlStr), "Failed to find disk usage of `%s` recursively.", urlStr)
+			return exitStatus(globalErrorExitStatus)
+		}
+		if content.Type.IsDir() || opts.excluded(content.URL.Path, false) {
+			continue
+		}
+		relPath := strings.TrimPrefix(filepath.ToSlash(content.URL.Path), filepath.ToSlash(targetPath))
+		for _, entry := range duTopEntries(relPath, depth) {
+			usage, ok := usages[entry]
+			if !ok {
+				usage = &duUsage{}
+				usages[entry] = usage
+			}
+			usage.add(content, opts.withVersions)
+		}
+	}
+
+	entries := make([]string, 0, len(usages))
+	for entry := range usages {
+		entries = append(entries, entry)
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		if usages[entries[i]].Size != usages[entries[j]].Size {
+			return usages[entries[i]].Size > usages[entries[j]].Size
+		}
+		return entries[i] < entries[j]
+	})
+	if len(entries) > n {
+		entries = entries[:n]
+	}
+
+	prefix := strings.TrimPrefix(filepath.ToSlash(targetPath), "/")
+	for _, entry := range entries {
+		usage := usages[entry]
+		printMsg(duMessage{
+			Prefix:     prefix + entry,
+			Size:       usage.Size,
+			Objects:    usage.Objects,
+			Status:     "success",
+			IsVersions: opts.withVersions,
+			Versions:   usage.Versions,
+		})
+	}
+	return nil
 }
 
 // main for du command.
@@ -217,6 +444,7 @@ func mainDu(cliCtx *cli.Context) error {
 	console.SetColor("Prefix", color.New(color.FgCyan, color.Bold))
 	console.SetColor("Objects", color.New(color.FgGreen))
 	console.SetColor("Size", color.New(color.FgYellow))
+	console.SetColor("Versions", color.New(color.FgWhite))
 
 	ctx, cancelRm := context.WithCancel(globalContext)
 	defer cancelRm()
@@ -233,19 +461,51 @@ func mainDu(cliCtx *cli.Context) error {
 		}
 	}
 
-	withVersions := cliCtx.Bool("versions")
-	timeRef := parseRewindFlag(cliCtx.String("rewind"))
+	if cliCtx.Bool("versions") && cliCtx.Bool("current-only") {
+		fatalIf(errInvalidArgument().Trace(cliCtx.Args()...), "`--versions` and `--current-only` cannot be used together.")
+	}
+	opts := duOptions{
+		timeRef:      parseRewindFlag(cliCtx.String("rewind")),
+		withVersions: cliCtx.Bool("versions"),
+		excludes:     cliCtx.StringSlice("exclude"),
+	}
+
+	top := cliCtx.Int("top")
+	if cliCtx.IsSet("top") && top < 1 {
+		fatalIf(errInvalidArgument().Trace(cliCtx.Args()...), "`--top` must be at least 1.")
+	}
 
 	var duErr error
 	var isDir bool
-	for _, urlStr := range cliCtx.Args() {
-		isDir, _ = isAliasURLDir(ctx, urlStr, nil, time.Time{}, false)
-		if !isDir {
-			fatalIf(errInvalidArgument().Trace(urlStr), fmt.Sprintf("Source `%s` is not a folder. Only folders are supported by 'du' command.", urlStr))
+	for _, arg := range cliCtx.Args() {
+		matches, err := expandGlobURL(ctx, arg)
+		fatalIf(err.Trace(arg), "Unable to expand `"+arg+"`.")
+		isGlob := len(matches) != 1 || matches[0] != arg
+		var dirs []string
+		for _, urlStr := range matches {
+			isDir, _ = isAliasURLDir(ctx, urlStr, nil, time.Time{}, false)
+			switch {
+			case isDir:
+				dirs = append(dirs, urlStr)
+			case !isGlob:
+				fatalIf(errInvalidArgument().Trace(urlStr), fmt.Sprintf("Source `%s` is not a folder. Only folders are supported by 'du' command.", urlStr))
+			}
+		}
+		// Only the folders matching a pattern are summarized.
+		if len(dirs) == 0 {
+			fatalIf(errInvalidArgument().Trace(arg), fmt.Sprintf("No folders match `%s`.", arg))
 		}
 
-		if _, _, err := du(ctx, urlStr, timeRef, withVersions, depth); duErr == nil {
-			duErr = err
+		for _, urlStr := range dirs {
+			var err error
+			if top > 0 {
+				err = duTop(ctx, urlStr, opts, depth, top)
+			} else {
+				_, err = du(ctx, urlStr, opts, depth)
+			}
+			if duErr == nil {
+				duErr = err
+			}
 		}
 	}
 
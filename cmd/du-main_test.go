@@ -0,0 +1,93 @@
+// Copyright (c) 2015-2024 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDuTopEntries(t *testing.T) {
+	testCases := []struct {
+		relPath  string
+		depth    int
+		expected []string
+	}{
+		{"obj", 1, []string{"obj"}},
+		{"a/b/obj", 1, []string{"a/"}},
+		{"a/b/obj", 2, []string{"a/", "a/b/"}},
+		{"a/b/obj", 3, []string{"a/", "a/b/", "a/b/obj"}},
+		{"a/b/obj", -1, []string{"a/", "a/b/", "a/b/obj"}},
+	}
+	for i, testCase := range testCases {
+		if entries := duTopEntries(testCase.relPath, testCase.depth); !reflect.DeepEqual(entries, testCase.expected) {
+			t.Errorf("Test %d: expected %v, got %v", i+1, testCase.expected, entries)
+		}
+	}
+}
+
+func TestDuUsageVersions(t *testing.T) {
+	var usage duUsage
+	usage.add(&ClientContent{Size: 10, IsLatest: true}, true)
+	usage.add(&ClientContent{Size: 5}, true)
+	usage.add(&ClientContent{IsDeleteMarker: true, IsLatest: true}, true)
+
+	var total duUsage
+	total.merge(usage)
+	total.add(&ClientContent{Size: 1}, true)
+
+	expected := duUsage{
+		Size:    16,
+		Objects: 3,
+		Versions: &duVersions{
+			Current:        1,
+			CurrentSize:    10,
+			NonCurrent:     2,
+			NonCurrentSize: 6,
+			DeleteMarkers:  1,
+		},
+	}
+	if !reflect.DeepEqual(total, expected) {
+		t.Errorf("expected %+v %+v, got %+v %+v", expected, *expected.Versions, total, *total.Versions)
+	}
+}
+
+func TestDuOptionsExcluded(t *testing.T) {
+	opts := duOptions{root: "/bucket/music/", excludes: []string{"*.tmp", "drafts/*", "cache/"}}
+	testCases := []struct {
+		path     string
+		isDir    bool
+		excluded bool
+	}{
+		{"/bucket/music/song.mp3", false, false},
+		{"/bucket/music/a/b.tmp", false, true},
+		{"/bucket/music/drafts/x.mp3", false, true},
+		{"/bucket/music/drafts", true, true},
+		{"/bucket/music/cache", true, true},
+		{"/bucket/music/cache/", true, true},
+		{"/bucket/music/other/drafts/x.mp3", false, false},
+	}
+	for _, tc := range testCases {
+		if got := opts.excluded(tc.path, tc.isDir); got != tc.excluded {
+			t.Errorf("%s: expected excluded=%v, got %v", tc.path, tc.excluded, got)
+		}
+	}
+	if (duOptions{root: "/bucket/"}).excluded("/bucket/a.tmp", false) {
+		t.Error("nothing should be excluded without patterns")
+	}
+}
@@ -21,7 +21,10 @@ import (
 	"bytes"
 	"encoding/base64"
 	"encoding/hex"
+	"errors"
 	"fmt"
+	"os"
+	"runtime"
 	"sort"
 	"strings"
 
@@ -71,8 +74,16 @@ func getSSE(resource string, encKeys []prefixSSEPair) encrypt.ServerSide {
 func validateAndCreateEncryptionKeys(ctx *cli.Context) (encMap map[string][]prefixSSEPair, err *probe.Error) {
 	encMap = make(map[string][]prefixSSEPair, 0)
 
+	kmsContext, err := parseSSEKMSContext(ctx.StringSlice("enc-context"))
+	if err != nil {
+		return nil, err
+	}
+	if kmsContext != nil && len(ctx.StringSlice("enc-kms")) == 0 {
+		return nil, errSSEKMSContext("--enc-context requires --enc-kms.")
+	}
+
 	for _, v := range ctx.StringSlice("enc-kms") {
-		prefixPair, alias, err := validateAndParseKey(ctx, v, sseKMS)
+		prefixPair, alias, err := validateAndParseKey(ctx, v, sseKMS, kmsContext)
 		if err != nil {
 			return nil, err
 		}
@@ -80,7 +91,7 @@ func validateAndCreateEncryptionKeys(ctx *cli.Context) (encMap map[string][]pref
 	}
 
 	for _, v := range ctx.StringSlice("enc-s3") {
-		prefixPair, alias, err := validateAndParseKey(ctx, v, sseS3)
+		prefixPair, alias, err := validateAndParseKey(ctx, v, sseS3, nil)
 		if err != nil {
 			return nil, err
 		}
@@ -88,7 +99,26 @@ func validateAndCreateEncryptionKeys(ctx *cli.Context) (encMap map[string][]pref
 	}
 
 	for _, v := range ctx.StringSlice("enc-c") {
-		prefixPair, alias, err := validateAndParseKey(ctx, v, sseC)
+		prefixPair, alias, err := validateAndParseKey(ctx, v, sseC, nil)
+		if err != nil {
+			return nil, err
+		}
+		encMap[alias] = append(encMap[alias], *prefixPair)
+	}
+
+	for _, v := range ctx.StringSlice("enc-c-file") {
+		prefix, path, found := strings.Cut(v, "=")
+		if !found || path == "" {
+			return nil, errSSEKeyMissing().Trace(v)
+		}
+		if alias, _ := splitKey(prefix); alias == "" {
+			return nil, errSSEInvalidAlias(prefix).Trace(v)
+		}
+		key, err := readSSECKeyFile(path)
+		if err != nil {
+			return nil, err
+		}
+		prefixPair, alias, err := validateAndParseKey(ctx, prefix+"="+hex.EncodeToString(key), sseC, nil)
 		if err != nil {
 			return nil, err
 		}
@@ -117,7 +147,27 @@ func validateAndCreateEncryptionKeys(ctx *cli.Context) (encMap map[string][]pref
 	return encMap, nil
 }
 
-func validateAndParseKey(ctx *cli.Context, key string, keyType sseKeyType) (SSEPair *prefixSSEPair, alias string, perr *probe.Error) {
+// parseSSEKMSContext parses the key=value pairs of the SSE-KMS encryption
+// context, nil is returned when no pairs are given.
+func parseSSEKMSContext(pairs []string) (map[string]string, *probe.Error) {
+	if len(pairs) == 0 {
+		return nil, nil
+	}
+	kmsContext := make(map[string]string, len(pairs))
+	for _, pair := range pairs {
+		key, value, found := strings.Cut(pair, "=")
+		if !found || key == "" {
+			return nil, errSSEKMSContext("Context should be of the form key=value.").Trace(pair)
+		}
+		if _, ok := kmsContext[key]; ok {
+			return nil, errSSEKMSContext("Context key `" + key + "` is specified more than once.").Trace(pair)
+		}
+		kmsContext[key] = value
+	}
+	return kmsContext, nil
+}
+
+func validateAndParseKey(ctx *cli.Context, key string, keyType sseKeyType, kmsContext map[string]string) (SSEPair *prefixSSEPair, alias string, perr *probe.Error) {
 	matchedCount := 0
 	alias, prefix, encKey, keyErr := parseSSEKey(key, keyType)
 	if keyErr != nil {
@@ -152,7 +202,11 @@ func validateAndParseKey(ctx *cli.Context, key string, keyType sseKeyType) (SSEP
 	case sseC:
 		sse, err = encrypt.NewSSEC([]byte(encKey))
 	case sseKMS:
-		sse, err = encrypt.NewSSEKMS(encKey, nil)
+		var sseContext interface{}
+		if kmsContext != nil {
+			sseContext = kmsContext
+		}
+		sse, err = encrypt.NewSSEKMS(encKey, sseContext)
 	case sseS3:
 		sse = encrypt.NewSSE()
 	}
@@ -243,6 +297,51 @@ func parseSSEKey(sseKey string, keyType sseKeyType) (
 	return
 }
 
+// readSSECKeyFile reads a SSE-C key from the file at path, the file
+// must not be accessible by other users.
+func readSSECKeyFile(path string) ([]byte, *probe.Error) {
+	st, e := os.Stat(path)
+	if e != nil {
+		return nil, probe.NewError(e).Trace(path)
+	}
+	if runtime.GOOS != "windows" && st.Mode().Perm()&0o077 != 0 {
+		return nil, errSSEClientKeyFile(fmt.Sprintf("Key file (%s) must not be accessible by group or others, its permissions are %#o.", path, st.Mode().Perm())).Trace(path)
+	}
+	data, e := os.ReadFile(path)
+	if e != nil {
+		return nil, probe.NewError(e).Trace(path)
+	}
+	key, e := parseSSECKeyFile(data)
+	if e != nil {
+		return nil, errSSEClientKeyFile(fmt.Sprintf("Key file (%s) %s.", path, e)).Trace(path)
+	}
+	return key, nil
+}
+
+// parseSSECKeyFile returns the SSE-C key in data, stored either as
+// 32 raw bytes or hex or base64 encoded.
+func parseSSECKeyFile(data []byte) ([]byte, error) {
+	if len(data) == 32 {
+		return data, nil
+	}
+
+	encodedKey := strings.TrimSpace(string(data))
+	var key []byte
+	var e error
+	if len(encodedKey) == 64 {
+		key, e = hex.DecodeString(encodedKey)
+	} else {
+		key, e = base64.RawStdEncoding.DecodeString(strings.TrimRight(encodedKey, "="))
+	}
+	if e != nil {
+		return nil, errors.New("is neither raw, base64 encoded nor hex encoded")
+	}
+	if len(key) != 32 {
+		return nil, fmt.Errorf("holds a %d bytes key, but should hold 32 bytes", len(key))
+	}
+	return key, nil
+}
+
 func validKMSKeyName(s string) bool {
 	if s == "" || s == "_" {
 		return false
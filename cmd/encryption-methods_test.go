@@ -19,6 +19,7 @@ package cmd
 
 import (
 	"fmt"
+	"reflect"
 	"testing"
 )
 
@@ -179,3 +180,52 @@ func TestParseEncryptionKeys(t *testing.T) {
 		}
 	}
 }
+
+func TestParseSSEKMSContext(t *testing.T) {
+	testCases := []struct {
+		pairs    []string
+		expected map[string]string
+		success  bool
+	}{
+		{nil, nil, true},
+		{[]string{"project=alpha"}, map[string]string{"project": "alpha"}, true},
+		{[]string{"project=alpha", "owner=a=b", "empty="}, map[string]string{"project": "alpha", "owner": "a=b", "empty": ""}, true},
+		{[]string{"project"}, nil, false},
+		{[]string{"=alpha"}, nil, false},
+		{[]string{"project=alpha", "project=beta"}, nil, false},
+	}
+	for i, tc := range testCases {
+		kmsContext, err := parseSSEKMSContext(tc.pairs)
+		if (err == nil) != tc.success {
+			t.Fatalf("Test %d: expected success %v, got %v", i+1, tc.success, err)
+		}
+		if !reflect.DeepEqual(kmsContext, tc.expected) {
+			t.Errorf("Test %d: expected %v, got %v", i+1, tc.expected, kmsContext)
+		}
+	}
+}
+
+func TestParseSSECKeyFile(t *testing.T) {
+	key := []byte("32byteslongsecretkeymustprovided")
+	testCases := []struct {
+		data    string
+		success bool
+	}{
+		{string(key), true},
+		{"MzJieXRlc2xvbmdzZWNyZXRrZXltdXN0cHJvdmlkZWQ\n", true},
+		{"MzJieXRlc2xvbmdzZWNyZXRrZXltdXN0cHJvdmlkZWQ=\n", true},
+		{"333262797465736c6f6e677365637265746b65796d75737470726f7669646564\n", true},
+		{"", false},
+		{"c2hvcnQ=", false},
+		{"not a key!", false},
+	}
+	for i, tc := range testCases {
+		got, e := parseSSECKeyFile([]byte(tc.data))
+		if (e == nil) != tc.success {
+			t.Fatalf("Test %d: expected success %v, got %v", i+1, tc.success, e)
+		}
+		if tc.success && !reflect.DeepEqual(got, key) {
+			t.Errorf("Test %d: expected %q, got %q", i+1, key, got)
+		}
+	}
+}
@@ -54,6 +54,11 @@ func fatalIf(err *probe.Error, msg string, data ...interface{}) {
 }
 
 func fatal(err *probe.Error, msg string, data ...interface{}) {
+	// The next commands of a script are still traced.
+	if globalScriptStep.Load() == nil {
+		shutdownTelemetry()
+	}
+
 	if globalJSON {
 		errorMsg := errorMessage{
 			Message: msg,
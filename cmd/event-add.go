@@ -46,6 +46,10 @@ var eventAddFlags = []cli.Flag{
 		Name:  "ignore-existing, p",
 		Usage: "ignore if event already exists",
 	},
+	cli.BoolFlag{
+		Name:  "replace",
+		Usage: "replace the configurations of the ARN sharing any of the events, e.g. to update their prefix and suffix filters",
+	},
 }
 
 var eventAddCmd = cli.Command{
@@ -76,6 +80,9 @@ EXAMPLES:
 
   4. Enable bucket notification for Replication and ILM transition events to a specific ARN
     {{.Prompt}} {{.HelpName}} myminio/mysourcebucket arn:aws:sqs:us-west-2:444455556666:your-queue --event replica,ilm
+
+  5. Update the prefix filter of an existing bucket notification of a specific ARN
+    {{.Prompt}} {{.HelpName}} s3/mybucket arn:aws:sqs:us-west-2:444455556666:your-queue --replace --event put,delete --prefix videos/
 `,
 }
 
@@ -88,11 +95,12 @@ func checkEventAddSyntax(ctx *cli.Context) {
 
 // eventAddMessage container
 type eventAddMessage struct {
-	ARN    string   `json:"arn"`
-	Event  []string `json:"event"`
-	Prefix string   `json:"prefix"`
-	Suffix string   `json:"suffix"`
-	Status string   `json:"status"`
+	ARN       string   `json:"arn"`
+	Event     []string `json:"event"`
+	Prefix    string   `json:"prefix"`
+	Suffix    string   `json:"suffix"`
+	Unchanged bool     `json:"unchanged,omitempty"`
+	Status    string   `json:"status"`
 }
 
 // JSON jsonified update message.
@@ -104,6 +112,9 @@ func (u eventAddMessage) JSON() string {
 }
 
 func (u eventAddMessage) String() string {
+	if u.Unchanged {
+		return console.Colorize("Event", "Notification for "+u.ARN+" is already configured")
+	}
 	msg := console.Colorize("Event", "Successfully added "+u.ARN)
 	return msg
 }
@@ -120,6 +131,7 @@ func mainEventAdd(cliCtx *cli.Context) error {
 	path := args[0]
 	arn := args[1]
 	ignoreExisting := cliCtx.Bool("p")
+	replace := cliCtx.Bool("replace")
 
 	event := strings.Split(cliCtx.String("event"), ",")
 	prefix := cliCtx.String("prefix")
@@ -135,13 +147,14 @@ func mainEventAdd(cliCtx *cli.Context) error {
 		fatalIf(errDummy().Trace(), "The provided url doesn't point to a S3 server.")
 	}
 
-	err = s3Client.AddNotificationConfig(ctx, arn, event, prefix, suffix, ignoreExisting)
+	changed, err := s3Client.AddNotificationConfig(ctx, arn, event, prefix, suffix, ignoreExisting, replace)
 	fatalIf(err, "Unable to enable notification on the specified bucket.")
 	printMsg(eventAddMessage{
-		ARN:    arn,
-		Event:  event,
-		Prefix: prefix,
-		Suffix: suffix,
+		ARN:       arn,
+		Event:     event,
+		Prefix:    prefix,
+		Suffix:    suffix,
+		Unchanged: !changed,
 	})
 
 	return nil
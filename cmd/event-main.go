@@ -25,6 +25,7 @@ var eventSubcommands = []cli.Command{
 	eventAddCmd,
 	eventRemoveCmd,
 	eventListCmd,
+	eventReplayCmd,
 }
 
 var eventCmd = cli.Command{
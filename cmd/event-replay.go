@@ -0,0 +1,429 @@
+// Copyright (c) 2015-2024 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	gojson "encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	json "github.com/minio/colorjson"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/pkg/v3/console"
+)
+
+var eventReplayFlags = []cli.Flag{
+	cli.StringFlag{
+		Name:  "since",
+		Usage: "replay objects modified at or after this time, e.g. 2024-01-01, 2024-01-01T15:04 or 7d",
+	},
+	cli.StringFlag{
+		Name:  "until",
+		Usage: "replay objects modified before this time, defaults to now",
+	},
+	cli.StringFlag{
+		Name:  "suffix",
+		Usage: "only replay objects with this suffix",
+	},
+	cli.StringFlag{
+		Name:  "event",
+		Usage: "event name to synthesize",
+		Value: "s3:ObjectCreated:Put",
+	},
+	cli.StringFlag{
+		Name:  "target",
+		Usage: "webhook URL or webhook ARN to deliver events to, defaults to JSON lines on stdout",
+	},
+	cli.StringFlag{
+		Name:   "auth-token",
+		Usage:  "bearer token for the webhook target, overrides the token configured on the server",
+		EnvVar: "MC_EVENT_REPLAY_AUTH_TOKEN",
+	},
+}
+
+var eventReplayCmd = cli.Command{
+	Name:         "replay",
+	Usage:        "synthesize create events for objects modified since a point in time",
+	Action:       mainEventReplay,
+	OnUsageError: onUsageError,
+	Before:       setGlobalsFromContext,
+	Flags:        append(eventReplayFlags, globalFlags...),
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} TARGET --since TIME [FLAGS]
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+ENVIRONMENT VARIABLES:
+  MC_EVENT_REPLAY_AUTH_TOKEN:  bearer token for the webhook target
+
+EXAMPLES:
+  1. Print create events for all objects written to 'mybucket' since the 1st of January 2024 as JSON lines.
+    {{.Prompt}} {{.HelpName}} myminio/mybucket --since 2024-01-01
+
+  2. Backfill the last 6 hours of '.jpg' uploads to a webhook endpoint.
+    {{.Prompt}} {{.HelpName}} myminio/mybucket/photos --since 6h --suffix .jpg --target https://hooks.example.com/minio
+
+  3. Backfill a downtime window to the webhook target configured on the server for the given ARN.
+    {{.Prompt}} {{.HelpName}} myminio/mybucket --since 2024-01-01T10:00 --until 2024-01-01T14:30 \
+       --target arn:minio:sqs::primary:webhook
+`,
+}
+
+// eventReplayTimeFormats - accepted layouts for --since and --until, in
+// addition to the ones supported by --rewind.
+var eventReplayTimeFormats = append([]string{
+	"2006-01-02",
+	"2006-01-02T15:04",
+	"2006-01-02T15:04:05",
+}, rewindSupportedFormat...)
+
+// parseEventReplayTime - parses an absolute time in the local time zone,
+// or a duration relative to now.
+func parseEventReplayTime(value string, now time.Time) (time.Time, error) {
+	for _, format := range eventReplayTimeFormats {
+		if t, e := time.ParseInLocation(format, value, now.Location()); e == nil {
+			return t, nil
+		}
+	}
+	duration, e := ParseDuration(value)
+	if e != nil {
+		return time.Time{}, fmt.Errorf("unrecognized time `%s`", value)
+	}
+	if duration < 0 {
+		return time.Time{}, errors.New("negative duration is not supported")
+	}
+	return now.Add(-time.Duration(duration)), nil
+}
+
+// eventReplayIdentity - user identity as found in S3 event records.
+type eventReplayIdentity struct {
+	PrincipalID string `json:"principalId"`
+}
+
+// eventReplayRecord - an S3 event record, laid out the same way the
+// server delivers it to notification targets.
+type eventReplayRecord struct {
+	EventVersion      string              `json:"eventVersion"`
+	EventSource       string              `json:"eventSource"`
+	AwsRegion         string              `json:"awsRegion"`
+	EventTime         string              `json:"eventTime"`
+	EventName         string              `json:"eventName"`
+	UserIdentity      eventReplayIdentity `json:"userIdentity"`
+	RequestParameters map[string]string   `json:"requestParameters"`
+	ResponseElements  map[string]string   `json:"responseElements"`
+	S3                struct {
+		SchemaVersion   string `json:"s3SchemaVersion"`
+		ConfigurationID string `json:"configurationId"`
+		Bucket          struct {
+			Name          string              `json:"name"`
+			OwnerIdentity eventReplayIdentity `json:"ownerIdentity"`
+			ARN           string              `json:"arn"`
+		} `json:"bucket"`
+		Object struct {
+			Key          string            `json:"key"`
+			Size         int64             `json:"size,omitempty"`
+			ETag         string            `json:"eTag,omitempty"`
+			ContentType  string            `json:"contentType,omitempty"`
+			UserMetadata map[string]string `json:"userMetadata,omitempty"`
+			VersionID    string            `json:"versionId,omitempty"`
+			Sequencer    string            `json:"sequencer"`
+		} `json:"object"`
+	} `json:"s3"`
+	Source struct {
+		Host      string `json:"host"`
+		Port      string `json:"port"`
+		UserAgent string `json:"userAgent"`
+	} `json:"source"`
+}
+
+// eventReplayPayload - body posted to webhook targets, one record per request.
+type eventReplayPayload struct {
+	EventName string              `json:"EventName"`
+	Key       string              `json:"Key"`
+	Records   []eventReplayRecord `json:"Records"`
+}
+
+// newEventReplayPayload - builds the event payload for an object.
+func newEventReplayPayload(eventName, region, bucket, object string, content *ClientContent) eventReplayPayload {
+	var rec eventReplayRecord
+	rec.EventVersion = "2.0"
+	rec.EventSource = "minio:s3"
+	rec.AwsRegion = region
+	rec.EventTime = content.Time.UTC().Format(time.RFC3339Nano)
+	rec.EventName = strings.TrimPrefix(eventName, "s3:")
+	rec.RequestParameters = map[string]string{}
+	rec.ResponseElements = map[string]string{}
+	rec.S3.SchemaVersion = "1.0"
+	rec.S3.ConfigurationID = "mc-event-replay"
+	rec.S3.Bucket.Name = bucket
+	rec.S3.Bucket.ARN = "arn:aws:s3:::" + bucket
+	rec.S3.Object.Key = url.QueryEscape(object)
+	rec.S3.Object.Size = content.Size
+	rec.S3.Object.ETag = strings.Trim(content.ETag, "\"")
+	rec.S3.Object.UserMetadata = content.UserMetadata
+	rec.S3.Object.VersionID = content.VersionID
+	rec.S3.Object.Sequencer = fmt.Sprintf("%X", content.Time.UnixNano())
+	if contentType, ok := content.Metadata["Content-Type"]; ok {
+		rec.S3.Object.ContentType = contentType
+	}
+	rec.Source.UserAgent = "mc event replay"
+	return eventReplayPayload{
+		EventName: eventName,
+		Key:       bucket + "/" + object,
+		Records:   []eventReplayRecord{rec},
+	}
+}
+
+// eventReplayLineMessage - a synthesized event printed to stdout.
+type eventReplayLineMessage struct {
+	payload eventReplayPayload
+}
+
+func (m eventReplayLineMessage) JSON() string {
+	buf, e := gojson.Marshal(m.payload)
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+	return string(buf)
+}
+
+func (m eventReplayLineMessage) String() string {
+	return m.JSON()
+}
+
+// eventReplayMessage - status of an event delivered to a webhook target.
+type eventReplayMessage struct {
+	Status string `json:"status"`
+	Key    string `json:"key,omitempty"`
+	Target string `json:"target"`
+	Error  string `json:"error,omitempty"`
+	Total  int    `json:"total,omitempty"`
+	Failed int    `json:"failed,omitempty"`
+}
+
+func (m eventReplayMessage) JSON() string {
+	buf, e := json.MarshalIndent(m, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+	return string(buf)
+}
+
+func (m eventReplayMessage) String() string {
+	if m.Key == "" {
+		return console.Colorize("Replay", fmt.Sprintf("Replayed %d event(s) to `%s`, %d failed.", m.Total, m.Target, m.Failed))
+	}
+	if m.Error != "" {
+		return console.Colorize("ReplayFailed", fmt.Sprintf("Failed to replay `%s`: %s", m.Key, m.Error))
+	}
+	return fmt.Sprintf("Replayed `%s`", m.Key)
+}
+
+// eventReplayWebhook - a webhook endpoint to post events to.
+type eventReplayWebhook struct {
+	endpoint  string
+	authToken string
+	client    *http.Client
+}
+
+func (w eventReplayWebhook) send(ctx context.Context, payload eventReplayPayload) error {
+	body, e := gojson.Marshal(payload)
+	if e != nil {
+		return e
+	}
+	req, e := http.NewRequestWithContext(ctx, http.MethodPost, w.endpoint, bytes.NewReader(body))
+	if e != nil {
+		return e
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if w.authToken != "" {
+		// Tokens configured with an explicit scheme are sent as is.
+		if strings.Contains(w.authToken, " ") {
+			req.Header.Set("Authorization", w.authToken)
+		} else {
+			req.Header.Set("Authorization", "Bearer "+w.authToken)
+		}
+	}
+	resp, e := w.client.Do(req)
+	if e != nil {
+		return e
+	}
+	defer func() {
+		io.Copy(io.Discard, resp.Body)
+		resp.Body.Close()
+	}()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("%s returned %s", w.endpoint, resp.Status)
+	}
+	return nil
+}
+
+// resolveEventReplayWebhook - resolves --target into a webhook endpoint. ARNs
+// are looked up in the notify_webhook configuration of the alias.
+func resolveEventReplayWebhook(aliasedURL, target, authToken string) (eventReplayWebhook, *probe.Error) {
+	webhook := eventReplayWebhook{
+		endpoint:  target,
+		authToken: authToken,
+		client:    httpClient(globalConnReadDeadline),
+	}
+	if !strings.HasPrefix(target, "arn:") {
+		u, e := url.Parse(target)
+		if e != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return webhook, probe.NewError(fmt.Errorf("`%s` is neither a webhook URL nor an ARN", target))
+		}
+		return webhook, nil
+	}
+
+	// arn:minio:sqs:REGION:ID:webhook
+	parts := strings.Split(target, ":")
+	if len(parts) != 6 || parts[5] != "webhook" || parts[4] == "" {
+		return webhook, probe.NewError(fmt.Errorf("only webhook ARNs can be replayed to, got `%s`", target))
+	}
+
+	aliasName, _ := url2Alias(aliasedURL)
+	client, err := newAdminClient(aliasName)
+	if err != nil {
+		return webhook, err.Trace(aliasName)
+	}
+	cfgs, e := getMinIOSubSysConfig(client, "notify_webhook:"+parts[4])
+	if e != nil {
+		return webhook, probe.NewError(e).Trace(target)
+	}
+	if len(cfgs) == 0 {
+		return webhook, probe.NewError(fmt.Errorf("no webhook target is configured for `%s`", target))
+	}
+	endpoint, _ := cfgs[0].Lookup("endpoint")
+	if endpoint == "" {
+		return webhook, probe.NewError(fmt.Errorf("the webhook target for `%s` has no endpoint", target))
+	}
+	webhook.endpoint = endpoint
+	if webhook.authToken == "" {
+		webhook.authToken, _ = cfgs[0].Lookup("auth_token")
+	}
+	return webhook, nil
+}
+
+// checkEventReplaySyntax - validate all the passed arguments
+func checkEventReplaySyntax(ctx *cli.Context) {
+	if len(ctx.Args()) != 1 {
+		showCommandHelpAndExit(ctx, 1) // last argument is exit code
+	}
+	if ctx.String("since") == "" {
+		fatalIf(errInvalidArgument().Trace(), "--since is required.")
+	}
+	if !strings.HasPrefix(ctx.String("event"), "s3:ObjectCreated:") {
+		fatalIf(errInvalidArgument().Trace(ctx.String("event")), "Only s3:ObjectCreated:* events can be replayed.")
+	}
+	if ctx.IsSet("auth-token") && ctx.String("target") == "" {
+		fatalIf(errInvalidArgument().Trace(), "--auth-token requires --target.")
+	}
+}
+
+func mainEventReplay(cliCtx *cli.Context) error {
+	ctx, cancelEventReplay := context.WithCancel(globalContext)
+	defer cancelEventReplay()
+
+	console.SetColor("Replay", color.New(color.FgGreen, color.Bold))
+	console.SetColor("ReplayFailed", color.New(color.FgRed, color.Bold))
+
+	checkEventReplaySyntax(cliCtx)
+
+	aliasedURL := cliCtx.Args().Get(0)
+	now := time.Now()
+	since, e := parseEventReplayTime(cliCtx.String("since"), now)
+	fatalIf(probe.NewError(e).Trace(cliCtx.String("since")), "Unable to parse --since.")
+	until := now
+	if cliCtx.IsSet("until") {
+		until, e = parseEventReplayTime(cliCtx.String("until"), now)
+		fatalIf(probe.NewError(e).Trace(cliCtx.String("until")), "Unable to parse --until.")
+	}
+	if !since.Before(until) {
+		fatalIf(errInvalidArgument().Trace(), "--since must be before --until.")
+	}
+
+	client, err := newClient(aliasedURL)
+	fatalIf(err.Trace(aliasedURL), "Unable to parse the provided url.")
+	s3Client, ok := client.(*S3Client)
+	if !ok {
+		fatalIf(errDummy().Trace(), "The provided url doesn't point to a S3 server.")
+	}
+
+	target := cliCtx.String("target")
+	var webhook eventReplayWebhook
+	if target != "" {
+		webhook, err = resolveEventReplayWebhook(aliasedURL, target, cliCtx.String("auth-token"))
+		fatalIf(err, "Unable to resolve the replay target.")
+	}
+
+	bucketName, _ := s3Client.url2BucketAndObject()
+	if bucketName == "" {
+		fatalIf(errInvalidArgument().Trace(aliasedURL), "A bucket is required to replay events.")
+	}
+	region, e := s3Client.api.GetBucketLocation(ctx, bucketName)
+	fatalIf(probe.NewError(e).Trace(aliasedURL), "Unable to get the bucket location.")
+
+	eventName := cliCtx.String("event")
+	suffix := cliCtx.String("suffix")
+	var total, failed int
+	for content := range s3Client.List(ctx, ListOptions{Recursive: true, WithMetadata: true, ShowDir: DirNone}) {
+		if content.Err != nil {
+			fatalIf(content.Err.Trace(aliasedURL), "Unable to list objects.")
+		}
+		if content.Type.IsDir() || content.Time.Before(since) || !content.Time.Before(until) {
+			continue
+		}
+		bucket, object := s3Client.splitPath(content.URL.Path)
+		if !strings.HasSuffix(object, suffix) {
+			continue
+		}
+		payload := newEventReplayPayload(eventName, region, bucket, object, content)
+		if target == "" {
+			printMsg(eventReplayLineMessage{payload: payload})
+			continue
+		}
+		total++
+		msg := eventReplayMessage{Status: "success", Key: payload.Key, Target: target}
+		if e := webhook.send(ctx, payload); e != nil {
+			failed++
+			msg.Status = "error"
+			msg.Error = e.Error()
+		}
+		printMsg(msg)
+	}
+
+	if target != "" {
+		status := "success"
+		if failed > 0 {
+			status = "error"
+		}
+		printMsg(eventReplayMessage{Status: status, Target: target, Total: total, Failed: failed})
+		if failed > 0 {
+			return exitStatus(globalErrorExitStatus)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,76 @@
+// Copyright (c) 2015-2024 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseEventReplayTime(t *testing.T) {
+	now := time.Date(2024, 3, 10, 12, 0, 0, 0, time.UTC)
+	testCases := []struct {
+		value   string
+		want    time.Time
+		wantErr bool
+	}{
+		{"2024-01-01", time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), false},
+		{"2024-01-01T10:30", time.Date(2024, 1, 1, 10, 30, 0, 0, time.UTC), false},
+		{"2024.01.02", time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC), false},
+		{"6h", now.Add(-6 * time.Hour), false},
+		{"1d", now.Add(-24 * time.Hour), false},
+		{"yesterday", time.Time{}, true},
+	}
+	for _, tc := range testCases {
+		got, e := parseEventReplayTime(tc.value, now)
+		if (e != nil) != tc.wantErr {
+			t.Fatalf("%s: unexpected error %v", tc.value, e)
+		}
+		if !got.Equal(tc.want) {
+			t.Fatalf("%s: expected %s, got %s", tc.value, tc.want, got)
+		}
+	}
+}
+
+func TestNewEventReplayPayload(t *testing.T) {
+	modTime := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+	content := &ClientContent{
+		Time:      modTime,
+		Size:      42,
+		ETag:      `"abc"`,
+		VersionID: "v1",
+		Metadata:  map[string]string{"Content-Type": "image/jpeg"},
+	}
+	p := newEventReplayPayload("s3:ObjectCreated:Put", "us-east-1", "photos", "2024/a b.jpg", content)
+	if p.EventName != "s3:ObjectCreated:Put" || p.Key != "photos/2024/a b.jpg" || len(p.Records) != 1 {
+		t.Fatalf("unexpected payload %+v", p)
+	}
+	rec := p.Records[0]
+	if rec.EventName != "ObjectCreated:Put" {
+		t.Fatalf("expected event name without s3: prefix, got %s", rec.EventName)
+	}
+	if rec.S3.Object.Key != "2024%2Fa+b.jpg" {
+		t.Fatalf("expected escaped key, got %s", rec.S3.Object.Key)
+	}
+	if rec.S3.Object.ETag != "abc" || rec.S3.Object.ContentType != "image/jpeg" || rec.S3.Object.Size != 42 {
+		t.Fatalf("unexpected object metadata %+v", rec.S3.Object)
+	}
+	if rec.EventTime != "2024-01-01T10:00:00Z" || rec.AwsRegion != "us-east-1" || rec.S3.Bucket.Name != "photos" {
+		t.Fatalf("unexpected record %+v", rec)
+	}
+}
@@ -0,0 +1,111 @@
+// Copyright (c) 2015-2024 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Output formats of find besides the default listing.
+const findOutCSV = "csv"
+
+// Fields printed by --out csv when --fields is not set.
+const findDefaultCSVFields = "key,size,modtime,storage-class"
+
+// findCSVFields maps the fields accepted by --fields to their value.
+var findCSVFields = map[string]func(contentMessage) string{
+	"key": func(c contentMessage) string { return c.Key },
+	"size": func(c contentMessage) string {
+		return strconv.FormatInt(c.Size, 10)
+	},
+	"modtime": func(c contentMessage) string {
+		return c.Time.UTC().Format(time.RFC3339Nano)
+	},
+	"etag":          func(c contentMessage) string { return c.ETag },
+	"storage-class": func(c contentMessage) string { return c.StorageClass },
+	"version-id":    func(c contentMessage) string { return c.VersionID },
+	"is-latest": func(c contentMessage) string {
+		return strconv.FormatBool(c.IsLatest)
+	},
+	"is-delete-marker": func(c contentMessage) string {
+		return strconv.FormatBool(c.IsDeleteMarker)
+	},
+}
+
+// parseFindCSVFields validates the comma separated list of --fields.
+func parseFindCSVFields(fields string) ([]string, error) {
+	if strings.TrimSpace(fields) == "" {
+		fields = findDefaultCSVFields
+	}
+	var parsed []string
+	for _, field := range strings.Split(fields, ",") {
+		field = strings.ToLower(strings.TrimSpace(field))
+		if _, ok := findCSVFields[field]; !ok {
+			return nil, fmt.Errorf("unknown field `%s`, valid fields are key, size, modtime, etag, storage-class, version-id, is-latest and is-delete-marker", field)
+		}
+		parsed = append(parsed, field)
+	}
+	return parsed, nil
+}
+
+// findCSVWriter prints the matched objects as CSV with a header line,
+// values are quoted as needed so keys with commas, quotes or newlines
+// are kept intact.
+type findCSVWriter struct {
+	w             *csv.Writer
+	fields        []string
+	headerWritten bool
+}
+
+func newFindCSVWriter(w io.Writer, fields []string) *findCSVWriter {
+	return &findCSVWriter{w: csv.NewWriter(w), fields: fields}
+}
+
+func (f *findCSVWriter) writeHeader() error {
+	if f.headerWritten {
+		return nil
+	}
+	f.headerWritten = true
+	return f.w.Write(f.fields)
+}
+
+func (f *findCSVWriter) Write(c contentMessage) error {
+	if e := f.writeHeader(); e != nil {
+		return e
+	}
+	record := make([]string, len(f.fields))
+	for i, field := range f.fields {
+		record[i] = findCSVFields[field](c)
+	}
+	return f.w.Write(record)
+}
+
+// Flush writes the buffered lines, the header is written even if no
+// object matched.
+func (f *findCSVWriter) Flush() error {
+	if e := f.writeHeader(); e != nil {
+		return e
+	}
+	f.w.Flush()
+	return f.w.Error()
+}
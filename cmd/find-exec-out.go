@@ -0,0 +1,87 @@
+// Copyright (c) 2015-2024 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"encoding/json"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/minio/mc/pkg/probe"
+)
+
+// Captured output of an --exec command kept in the --exec-out results.
+const findExecOutMaxBytes = 4 * 1024
+
+// findExecResult is the result of an --exec command run for an object.
+type findExecResult struct {
+	Object     string    `json:"object"`
+	VersionID  string    `json:"versionId,omitempty"`
+	Command    []string  `json:"command"`
+	Start      time.Time `json:"start"`
+	DurationMS int64     `json:"durationMs"`
+	ExitCode   int       `json:"exitCode"`
+	Error      string    `json:"error,omitempty"`
+	Stdout     string    `json:"stdout"`
+	Stderr     string    `json:"stderr"`
+	Truncated  bool      `json:"truncated,omitempty"`
+}
+
+// findExecRecorder writes the results of the --exec commands to the
+// --exec-out file, one JSON object per line.
+type findExecRecorder struct {
+	mu     sync.Mutex
+	f      *os.File
+	enc    *json.Encoder
+	failed int
+}
+
+func newFindExecRecorder(path string) (*findExecRecorder, *probe.Error) {
+	f, e := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if e != nil {
+		return nil, probe.NewError(e)
+	}
+	return &findExecRecorder{f: f, enc: json.NewEncoder(f)}, nil
+}
+
+// truncateExecOutput returns at most findExecOutMaxBytes of out.
+func truncateExecOutput(out []byte) (string, bool) {
+	if len(out) > findExecOutMaxBytes {
+		return string(out[:findExecOutMaxBytes]), true
+	}
+	return string(out), false
+}
+
+// record writes the result of a command.
+func (r *findExecRecorder) record(result findExecResult) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if result.ExitCode != 0 {
+		r.failed++
+	}
+	e := r.enc.Encode(result)
+	fatalIf(probe.NewError(e).Trace(r.f.Name()), "Unable to write the --exec results.")
+}
+
+// close closes the results file and returns the number of failed commands.
+func (r *findExecRecorder) close() (failed int, err *probe.Error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.failed, probe.NewError(r.f.Close())
+}
@@ -19,7 +19,9 @@ package cmd
 
 import (
 	"context"
+	"os"
 	"regexp"
+	"strconv"
 	"strings"
 	"time"
 
@@ -37,6 +39,15 @@ var (
 			Name:  "exec",
 			Usage: "spawn an external process for each matching object (see FORMAT)",
 		},
+		cli.IntFlag{
+			Name:  "exec-workers",
+			Usage: "number of --exec processes to run concurrently",
+			Value: 1,
+		},
+		cli.StringFlag{
+			Name:  "exec-out",
+			Usage: "append the exit code, duration and output of each --exec process to a JSON lines file, failures do not stop find",
+		},
 		cli.StringFlag{
 			Name:  "ignore",
 			Usage: "exclude objects matching the wildcard pattern",
@@ -45,6 +56,14 @@ var (
 			Name:  "versions",
 			Usage: "include all objects versions",
 		},
+		cli.BoolFlag{
+			Name:  "with-delete-markers",
+			Usage: "include delete markers, requires --versions",
+		},
+		cli.BoolFlag{
+			Name:  "non-current",
+			Usage: "match only non-current versions, requires --versions",
+		},
 		cli.StringFlag{
 			Name:  "name",
 			Usage: "find object names matching wildcard pattern",
@@ -93,6 +112,14 @@ var (
 			Name:  "tags",
 			Usage: "match tags with RE2 regex pattern. Specify each with key=regex. MinIO server only.",
 		},
+		cli.StringFlag{
+			Name:  "out",
+			Usage: "print the matching objects in the given format, only 'csv' is supported",
+		},
+		cli.StringFlag{
+			Name:  "fields",
+			Usage: "comma separated fields printed by --out csv: key, size, modtime, etag, storage-class, version-id, is-latest, is-delete-marker (default: \"key,size,modtime,storage-class\")",
+		},
 	}
 )
 
@@ -132,6 +159,9 @@ FORMAT
      {size}    --> Substitutes to object size of the path.
      {time}    --> Substitutes to object modified time of the path.
      {version} --> Substitutes to object version identifier.
+     {latest}  --> Substitutes to "true" for the latest version of an object, "false" otherwise.
+     {delete-marker} --> Substitutes to "true" for a delete marker, "false" otherwise.
+     {etag}    --> Substitutes to object ETag.
 
   Keywords supported if target is object storage:
 
@@ -171,6 +201,24 @@ EXAMPLES:
 
   11. Copy all versions of all objects in bucket in the local machine
       {{.Prompt}} {{.HelpName}} s3/bucket --versions --exec "mc cp --version-id {version} {} /tmp/dir/{}.{version}"
+
+  12. Remove all versions and delete markers of all objects older than 30 days in bucket, running 8 removals at a time.
+      {{.Prompt}} {{.HelpName}} s3/bucket --versions --with-delete-markers --older-than 30d --exec-workers 8 --exec "mc rm --version-id {version} {}"
+
+  13. Print the ETag of all objects with ".iso" extension in bucket.
+      {{.Prompt}} {{.HelpName}} s3/bucket --name "*.iso" --print "{etag} {}"
+
+  14. Transcode all videos in bucket and record the result of each transcoding in "results.jsonl".
+      {{.Prompt}} {{.HelpName}} s3/bucket --name "*.mov" --exec-workers 4 --exec-out results.jsonl --exec "transcode.sh {}"
+
+  15. Remove the non-current versions of the logs older than 90 days in bucket.
+      {{.Prompt}} {{.HelpName}} s3/bucket/logs --versions --non-current --older-than 90d --exec "mc rm --version-id {version} {}"
+
+  16. Print the delete markers of bucket along with the latest version of each object.
+      {{.Prompt}} {{.HelpName}} s3/bucket --versions --with-delete-markers --print "{} {version} latest={latest} delete-marker={delete-marker}"
+
+  17. Write an inventory of the objects larger than 1 GiB in bucket to a spreadsheet.
+      {{.Prompt}} {{.HelpName}} s3/bucket --larger 1GiB --out csv --fields key,size,modtime,storage-class > inventory.csv
 `,
 }
 
@@ -189,6 +237,36 @@ func checkFindSyntax(ctx context.Context, cliCtx *cli.Context, encKeyDB map[stri
 		}
 	}
 
+	if cliCtx.String("exec-out") != "" && cliCtx.String("exec") == "" {
+		fatalIf(errInvalidArgument().Trace(cliCtx.String("exec-out")), "--exec-out requires --exec.")
+	}
+
+	for _, flag := range []string{"with-delete-markers", "non-current"} {
+		if cliCtx.Bool(flag) && !cliCtx.Bool("versions") {
+			fatalIf(errInvalidArgument().Trace(flag), "--"+flag+" requires --versions.")
+		}
+	}
+
+	if out := cliCtx.String("out"); out != "" {
+		if out != findOutCSV {
+			fatalIf(errInvalidArgument().Trace(out), "Unsupported --out format, only `csv` is supported.")
+		}
+		for _, flag := range []string{"exec", "print"} {
+			if cliCtx.String(flag) != "" {
+				fatalIf(errInvalidArgument().Trace(flag), "--out cannot be used with --"+flag+".")
+			}
+		}
+		if _, e := parseFindCSVFields(cliCtx.String("fields")); e != nil {
+			fatalIf(probe.NewError(e).Trace(cliCtx.String("fields")), "Unable to parse --fields.")
+		}
+	} else if cliCtx.String("fields") != "" {
+		fatalIf(errInvalidArgument().Trace(cliCtx.String("fields")), "--fields requires --out csv.")
+	}
+
+	if cliCtx.Int("exec-workers") < 1 {
+		fatalIf(errInvalidArgument().Trace(strconv.Itoa(cliCtx.Int("exec-workers"))), "--exec-workers must be at least 1.")
+	}
+
 	// Extract input URLs and validate.
 	for _, url := range args {
 		_, _, err := url2Stat(ctx, url2StatOptions{urlStr: url, versionID: "", fileAttr: false, encKeyDB: encKeyDB, timeRef: time.Time{}, isZip: false, ignoreBucketExistsCheck: false})
@@ -208,6 +286,8 @@ func checkFindSyntax(ctx context.Context, cliCtx *cli.Context, encKeyDB map[stri
 type findContext struct {
 	*cli.Context
 	execCmd       string
+	execWorkers   int
+	execOut       *findExecRecorder
 	ignorePattern string
 	namePattern   string
 	pathPattern   string
@@ -220,10 +300,15 @@ type findContext struct {
 	smallerSize   uint64
 	watch         bool
 	withVersions  bool
-	matchMeta     map[string]*regexp.Regexp
-	matchTags     map[string]*regexp.Regexp
+	// Only with versions.
+	withDeleteMarkers bool
+	nonCurrent        bool
+	matchMeta         map[string]*regexp.Regexp
+	matchTags         map[string]*regexp.Regexp
+	csvOut            *findCSVWriter
 
 	// Internal values
+	execCh        chan contentMessage
 	targetAlias   string
 	targetURL     string
 	targetFullURL string
@@ -294,26 +379,52 @@ func mainFind(cliCtx *cli.Context) error {
 		regMatch = regexp.MustCompile(cliCtx.String("regex"))
 	}
 
-	return doFind(ctx, &findContext{
-		Context:       cliCtx,
-		maxDepth:      cliCtx.Uint("maxdepth"),
-		execCmd:       cliCtx.String("exec"),
-		printFmt:      cliCtx.String("print"),
-		namePattern:   cliCtx.String("name"),
-		pathPattern:   cliCtx.String("path"),
-		regexPattern:  regMatch,
-		ignorePattern: cliCtx.String("ignore"),
-		withVersions:  withVersions,
-		olderThan:     olderThan,
-		newerThan:     newerThan,
-		largerSize:    largerSize,
-		smallerSize:   smallerSize,
-		watch:         cliCtx.Bool("watch"),
-		targetAlias:   targetAlias,
-		targetURL:     args[0],
-		targetFullURL: targetFullURL,
-		clnt:          clnt,
-		matchMeta:     getRegexMap(cliCtx, "metadata"),
-		matchTags:     getRegexMap(cliCtx, "tags"),
+	var execOut *findExecRecorder
+	if path := cliCtx.String("exec-out"); path != "" {
+		execOut, err = newFindExecRecorder(path)
+		fatalIf(err.Trace(path), "Unable to open the --exec results file.")
+	}
+
+	var csvOut *findCSVWriter
+	if cliCtx.String("out") == findOutCSV {
+		fields, _ := parseFindCSVFields(cliCtx.String("fields"))
+		csvOut = newFindCSVWriter(os.Stdout, fields)
+	}
+
+	e = doFind(ctx, &findContext{
+		Context:           cliCtx,
+		maxDepth:          cliCtx.Uint("maxdepth"),
+		execCmd:           cliCtx.String("exec"),
+		execWorkers:       cliCtx.Int("exec-workers"),
+		execOut:           execOut,
+		printFmt:          cliCtx.String("print"),
+		namePattern:       cliCtx.String("name"),
+		pathPattern:       cliCtx.String("path"),
+		regexPattern:      regMatch,
+		ignorePattern:     cliCtx.String("ignore"),
+		withVersions:      withVersions,
+		withDeleteMarkers: cliCtx.Bool("with-delete-markers"),
+		nonCurrent:        cliCtx.Bool("non-current"),
+		olderThan:         olderThan,
+		newerThan:         newerThan,
+		largerSize:        largerSize,
+		smallerSize:       smallerSize,
+		watch:             cliCtx.Bool("watch"),
+		targetAlias:       targetAlias,
+		targetURL:         args[0],
+		targetFullURL:     targetFullURL,
+		clnt:              clnt,
+		matchMeta:         getRegexMap(cliCtx, "metadata"),
+		matchTags:         getRegexMap(cliCtx, "tags"),
+		csvOut:            csvOut,
 	})
+	if execOut != nil {
+		failed, err := execOut.close()
+		fatalIf(err.Trace(cliCtx.String("exec-out")), "Unable to write the --exec results.")
+		if failed > 0 {
+			errorIf(errDummy().Trace(cliCtx.String("exec-out")), "%d --exec commands failed, see `%s`.", failed, cliCtx.String("exec-out"))
+			return exitStatus(globalErrorExitStatus)
+		}
+	}
+	return e
 }
@@ -22,12 +22,12 @@ import (
 	"context"
 	"fmt"
 	"net/http"
-	"os"
 	"os/exec"
 	"path/filepath"
 	"regexp"
 	"strconv"
 	"strings"
+	"sync"
 	"syscall"
 	"time"
 
@@ -127,11 +127,11 @@ func getExitStatus(err error) int {
 
 // execFind executes the input command line, additionally formats input
 // for the command line in accordance with subsititution arguments.
-func execFind(ctx context.Context, args string, fileContent contentMessage) {
+func execFind(ctx context.Context, args string, fileContent contentMessage, rec *findExecRecorder) {
 	split, err := shlex.Split(args)
 	if err != nil {
 		console.Println(console.Colorize("FindExecErr", "Unable to parse --exec: "+err.Error()))
-		os.Exit(getExitStatus(err))
+		cli.OsExiter(getExitStatus(err))
 	}
 	if len(split) == 0 {
 		return
@@ -144,17 +144,75 @@ func execFind(ctx context.Context, args string, fileContent contentMessage) {
 	var stderr bytes.Buffer
 	cmd.Stdout = &out
 	cmd.Stderr = &stderr
-	if err := cmd.Run(); err != nil {
+	start := time.Now()
+	err = cmd.Run()
+	if rec != nil {
+		// Failures are recorded and do not stop the other commands.
+		result := findExecResult{
+			Object:     fileContent.Key,
+			VersionID:  fileContent.VersionID,
+			Command:    split,
+			Start:      start,
+			DurationMS: time.Since(start).Milliseconds(),
+		}
+		var outTruncated, errTruncated bool
+		result.Stdout, outTruncated = truncateExecOutput(out.Bytes())
+		result.Stderr, errTruncated = truncateExecOutput(stderr.Bytes())
+		result.Truncated = outTruncated || errTruncated
+		if err != nil {
+			result.ExitCode = getExitStatus(err)
+			result.Error = err.Error()
+			console.Println(console.Colorize("FindExecErr", fileContent.Key+": "+err.Error()))
+		}
+		rec.record(result)
+		console.PrintC(out.String())
+		return
+	}
+	if err != nil {
 		if stderr.Len() > 0 {
 			console.Println(console.Colorize("FindExecErr", strings.TrimSpace(stderr.String())))
 		}
 		console.Println(console.Colorize("FindExecErr", err.Error()))
 		// Return exit status of the command run
-		os.Exit(getExitStatus(err))
+		cli.OsExiter(getExitStatus(err))
 	}
 	console.PrintC(out.String())
 }
 
+// startExecWorkers starts the workers running the --exec commands
+// concurrently when --exec-workers is more than one, the returned
+// function waits for all the queued commands to finish.
+func (ctx *findContext) startExecWorkers(ctxCtx context.Context) func() {
+	if ctx.execCmd == "" || ctx.execWorkers <= 1 {
+		return func() {}
+	}
+	ctx.execCh = make(chan contentMessage)
+	var wg sync.WaitGroup
+	for i := 0; i < ctx.execWorkers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for fileContent := range ctx.execCh {
+				execFind(ctxCtx, ctx.execCmd, fileContent, ctx.execOut)
+			}
+		}()
+	}
+	return func() {
+		close(ctx.execCh)
+		wg.Wait()
+	}
+}
+
+// exec runs the --exec command for the matching content, or queues
+// it to the exec workers if they are started.
+func (ctx *findContext) exec(ctxCtx context.Context, fileContent contentMessage) {
+	if ctx.execCh == nil {
+		execFind(ctxCtx, ctx.execCmd, fileContent, ctx.execOut)
+		return
+	}
+	ctx.execCh <- fileContent
+}
+
 // watchFind - enables listening on the input path, listens for all file/object
 // created actions. Asynchronously executes the input command line, also allows
 // formatting for the command line in accordance with subsititution arguments.
@@ -190,9 +248,11 @@ func watchFind(ctxCtx context.Context, ctx *findContext) {
 				}
 
 				find(ctxCtx, ctx, contentMessage{
-					Key:  getAliasedPath(ctx, event.Path),
-					Time: time,
-					Size: event.Size,
+					Key:       getAliasedPath(ctx, event.Path),
+					VersionID: event.VersionID,
+					ETag:      event.ETag,
+					Time:      time,
+					Size:      event.Size,
 				})
 			}
 		case err, ok := <-watchObj.Errors():
@@ -255,7 +315,21 @@ func find(ctxCtx context.Context, ctx *findContext, fileContent contentMessage)
 
 	// proceed to either exec, format the output string.
 	if ctx.execCmd != "" {
-		execFind(ctxCtx, ctx.execCmd, fileContent)
+		ctx.exec(ctxCtx, fileContent)
+		return
+	}
+	ctx.print(ctxCtx, fileContent)
+	if ctx.csvOut != nil {
+		// Watched objects are printed as they are found.
+		fatalIf(probe.NewError(ctx.csvOut.Flush()), "Unable to write the CSV output.")
+	}
+}
+
+// print displays the matching content, as CSV with --out csv or
+// formatted by --print.
+func (ctx *findContext) print(ctxCtx context.Context, fileContent contentMessage) {
+	if ctx.csvOut != nil {
+		fatalIf(probe.NewError(ctx.csvOut.Write(fileContent)), "Unable to write the CSV output.")
 		return
 	}
 	if ctx.printFmt != "" {
@@ -267,6 +341,9 @@ func find(ctxCtx context.Context, ctx *findContext, fileContent contentMessage)
 // doFind - find is main function body which interprets and executes
 // all the input parameters.
 func doFind(ctxCtx context.Context, ctx *findContext) error {
+	// Wait for the commands still running, after watch returns.
+	defer ctx.startExecWorkers(ctxCtx)()
+
 	// If watch is enabled we will wait on the prefix perpetually
 	// for all I/O events until canceled by user, if watch is not enabled
 	// following defer is a no-op.
@@ -274,7 +351,7 @@ func doFind(ctxCtx context.Context, ctx *findContext) error {
 
 	lstOptions := ListOptions{
 		WithOlderVersions: ctx.withVersions,
-		WithDeleteMarkers: ctx.withVersions,
+		WithDeleteMarkers: ctx.withDeleteMarkers,
 		Recursive:         true,
 		ShowDir:           DirFirst,
 		WithMetadata:      len(ctx.matchMeta) > 0 || len(ctx.matchTags) > 0,
@@ -307,12 +384,16 @@ func doFind(ctxCtx context.Context, ctx *findContext) error {
 
 		fileKeyName := getAliasedPath(ctx, content.URL.String())
 		fileContent := contentMessage{
-			Key:       fileKeyName,
-			VersionID: content.VersionID,
-			Time:      content.Time.Local(),
-			Size:      content.Size,
-			Metadata:  content.UserMetadata,
-			Tags:      content.Tags,
+			Key:            fileKeyName,
+			VersionID:      content.VersionID,
+			IsDeleteMarker: content.IsDeleteMarker,
+			IsLatest:       content.IsLatest,
+			ETag:           content.ETag,
+			Time:           content.Time.Local(),
+			Size:           content.Size,
+			StorageClass:   content.StorageClass,
+			Metadata:       content.UserMetadata,
+			Tags:           content.Tags,
 		}
 
 		// Match the incoming content, didn't match return.
@@ -322,14 +403,14 @@ func doFind(ctxCtx context.Context, ctx *findContext) error {
 
 		// proceed to either exec, format the output string.
 		if ctx.execCmd != "" {
-			execFind(ctxCtx, ctx.execCmd, fileContent)
+			ctx.exec(ctxCtx, fileContent)
 			continue
 		}
-		if ctx.printFmt != "" {
-			fileContent.Key = stringsReplace(ctxCtx, ctx.printFmt, fileContent)
-		}
+		ctx.print(ctxCtx, fileContent)
+	}
 
-		printMsg(findMessage{fileContent})
+	if ctx.csvOut != nil {
+		fatalIf(probe.NewError(ctx.csvOut.Flush()), "Unable to write the CSV output.")
 	}
 
 	// Success, notice watch will execute in defer only if enabled and this call
@@ -388,6 +469,18 @@ func stringsReplace(ctx context.Context, args string, fileContent contentMessage
 	// replace all instances of {"version"}
 	str = strings.ReplaceAll(str, `{"version"}`, strconv.Quote(fileContent.VersionID))
 
+	// replace all instances of {latest}
+	str = strings.ReplaceAll(str, `{latest}`, strconv.FormatBool(fileContent.IsLatest))
+
+	// replace all instances of {delete-marker}
+	str = strings.ReplaceAll(str, `{delete-marker}`, strconv.FormatBool(fileContent.IsDeleteMarker))
+
+	// replace all instances of {etag}
+	str = strings.ReplaceAll(str, `{etag}`, fileContent.ETag)
+
+	// replace all instances of {"etag"}
+	str = strings.ReplaceAll(str, `{"etag"}`, strconv.Quote(fileContent.ETag))
+
 	return str
 }
 
@@ -433,6 +526,9 @@ func matchFind(ctx *findContext, fileContent contentMessage) (match bool) {
 	if match && len(ctx.matchTags) > 0 {
 		match = matchRegexMaps(ctx.matchTags, fileContent.Tags)
 	}
+	if match && ctx.nonCurrent {
+		match = !fileContent.IsLatest
+	}
 	return match
 }
 
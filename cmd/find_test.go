@@ -400,6 +400,12 @@ func TestStringReplace(t *testing.T) {
 				Time: time.Unix(2147483647, 0).UTC(),
 			},
 		},
+		// Tests string replace {latest} and {delete-marker}
+		{
+			str:         `{version} {latest} {delete-marker}`,
+			expectedStr: `v1 false true`,
+			content:     contentMessage{VersionID: "v1", IsDeleteMarker: true},
+		},
 	}
 	for i, testCase := range testCases {
 		gotStr := stringsReplace(context.Background(), testCase.str, testCase.content)
@@ -448,3 +454,37 @@ func TestGetExitStatus(t *testing.T) {
 		}
 	}
 }
+
+func TestFindCSVWriter(t *testing.T) {
+	if _, e := parseFindCSVFields("key,owner"); e == nil {
+		t.Fatal("expected an error for an unknown field")
+	}
+	fields, e := parseFindCSVFields("")
+	if e != nil {
+		t.Fatal(e)
+	}
+
+	var buf strings.Builder
+	w := newFindCSVWriter(&buf, fields)
+	modTime := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	if e = w.Write(contentMessage{Key: `s3/bucket/a, "quoted".txt`, Size: 42, Time: modTime, StorageClass: "STANDARD"}); e != nil {
+		t.Fatal(e)
+	}
+	if e = w.Flush(); e != nil {
+		t.Fatal(e)
+	}
+	expected := "key,size,modtime,storage-class\n" +
+		`"s3/bucket/a, ""quoted"".txt",42,2024-01-02T03:04:05Z,STANDARD` + "\n"
+	if buf.String() != expected {
+		t.Fatalf("expected %q, got %q", expected, buf.String())
+	}
+
+	buf.Reset()
+	w = newFindCSVWriter(&buf, []string{"key", "is-latest"})
+	if e = w.Flush(); e != nil {
+		t.Fatal(e)
+	}
+	if buf.String() != "key,is-latest\n" {
+		t.Fatalf("expected only the header, got %q", buf.String())
+	}
+}
@@ -84,6 +84,27 @@ var globalFlags = []cli.Flag{
 		Usage:  "limits downloads to a maximum rate in KiB/s, MiB/s, GiB/s. (default: unlimited)",
 		EnvVar: envPrefix + "LIMIT_DOWNLOAD",
 	},
+	cli.StringFlag{
+		Name:   "ip-family",
+		Usage:  "IP family of the connections: auto, 4, 6, prefer-4 or prefer-6",
+		Value:  ipFamilyAuto,
+		EnvVar: envPrefix + "IP_FAMILY",
+	},
+	cli.DurationFlag{
+		Name:   "happy-eyeballs-delay",
+		Usage:  "delay before falling back to the other IP family when connecting, a negative delay disables the fallback race",
+		EnvVar: envPrefix + "HAPPY_EYEBALLS_DELAY",
+	},
+	cli.IntFlag{
+		Name:   "max-idle-conns",
+		Usage:  "maximum number of idle connections kept open across all hosts (default: unlimited)",
+		EnvVar: envPrefix + "MAX_IDLE_CONNS",
+	},
+	cli.IntFlag{
+		Name:   "conns-per-host",
+		Usage:  "maximum number of connections per host, including active and idle ones (default: unlimited)",
+		EnvVar: envPrefix + "CONNS_PER_HOST",
+	},
 	cli.DurationFlag{
 		Name:   "conn-read-deadline",
 		Usage:  "custom connection READ deadline",
@@ -101,8 +122,10 @@ var globalFlags = []cli.Flag{
 // bundled encryption flags
 var encFlags = []cli.Flag{
 	encCFlag,
+	encCFileFlag,
 	encKSMFlag,
 	encS3Flag,
+	encContextFlag,
 }
 
 var encCFlag = cli.StringSliceFlag{
@@ -110,12 +133,29 @@ var encCFlag = cli.StringSliceFlag{
 	Usage: "encrypt/decrypt objects using client provided keys. (multiple keys can be provided) Formats: RawBase64 or Hex.",
 }
 
+// bundled client provided encryption key flags
+var encCFlags = []cli.Flag{
+	encCFlag,
+	encCFileFlag,
+}
+
+var encCFileFlag = cli.StringSliceFlag{
+	Name:  "enc-c-file",
+	Usage: "encrypt/decrypt objects using client provided keys read from files only readable by their owner, as alias/prefix=path. (multiple keys can be provided) Formats: Raw 32 bytes, RawBase64 or Hex.",
+}
+
 var encKSMFlag = cli.StringSliceFlag{
 	Name:   "enc-kms",
 	Usage:  "encrypt/decrypt objects using specific server-side encryption keys. (multiple keys can be provided)",
 	EnvVar: envPrefix + "ENC_KMS",
 }
 
+var encContextFlag = cli.StringSliceFlag{
+	Name:   "enc-context",
+	Usage:  "SSE-KMS encryption context as key=value, applied to all --enc-kms keys. (multiple pairs can be provided)",
+	EnvVar: envPrefix + "ENC_CONTEXT",
+}
+
 var encS3Flag = cli.StringSliceFlag{
 	Name:   "enc-s3",
 	Usage:  "encrypt/decrypt objects using server-side default keys and configurations. (multiple keys can be provided).",
@@ -0,0 +1,263 @@
+// Copyright (c) 2015-2024 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dustin/go-humanize"
+	json "github.com/minio/colorjson"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/pkg/v3/console"
+)
+
+// getListSummaryMessage is printed once all the keys of a --list
+// manifest were processed.
+type getListSummaryMessage struct {
+	Status     string   `json:"status"`
+	Total      int      `json:"total"`
+	Downloaded int      `json:"downloaded"`
+	Failed     int      `json:"failed"`
+	Retries    int      `json:"retries"`
+	Bytes      int64    `json:"bytes"`
+	FailedKeys []string `json:"failedKeys,omitempty"`
+}
+
+func (s getListSummaryMessage) JSON() string {
+	s.Status = "success"
+	if s.Failed > 0 {
+		s.Status = "error"
+	}
+	jsonMessageBytes, e := json.MarshalIndent(s, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+	return string(jsonMessageBytes)
+}
+
+func (s getListSummaryMessage) String() string {
+	msg := fmt.Sprintf("Downloaded %d of %d objects (%s), %d failed, %d retries.",
+		s.Downloaded, s.Total, humanize.IBytes(uint64(s.Bytes)), s.Failed, s.Retries)
+	for _, key := range s.FailedKeys {
+		msg += "\n  " + console.Colorize("Failed", key)
+	}
+	return msg
+}
+
+// readGetListKeys reads the keys of a --list manifest, one per line.
+// Empty lines and lines starting with '#' are skipped, duplicates are
+// only returned once.
+func readGetListKeys(r io.Reader) ([]string, error) {
+	var keys []string
+	seen := make(map[string]struct{})
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		key := strings.TrimSuffix(scanner.Text(), "\r")
+		if strings.TrimSpace(key) == "" || strings.HasPrefix(key, "#") {
+			continue
+		}
+		key = strings.TrimPrefix(key, "/")
+		if _, ok := seen[key]; ok {
+			continue
+		}
+		seen[key] = struct{}{}
+		keys = append(keys, key)
+	}
+	return keys, scanner.Err()
+}
+
+// getListTarget returns the local path key is downloaded to, keeping
+// its prefixes as directories under targetDir. Keys escaping targetDir
+// are rejected.
+func getListTarget(targetDir, key string) (string, error) {
+	target := filepath.Join(targetDir, filepath.FromSlash(key))
+	rel, e := filepath.Rel(targetDir, target)
+	if e != nil || rel == "." || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("key `%s` is not a valid object name", key)
+	}
+	return target, nil
+}
+
+// getListOpts are the options of mc get --list.
+type getListOpts struct {
+	sourceURL string
+	targetDir string
+	workers   int
+	retries   int
+	encKeyDB  map[string][]prefixSSEPair
+}
+
+// downloadGetListKeys runs download for every key with up to workers
+// downloads in parallel, retrying failed downloads up to retries times.
+func downloadGetListKeys(ctx context.Context, keys []string, workers, retries int, download func(key string) (int64, error)) getListSummaryMessage {
+	summary := getListSummaryMessage{Total: len(keys)}
+	keysCh := make(chan string)
+	var (
+		wg sync.WaitGroup
+		mu sync.Mutex
+	)
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for key := range keysCh {
+				var (
+					size int64
+					e    error
+				)
+				for attempt := 0; attempt <= retries; attempt++ {
+					if attempt > 0 {
+						mu.Lock()
+						summary.Retries++
+						mu.Unlock()
+						select {
+						case <-time.After(time.Duration(attempt) * time.Second):
+						case <-ctx.Done():
+						}
+					}
+					if ctx.Err() != nil {
+						e = ctx.Err()
+						break
+					}
+					if size, e = download(key); e == nil {
+						break
+					}
+				}
+				mu.Lock()
+				if e != nil {
+					summary.Failed++
+					summary.FailedKeys = append(summary.FailedKeys, key)
+				} else {
+					summary.Downloaded++
+					summary.Bytes += size
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+	for _, key := range keys {
+		select {
+		case keysCh <- key:
+		case <-ctx.Done():
+		}
+		if ctx.Err() != nil {
+			break
+		}
+	}
+	close(keysCh)
+	wg.Wait()
+
+	// Keys which were never handed to a worker are failures as well.
+	if handled := summary.Downloaded + summary.Failed; handled < summary.Total {
+		summary.Failed += summary.Total - handled
+		seen := make(map[string]struct{}, handled)
+		for _, key := range summary.FailedKeys {
+			seen[key] = struct{}{}
+		}
+		for _, key := range keys[handled:] {
+			if _, ok := seen[key]; !ok {
+				summary.FailedKeys = append(summary.FailedKeys, key)
+			}
+		}
+	}
+	return summary
+}
+
+// getList is the handler of mc get --list.
+func getList(ctx context.Context, manifest string, o getListOpts) error {
+	var r io.Reader = os.Stdin
+	if manifest != "-" {
+		f, e := os.Open(manifest)
+		fatalIf(probe.NewError(e).Trace(manifest), "Unable to open the manifest.")
+		defer f.Close()
+		r = f
+	}
+	keys, e := readGetListKeys(r)
+	fatalIf(probe.NewError(e).Trace(manifest), "Unable to read the manifest.")
+
+	// Store a progress bar or an accounter
+	var pg ProgressReader
+	if !globalQuiet && !globalJSON {
+		pg = newProgressBar(0)
+	} else {
+		pg = newAccounter(0)
+	}
+
+	// Reject keys escaping the target directory before downloading
+	// anything, there is no point in retrying them.
+	var valid, invalid []string
+	for _, key := range keys {
+		if _, e := getListTarget(o.targetDir, key); e != nil {
+			errorIf(probe.NewError(e).Trace(key), "Unable to download.")
+			invalid = append(invalid, key)
+			continue
+		}
+		valid = append(valid, key)
+	}
+
+	download := func(key string) (int64, error) {
+		target, _ := getListTarget(o.targetDir, key)
+		copyOpts := prepareCopyURLsOpts{
+			sourceURLs:              []string{urlJoinPath(o.sourceURL, key)},
+			targetURL:               target,
+			encKeyDB:                o.encKeyDB,
+			ignoreBucketExistsCheck: true,
+		}
+		for getURLs := range prepareGetURLs(ctx, copyOpts) {
+			if getURLs.Error != nil {
+				printGetURLsError(&getURLs)
+				return 0, getURLs.Error.ToGoError()
+			}
+			urls := doCopy(ctx, doCopyOpts{
+				cpURLs:              getURLs,
+				pg:                  pg,
+				encryptionKeys:      o.encKeyDB,
+				updateProgressTotal: true,
+			})
+			if urls.Error != nil {
+				if !globalQuiet && !globalJSON {
+					console.Eraseline()
+				}
+				errorIf(urls.Error.Trace(key), "Unable to download.")
+				return 0, urls.Error.ToGoError()
+			}
+		}
+		st, e := os.Stat(target)
+		if e != nil {
+			return 0, e
+		}
+		return st.Size(), nil
+	}
+
+	summary := downloadGetListKeys(ctx, valid, o.workers, o.retries, download)
+	summary.Total += len(invalid)
+	summary.Failed += len(invalid)
+	summary.FailedKeys = append(summary.FailedKeys, invalid...)
+	showLastProgressBar(pg, nil)
+	printMsg(summary)
+	if summary.Failed > 0 {
+		return exitStatus(globalErrorExitStatus)
+	}
+	return nil
+}
@@ -0,0 +1,80 @@
+// Copyright (c) 2015-2024 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestReadGetListKeys(t *testing.T) {
+	keys, e := readGetListKeys(strings.NewReader("a/1.txt\r\n# comment\n\n/b.txt\na/1.txt\nc d.txt\n"))
+	if e != nil {
+		t.Fatal(e)
+	}
+	want := []string{"a/1.txt", "b.txt", "c d.txt"}
+	if !reflect.DeepEqual(keys, want) {
+		t.Fatalf("expected %v, got %v", want, keys)
+	}
+}
+
+func TestGetListTarget(t *testing.T) {
+	dir := filepath.Join("backup", "2024")
+	if target, e := getListTarget(dir, "a/b/c.txt"); e != nil || target != filepath.Join(dir, "a", "b", "c.txt") {
+		t.Fatalf("unexpected target %s, %v", target, e)
+	}
+	for _, key := range []string{"../x", "a/../../x", "."} {
+		if _, e := getListTarget(dir, key); e == nil {
+			t.Fatalf("expected %s to be rejected", key)
+		}
+	}
+}
+
+func TestDownloadGetListKeys(t *testing.T) {
+	var mu sync.Mutex
+	attempts := make(map[string]int)
+	download := func(key string) (int64, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		attempts[key]++
+		switch {
+		case key == "flaky" && attempts[key] < 2:
+			return 0, errors.New("transient")
+		case key == "broken":
+			return 0, errors.New("permanent")
+		}
+		return 10, nil
+	}
+	summary := downloadGetListKeys(context.Background(), []string{"ok", "flaky", "broken"}, 2, 1, download)
+	if summary.Total != 3 || summary.Downloaded != 2 || summary.Failed != 1 || summary.Bytes != 20 {
+		t.Fatalf("unexpected summary %+v", summary)
+	}
+	if summary.Retries != 2 || attempts["broken"] != 2 {
+		t.Fatalf("expected one retry for flaky and broken, got %+v, %v", summary, attempts)
+	}
+	sort.Strings(summary.FailedKeys)
+	if !reflect.DeepEqual(summary.FailedKeys, []string{"broken"}) {
+		t.Fatalf("unexpected failed keys %v", summary.FailedKeys)
+	}
+}
@@ -32,6 +32,20 @@ var (
 			Name:  "version-id, vid",
 			Usage: "get a specific version of an object",
 		},
+		cli.StringFlag{
+			Name:  "list",
+			Usage: "download the keys listed in this file, one per line, relative to SOURCE ('-' reads from STDIN)",
+		},
+		cli.IntFlag{
+			Name:  "workers",
+			Usage: "number of objects downloaded in parallel with --list",
+			Value: 8,
+		},
+		cli.IntFlag{
+			Name:  "retry",
+			Usage: "number of times a failed download is retried with --list",
+			Value: 3,
+		},
 	}
 )
 
@@ -42,12 +56,13 @@ var getCmd = cli.Command{
 	Action:       mainGet,
 	OnUsageError: onUsageError,
 	Before:       setGlobalsFromContext,
-	Flags:        append(append(globalFlags, encCFlag), getFlags...),
+	Flags:        append(append(globalFlags, encCFlags...), getFlags...),
 	CustomHelpTemplate: `NAME:
   {{.HelpName}} - {{.Usage}}
 
 USAGE:
   {{.HelpName}} [FLAGS] SOURCE TARGET
+  {{.HelpName}} --list MANIFEST [FLAGS] SOURCE TARGET-DIR
 
 FLAGS:
   {{range .VisibleFlags}}{{.}}
@@ -59,6 +74,15 @@ EXAMPLES:
 
   2. Get an object from MinIO storage using encryption
      {{.Prompt}} {{.HelpName}} --enc-c "play/mybucket/object=MDEyMzQ1Njc4OTAxMjM0NTY3ODkwMTIzNDU2Nzg5MDA" play/mybucket/object path-to/object
+
+  3. Download the objects listed in 'keys.txt' from 'mybucket' into 'backup/', keeping their prefixes
+     {{.Prompt}} {{.HelpName}} --list keys.txt play/mybucket backup/
+
+  4. Download a curated subset with 32 parallel downloads and up to 5 retries per object
+     {{.Prompt}} cat keys.txt | {{.HelpName}} --list - --workers 32 --retry 5 play/mybucket/2024/ backup/2024/
+
+  5. Get an object encrypted with sse-c, reading the key from a file only readable by its owner.
+     {{.Prompt}} {{.HelpName}} --enc-c-file "play/mybucket/object=$HOME/.mc/keys/object.key" play/mybucket/object path-to/object
 `,
 }
 
@@ -78,6 +102,25 @@ func mainGet(cliCtx *cli.Context) (e error) {
 	}
 	fatalIf(err, "unable to parse encryption keys")
 
+	if manifest := cliCtx.String("list"); manifest != "" {
+		if cliCtx.IsSet("version-id") {
+			fatalIf(errInvalidArgument().Trace(), "--version-id cannot be used with --list.")
+		}
+		if cliCtx.Int("workers") < 1 || cliCtx.Int("retry") < 0 {
+			fatalIf(errInvalidArgument().Trace(), "--workers must be at least 1 and --retry cannot be negative.")
+		}
+		return getList(ctx, manifest, getListOpts{
+			sourceURL: args[0],
+			targetDir: args[1],
+			workers:   cliCtx.Int("workers"),
+			retries:   cliCtx.Int("retry"),
+			encKeyDB:  encryptionKeys,
+		})
+	}
+	if cliCtx.IsSet("workers") || cliCtx.IsSet("retry") {
+		fatalIf(errInvalidArgument().Trace(), "--workers and --retry can only be used with --list.")
+	}
+
 	// get source and target
 	sourceURLs := args[:len(args)-1]
 	targetURL := args[len(args)-1]
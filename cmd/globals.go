@@ -64,6 +64,10 @@ const (
 
 	// Global SIGTERM (#15) exit status
 	globalTerminatExitStatus = 143
+
+	// Exit status when a command stopped gracefully on a signal after
+	// completing all in-flight operations (EX_TEMPFAIL).
+	globalGracefulExitStatus = 75
 )
 
 var (
@@ -86,6 +90,12 @@ var (
 	globalConnReadDeadline  time.Duration
 	globalConnWriteDeadline time.Duration
 
+	globalIPFamily           = ipFamilyAuto
+	globalHappyEyeballsDelay time.Duration
+
+	globalMaxIdleConns int
+	globalConnsPerHost int
+
 	globalLimitUpload   uint64
 	globalLimitDownload uint64
 
@@ -150,6 +160,34 @@ func setGlobalsFromContext(ctx *cli.Context) error {
 		globalConnWriteDeadline = ctx.GlobalDuration("conn-write-deadline")
 	}
 
+	ipFamily := ctx.String("ip-family")
+	if !ctx.IsSet("ip-family") && ctx.GlobalIsSet("ip-family") {
+		ipFamily = ctx.GlobalString("ip-family")
+	}
+	var e error
+	if globalIPFamily, e = parseIPFamily(ipFamily); e != nil {
+		return e
+	}
+	globalHappyEyeballsDelay = ctx.Duration("happy-eyeballs-delay")
+	if globalHappyEyeballsDelay == 0 {
+		globalHappyEyeballsDelay = ctx.GlobalDuration("happy-eyeballs-delay")
+	}
+
+	maxIdleConns := ctx.Int("max-idle-conns")
+	if !ctx.IsSet("max-idle-conns") && ctx.GlobalIsSet("max-idle-conns") {
+		maxIdleConns = ctx.GlobalInt("max-idle-conns")
+	}
+	if globalMaxIdleConns, e = parseConnPoolSize("max-idle-conns", maxIdleConns); e != nil {
+		return e
+	}
+	connsPerHost := ctx.Int("conns-per-host")
+	if !ctx.IsSet("conns-per-host") && ctx.GlobalIsSet("conns-per-host") {
+		connsPerHost = ctx.GlobalInt("conns-per-host")
+	}
+	if globalConnsPerHost, e = parseConnPoolSize("conns-per-host", connsPerHost); e != nil {
+		return e
+	}
+
 	limitUploadStr := ctx.String("limit-upload")
 	if limitUploadStr == "" {
 		limitUploadStr = ctx.GlobalString("limit-upload")
@@ -59,6 +59,7 @@ var idpLdapAccesskeyCreateFlags = []cli.Flag{
 		Name:  "expiry",
 		Usage: "expiry date for the access key",
 	},
+	credentialsOutputFlag,
 	cli.BoolFlag{
 		Name:   "login",
 		Usage:  "log in using ldap credentials to generate access key pair for future use",
@@ -114,6 +115,8 @@ func commonAccesskeyCreate(ctx *cli.Context, ldap bool) error {
 		deprecatedError("mc idp ldap accesskey create-with-login")
 	}
 
+	checkCredentialsOutputSyntax(ctx)
+
 	opts := accessKeyCreateOpts(ctx, targetUser)
 	client, err := newAdminClient(aliasedURL)
 	fatalIf(err, "Unable to initialize admin connection.")
@@ -127,6 +130,10 @@ func commonAccesskeyCreate(ctx *cli.Context, ldap bool) error {
 	}
 	fatalIf(probe.NewError(e), "Unable to add service account.")
 
+	if printCredentialsOutput(ctx, aliasedURL, res.AccessKey, res.SecretKey, &res.Expiration) {
+		return nil
+	}
+
 	m := ldapAccesskeyMessage{
 		op:          "create",
 		Status:      "success",
@@ -0,0 +1,231 @@
+// Copyright (c) 2015-2024 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	json "github.com/minio/colorjson"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/pkg/v3/console"
+)
+
+var ilmRestoreStatusFlags = []cli.Flag{
+	cli.BoolFlag{
+		Name:  "recursive, r",
+		Usage: "show the restore status of all the objects under the prefix",
+	},
+	cli.BoolFlag{
+		Name:  "versions",
+		Usage: "include all the versions of the objects",
+	},
+	cli.StringFlag{
+		Name:  "version-id, vid",
+		Usage: "select a specific version id",
+	},
+}
+
+var ilmRestoreStatusCmd = cli.Command{
+	Name:         "status",
+	Usage:        "show the restore progress of transitioned objects",
+	Action:       mainILMRestoreStatus,
+	OnUsageError: onUsageError,
+	Before:       setGlobalsFromContext,
+	Flags:        append(append(ilmRestoreStatusFlags, encCFlags...), globalFlags...),
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} [FLAGS] TARGET
+
+DESCRIPTION:
+  Report how many transitioned objects are restored, being restored or not restored.
+  A HEAD request is sent for each object since listings do not report the restore status.
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+EXAMPLES:
+  1. Show the restore progress of one specific object
+     {{.Prompt}} {{.HelpName}} myminio/mybucket/path/to/object
+
+  2. Show the restore progress of all transitioned objects under a specific prefix
+     {{.Prompt}} {{.HelpName}} --recursive myminio/mybucket/dir/
+`,
+}
+
+// checkILMRestoreStatusSyntax - validate arguments passed by user
+func checkILMRestoreStatusSyntax(ctx *cli.Context) {
+	if len(ctx.Args()) != 1 {
+		showCommandHelpAndExit(ctx, globalErrorExitStatus)
+	}
+	if ctx.String("version-id") != "" && (ctx.Bool("recursive") || ctx.Bool("versions")) {
+		fatalIf(errDummy().Trace(), "You cannot combine --version-id with --recursive or --versions flags.")
+	}
+}
+
+// Restore states of an object.
+const (
+	restoreStateRestored        = "restored"
+	restoreStateOngoing         = "in-progress"
+	restoreStateNotRestored     = "not-restored"
+	restoreStateNotTransitioned = "not-transitioned"
+)
+
+// objectRestoreState returns the restore state of an object, objects
+// stored in a remote tier are reported with the tier as storage class.
+func objectRestoreState(content *ClientContent) string {
+	if content.Restore != nil {
+		if content.Restore.OngoingRestore {
+			return restoreStateOngoing
+		}
+		return restoreStateRestored
+	}
+	switch strings.ToUpper(content.StorageClass) {
+	case "", "STANDARD", "REDUCED_REDUNDANCY":
+		return restoreStateNotTransitioned
+	}
+	return restoreStateNotRestored
+}
+
+// ilmRestoreStatusMessage reports the restore progress of transitioned objects.
+type ilmRestoreStatusMessage struct {
+	Status          string `json:"status"`
+	Target          string `json:"target"`
+	Restored        int64  `json:"restored"`
+	Ongoing         int64  `json:"inProgress"`
+	NotRestored     int64  `json:"notRestored"`
+	NotTransitioned int64  `json:"notTransitioned"`
+	Failed          int64  `json:"failed,omitempty"`
+}
+
+func (m *ilmRestoreStatusMessage) add(state string) {
+	switch state {
+	case restoreStateRestored:
+		m.Restored++
+	case restoreStateOngoing:
+		m.Ongoing++
+	case restoreStateNotRestored:
+		m.NotRestored++
+	default:
+		m.NotTransitioned++
+	}
+}
+
+func (m ilmRestoreStatusMessage) String() string {
+	var s strings.Builder
+	s.WriteString(console.Colorize("RestoreStatusHeader", fmt.Sprintf("Restore status of `%s`:\n", m.Target)))
+	fmt.Fprintf(&s, "  %-18s %s\n", "Restored:", console.Colorize("RestoreStatusRestored", m.Restored))
+	fmt.Fprintf(&s, "  %-18s %s\n", "In progress:", console.Colorize("RestoreStatusOngoing", m.Ongoing))
+	fmt.Fprintf(&s, "  %-18s %s\n", "Not restored:", console.Colorize("RestoreStatusNotRestored", m.NotRestored))
+	fmt.Fprintf(&s, "  %-18s %d", "Not transitioned:", m.NotTransitioned)
+	if m.Failed > 0 {
+		fmt.Fprintf(&s, "\n  %-18s %s", "Failed:", console.Colorize("RestoreStatusNotRestored", m.Failed))
+	}
+	return s.String()
+}
+
+func (m ilmRestoreStatusMessage) JSON() string {
+	m.Status = "success"
+	b, e := json.MarshalIndent(m, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+	return string(b)
+}
+
+// statRestoreState returns the restore state of a single object version.
+func statRestoreState(ctx context.Context, targetAlias, targetURL, versionID string, encKeyDB map[string][]prefixSSEPair) (string, *probe.Error) {
+	clnt, err := newClientFromAlias(targetAlias, targetURL)
+	if err != nil {
+		return "", err
+	}
+	st, err := clnt.Stat(ctx, StatOptions{
+		versionID: versionID,
+		sse:       getSSE(targetAlias+clnt.GetURL().Path, encKeyDB[targetAlias]),
+	})
+	if err != nil {
+		return "", err
+	}
+	return objectRestoreState(st), nil
+}
+
+// mainILMRestoreStatus counts transitioned objects by restore state, a HEAD
+// request is sent for each object since listings do not report the restore status.
+func mainILMRestoreStatus(cliCtx *cli.Context) error {
+	ctx, cancelRestoreStatus := context.WithCancel(globalContext)
+	defer cancelRestoreStatus()
+
+	checkILMRestoreStatusSyntax(cliCtx)
+	aliasedURL := cliCtx.Args().Get(0)
+
+	console.SetColor("RestoreStatusHeader", color.New(color.Bold))
+	console.SetColor("RestoreStatusRestored", color.New(color.FgGreen, color.Bold))
+	console.SetColor("RestoreStatusOngoing", color.New(color.FgYellow, color.Bold))
+	console.SetColor("RestoreStatusNotRestored", color.New(color.FgRed, color.Bold))
+
+	encKeyDB, err := validateAndCreateEncryptionKeys(cliCtx)
+	fatalIf(err, "Unable to parse encryption keys.")
+
+	targetAlias, targetURL, _ := mustExpandAlias(aliasedURL)
+	if targetAlias == "" {
+		fatalIf(errDummy().Trace(), "Unable to check the restore status of the given URL")
+	}
+
+	msg := ilmRestoreStatusMessage{Target: aliasedURL}
+	if !cliCtx.Bool("recursive") {
+		state, err := statRestoreState(ctx, targetAlias, targetURL, cliCtx.String("version-id"), encKeyDB)
+		fatalIf(err.Trace(aliasedURL), "Unable to check the restore status.")
+		msg.add(state)
+		printMsg(msg)
+		return nil
+	}
+
+	client, err := newClientFromAlias(targetAlias, targetURL)
+	fatalIf(err.Trace(aliasedURL), "Unable to initialize target `%s`.", aliasedURL)
+
+	for content := range client.List(ctx, ListOptions{
+		Recursive:         true,
+		WithOlderVersions: cliCtx.Bool("versions"),
+		ShowDir:           DirNone,
+	}) {
+		if content.Err != nil {
+			errorIf(content.Err.Trace(client.GetURL().String()), "Unable to list folder.")
+			continue
+		}
+		if content.IsDeleteMarker {
+			continue
+		}
+		state, err := statRestoreState(ctx, targetAlias, content.URL.String(), content.VersionID, encKeyDB)
+		if err != nil {
+			errorIf(err.Trace(content.URL.String()), "Unable to check the restore status.")
+			msg.Failed++
+			continue
+		}
+		msg.add(state)
+		printStatus("Checked %d object(s), %d restored, %d in progress", msg.Restored+msg.Ongoing+msg.NotRestored+msg.NotTransitioned, msg.Restored, msg.Ongoing)
+	}
+	if !globalJSON {
+		fmt.Print("\n\033[1A\033[K")
+	}
+
+	printMsg(msg)
+	return nil
+}
@@ -0,0 +1,104 @@
+// Copyright (c) 2015-2024 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/minio/cli"
+	"github.com/minio/minio-go/v7"
+)
+
+func TestObjectRestoreState(t *testing.T) {
+	testCases := []struct {
+		content *ClientContent
+		state   string
+	}{
+		{&ClientContent{}, restoreStateNotTransitioned},
+		{&ClientContent{StorageClass: "STANDARD"}, restoreStateNotTransitioned},
+		{&ClientContent{StorageClass: "reduced_redundancy"}, restoreStateNotTransitioned},
+		{&ClientContent{StorageClass: "WARM-TIER"}, restoreStateNotRestored},
+		{&ClientContent{StorageClass: "WARM-TIER", Restore: &minio.RestoreInfo{OngoingRestore: true}}, restoreStateOngoing},
+		{&ClientContent{StorageClass: "WARM-TIER", Restore: &minio.RestoreInfo{}}, restoreStateRestored},
+	}
+	for i, testCase := range testCases {
+		if state := objectRestoreState(testCase.content); state != testCase.state {
+			t.Fatalf("Test %d: expected %s, got %s", i+1, testCase.state, state)
+		}
+	}
+}
+
+func TestILMRestoreStatusMessage(t *testing.T) {
+	var msg ilmRestoreStatusMessage
+	for _, state := range []string{restoreStateRestored, restoreStateOngoing, restoreStateOngoing, restoreStateNotRestored, restoreStateNotTransitioned} {
+		msg.add(state)
+	}
+	want := ilmRestoreStatusMessage{Restored: 1, Ongoing: 2, NotRestored: 1, NotTransitioned: 1}
+	if !reflect.DeepEqual(msg, want) {
+		t.Fatalf("expected %+v, got %+v", want, msg)
+	}
+}
+
+func TestILMRestoreStatusSubcommand(t *testing.T) {
+	var gotCmd string
+	var gotArgs []string
+	var gotRecursive bool
+	var gotDays int
+	statusCmd := ilmRestoreStatusCmd
+	statusCmd.Before = nil
+	statusCmd.Action = func(ctx *cli.Context) error {
+		gotCmd, gotArgs, gotRecursive = "status", ctx.Args(), ctx.Bool("recursive")
+		return nil
+	}
+	restoreCmd := ilmRestoreCmd
+	restoreCmd.Before = nil
+	restoreCmd.Subcommands = []cli.Command{statusCmd}
+	restoreCmd.Action = func(ctx *cli.Context) error {
+		gotCmd, gotArgs, gotDays = "restore", ctx.Args(), ctx.Int("days")
+		return nil
+	}
+
+	testCases := []struct {
+		args      []string
+		cmd       string
+		target    string
+		recursive bool
+		days      int
+	}{
+		// Flags of the status subcommand are parsed after its target.
+		{[]string{"status", "myminio/status", "--recursive"}, "status", "myminio/status", true, 0},
+		{[]string{"status", "--recursive", "myminio/bucket"}, "status", "myminio/bucket", true, 0},
+		// A target which is not a subcommand is restored.
+		{[]string{"--days", "3", "myminio/bucket/object"}, "restore", "myminio/bucket/object", false, 3},
+		{[]string{"myminio/bucket/object"}, "restore", "myminio/bucket/object", false, 1},
+	}
+	for i, testCase := range testCases {
+		gotCmd, gotArgs, gotRecursive, gotDays = "", nil, false, 0
+		app := cli.NewApp()
+		app.Commands = []cli.Command{{Name: "ilm", Subcommands: []cli.Command{restoreCmd}}}
+		if e := app.Run(append([]string{"mc", "ilm", "restore"}, testCase.args...)); e != nil {
+			t.Fatalf("Test %d: %v", i+1, e)
+		}
+		if gotCmd != testCase.cmd || !reflect.DeepEqual(gotArgs, []string{testCase.target}) ||
+			gotRecursive != testCase.recursive || gotDays != testCase.days {
+			t.Errorf("Test %d: expected %s of %s, got %s of %v, recursive %v, days %d",
+				i+1, testCase.cmd, testCase.target, gotCmd, gotArgs, gotRecursive, gotDays)
+		}
+	}
+}
@@ -57,17 +57,23 @@ var ilmRestoreCmd = cli.Command{
 	Action:       mainILMRestore,
 	OnUsageError: onUsageError,
 	Before:       setGlobalsFromContext,
-	Flags:        append(append(ilmRestoreFlags, encCFlag), globalFlags...),
+	Flags:        append(append(ilmRestoreFlags, encCFlags...), globalFlags...),
+	// Action runs when the first argument is not a subcommand.
+	Subcommands:     []cli.Command{ilmRestoreStatusCmd},
+	HideHelpCommand: true,
 	CustomHelpTemplate: `NAME:
   {{.HelpName}} - {{.Usage}}
 
 USAGE:
-  {{.HelpName}} TARGET
+  {{.HelpName}} [FLAGS] TARGET
+  {{.HelpName}} status [FLAGS] TARGET
 
 DESCRIPTION:
   Restore a copy of one or more objects from its remote tier. This copy automatically expires
   after the specified number of days (Default 1 day).
 
+  Run '{{.HelpName}} status --help' to show the restore progress of transitioned objects.
+
 FLAGS:
   {{range .VisibleFlags}}{{.}}
   {{end}}
@@ -297,14 +303,14 @@ func showRestoreStatus(restoreReqStatus, restoreFinishedStatus chan *probe.Error
 	close(doneCh)
 }
 
+// mainILMRestore is the handle for "mc ilm restore TARGET" command.
 func mainILMRestore(cliCtx *cli.Context) (cErr error) {
 	ctx, cancelILMRestore := context.WithCancel(globalContext)
 	defer cancelILMRestore()
 
 	checkILMRestoreSyntax(cliCtx)
 
-	args := cliCtx.Args()
-	aliasedURL := args.Get(0)
+	aliasedURL := cliCtx.Args().Get(0)
 
 	versionID := cliCtx.String("version-id")
 	recursive := cliCtx.Bool("recursive")
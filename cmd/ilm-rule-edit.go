@@ -59,6 +59,9 @@ EXAMPLES:
   3. Disable the rule with id "rHTY.a123".
      {{.Prompt}} {{.HelpName}} --id "rHTY.a123" --disable s3/mybucket
 
+  4. Restrict the rule with id "rHTY.a123" to objects smaller than 1MiB, and remove its size-gt filter.
+     {{.Prompt}} {{.HelpName}} --id "rHTY.a123" --size-lt 1MiB --size-gt 0 s3/mybucket
+
 `,
 }
 
@@ -26,6 +26,7 @@ var ilmRuleSubcommands = []cli.Command{
 	ilmRmCmd,
 	ilmExportCmd,
 	ilmImportCmd,
+	ilmSimulateCmd,
 }
 
 var ilmRuleCmd = cli.Command{
@@ -0,0 +1,199 @@
+// Copyright (c) 2015-2024 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/dustin/go-humanize"
+	"github.com/minio/cli"
+	json "github.com/minio/colorjson"
+	"github.com/minio/mc/cmd/ilm"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/pkg/v3/console"
+)
+
+var ilmSimulateFlags = []cli.Flag{
+	cli.StringFlag{
+		Name:  "at",
+		Usage: "evaluate the rules at this date, or this long from now, e.g. 2025.01.01 or 30d",
+	},
+}
+
+var ilmSimulateCmd = cli.Command{
+	Name:         "simulate",
+	Usage:        "report what lifecycle rules would do to the current bucket contents",
+	Action:       mainILMSimulate,
+	OnUsageError: onUsageError,
+	Before:       setGlobalsFromContext,
+	Flags:        append(ilmSimulateFlags, globalFlags...),
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} [FLAGS] TARGET
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+DESCRIPTION:
+  Evaluates the lifecycle configuration of a bucket against a listing of its
+  objects and reports, for each rule, how many objects and bytes it matches,
+  and how many would be expired or transitioned. Nothing is modified.
+
+  Rules are evaluated independently, objects matched by overlapping rules are
+  counted by each of them.
+
+EXAMPLES:
+  1. Simulate the lifecycle rules of 'mybucket' on alias 'myminio'.
+     {{.Prompt}} {{.HelpName}} myminio/mybucket
+
+  2. Simulate the lifecycle rules of 'mybucket' as they will apply in 90 days.
+     {{.Prompt}} {{.HelpName}} --at 90d myminio/mybucket
+`,
+}
+
+type ilmSimulateMessage struct {
+	Status string             `json:"status"`
+	Target string             `json:"target"`
+	At     time.Time          `json:"at"`
+	Rule   ilm.RuleSimulation `json:"rule"`
+}
+
+func (i ilmSimulateMessage) String() string {
+	r := i.Rule
+	if r.Status != "Enabled" {
+		return console.Colorize(ilmThemeRow, fmt.Sprintf("%s: rule is %s, skipped", r.ID, r.Status))
+	}
+	msg := fmt.Sprintf("%s: matches %d object(s) (%s), expires %d (%s), transitions %d (%s)",
+		r.ID, r.Matched, humanize.IBytes(uint64(r.MatchedBytes)),
+		r.Expire, humanize.IBytes(uint64(r.ExpireBytes)),
+		r.Transition, humanize.IBytes(uint64(r.TransitionBytes)))
+	if r.Matched == 0 {
+		return console.Colorize(ilmThemeResultFailure, msg+", check the rule filter")
+	}
+	return console.Colorize(ilmThemeResultSuccess, msg)
+}
+
+func (i ilmSimulateMessage) JSON() string {
+	msgBytes, e := json.MarshalIndent(i, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+	return string(msgBytes)
+}
+
+// parseILMSimulateAt parses --at, a date in the local time zone or a
+// duration from now.
+func parseILMSimulateAt(at string, now time.Time) (time.Time, error) {
+	for _, format := range rewindSupportedFormat {
+		if t, e := time.ParseInLocation(format, at, now.Location()); e == nil {
+			return t, nil
+		}
+	}
+	d, e := ParseDuration(at)
+	if e != nil || d < 0 {
+		return time.Time{}, fmt.Errorf("`%s` is neither a date nor a positive duration", at)
+	}
+	return now.Add(time.Duration(d)), nil
+}
+
+// checkILMSimulateSyntax - validate arguments passed by a user
+func checkILMSimulateSyntax(ctx *cli.Context) {
+	if len(ctx.Args()) != 1 {
+		showCommandHelpAndExit(ctx, globalErrorExitStatus)
+	}
+}
+
+func mainILMSimulate(cliCtx *cli.Context) error {
+	ctx, cancelILMSimulate := context.WithCancel(globalContext)
+	defer cancelILMSimulate()
+
+	checkILMSimulateSyntax(cliCtx)
+	setILMDisplayColorScheme()
+
+	urlStr := cliCtx.Args().Get(0)
+	at := time.Now()
+	if cliCtx.IsSet("at") {
+		var e error
+		at, e = parseILMSimulateAt(cliCtx.String("at"), at)
+		fatalIf(probe.NewError(e), "Unable to parse --at.")
+	}
+
+	client, err := newClient(urlStr)
+	fatalIf(err.Trace(urlStr), "Unable to initialize client for "+urlStr)
+
+	ilmCfg, _, err := client.GetLifecycle(ctx)
+	fatalIf(err.Trace(urlStr), "Unable to get lifecycle")
+	if len(ilmCfg.Rules) == 0 {
+		fatalIf(probe.NewError(errors.New("lifecycle configuration not set")).Trace(urlStr),
+			"Unable to simulate lifecycle configuration")
+	}
+
+	// Noncurrent versions and delete markers only exist in versioned buckets.
+	var versioned bool
+	if vcfg, err := client.GetVersion(ctx); err == nil {
+		versioned = vcfg.Status == "Enabled" || vcfg.Status == "Suspended"
+	}
+
+	sim := ilm.NewSimulation(ilmCfg, at)
+	var (
+		name     string
+		versions []ilm.ObjectVersion
+	)
+	flush := func() {
+		if len(versions) > 0 {
+			sim.AddObject(name, versions)
+		}
+		versions = versions[:0]
+	}
+	for content := range client.List(ctx, ListOptions{
+		Recursive:         true,
+		WithOlderVersions: versioned,
+		WithDeleteMarkers: versioned,
+		WithMetadata:      true,
+		ShowDir:           DirNone,
+	}) {
+		if content.Err != nil {
+			fatalIf(content.Err.Trace(urlStr), "Unable to list objects.")
+		}
+		_, object := url2BucketAndObject(&content.URL)
+		if object != name {
+			flush()
+			name = object
+		}
+		versions = append(versions, ilm.ObjectVersion{
+			Size:           content.Size,
+			ModTime:        content.Time,
+			IsDeleteMarker: content.IsDeleteMarker,
+			Tags:           content.Tags,
+		})
+	}
+	flush()
+
+	for _, rule := range sim.Rules {
+		printMsg(ilmSimulateMessage{
+			Status: "success",
+			Target: urlStr,
+			At:     at,
+			Rule:   rule,
+		})
+	}
+	return nil
+}
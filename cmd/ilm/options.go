@@ -291,6 +291,9 @@ func GetLifecycleOptions(ctx *cli.Context) (LifecycleOptions, *probe.Error) {
 		}
 		sizeGt = int64Ptr(int64(szGt))
 	}
+	if sizeLt != nil && sizeGt != nil && *sizeLt > 0 && *sizeGt >= *sizeLt {
+		return LifecycleOptions{}, probe.NewError(errors.New("size-gt value must be less than size-lt value"))
+	}
 
 	// For backward-compatibility
 	if ctx.IsSet("storage-class") {
@@ -422,6 +425,37 @@ func ApplyRuleFields(dest *lifecycle.Rule, opts LifecycleOptions) *probe.Error {
 		}
 	}
 
+	// The object size filters may move the other predicates of the filter
+	// in or out of its And element, the filter is rebuilt with the sizes
+	// overridden by opts. A zero size removes the corresponding filter.
+	if opts.ObjectSizeLessThan != nil || opts.ObjectSizeGreaterThan != nil {
+		szLt, szGt := getObjectSizeLessThan(*dest), getObjectSizeGreaterThan(*dest)
+		if opts.ObjectSizeLessThan != nil {
+			szLt = *opts.ObjectSizeLessThan
+		}
+		if opts.ObjectSizeGreaterThan != nil {
+			szGt = *opts.ObjectSizeGreaterThan
+		}
+		if szLt > 0 && szGt >= szLt {
+			return probe.NewError(errors.New("size-gt value must be less than size-lt value"))
+		}
+		var merged LifecycleOptions
+		if prefix := getPrefix(*dest); prefix != "" {
+			merged.Prefix = &prefix
+		}
+		if tags := getTags(*dest); tags != "" {
+			merged.Tags = &tags
+		}
+		if szLt > 0 {
+			merged.ObjectSizeLessThan = &szLt
+		}
+		if szGt > 0 {
+			merged.ObjectSizeGreaterThan = &szGt
+		}
+		dest.Prefix = ""
+		dest.RuleFilter = merged.Filter()
+	}
+
 	// only one of expiration day, date or transition day, date is expected
 	if opts.ExpiryDate != nil {
 		date, err := parseExpiryDate(*opts.ExpiryDate)
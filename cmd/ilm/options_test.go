@@ -153,3 +153,33 @@ func TestOptionFilter(t *testing.T) {
 		})
 	}
 }
+
+func TestApplyRuleFieldsObjectSize(t *testing.T) {
+	rule := lifecycle.Rule{
+		ID:         "rule",
+		RuleFilter: lifecycle.Filter{Prefix: "doc/"},
+	}
+
+	// Adding a size filter moves the prefix in the And element.
+	if err := ApplyRuleFields(&rule, LifecycleOptions{ObjectSizeLessThan: int64Ptr(humanize.MiByte)}); err != nil {
+		t.Fatal(err)
+	}
+	if rule.RuleFilter.Prefix != "" || rule.RuleFilter.And.Prefix != "doc/" || rule.RuleFilter.And.ObjectSizeLessThan != humanize.MiByte {
+		t.Fatalf("unexpected filter %+v", rule.RuleFilter)
+	}
+
+	// Removing it moves the prefix back.
+	if err := ApplyRuleFields(&rule, LifecycleOptions{ObjectSizeLessThan: int64Ptr(0)}); err != nil {
+		t.Fatal(err)
+	}
+	if rule.RuleFilter.Prefix != "doc/" || !rule.RuleFilter.And.IsEmpty() {
+		t.Fatalf("unexpected filter %+v", rule.RuleFilter)
+	}
+
+	if err := ApplyRuleFields(&rule, LifecycleOptions{
+		ObjectSizeLessThan:    int64Ptr(humanize.KiByte),
+		ObjectSizeGreaterThan: int64Ptr(humanize.MiByte),
+	}); err == nil {
+		t.Fatal("expected an error for size-gt greater than size-lt")
+	}
+}
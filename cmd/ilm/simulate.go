@@ -0,0 +1,176 @@
+// Copyright (c) 2015-2024 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package ilm
+
+import (
+	"strings"
+	"time"
+
+	"github.com/minio/minio-go/v7/pkg/lifecycle"
+)
+
+// ObjectVersion is a version of an object evaluated by a Simulation.
+type ObjectVersion struct {
+	Size           int64
+	ModTime        time.Time
+	IsDeleteMarker bool
+	Tags           map[string]string
+}
+
+// RuleSimulation is what a lifecycle rule would do to the objects
+// evaluated by a Simulation.
+type RuleSimulation struct {
+	ID              string `json:"id"`
+	Status          string `json:"status"`
+	Matched         int64  `json:"matched"`
+	MatchedBytes    int64  `json:"matchedBytes"`
+	Expire          int64  `json:"expire"`
+	ExpireBytes     int64  `json:"expireBytes"`
+	Transition      int64  `json:"transition"`
+	TransitionBytes int64  `json:"transitionBytes"`
+}
+
+// Simulation evaluates lifecycle rules against object versions as the
+// server scanner would at a given time. Rules are evaluated
+// independently, objects matched by overlapping rules are counted by
+// each of them. ExpiredObjectAllVersions and AllVersionsExpiration are
+// not simulated.
+type Simulation struct {
+	rules []lifecycle.Rule
+	now   time.Time
+	Rules []RuleSimulation
+}
+
+// NewSimulation returns a simulation of the rules of cfg at now.
+func NewSimulation(cfg *lifecycle.Configuration, now time.Time) *Simulation {
+	s := &Simulation{rules: cfg.Rules, now: now}
+	for _, rule := range cfg.Rules {
+		s.Rules = append(s.Rules, RuleSimulation{ID: rule.ID, Status: rule.Status})
+	}
+	return s
+}
+
+// expectedExpiryTime returns the time at which an action configured
+// days after modTime is due, at the first midnight UTC following it.
+func expectedExpiryTime(modTime time.Time, days int) time.Time {
+	if days == 0 {
+		return modTime
+	}
+	return modTime.UTC().Add(time.Duration(days+1) * 24 * time.Hour).Truncate(24 * time.Hour)
+}
+
+// matches returns true when the rule filter selects the object.
+func matches(rule lifecycle.Rule, name string, v ObjectVersion) bool {
+	if !strings.HasPrefix(name, getPrefix(rule)) {
+		return false
+	}
+	if gt := getObjectSizeGreaterThan(rule); gt > 0 && v.Size <= gt {
+		return false
+	}
+	if lt := getObjectSizeLessThan(rule); lt > 0 && v.Size >= lt {
+		return false
+	}
+	tags := append([]lifecycle.Tag{}, rule.RuleFilter.And.Tags...)
+	if !rule.RuleFilter.Tag.IsEmpty() {
+		tags = append(tags, rule.RuleFilter.Tag)
+	}
+	for _, tag := range tags {
+		if value, ok := v.Tags[tag.Key]; !ok || value != tag.Value {
+			return false
+		}
+	}
+	return true
+}
+
+// AddObject evaluates all versions of an object, versions are sorted
+// from the newest to the oldest, the first one being the current
+// version. A non versioned object has a single version.
+func (s *Simulation) AddObject(name string, versions []ObjectVersion) {
+	for i, rule := range s.rules {
+		if rule.Status != "Enabled" {
+			continue
+		}
+		stats := &s.Rules[i]
+		// Number of noncurrent versions seen so far, newest first.
+		var noncurrent int
+		for j, v := range versions {
+			if !matches(rule, name, v) {
+				continue
+			}
+			stats.Matched++
+			stats.MatchedBytes += v.Size
+
+			var expire, transition bool
+			if j == 0 {
+				expire, transition = s.evalCurrent(rule, v, len(versions))
+			} else {
+				// A version becomes noncurrent when its successor is created.
+				expire, transition = s.evalNoncurrent(rule, versions[j-1].ModTime, noncurrent)
+				noncurrent++
+			}
+			switch {
+			case expire:
+				stats.Expire++
+				stats.ExpireBytes += v.Size
+			case transition && !v.IsDeleteMarker:
+				stats.Transition++
+				stats.TransitionBytes += v.Size
+			}
+		}
+	}
+}
+
+func (s *Simulation) evalCurrent(rule lifecycle.Rule, v ObjectVersion, numVersions int) (expire, transition bool) {
+	if v.IsDeleteMarker {
+		if rule.Expiration.DeleteMarker.IsEnabled() && numVersions == 1 {
+			return true, false
+		}
+		if days := rule.DelMarkerExpiration.Days; days > 0 {
+			return !s.now.Before(expectedExpiryTime(v.ModTime, days)), false
+		}
+		return false, false
+	}
+	if days := int(rule.Expiration.Days); days > 0 && !s.now.Before(expectedExpiryTime(v.ModTime, days)) {
+		return true, false
+	}
+	if date := rule.Expiration.Date; !date.IsZero() && !s.now.Before(date.Time) {
+		return true, false
+	}
+	if rule.Transition.StorageClass != "" {
+		if date := rule.Transition.Date; !date.IsZero() {
+			return false, !s.now.Before(date.Time)
+		}
+		return false, !s.now.Before(expectedExpiryTime(v.ModTime, int(rule.Transition.Days)))
+	}
+	return false, false
+}
+
+func (s *Simulation) evalNoncurrent(rule lifecycle.Rule, successorModTime time.Time, newerNoncurrent int) (expire, transition bool) {
+	nve := rule.NoncurrentVersionExpiration
+	if nve.NoncurrentDays > 0 || nve.NewerNoncurrentVersions > 0 {
+		retained := nve.NewerNoncurrentVersions > 0 && newerNoncurrent < nve.NewerNoncurrentVersions
+		due := !s.now.Before(expectedExpiryTime(successorModTime, int(nve.NoncurrentDays)))
+		if !retained && due {
+			return true, false
+		}
+	}
+	if nvt := rule.NoncurrentVersionTransition; nvt.StorageClass != "" {
+		return false, !s.now.Before(expectedExpiryTime(successorModTime, int(nvt.NoncurrentDays)))
+	}
+	return false, false
+}
@@ -0,0 +1,106 @@
+// Copyright (c) 2015-2024 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package ilm
+
+import (
+	"testing"
+	"time"
+
+	"github.com/minio/minio-go/v7/pkg/lifecycle"
+)
+
+func TestSimulation(t *testing.T) {
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	daysAgo := func(days int) time.Time { return now.Add(-time.Duration(days) * 24 * time.Hour) }
+	cfg := &lifecycle.Configuration{Rules: []lifecycle.Rule{
+		{
+			ID:         "expire-logs",
+			Status:     "Enabled",
+			RuleFilter: lifecycle.Filter{Prefix: "logs/"},
+			Expiration: lifecycle.Expiration{Days: 30},
+		},
+		{
+			ID:     "tier-large",
+			Status: "Enabled",
+			RuleFilter: lifecycle.Filter{And: lifecycle.And{
+				Prefix:                "data/",
+				ObjectSizeGreaterThan: 100,
+				Tags:                  []lifecycle.Tag{{Key: "tier", Value: "cold"}},
+			}},
+			Transition: lifecycle.Transition{Days: 7, StorageClass: "WARM"},
+		},
+		{
+			ID:                          "noncurrent",
+			Status:                      "Enabled",
+			NoncurrentVersionExpiration: lifecycle.NoncurrentVersionExpiration{NoncurrentDays: 1, NewerNoncurrentVersions: 1},
+			Expiration:                  lifecycle.Expiration{DeleteMarker: true},
+		},
+		{
+			ID:         "disabled",
+			Status:     "Disabled",
+			Expiration: lifecycle.Expiration{Days: 1},
+		},
+	}}
+
+	sim := NewSimulation(cfg, now)
+	sim.AddObject("logs/old", []ObjectVersion{{Size: 10, ModTime: daysAgo(40)}})
+	sim.AddObject("logs/new", []ObjectVersion{{Size: 20, ModTime: daysAgo(10)}})
+	sim.AddObject("data/big", []ObjectVersion{{Size: 1000, ModTime: daysAgo(10), Tags: map[string]string{"tier": "cold"}}})
+	sim.AddObject("data/untagged", []ObjectVersion{{Size: 1000, ModTime: daysAgo(10)}})
+	sim.AddObject("data/small", []ObjectVersion{{Size: 50, ModTime: daysAgo(10), Tags: map[string]string{"tier": "cold"}}})
+	sim.AddObject("versioned", []ObjectVersion{
+		{Size: 1, ModTime: daysAgo(1)},
+		{Size: 2, ModTime: daysAgo(5)}, // kept by NewerNoncurrentVersions
+		{Size: 3, ModTime: daysAgo(10)},
+		{Size: 4, ModTime: daysAgo(20)},
+	})
+	sim.AddObject("deleted", []ObjectVersion{{ModTime: daysAgo(1), IsDeleteMarker: true}})
+
+	expected := []RuleSimulation{
+		{ID: "expire-logs", Status: "Enabled", Matched: 2, MatchedBytes: 30, Expire: 1, ExpireBytes: 10},
+		{ID: "tier-large", Status: "Enabled", Matched: 1, MatchedBytes: 1000, Transition: 1, TransitionBytes: 1000},
+		{ID: "noncurrent", Status: "Enabled", Matched: 10, MatchedBytes: 2090, Expire: 3, ExpireBytes: 7},
+		{ID: "disabled", Status: "Disabled"},
+	}
+	for i, want := range expected {
+		if got := sim.Rules[i]; got != want {
+			t.Errorf("rule %s: expected %+v, got %+v", want.ID, want, got)
+		}
+	}
+}
+
+func TestSimulationExpiryRounding(t *testing.T) {
+	cfg := &lifecycle.Configuration{Rules: []lifecycle.Rule{
+		{ID: "one-day", Status: "Enabled", Expiration: lifecycle.Expiration{Days: 1}},
+	}}
+	modTime := time.Date(2024, 6, 1, 23, 0, 0, 0, time.UTC)
+	// Due at the first midnight UTC after modTime + 1 day.
+	for _, tc := range []struct {
+		now    time.Time
+		expire int64
+	}{
+		{time.Date(2024, 6, 2, 23, 30, 0, 0, time.UTC), 0},
+		{time.Date(2024, 6, 3, 0, 0, 0, 0, time.UTC), 1},
+	} {
+		sim := NewSimulation(cfg, tc.now)
+		sim.AddObject("a", []ObjectVersion{{Size: 1, ModTime: modTime}})
+		if sim.Rules[0].Expire != tc.expire {
+			t.Errorf("at %s: expected %d expired, got %d", tc.now, tc.expire, sim.Rules[0].Expire)
+		}
+	}
+}
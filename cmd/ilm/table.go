@@ -72,6 +72,7 @@ type expirationCurrentRow struct {
 	Status          string
 	Prefix          string
 	Tags            string
+	Size            string
 	Days            int
 	ExpireDelMarker bool
 }
@@ -94,13 +95,16 @@ func (e expirationCurrentTable) Rows() (rows []table.Row) {
 		if row.Tags == "" {
 			row.Tags = "-"
 		}
-		rows = append(rows, table.Row{row.ID, row.Status, row.Prefix, row.Tags, row.Days, row.ExpireDelMarker})
+		if row.Size == "" {
+			row.Size = "-"
+		}
+		rows = append(rows, table.Row{row.ID, row.Status, row.Prefix, row.Tags, row.Size, row.Days, row.ExpireDelMarker})
 	}
 	return rows
 }
 
 func (e expirationCurrentTable) ColumnHeaders() (headers table.Row) {
-	return table.Row{"ID", "Status", "Prefix", "Tags", "Days to Expire", "Expire DeleteMarker"}
+	return table.Row{"ID", "Status", "Prefix", "Tags", "Size", "Days to Expire", "Expire DeleteMarker"}
 }
 
 type expirationNoncurrentTable []expirationNoncurrentRow
@@ -110,6 +114,7 @@ type expirationNoncurrentRow struct {
 	Status       string
 	Prefix       string
 	Tags         string
+	Size         string
 	Days         int
 	KeepVersions int
 }
@@ -130,13 +135,16 @@ func (e expirationNoncurrentTable) Rows() (rows []table.Row) {
 		if row.Tags == "" {
 			row.Tags = "-"
 		}
-		rows = append(rows, table.Row{row.ID, row.Status, row.Prefix, row.Tags, row.Days, row.KeepVersions})
+		if row.Size == "" {
+			row.Size = "-"
+		}
+		rows = append(rows, table.Row{row.ID, row.Status, row.Prefix, row.Tags, row.Size, row.Days, row.KeepVersions})
 	}
 	return rows
 }
 
 func (e expirationNoncurrentTable) ColumnHeaders() (headers table.Row) {
-	return table.Row{"ID", "Status", "Prefix", "Tags", "Days to Expire", "Keep Versions"}
+	return table.Row{"ID", "Status", "Prefix", "Tags", "Size", "Days to Expire", "Keep Versions"}
 }
 
 type tierCurrentTable []tierCurrentRow
@@ -146,6 +154,7 @@ type tierCurrentRow struct {
 	Status string
 	Prefix string
 	Tags   string
+	Size   string
 	Days   int
 	Tier   string
 }
@@ -159,7 +168,7 @@ func (t tierCurrentTable) Title() string {
 }
 
 func (t tierCurrentTable) ColumnHeaders() (headers table.Row) {
-	return table.Row{"ID", "Status", "Prefix", "Tags", "Days to Tier", "Tier"}
+	return table.Row{"ID", "Status", "Prefix", "Tags", "Size", "Days to Tier", "Tier"}
 }
 
 func (t tierCurrentTable) Rows() (rows []table.Row) {
@@ -170,7 +179,10 @@ func (t tierCurrentTable) Rows() (rows []table.Row) {
 		if row.Tags == "" {
 			row.Tags = "-"
 		}
-		rows = append(rows, table.Row{row.ID, row.Status, row.Prefix, row.Tags, row.Days, row.Tier})
+		if row.Size == "" {
+			row.Size = "-"
+		}
+		rows = append(rows, table.Row{row.ID, row.Status, row.Prefix, row.Tags, row.Size, row.Days, row.Tier})
 	}
 	return rows
 }
@@ -189,7 +201,7 @@ func (t tierNoncurrentTable) Title() string {
 }
 
 func (t tierNoncurrentTable) ColumnHeaders() table.Row {
-	return table.Row{"ID", "Status", "Prefix", "Tags", "Days to Tier", "Tier"}
+	return table.Row{"ID", "Status", "Prefix", "Tags", "Size", "Days to Tier", "Tier"}
 }
 
 func (t tierNoncurrentTable) Rows() (rows []table.Row) {
@@ -200,7 +212,10 @@ func (t tierNoncurrentTable) Rows() (rows []table.Row) {
 		if row.Tags == "" {
 			row.Tags = "-"
 		}
-		rows = append(rows, table.Row{row.ID, row.Status, row.Prefix, row.Tags, row.Days, row.Tier})
+		if row.Size == "" {
+			row.Size = "-"
+		}
+		rows = append(rows, table.Row{row.ID, row.Status, row.Prefix, row.Tags, row.Size, row.Days, row.Tier})
 	}
 	return rows
 }
@@ -22,6 +22,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/dustin/go-humanize"
 	"github.com/minio/minio-go/v7/pkg/lifecycle"
 )
 
@@ -58,6 +59,37 @@ func getTags(rule lifecycle.Rule) string {
 	return ""
 }
 
+// getObjectSizeLessThan returns the ObjectSizeLessThan filter configured,
+// 0 if none.
+func getObjectSizeLessThan(rule lifecycle.Rule) int64 {
+	if rule.RuleFilter.ObjectSizeLessThan > 0 {
+		return rule.RuleFilter.ObjectSizeLessThan
+	}
+	return rule.RuleFilter.And.ObjectSizeLessThan
+}
+
+// getObjectSizeGreaterThan returns the ObjectSizeGreaterThan filter
+// configured, 0 if none.
+func getObjectSizeGreaterThan(rule lifecycle.Rule) int64 {
+	if rule.RuleFilter.ObjectSizeGreaterThan > 0 {
+		return rule.RuleFilter.ObjectSizeGreaterThan
+	}
+	return rule.RuleFilter.And.ObjectSizeGreaterThan
+}
+
+// getObjectSize returns the object size filters configured as
+// ">1.0 MiB <100 MiB"
+func getObjectSize(rule lifecycle.Rule) string {
+	var sizes []string
+	if szGt := getObjectSizeGreaterThan(rule); szGt > 0 {
+		sizes = append(sizes, ">"+humanize.IBytes(uint64(szGt)))
+	}
+	if szLt := getObjectSizeLessThan(rule); szLt > 0 {
+		sizes = append(sizes, "<"+humanize.IBytes(uint64(szLt)))
+	}
+	return strings.Join(sizes, " ")
+}
+
 // getExpirationDays returns the number of days to expire relative to
 // time.Now().UTC() for the given rule.
 func getExpirationDays(rule lifecycle.Rule) int {
@@ -94,6 +126,7 @@ func ToTables(cfg *lifecycle.Configuration) []Table {
 				Status:          rule.Status,
 				Prefix:          getPrefix(rule),
 				Tags:            getTags(rule),
+				Size:            getObjectSize(rule),
 				Days:            getExpirationDays(rule),
 				ExpireDelMarker: bool(rule.Expiration.DeleteMarker),
 			})
@@ -104,6 +137,7 @@ func ToTables(cfg *lifecycle.Configuration) []Table {
 				Status:       rule.Status,
 				Prefix:       getPrefix(rule),
 				Tags:         getTags(rule),
+				Size:         getObjectSize(rule),
 				Days:         int(rule.NoncurrentVersionExpiration.NoncurrentDays),
 				KeepVersions: rule.NoncurrentVersionExpiration.NewerNoncurrentVersions,
 			})
@@ -114,6 +148,7 @@ func ToTables(cfg *lifecycle.Configuration) []Table {
 				Status: rule.Status,
 				Prefix: getPrefix(rule),
 				Tags:   getTags(rule),
+				Size:   getObjectSize(rule),
 				Days:   getTransitionDays(rule),
 				Tier:   rule.Transition.StorageClass,
 			})
@@ -124,6 +159,7 @@ func ToTables(cfg *lifecycle.Configuration) []Table {
 				Status: rule.Status,
 				Prefix: getPrefix(rule),
 				Tags:   getTags(rule),
+				Size:   getObjectSize(rule),
 				Days:   int(rule.NoncurrentVersionTransition.NoncurrentDays),
 				Tier:   rule.NoncurrentVersionTransition.StorageClass,
 			})
@@ -71,3 +71,19 @@ func TestILMTags(t *testing.T) {
 		}
 	}
 }
+
+func TestILMObjectSize(t *testing.T) {
+	tests := []struct {
+		filter   lifecycle.Filter
+		expected string
+	}{
+		{lifecycle.Filter{}, ""},
+		{lifecycle.Filter{ObjectSizeLessThan: 1024}, "<1.0 KiB"},
+		{lifecycle.Filter{And: lifecycle.And{Prefix: "doc/", ObjectSizeGreaterThan: 1 << 20, ObjectSizeLessThan: 100 << 20}}, ">1.0 MiB <100 MiB"},
+	}
+	for i, test := range tests {
+		if got := getObjectSize(lifecycle.Rule{RuleFilter: test.filter}); got != test.expected {
+			t.Fatalf("%d: Expected %s but got %s", i+1, test.expected, got)
+		}
+	}
+}
@@ -0,0 +1,87 @@
+// Copyright (c) 2015-2024 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+type diffLineOp int
+
+const (
+	diffLineEqual diffLineOp = iota
+	diffLineRemoved
+	diffLineAdded
+)
+
+// diffLine is a single line of a line by line diff.
+type diffLine struct {
+	op   diffLineOp
+	text string
+}
+
+// String returns the line prefixed in the unified diff style.
+func (d diffLine) String() string {
+	switch d.op {
+	case diffLineRemoved:
+		return "- " + d.text
+	case diffLineAdded:
+		return "+ " + d.text
+	}
+	return "  " + d.text
+}
+
+// lineDiff returns the changes needed to turn a into b, computed from the
+// longest common subsequence of lines. Meant for small documents such as
+// policies and configurations.
+func lineDiff(a, b []string) []diffLine {
+	// lcs[i][j] is the length of the longest common subsequence of a[i:] and b[j:]
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	diff := make([]diffLine, 0, max(len(a), len(b)))
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] == b[j]:
+			diff = append(diff, diffLine{op: diffLineEqual, text: a[i]})
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			diff = append(diff, diffLine{op: diffLineRemoved, text: a[i]})
+			i++
+		default:
+			diff = append(diff, diffLine{op: diffLineAdded, text: b[j]})
+			j++
+		}
+	}
+	for ; i < len(a); i++ {
+		diff = append(diff, diffLine{op: diffLineRemoved, text: a[i]})
+	}
+	for ; j < len(b); j++ {
+		diff = append(diff, diffLine{op: diffLineAdded, text: b[j]})
+	}
+	return diff
+}
@@ -0,0 +1,48 @@
+// Copyright (c) 2015-2024 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestLineDiff(t *testing.T) {
+	testCases := []struct {
+		a, b string
+		diff []string
+	}{
+		{"", "", []string{"  "}},
+		{"a\nb\nc", "a\nb\nc", []string{"  a", "  b", "  c"}},
+		{"a\nb\nc", "a\nc", []string{"  a", "- b", "  c"}},
+		{"a\nc", "a\nb\nc", []string{"  a", "+ b", "  c"}},
+		{"a\nb", "a\nc", []string{"  a", "- b", "+ c"}},
+		{"x", "y\nz", []string{"- x", "+ y", "+ z"}},
+	}
+
+	for i, testCase := range testCases {
+		var got []string
+		for _, line := range lineDiff(strings.Split(testCase.a, "\n"), strings.Split(testCase.b, "\n")) {
+			got = append(got, line.String())
+		}
+		if !reflect.DeepEqual(got, testCase.diff) {
+			t.Fatalf("Test %d: expected %q, got %q", i+1, testCase.diff, got)
+		}
+	}
+}
@@ -50,7 +50,7 @@ var (
 		},
 		cli.BoolFlag{
 			Name:  "summarize",
-			Usage: "display summary information (number of objects, total size)",
+			Usage: "display summary information (number of objects, total size, per storage class and per version with --versions)",
 		},
 		cli.StringFlag{
 			Name:  "storage-class, sc",
@@ -60,6 +60,10 @@ var (
 			Name:  "zip",
 			Usage: "list files inside zip archive (MinIO servers only)",
 		},
+		cli.BoolFlag{
+			Name:  "show-owner",
+			Usage: "show the owner of objects and versions, the user who deleted the object for delete markers",
+		},
 	}
 )
 
@@ -112,6 +116,15 @@ EXAMPLES:
   
   10. List all objects on mybucket, for the GLACIER storage class
      {{.Prompt}} {{.HelpName}} --storage-class 'GLACIER' s3/mybucket 
+
+  11. List the contents of the folders of January to June 2024, patterns are expanded by mc against the listing.
+      {{.Prompt}} {{.HelpName}} 's3/mybucket/logs/2024-0[1-6]-*/'
+
+  12. Summarize all the versions of the objects of mybucket per storage class, latest and non-current versions.
+      {{.Prompt}} {{.HelpName}} --recursive --versions --summarize s3/mybucket
+
+  13. List all the versions of the objects of mybucket with their owner, to find who created the delete markers.
+      {{.Prompt}} {{.HelpName}} --recursive --versions --show-owner s3/mybucket
 `,
 }
 
@@ -187,6 +200,7 @@ func checkListSyntax(cliCtx *cli.Context) ([]string, doListOptions) {
 		isIncomplete: isIncomplete,
 		isSummary:    isSummary,
 		withVersions: withVersions,
+		showOwner:    cliCtx.Bool("show-owner"),
 		listZip:      listZip,
 		filter:       storageClasss,
 	}
@@ -209,12 +223,23 @@ func mainList(cliCtx *cli.Context) error {
 	console.SetColor("Time", color.New(color.FgGreen))
 	console.SetColor("Summarize", color.New(color.Bold))
 	console.SetColor("SC", color.New(color.FgBlue))
+	console.SetColor("Owner", color.New(color.FgHiCyan))
 
 	// check 'ls' cliCtx arguments.
 	args, opts := checkListSyntax(cliCtx)
 
 	var cErr error
-	for _, targetURL := range args {
+	var targetURLs []string
+	for _, arg := range args {
+		matches, err := expandGlobURL(ctx, arg)
+		fatalIf(err.Trace(arg), "Unable to expand `"+arg+"`.")
+		if len(matches) == 0 {
+			errorIf(errInvalidArgument().Trace(arg), "No objects match `"+arg+"`.")
+			cErr = exitStatus(globalErrorExitStatus)
+		}
+		targetURLs = append(targetURLs, matches...)
+	}
+	for _, targetURL := range targetURLs {
 		clnt, err := newClient(targetURL)
 		fatalIf(err.Trace(targetURL), "Unable to initialize target `"+targetURL+"`.")
 		if !strings.HasSuffix(targetURL, string(clnt.GetURL().Separator)) {
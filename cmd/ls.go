@@ -50,12 +50,22 @@ type contentMessage struct {
 	VersionOrd     int    `json:"versionOrdinal,omitempty"`
 	VersionIndex   int    `json:"versionIndex,omitempty"`
 	IsDeleteMarker bool   `json:"isDeleteMarker,omitempty"`
+	IsLatest       bool   `json:"isLatest,omitempty"`
 	StorageClass   string `json:"storageClass,omitempty"`
 
+	Owner *contentOwner `json:"owner,omitempty"`
+
 	Metadata map[string]string `json:"metadata,omitempty"`
 	Tags     map[string]string `json:"tags,omitempty"`
 }
 
+// contentOwner is the owner of an object version, for a delete marker
+// it is the user who deleted the object.
+type contentOwner struct {
+	ID          string `json:"id,omitempty"`
+	DisplayName string `json:"displayName,omitempty"`
+}
+
 // String colorized string message.
 func (c contentMessage) String() string {
 	message := console.Colorize("Time", fmt.Sprintf("[%s]", c.Time.Format(printDate)))
@@ -66,6 +76,17 @@ func (c contentMessage) String() string {
 		message += " " + console.Colorize("SC", c.StorageClass)
 	}
 
+	if c.Owner != nil {
+		owner := c.Owner.DisplayName
+		if owner == "" {
+			owner = c.Owner.ID
+		}
+		if owner == "" {
+			owner = "-"
+		}
+		message += " " + console.Colorize("Owner", owner)
+	}
+
 	if c.VersionID != "" {
 		fileDesc += console.Colorize("VersionID", " "+c.VersionID) + console.Colorize("VersionOrd", fmt.Sprintf(" v%d", c.VersionOrd))
 		if c.IsDeleteMarker {
@@ -111,7 +132,7 @@ func getKey(c *ClientContent) string {
 
 // Generate printable listing from a list of sorted client
 // contents, the latest created content comes first.
-func generateContentMessages(clntURL ClientURL, ctnts []*ClientContent, printAllVersions bool) (msgs []contentMessage) {
+func generateContentMessages(clntURL ClientURL, ctnts []*ClientContent, printAllVersions, showOwner bool) (msgs []contentMessage) {
 	prefixPath := clntURL.Path
 	prefixPath = filepath.ToSlash(prefixPath)
 	if !strings.HasSuffix(prefixPath, "/") {
@@ -150,7 +171,11 @@ func generateContentMessages(clntURL ClientURL, ctnts []*ClientContent, printAll
 		contentMsg.Key = getKey(c)
 		contentMsg.VersionID = c.VersionID
 		contentMsg.IsDeleteMarker = c.IsDeleteMarker
+		contentMsg.IsLatest = c.IsLatest
 		contentMsg.VersionOrd = nrVersions - i
+		if showOwner {
+			contentMsg.Owner = &contentOwner{ID: c.OwnerID, DisplayName: c.OwnerName}
+		}
 		// URL is empty by default
 		// Set it to either relative dir (host) or public url (remote)
 		contentMsg.URL = clntURL.String()
@@ -177,16 +202,75 @@ func sortObjectVersions(ctntVersions []*ClientContent) {
 	})
 }
 
+// storageClassSummary container for the objects of a storage class.
+type storageClassSummary struct {
+	Objects int64 `json:"objects"`
+	Size    int64 `json:"size"`
+}
+
+// versionsSummary container for the versions listed with --versions.
+type versionsSummary struct {
+	Latest         int64 `json:"latest"`
+	NonCurrent     int64 `json:"nonCurrent"`
+	NonCurrentSize int64 `json:"nonCurrentSize"`
+	DeleteMarkers  int64 `json:"deleteMarkers"`
+}
+
 // summaryMessage container for summary message structure
 type summaryMessage struct {
-	TotalObjects int64 `json:"totalObjects"`
-	TotalSize    int64 `json:"totalSize"`
+	TotalObjects   int64                          `json:"totalObjects"`
+	TotalSize      int64                          `json:"totalSize"`
+	StorageClasses map[string]storageClassSummary `json:"storageClasses,omitempty"`
+	Versions       *versionsSummary               `json:"versions,omitempty"`
+}
+
+// add counts content in the summary.
+func (s *summaryMessage) add(content *ClientContent, withVersions bool) {
+	s.TotalObjects++
+	s.TotalSize += content.Size
+	if content.StorageClass != "" {
+		if s.StorageClasses == nil {
+			s.StorageClasses = make(map[string]storageClassSummary)
+		}
+		sc := s.StorageClasses[content.StorageClass]
+		sc.Objects++
+		sc.Size += content.Size
+		s.StorageClasses[content.StorageClass] = sc
+	}
+	if !withVersions {
+		return
+	}
+	if s.Versions == nil {
+		s.Versions = &versionsSummary{}
+	}
+	switch {
+	case content.IsDeleteMarker:
+		s.Versions.DeleteMarkers++
+	case content.IsLatest:
+		s.Versions.Latest++
+	default:
+		s.Versions.NonCurrent++
+		s.Versions.NonCurrentSize += content.Size
+	}
 }
 
 // String colorized string message
 func (s summaryMessage) String() string {
 	msg := console.Colorize("Summarize", fmt.Sprintf("\nTotal Size: %s", humanize.IBytes(uint64(s.TotalSize))))
 	msg += "\n" + console.Colorize("Summarize", fmt.Sprintf("Total Objects: %d", s.TotalObjects))
+	storageClasses := make([]string, 0, len(s.StorageClasses))
+	for sc := range s.StorageClasses {
+		storageClasses = append(storageClasses, sc)
+	}
+	sort.Strings(storageClasses)
+	for _, sc := range storageClasses {
+		msg += "\n" + console.Colorize("SC", fmt.Sprintf("  %-20s", sc)) +
+			fmt.Sprintf(" %d objects, %s", s.StorageClasses[sc].Objects, humanize.IBytes(uint64(s.StorageClasses[sc].Size)))
+	}
+	if s.Versions != nil {
+		msg += "\n" + console.Colorize("Summarize", fmt.Sprintf("Versions: %d latest, %d non-current (%s), %d delete markers",
+			s.Versions.Latest, s.Versions.NonCurrent, humanize.IBytes(uint64(s.Versions.NonCurrentSize)), s.Versions.DeleteMarkers))
+	}
 	return msg
 }
 
@@ -198,9 +282,9 @@ func (s summaryMessage) JSON() string {
 }
 
 // Pretty print the list of versions belonging to one object
-func printObjectVersions(clntURL ClientURL, ctntVersions []*ClientContent, printAllVersions bool) {
+func printObjectVersions(clntURL ClientURL, ctntVersions []*ClientContent, printAllVersions, showOwner bool) {
 	sortObjectVersions(ctntVersions)
-	msgs := generateContentMessages(clntURL, ctntVersions, printAllVersions)
+	msgs := generateContentMessages(clntURL, ctntVersions, printAllVersions, showOwner)
 	for _, msg := range msgs {
 		printMsg(msg)
 	}
@@ -212,6 +296,7 @@ type doListOptions struct {
 	isIncomplete bool
 	isSummary    bool
 	withVersions bool
+	showOwner    bool
 	listZip      bool
 	filter       string
 }
@@ -222,8 +307,7 @@ func doList(ctx context.Context, clnt Client, o doListOptions) error {
 		lastPath          string
 		perObjectVersions []*ClientContent
 		cErr              error
-		totalSize         int64
-		totalObjects      int64
+		summary           summaryMessage
 	)
 
 	for content := range clnt.List(ctx, ListOptions{
@@ -247,23 +331,19 @@ func doList(ctx context.Context, clnt Client, o doListOptions) error {
 
 		if lastPath != content.URL.Path {
 			// Print any object in the current list before reinitializing it
-			printObjectVersions(clnt.GetURL(), perObjectVersions, o.withVersions)
+			printObjectVersions(clnt.GetURL(), perObjectVersions, o.withVersions, o.showOwner)
 			lastPath = content.URL.Path
 			perObjectVersions = []*ClientContent{}
 		}
 
 		perObjectVersions = append(perObjectVersions, content)
-		totalSize += content.Size
-		totalObjects++
+		summary.add(content, o.withVersions)
 	}
 
-	printObjectVersions(clnt.GetURL(), perObjectVersions, o.withVersions)
+	printObjectVersions(clnt.GetURL(), perObjectVersions, o.withVersions, o.showOwner)
 
 	if o.isSummary {
-		printMsg(summaryMessage{
-			TotalObjects: totalObjects,
-			TotalSize:    totalSize,
-		})
+		printMsg(summary)
 	}
 
 	return cErr
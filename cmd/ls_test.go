@@ -16,3 +16,32 @@
 // along with this program.  If not, see <http://www.gnu.org/licenses/>.
 
 package cmd
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSummaryMessageAdd(t *testing.T) {
+	var s summaryMessage
+	for _, content := range []*ClientContent{
+		{Size: 10, StorageClass: "STANDARD", IsLatest: true},
+		{Size: 5, StorageClass: "STANDARD"},
+		{Size: 7, StorageClass: "GLACIER", IsLatest: true},
+		{IsDeleteMarker: true, IsLatest: true},
+	} {
+		s.add(content, true)
+	}
+	expected := summaryMessage{
+		TotalObjects: 4,
+		TotalSize:    22,
+		StorageClasses: map[string]storageClassSummary{
+			"STANDARD": {Objects: 2, Size: 15},
+			"GLACIER":  {Objects: 1, Size: 7},
+		},
+		Versions: &versionsSummary{Latest: 2, NonCurrent: 1, NonCurrentSize: 5, DeleteMarkers: 1},
+	}
+	if !reflect.DeepEqual(s, expected) {
+		t.Fatalf("expected %+v, got %+v", expected, s)
+	}
+}
@@ -375,6 +375,9 @@ func registerBefore(ctx *cli.Context) error {
 	// Check if config can be read.
 	checkConfig()
 
+	// Enable tracing of mc operations if requested.
+	initTelemetry(ctx.Args().First())
+
 	return nil
 }
 
@@ -419,8 +422,11 @@ var appCmds = []cli.Command{
 	adminCmd,
 	anonymousCmd,
 	batchCmd,
+	benchCmd,
 	cpCmd,
 	catCmd,
+	checksumCmd,
+	compatCmd,
 	configCmd,
 	corsCmd,
 	diffCmd,
@@ -449,14 +455,20 @@ var appCmds = []cli.Command{
 	rbCmd,
 	replicateCmd,
 	readyCmd,
+	runCmd,
+	scanCmd,
+	serveCmd,
 	sqlCmd,
 	statCmd,
 	supportCmd,
 	shareCmd,
+	syncCmd,
 	treeCmd,
 	tagCmd,
 	undoCmd,
 	updateCmd,
+	verifyCmd,
+	verifyManifestCmd,
 	versionCmd,
 	watchCmd,
 }
@@ -513,6 +525,7 @@ func registerApp(name string) *cli.App {
 	app.EnableBashCompletion = true
 	app.OnUsageError = onUsageError
 	app.After = func(*cli.Context) error {
+		shutdownTelemetry()
 		globalExpiringCerts.Range(func(k, v interface{}) bool {
 			host := k.(string)
 			expires := v.(time.Time)
@@ -542,12 +555,12 @@ func showCommandHelpAndExit(cliCtx *cli.Context, code int) {
 	cli.ShowCommandHelp(cliCtx, cliCtx.Command.Name)
 	// Wait until the user quits the pager
 	globalHelpPager.WaitForExit()
-	os.Exit(code)
+	cli.OsExiter(code)
 }
 
 func showAppHelpAndExit(cliCtx *cli.Context) {
 	cli.ShowAppHelp(cliCtx)
 	// Wait until the user quits the pager
 	globalHelpPager.WaitForExit()
-	os.Exit(globalErrorExitStatus)
+	cli.OsExiter(globalErrorExitStatus)
 }
@@ -0,0 +1,179 @@
+// Copyright (c) 2015-2024 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"os"
+
+	"github.com/dustin/go-humanize"
+	"github.com/minio/madmin-go/v3"
+	"github.com/minio/mc/pkg/probe"
+	yaml "gopkg.in/yaml.v2"
+)
+
+// mbBucketSpec describes a bucket to create with `mc mb --from-file`.
+type mbBucketSpec struct {
+	Target     string            `yaml:"target"`
+	Region     string            `yaml:"region,omitempty"`
+	ObjectLock *bool             `yaml:"object-lock,omitempty"`
+	Versioning *bool             `yaml:"versioning,omitempty"`
+	Quota      string            `yaml:"quota,omitempty"`
+	Tags       map[string]string `yaml:"tags,omitempty"`
+}
+
+// mbFileSpec is the content of a `mc mb --from-file` file, the
+// settings in defaults apply to all the buckets which do not set them.
+type mbFileSpec struct {
+	Defaults mbBucketSpec   `yaml:"defaults"`
+	Buckets  []mbBucketSpec `yaml:"buckets"`
+}
+
+// withDefaults returns s with the settings it does not set taken from d.
+func (s mbBucketSpec) withDefaults(d mbBucketSpec) mbBucketSpec {
+	if s.Region == "" {
+		s.Region = d.Region
+	}
+	if s.ObjectLock == nil {
+		s.ObjectLock = d.ObjectLock
+	}
+	if s.Versioning == nil {
+		s.Versioning = d.Versioning
+	}
+	if s.Quota == "" {
+		s.Quota = d.Quota
+	}
+	if len(d.Tags) > 0 {
+		tags := make(map[string]string, len(d.Tags)+len(s.Tags))
+		for k, v := range d.Tags {
+			tags[k] = v
+		}
+		for k, v := range s.Tags {
+			tags[k] = v
+		}
+		s.Tags = tags
+	}
+	return s
+}
+
+func (s mbBucketSpec) objectLock() bool {
+	return s.ObjectLock != nil && *s.ObjectLock
+}
+
+func (s mbBucketSpec) versioning() bool {
+	return s.Versioning != nil && *s.Versioning
+}
+
+// parseMakeBucketFile parses the buckets of a `mc mb --from-file` file,
+// flags holds the settings given on the command line, used when
+// neither the bucket nor the file defaults set them.
+func parseMakeBucketFile(data []byte, flags mbBucketSpec) ([]mbBucketSpec, error) {
+	var spec mbFileSpec
+	if e := yaml.UnmarshalStrict(data, &spec); e != nil {
+		return nil, e
+	}
+	if len(spec.Buckets) == 0 {
+		return nil, fmt.Errorf("no buckets found")
+	}
+
+	defaults := spec.Defaults.withDefaults(flags)
+	seen := make(map[string]bool, len(spec.Buckets))
+	buckets := make([]mbBucketSpec, 0, len(spec.Buckets))
+	for i, bucket := range spec.Buckets {
+		bucket = bucket.withDefaults(defaults)
+		switch {
+		case bucket.Target == "":
+			return nil, fmt.Errorf("bucket #%d has no target", i+1)
+		case seen[bucket.Target]:
+			return nil, fmt.Errorf("bucket `%s` is listed more than once", bucket.Target)
+		case bucket.objectLock() && bucket.Versioning != nil && !*bucket.Versioning:
+			return nil, fmt.Errorf("bucket `%s` cannot have object lock without versioning", bucket.Target)
+		}
+		if bucket.Quota != "" {
+			if _, e := humanize.ParseBytes(bucket.Quota); e != nil {
+				return nil, fmt.Errorf("bucket `%s` has an invalid quota: %w", bucket.Target, e)
+			}
+		}
+		seen[bucket.Target] = true
+		buckets = append(buckets, bucket)
+	}
+	return buckets, nil
+}
+
+// readMakeBucketFile reads and parses the `mc mb --from-file` file at path.
+func readMakeBucketFile(path string, flags mbBucketSpec) ([]mbBucketSpec, *probe.Error) {
+	data, e := os.ReadFile(path)
+	if e != nil {
+		return nil, probe.NewError(e).Trace(path)
+	}
+	buckets, e := parseMakeBucketFile(data, flags)
+	if e != nil {
+		return nil, probe.NewError(e).Trace(path)
+	}
+	return buckets, nil
+}
+
+// makeBucketFromSpec creates the bucket described by spec and applies
+// its settings.
+func makeBucketFromSpec(ctx context.Context, spec mbBucketSpec, ignoreExisting bool) *probe.Error {
+	clnt, err := newClient(spec.Target)
+	if err != nil {
+		return err.Trace(spec.Target)
+	}
+	if err = clnt.MakeBucket(ctx, spec.Region, ignoreExisting, spec.objectLock()); err != nil {
+		return err.Trace(spec.Target)
+	}
+
+	if spec.versioning() {
+		if err = clnt.SetVersion(ctx, "enable", []string{}, false); err != nil {
+			return err.Trace(spec.Target)
+		}
+	}
+
+	if len(spec.Tags) > 0 {
+		tags := url.Values{}
+		for k, v := range spec.Tags {
+			tags.Set(k, v)
+		}
+		if err = clnt.SetTags(ctx, "", tags.Encode()); err != nil {
+			return err.Trace(spec.Target)
+		}
+	}
+
+	if spec.Quota != "" {
+		quota, e := humanize.ParseBytes(spec.Quota)
+		if e != nil {
+			return probe.NewError(e).Trace(spec.Quota)
+		}
+		admClnt, err := newAdminClient(spec.Target)
+		if err != nil {
+			return err.Trace(spec.Target)
+		}
+		_, bucket := url2Alias(spec.Target)
+		e = admClnt.SetBucketQuota(ctx, bucket, &madmin.BucketQuota{
+			Quota: quota,
+			Type:  madmin.HardQuota,
+		})
+		if e != nil {
+			return probe.NewError(e).Trace(spec.Target)
+		}
+	}
+	return nil
+}
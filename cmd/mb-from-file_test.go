@@ -0,0 +1,76 @@
+// Copyright (c) 2015-2024 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseMakeBucketFile(t *testing.T) {
+	data := []byte(`
+defaults:
+  versioning: true
+  quota: 1GiB
+  tags:
+    env: staging
+buckets:
+  - target: myminio/logs
+    region: eu-west-1
+  - target: myminio/invoices
+    object-lock: true
+    quota: 10GiB
+    tags:
+      env: prod
+      team: finance
+`)
+	buckets, e := parseMakeBucketFile(data, mbBucketSpec{Region: "us-east-1"})
+	if e != nil {
+		t.Fatal(e)
+	}
+	if len(buckets) != 2 {
+		t.Fatalf("expected 2 buckets, got %d", len(buckets))
+	}
+
+	logs, invoices := buckets[0], buckets[1]
+	if logs.Region != "eu-west-1" || !logs.versioning() || logs.objectLock() || logs.Quota != "1GiB" {
+		t.Errorf("unexpected settings for logs: %+v", logs)
+	}
+	if !reflect.DeepEqual(logs.Tags, map[string]string{"env": "staging"}) {
+		t.Errorf("unexpected tags for logs: %v", logs.Tags)
+	}
+	if invoices.Region != "us-east-1" || !invoices.versioning() || !invoices.objectLock() || invoices.Quota != "10GiB" {
+		t.Errorf("unexpected settings for invoices: %+v", invoices)
+	}
+	if !reflect.DeepEqual(invoices.Tags, map[string]string{"env": "prod", "team": "finance"}) {
+		t.Errorf("unexpected tags for invoices: %v", invoices.Tags)
+	}
+
+	for _, invalid := range []string{
+		``,
+		`buckets: [{region: us-east-1}]`,
+		`buckets: [{target: myminio/a}, {target: myminio/a}]`,
+		`buckets: [{target: myminio/a, object-lock: true, versioning: false}]`,
+		`buckets: [{target: myminio/a, quota: lots}]`,
+		`buckets: [{target: myminio/a, unknown: true}]`,
+	} {
+		if _, e := parseMakeBucketFile([]byte(invalid), mbBucketSpec{}); e == nil {
+			t.Errorf("expected an error for %q", invalid)
+		}
+	}
+}
@@ -45,6 +45,10 @@ var mbFlags = []cli.Flag{
 		Name:  "with-versioning",
 		Usage: "enable versioned bucket",
 	},
+	cli.StringFlag{
+		Name:  "from-file",
+		Usage: "create the buckets listed with their settings in a YAML file",
+	},
 }
 
 // make a bucket.
@@ -60,6 +64,7 @@ var mbCmd = cli.Command{
 
 USAGE:
   {{.HelpName}} [FLAGS] TARGET [TARGET...]
+  {{.HelpName}} [FLAGS] --from-file FILE
 {{if .VisibleFlags}}
 FLAGS:
   {{range .VisibleFlags}}{{.}}
@@ -88,6 +93,21 @@ EXAMPLES:
 
   8. Create a new bucket on MinIO with versioning enabled.
      {{.Prompt}} {{.HelpName}} --with-versioning myminio/myversionedbucket
+
+  9. Create the buckets listed in a file, with their region, object lock, versioning, quota and tags.
+     {{.Prompt}} cat buckets.yaml
+     defaults:
+       versioning: true
+       tags:
+         env: staging
+     buckets:
+       - target: myminio/logs
+         quota: 500GiB
+       - target: myminio/invoices
+         object-lock: true
+         tags:
+           team: finance
+     {{.Prompt}} {{.HelpName}} --ignore-existing --from-file buckets.yaml
 `,
 }
 
@@ -113,6 +133,12 @@ func (s makeBucketMessage) JSON() string {
 
 // Validate command line arguments.
 func checkMakeBucketSyntax(cliCtx *cli.Context) {
+	if cliCtx.String("from-file") != "" {
+		if cliCtx.Args().Present() {
+			fatalIf(errInvalidArgument().Trace(cliCtx.Args()...), "TARGET arguments cannot be used with --from-file.")
+		}
+		return
+	}
 	if !cliCtx.Args().Present() {
 		showCommandHelpAndExit(cliCtx, 1) // last argument is exit code
 	}
@@ -131,6 +157,10 @@ func mainMakeBucket(cliCtx *cli.Context) error {
 	ignoreExisting := cliCtx.Bool("p")
 	withLock := cliCtx.Bool("l")
 
+	if path := cliCtx.String("from-file"); path != "" {
+		return makeBucketsFromFile(cliCtx, path)
+	}
+
 	var cErr error
 	for _, targetURL := range cliCtx.Args() {
 		// Instantiate client for URL.
@@ -165,3 +195,32 @@ func mainMakeBucket(cliCtx *cli.Context) error {
 	}
 	return cErr
 }
+
+// makeBucketsFromFile creates the buckets of a `mc mb --from-file` file.
+func makeBucketsFromFile(cliCtx *cli.Context, path string) error {
+	flags := mbBucketSpec{Region: cliCtx.String("region")}
+	if cliCtx.IsSet("with-lock") {
+		withLock := cliCtx.Bool("with-lock")
+		flags.ObjectLock = &withLock
+	}
+	if cliCtx.IsSet("with-versioning") {
+		withVersioning := cliCtx.Bool("with-versioning")
+		flags.Versioning = &withVersioning
+	}
+	buckets, err := readMakeBucketFile(path, flags)
+	fatalIf(err, "Unable to read buckets from `%s`.", path)
+
+	ctx, cancelMakeBucket := context.WithCancel(globalContext)
+	defer cancelMakeBucket()
+
+	var cErr error
+	for _, bucket := range buckets {
+		if err := makeBucketFromSpec(ctx, bucket, cliCtx.Bool("p")); err != nil {
+			errorIf(err, "Unable to make bucket `%s`.", bucket.Target)
+			cErr = exitStatus(globalErrorExitStatus)
+			continue
+		}
+		printMsg(makeBucketMessage{Status: "success", Bucket: bucket.Target, Region: bucket.Region})
+	}
+	return cErr
+}
@@ -0,0 +1,102 @@
+// Copyright (c) 2015-2024 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// Operations of the --attr-transform rules.
+const (
+	metadataOpDel    = "del"
+	metadataOpSet    = "set"
+	metadataOpRename = "rename"
+)
+
+// metadataRule is a rule of --attr-transform, value is the new name of
+// a renamed header.
+type metadataRule struct {
+	op    string
+	name  string
+	value string
+}
+
+// parseMetadataTransform parses the comma separated rules of
+// --attr-transform, e.g. 'del:X-Amz-Meta-Temp,set:Cache-Control=max-age=3600,rename:X-Old=X-New'.
+// A part without an operation continues the value of the previous set
+// rule so values can contain commas.
+func parseMetadataTransform(list string) (rules []metadataRule, e error) {
+	for _, part := range strings.Split(list, ",") {
+		if strings.TrimSpace(part) == "" {
+			continue
+		}
+		op, rule, found := strings.Cut(strings.TrimSpace(part), ":")
+		switch op = strings.ToLower(op); {
+		case found && op == metadataOpDel:
+			if rule = strings.TrimSpace(rule); rule == "" {
+				return nil, fmt.Errorf("missing header in `%s`", part)
+			}
+			rules = append(rules, metadataRule{op: op, name: rule})
+			continue
+		case found && (op == metadataOpSet || op == metadataOpRename):
+			name, value, ok := strings.Cut(rule, "=")
+			if name = strings.TrimSpace(name); !ok || name == "" {
+				return nil, fmt.Errorf("expected %s:NAME=VALUE, got `%s`", op, part)
+			}
+			if op == metadataOpRename {
+				if value = strings.TrimSpace(value); value == "" {
+					return nil, fmt.Errorf("missing new name in `%s`", part)
+				}
+			}
+			rules = append(rules, metadataRule{op: op, name: name, value: value})
+			continue
+		}
+		if len(rules) == 0 || rules[len(rules)-1].op != metadataOpSet {
+			return nil, fmt.Errorf("unknown rule `%s`, expected del:NAME, set:NAME=VALUE or rename:OLD=NEW", part)
+		}
+		rules[len(rules)-1].value += "," + part
+	}
+	return rules, nil
+}
+
+// transformMetadata applies the rules of --attr-transform to metadata in
+// order, user metadata can be named with or without the X-Amz-Meta- prefix.
+func transformMetadata(metadata map[string]string, rules []metadataRule) {
+	for _, rule := range rules {
+		switch rule.op {
+		case metadataOpDel:
+			stripMetadata(metadata, []string{rule.name})
+		case metadataOpSet:
+			stripMetadata(metadata, []string{rule.name})
+			metadata[http.CanonicalHeaderKey(rule.name)] = rule.value
+		case metadataOpRename:
+			name := http.CanonicalHeaderKey(rule.name)
+			for k, v := range metadata {
+				k1 := http.CanonicalHeaderKey(k)
+				if k1 == name || k1 == http.CanonicalHeaderKey("X-Amz-Meta-"+name) {
+					delete(metadata, k)
+					stripMetadata(metadata, []string{rule.value})
+					metadata[http.CanonicalHeaderKey(rule.value)] = v
+					break
+				}
+			}
+		}
+	}
+}
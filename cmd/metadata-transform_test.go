@@ -0,0 +1,68 @@
+// Copyright (c) 2015-2024 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseMetadataTransform(t *testing.T) {
+	rules, e := parseMetadataTransform("del:X-Amz-Meta-Temp, set:Cache-Control=no-cache, max-age=0,rename:X-Old=X-New")
+	if e != nil {
+		t.Fatal(e)
+	}
+	expected := []metadataRule{
+		{op: metadataOpDel, name: "X-Amz-Meta-Temp"},
+		{op: metadataOpSet, name: "Cache-Control", value: "no-cache, max-age=0"},
+		{op: metadataOpRename, name: "X-Old", value: "X-New"},
+	}
+	if !reflect.DeepEqual(rules, expected) {
+		t.Fatalf("expected %v, got %v", expected, rules)
+	}
+
+	for _, list := range []string{"del:", "set:Cache-Control", "rename:X-Old=", "drop:X-Old", "X-Old"} {
+		if _, e = parseMetadataTransform(list); e == nil {
+			t.Fatalf("expected an error for %q", list)
+		}
+	}
+}
+
+func TestTransformMetadata(t *testing.T) {
+	metadata := map[string]string{
+		"Content-Type":       "text/plain",
+		"Cache-Control":      "no-cache",
+		"X-Amz-Meta-Temp":    "1",
+		"X-Amz-Meta-Old":     "value",
+		"X-Amz-Meta-Project": "mc",
+	}
+	rules, e := parseMetadataTransform("del:temp,set:cache-control=max-age=3600,rename:Old=X-Amz-Meta-New,rename:Missing=X-Amz-Meta-Other")
+	if e != nil {
+		t.Fatal(e)
+	}
+	transformMetadata(metadata, rules)
+	expected := map[string]string{
+		"Content-Type":       "text/plain",
+		"Cache-Control":      "max-age=3600",
+		"X-Amz-Meta-New":     "value",
+		"X-Amz-Meta-Project": "mc",
+	}
+	if !reflect.DeepEqual(metadata, expected) {
+		t.Fatalf("expected %v, got %v", expected, metadata)
+	}
+}
@@ -0,0 +1,133 @@
+// Copyright (c) 2015-2024 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"regexp"
+	"runtime"
+	"strings"
+
+	"github.com/minio/mc/pkg/probe"
+)
+
+// mirrorFilter selects the objects to mirror with regular expressions
+// matched against the object names and with object tags.
+type mirrorFilter struct {
+	excludeRegex []*regexp.Regexp
+	includeRegex []*regexp.Regexp
+	excludeTags  map[string]string
+}
+
+// newMirrorFilter returns nil when no filter is set.
+func newMirrorFilter(excludeRegex, includeRegex, excludeTags []string) (*mirrorFilter, *probe.Error) {
+	if len(excludeRegex) == 0 && len(includeRegex) == 0 && len(excludeTags) == 0 {
+		return nil, nil
+	}
+	f := &mirrorFilter{}
+	for _, pattern := range excludeRegex {
+		re, e := regexp.Compile(pattern)
+		if e != nil {
+			return nil, probe.NewError(e).Trace(pattern)
+		}
+		f.excludeRegex = append(f.excludeRegex, re)
+	}
+	for _, pattern := range includeRegex {
+		re, e := regexp.Compile(pattern)
+		if e != nil {
+			return nil, probe.NewError(e).Trace(pattern)
+		}
+		f.includeRegex = append(f.includeRegex, re)
+	}
+	for _, tag := range excludeTags {
+		key, value, ok := strings.Cut(tag, "=")
+		if !ok || key == "" {
+			return nil, errInvalidArgument().Trace(tag)
+		}
+		if f.excludeTags == nil {
+			f.excludeTags = make(map[string]string)
+		}
+		f.excludeTags[key] = value
+	}
+	return f, nil
+}
+
+// hasTagFilters returns true if the tags of the objects are needed.
+func (f *mirrorFilter) hasTagFilters() bool {
+	return f != nil && len(f.excludeTags) > 0
+}
+
+// excludedByName returns true if the object name, relative to the
+// mirrored folder, is excluded by --exclude-regex or not selected by
+// --include-regex.
+func (f *mirrorFilter) excludedByName(suffix string, typ ClientURLType) bool {
+	if f == nil {
+		return false
+	}
+	suffix = strings.TrimPrefix(suffix, "/")
+	if typ == fileSystem && runtime.GOOS == "windows" {
+		// Patterns are written with slashes on all platforms.
+		suffix = strings.ReplaceAll(strings.TrimPrefix(suffix, `\`), `\`, "/")
+	}
+	for _, re := range f.excludeRegex {
+		if re.MatchString(suffix) {
+			return true
+		}
+	}
+	if len(f.includeRegex) == 0 {
+		return false
+	}
+	for _, re := range f.includeRegex {
+		if re.MatchString(suffix) {
+			return false
+		}
+	}
+	return true
+}
+
+// excludedByTags returns true if one of the tags is excluded by --exclude-tag.
+func (f *mirrorFilter) excludedByTags(tags map[string]string) bool {
+	if !f.hasTagFilters() {
+		return false
+	}
+	for key, value := range tags {
+		if excluded, ok := f.excludeTags[key]; ok && excluded == value {
+			return true
+		}
+	}
+	return false
+}
+
+// excludedByObjectTags is excludedByTags for an object, tags missing from
+// the listing are fetched from the server. Filesystem files have no tags.
+func (f *mirrorFilter) excludedByObjectTags(ctx context.Context, alias string, content *ClientContent) (bool, *probe.Error) {
+	if !f.hasTagFilters() || content == nil || content.URL.Type == fileSystem {
+		return false, nil
+	}
+	tags := content.Tags
+	if tags == nil {
+		clnt, err := newClientFromAlias(alias, content.URL.String())
+		if err != nil {
+			return false, err.Trace(alias, content.URL.String())
+		}
+		if tags, err = clnt.GetTags(ctx, content.VersionID); err != nil {
+			return false, err.Trace(alias, content.URL.String())
+		}
+	}
+	return f.excludedByTags(tags), nil
+}
@@ -0,0 +1,68 @@
+// Copyright (c) 2015-2024 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import "testing"
+
+func TestMirrorFilter(t *testing.T) {
+	f, err := newMirrorFilter([]string{`\.tmp$`}, []string{`^logs/`, `^daily/2024-`}, []string{"temporary=true", "scratch="})
+	if err != nil {
+		t.Fatal(err)
+	}
+	names := map[string]bool{
+		"logs/app.log":             false,
+		"/logs/app.log":            false,
+		"logs/app.tmp":             true,
+		"daily/2024-01-01.parquet": false,
+		"daily/2023-01-01.parquet": true,
+		"archive/logs/app.log":     true,
+		"archive/daily/2024-01-01": true,
+	}
+	for name, excluded := range names {
+		if got := f.excludedByName(name, objectStorage); got != excluded {
+			t.Errorf("%s: expected excluded=%t, got %t", name, excluded, got)
+		}
+	}
+
+	tags := []struct {
+		tags     map[string]string
+		excluded bool
+	}{
+		{nil, false},
+		{map[string]string{"temporary": "true"}, true},
+		{map[string]string{"temporary": "false", "team": "a"}, false},
+		{map[string]string{"scratch": ""}, true},
+	}
+	for _, tc := range tags {
+		if got := f.excludedByTags(tc.tags); got != tc.excluded {
+			t.Errorf("%v: expected excluded=%t, got %t", tc.tags, tc.excluded, got)
+		}
+	}
+
+	if f, err := newMirrorFilter(nil, nil, nil); err != nil || f != nil || f.excludedByName("a", objectStorage) || f.hasTagFilters() {
+		t.Fatal("expected no filter")
+	}
+	for _, tag := range []string{"temporary", "=true"} {
+		if _, err := newMirrorFilter(nil, nil, []string{tag}); err == nil {
+			t.Errorf("expected an error for tag %q", tag)
+		}
+	}
+	if _, err := newMirrorFilter([]string{"("}, nil, nil); err == nil {
+		t.Error("expected an error for an invalid regex")
+	}
+}
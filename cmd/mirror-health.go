@@ -0,0 +1,135 @@
+// Copyright (c) 2015-2024 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	gojson "encoding/json"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// mirrorHealth tracks the activity of a mirror for --health-address.
+// A mirror is reported as stalled when operations are pending and none
+// completed for longer than timeout. All methods are no-ops on a nil
+// *mirrorHealth.
+type mirrorHealth struct {
+	mu      sync.Mutex
+	timeout time.Duration
+	now     func() time.Time
+
+	started      time.Time
+	lastActivity time.Time
+	lastSuccess  time.Time
+	lastObject   string
+	queued       int64
+	succeeded    int64
+	failed       int64
+	lastError    string
+}
+
+// mirrorHealthStatus is the body of the health endpoint responses.
+type mirrorHealthStatus struct {
+	Status      string    `json:"status"`
+	Started     time.Time `json:"started"`
+	LastSuccess time.Time `json:"lastSuccess,omitempty"`
+	LastObject  string    `json:"lastObject,omitempty"`
+	QueueDepth  int64     `json:"queueDepth"`
+	Succeeded   int64     `json:"succeeded"`
+	Errors      int64     `json:"errors"`
+	LastError   string    `json:"lastError,omitempty"`
+}
+
+func newMirrorHealth(timeout time.Duration) *mirrorHealth {
+	now := time.Now().UTC()
+	return &mirrorHealth{
+		timeout:      timeout,
+		now:          func() time.Time { return time.Now().UTC() },
+		started:      now,
+		lastActivity: now,
+	}
+}
+
+// queue adds delta to the number of pending operations.
+func (h *mirrorHealth) queue(delta int64) {
+	if h == nil {
+		return
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.queued == 0 && delta > 0 {
+		// Nothing was pending, the stall timeout starts now.
+		h.lastActivity = h.now()
+	}
+	h.queued += delta
+}
+
+// success records a completed operation on object.
+func (h *mirrorHealth) success(object string) {
+	if h == nil {
+		return
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.lastActivity = h.now()
+	h.lastSuccess = h.lastActivity
+	h.lastObject = object
+	h.succeeded++
+}
+
+// failure records a failed operation.
+func (h *mirrorHealth) failure(e error) {
+	if h == nil {
+		return
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.lastActivity = h.now()
+	h.failed++
+	h.lastError = e.Error()
+}
+
+func (h *mirrorHealth) status() mirrorHealthStatus {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	st := mirrorHealthStatus{
+		Status:      "ok",
+		Started:     h.started,
+		LastSuccess: h.lastSuccess,
+		LastObject:  h.lastObject,
+		QueueDepth:  h.queued,
+		Succeeded:   h.succeeded,
+		Errors:      h.failed,
+		LastError:   h.lastError,
+	}
+	if h.queued > 0 && h.now().Sub(h.lastActivity) > h.timeout {
+		st.Status = "stalled"
+	}
+	return st
+}
+
+// ServeHTTP replies 200 while the mirror makes progress and 503 once it
+// is stalled, with the details as JSON.
+func (h *mirrorHealth) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	st := h.status()
+	w.Header().Set("Content-Type", "application/json")
+	if st.Status != "ok" {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
+	gojson.NewEncoder(w).Encode(st)
+}
@@ -0,0 +1,74 @@
+// Copyright (c) 2015-2024 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestMirrorHealth(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	h := newMirrorHealth(time.Minute)
+	h.now = func() time.Time { return now }
+
+	check := func(wantCode int, wantStatus string) {
+		t.Helper()
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+		if rec.Code != wantCode {
+			t.Fatalf("expected %d, got %d: %s", wantCode, rec.Code, rec.Body)
+		}
+		if st := h.status(); st.Status != wantStatus {
+			t.Fatalf("expected %s, got %+v", wantStatus, st)
+		}
+	}
+
+	// Idle mirrors are healthy however long nothing happens.
+	now = now.Add(time.Hour)
+	check(http.StatusOK, "ok")
+
+	h.queue(2)
+	now = now.Add(30 * time.Second)
+	h.success("src/a")
+	h.queue(-1)
+	now = now.Add(50 * time.Second)
+	check(http.StatusOK, "ok")
+
+	// A failure is progress as well.
+	h.failure(errors.New("boom"))
+	now = now.Add(61 * time.Second)
+	check(http.StatusServiceUnavailable, "stalled")
+
+	h.queue(-1)
+	check(http.StatusOK, "ok")
+
+	st := h.status()
+	if st.Succeeded != 1 || st.Errors != 1 || st.LastError != "boom" || st.LastObject != "src/a" || st.QueueDepth != 0 {
+		t.Fatalf("unexpected status %+v", st)
+	}
+
+	// Nil health tracking is a no-op.
+	var nilHealth *mirrorHealth
+	nilHealth.queue(1)
+	nilHealth.success("x")
+	nilHealth.failure(errors.New("x"))
+}
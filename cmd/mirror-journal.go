@@ -0,0 +1,181 @@
+// Copyright (c) 2015-2024 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/minio/mc/pkg/probe"
+)
+
+// Operations recorded in the mirror failure journal.
+const (
+	mirrorJournalCopy   = "copy"
+	mirrorJournalRemove = "remove"
+)
+
+// mirrorJournalRecord is a failed mirror operation, source and target
+// are aliased URLs like the ones printed by mirror.
+type mirrorJournalRecord struct {
+	Time   time.Time `json:"time"`
+	Op     string    `json:"op"`
+	Source string    `json:"source,omitempty"`
+	Target string    `json:"target"`
+	Error  string    `json:"error"`
+}
+
+// mirrorJournal appends the failed operations of a mirror to a file,
+// one JSON object per line, so that they can be replayed later with
+// --retry-journal.
+type mirrorJournal struct {
+	mu  sync.Mutex
+	f   *os.File
+	enc *json.Encoder
+}
+
+// openMirrorJournal opens the journal at path, the previous records are
+// removed if truncate is set.
+func openMirrorJournal(path string, truncate bool) (*mirrorJournal, *probe.Error) {
+	flags := os.O_CREATE | os.O_WRONLY | os.O_APPEND
+	if truncate {
+		flags |= os.O_TRUNC
+	}
+	f, e := os.OpenFile(path, flags, 0o600)
+	if e != nil {
+		return nil, probe.NewError(e)
+	}
+	return &mirrorJournal{f: f, enc: json.NewEncoder(f)}, nil
+}
+
+// record appends the failed operation of sURLs to the journal.
+func (j *mirrorJournal) record(sURLs URLs) {
+	if j == nil || sURLs.Error == nil {
+		return
+	}
+	rec := mirrorJournalRecord{
+		Time:  time.Now().UTC(),
+		Error: sURLs.Error.ToGoError().Error(),
+	}
+	switch {
+	case sURLs.SourceContent != nil && sURLs.TargetContent != nil:
+		rec.Op = mirrorJournalCopy
+		rec.Source = filepath.ToSlash(filepath.Join(sURLs.SourceAlias, sURLs.SourceContent.URL.Path))
+	case sURLs.TargetContent != nil:
+		rec.Op = mirrorJournalRemove
+	default:
+		// Not related to an object, nothing to replay.
+		return
+	}
+	rec.Target = filepath.ToSlash(filepath.Join(sURLs.TargetAlias, sURLs.TargetContent.URL.Path))
+
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	e := j.enc.Encode(rec)
+	errorIf(probe.NewError(e).Trace(j.f.Name()), "Unable to write to the failure journal.")
+}
+
+func (j *mirrorJournal) close() *probe.Error {
+	if j == nil {
+		return nil
+	}
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return probe.NewError(j.f.Close())
+}
+
+// loadMirrorJournal reads the records of a failure journal.
+func loadMirrorJournal(path string) ([]mirrorJournalRecord, *probe.Error) {
+	f, e := os.Open(path)
+	if e != nil {
+		return nil, probe.NewError(e)
+	}
+	defer f.Close()
+
+	var records []mirrorJournalRecord
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		var rec mirrorJournalRecord
+		if e = json.Unmarshal(scanner.Bytes(), &rec); e != nil {
+			return nil, probe.NewError(fmt.Errorf("line %d: %w", line, e))
+		}
+		switch rec.Op {
+		case mirrorJournalCopy:
+			if rec.Source == "" || rec.Target == "" {
+				return nil, probe.NewError(fmt.Errorf("line %d: copy without source or target", line))
+			}
+		case mirrorJournalRemove:
+			if rec.Target == "" {
+				return nil, probe.NewError(fmt.Errorf("line %d: remove without target", line))
+			}
+		default:
+			return nil, probe.NewError(fmt.Errorf("line %d: unknown operation '%s'", line, rec.Op))
+		}
+		records = append(records, rec)
+	}
+	if e = scanner.Err(); e != nil {
+		return nil, probe.NewError(e)
+	}
+	return records, nil
+}
+
+// journalMirrorURLs sends the operations of the journal records to
+// replay them instead of comparing source and target. Objects removed
+// from the source since they failed are skipped.
+func journalMirrorURLs(ctx context.Context, records []mirrorJournalRecord, opts mirrorOptions) <-chan URLs {
+	URLsCh := make(chan URLs)
+	go func() {
+		defer close(URLsCh)
+		for _, rec := range records {
+			targetAlias, targetURL, _ := mustExpandAlias(rec.Target)
+			sURLs := URLs{
+				TargetAlias:   targetAlias,
+				TargetContent: &ClientContent{URL: *newClientURL(targetURL)},
+			}
+			if rec.Op == mirrorJournalCopy {
+				sourceAlias, sourceURL, _ := mustExpandAlias(rec.Source)
+				sURLs.SourceAlias = sourceAlias
+				_, content, err := url2Stat(ctx, url2StatOptions{urlStr: rec.Source, encKeyDB: opts.encKeyDB})
+				if err != nil {
+					if _, ok := err.ToGoError().(ObjectMissing); ok {
+						continue
+					}
+					content = &ClientContent{URL: *newClientURL(sourceURL)}
+					sURLs.Error = err.Trace(rec.Source)
+				}
+				sURLs.SourceContent = content
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case URLsCh <- sURLs:
+			}
+		}
+	}()
+	return URLsCh
+}
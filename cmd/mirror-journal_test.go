@@ -0,0 +1,75 @@
+// Copyright (c) 2015-2024 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/minio/mc/pkg/probe"
+)
+
+func TestMirrorJournal(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "failures.jsonl")
+	j, err := openMirrorJournal(path, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	failure := probe.NewError(errors.New("failure"))
+	j.record(URLs{
+		SourceAlias:   "src",
+		SourceContent: &ClientContent{URL: *newClientURL("http://localhost:9000/bucket/a")},
+		TargetAlias:   "tgt",
+		TargetContent: &ClientContent{URL: *newClientURL("http://localhost:9001/bucket/a")},
+		Error:         failure,
+	})
+	j.record(URLs{
+		TargetAlias:   "tgt",
+		TargetContent: &ClientContent{URL: *newClientURL("http://localhost:9001/bucket/b")},
+		Error:         failure,
+	})
+	// Neither successes nor errors unrelated to an object are recorded.
+	j.record(URLs{TargetAlias: "tgt", TargetContent: &ClientContent{URL: *newClientURL("http://localhost:9001/bucket/c")}})
+	j.record(URLs{Error: failure})
+	if err = j.close(); err != nil {
+		t.Fatal(err)
+	}
+
+	records, err := loadMirrorJournal(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 2 {
+		t.Fatalf("expected 2 records, got %d", len(records))
+	}
+	if r := records[0]; r.Op != mirrorJournalCopy || r.Source != "src/bucket/a" || r.Target != "tgt/bucket/a" || r.Error != "failure" {
+		t.Fatalf("unexpected copy record %+v", r)
+	}
+	if r := records[1]; r.Op != mirrorJournalRemove || r.Source != "" || r.Target != "tgt/bucket/b" {
+		t.Fatalf("unexpected remove record %+v", r)
+	}
+
+	if e := os.WriteFile(path, []byte(`{"op":"move","target":"tgt/bucket/a"}`+"\n"), 0o600); e != nil {
+		t.Fatal(e)
+	}
+	if _, err = loadMirrorJournal(path); err == nil {
+		t.Fatal("expected an error for an unknown operation")
+	}
+}
@@ -20,8 +20,10 @@ package cmd
 import (
 	"context"
 	"fmt"
+	"io"
 	"math/rand"
 	"net/http"
+	"os"
 	"path"
 	"path/filepath"
 	"runtime"
@@ -82,6 +84,14 @@ var (
 			Name:  "preserve, a",
 			Usage: "preserve file(s)/object(s) attributes and bucket(s) policy/locking configuration(s) on target bucket(s)",
 		},
+		cli.BoolFlag{
+			Name:  "preserve-tags",
+			Usage: "preserve the tags of object(s) on target",
+		},
+		cli.BoolFlag{
+			Name:  "preserve-retention",
+			Usage: "preserve the retention and legal hold of object(s) on target, target bucket(s) must have object locking enabled",
+		},
 		cli.BoolFlag{
 			Name:   "md5",
 			Usage:  "force all upload(s) to calculate md5sum checksum",
@@ -128,6 +138,18 @@ var (
 			Name:  "attr",
 			Usage: "add custom metadata for all objects",
 		},
+		cli.StringFlag{
+			Name:  "strip-metadata",
+			Usage: "comma separated list of headers and metadata not copied to the target, e.g. 'Expires,X-Amz-Meta-Owner'",
+		},
+		cli.StringFlag{
+			Name:  "attr-transform",
+			Usage: "comma separated rules transforming the metadata of each object, e.g. 'del:X-Amz-Meta-Temp,set:Cache-Control=max-age=3600,rename:X-Old=X-New'",
+		},
+		cli.StringFlag{
+			Name:  "install-service",
+			Usage: "write a systemd unit with this name running the mirror command with --watch, instead of mirroring",
+		},
 		cli.StringFlag{
 			Name:  "monitoring-address",
 			Usage: "if specified, a new prometheus endpoint will be created to report mirroring activity. (eg: localhost:8081)",
@@ -144,6 +166,69 @@ var (
 			Name:  "skip-errors",
 			Usage: "skip any errors when mirroring",
 		},
+		cli.DurationFlag{
+			Name:  "grace-period",
+			Usage: "on SIGINT/SIGTERM stop queueing new objects and wait up to this duration for in-flight transfers, then save a resume state",
+		},
+		cli.StringFlag{
+			Name:  "state-file",
+			Usage: "path of the resume state saved on graceful shutdown, requires --grace-period or --resume",
+		},
+		cli.BoolFlag{
+			Name:  "resume",
+			Usage: "resume a mirror stopped on a signal from its resume state, the state is removed once resumed",
+		},
+		cli.StringFlag{
+			Name:  "event-source, watch-events",
+			Usage: "receive the events of --watch from a webhook or an SQS queue instead of the server, e.g. 'webhook://:8080/events' or 'sqs://sqs.us-east-1.amazonaws.com/123456789012/events'",
+		},
+		cli.StringSliceFlag{
+			Name:  "schedule",
+			Usage: "with --watch, only transfer objects during these daily windows in local time, queuing changes otherwise, e.g. '22:00-06:00' or '22:00-06:00@50MiB' to also limit the bandwidth",
+		},
+		cli.StringFlag{
+			Name:  "health-address",
+			Usage: "with --watch, serve a health endpoint reporting the last processed object, queue depth and errors, e.g. ':8082'",
+		},
+		cli.DurationFlag{
+			Name:  "health-timeout",
+			Usage: "report the mirror as unhealthy when operations are pending and none completed for this long",
+			Value: 5 * time.Minute,
+		},
+		cli.IntFlag{
+			Name:  "list-workers",
+			Usage: "number of top level prefixes listed and compared in parallel",
+			Value: 1,
+		},
+		cli.StringFlag{
+			Name:  "failure-journal",
+			Usage: "append the failed copies and removals to a JSON lines file, to replay them with --retry-journal",
+		},
+		cli.StringFlag{
+			Name:  "retry-journal",
+			Usage: "replay the failed copies and removals of a failure journal instead of comparing SOURCE and TARGET",
+		},
+		cli.StringFlag{
+			Name:  "compare",
+			Usage: "compare objects by 'size' and modification time, or by 'checksum'",
+			Value: compareSize,
+		},
+		cli.StringSliceFlag{
+			Name:  "exclude-regex",
+			Usage: "exclude object(s) whose name matches the specified regular expression",
+		},
+		cli.StringSliceFlag{
+			Name:  "include-regex",
+			Usage: "mirror only the object(s) whose name matches the specified regular expression",
+		},
+		cli.StringSliceFlag{
+			Name:  "exclude-tag",
+			Usage: "exclude object(s) with the specified tag, e.g. 'temporary=true'",
+		},
+		cli.StringFlag{
+			Name:  "unicode-normalize",
+			Usage: "normalize object names to 'nfc' or 'nfd' when comparing and uploading them, 'none' compares names byte by byte",
+		},
 		checksumFlag,
 	}
 )
@@ -155,7 +240,7 @@ var mirrorCmd = cli.Command{
 	Action:       mainMirror,
 	OnUsageError: onUsageError,
 	Before:       setGlobalsFromContext,
-	Flags:        append(append(mirrorFlags, encFlags...), globalFlags...),
+	Flags:        append(append(append(mirrorFlags, transferManifestFlags...), encFlags...), globalFlags...),
 	CustomHelpTemplate: `NAME:
   {{.HelpName}} - {{.Usage}}
 
@@ -169,6 +254,7 @@ FLAGS:
 ENVIRONMENT VARIABLES:
   MC_ENC_KMS: KMS encryption key in the form of (alias/prefix=key).
   MC_ENC_S3: S3 encryption key in the form of (alias/prefix=key).
+  MC_ENC_CONTEXT: SSE-KMS encryption context in the form of (key=value).
 
 EXAMPLES:
   01. Mirror a bucket recursively from MinIO cloud storage to a bucket on Amazon S3 cloud storage.
@@ -223,6 +309,65 @@ EXAMPLES:
   16. Cross mirror between sites in a active-active deployment.
       Site-A: {{.Prompt}} {{.HelpName}} --active-active siteA siteB
       Site-B: {{.Prompt}} {{.HelpName}} --active-active siteB siteA
+
+  17. Mirror a bucket, on SIGTERM finish in-flight transfers within 30 seconds and save the progress
+      in '/data/mirror-state.json'. Exits with status 75 when all in-flight transfers completed.
+      {{.Prompt}} {{.HelpName}} --grace-period 30s --state-file /data/mirror-state.json play/photos s3/backup-photos
+      Resume it once restarted, the objects mirrored before the shutdown are skipped.
+      {{.Prompt}} {{.HelpName}} --resume --grace-period 30s --state-file /data/mirror-state.json play/photos s3/backup-photos
+
+  18. Continuously mirror a bucket without saturating the WAN link, uploads of all parallel
+      transfers are throttled to 50MiB per second in total.
+      {{.Prompt}} {{.HelpName}} --watch --limit-upload 50MiB play/photos s3/backup-photos
+
+  19. Mirror a bucket with millions of objects, listing and comparing 16 top level prefixes in parallel.
+      {{.Prompt}} {{.HelpName}} --list-workers 16 play/photos s3/backup-photos
+
+  20. Continuously mirror a bucket of a server without reliable bucket notifications, the events are
+      sent by the server, or forwarded from a Kafka topic by a bridge, to a webhook started by mirror.
+      {{.Prompt}} {{.HelpName}} --watch --event-source "webhook://:8080/events?token=secret" ceph/photos s3/backup-photos
+
+  21. Mirror a bucket and overwrite the objects of the same size whose content changed, the objects are
+      compared by their SHA256, CRC32C, CRC32, SHA1 checksum or their ETag.
+      {{.Prompt}} {{.HelpName}} --overwrite --compare checksum play/photos s3/backup-photos
+
+  22. Mirror a bucket skipping the objects which fail, then replay only the failed objects, the
+      journal is rewritten with the objects failing again.
+      {{.Prompt}} {{.HelpName}} --skip-errors --failure-journal failures.jsonl play/photos s3/backup-photos
+      {{.Prompt}} {{.HelpName}} --skip-errors --retry-journal failures.jsonl --failure-journal failures.jsonl play/photos s3/backup-photos
+
+  23. Mirror a bucket without the website redirects of its objects.
+      {{.Prompt}} {{.HelpName}} --strip-metadata X-Amz-Website-Redirect-Location play/website s3/website-backup
+
+  24. Install a systemd service continuously mirroring a bucket, restarted on failure.
+      {{.Prompt}} {{.HelpName}} --install-service mirror-photos --watch --grace-period 30s play/photos s3/backup-photos
+
+  25. Continuously mirror an AWS S3 bucket whose event notifications are sent to an SQS queue.
+      {{.Prompt}} {{.HelpName}} --watch --watch-events "sqs://sqs.us-east-1.amazonaws.com/123456789012/photos-events" aws/photos s3/backup-photos
+
+  26. Mirror a bucket with the tags, retention and legal hold of its objects, for compliance.
+      {{.Prompt}} {{.HelpName}} --preserve-tags --preserve-retention play/records s3/records-archive
+
+  27. Mirror a macOS folder whose file names are decomposed (NFD) to a bucket using precomposed (NFC) object names.
+      {{.Prompt}} {{.HelpName}} --unicode-normalize nfc ~/Documents s3/documents
+
+  28. Mirror only the daily parquet files of 2024, except the objects tagged as temporary.
+      {{.Prompt}} {{.HelpName}} --include-regex '^daily/2024-[0-9]{2}-[0-9]{2}\.parquet$' --exclude-tag "temporary=true" s3/lake myminio/lake
+
+  29. Mirror a local folder to a bucket, encrypting the objects with a KMS key and an encryption context.
+      {{.Prompt}} {{.HelpName}} --enc-kms "myminio/reports/=my-key" --enc-context project=alpha ~/reports myminio/reports
+
+  30. Continuously mirror a bucket only during off-peak hours, limiting the bandwidth during lunch time.
+      {{.Prompt}} {{.HelpName}} --watch --schedule "22:00-06:00" --schedule "12:00-13:00@10MiB" play/photos s3/backup-photos
+
+  31. Continuously mirror a bucket with a health endpoint for liveness probes, failing after 10 minutes without progress.
+      {{.Prompt}} {{.HelpName}} --watch --health-address :8082 --health-timeout 10m play/photos s3/backup-photos
+
+  32. Mirror a bucket dropping the temporary metadata, caching the objects for an hour and renaming a legacy header.
+      {{.Prompt}} {{.HelpName}} --attr-transform "del:X-Amz-Meta-Temp,set:Cache-Control=max-age=3600,rename:X-Amz-Meta-Old=X-Amz-Meta-New" play/website s3/website-backup
+
+  33. Mirror a bucket writing a manifest of the mirrored objects signed with a minisign key.
+      {{.Prompt}} {{.HelpName}} --manifest backup.sha256 --manifest-sign-key ~/.minisign/minisign.key play/photos s3/backup-photos
 `,
 }
 
@@ -251,8 +396,59 @@ var (
 		},
 		[]string{"object_size"},
 	)
+
+	// Metrics labeled by bucket and direction, see mirrorMetricLabels.
+	mirrorMetricLabelNames = []string{"bucket", "direction"}
+	mirrorBucketOps        = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "mc_mirror_bucket_s3ops",
+		Help: "The total number of mirror operations per bucket",
+	}, mirrorMetricLabelNames)
+	mirrorBucketFailedOps = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "mc_mirror_bucket_failed_s3ops",
+		Help: "The total number of failed mirror operations per bucket",
+	}, mirrorMetricLabelNames)
+	mirrorBucketUploadedBytes = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "mc_mirror_bucket_s3uploaded_bytes",
+		Help: "The total number of bytes uploaded per bucket",
+	}, mirrorMetricLabelNames)
+	mirrorQueueDepth = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "mc_mirror_queue_depth",
+		Help: "The number of mirror operations queued or in progress per bucket",
+	}, mirrorMetricLabelNames)
+	mirrorWatchEventLag = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "mc_mirror_watch_event_lag_seconds",
+		Help: "Time between the last watched event and the start of its mirroring",
+	}, mirrorMetricLabelNames)
+	mirrorLastSuccess = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "mc_mirror_last_success_timestamp_seconds",
+		Help: "Unix time of the last successful mirror operation per bucket",
+	}, mirrorMetricLabelNames)
 )
 
+// mirrorMetricLabels returns the labels of the per bucket metrics for
+// an operation. The bucket is the source bucket, or the target bucket
+// when mirroring from a local folder or removing from the target. The
+// direction is made of the source and target aliases, 'local' standing
+// for the local filesystem.
+func mirrorMetricLabels(sURLs URLs) prometheus.Labels {
+	aliasName := func(alias string) string {
+		if alias == "" {
+			return "local"
+		}
+		return alias
+	}
+	var bucket string
+	if c := sURLs.SourceContent; c != nil && c.URL.Type == objectStorage {
+		bucket, _ = url2BucketAndObject(&c.URL)
+	} else if c := sURLs.TargetContent; c != nil && c.URL.Type == objectStorage {
+		bucket, _ = url2BucketAndObject(&c.URL)
+	}
+	return prometheus.Labels{
+		"bucket":    bucket,
+		"direction": aliasName(sURLs.SourceAlias) + "->" + aliasName(sURLs.TargetAlias),
+	}
+}
+
 const uaMirrorAppName = "mc-mirror"
 
 type mirrorJob struct {
@@ -368,6 +564,12 @@ func (mj *mirrorJob) doRemove(ctx context.Context, sURLs URLs, event EventInfo)
 		return sURLs.WithError(nil)
 	}
 
+	if mj.opts.schedule != nil {
+		if _, e := mj.opts.schedule.wait(ctx); e != nil {
+			return sURLs.WithError(probe.NewError(e))
+		}
+	}
+
 	// Construct proper path with alias.
 	targetWithAlias := filepath.Join(sURLs.TargetAlias, sURLs.TargetContent.URL.Path)
 	clnt, pErr := newClient(targetWithAlias)
@@ -515,16 +717,31 @@ func (mj *mirrorJob) doMirror(ctx context.Context, sURLs URLs, event EventInfo)
 	sURLs.checksum = mj.opts.checksum
 	sURLs.DisableMultipart = mj.opts.disableMultipart
 
+	var progress io.Reader = mj.status
+	if mj.opts.schedule != nil {
+		window, e := mj.opts.schedule.wait(ctx)
+		if e != nil {
+			return sURLs.WithError(probe.NewError(e))
+		}
+		progress = window.limitProgress(mj.status)
+	}
+
 	var ret URLs
 
+	mj.opts.shutdown.begin(sURLs)
+	defer func() {
+		mj.opts.shutdown.end(sURLs, ret.Error)
+	}()
+
 	if !mj.opts.isRetriable {
 		now := time.Now()
-		ret = uploadSourceToTargetURL(ctx, uploadSourceToTargetURLOpts{urls: sURLs, progress: mj.status, encKeyDB: mj.opts.encKeyDB, preserve: mj.opts.isMetadata, isZip: false})
+		ret = uploadSourceToTargetURL(ctx, uploadSourceToTargetURLOpts{urls: sURLs, progress: progress, encKeyDB: mj.opts.encKeyDB, preserve: mj.opts.isMetadata, preserveTags: mj.opts.preserveTags, preserveRetention: mj.opts.preserveRetention, isZip: false, stripMetadata: mj.opts.stripMetadata, attrTransform: mj.opts.attrTransform})
 		if ret.Error == nil {
 			durationMs := time.Since(now).Milliseconds()
 			mirrorReplicationDurations.With(prometheus.Labels{"object_size": convertSizeToTag(sURLs.SourceContent.Size)}).Observe(float64(durationMs))
 		}
 
+		ret = mj.addToManifest(ctx, ret)
 		return ret
 	}
 
@@ -538,7 +755,7 @@ func (mj *mirrorJob) doMirror(ctx context.Context, sURLs URLs, event EventInfo)
 		}
 
 		now := time.Now()
-		ret = uploadSourceToTargetURL(ctx, uploadSourceToTargetURLOpts{urls: sURLs, progress: mj.status, encKeyDB: mj.opts.encKeyDB, preserve: mj.opts.isMetadata, isZip: false})
+		ret = uploadSourceToTargetURL(ctx, uploadSourceToTargetURLOpts{urls: sURLs, progress: progress, encKeyDB: mj.opts.encKeyDB, preserve: mj.opts.isMetadata, preserveTags: mj.opts.preserveTags, preserveRetention: mj.opts.preserveRetention, isZip: false, stripMetadata: mj.opts.stripMetadata, attrTransform: mj.opts.attrTransform})
 		if ret.Error == nil {
 			durationMs := time.Since(now).Milliseconds()
 			mirrorReplicationDurations.With(prometheus.Labels{"object_size": convertSizeToTag(sURLs.SourceContent.Size)}).Observe(float64(durationMs))
@@ -547,9 +764,18 @@ func (mj *mirrorJob) doMirror(ctx context.Context, sURLs URLs, event EventInfo)
 		return ret.Error
 	})
 
+	ret = mj.addToManifest(ctx, ret)
 	return ret
 }
 
+// addToManifest records a mirrored object in the manifest of --manifest.
+func (mj *mirrorJob) addToManifest(ctx context.Context, ret URLs) URLs {
+	if mj.opts.manifest == nil || ret.Error != nil {
+		return ret
+	}
+	return ret.WithError(mj.opts.manifest.add(ctx, ret, mj.opts.encKeyDB))
+}
+
 // Update progress status
 func (mj *mirrorJob) monitorMirrorStatus(cancel context.CancelFunc) (errDuringMirror bool) {
 	// now we want to start the progress bar
@@ -575,6 +801,11 @@ func (mj *mirrorJob) monitorMirrorStatus(cancel context.CancelFunc) (errDuringMi
 
 		// Update prometheus fields
 		mirrorTotalOps.Inc()
+		var labels prometheus.Labels
+		if sURLs.SourceContent != nil || sURLs.TargetContent != nil {
+			labels = mirrorMetricLabels(sURLs)
+			mirrorBucketOps.With(labels).Inc()
+		}
 
 		if sURLs.Error != nil {
 			var ignoreErr bool
@@ -611,7 +842,12 @@ func (mj *mirrorJob) monitorMirrorStatus(cancel context.CancelFunc) (errDuringMi
 			}
 
 			if !ignoreErr {
+				mj.opts.journal.record(sURLs)
 				mirrorFailedOps.Inc()
+				if labels != nil {
+					mirrorBucketFailedOps.With(labels).Inc()
+				}
+				mj.opts.health.failure(sURLs.Error.ToGoError())
 				errDuringMirror = true
 				// Quit mirroring if --skip-errors is not passed
 				if !mj.opts.skipErrors {
@@ -626,6 +862,17 @@ func (mj *mirrorJob) monitorMirrorStatus(cancel context.CancelFunc) (errDuringMi
 		if sURLs.SourceContent != nil {
 			mirrorTotalUploadedBytes.Add(float64(sURLs.SourceContent.Size))
 		}
+		if labels != nil {
+			if sURLs.SourceContent != nil {
+				mirrorBucketUploadedBytes.With(labels).Add(float64(sURLs.SourceContent.Size))
+			}
+			mirrorLastSuccess.With(labels).SetToCurrentTime()
+			if c := sURLs.SourceContent; c != nil {
+				mj.opts.health.success(filepath.ToSlash(filepath.Join(sURLs.SourceAlias, c.URL.Path)))
+			} else {
+				mj.opts.health.success(filepath.ToSlash(filepath.Join(sURLs.TargetAlias, sURLs.TargetContent.URL.Path)))
+			}
+		}
 	}
 
 	return
@@ -683,7 +930,22 @@ func (mj *mirrorJob) watchMirrorEvents(ctx context.Context, events []EventInfo)
 			}
 		}
 
-		targetPath := urlJoinPath(mj.targetURL, sourceSuffix)
+		// Skip the object, if it is not selected by the regex and tag filters
+		if mj.opts.filter.excludedByName(sourceSuffix, sourceURL.Type) {
+			continue
+		}
+		if strings.HasPrefix(string(event.Type), "s3:ObjectCreated:") {
+			excluded, err := mj.opts.filter.excludedByObjectTags(ctx, sourceAlias, &ClientContent{URL: *sourceURL})
+			if err != nil {
+				errorIf(err, "Unable to get the tags of `%s`, skipping it.", sourceURL.String())
+				continue
+			}
+			if excluded {
+				continue
+			}
+		}
+
+		targetPath := urlJoinPath(mj.targetURL, normalizeKey(mj.opts.unicodeNormalize, sourceSuffix))
 
 		// newClient needs the unexpanded  path, newCLientURL needs the expanded path
 		targetAlias, expandedTargetPath, _ := mustExpandAlias(targetPath)
@@ -719,7 +981,7 @@ func (mj *mirrorJob) watchMirrorEvents(ctx context.Context, events []EventInfo)
 				// to avoid copying it.
 				continue
 			}
-			mj.parallel.queueTask(func() URLs {
+			mj.queueMirrorTask(mirrorURL, event, func() URLs {
 				return mj.doMirrorWatch(ctx, targetPath, tgtSSE, mirrorURL, event)
 			}, mirrorURL.SourceContent.Size)
 		} else if event.Type == notification.ObjectRemovedDelete {
@@ -740,7 +1002,7 @@ func (mj *mirrorJob) watchMirrorEvents(ctx context.Context, events []EventInfo)
 			mirrorURL.TotalCount = mj.status.GetCounts()
 			mirrorURL.TotalSize = mj.status.Get()
 			if mirrorURL.TargetContent != nil && (mj.opts.isRemove || mj.opts.activeActive) {
-				mj.parallel.queueTask(func() URLs {
+				mj.queueMirrorTask(mirrorURL, event, func() URLs {
 					return mj.doRemove(ctx, mirrorURL, event)
 				}, 0)
 			}
@@ -767,17 +1029,48 @@ func (mj *mirrorJob) watchMirrorEvents(ctx context.Context, events []EventInfo)
 	}
 }
 
+// queueMirrorTask queues a copy or a removal, keeping track of the
+// queue depth and, for watched events, of the time elapsed since the
+// event when the operation starts.
+func (mj *mirrorJob) queueMirrorTask(sURLs URLs, event EventInfo, fn func() URLs, uploadSize int64) {
+	labels := mirrorMetricLabels(sURLs)
+	mirrorQueueDepth.With(labels).Inc()
+	mj.opts.health.queue(1)
+	mj.parallel.queueTask(func() URLs {
+		defer mj.opts.health.queue(-1)
+		defer mirrorQueueDepth.With(labels).Dec()
+		if eventTime, e := time.Parse(time.RFC3339Nano, event.Time); e == nil {
+			mirrorWatchEventLag.With(labels).Set(time.Since(eventTime).Seconds())
+		}
+		return fn()
+	}, uploadSize)
+}
+
 // this goroutine will watch for notifications, and add modified objects to the queue
 func (mj *mirrorJob) watchMirror(ctx context.Context) {
 	defer mj.watcher.Stop()
 
+	// Fires when the next window of --schedule starts.
+	var scheduleTimer <-chan time.Time
+
 	for {
 		select {
 		case events, ok := <-mj.watcher.Events():
 			if !ok {
 				return
 			}
+			if schedule := mj.opts.schedule; schedule != nil && schedule.active() == nil {
+				if schedule.queue(events) {
+					next := schedule.nextStart()
+					scheduleTimer = time.After(time.Until(next))
+					mj.status.PrintMsg(mirrorScheduleMessage{Until: next})
+				}
+				continue
+			}
 			mj.watchMirrorEvents(ctx, events)
+		case <-scheduleTimer:
+			scheduleTimer = nil
+			mj.watchMirrorEvents(ctx, mj.opts.schedule.flush())
 		case err, ok := <-mj.watcher.Errors():
 			if !ok {
 				return
@@ -795,17 +1088,24 @@ func (mj *mirrorJob) watchMirror(ctx context.Context) {
 			}
 		case <-ctx.Done():
 			return
+		case <-mj.opts.shutdown.stopped():
+			return
 		}
 	}
 }
 
 func (mj *mirrorJob) watchURL(ctx context.Context, sourceClient Client) *probe.Error {
-	return mj.watcher.Join(ctx, sourceClient, true)
+	return mj.watcher.Join(ctx, sourceClient, true, mj.opts.eventSource)
 }
 
 // Fetch urls that need to be mirrored
 func (mj *mirrorJob) startMirror(ctx context.Context) {
-	URLsCh := prepareMirrorURLs(ctx, mj.sourceURL, mj.targetURL, mj.opts)
+	var URLsCh <-chan URLs
+	if mj.opts.retryRecords != nil {
+		URLsCh = journalMirrorURLs(ctx, mj.opts.retryRecords, mj.opts)
+	} else {
+		URLsCh = prepareMirrorURLs(ctx, mj.sourceURL, mj.targetURL, mj.opts)
+	}
 
 	for {
 		select {
@@ -840,11 +1140,15 @@ func (mj *mirrorJob) startMirror(ctx context.Context) {
 			sURLs.TotalSize = mj.status.Get()
 
 			if sURLs.SourceContent != nil {
-				mj.parallel.queueTask(func() URLs {
+				mj.queueMirrorTask(sURLs, EventInfo{}, func() URLs {
+					if mj.opts.shutdown.isStopping() {
+						mj.opts.shutdown.skip(sURLs)
+						return URLs{}
+					}
 					return mj.doMirror(ctx, sURLs, EventInfo{})
 				}, sURLs.SourceContent.Size)
 			} else if sURLs.TargetContent != nil && mj.opts.isRemove {
-				mj.parallel.queueTask(func() URLs {
+				mj.queueMirrorTask(sURLs, EventInfo{}, func() URLs {
 					return mj.doRemove(ctx, sURLs, EventInfo{})
 				}, 0)
 			}
@@ -852,6 +1156,8 @@ func (mj *mirrorJob) startMirror(ctx context.Context) {
 			return
 		case <-mj.stopCh:
 			return
+		case <-mj.opts.shutdown.stopped():
+			return
 		}
 	}
 }
@@ -966,7 +1272,7 @@ func getEventPathURLWin(srcURL, eventPath string) string {
 }
 
 // runMirror - mirrors all buckets to another S3 server
-func runMirror(ctx context.Context, srcURL, dstURL string, cli *cli.Context, encKeyDB map[string][]prefixSSEPair) bool {
+func runMirror(ctx context.Context, srcURL, dstURL string, cli *cli.Context, encKeyDB map[string][]prefixSSEPair, shutdown *mirrorShutdown, journal *mirrorJournal, retryRecords []mirrorJournalRecord, health *mirrorHealth, manifest *transferManifest) bool {
 	// Parse metadata.
 	userMetadata := make(map[string]string)
 	if cli.String("attr") != "" {
@@ -974,6 +1280,8 @@ func runMirror(ctx context.Context, srcURL, dstURL string, cli *cli.Context, enc
 		userMetadata, err = getMetaDataEntry(cli.String("attr"))
 		fatalIf(err, "Unable to parse attribute %v", cli.String("attr"))
 	}
+	attrTransform, e := parseMetadataTransform(cli.String("attr-transform"))
+	fatalIf(probe.NewError(e), "Unable to parse --attr-transform %v", cli.String("attr-transform"))
 
 	srcClt, err := newClient(srcURL)
 	fatalIf(err, "Unable to initialize `"+srcURL+"`.")
@@ -995,12 +1303,17 @@ func runMirror(ctx context.Context, srcURL, dstURL string, cli *cli.Context, enc
 	isMetadata := cli.Bool("a") || isWatch || len(userMetadata) > 0
 	isFake := cli.Bool("fake") || cli.Bool("dry-run")
 
+	filter, err := newMirrorFilter(cli.StringSlice("exclude-regex"), cli.StringSlice("include-regex"), cli.StringSlice("exclude-tag"))
+	fatalIf(err, "Invalid mirror filters.")
+
 	mopts := mirrorOptions{
 		isFake:                isFake,
 		isRemove:              isRemove,
 		isOverwrite:           isOverwrite,
 		isWatch:               isWatch,
 		isMetadata:            isMetadata,
+		preserveTags:          cli.Bool("preserve-tags"),
+		preserveRetention:     cli.Bool("preserve-retention"),
 		isSummary:             cli.Bool("summary"),
 		isRetriable:           cli.Bool("retry"),
 		md5:                   md5,
@@ -1014,8 +1327,26 @@ func runMirror(ctx context.Context, srcURL, dstURL string, cli *cli.Context, enc
 		newerThan:             cli.String("newer-than"),
 		storageClass:          cli.String("storage-class"),
 		userMetadata:          userMetadata,
+		stripMetadata:         parseStripMetadata(cli.String("strip-metadata")),
+		attrTransform:         attrTransform,
 		encKeyDB:              encKeyDB,
 		activeActive:          isWatch,
+		shutdown:              shutdown,
+		listWorkers:           cli.Int("list-workers"),
+		eventSource:           cli.String("event-source"),
+		compare:               cli.String("compare"),
+		unicodeNormalize:      cli.String("unicode-normalize"),
+		filter:                filter,
+		journal:               journal,
+		retryRecords:          retryRecords,
+		health:                health,
+		manifest:              manifest,
+	}
+
+	if values := cli.StringSlice("schedule"); len(values) > 0 {
+		schedule, e := parseMirrorSchedule(values)
+		fatalIf(probe.NewError(e), "Invalid `--schedule`.")
+		mopts.schedule = schedule
 	}
 
 	// If we are not using active/active and we are not removing
@@ -1049,7 +1380,7 @@ func runMirror(ctx context.Context, srcURL, dstURL string, cli *cli.Context, enc
 				diffBucket := strings.TrimPrefix(d.SecondURL, dstClt.GetURL().String())
 				if !isFake && isRemove {
 					aliasedDstBucket := path.Join(dstURL, diffBucket)
-					err := deleteBucket(ctx, aliasedDstBucket, false)
+					err := deleteBucket(ctx, aliasedDstBucket, false, nil)
 					mj.status.fatalIf(err, "Failed to start mirroring.")
 				}
 				continue
@@ -1146,6 +1477,11 @@ func mainMirror(cliCtx *cli.Context) error {
 	// check 'mirror' cli arguments.
 	srcURL, tgtURL := checkMirrorSyntax(ctx, cliCtx, encKeyDB)
 
+	if name := cliCtx.String("install-service"); name != "" {
+		installMirrorService(cliCtx, name, srcURL, tgtURL)
+		return nil
+	}
+
 	if prometheusAddress := cliCtx.String("monitoring-address"); prometheusAddress != "" {
 		http.Handle("/metrics", promhttp.Handler())
 		go func() {
@@ -1155,13 +1491,69 @@ func mainMirror(cliCtx *cli.Context) error {
 		}()
 	}
 
+	var health *mirrorHealth
+	if healthAddress := cliCtx.String("health-address"); healthAddress != "" {
+		health = newMirrorHealth(cliCtx.Duration("health-timeout"))
+		go func() {
+			if e := http.ListenAndServe(healthAddress, health); e != nil {
+				fatalIf(probe.NewError(e), "Unable to setup health endpoint.")
+			}
+		}()
+	}
+
+	shutdown := newMirrorShutdownFromContext(cliCtx, srcURL, tgtURL, cancelMirror)
+	var resumeStateFile string
+	if cliCtx.Bool("resume") {
+		resumeStateFile = resumeMirror(cliCtx, srcURL, tgtURL)
+	}
+
+	// The records to replay are loaded before the failure journal is opened,
+	// the journal is rewritten when both are the same file.
+	var retryRecords []mirrorJournalRecord
+	retryPath := cliCtx.String("retry-journal")
+	if retryPath != "" {
+		retryRecords, err = loadMirrorJournal(retryPath)
+		fatalIf(err.Trace(retryPath), "Unable to read the failure journal.")
+		if retryRecords == nil {
+			retryRecords = []mirrorJournalRecord{}
+		}
+	}
+	var journal *mirrorJournal
+	if journalPath := cliCtx.String("failure-journal"); journalPath != "" {
+		truncate := retryPath != "" && filepath.Clean(retryPath) == filepath.Clean(journalPath)
+		journal, err = openMirrorJournal(journalPath, truncate)
+		fatalIf(err.Trace(journalPath), "Unable to open the failure journal.")
+		defer func() {
+			errorIf(journal.close().Trace(journalPath), "Unable to close the failure journal.")
+		}()
+	}
+
+	var manifest *transferManifest
+	if manifestPath := cliCtx.String("manifest"); manifestPath != "" {
+		manifest, err = newTransferManifest(manifestPath, cliCtx.String("manifest-sign-key"), tgtURL)
+		fatalIf(err, "Unable to initialize the manifest.")
+		defer func() {
+			errorIf(manifest.close(), "Unable to write the manifest.")
+		}()
+	}
+
 	r := rand.New(rand.NewSource(time.Now().UnixNano()))
 	for {
 		select {
+		case <-shutdown.stopped():
+			return shutdown.saveState(srcURL, tgtURL)
 		case <-ctx.Done():
 			return exitStatus(globalErrorExitStatus)
 		default:
-			errorDetected := runMirror(ctx, srcURL, tgtURL, cliCtx, encKeyDB)
+			errorDetected := runMirror(ctx, srcURL, tgtURL, cliCtx, encKeyDB, shutdown, journal, retryRecords, health, manifest)
+			if shutdown.isStopping() {
+				return shutdown.saveState(srcURL, tgtURL)
+			}
+			if resumeStateFile != "" && !errorDetected {
+				e := os.Remove(resumeStateFile)
+				errorIf(probe.NewError(e).Trace(resumeStateFile), "Unable to remove the mirror resume state.")
+				resumeStateFile = ""
+			}
 			if cliCtx.Bool("watch") || cliCtx.Bool("multi-master") || cliCtx.Bool("active-active") {
 				mirrorRestarts.Inc()
 				time.Sleep(time.Duration(r.Float64() * float64(2*time.Second)))
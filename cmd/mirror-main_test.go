@@ -0,0 +1,54 @@
+// Copyright (c) 2015-2024 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+func TestMirrorMetricLabels(t *testing.T) {
+	s3URL := func(path string) *ClientContent {
+		return &ClientContent{URL: ClientURL{Type: objectStorage, Path: path, Separator: '/'}}
+	}
+	fsURL := &ClientContent{URL: ClientURL{Type: fileSystem, Path: "/data/photos/a.jpg", Separator: '/'}}
+	testCases := []struct {
+		sURLs URLs
+		want  prometheus.Labels
+	}{
+		{
+			URLs{SourceAlias: "play", SourceContent: s3URL("/photos/a.jpg"), TargetAlias: "backup", TargetContent: s3URL("/archive/a.jpg")},
+			prometheus.Labels{"bucket": "photos", "direction": "play->backup"},
+		},
+		{
+			URLs{SourceContent: fsURL, TargetAlias: "backup", TargetContent: s3URL("/archive/a.jpg")},
+			prometheus.Labels{"bucket": "archive", "direction": "local->backup"},
+		},
+		{
+			URLs{SourceAlias: "play", TargetAlias: "backup", TargetContent: s3URL("/archive/a.jpg")},
+			prometheus.Labels{"bucket": "archive", "direction": "play->backup"},
+		},
+	}
+	for i, tc := range testCases {
+		if got := mirrorMetricLabels(tc.sURLs); !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("test %d: expected %v, got %v", i+1, tc.want, got)
+		}
+	}
+}
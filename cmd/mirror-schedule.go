@@ -0,0 +1,244 @@
+// Copyright (c) 2015-2024 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dustin/go-humanize"
+	"github.com/juju/ratelimit"
+	json "github.com/minio/colorjson"
+	"github.com/minio/mc/pkg/probe"
+)
+
+// mirrorWindow is a daily time window of --schedule, transfers are only
+// performed inside of it, with an optional bandwidth limit.
+type mirrorWindow struct {
+	start, end time.Duration // Since midnight, in local time.
+	limit      uint64
+	bucket     *ratelimit.Bucket
+}
+
+// contains returns true if t, in local time, is inside the window. The
+// window wraps around midnight when it ends before it starts.
+func (w mirrorWindow) contains(t time.Time) bool {
+	d := sinceMidnight(t)
+	if w.start < w.end {
+		return d >= w.start && d < w.end
+	}
+	return d >= w.start || d < w.end
+}
+
+// nextStart returns the first start of the window after t.
+func (w mirrorWindow) nextStart(t time.Time) time.Time {
+	t = t.Local()
+	midnight := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+	start := midnight.Add(w.start)
+	if !start.After(t) {
+		start = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, t.Location()).Add(w.start)
+	}
+	return start
+}
+
+func (w mirrorWindow) String() string {
+	s := fmt.Sprintf("%02d:%02d-%02d:%02d", int(w.start.Hours()), int(w.start.Minutes())%60, int(w.end.Hours()), int(w.end.Minutes())%60)
+	if w.limit > 0 {
+		s += "@" + humanize.IBytes(w.limit)
+	}
+	return s
+}
+
+func sinceMidnight(t time.Time) time.Duration {
+	t = t.Local()
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute + time.Duration(t.Second())*time.Second
+}
+
+// parseTimeOfDay parses HH:MM into the duration since midnight.
+func parseTimeOfDay(s string) (time.Duration, error) {
+	t, e := time.Parse("15:04", s)
+	if e != nil {
+		return 0, fmt.Errorf("invalid time of day `%s`, expected HH:MM", s)
+	}
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, nil
+}
+
+// mirrorSchedule holds the windows of --schedule and the events received
+// while outside of all of them.
+type mirrorSchedule struct {
+	windows []mirrorWindow
+	now     func() time.Time
+
+	mu      sync.Mutex
+	pending []EventInfo
+	index   map[string]int
+}
+
+// parseMirrorSchedule parses windows of the form HH:MM-HH:MM with an
+// optional @RATE bandwidth limit, several windows may be separated by
+// commas.
+func parseMirrorSchedule(values []string) (*mirrorSchedule, error) {
+	s := &mirrorSchedule{now: time.Now, index: make(map[string]int)}
+	for _, value := range values {
+		for _, spec := range strings.Split(value, ",") {
+			spec = strings.TrimSpace(spec)
+			if spec == "" {
+				continue
+			}
+			var w mirrorWindow
+			span, limit, hasLimit := strings.Cut(spec, "@")
+			if hasLimit {
+				rate, e := humanize.ParseBytes(limit)
+				if e != nil || rate == 0 {
+					return nil, fmt.Errorf("invalid bandwidth limit `%s` in window `%s`", limit, spec)
+				}
+				w.limit = rate
+				w.bucket = ratelimit.NewBucketWithRate(float64(rate), int64(rate))
+			}
+			start, end, found := strings.Cut(span, "-")
+			if !found {
+				return nil, fmt.Errorf("invalid window `%s`, expected HH:MM-HH:MM", spec)
+			}
+			var e error
+			if w.start, e = parseTimeOfDay(start); e != nil {
+				return nil, e
+			}
+			if w.end, e = parseTimeOfDay(end); e != nil {
+				return nil, e
+			}
+			if w.start == w.end {
+				return nil, fmt.Errorf("window `%s` starts and ends at the same time", spec)
+			}
+			s.windows = append(s.windows, w)
+		}
+	}
+	if len(s.windows) == 0 {
+		return nil, fmt.Errorf("no window specified")
+	}
+	return s, nil
+}
+
+// active returns the window containing the current time, if any.
+func (s *mirrorSchedule) active() *mirrorWindow {
+	now := s.now()
+	for i := range s.windows {
+		if s.windows[i].contains(now) {
+			return &s.windows[i]
+		}
+	}
+	return nil
+}
+
+// nextStart returns when the next window starts.
+func (s *mirrorSchedule) nextStart() time.Time {
+	now := s.now()
+	var next time.Time
+	for _, w := range s.windows {
+		if start := w.nextStart(now); next.IsZero() || start.Before(next) {
+			next = start
+		}
+	}
+	return next
+}
+
+// wait blocks until the current time is inside of a window and returns it.
+func (s *mirrorSchedule) wait(ctx context.Context) (*mirrorWindow, error) {
+	for {
+		if w := s.active(); w != nil {
+			return w, nil
+		}
+		timer := time.NewTimer(time.Until(s.nextStart()))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// queue keeps events until the next window, only the latest event of
+// an object is kept. Returns true if the queue was empty.
+func (s *mirrorSchedule) queue(events []EventInfo) (wasEmpty bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	wasEmpty = len(s.pending) == 0
+	for _, event := range events {
+		if i, ok := s.index[event.Path]; ok {
+			s.pending[i] = event
+			continue
+		}
+		s.index[event.Path] = len(s.pending)
+		s.pending = append(s.pending, event)
+	}
+	return wasEmpty
+}
+
+// flush returns and clears the queued events.
+func (s *mirrorSchedule) flush() []EventInfo {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	events := s.pending
+	s.pending = nil
+	s.index = make(map[string]int)
+	return events
+}
+
+// limitProgress applies the bandwidth limit of the window to a transfer
+// by throttling its progress reader.
+func (w *mirrorWindow) limitProgress(progress io.Reader) io.Reader {
+	if w == nil || w.bucket == nil {
+		return progress
+	}
+	return &mirrorLimitedReader{Reader: progress, bucket: w.bucket}
+}
+
+type mirrorLimitedReader struct {
+	io.Reader
+	bucket *ratelimit.Bucket
+}
+
+func (r *mirrorLimitedReader) Read(p []byte) (int, error) {
+	n, e := r.Reader.Read(p)
+	if n > 0 {
+		r.bucket.Wait(int64(n))
+	}
+	return n, e
+}
+
+// mirrorScheduleMessage is printed when transfers are paused until the
+// next window of --schedule.
+type mirrorScheduleMessage struct {
+	Status string    `json:"status"`
+	Until  time.Time `json:"until"`
+}
+
+func (m mirrorScheduleMessage) String() string {
+	return fmt.Sprintf("Outside of the mirror schedule, queuing changes until %s.", m.Until.Format("2006-01-02 15:04"))
+}
+
+func (m mirrorScheduleMessage) JSON() string {
+	m.Status = "paused"
+	jsonMessageBytes, e := json.MarshalIndent(m, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+	return string(jsonMessageBytes)
+}
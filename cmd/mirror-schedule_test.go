@@ -0,0 +1,114 @@
+// Copyright (c) 2015-2024 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseMirrorSchedule(t *testing.T) {
+	testCases := []struct {
+		values   []string
+		expected string
+		success  bool
+	}{
+		{[]string{"22:00-06:00"}, "22:00-06:00", true},
+		{[]string{"22:00-06:00@50MiB, 12:00-13:30"}, "22:00-06:00@50 MiB 12:00-13:30", true},
+		{[]string{"09:00-10:00", "11:00-12:00@1MB"}, "09:00-10:00 11:00-12:00@977 KiB", true},
+		{[]string{"22:00"}, "", false},
+		{[]string{"25:00-06:00"}, "", false},
+		{[]string{"22:00-22:00"}, "", false},
+		{[]string{"22:00-06:00@fast"}, "", false},
+		{[]string{"22:00-06:00@0"}, "", false},
+		{[]string{""}, "", false},
+	}
+	for i, tc := range testCases {
+		s, err := parseMirrorSchedule(tc.values)
+		if (err == nil) != tc.success {
+			t.Fatalf("Test %d: expected success %v, got %v", i+1, tc.success, err)
+		}
+		if err != nil {
+			continue
+		}
+		var got string
+		for j, w := range s.windows {
+			if j > 0 {
+				got += " "
+			}
+			got += w.String()
+		}
+		if got != tc.expected {
+			t.Errorf("Test %d: expected %q, got %q", i+1, tc.expected, got)
+		}
+	}
+}
+
+func TestMirrorScheduleWindows(t *testing.T) {
+	s, err := parseMirrorSchedule([]string{"22:00-06:00", "12:00-13:00@1MiB"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	at := func(hour, minute int) time.Time {
+		return time.Date(2026, 3, 10, hour, minute, 0, 0, time.Local)
+	}
+	testCases := []struct {
+		now       time.Time
+		active    string
+		nextStart time.Time
+	}{
+		{at(23, 0), "22:00-06:00", at(24+12, 0)},
+		{at(5, 59), "22:00-06:00", at(12, 0)},
+		{at(6, 0), "", at(12, 0)},
+		{at(12, 30), "12:00-13:00@1.0 MiB", at(22, 0)},
+		{at(13, 0), "", at(22, 0)},
+	}
+	for i, tc := range testCases {
+		s.now = func() time.Time { return tc.now }
+		var active string
+		if w := s.active(); w != nil {
+			active = w.String()
+		}
+		if active != tc.active {
+			t.Errorf("Test %d: expected window %q, got %q", i+1, tc.active, active)
+		}
+		if next := s.nextStart(); !next.Equal(tc.nextStart) {
+			t.Errorf("Test %d: expected next start %v, got %v", i+1, tc.nextStart, next)
+		}
+	}
+}
+
+func TestMirrorScheduleQueue(t *testing.T) {
+	s, err := parseMirrorSchedule([]string{"22:00-06:00"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !s.queue([]EventInfo{{Path: "a", Type: "s3:ObjectCreated:Put"}, {Path: "b", Type: "s3:ObjectCreated:Put"}}) {
+		t.Error("expected the queue to be empty")
+	}
+	if s.queue([]EventInfo{{Path: "a", Type: "s3:ObjectRemoved:Delete"}}) {
+		t.Error("expected the queue not to be empty")
+	}
+	events := s.flush()
+	if len(events) != 2 || events[0].Path != "a" || events[0].Type != "s3:ObjectRemoved:Delete" || events[1].Path != "b" {
+		t.Errorf("unexpected queued events %+v", events)
+	}
+	if events = s.flush(); len(events) != 0 {
+		t.Errorf("expected an empty queue, got %+v", events)
+	}
+}
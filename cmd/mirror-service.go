@@ -0,0 +1,208 @@
+// Copyright (c) 2015-2024 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/minio/cli"
+	json "github.com/minio/colorjson"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/pkg/v3/console"
+)
+
+var validServiceName = regexp.MustCompile(`^[a-zA-Z0-9_.@-]+$`)
+
+// mirrorServiceMessage is printed once the systemd unit of a mirror is written.
+type mirrorServiceMessage struct {
+	Status   string `json:"status"`
+	Name     string `json:"name"`
+	UnitFile string `json:"unitFile"`
+	EnvFile  string `json:"envFile"`
+	User     bool   `json:"user"`
+}
+
+func (m mirrorServiceMessage) String() string {
+	systemctl := "systemctl"
+	if m.User {
+		systemctl += " --user"
+	}
+	return console.Colorize("Mirror", fmt.Sprintf("Installed service `%s` in `%s`, its environment is in `%s`.\n", m.Name, m.UnitFile, m.EnvFile)) +
+		fmt.Sprintf("Start it with: %s daemon-reload && %s enable --now %s", systemctl, systemctl, m.Name)
+}
+
+func (m mirrorServiceMessage) JSON() string {
+	m.Status = "success"
+	jsonMessageBytes, e := json.MarshalIndent(m, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+	return string(jsonMessageBytes)
+}
+
+// mirrorServiceArgs returns args without the --install-service flag.
+func mirrorServiceArgs(args []string) []string {
+	var serviceArgs []string
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		switch {
+		case arg == "--install-service" || arg == "-install-service":
+			i++
+		case strings.HasPrefix(arg, "--install-service=") || strings.HasPrefix(arg, "-install-service="):
+		default:
+			serviceArgs = append(serviceArgs, arg)
+		}
+	}
+	return serviceArgs
+}
+
+// systemdQuote quotes s for the command line of a systemd unit, specifiers
+// and variables are escaped so that s is passed as is.
+func systemdQuote(s string) string {
+	r := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "%", "%%", "$", "$$")
+	return `"` + r.Replace(s) + `"`
+}
+
+// envFileQuote quotes s for a systemd environment file.
+func envFileQuote(s string) string {
+	r := strings.NewReplacer(`\`, `\\`, `"`, `\"`)
+	return `"` + r.Replace(s) + `"`
+}
+
+// mirrorServiceUnit returns the systemd unit running the command args.
+func mirrorServiceUnit(description, envFile string, args []string, grace time.Duration, user bool) string {
+	quoted := make([]string, 0, len(args))
+	for _, arg := range args {
+		quoted = append(quoted, systemdQuote(arg))
+	}
+	wantedBy := "multi-user.target"
+	if user {
+		wantedBy = "default.target"
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "[Unit]\n")
+	fmt.Fprintf(&b, "Description=%s\n", strings.ReplaceAll(description, "%", "%%"))
+	fmt.Fprintf(&b, "Wants=network-online.target\n")
+	fmt.Fprintf(&b, "After=network-online.target\n\n")
+	fmt.Fprintf(&b, "[Service]\n")
+	fmt.Fprintf(&b, "Type=simple\n")
+	fmt.Fprintf(&b, "EnvironmentFile=%s\n", envFile)
+	fmt.Fprintf(&b, "ExecStart=%s\n", strings.Join(quoted, " "))
+	fmt.Fprintf(&b, "Restart=always\n")
+	fmt.Fprintf(&b, "RestartSec=10\n")
+	fmt.Fprintf(&b, "KillSignal=SIGTERM\n")
+	if grace > 0 {
+		// Leave the time for the graceful shutdown of the mirror.
+		fmt.Fprintf(&b, "TimeoutStopSec=%d\n", int64((grace + 30*time.Second).Seconds()))
+	}
+	fmt.Fprintf(&b, "\n[Install]\n")
+	fmt.Fprintf(&b, "WantedBy=%s\n", wantedBy)
+	return b.String()
+}
+
+// mirrorServiceEnv returns the environment file of the service, the mc
+// variables of the current environment are kept so that the service uses
+// the same aliases.
+func mirrorServiceEnv() string {
+	var lines []string
+	hasConfigDir := false
+	for _, kv := range os.Environ() {
+		key, value, ok := strings.Cut(kv, "=")
+		if !ok || !strings.HasPrefix(key, "MC_") {
+			continue
+		}
+		if key == "MC_CONFIG_DIR" {
+			hasConfigDir = true
+		}
+		lines = append(lines, key+"="+envFileQuote(value))
+	}
+	if !hasConfigDir {
+		lines = append(lines, "MC_CONFIG_DIR="+envFileQuote(mustGetMcConfigDir()))
+	}
+	sort.Strings(lines)
+	return strings.Join(lines, "\n") + "\n"
+}
+
+// systemdUnitDir returns the directory of the systemd units of the system
+// when running as root, of the user otherwise.
+func systemdUnitDir() (dir string, user bool, err *probe.Error) {
+	if os.Geteuid() == 0 {
+		return "/etc/systemd/system", false, nil
+	}
+	configDir := os.Getenv("XDG_CONFIG_HOME")
+	if configDir == "" {
+		homeDir, e := os.UserHomeDir()
+		if e != nil {
+			return "", true, probe.NewError(e)
+		}
+		configDir = filepath.Join(homeDir, ".config")
+	}
+	return filepath.Join(configDir, "systemd", "user"), true, nil
+}
+
+// installMirrorService writes a systemd unit running the current mirror
+// command, along with its environment file.
+func installMirrorService(cliCtx *cli.Context, name, srcURL, tgtURL string) {
+	if runtime.GOOS != "linux" {
+		fatalIf(errInvalidArgument().Trace(name), "--install-service is only supported on Linux with systemd.")
+	}
+	if !validServiceName.MatchString(name) {
+		fatalIf(errInvalidArgument().Trace(name), "Invalid service name `"+name+"`.")
+	}
+	if !(cliCtx.Bool("watch") || cliCtx.Bool("multi-master") || cliCtx.Bool("active-active")) {
+		fatalIf(errInvalidArgument().Trace(name), "--install-service requires --watch.")
+	}
+	name = strings.TrimSuffix(name, ".service")
+
+	exe, e := os.Executable()
+	fatalIf(probe.NewError(e), "Unable to find the path of mc.")
+	args := append([]string{exe}, mirrorServiceArgs(os.Args[1:])...)
+
+	unitDir, user, err := systemdUnitDir()
+	fatalIf(err, "Unable to find the directory of systemd units.")
+	unitFile := filepath.Join(unitDir, name+".service")
+	envFile := filepath.Join(mustGetMcConfigDir(), "services", name+".env")
+	if _, e := os.Stat(unitFile); e == nil {
+		fatalIf(errInvalidArgument().Trace(unitFile), "Service `"+name+"` already exists in `"+unitFile+"`.")
+	}
+
+	e = os.MkdirAll(filepath.Dir(envFile), 0o700)
+	fatalIf(probe.NewError(e).Trace(envFile), "Unable to create the environment file of the service.")
+	// The environment may have credentials.
+	e = os.WriteFile(envFile, []byte(mirrorServiceEnv()), 0o600)
+	fatalIf(probe.NewError(e).Trace(envFile), "Unable to write the environment file of the service.")
+
+	unit := mirrorServiceUnit("mc mirror "+srcURL+" to "+tgtURL, envFile, args, cliCtx.Duration("grace-period"), user)
+	e = os.MkdirAll(unitDir, 0o755)
+	fatalIf(probe.NewError(e).Trace(unitDir), "Unable to create the directory of systemd units.")
+	e = os.WriteFile(unitFile, []byte(unit), 0o644)
+	fatalIf(probe.NewError(e).Trace(unitFile), "Unable to write the systemd unit of the service.")
+
+	printMsg(mirrorServiceMessage{
+		Name:     name,
+		UnitFile: unitFile,
+		EnvFile:  envFile,
+		User:     user,
+	})
+}
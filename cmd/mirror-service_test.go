@@ -0,0 +1,53 @@
+// Copyright (c) 2015-2024 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestMirrorServiceArgs(t *testing.T) {
+	testCases := []struct {
+		args     []string
+		expected []string
+	}{
+		{[]string{"mirror", "--install-service", "photos", "--watch", "a", "b"}, []string{"mirror", "--watch", "a", "b"}},
+		{[]string{"mirror", "--watch", "--install-service=photos", "a", "b"}, []string{"mirror", "--watch", "a", "b"}},
+		{[]string{"mirror", "-install-service", "photos", "--watch", "a", "b"}, []string{"mirror", "--watch", "a", "b"}},
+		{[]string{"mirror", "--watch", "a", "b"}, []string{"mirror", "--watch", "a", "b"}},
+	}
+	for i, testCase := range testCases {
+		if args := mirrorServiceArgs(testCase.args); !reflect.DeepEqual(args, testCase.expected) {
+			t.Errorf("Test %d: expected %q, got %q", i+1, testCase.expected, args)
+		}
+	}
+}
+
+func TestSystemdQuote(t *testing.T) {
+	testCases := map[string]string{
+		"play/photos":   `"play/photos"`,
+		`C:\data "x"`:   `"C:\\data \"x\""`,
+		"50%-off/$HOME": `"50%%-off/$$HOME"`,
+	}
+	for s, expected := range testCases {
+		if quoted := systemdQuote(s); quoted != expected {
+			t.Errorf("%q: expected %s, got %s", s, expected, quoted)
+		}
+	}
+}
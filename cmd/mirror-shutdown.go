@@ -0,0 +1,316 @@
+// Copyright (c) 2015-2024 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	gojson "encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/minio/cli"
+	json "github.com/minio/colorjson"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/pkg/v3/console"
+)
+
+// Version of the mirror resume state file format.
+const mirrorStateVersion = "1"
+
+// mirrorStateObject is an object that was not mirrored before the shutdown.
+type mirrorStateObject struct {
+	Source string `json:"source"`
+	Target string `json:"target"`
+}
+
+// mirrorState is dumped when mirror is stopped by a signal, it records
+// what was done and what is left so that the mirror can be resumed.
+type mirrorState struct {
+	Version     string              `json:"version"`
+	Source      string              `json:"source"`
+	Target      string              `json:"target"`
+	Args        []string            `json:"args"`
+	Signal      string              `json:"signal"`
+	StartTime   time.Time           `json:"startTime"`
+	StopTime    time.Time           `json:"stopTime"`
+	GracePeriod string              `json:"gracePeriod"`
+	Drained     bool                `json:"drained"`
+	Mirrored    int64               `json:"mirrored"`
+	Bytes       int64               `json:"bytes"`
+	Interrupted []mirrorStateObject `json:"interrupted,omitempty"`
+	Pending     []mirrorStateObject `json:"pending,omitempty"`
+}
+
+// mirrorShutdownMessage is printed once mirror has stopped on a signal.
+type mirrorShutdownMessage struct {
+	Status      string `json:"status"`
+	Signal      string `json:"signal"`
+	StateFile   string `json:"stateFile"`
+	Drained     bool   `json:"drained"`
+	Interrupted int    `json:"interrupted"`
+	Pending     int    `json:"pending"`
+}
+
+func (m mirrorShutdownMessage) String() string {
+	msg := fmt.Sprintf("Mirror stopped on `%s`, resume state saved to `%s`.", m.Signal, m.StateFile)
+	if !m.Drained {
+		msg += fmt.Sprintf(" Grace period expired, %d transfer(s) were interrupted.", m.Interrupted)
+	}
+	return console.Colorize("Mirror", msg)
+}
+
+func (m mirrorShutdownMessage) JSON() string {
+	m.Status = "success"
+	b, e := json.MarshalIndent(m, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+	return string(b)
+}
+
+// mirrorShutdown stops a mirror gracefully: on a signal no new objects are
+// queued, in-flight transfers are given a grace period to complete before
+// being canceled and the progress is saved in a resume state file.
+type mirrorShutdown struct {
+	grace     time.Duration
+	stateFile string
+	cancel    context.CancelFunc
+
+	stopOnce sync.Once
+	stopCh   chan struct{}
+
+	mu        sync.Mutex
+	signal    string
+	startTime time.Time
+	timedOut  bool
+	mirrored  int64
+	bytes     int64
+	inflight  map[string]mirrorStateObject
+	pending   []mirrorStateObject
+}
+
+func newMirrorShutdown(grace time.Duration, stateFile string, cancel context.CancelFunc) *mirrorShutdown {
+	return &mirrorShutdown{
+		grace:     grace,
+		stateFile: stateFile,
+		cancel:    cancel,
+		stopCh:    make(chan struct{}),
+		startTime: time.Now(),
+		inflight:  make(map[string]mirrorStateObject),
+	}
+}
+
+// defaultMirrorStateFile returns the resume state file of a SOURCE/TARGET pair.
+func defaultMirrorStateFile(srcURL, tgtURL string) string {
+	sum := sha256.Sum256([]byte(srcURL + "\x00" + tgtURL))
+	return filepath.Join(mustGetMcConfigDir(), globalSessionDir, "mirror-"+hex.EncodeToString(sum[:8])+".json")
+}
+
+// trigger is registered as the shutdown handler, it starts draining
+// the mirror and cancels in-flight transfers once the grace period expires.
+func (s *mirrorShutdown) trigger(sig os.Signal) bool {
+	s.stopOnce.Do(func() {
+		s.mu.Lock()
+		s.signal = sig.String()
+		s.mu.Unlock()
+		close(s.stopCh)
+
+		if !globalQuiet && !globalJSON {
+			console.Infof("Received `%s`, waiting up to %s for in-flight transfers to complete.\n", sig, s.grace)
+		}
+
+		go func() {
+			time.Sleep(s.grace)
+			s.mu.Lock()
+			s.timedOut = true
+			s.mu.Unlock()
+			s.cancel()
+		}()
+	})
+	return true
+}
+
+// stopped returns a channel closed when shutdown was requested, nil if
+// graceful shutdown is disabled.
+func (s *mirrorShutdown) stopped() <-chan struct{} {
+	if s == nil {
+		return nil
+	}
+	return s.stopCh
+}
+
+// isStopping returns true once shutdown was requested.
+func (s *mirrorShutdown) isStopping() bool {
+	select {
+	case <-s.stopped():
+		return true
+	default:
+		return false
+	}
+}
+
+// skip records an object that was not started because of the shutdown.
+func (s *mirrorShutdown) skip(sURLs URLs) {
+	if s == nil || sURLs.SourceContent == nil || sURLs.TargetContent == nil {
+		return
+	}
+	s.mu.Lock()
+	s.pending = append(s.pending, mirrorStateObject{
+		Source: sURLs.SourceContent.URL.String(),
+		Target: sURLs.TargetContent.URL.String(),
+	})
+	s.mu.Unlock()
+}
+
+// begin records the start of a transfer.
+func (s *mirrorShutdown) begin(sURLs URLs) {
+	if s == nil {
+		return
+	}
+	src := sURLs.SourceContent.URL.String()
+	s.mu.Lock()
+	s.inflight[src] = mirrorStateObject{Source: src, Target: sURLs.TargetContent.URL.String()}
+	s.mu.Unlock()
+}
+
+// end records the end of a transfer, failed transfers remain in-flight
+// when the shutdown interrupted them.
+func (s *mirrorShutdown) end(sURLs URLs, err *probe.Error) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err != nil && s.timedOut {
+		return
+	}
+	delete(s.inflight, sURLs.SourceContent.URL.String())
+	if err == nil {
+		s.mirrored++
+		s.bytes += sURLs.SourceContent.Size
+	}
+}
+
+// saveState writes the resume state file and returns the exit status, a
+// distinct status is used when all in-flight transfers were drained.
+func (s *mirrorShutdown) saveState(srcURL, tgtURL string) error {
+	s.mu.Lock()
+	state := mirrorState{
+		Version:     mirrorStateVersion,
+		Source:      srcURL,
+		Target:      tgtURL,
+		Args:        os.Args[1:],
+		Signal:      s.signal,
+		StartTime:   s.startTime,
+		StopTime:    time.Now(),
+		GracePeriod: s.grace.String(),
+		Drained:     len(s.inflight) == 0,
+		Mirrored:    s.mirrored,
+		Bytes:       s.bytes,
+		Pending:     s.pending,
+	}
+	for _, obj := range s.inflight {
+		state.Interrupted = append(state.Interrupted, obj)
+	}
+	s.mu.Unlock()
+	sort.Slice(state.Interrupted, func(i, j int) bool { return state.Interrupted[i].Source < state.Interrupted[j].Source })
+
+	buf, e := gojson.MarshalIndent(state, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+	e = os.MkdirAll(filepath.Dir(s.stateFile), 0o700)
+	if e == nil {
+		e = os.WriteFile(s.stateFile, buf, 0o600)
+	}
+	fatalIf(probe.NewError(e).Trace(s.stateFile), "Unable to save mirror resume state.")
+
+	printMsg(mirrorShutdownMessage{
+		Signal:      state.Signal,
+		StateFile:   s.stateFile,
+		Drained:     state.Drained,
+		Interrupted: len(state.Interrupted),
+		Pending:     len(state.Pending),
+	})
+
+	if !state.Drained {
+		if state.Signal == os.Interrupt.String() {
+			return exitStatus(globalCancelExitStatus)
+		}
+		return exitStatus(globalTerminatExitStatus)
+	}
+	return exitStatus(globalGracefulExitStatus)
+}
+
+// loadMirrorState reads the resume state of a mirror of srcURL to tgtURL.
+func loadMirrorState(stateFile, srcURL, tgtURL string) (mirrorState, *probe.Error) {
+	var state mirrorState
+	buf, e := os.ReadFile(stateFile)
+	if e != nil {
+		return state, probe.NewError(e)
+	}
+	if e = gojson.Unmarshal(buf, &state); e != nil {
+		return state, probe.NewError(e)
+	}
+	if state.Version != mirrorStateVersion {
+		return state, probe.NewError(fmt.Errorf("unsupported resume state version '%s'", state.Version))
+	}
+	if state.Source != srcURL || state.Target != tgtURL {
+		return state, probe.NewError(fmt.Errorf("resume state of a mirror from `%s` to `%s`", state.Source, state.Target))
+	}
+	return state, nil
+}
+
+// resumeMirror loads the resume state of a stopped mirror, the mirror is
+// resumed by comparing source and target again: the objects mirrored
+// before the shutdown are skipped, the interrupted and pending ones are
+// mirrored. It returns the path of the state, removed once resumed.
+func resumeMirror(cliCtx *cli.Context, srcURL, tgtURL string) string {
+	stateFile := mirrorStateFileFromContext(cliCtx, srcURL, tgtURL)
+	state, err := loadMirrorState(stateFile, srcURL, tgtURL)
+	fatalIf(err.Trace(stateFile), "Unable to read the mirror resume state.")
+	if !globalQuiet && !globalJSON {
+		console.Infof("Resuming mirror stopped on `%s` at %s, %d object(s) were interrupted and %d pending.\n",
+			state.Signal, state.StopTime.Format(time.RFC3339), len(state.Interrupted), len(state.Pending))
+	}
+	return stateFile
+}
+
+// mirrorStateFileFromContext returns the path of the resume state of the mirror.
+func mirrorStateFileFromContext(cliCtx *cli.Context, srcURL, tgtURL string) string {
+	if stateFile := cliCtx.String("state-file"); stateFile != "" {
+		return stateFile
+	}
+	return defaultMirrorStateFile(srcURL, tgtURL)
+}
+
+// newMirrorShutdownFromContext enables graceful shutdown if requested on the command line.
+func newMirrorShutdownFromContext(cliCtx *cli.Context, srcURL, tgtURL string, cancel context.CancelFunc) *mirrorShutdown {
+	grace := cliCtx.Duration("grace-period")
+	if grace <= 0 {
+		if cliCtx.String("state-file") != "" && !cliCtx.Bool("resume") {
+			fatalIf(errInvalidArgument().Trace(cliCtx.String("state-file")), "--state-file requires --grace-period or --resume.")
+		}
+		return nil
+	}
+	s := newMirrorShutdown(grace, mirrorStateFileFromContext(cliCtx, srcURL, tgtURL), cancel)
+	setShutdownHandler(s.trigger)
+	return s
+}
@@ -0,0 +1,120 @@
+// Copyright (c) 2015-2024 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/minio/cli"
+	"github.com/minio/mc/pkg/probe"
+)
+
+func newMirrorShutdownTestURLs(name string, size int64) URLs {
+	return URLs{
+		SourceContent: &ClientContent{URL: *newClientURL("/src/" + name), Size: size},
+		TargetContent: &ClientContent{URL: *newClientURL("/dst/" + name)},
+	}
+}
+
+func mirrorShutdownExitCode(t *testing.T, e error) int {
+	t.Helper()
+	exitErr, ok := e.(*cli.ExitError)
+	if !ok {
+		t.Fatalf("expected an exit status, got %v", e)
+	}
+	return exitErr.ExitCode()
+}
+
+func TestMirrorShutdownDrained(t *testing.T) {
+	stateFile := filepath.Join(t.TempDir(), "state.json")
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	s := newMirrorShutdown(time.Hour, stateFile, cancel)
+
+	a, b, c := newMirrorShutdownTestURLs("a", 10), newMirrorShutdownTestURLs("b", 20), newMirrorShutdownTestURLs("c", 30)
+	s.begin(a)
+	s.begin(b)
+	if s.isStopping() {
+		t.Fatal("expected the mirror to run before the signal")
+	}
+	s.trigger(os.Interrupt)
+	if !s.isStopping() {
+		t.Fatal("expected the mirror to stop after the signal")
+	}
+	// In-flight transfers complete within the grace period, new ones are skipped.
+	s.end(a, nil)
+	s.end(b, nil)
+	s.skip(c)
+	if ctx.Err() != nil {
+		t.Fatal("expected in-flight transfers not to be canceled before the grace period")
+	}
+
+	if code := mirrorShutdownExitCode(t, s.saveState("/src", "/dst")); code != globalGracefulExitStatus {
+		t.Fatalf("expected exit status %d, got %d", globalGracefulExitStatus, code)
+	}
+	state, err := loadMirrorState(stateFile, "/src", "/dst")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !state.Drained || state.Mirrored != 2 || state.Bytes != 30 || len(state.Interrupted) != 0 {
+		t.Fatalf("unexpected state %+v", state)
+	}
+	if len(state.Pending) != 1 || state.Pending[0].Source != "/src/c" || state.Pending[0].Target != "/dst/c" {
+		t.Fatalf("expected /src/c to be pending, got %+v", state.Pending)
+	}
+	if _, err = loadMirrorState(stateFile, "/src", "/other"); err == nil {
+		t.Fatal("expected the state of another mirror to be rejected")
+	}
+}
+
+func TestMirrorShutdownGracePeriodExpired(t *testing.T) {
+	stateFile := filepath.Join(t.TempDir(), "state.json")
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	s := newMirrorShutdown(10*time.Millisecond, stateFile, cancel)
+
+	a, b := newMirrorShutdownTestURLs("a", 10), newMirrorShutdownTestURLs("b", 20)
+	s.begin(a)
+	s.begin(b)
+	s.trigger(syscall.SIGTERM)
+	s.end(a, nil)
+
+	select {
+	case <-ctx.Done():
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected in-flight transfers to be canceled once the grace period expired")
+	}
+	// The canceled transfer remains interrupted.
+	s.end(b, probe.NewError(context.Canceled))
+
+	if code := mirrorShutdownExitCode(t, s.saveState("/src", "/dst")); code != globalTerminatExitStatus {
+		t.Fatalf("expected exit status %d, got %d", globalTerminatExitStatus, code)
+	}
+	state, err := loadMirrorState(stateFile, "/src", "/dst")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if state.Drained || state.Mirrored != 1 || len(state.Interrupted) != 1 || state.Interrupted[0].Source != "/src/b" {
+		t.Fatalf("unexpected state %+v", state)
+	}
+}
@@ -26,6 +26,7 @@ import (
 	"time"
 
 	"github.com/minio/cli"
+	"github.com/minio/mc/pkg/probe"
 	"github.com/minio/minio-go/v7"
 	"github.com/minio/pkg/v3/wildcard"
 )
@@ -42,6 +43,63 @@ func checkMirrorSyntax(ctx context.Context, cliCtx *cli.Context, encKeyDB map[st
 	}
 	parseChecksum(cliCtx)
 
+	if source := cliCtx.String("event-source"); source != "" {
+		if !cliCtx.Bool("watch") && !cliCtx.Bool("active-active") && !cliCtx.Bool("multi-master") {
+			fatalIf(errInvalidArgument().Trace(source), "`--event-source` requires `--watch`.")
+		}
+		_, err := parseEventSource(source)
+		fatalIf(err, "Invalid event source.")
+	}
+
+	if mode := cliCtx.String("compare"); !isValidCompareMode(mode) {
+		fatalIf(errInvalidArgument().Trace(mode), "`--compare` must be either 'size' or 'checksum'.")
+	}
+
+	if form := cliCtx.String("unicode-normalize"); !isValidUnicodeNormalize(form) {
+		fatalIf(errInvalidArgument().Trace(form), "`--unicode-normalize` must be one of 'nfc', 'nfd' or 'none'.")
+	}
+
+	_, err := newMirrorFilter(cliCtx.StringSlice("exclude-regex"), cliCtx.StringSlice("include-regex"), cliCtx.StringSlice("exclude-tag"))
+	fatalIf(err, "Invalid `--exclude-regex`, `--include-regex` or `--exclude-tag`, tags are expected as 'key=value'.")
+
+	if values := cliCtx.StringSlice("schedule"); len(values) > 0 {
+		if !cliCtx.Bool("watch") && !cliCtx.Bool("active-active") && !cliCtx.Bool("multi-master") {
+			fatalIf(errInvalidArgument().Trace(values...), "`--schedule` requires `--watch`.")
+		}
+		_, e := parseMirrorSchedule(values)
+		fatalIf(probe.NewError(e).Trace(values...), "Invalid `--schedule`.")
+	}
+
+	if address := cliCtx.String("health-address"); address != "" {
+		if !cliCtx.Bool("watch") && !cliCtx.Bool("active-active") && !cliCtx.Bool("multi-master") {
+			fatalIf(errInvalidArgument().Trace(address), "`--health-address` requires `--watch`.")
+		}
+		if cliCtx.Duration("health-timeout") <= 0 {
+			fatalIf(errInvalidArgument().Trace(address), "`--health-timeout` must be positive.")
+		}
+	}
+
+	if journal := cliCtx.String("retry-journal"); journal != "" {
+		if cliCtx.Bool("watch") || cliCtx.Bool("active-active") || cliCtx.Bool("multi-master") {
+			fatalIf(errInvalidArgument().Trace(journal), "`--retry-journal` cannot be used with `--watch`.")
+		}
+	}
+
+	checkTransferManifestSyntax(cliCtx)
+	if manifest := cliCtx.String("manifest"); manifest != "" {
+		if cliCtx.Bool("watch") || cliCtx.Bool("active-active") || cliCtx.Bool("multi-master") {
+			fatalIf(errInvalidArgument().Trace(manifest), "`--manifest` cannot be used with `--watch`.")
+		}
+	}
+
+	if _, e := parseMetadataTransform(cliCtx.String("attr-transform")); e != nil {
+		fatalIf(probe.NewError(e).Trace(cliCtx.String("attr-transform")), "Invalid `--attr-transform`.")
+	}
+
+	if cliCtx.Int("list-workers") < 1 {
+		fatalIf(errInvalidArgument().Trace(cliCtx.Args()...), "`--list-workers` must be at least 1.")
+	}
+
 	// extract URLs.
 	URLs := cliCtx.Args()
 	srcURL = URLs[0]
@@ -167,7 +225,13 @@ func deltaSourceTarget(ctx context.Context, sourceURL, targetURL string, opts mi
 	}
 
 	// List both source and target, compare and return values through channel.
-	for diffMsg := range objectDifference(ctx, sourceClnt, targetClnt, opts) {
+	var diffCh chan diffMessage
+	if opts.listWorkers > 1 {
+		diffCh = parallelObjectDifference(ctx, sourceAlias, sourceURL, sourceClnt, targetAlias, targetURL, targetClnt, opts)
+	} else {
+		diffCh = objectDifference(ctx, sourceClnt, targetClnt, opts)
+	}
+	for diffMsg := range diffCh {
 		if diffMsg.Error != nil {
 			// Send all errors through the channel
 			URLsCh <- URLs{Error: diffMsg.Error, ErrorCond: differInUnknown}
@@ -196,6 +260,28 @@ func deltaSourceTarget(ctx context.Context, sourceURL, targetURL string, opts mi
 			continue
 		}
 
+		// Skip the objects not selected by the regex and tag filters
+		if diffMsg.FirstURL != "" && opts.filter.excludedByName(srcSuffix, newClientURL(sourceURL).Type) {
+			continue
+		}
+		if diffMsg.SecondURL != "" && opts.filter.excludedByName(tgtSuffix, newClientURL(targetURL).Type) {
+			continue
+		}
+		if opts.filter.hasTagFilters() {
+			alias, content := sourceAlias, diffMsg.firstContent
+			if content == nil {
+				alias, content = targetAlias, diffMsg.secondContent
+			}
+			excluded, err := opts.filter.excludedByObjectTags(ctx, alias, content)
+			if err != nil {
+				URLsCh <- URLs{Error: err, ErrorCond: differInUnknown}
+				continue
+			}
+			if excluded {
+				continue
+			}
+		}
+
 		if diffMsg.firstContent != nil {
 			var found bool
 			for _, esc := range opts.excludeStorageClasses {
@@ -214,7 +300,7 @@ func deltaSourceTarget(ctx context.Context, sourceURL, targetURL string, opts mi
 			// No difference, continue.
 		case differInType:
 			URLsCh <- URLs{Error: errInvalidTarget(diffMsg.SecondURL)}
-		case differInSize, differInMetadata, differInAASourceMTime:
+		case differInSize, differInMetadata, differInAASourceMTime, differInChecksum:
 			if !opts.isOverwrite && !opts.isFake && !opts.activeActive {
 				// Size or time or etag differs but --overwrite not set.
 				URLsCh <- URLs{
@@ -226,7 +312,7 @@ func deltaSourceTarget(ctx context.Context, sourceURL, targetURL string, opts mi
 
 			sourceSuffix := strings.TrimPrefix(diffMsg.FirstURL, sourceURL)
 			// Either available only in source or size differs and force is set
-			targetPath := urlJoinPath(targetURL, sourceSuffix)
+			targetPath := urlJoinPath(targetURL, normalizeKey(opts.unicodeNormalize, sourceSuffix))
 			sourceContent := diffMsg.firstContent
 			targetContent := &ClientContent{URL: *newClientURL(targetPath)}
 			URLsCh <- URLs{
@@ -234,11 +320,12 @@ func deltaSourceTarget(ctx context.Context, sourceURL, targetURL string, opts mi
 				SourceContent: sourceContent,
 				TargetAlias:   targetAlias,
 				TargetContent: targetContent,
+				overwritten:   diffMsg.secondContent,
 			}
 		case differInFirst:
 			// Only in first, always copy.
 			sourceSuffix := strings.TrimPrefix(diffMsg.FirstURL, sourceURL)
-			targetPath := urlJoinPath(targetURL, sourceSuffix)
+			targetPath := urlJoinPath(targetURL, normalizeKey(opts.unicodeNormalize, sourceSuffix))
 			sourceContent := diffMsg.firstContent
 			targetContent := &ClientContent{URL: *newClientURL(targetPath)}
 			URLsCh <- URLs{
@@ -267,6 +354,7 @@ func deltaSourceTarget(ctx context.Context, sourceURL, targetURL string, opts mi
 type mirrorOptions struct {
 	isFake, isOverwrite, activeActive                     bool
 	isWatch, isRemove, isMetadata                         bool
+	preserveTags, preserveRetention                       bool
 	isRetriable                                           bool
 	isSummary                                             bool
 	skipErrors                                            bool
@@ -276,8 +364,37 @@ type mirrorOptions struct {
 	olderThan, newerThan                                  string
 	storageClass                                          string
 	userMetadata                                          map[string]string
+	stripMetadata                                         []string
+	attrTransform                                         []metadataRule
 	checksum                                              minio.ChecksumType
 	sourceListingOnly                                     bool
+	listWorkers                                           int
+	eventSource                                           string
+	compare                                               string
+	unicodeNormalize                                      string
+	filter                                                *mirrorFilter
+	checksums                                             *checksumComparer
+	journal                                               *mirrorJournal
+	retryRecords                                          []mirrorJournalRecord
+	shutdown                                              *mirrorShutdown
+	schedule                                              *mirrorSchedule
+	health                                                *mirrorHealth
+	manifest                                              *transferManifest
+}
+
+// listWithMetadata returns true if the listings need the metadata and
+// the tags of the objects.
+func (opts mirrorOptions) listWithMetadata() bool {
+	return opts.isMetadata || opts.filter.hasTagFilters()
+}
+
+// checksumsEqual compares the checksums of src and tgt with --compare
+// checksum, ok is false if the checksums are not compared.
+func (opts mirrorOptions) checksumsEqual(src, tgt *ClientContent) (equal, ok bool) {
+	if opts.checksums == nil {
+		return false, false
+	}
+	return opts.checksums.equal(src, tgt)
 }
 
 // Prepares urls that need to be copied or removed based on requested options.
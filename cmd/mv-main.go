@@ -83,6 +83,7 @@ FLAGS:
 ENVIRONMENT VARIABLES:
   MC_ENC_KMS: KMS encryption key in the form of (alias/prefix=key).
   MC_ENC_S3: S3 encryption key in the form of (alias/prefix=key).
+  MC_ENC_CONTEXT: SSE-KMS encryption context in the form of (key=value).
 
 EXAMPLES:
   01. Move a list of objects from local file system to Amazon S3 cloud storage.
@@ -220,7 +221,7 @@ func mainMove(cliCtx *cli.Context) error {
 	encKeyDB, err := validateAndCreateEncryptionKeys(cliCtx)
 	fatalIf(err, "Unable to parse encryption keys.")
 
-	e := doCopySession(ctx, cancelMove, cliCtx, encKeyDB, true)
+	e := doCopySession(ctx, cancelMove, cliCtx, encKeyDB, true, nil)
 
 	console.Colorize("Copy", "Waiting for move operations to complete")
 	rmManager.close()
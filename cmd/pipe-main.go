@@ -22,6 +22,7 @@ import (
 	"io"
 	"os"
 	"runtime/debug"
+	"sync"
 	"syscall"
 
 	"github.com/dustin/go-humanize"
@@ -58,7 +59,7 @@ var pipeFlags = []cli.Flag{
 	cli.StringFlag{
 		Name:  "part-size",
 		Value: defaultPartSize(),
-		Usage: "customize chunk size for each concurrent upload",
+		Usage: "size of the uploaded parts, an object can have up to 10000 parts",
 	},
 	cli.IntFlag{
 		Name:   "pipe-max-size",
@@ -80,7 +81,7 @@ var pipeCmd = cli.Command{
   {{.HelpName}} - {{.Usage}}
 
 USAGE:
-  {{.HelpName}} [FLAGS] [TARGET]
+  {{.HelpName}} [FLAGS] [TARGET...]
 {{if .VisibleFlags}}
 FLAGS:
   {{range .VisibleFlags}}{{.}}
@@ -89,6 +90,7 @@ FLAGS:
 ENVIRONMENT VARIABLES:
   MC_ENC_KMS: KMS encryption key in the form of (alias/prefix=key).
   MC_ENC_S3: S3 encryption key in the form of (alias/prefix=key).
+  MC_ENC_CONTEXT: SSE-KMS encryption context in the form of (key=value).
 
 EXAMPLES:
   1. Write contents of stdin to a file on local filesystem.
@@ -114,6 +116,12 @@ EXAMPLES:
 
   8. Set tags to the uploaded objects
       {{.Prompt}} tar cvf - . | {{.HelpName}} --tags "category=prod&type=backup" play/mybucket/backup.tar
+
+  9. Stream a database dump to two sites at once, stdin is read only once.
+      {{.Prompt}} pg_dump accountsdb | {{.HelpName}} site1/backups/accountsdb.sql site2/backups/accountsdb.sql
+
+  10. Stream a database dump of up to 2.5TiB uploading 16 parts of 256MiB at the same time, using 4GiB of memory.
+      {{.Prompt}} pg_dump accountsdb | {{.HelpName}} --part-size 256MiB --concurrent 16 s3/sql-backups/accountsdb.sql
 `,
 }
 
@@ -138,26 +146,20 @@ func (p pipeMessage) JSON() string {
 	return string(pipeMessageBytes)
 }
 
-func pipe(ctx *cli.Context, targetURL string, encKeyDB map[string][]prefixSSEPair, meta map[string]string, quiet bool, json bool) *probe.Error {
+func pipe(ctx *cli.Context, targetURLs []string, encKeyDB map[string][]prefixSSEPair, meta map[string]string, quiet bool, json bool) *probe.Error {
 	// If possible increase the pipe buffer size
 	if e := increasePipeBufferSize(os.Stdin, ctx.Int("pipe-max-size")); e != nil {
 		fatalIf(probe.NewError(e), "Unable to increase custom pipe-max-size")
 	}
 
-	if targetURL == "" {
+	if len(targetURLs) == 0 {
 		// When no target is specified, pipe cat's stdin to stdout.
 		return catOut(os.Stdin, -1).Trace()
 	}
 	md5, checksum := parseChecksum(ctx)
 	storageClass := ctx.String("storage-class")
-	alias, _ := url2Alias(targetURL)
-	sseKey := getSSE(targetURL, encKeyDB[alias])
 
 	multipartThreads := ctx.Int("concurrent")
-	if multipartThreads > 1 {
-		// We will be allocating large buffers, reduce default GC overhead
-		debug.SetGCPercent(20)
-	}
 
 	var multipartSize uint64
 	var e error
@@ -168,18 +170,39 @@ func pipe(ctx *cli.Context, targetURL string, encKeyDB map[string][]prefixSSEPai
 		}
 	}
 
+	if multipartThreads > 1 {
+		// Every part being uploaded to every target is buffered,
+		// only upload as many parts as the memory can hold.
+		maxThreads := availableMemory() / (max(multipartSize, 1) * uint64(len(targetURLs)))
+		if uint64(multipartThreads) > maxThreads {
+			multipartThreads = int(max(maxThreads, 1))
+			if !json {
+				console.Infoln(fmt.Sprintf("Uploading %d parts at the same time, the buffered parts have to fit in the available memory.", multipartThreads))
+			}
+		}
+		// We will be allocating large buffers, reduce default GC overhead
+		debug.SetGCPercent(20)
+	}
+
 	// Stream from stdin to multiple objects until EOF.
 	// Ignore size, since os.Stat() would not return proper size all the time
 	// for local filesystem for example /proc files.
-	opts := PutOptions{
-		sse:              sseKey,
-		storageClass:     storageClass,
-		metadata:         meta,
-		multipartSize:    multipartSize,
-		multipartThreads: uint(multipartThreads),
-		concurrentStream: ctx.IsSet("concurrent"),
-		md5:              md5,
-		checksum:         checksum,
+	putOpts := func(targetURL string) PutOptions {
+		alias, _ := url2Alias(targetURL)
+		metadata := make(map[string]string, len(meta))
+		for k, v := range meta {
+			metadata[k] = v
+		}
+		return PutOptions{
+			sse:              getSSE(targetURL, encKeyDB[alias]),
+			storageClass:     storageClass,
+			metadata:         metadata,
+			multipartSize:    multipartSize,
+			multipartThreads: uint(multipartThreads),
+			concurrentStream: ctx.IsSet("concurrent"),
+			md5:              md5,
+			checksum:         checksum,
+		}
 	}
 
 	var reader io.Reader
@@ -190,7 +213,12 @@ func pipe(ctx *cli.Context, targetURL string, encKeyDB map[string][]prefixSSEPai
 		reader = os.Stdin
 	}
 
-	n, err := putTargetStreamWithURL(targetURL, reader, -1, opts)
+	if len(targetURLs) > 1 {
+		return pipeFanOut(reader, -1, targetURLs, putOpts)
+	}
+
+	targetURL := targetURLs[0]
+	n, err := putTargetStreamWithURL(targetURL, reader, -1, putOpts(targetURL))
 	// TODO: See if this check is necessary.
 	switch e := err.ToGoError().(type) {
 	case *os.PathError:
@@ -207,11 +235,103 @@ func pipe(ctx *cli.Context, targetURL string, encKeyDB map[string][]prefixSSEPai
 	return err.Trace(targetURL)
 }
 
+// fanOutWriter writes to all the uploads of a fan-out, a failed upload
+// is dropped without interrupting the others.
+type fanOutWriter struct {
+	writers []*io.PipeWriter
+	failed  []bool
+}
+
+func (f *fanOutWriter) Write(p []byte) (int, error) {
+	alive := 0
+	for i, w := range f.writers {
+		if f.failed[i] {
+			continue
+		}
+		if _, e := w.Write(p); e != nil {
+			f.failed[i] = true
+			continue
+		}
+		alive++
+	}
+	if alive == 0 {
+		return 0, io.ErrClosedPipe
+	}
+	return len(p), nil
+}
+
+// pipeFanOut reads reader once and uploads it to all targetURLs at the
+// same time, the slowest upload sets the pace of all of them. size is -1
+// when unknown.
+func pipeFanOut(reader io.Reader, size int64, targetURLs []string, putOpts func(string) PutOptions) *probe.Error {
+	fw := &fanOutWriter{failed: make([]bool, len(targetURLs))}
+	errs := make([]*probe.Error, len(targetURLs))
+
+	var wg sync.WaitGroup
+	for i, targetURL := range targetURLs {
+		pr, pw := io.Pipe()
+		fw.writers = append(fw.writers, pw)
+		wg.Add(1)
+		go func(i int, targetURL string) {
+			defer wg.Done()
+			n, err := putTargetStreamWithURL(targetURL, pr, size, putOpts(targetURL))
+			if err != nil {
+				// Unblock the writes of this target.
+				pr.CloseWithError(err.ToGoError())
+				errs[i] = err.Trace(targetURL)
+				return
+			}
+			printMsg(pipeMessage{
+				Target: targetURL,
+				Size:   n,
+			})
+		}(i, targetURL)
+	}
+
+	_, e := io.Copy(fw, reader)
+	if pathErr, ok := e.(*os.PathError); ok && pathErr.Err == syscall.EPIPE {
+		// stdin closed by the user, uploads are completed with what was read.
+		e = nil
+	}
+	for _, pw := range fw.writers {
+		if e != nil && e != io.ErrClosedPipe {
+			pw.CloseWithError(e)
+		} else {
+			pw.Close()
+		}
+	}
+	wg.Wait()
+
+	var lastErr *probe.Error
+	for i, err := range errs {
+		if err != nil {
+			errorIf(err, "Unable to write to `%s`.", targetURLs[i])
+			lastErr = err
+		}
+	}
+	if lastErr == nil && e != nil {
+		return probe.NewError(e)
+	}
+	return lastErr
+}
+
 // checkPipeSyntax - validate arguments passed by user
 func checkPipeSyntax(ctx *cli.Context) {
-	if len(ctx.Args()) != 1 {
+	if len(ctx.Args()) < 1 {
 		showCommandHelpAndExit(ctx, 1) // last argument is exit code.
 	}
+
+	if partSizeStr := ctx.String("part-size"); partSizeStr != "" {
+		partSize, e := humanize.ParseBytes(partSizeStr)
+		fatalIf(probe.NewError(e).Trace(partSizeStr), "Unable to parse --part-size.")
+		if partSize < 5*humanize.MiByte || partSize > 5*humanize.GiByte {
+			fatalIf(errInvalidArgument().Trace(partSizeStr), "--part-size must be between 5MiB and 5GiB.")
+		}
+	}
+
+	if ctx.IsSet("concurrent") && ctx.Int("concurrent") < 1 {
+		fatalIf(errInvalidArgument().Trace(ctx.Args()...), "--concurrent must be at least 1.")
+	}
 }
 
 // mainPipe is the main entry point for pipe command.
@@ -235,15 +355,10 @@ func mainPipe(ctx *cli.Context) error {
 	if tags := ctx.String("tags"); tags != "" {
 		meta["X-Amz-Tagging"] = tags
 	}
-	if len(ctx.Args()) == 0 {
-		err = pipe(ctx, "", nil, meta, quiet, json)
-		fatalIf(err.Trace("stdout"), "Unable to write to one or more targets.")
-	} else {
-		// extract URLs.
-		URLs := ctx.Args()
-		err = pipe(ctx, URLs[0], encKeyDB, meta, quiet, json)
-		fatalIf(err.Trace(URLs[0]), "Unable to write to one or more targets.")
-	}
+	// extract URLs.
+	URLs := ctx.Args()
+	err = pipe(ctx, URLs, encKeyDB, meta, quiet, json)
+	fatalIf(err.Trace(URLs...), "Unable to write to one or more targets.")
 
 	// Done.
 	return nil
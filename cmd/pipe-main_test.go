@@ -0,0 +1,59 @@
+// Copyright (c) 2015-2024 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestFanOutWriter(t *testing.T) {
+	okReader, okWriter := io.Pipe()
+	failedReader, failedWriter := io.Pipe()
+	failedReader.CloseWithError(errors.New("upload failed"))
+
+	fw := &fanOutWriter{
+		writers: []*io.PipeWriter{okWriter, failedWriter},
+		failed:  make([]bool, 2),
+	}
+	done := make(chan string)
+	go func() {
+		b, _ := io.ReadAll(okReader)
+		done <- string(b)
+	}()
+
+	input := strings.Repeat("data", 1000)
+	if _, e := io.Copy(fw, strings.NewReader(input)); e != nil {
+		t.Fatal(e)
+	}
+	okWriter.Close()
+	if got := <-done; got != input {
+		t.Fatalf("expected %d bytes, got %d", len(input), len(got))
+	}
+	if fw.failed[0] || !fw.failed[1] {
+		t.Fatalf("unexpected failed writers %v", fw.failed)
+	}
+
+	// Writes fail once all uploads failed.
+	okReader.CloseWithError(errors.New("upload failed"))
+	if _, e := fw.Write([]byte("more")); e == nil {
+		t.Fatal("expected an error when all uploads failed")
+	}
+}
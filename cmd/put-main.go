@@ -75,6 +75,7 @@ FLAGS:
 ENVIRONMENT VARIABLES:
   MC_ENC_KMS: KMS encryption key in the form of (alias/prefix=key).
   MC_ENC_S3: S3 encryption key in the form of (alias/prefix=key).
+  MC_ENC_CONTEXT: SSE-KMS encryption context in the form of (key=value).
 
 EXAMPLES:
   1. Put an object from local file system to S3 storage
@@ -91,6 +92,9 @@ EXAMPLES:
 
   5. Put an object to MinIO storage using sse-kms encryption
      {{.Prompt}} {{.HelpName}} --enc-kms path-to/object play/mybucket/object 
+
+  6. Put an object to MinIO storage using sse-c encryption, reading the key from a file only readable by its owner
+     {{.Prompt}} {{.HelpName}} --enc-c-file "play/mybucket/object=$HOME/.mc/keys/object.key" path-to/object play/mybucket/object
 `,
 }
 
@@ -22,6 +22,7 @@ import (
 	"fmt"
 	"path"
 	"path/filepath"
+	"slices"
 	"strings"
 
 	"github.com/fatih/color"
@@ -41,6 +42,10 @@ var rbFlags = []cli.Flag{
 		Name:  "dangerous",
 		Usage: "allow site-wide removal of objects",
 	},
+	cli.BoolFlag{
+		Name:  "ignore-config",
+		Usage: "remove buckets with replication, lifecycle or object lock configurations",
+	},
 }
 
 // remove a bucket.
@@ -60,6 +65,11 @@ USAGE:
 FLAGS:
   {{range .VisibleFlags}}{{.}}
   {{end}}{{end}}
+PROGRESS:
+  While the objects of a bucket are removed with '--force', the number of objects removed, the rate
+  and the elapsed time are displayed. The remaining time is estimated from the data usage reported
+  by MinIO servers. A summary is printed once the bucket is removed.
+
 EXAMPLES:
   1. Remove an empty bucket on Amazon S3 cloud storage
      {{.Prompt}} {{.HelpName}} s3/mybucket
@@ -72,6 +82,9 @@ EXAMPLES:
 
   4. Remove all buckets and objects recursively from S3 host
      {{.Prompt}} {{.HelpName}} --force --dangerous s3
+
+  5. Remove bucket 'jazz-songs' and all its contents along with its replication and lifecycle configurations.
+     {{.Prompt}} {{.HelpName}} --force --ignore-config s3/jazz-songs
 `,
 }
 
@@ -150,7 +163,8 @@ func listBucketsURLs(ctx context.Context, url string) ([]string, *probe.Error) {
 }
 
 // Delete a bucket and all its objects and versions will be removed as well.
-func deleteBucket(ctx context.Context, url string, isForce bool) *probe.Error {
+// The removed objects are counted by progress when it is not nil.
+func deleteBucket(ctx context.Context, url string, isForce bool, progress *rbProgress) *probe.Error {
 	targetAlias, targetURL, _ := mustExpandAlias(url)
 	clnt, pErr := newClientFromAlias(targetAlias, targetURL)
 	if pErr != nil {
@@ -198,6 +212,9 @@ func deleteBucket(ctx context.Context, url string, isForce bool) *probe.Error {
 		if result.Err != nil {
 			return result.Err.Trace(url)
 		}
+		if progress != nil {
+			progress.add()
+		}
 	}
 	// Return early if prefix delete
 	switch c := clnt.(type) {
@@ -222,6 +239,71 @@ func deleteBucket(ctx context.Context, url string, isForce bool) *probe.Error {
 	return err
 }
 
+// Error codes meaning a bucket has no such configuration.
+var bucketConfigNotFoundCodes = []string{
+	"ReplicationConfigurationNotFoundError",
+	"NoSuchLifecycleConfiguration",
+	"ObjectLockConfigurationNotFoundError",
+}
+
+// isBucketConfigNotFound returns true if err means the requested bucket
+// configuration is not set.
+func isBucketConfigNotFound(err *probe.Error) bool {
+	return slices.Contains(bucketConfigNotFoundCodes, minio.ToErrorResponse(err.ToGoError()).Code)
+}
+
+// bucketConfigs returns the replication, lifecycle and object lock
+// configurations of the bucket of clnt which are removed with it. Any
+// error other than a missing configuration is returned, the bucket may
+// have configurations that could not be read.
+func bucketConfigs(ctx context.Context, clnt Client) (configs []string, err *probe.Error) {
+	s3Clnt, ok := clnt.(*S3Client)
+	if !ok {
+		return nil, nil
+	}
+	if bucket, object := s3Clnt.url2BucketAndObject(); bucket == "" || object != "" {
+		return nil, nil
+	}
+
+	replicationCfg, err := s3Clnt.GetReplication(ctx)
+	switch {
+	case err == nil:
+		if len(replicationCfg.Rules) > 0 {
+			configs = append(configs, "replication "+rulesCount(len(replicationCfg.Rules)))
+		}
+	case !isBucketConfigNotFound(err):
+		return nil, err.Trace("replication")
+	}
+
+	lifecycleCfg, _, err := s3Clnt.GetLifecycle(ctx)
+	switch {
+	case err == nil:
+		if lifecycleCfg != nil && len(lifecycleCfg.Rules) > 0 {
+			configs = append(configs, "lifecycle "+rulesCount(len(lifecycleCfg.Rules)))
+		}
+	case !isBucketConfigNotFound(err):
+		return nil, err.Trace("lifecycle")
+	}
+
+	status, _, _, _, err := s3Clnt.GetObjectLockConfig(ctx)
+	switch {
+	case err == nil:
+		if status == "Enabled" {
+			configs = append(configs, "object lock")
+		}
+	case !isBucketConfigNotFound(err):
+		return nil, err.Trace("object lock")
+	}
+	return configs, nil
+}
+
+func rulesCount(n int) string {
+	if n == 1 {
+		return "(1 rule)"
+	}
+	return fmt.Sprintf("(%d rules)", n)
+}
+
 // isS3NamespaceRemoval returns true if alias
 // is not qualified by bucket
 func isS3NamespaceRemoval(url string) bool {
@@ -241,9 +323,12 @@ func mainRemoveBucket(cliCtx *cli.Context) error {
 	// check 'rb' cli arguments.
 	checkRbSyntax(cliCtx)
 	isForce := cliCtx.Bool("force")
+	ignoreConfig := cliCtx.Bool("ignore-config")
 
 	// Additional command specific theme customization.
 	console.SetColor("RemoveBucket", color.New(color.FgGreen, color.Bold))
+	console.SetColor("RemoveBucketProgress", color.New(color.FgCyan))
+	console.SetColor("RemoveBucketSummary", color.New(color.FgGreen))
 
 	var cErr error
 	for _, targetURL := range cliCtx.Args() {
@@ -304,8 +389,33 @@ func mainRemoveBucket(cliCtx *cli.Context) error {
 			bucketsURL = []string{targetURL}
 		}
 
+		// Refuse to remove any bucket before all of them are checked.
+		if !ignoreConfig {
+			for _, bucketURL := range bucketsURL {
+				bucketClnt, err := newClient(bucketURL)
+				fatalIf(err.Trace(bucketURL), "Invalid target `%s`.", bucketURL)
+				configs, err := bucketConfigs(ctx, bucketClnt)
+				fatalIf(err.Trace(bucketURL), "Unable to check the configurations of `"+bucketURL+
+					"`. Retry this command with ‘--ignore-config’ flag if you want to remove `"+bucketURL+"` without checking them")
+				if len(configs) > 0 {
+					fatalIf(errDummy().Trace(bucketURL), "`"+bucketURL+"` has "+strings.Join(configs, ", ")+
+						" configured. Retry this command with ‘--ignore-config’ flag if you want to remove `"+bucketURL+"` and its configurations")
+				}
+			}
+		}
+
 		for _, bucketURL := range bucketsURL {
-			e := deleteBucket(ctx, bucketURL, isForce)
+			var progress *rbProgress
+			if !isEmpty {
+				progress = newRbProgress(bucketURL, estimateBucketObjects(ctx, bucketURL))
+			}
+			e := deleteBucket(ctx, bucketURL, isForce, progress)
+			if progress != nil {
+				summary := progress.finish()
+				if e == nil {
+					printMsg(summary)
+				}
+			}
 			fatalIf(e.Trace(bucketURL), "Failed to remove `"+bucketURL+"`.")
 
 			printMsg(removeBucketMessage{
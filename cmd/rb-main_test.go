@@ -0,0 +1,118 @@
+// Copyright (c) 2015-2024 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+// bucketConfigResponse is the answer of a test server to a bucket
+// configuration request, an error code if code is set.
+type bucketConfigResponse struct {
+	code   string
+	status int
+	body   string
+}
+
+func (r bucketConfigResponse) write(w http.ResponseWriter) {
+	w.Header().Set("Content-Type", "application/xml")
+	if r.code != "" {
+		w.WriteHeader(r.status)
+		fmt.Fprintf(w, "<Error><Code>%s</Code><Message>%s</Message></Error>", r.code, r.code)
+		return
+	}
+	io.WriteString(w, r.body)
+}
+
+func TestBucketConfigs(t *testing.T) {
+	var (
+		noReplication = bucketConfigResponse{code: "ReplicationConfigurationNotFoundError", status: http.StatusNotFound}
+		noLifecycle   = bucketConfigResponse{code: "NoSuchLifecycleConfiguration", status: http.StatusNotFound}
+		noObjectLock  = bucketConfigResponse{code: "ObjectLockConfigurationNotFoundError", status: http.StatusNotFound}
+		accessDenied  = bucketConfigResponse{code: "AccessDenied", status: http.StatusForbidden}
+
+		replication = bucketConfigResponse{body: `<ReplicationConfiguration><Role></Role><Rule><ID>r1</ID><Status>Enabled</Status><Priority>1</Priority><DeleteMarkerReplication><Status>Disabled</Status></DeleteMarkerReplication><Filter><Prefix></Prefix></Filter><Destination><Bucket>arn:minio:replication::id:target</Bucket></Destination></Rule></ReplicationConfiguration>`}
+		lifecycle   = bucketConfigResponse{body: `<LifecycleConfiguration><Rule><ID>l1</ID><Status>Enabled</Status><Filter><Prefix></Prefix></Filter><Expiration><Days>1</Days></Expiration></Rule><Rule><ID>l2</ID><Status>Enabled</Status><Filter><Prefix>tmp/</Prefix></Filter><Expiration><Days>2</Days></Expiration></Rule></LifecycleConfiguration>`}
+		objectLock  = bucketConfigResponse{body: `<ObjectLockConfiguration><ObjectLockEnabled>Enabled</ObjectLockEnabled></ObjectLockConfiguration>`}
+	)
+
+	testCases := []struct {
+		replication, lifecycle, objectLock bucketConfigResponse
+		expected                           []string
+		shouldFail                         bool
+	}{
+		// No configuration.
+		{noReplication, noLifecycle, noObjectLock, nil, false},
+		// All configurations.
+		{replication, lifecycle, objectLock, []string{"replication (1 rule)", "lifecycle (2 rules)", "object lock"}, false},
+		{noReplication, lifecycle, noObjectLock, []string{"lifecycle (2 rules)"}, false},
+		// Configurations that cannot be read.
+		{accessDenied, noLifecycle, noObjectLock, nil, true},
+		{noReplication, accessDenied, noObjectLock, nil, true},
+		{replication, noLifecycle, accessDenied, nil, true},
+	}
+
+	for i, testCase := range testCases {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			query := r.URL.Query()
+			switch {
+			case query.Has("location"):
+				bucketConfigResponse{body: `<LocationConstraint>us-east-1</LocationConstraint>`}.write(w)
+			case query.Has("replication"):
+				testCase.replication.write(w)
+			case query.Has("lifecycle"):
+				testCase.lifecycle.write(w)
+			case query.Has("object-lock"):
+				testCase.objectLock.write(w)
+			default:
+				w.WriteHeader(http.StatusNotImplemented)
+			}
+		}))
+
+		conf := new(Config)
+		conf.HostURL = server.URL + "/bucket"
+		conf.AccessKey = "WLGDGYAQYIGI833EV05A"
+		conf.SecretKey = "BYvgJM101sHngl2uzjXS/OBF/aMxAN06JrJ3qJlF"
+		conf.Signature = "S3v4"
+		clnt, err := S3New(conf)
+		if err != nil {
+			t.Fatalf("Test %d: unable to create client %v", i+1, err)
+		}
+
+		configs, err := bucketConfigs(context.Background(), clnt)
+		server.Close()
+		if testCase.shouldFail {
+			if err == nil {
+				t.Errorf("Test %d: expected an error, got configs %v", i+1, configs)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("Test %d: unexpected error %v", i+1, err)
+		}
+		if !reflect.DeepEqual(configs, testCase.expected) {
+			t.Errorf("Test %d: expected %v, got %v", i+1, testCase.expected, configs)
+		}
+	}
+}
@@ -0,0 +1,173 @@
+// Copyright (c) 2015-2024 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/dustin/go-humanize"
+	json "github.com/minio/colorjson"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/pkg/v3/console"
+)
+
+// Interval between two refreshes of the rb --force progress line.
+const rbProgressInterval = time.Second
+
+// removeBucketSummaryMessage is the summary of the objects removed with
+// a bucket.
+type removeBucketSummaryMessage struct {
+	Status      string        `json:"status"`
+	Bucket      string        `json:"bucket"`
+	Objects     uint64        `json:"objects"`
+	Elapsed     time.Duration `json:"elapsed"`
+	ObjectsRate float64       `json:"objectsPerSecond"`
+}
+
+// String colorized remove bucket summary message.
+func (s removeBucketSummaryMessage) String() string {
+	return console.Colorize("RemoveBucketSummary", fmt.Sprintf("Removed %s objects and versions of `%s` in %s (%s objects/s).",
+		humanize.Comma(int64(s.Objects)), s.Bucket, s.Elapsed.Round(time.Second), humanize.Comma(int64(s.ObjectsRate))))
+}
+
+// JSON jsonified remove bucket summary message.
+func (s removeBucketSummaryMessage) JSON() string {
+	summaryJSONBytes, e := json.Marshal(s)
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+
+	return string(summaryJSONBytes)
+}
+
+// rbProgress counts the objects removed with a bucket and displays
+// the removal rate, along with the remaining time when the number of
+// objects in the bucket could be estimated.
+type rbProgress struct {
+	bucket   string
+	estimate uint64
+	start    time.Time
+	removed  atomic.Uint64
+
+	stopCh chan struct{}
+	wg     sync.WaitGroup
+}
+
+// estimateBucketObjects returns the number of objects, versions and
+// delete markers of the bucket of url from the data usage of a MinIO
+// server, zero when it is unknown. The data usage is refreshed by the
+// scanner of the server, it is only an estimate.
+func estimateBucketObjects(ctx context.Context, url string) uint64 {
+	clnt, err := newClient(url)
+	if err != nil {
+		return 0
+	}
+	s3Clnt, ok := clnt.(*S3Client)
+	if !ok {
+		return 0
+	}
+	bucket, object := s3Clnt.url2BucketAndObject()
+	if bucket == "" || object != "" {
+		return 0
+	}
+	adminClient, err := newAdminClient(url)
+	if err != nil {
+		return 0
+	}
+	info, e := adminClient.DataUsageInfo(ctx)
+	if e != nil {
+		return 0
+	}
+	usage := info.BucketsUsage[bucket]
+	if usage.VersionsCount > 0 {
+		return usage.VersionsCount + usage.DeleteMarkersCount
+	}
+	return usage.ObjectsCount + usage.DeleteMarkersCount
+}
+
+// newRbProgress starts displaying the progress of the removal of the
+// objects of bucket, nothing is displayed with --quiet or --json.
+func newRbProgress(bucket string, estimate uint64) *rbProgress {
+	p := &rbProgress{
+		bucket:   bucket,
+		estimate: estimate,
+		start:    time.Now(),
+		stopCh:   make(chan struct{}),
+	}
+	if globalQuiet || globalJSON {
+		return p
+	}
+	p.wg.Add(1)
+	go func() {
+		defer p.wg.Done()
+		ticker := time.NewTicker(rbProgressInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-p.stopCh:
+				return
+			case <-ticker.C:
+				console.PrintC("\r" + console.Colorize("RemoveBucketProgress", p.line(time.Since(p.start))))
+			}
+		}
+	}()
+	return p
+}
+
+// line returns the progress line after elapsed.
+func (p *rbProgress) line(elapsed time.Duration) string {
+	removed := p.removed.Load()
+	rate := float64(removed) / elapsed.Seconds()
+	var b strings.Builder
+	fmt.Fprintf(&b, "Removing `%s`: %s objects, %s objects/s, elapsed %s", p.bucket,
+		humanize.Comma(int64(removed)), humanize.Comma(int64(rate)), elapsed.Round(time.Second))
+	if p.estimate > removed && rate > 0 {
+		remaining := time.Duration(float64(p.estimate-removed) / rate * float64(time.Second))
+		fmt.Fprintf(&b, ", about %s left", remaining.Round(time.Second))
+	}
+	return b.String()
+}
+
+// add counts a removed object.
+func (p *rbProgress) add() {
+	p.removed.Add(1)
+}
+
+// finish stops the progress line and returns the summary of the removal.
+func (p *rbProgress) finish() removeBucketSummaryMessage {
+	close(p.stopCh)
+	p.wg.Wait()
+	elapsed := time.Since(p.start)
+	if !globalQuiet && !globalJSON && elapsed >= rbProgressInterval {
+		// Clear the progress line.
+		console.PrintC("\r" + strings.Repeat(" ", len(p.line(elapsed))) + "\r")
+	}
+	summary := removeBucketSummaryMessage{
+		Status:  "success",
+		Bucket:  p.bucket,
+		Objects: p.removed.Load(),
+		Elapsed: elapsed,
+	}
+	if elapsed > 0 {
+		summary.ObjectsRate = float64(summary.Objects) / elapsed.Seconds()
+	}
+	return summary
+}
@@ -0,0 +1,53 @@
+// Copyright (c) 2015-2024 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRbProgressLine(t *testing.T) {
+	p := &rbProgress{bucket: "s3/logs", estimate: 30000}
+	p.removed.Store(10000)
+	expected := "Removing `s3/logs`: 10,000 objects, 1,000 objects/s, elapsed 10s, about 20s left"
+	if line := p.line(10 * time.Second); line != expected {
+		t.Fatalf("expected %q, got %q", expected, line)
+	}
+
+	// Without an estimate, or once it is exceeded, there is no remaining time.
+	p.estimate = 5000
+	expected = "Removing `s3/logs`: 10,000 objects, 1,000 objects/s, elapsed 10s"
+	if line := p.line(10 * time.Second); line != expected {
+		t.Fatalf("expected %q, got %q", expected, line)
+	}
+}
+
+func TestRbProgressFinish(t *testing.T) {
+	globalQuiet = true
+	defer func() { globalQuiet = false }()
+
+	p := newRbProgress("s3/logs", 0)
+	for i := 0; i < 3; i++ {
+		p.add()
+	}
+	summary := p.finish()
+	if summary.Objects != 3 || summary.Bucket != "s3/logs" || summary.Elapsed <= 0 {
+		t.Fatalf("unexpected summary %+v", summary)
+	}
+}
@@ -19,6 +19,8 @@ package cmd
 
 import (
 	"context"
+	"fmt"
+	"time"
 
 	"github.com/fatih/color"
 	"github.com/minio/cli"
@@ -41,6 +43,15 @@ var replicateRemoveFlags = []cli.Flag{
 		Name:  "all",
 		Usage: "remove all replication configuration rules of the bucket, force flag enforced",
 	},
+	cli.BoolFlag{
+		Name:  "drain",
+		Usage: "wait for queued and failed replication to clear before removing the rule and its remote target",
+	},
+	cli.DurationFlag{
+		Name:  "drain-timeout",
+		Usage: "maximum duration to wait for the replication backlog to clear, requires --drain",
+		Value: time.Hour,
+	},
 }
 
 var replicateRemoveCmd = cli.Command{
@@ -66,6 +77,9 @@ EXAMPLES:
 
   2. Remove all the replication configuration rules on bucket "mybucket" for alias "myminio". --force flag is required.
      {{.Prompt}} {{.HelpName}} --all --force myminio/mybucket
+
+  3. Remove replication configuration rule with id "bsib5mgt874bi56l0fmg" once its replication backlog has cleared, waiting at most 30 minutes.
+     {{.Prompt}} {{.HelpName}} --id "bsib5mgt874bi56l0fmg" --drain --drain-timeout 30m myminio/mybucket
 `,
 }
 
@@ -83,6 +97,9 @@ func checkReplicateRemoveSyntax(ctx *cli.Context) {
 		fatalIf(errInvalidArgument(),
 			"It is mandatory to specify --all and --force flag together for mc "+ctx.Command.FullName()+".")
 	}
+	if ctx.IsSet("drain-timeout") && !ctx.Bool("drain") {
+		fatalIf(errInvalidArgument().Trace(ctx.Args()...), "--drain-timeout requires --drain.")
+	}
 	if rmAll && rmForce {
 		return
 	}
@@ -113,11 +130,87 @@ func (l replicateRemoveMessage) String() string {
 	return console.Colorize("replicateRemoveMessage", "Replication configuration removed from "+l.URL+" successfully.")
 }
 
+type replicateDrainMessage struct {
+	Op      string `json:"op"`
+	Status  string `json:"status"`
+	URL     string `json:"url"`
+	ARN     string `json:"arn,omitempty"`
+	Pending uint64 `json:"pending"`
+	Failed  uint64 `json:"failed"`
+}
+
+func (d replicateDrainMessage) JSON() string {
+	jsonMessageBytes, e := json.MarshalIndent(d, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+	return string(jsonMessageBytes)
+}
+
+func (d replicateDrainMessage) String() string {
+	return console.Colorize("replicateDrainMessage", fmt.Sprintf("Waiting for replication backlog of %s to clear: %d pending, %d failed.", d.URL, d.Pending, d.Failed))
+}
+
+// replicationBacklog returns the number of objects still waiting to be
+// replicated and the number of recently failed replications, either for
+// the remote target arn or, when arn is empty, for all targets of the
+// bucket. The queue is only reported per bucket, so it always counts.
+// Deprecated pending and failed counters are included for older servers.
+func replicationBacklog(m replication.MetricsV2, arn string) (pending, failed uint64) {
+	cur := m.CurrentStats
+	pending = uint64(cur.QStats.Curr.Count)
+	if arn == "" {
+		pending += cur.PendingCount
+		failed = uint64(cur.Errors.LastMinute.Count) + cur.FailedCount
+		return pending, failed
+	}
+	st := cur.Stats[arn]
+	pending += st.PendingCount
+	failed = uint64(st.Failed.LastMinute.Count) + st.FailedCount
+	return pending, failed
+}
+
+// waitReplicationDrained polls the replication metrics of the bucket
+// until there is no backlog left for arn, printing progress whenever
+// the backlog changes.
+func waitReplicationDrained(ctx context.Context, client Client, aliasedURL, arn string) *probe.Error {
+	ticker := time.NewTicker(5 * time.Second)
+	defer ticker.Stop()
+
+	first := true
+	var lastPending, lastFailed uint64
+	for {
+		metrics, err := client.GetReplicationMetrics(ctx)
+		if err != nil {
+			return err
+		}
+		pending, failed := replicationBacklog(metrics, arn)
+		if pending == 0 && failed == 0 {
+			return nil
+		}
+		if first || pending != lastPending || failed != lastFailed {
+			printMsg(replicateDrainMessage{
+				Op:      "drain",
+				Status:  "waiting",
+				URL:     aliasedURL,
+				ARN:     arn,
+				Pending: pending,
+				Failed:  failed,
+			})
+			first, lastPending, lastFailed = false, pending, failed
+		}
+		select {
+		case <-ctx.Done():
+			return probe.NewError(fmt.Errorf("replication backlog did not clear: %d pending, %d failed", pending, failed))
+		case <-ticker.C:
+		}
+	}
+}
+
 func mainReplicateRemove(cliCtx *cli.Context) error {
 	ctx, cancelReplicateRemove := context.WithCancel(globalContext)
 	defer cancelReplicateRemove()
 
 	console.SetColor("replicateRemoveMessage", color.New(color.FgGreen))
+	console.SetColor("replicateDrainMessage", color.New(color.FgYellow))
 
 	checkReplicateRemoveSyntax(cliCtx)
 
@@ -142,15 +235,20 @@ func mainReplicateRemove(cliCtx *cli.Context) error {
 		})
 		return nil
 	}
+	var removeArn string
+	for _, rule := range rcfg.Rules {
+		if rule.ID == ruleID {
+			removeArn = rule.Destination.Bucket
+		}
+	}
+	if cliCtx.Bool("drain") && (rmAll || removeArn != "") {
+		drainCtx, cancelDrain := context.WithTimeout(ctx, cliCtx.Duration("drain-timeout"))
+		defer cancelDrain()
+		fatalIf(waitReplicationDrained(drainCtx, client, aliasedURL, removeArn).Trace(args...), "Unable to drain replication, nothing was removed")
+	}
 	if rmAll && rmForce {
 		fatalIf(client.RemoveReplication(ctx), "Unable to remove replication configuration")
 	} else {
-		var removeArn string
-		for _, rule := range rcfg.Rules {
-			if rule.ID == ruleID {
-				removeArn = rule.Destination.Bucket
-			}
-		}
 		opts := replication.Options{
 			ID: ruleID,
 			Op: replication.RemoveOption,
@@ -0,0 +1,58 @@
+// Copyright (c) 2015-2024 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"testing"
+
+	"github.com/minio/minio-go/v7/pkg/replication"
+)
+
+func TestReplicationBacklog(t *testing.T) {
+	m := replication.MetricsV2{
+		CurrentStats: replication.Metrics{
+			Stats: map[string]replication.TargetMetrics{
+				"arn:a": {Failed: replication.TimedErrStats{LastMinute: replication.RStat{Count: 2}}},
+				"arn:b": {PendingCount: 3},
+			},
+			Errors: replication.TimedErrStats{LastMinute: replication.RStat{Count: 2}},
+			QStats: replication.InQueueMetric{Curr: replication.QStat{Count: 4}},
+		},
+	}
+	testCases := []struct {
+		arn     string
+		pending uint64
+		failed  uint64
+	}{
+		{"", 4, 2},
+		{"arn:a", 4, 2},
+		{"arn:b", 7, 0},
+		{"arn:unknown", 4, 0},
+	}
+	for _, tc := range testCases {
+		pending, failed := replicationBacklog(m, tc.arn)
+		if pending != tc.pending || failed != tc.failed {
+			t.Errorf("%q: expected %d pending, %d failed, got %d, %d", tc.arn, tc.pending, tc.failed, pending, failed)
+		}
+	}
+
+	pending, failed := replicationBacklog(replication.MetricsV2{}, "arn:a")
+	if pending != 0 || failed != 0 {
+		t.Errorf("expected an empty backlog, got %d pending, %d failed", pending, failed)
+	}
+}
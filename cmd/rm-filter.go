@@ -0,0 +1,95 @@
+// Copyright (c) 2015-2024 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/minio/mc/pkg/probe"
+)
+
+// rmFilter restricts a recursive removal to the objects having all the
+// given tags and metadata. It relies on the tags and the metadata returned
+// by the listing, which only MinIO servers include.
+type rmFilter struct {
+	tags     map[string]string
+	metadata map[string]string
+}
+
+// newRmFilter parses the key=value pairs of --tags and --metadata, it
+// returns nil when none is set.
+func newRmFilter(tags, metadata []string) (*rmFilter, *probe.Error) {
+	if len(tags) == 0 && len(metadata) == 0 {
+		return nil, nil
+	}
+	parse := func(kvs []string, normalize func(string) string) (map[string]string, *probe.Error) {
+		m := make(map[string]string, len(kvs))
+		for _, kv := range kvs {
+			key, value, ok := strings.Cut(kv, "=")
+			if !ok || key == "" {
+				return nil, errInvalidArgument().Trace(kv)
+			}
+			m[normalize(key)] = value
+		}
+		return m, nil
+	}
+	f := &rmFilter{}
+	var err *probe.Error
+	if f.tags, err = parse(tags, func(key string) string { return key }); err != nil {
+		return nil, err
+	}
+	if f.metadata, err = parse(metadata, rmMetadataKey); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// matches returns true if content has all the tags and the metadata of
+// the filter, a nil filter matches everything.
+func (f *rmFilter) matches(content *ClientContent) bool {
+	if f == nil {
+		return true
+	}
+	for key, value := range f.tags {
+		if v, ok := content.Tags[key]; !ok || v != value {
+			return false
+		}
+	}
+	if len(f.metadata) == 0 {
+		return true
+	}
+	// The listing returns user metadata with or without the
+	// X-Amz-Meta- prefix depending on the server.
+	metadata := make(map[string]string, len(content.UserMetadata))
+	for key, value := range content.UserMetadata {
+		metadata[rmMetadataKey(key)] = value
+	}
+	for key, value := range f.metadata {
+		if v, ok := metadata[key]; !ok || v != value {
+			return false
+		}
+	}
+	return true
+}
+
+// rmMetadataKey returns the canonical form of a metadata key, without its
+// X-Amz-Meta- prefix.
+func rmMetadataKey(key string) string {
+	return http.CanonicalHeaderKey(strings.TrimPrefix(strings.ToLower(key), "x-amz-meta-"))
+}
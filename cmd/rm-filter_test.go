@@ -0,0 +1,51 @@
+// Copyright (c) 2015-2024 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import "testing"
+
+func TestRmFilter(t *testing.T) {
+	f, err := newRmFilter([]string{"temporary=true"}, []string{"X-Amz-Meta-owner=ci"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	testCases := []struct {
+		tags     map[string]string
+		metadata map[string]string
+		matches  bool
+	}{
+		{map[string]string{"temporary": "true"}, map[string]string{"X-Amz-Meta-Owner": "ci"}, true},
+		{map[string]string{"temporary": "true", "team": "a"}, map[string]string{"owner": "ci"}, true},
+		{map[string]string{"temporary": "false"}, map[string]string{"owner": "ci"}, false},
+		{map[string]string{"temporary": "true"}, map[string]string{"owner": "dev"}, false},
+		{nil, nil, false},
+	}
+	for i, tc := range testCases {
+		content := &ClientContent{Tags: tc.tags, UserMetadata: tc.metadata}
+		if got := f.matches(content); got != tc.matches {
+			t.Errorf("test %d: expected %v, got %v", i+1, tc.matches, got)
+		}
+	}
+
+	if f, _ = newRmFilter(nil, nil); !f.matches(&ClientContent{}) {
+		t.Error("a nil filter must match everything")
+	}
+	if _, err = newRmFilter([]string{"temporary"}, nil); err == nil {
+		t.Error("expected an error for a tag without a value")
+	}
+}
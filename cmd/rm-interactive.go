@@ -0,0 +1,102 @@
+// Copyright (c) 2015-2024 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math/rand"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/dustin/go-humanize"
+	"github.com/minio/pkg/v3/console"
+)
+
+// Number of objects shown in the preview of rm --interactive.
+const rmPreviewSampleSize = 10
+
+// rmPreview collects the objects a removal would delete, a random sample
+// of them is kept to be shown before asking for confirmation.
+type rmPreview struct {
+	objects int64
+	size    int64
+	sample  []string
+}
+
+// add adds an object to the preview, every object has the same chance
+// to be part of the sample.
+func (p *rmPreview) add(targetAlias string, content *ClientContent) {
+	key := path.Join(targetAlias, content.URL.Path)
+	if content.VersionID != "" {
+		key += " (versionId=" + content.VersionID + ")"
+	}
+	p.objects++
+	p.size += content.Size
+	if len(p.sample) < rmPreviewSampleSize {
+		p.sample = append(p.sample, key)
+		return
+	}
+	if i := rand.Int63n(p.objects); i < rmPreviewSampleSize {
+		p.sample[i] = key
+	}
+}
+
+// String returns the preview of the removal of url.
+func (p *rmPreview) String(url string) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s objects (%s) will be removed from `%s`", humanize.Comma(p.objects), humanize.IBytes(uint64(p.size)), url)
+	if p.objects > int64(len(p.sample)) {
+		fmt.Fprintf(&b, ", including:\n")
+	} else {
+		fmt.Fprintf(&b, ":\n")
+	}
+	for _, key := range p.sample {
+		fmt.Fprintf(&b, "   %s\n", console.Colorize("Removed", key))
+	}
+	return b.String()
+}
+
+// rmConfirmName returns the name which must be typed to confirm the
+// removal of url, the bucket of object storage or the last element of a
+// local path.
+func rmConfirmName(url string) string {
+	clnt, err := newClient(url)
+	if err == nil {
+		if s3Clnt, ok := clnt.(*S3Client); ok {
+			bucket, _ := s3Clnt.url2BucketAndObject()
+			return bucket
+		}
+	}
+	return filepath.Base(filepath.Clean(url))
+}
+
+// confirmRemoval shows the preview of the removal of url and reads the
+// name to type from r, it returns true if the removal is confirmed.
+func confirmRemoval(r *bufio.Reader, url string, preview *rmPreview) bool {
+	console.Print(preview.String(url))
+	name := rmConfirmName(url)
+	console.Printf("Type %s to confirm the removal: ", console.Colorize("Removed", name))
+	answer, e := r.ReadString('\n')
+	if e != nil && e != io.EOF {
+		return false
+	}
+	return strings.TrimSpace(answer) == name
+}
@@ -0,0 +1,49 @@
+// Copyright (c) 2015-2024 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestRmPreview(t *testing.T) {
+	for _, n := range []int{0, 3, rmPreviewSampleSize, 250} {
+		p := &rmPreview{}
+		for i := 0; i < n; i++ {
+			p.add("s3", &ClientContent{URL: *newClientURL(fmt.Sprintf("/bucket/obj%d", i)), Size: 2})
+		}
+		if p.objects != int64(n) {
+			t.Errorf("%d objects: got %d objects", n, p.objects)
+		}
+		if p.size != int64(2*n) {
+			t.Errorf("%d objects: got size %d", n, p.size)
+		}
+		want := min(n, rmPreviewSampleSize)
+		if len(p.sample) != want {
+			t.Errorf("%d objects: expected a sample of %d, got %d", n, want, len(p.sample))
+		}
+		seen := map[string]bool{}
+		for _, key := range p.sample {
+			if seen[key] {
+				t.Errorf("%d objects: %s sampled twice", n, key)
+			}
+			seen[key] = true
+		}
+	}
+}
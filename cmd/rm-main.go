@@ -55,6 +55,10 @@ var (
 			Name:  "dangerous",
 			Usage: "allow site-wide removal of objects",
 		},
+		cli.BoolFlag{
+			Name:  "interactive",
+			Usage: "preview a recursive remove operation and confirm it by typing the bucket name, with --force the preview is shown without confirmation",
+		},
 		cli.StringFlag{
 			Name:  "rewind",
 			Usage: "roll back object(s) to current version at specified time",
@@ -96,6 +100,24 @@ var (
 			Name:  "non-current",
 			Usage: "remove object(s) versions that are non-current",
 		},
+		cli.IntFlag{
+			Name:  "workers",
+			Usage: "number of multi-object delete requests sent in parallel with --recursive or --versions",
+			Value: 1,
+		},
+		cli.IntFlag{
+			Name:  "batch-size",
+			Usage: "number of objects deleted by each multi-object delete request, at most 1000",
+			Value: maxRemoveBatchSize,
+		},
+		cli.StringSliceFlag{
+			Name:  "tags",
+			Usage: "remove only the objects having this tag, specify each with key=value. MinIO server only.",
+		},
+		cli.StringSliceFlag{
+			Name:  "metadata",
+			Usage: "remove only the objects having this metadata, specify each with key=value. MinIO server only.",
+		},
 		cli.BoolFlag{
 			Name:   "purge",
 			Usage:  "attempt a prefix purge, requires confirmation please use with caution - only works with '--force'",
@@ -162,6 +184,18 @@ EXAMPLES:
   14. Perform a fake removal of object(s) versions that are non-current and older than 10 days. If top-level version is a delete 
   marker, this will also be deleted when --non-current flag is specified.
       {{.Prompt}} {{.HelpName}} s3/docs/ --recursive --force --versions --non-current --older-than 10d --dry-run
+
+  15. Remove tens of millions of objects recursively, sending 16 multi-object delete requests of 1000 objects in parallel.
+      {{.Prompt}} {{.HelpName}} --recursive --force --workers 16 --batch-size 1000 s3/logs/2019/
+
+  16. Preview the objects older than 90 days under the prefix 'louis' and remove them once confirmed.
+      {{.Prompt}} {{.HelpName}} --recursive --interactive --older-than 90d s3/jazz-songs/louis/
+
+  17. Remove the objects tagged 'temporary=true' whose 'owner' metadata is 'ci' recursively from bucket 'builds'.
+      {{.Prompt}} {{.HelpName}} --recursive --force --tags "temporary=true" --metadata "owner=ci" s3/builds/
+
+  18. Remove the objects under the prefix 'nightly' from a script, showing a preview of the removal without confirmation.
+      {{.Prompt}} {{.HelpName}} --recursive --interactive --force s3/builds/nightly/
 `,
 }
 
@@ -212,6 +246,7 @@ func checkRmSyntax(ctx context.Context, cliCtx *cli.Context) {
 	isRecursive := cliCtx.Bool("recursive")
 	isStdin := cliCtx.Bool("stdin")
 	isDangerous := cliCtx.Bool("dangerous")
+	isInteractive := cliCtx.Bool("interactive")
 	isVersions := cliCtx.Bool("versions")
 	isNoncurrentVersion := cliCtx.Bool("non-current")
 	isForceDel := cliCtx.Bool("purge")
@@ -239,6 +274,35 @@ func checkRmSyntax(ctx context.Context, cliCtx *cli.Context) {
 			"You cannot specify --purge with --recursive.")
 	}
 
+	if isInteractive && !(isRecursive || isVersions) {
+		fatalIf(errDummy().Trace(),
+			"You cannot specify --interactive without --recursive or --versions.")
+	}
+
+	if isInteractive && (isStdin || isForceDel || globalJSON || cliCtx.Bool("dry-run") || cliCtx.Bool("fake")) {
+		fatalIf(errDummy().Trace(),
+			"You cannot specify --interactive with any of --stdin, --purge, --dry-run and --json flags.")
+	}
+
+	if cliCtx.Int("workers") < 1 {
+		fatalIf(errDummy().Trace(),
+			"--workers must be at least 1.")
+	}
+
+	if cliCtx.IsSet("tags") || cliCtx.IsSet("metadata") {
+		if !isRecursive || isVersions || cliCtx.Bool("incomplete") {
+			fatalIf(errDummy().Trace(),
+				"You cannot specify --tags or --metadata without --recursive, or with --versions or --incomplete.")
+		}
+		_, err := newRmFilter(cliCtx.StringSlice("tags"), cliCtx.StringSlice("metadata"))
+		fatalIf(err, "Invalid --tags or --metadata, please specify each with key=value.")
+	}
+
+	if batchSize := cliCtx.Int("batch-size"); batchSize < 1 || batchSize > maxRemoveBatchSize {
+		fatalIf(errDummy().Trace(),
+			fmt.Sprintf("--batch-size must be between 1 and %d.", maxRemoveBatchSize))
+	}
+
 	if isForceDel && (isNoncurrentVersion || isVersions || cliCtx.IsSet("older-than") || cliCtx.IsSet("newer-than") || versionID != "") {
 		fatalIf(errDummy().Trace(),
 			"You cannot specify --purge flag with any flag(s) other than --force.")
@@ -256,7 +320,7 @@ func checkRmSyntax(ctx context.Context, cliCtx *cli.Context) {
 				isNamespaceRemoval = (path == "")
 				break
 			}
-			if dir && isRecursive && !isForce {
+			if dir && isRecursive && !isForce && !isInteractive {
 				fatalIf(errDummy().Trace(),
 					"Removal requires --force flag. This operation is *IRREVERSIBLE*. Please review carefully before performing this *DANGEROUS* operation.")
 			}
@@ -273,12 +337,12 @@ func checkRmSyntax(ctx context.Context, cliCtx *cli.Context) {
 	}
 
 	// For all recursive or versions bulk deletion operations make sure to check for 'force' flag.
-	if (isVersions || isRecursive || isStdin) && !isForce {
+	if (isVersions || isRecursive || isStdin) && !isForce && !isInteractive {
 		fatalIf(errDummy().Trace(),
 			"Removal requires --force flag. This operation is *IRREVERSIBLE*. Please review carefully before performing this *DANGEROUS* operation.")
 	}
 
-	if isNamespaceRemoval && !(isDangerous && isForce) {
+	if isNamespaceRemoval && !(isDangerous && (isForce || isInteractive)) {
 		fatalIf(errDummy().Trace(),
 			"This operation results in site-wide removal of objects. If you are really sure, retry this command with ‘--dangerous’ and ‘--force’ flags.")
 	}
@@ -345,7 +409,7 @@ func removeSingle(url, versionID string, opts removeOpts) error {
 		}
 
 		if opts.isFake {
-			printDryRunMsg(targetAlias, content, opts.withVersions)
+			opts.dryRun(targetAlias, content, opts.withVersions)
 			return nil
 		}
 	}
@@ -401,6 +465,22 @@ type removeOpts struct {
 	isForceDel        bool
 	olderThan         string
 	newerThan         string
+	workers           int
+	batchSize         int
+	preview           *rmPreview
+	filter            *rmFilter
+}
+
+// dryRun reports an object removed by a fake remove operation, it is
+// added to the preview of --interactive if any.
+func (opts removeOpts) dryRun(targetAlias string, content *ClientContent, printModTime bool) {
+	if opts.preview != nil {
+		if content != nil {
+			opts.preview.add(targetAlias, content)
+		}
+		return
+	}
+	printDryRunMsg(targetAlias, content, printModTime)
 }
 
 func printDryRunMsg(targetAlias string, content *ClientContent, printModTime bool) {
@@ -434,10 +514,13 @@ func listAndRemove(url string, opts removeOpts) error {
 		errorIf(pErr.Trace(url), "Failed to remove `%s` recursively.", url)
 		return exitStatus(globalErrorExitStatus) // End of journey.
 	}
+	if s3Clnt, ok := clnt.(*S3Client); ok {
+		s3Clnt.SetRemoveConcurrency(opts.workers, opts.batchSize)
+	}
 	contentCh := make(chan *ClientContent)
 	isRemoveBucket := false
 
-	listOpts := ListOptions{Recursive: opts.isRecursive, Incomplete: opts.isIncomplete, ShowDir: DirLast}
+	listOpts := ListOptions{Recursive: opts.isRecursive, Incomplete: opts.isIncomplete, ShowDir: DirLast, WithMetadata: opts.filter != nil}
 	if !opts.timeRef.IsZero() {
 		listOpts.WithOlderVersions = opts.withVersions
 		listOpts.WithDeleteMarkers = true
@@ -499,7 +582,7 @@ func listAndRemove(url string, opts removeOpts) error {
 					}
 
 					if opts.isFake {
-						printDryRunMsg(targetAlias, content, true)
+						opts.dryRun(targetAlias, content, true)
 						continue
 					}
 
@@ -560,6 +643,11 @@ func listAndRemove(url string, opts removeOpts) error {
 			continue
 		}
 
+		// Skip objects not having the tags and metadata of --tags and --metadata
+		if !opts.filter.matches(content) {
+			continue
+		}
+
 		if !opts.isFake {
 			sent := false
 			for !sent {
@@ -595,7 +683,7 @@ func listAndRemove(url string, opts removeOpts) error {
 				}
 			}
 		} else {
-			printDryRunMsg(targetAlias, content, opts.withVersions)
+			opts.dryRun(targetAlias, content, opts.withVersions)
 		}
 	}
 
@@ -620,7 +708,7 @@ func listAndRemove(url string, opts removeOpts) error {
 			}
 
 			if opts.isFake {
-				printDryRunMsg(targetAlias, content, true)
+				opts.dryRun(targetAlias, content, true)
 				continue
 			}
 
@@ -711,10 +799,12 @@ func mainRm(cliCtx *cli.Context) error {
 	newerThan := cliCtx.String("newer-than")
 	isForce := cliCtx.Bool("force")
 	isForceDel := cliCtx.Bool("purge")
+	isInteractive := cliCtx.Bool("interactive")
 	withNoncurrentVersion := cliCtx.Bool("non-current")
 	withVersions := cliCtx.Bool("versions")
 	versionID := cliCtx.String("version-id")
 	rewind := parseRewindFlag(cliCtx.String("rewind"))
+	filter, _ := newRmFilter(cliCtx.StringSlice("tags"), cliCtx.StringSlice("metadata"))
 
 	if withVersions && rewind.IsZero() {
 		rewind = time.Now().UTC()
@@ -725,10 +815,11 @@ func mainRm(cliCtx *cli.Context) error {
 
 	var rerr error
 	var e error
+	var confirmReader *bufio.Reader
 	// Support multiple targets.
 	for _, url := range cliCtx.Args() {
 		if isRecursive || withVersions {
-			e = listAndRemove(url, removeOpts{
+			opts := removeOpts{
 				timeRef:           rewind,
 				withVersions:      withVersions,
 				nonCurrentVersion: withNoncurrentVersion,
@@ -739,7 +830,41 @@ func mainRm(cliCtx *cli.Context) error {
 				isBypass:          isBypass,
 				olderThan:         olderThan,
 				newerThan:         newerThan,
-			})
+				workers:           cliCtx.Int("workers"),
+				batchSize:         cliCtx.Int("batch-size"),
+				filter:            filter,
+			}
+			if isInteractive {
+				// Collect the objects with a fake removal first, the removal
+				// only happens once the user types the expected name.
+				previewOpts := opts
+				previewOpts.isFake = true
+				previewOpts.preview = &rmPreview{}
+				e = listAndRemove(url, previewOpts)
+				switch {
+				case e != nil:
+				case previewOpts.preview.objects == 0:
+					// Nothing to confirm, let the removal report it.
+					e = listAndRemove(url, opts)
+				case isForce:
+					// --force skips the confirmation, the preview is still
+					// shown for the record.
+					console.Print(previewOpts.preview.String(url))
+					e = listAndRemove(url, opts)
+				default:
+					if confirmReader == nil {
+						confirmReader = bufio.NewReader(os.Stdin)
+					}
+					if confirmRemoval(confirmReader, url, previewOpts.preview) {
+						e = listAndRemove(url, opts)
+					} else {
+						errorIf(errDummy().Trace(url), "Removal of `%s` aborted.", url)
+						e = exitStatus(globalErrorExitStatus)
+					}
+				}
+			} else {
+				e = listAndRemove(url, opts)
+			}
 		} else {
 			e = removeSingle(url, versionID, removeOpts{
 				isIncomplete: isIncomplete,
@@ -775,6 +900,9 @@ func mainRm(cliCtx *cli.Context) error {
 				isBypass:          isBypass,
 				olderThan:         olderThan,
 				newerThan:         newerThan,
+				workers:           cliCtx.Int("workers"),
+				batchSize:         cliCtx.Int("batch-size"),
+				filter:            filter,
 			})
 		} else {
 			e = removeSingle(url, versionID, removeOpts{
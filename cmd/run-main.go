@@ -0,0 +1,422 @@
+// Copyright (c) 2015-2024 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"net/netip"
+	"os"
+	"runtime"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/google/shlex"
+	"github.com/minio/cli"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/pkg/v3/console"
+)
+
+// Time given to a command of a script to stop once one of its goroutines
+// failed, the script goes on without waiting for it after this delay.
+const runStepCancelDelay = 10 * time.Second
+
+var runFlags = []cli.Flag{
+	cli.BoolFlag{
+		Name:  "continue-on-error",
+		Usage: "run the remaining commands when a command fails, by default the script stops at the first failure",
+	},
+}
+
+// run a script of mc commands.
+var runCmd = cli.Command{
+	Name:         "run",
+	Usage:        "run a script of mc commands in a single process",
+	Action:       mainRun,
+	OnUsageError: onUsageError,
+	Before:       setGlobalsFromContext,
+	Flags:        append(runFlags, globalFlags...),
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} [FLAGS] SCRIPT
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+SCRIPT:
+  A file with one mc command per line, '-' reads the script from standard input. The leading 'mc'
+  of a command is optional. Arguments are split like a shell does, quotes and backslash escapes are
+  honored, lines ending with a backslash continue on the next line and '#' starts a comment.
+  Variables and globs are not expanded.
+
+DESCRIPTION:
+  All the commands are checked before the first one runs, then they run one after the other in the
+  same process: the configuration is loaded once and the connections and TLS sessions to the servers
+  are reused between commands. Global flags given to '{{.HelpName}}' apply to all the commands of the
+  script, the flags given to a command only apply to that command.
+
+EXAMPLES:
+  1. Run the commands of the script 'nightly.mcs', stop at the first failure.
+     {{.Prompt}} {{.HelpName}} nightly.mcs
+
+  2. Run the commands of the script 'cleanup.mcs' even if some of them fail.
+     {{.Prompt}} {{.HelpName}} --continue-on-error cleanup.mcs
+
+  3. Run commands read from standard input with JSON output.
+     {{.Prompt}} printf "mb myminio/logs\ncp app.log myminio/logs/\n" | {{.HelpName}} --json -
+`,
+}
+
+// runStep is a command of a script.
+type runStep struct {
+	Line int
+	Args []string
+}
+
+// parseRunScript reads the commands of a script.
+func parseRunScript(r io.Reader) ([]runStep, *probe.Error) {
+	var steps []runStep
+	var cmdline strings.Builder
+	startLine := 0
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for lineNr := 1; scanner.Scan(); lineNr++ {
+		line := scanner.Text()
+		if cmdline.Len() == 0 {
+			startLine = lineNr
+		}
+		if strings.HasSuffix(line, "\\") {
+			cmdline.WriteString(strings.TrimSuffix(line, "\\"))
+			cmdline.WriteString(" ")
+			continue
+		}
+		cmdline.WriteString(line)
+
+		args, e := shlex.Split(cmdline.String())
+		if e != nil {
+			return nil, probe.NewError(fmt.Errorf("line %d: %w", startLine, e))
+		}
+		cmdline.Reset()
+		if len(args) > 0 && args[0] == "mc" {
+			args = args[1:]
+		}
+		if len(args) == 0 {
+			continue
+		}
+		steps = append(steps, runStep{Line: startLine, Args: args})
+	}
+	if e := scanner.Err(); e != nil {
+		return nil, probe.NewError(e)
+	}
+	if cmdline.Len() > 0 {
+		return nil, probe.NewError(fmt.Errorf("line %d: %w", startLine, io.ErrUnexpectedEOF))
+	}
+	return steps, nil
+}
+
+// checkRunScript verifies all the commands of a script are known before
+// running any of them, scripts cannot run other scripts.
+func checkRunScript(steps []runStep, cmds []cli.Command, runName string) *probe.Error {
+	for _, step := range steps {
+		name := step.Args[0]
+		if strings.HasPrefix(name, "-") {
+			// Global flags are given before the command.
+			continue
+		}
+		if name == runName {
+			return probe.NewError(fmt.Errorf("line %d: `%s` cannot be used in a script", step.Line, name))
+		}
+		found := false
+		for _, cmd := range cmds {
+			if cmd.HasName(name) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return probe.NewError(fmt.Errorf("line %d: unknown command `%s`", step.Line, name))
+		}
+	}
+	return nil
+}
+
+// runGlobalFlags returns the global flags given to run, they are given
+// to all the commands of the script.
+func runGlobalFlags(cliCtx *cli.Context) []string {
+	var flags []string
+	for _, f := range globalFlags {
+		name := strings.Split(f.GetName(), ",")[0]
+		if !cliCtx.IsSet(name) {
+			continue
+		}
+		switch v := cliCtx.Generic(name).(type) {
+		case *cli.StringSlice:
+			for _, s := range *v {
+				flags = append(flags, "--"+name+"="+s)
+			}
+		case flag.Value:
+			flags = append(flags, "--"+name+"="+v.String())
+		}
+	}
+	return flags
+}
+
+// runGlobals is the state of the global flags, it is restored before each
+// command as the flags of a command must not leak to the next ones.
+type runGlobals struct {
+	quiet, debug, json, jsonLine, noColor, insecure, devMode, airgapped bool
+
+	connReadDeadline, connWriteDeadline time.Duration
+	ipFamily                            string
+	happyEyeballsDelay                  time.Duration
+	maxIdleConns, connsPerHost          int
+	limitUpload, limitDownload          uint64
+	resolvers                           map[string]netip.Addr
+}
+
+func saveRunGlobals() runGlobals {
+	return runGlobals{
+		quiet:              globalQuiet,
+		debug:              globalDebug,
+		json:               globalJSON,
+		jsonLine:           globalJSONLine,
+		noColor:            globalNoColor,
+		insecure:           globalInsecure,
+		devMode:            GlobalDevMode,
+		airgapped:          globalAirgapped,
+		connReadDeadline:   globalConnReadDeadline,
+		connWriteDeadline:  globalConnWriteDeadline,
+		ipFamily:           globalIPFamily,
+		happyEyeballsDelay: globalHappyEyeballsDelay,
+		maxIdleConns:       globalMaxIdleConns,
+		connsPerHost:       globalConnsPerHost,
+		limitUpload:        globalLimitUpload,
+		limitDownload:      globalLimitDownload,
+		resolvers:          globalResolvers,
+	}
+}
+
+func (g runGlobals) restore() {
+	globalQuiet = g.quiet
+	globalDebug = g.debug
+	globalJSON = g.json
+	globalJSONLine = g.jsonLine
+	globalNoColor = g.noColor
+	globalInsecure = g.insecure
+	GlobalDevMode = g.devMode
+	globalAirgapped = g.airgapped
+	globalConnReadDeadline = g.connReadDeadline
+	globalConnWriteDeadline = g.connWriteDeadline
+	globalIPFamily = g.ipFamily
+	globalHappyEyeballsDelay = g.happyEyeballsDelay
+	globalMaxIdleConns = g.maxIdleConns
+	globalConnsPerHost = g.connsPerHost
+	globalLimitUpload = g.limitUpload
+	globalLimitDownload = g.limitDownload
+	globalResolvers = g.resolvers
+}
+
+// scriptStep is a command of a script running in the mc process.
+type scriptStep struct {
+	cancel context.CancelFunc
+	once   sync.Once
+	code   int
+	// Closed when the command exits, code is then set.
+	exited chan struct{}
+}
+
+// end records the exit code of the command, only the first one counts.
+func (s *scriptStep) end(code int) {
+	s.once.Do(func() {
+		s.code = code
+		close(s.exited)
+		s.cancel()
+	})
+}
+
+// globalScriptStep is the last command started by 'mc run', nil when no
+// script runs.
+var globalScriptStep atomic.Pointer[scriptStep]
+
+// exitScriptStep ends the command of the script instead of the process:
+// the calling goroutine stops and the other goroutines of the command see
+// the global context canceled.
+func exitScriptStep(code int) {
+	s := globalScriptStep.Load()
+	if s == nil {
+		os.Exit(code)
+	}
+	s.end(code)
+	runtime.Goexit()
+}
+
+// interceptRunExits makes the exits of the commands, through the cli
+// package or through fatal errors, end the command of the script instead
+// of the process.
+func interceptRunExits() (restore func()) {
+	osExiter := cli.OsExiter
+	fatal, fatalf, fatalln := console.Fatal, console.Fatalf, console.Fatalln
+
+	cli.OsExiter = exitScriptStep
+	console.Fatal = func(data ...interface{}) {
+		console.Error(data...)
+		exitScriptStep(1)
+	}
+	console.Fatalf = func(format string, data ...interface{}) {
+		console.Errorf(format, data...)
+		exitScriptStep(1)
+	}
+	console.Fatalln = func(data ...interface{}) {
+		console.Errorln(data...)
+		exitScriptStep(1)
+	}
+
+	return func() {
+		cli.OsExiter = osExiter
+		console.Fatal, console.Fatalf, console.Fatalln = fatal, fatalf, fatalln
+	}
+}
+
+// newRunScriptApp returns the application running the commands of a
+// script. The configuration, the telemetry and the clients of mc are
+// already set up, they are shared by all the commands.
+func newRunScriptApp(cliCtx *cli.Context) *cli.App {
+	app := cli.NewApp()
+	app.Name = cliCtx.App.Name
+	app.HelpName = cliCtx.App.HelpName
+	app.Usage = cliCtx.App.Usage
+	app.Version = cliCtx.App.Version
+	app.HideHelpCommand = true
+	app.Commands = cliCtx.App.Commands
+	app.Flags = globalFlags
+	app.Before = setGlobalsFromContext
+	app.OnUsageError = onUsageError
+	return app
+}
+
+// runScriptStep runs a command of a script in the mc process and returns
+// its exit code, interceptRunExits must be in effect.
+func runScriptStep(app *cli.App, globalArgs []string, step runStep) int {
+	scriptCtx, scriptCancel := globalContext, globalCancel
+	ctx, cancel := context.WithCancel(scriptCtx)
+	defer func() {
+		cancel()
+		globalContext, globalCancel = scriptCtx, scriptCancel
+		setShutdownHandler(nil)
+	}()
+	globalContext, globalCancel = ctx, cancel
+
+	s := &scriptStep{cancel: cancel, exited: make(chan struct{})}
+	globalScriptStep.Store(s)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		args := append(append([]string{app.Name}, globalArgs...), step.Args...)
+		if e := app.Run(args); e != nil {
+			s.end(globalErrorExitStatus)
+		}
+	}()
+
+	select {
+	case <-done:
+	case <-s.exited:
+		// Another goroutine of the command failed, give the command
+		// some time to stop.
+		select {
+		case <-done:
+		case <-time.After(runStepCancelDelay):
+		}
+	}
+	select {
+	case <-s.exited:
+		return s.code
+	default:
+		return 0
+	}
+}
+
+// runScript runs the commands of a script and returns the number of
+// failed commands, it stops at the first failure unless continueOnError
+// is set. The exit code of the last failure is returned.
+func runScript(app *cli.App, globalArgs []string, steps []runStep, script string, continueOnError bool) (failed, code int) {
+	globals := saveRunGlobals()
+	defer globals.restore()
+
+	restore := interceptRunExits()
+	defer func() {
+		restore()
+		globalScriptStep.Store(nil)
+	}()
+
+	for _, step := range steps {
+		if globalContext.Err() != nil {
+			break
+		}
+		globals.restore()
+		stepCode := runScriptStep(app, globalArgs, step)
+		if stepCode == 0 {
+			continue
+		}
+		failed++
+		code = stepCode
+		errorIf(probe.NewError(fmt.Errorf("exit status %d", stepCode)).Trace(step.Args...),
+			"Command at line %d of `%s` failed.", step.Line, script)
+		if !continueOnError {
+			break
+		}
+	}
+	return failed, code
+}
+
+func mainRun(cliCtx *cli.Context) error {
+	if len(cliCtx.Args()) != 1 {
+		showCommandHelpAndExit(cliCtx, 1) // last argument is exit code
+	}
+
+	script := cliCtx.Args().First()
+	var r io.Reader = os.Stdin
+	if script != "-" {
+		f, e := os.Open(script)
+		fatalIf(probe.NewError(e).Trace(script), "Unable to open the script.")
+		defer f.Close()
+		r = f
+	}
+
+	steps, err := parseRunScript(r)
+	fatalIf(err.Trace(script), "Unable to parse the script.")
+	fatalIf(checkRunScript(steps, cliCtx.App.Commands, cliCtx.Command.Name).Trace(script), "Invalid script.")
+
+	failed, code := runScript(newRunScriptApp(cliCtx), runGlobalFlags(cliCtx), steps, script, cliCtx.Bool("continue-on-error"))
+	switch {
+	case failed == 0:
+		return nil
+	case cliCtx.Bool("continue-on-error"):
+		return exitStatus(globalErrorExitStatus)
+	default:
+		return exitStatus(code)
+	}
+}
@@ -0,0 +1,145 @@
+// Copyright (c) 2015-2024 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/minio/cli"
+	"github.com/minio/mc/pkg/probe"
+)
+
+func TestParseRunScript(t *testing.T) {
+	script := `# nightly backup
+mc mb --ignore-existing backup/logs
+
+cp --recursive "/var/log/app dir/" \
+   backup/logs/ # trailing comment
+  ls 'backup/logs'
+`
+	steps, err := parseRunScript(strings.NewReader(script))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []runStep{
+		{Line: 2, Args: []string{"mb", "--ignore-existing", "backup/logs"}},
+		{Line: 4, Args: []string{"cp", "--recursive", "/var/log/app dir/", "backup/logs/"}},
+		{Line: 6, Args: []string{"ls", "backup/logs"}},
+	}
+	if !reflect.DeepEqual(steps, want) {
+		t.Fatalf("expected %v, got %v", want, steps)
+	}
+
+	for _, script := range []string{"ls 'backup\n", "ls \\\n"} {
+		if _, err := parseRunScript(strings.NewReader(script)); err == nil {
+			t.Fatalf("expected an error parsing %q", script)
+		}
+	}
+}
+
+func TestRunGlobalFlags(t *testing.T) {
+	var flags []string
+	cmd := runCmd
+	cmd.Before = nil
+	cmd.Action = func(cliCtx *cli.Context) error {
+		flags = runGlobalFlags(cliCtx)
+		return nil
+	}
+	app := cli.NewApp()
+	app.Commands = []cli.Command{cmd}
+	args := []string{"mc", "run", "--json", "--continue-on-error", "--resolve", "a.local=10.0.0.1", "--resolve", "b.local=10.0.0.2", "--limit-upload", "1MiB", "script.mcs"}
+	if e := app.Run(args); e != nil {
+		t.Fatal(e)
+	}
+	// The flags of run are not given to the commands.
+	want := []string{"--json=true", "--resolve=a.local=10.0.0.1", "--resolve=b.local=10.0.0.2", "--limit-upload=1MiB"}
+	if !reflect.DeepEqual(flags, want) {
+		t.Fatalf("expected %v, got %v", want, flags)
+	}
+}
+
+func TestRunScript(t *testing.T) {
+	useTestMcConfig(t)
+	if err := saveMcConfig(newMcConfig()); err != nil {
+		t.Fatal(err)
+	}
+	defer func(quiet bool) { globalQuiet = quiet }(globalQuiet)
+
+	// Count the uses of the loaded configuration, a command loading it
+	// again would replace loadMcConfig.
+	var uses int
+	load := loadMcConfig
+	loadMcConfig = func() (*configV10, *probe.Error) {
+		uses++
+		return load()
+	}
+
+	dir := t.TempDir()
+	file := filepath.Join(dir, "file")
+	if e := os.WriteFile(file, []byte("data"), 0o600); e != nil {
+		t.Fatal(e)
+	}
+	app := cli.NewApp()
+	app.Name = "mc"
+	app.Commands = appCmds
+	app.Flags = globalFlags
+	cliCtx := cli.NewContext(app, nil, nil)
+	steps := []runStep{
+		{Line: 1, Args: []string{"mb", filepath.Join(dir, "a")}},
+		// Fails through a fatal error.
+		{Line: 2, Args: []string{"stat", filepath.Join(dir, "missing")}},
+		// Fails through the exit status of the command.
+		{Line: 3, Args: []string{"mb", filepath.Join(file, "b")}},
+		{Line: 4, Args: []string{"mb", filepath.Join(dir, "c")}},
+	}
+
+	failed, code := runScript(newRunScriptApp(cliCtx), []string{"--quiet"}, steps, "script.mcs", false)
+	if failed != 1 || code == 0 {
+		t.Fatalf("expected the script to stop at the first failure, got %d failures and exit code %d", failed, code)
+	}
+	if _, e := os.Stat(filepath.Join(dir, "c")); !os.IsNotExist(e) {
+		t.Fatal("expected the commands after the failure not to run")
+	}
+
+	failed, _ = runScript(newRunScriptApp(cliCtx), []string{"--quiet"}, steps, "script.mcs", true)
+	if failed != 2 {
+		t.Fatalf("expected 2 failures, got %d", failed)
+	}
+	for _, name := range []string{"a", "c"} {
+		if fi, e := os.Stat(filepath.Join(dir, name)); e != nil || !fi.IsDir() {
+			t.Fatalf("expected the folder %s to be created, got %v", name, e)
+		}
+	}
+
+	// The commands used the configuration loaded before the script.
+	used := uses
+	if used == 0 {
+		t.Fatal("expected the commands to use the loaded configuration")
+	}
+	loadMcConfig()
+	if uses != used+1 {
+		t.Fatal("expected the configuration not to be loaded again")
+	}
+	if globalQuiet {
+		t.Fatal("expected the global flags of the script to be reset")
+	}
+}
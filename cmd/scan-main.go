@@ -0,0 +1,286 @@
+// Copyright (c) 2015-2024 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/dustin/go-humanize"
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	json "github.com/minio/colorjson"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/pkg/v3/console"
+)
+
+var scanFlags = []cli.Flag{
+	cli.StringFlag{
+		Name:  "format",
+		Usage: "format of the manifest, 'csv' or 'json' (one object per line)",
+		Value: scanFormatCSV,
+	},
+	cli.StringFlag{
+		Name:  "output, o",
+		Usage: "write the manifest to a file instead of stdout",
+	},
+	cli.IntFlag{
+		Name:  "workers",
+		Usage: "number of top level prefixes listed in parallel",
+		Value: 16,
+	},
+	cli.BoolFlag{
+		Name:  "versions",
+		Usage: "include all object versions and delete markers",
+	},
+	cli.BoolFlag{
+		Name:  "tags",
+		Usage: "include object tags, tags not returned by the listing are fetched for each object",
+	},
+}
+
+// Generate a manifest of the objects of a bucket.
+var scanCmd = cli.Command{
+	Name:         "scan",
+	Usage:        "generate a manifest of objects",
+	Action:       mainScan,
+	OnUsageError: onUsageError,
+	Before:       setGlobalsFromContext,
+	Flags:        append(scanFlags, globalFlags...),
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} [FLAGS] TARGET
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+DESCRIPTION:
+  Scan lists all objects under TARGET and writes a manifest with the key, size, modification time,
+  ETag, version ID, storage class and tags of each object. Keys are relative to TARGET. Top level
+  prefixes are listed in parallel, so entries of different prefixes are not in lexical order.
+
+EXAMPLES:
+  1. Write a CSV manifest of the objects of a bucket.
+     {{.Prompt}} {{.HelpName}} --output inventory.csv myminio/mybucket
+
+  2. Write a manifest of all objects of all buckets, one JSON object per line, listing 64 prefixes in parallel.
+     {{.Prompt}} {{.HelpName}} --format json --workers 64 myminio > inventory.json
+
+  3. Write a manifest of all object versions and their tags.
+     {{.Prompt}} {{.HelpName}} --versions --tags --output inventory.csv myminio/mybucket
+`,
+}
+
+// scanMessage is the summary of a manifest written to a file.
+type scanMessage struct {
+	Status  string `json:"status"`
+	Output  string `json:"output"`
+	Objects int64  `json:"objects"`
+	Size    int64  `json:"size"`
+	Errors  int64  `json:"errors"`
+}
+
+func (s scanMessage) String() string {
+	msg := fmt.Sprintf("Wrote %d objects (%s) to `%s`.", s.Objects, humanize.IBytes(uint64(s.Size)), s.Output)
+	if s.Errors > 0 {
+		msg += fmt.Sprintf(" %d listing errors.", s.Errors)
+	}
+	return console.Colorize("Scan", msg)
+}
+
+func (s scanMessage) JSON() string {
+	s.Status = "success"
+	jsonMessageBytes, e := json.MarshalIndent(s, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+	return string(jsonMessageBytes)
+}
+
+// scanOptions are the options of a scan.
+type scanOptions struct {
+	workers  int
+	versions bool
+	tags     bool
+}
+
+// scanRecords lists the objects under urlStr and sends their manifest
+// entries, the top level prefixes are listed by opts.workers goroutines.
+// Listing errors are reported and counted in errs.
+func scanRecords(ctx context.Context, alias, urlStr string, opts scanOptions, errs *int64) <-chan scanRecord {
+	recordCh := make(chan scanRecord, 1000)
+
+	clnt, err := newClientFromAlias(alias, urlStr)
+	fatalIf(err.Trace(urlStr), "Unable to initialize target `"+urlStr+"`.")
+	root := clnt.GetURL()
+	separator := string(root.Separator)
+	rootPath := strings.TrimSuffix(root.Path, separator) + separator
+
+	var errMu sync.Mutex
+	listErr := func(err *probe.Error) {
+		errorIf(err, "Unable to list `%s`.", urlStr)
+		errMu.Lock()
+		*errs++
+		errMu.Unlock()
+	}
+
+	send := func(content *ClientContent) {
+		if content.Err != nil {
+			listErr(content.Err.Trace(urlStr))
+			return
+		}
+		if opts.tags && content.Tags == nil && !content.IsDeleteMarker {
+			content.Tags = scanObjectTags(ctx, alias, content)
+		}
+		select {
+		case <-ctx.Done():
+		case recordCh <- scanRecord{
+			Key:            strings.TrimPrefix(content.URL.Path, rootPath),
+			Size:           content.Size,
+			LastModified:   content.Time,
+			ETag:           content.ETag,
+			VersionID:      content.VersionID,
+			IsLatest:       content.IsLatest || !opts.versions,
+			IsDeleteMarker: content.IsDeleteMarker,
+			StorageClass:   content.StorageClass,
+			Tags:           content.Tags,
+		}:
+		}
+	}
+
+	listOpts := ListOptions{
+		WithMetadata:      opts.tags,
+		WithOlderVersions: opts.versions,
+		WithDeleteMarkers: opts.versions,
+		ShowDir:           DirNone,
+	}
+
+	prefixCh := make(chan string)
+	var wg sync.WaitGroup
+	for i := 0; i < opts.workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for prefix := range prefixCh {
+				prefixClnt, err := newClientFromAlias(alias, prefix)
+				if err != nil {
+					listErr(err.Trace(prefix))
+					continue
+				}
+				recursiveOpts := listOpts
+				recursiveOpts.Recursive = true
+				for content := range prefixClnt.List(ctx, recursiveOpts) {
+					send(content)
+				}
+			}
+		}()
+	}
+
+	go func() {
+		defer close(recordCh)
+		// Objects of the top level are sent right away, prefixes and
+		// buckets are listed by the workers.
+		for content := range clnt.List(ctx, listOpts) {
+			if content.Err == nil && content.Type.IsDir() {
+				prefix := strings.TrimSuffix(content.URL.String(), separator) + separator
+				select {
+				case <-ctx.Done():
+				case prefixCh <- prefix:
+				}
+				continue
+			}
+			send(content)
+		}
+		close(prefixCh)
+		wg.Wait()
+	}()
+	return recordCh
+}
+
+// scanObjectTags returns the tags of an object, errors are ignored since
+// some servers do not implement object tagging.
+func scanObjectTags(ctx context.Context, alias string, content *ClientContent) map[string]string {
+	clnt, err := newClientFromAlias(alias, content.URL.String())
+	if err != nil {
+		return nil
+	}
+	tags, err := clnt.GetTags(ctx, content.VersionID)
+	if err != nil {
+		return nil
+	}
+	return tags
+}
+
+// mainScan is the handle for "mc scan" command.
+func mainScan(cliCtx *cli.Context) error {
+	if len(cliCtx.Args()) != 1 {
+		showCommandHelpAndExit(cliCtx, 1) // last argument is exit code
+	}
+	if cliCtx.Int("workers") < 1 {
+		fatalIf(errInvalidArgument().Trace(cliCtx.Args()...), "`--workers` must be at least 1.")
+	}
+
+	format := cliCtx.String("format")
+	if format != scanFormatCSV && format != scanFormatJSON {
+		fatalIf(errInvalidArgument().Trace(format), "`--format` must be either 'csv' or 'json'.")
+	}
+
+	console.SetColor("Scan", color.New(color.FgGreen, color.Bold))
+
+	ctx, cancelScan := context.WithCancel(globalContext)
+	defer cancelScan()
+
+	var out io.Writer = os.Stdout
+	output := cliCtx.String("output")
+	if output != "" {
+		f, e := os.Create(output)
+		fatalIf(probe.NewError(e).Trace(output), "Unable to create the manifest file.")
+		defer f.Close()
+		out = f
+	}
+	writer, _ := newScanWriter(out, format)
+
+	targetURL := cliCtx.Args().Get(0)
+	alias, urlStr, _ := mustExpandAlias(targetURL)
+
+	var summary scanMessage
+	for r := range scanRecords(ctx, alias, urlStr, scanOptions{
+		workers:  cliCtx.Int("workers"),
+		versions: cliCtx.Bool("versions"),
+		tags:     cliCtx.Bool("tags"),
+	}, &summary.Errors) {
+		e := writer.Write(r)
+		fatalIf(probe.NewError(e).Trace(output), "Unable to write the manifest.")
+		summary.Objects++
+		summary.Size += r.Size
+	}
+	fatalIf(probe.NewError(writer.Close()).Trace(output), "Unable to write the manifest.")
+
+	if output != "" {
+		summary.Output = output
+		printMsg(summary)
+	}
+	if summary.Errors > 0 {
+		return exitStatus(globalErrorExitStatus)
+	}
+	return nil
+}
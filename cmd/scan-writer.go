@@ -0,0 +1,122 @@
+// Copyright (c) 2015-2024 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// Formats of the manifest written by scan.
+const (
+	scanFormatCSV  = "csv"
+	scanFormatJSON = "json"
+)
+
+// scanRecord is the manifest entry of an object version.
+type scanRecord struct {
+	Key            string            `json:"key"`
+	Size           int64             `json:"size"`
+	LastModified   time.Time         `json:"lastModified"`
+	ETag           string            `json:"etag"`
+	VersionID      string            `json:"versionId,omitempty"`
+	IsLatest       bool              `json:"isLatest"`
+	IsDeleteMarker bool              `json:"isDeleteMarker"`
+	StorageClass   string            `json:"storageClass,omitempty"`
+	Tags           map[string]string `json:"tags,omitempty"`
+}
+
+// scanWriter writes the records of a manifest.
+type scanWriter interface {
+	Write(r scanRecord) error
+	Close() error
+}
+
+// newScanWriter returns a writer of manifests in format, ok is false for
+// an unknown format.
+func newScanWriter(w io.Writer, format string) (sw scanWriter, ok bool) {
+	switch format {
+	case scanFormatCSV:
+		return &csvScanWriter{w: csv.NewWriter(w)}, true
+	case scanFormatJSON:
+		bw := bufio.NewWriter(w)
+		return &jsonScanWriter{bw: bw, enc: json.NewEncoder(bw)}, true
+	}
+	return nil, false
+}
+
+var scanCSVHeader = []string{"key", "size", "lastModified", "etag", "versionId", "isLatest", "isDeleteMarker", "storageClass", "tags"}
+
+// csvScanWriter writes a CSV manifest with a header line, tags are
+// encoded like the x-amz-tagging header.
+type csvScanWriter struct {
+	w             *csv.Writer
+	headerWritten bool
+}
+
+func (c *csvScanWriter) Write(r scanRecord) error {
+	if !c.headerWritten {
+		c.headerWritten = true
+		if e := c.w.Write(scanCSVHeader); e != nil {
+			return e
+		}
+	}
+	tags := make(url.Values, len(r.Tags))
+	for k, v := range r.Tags {
+		tags.Set(k, v)
+	}
+	return c.w.Write([]string{
+		r.Key,
+		strconv.FormatInt(r.Size, 10),
+		r.LastModified.UTC().Format(time.RFC3339Nano),
+		r.ETag,
+		r.VersionID,
+		strconv.FormatBool(r.IsLatest),
+		strconv.FormatBool(r.IsDeleteMarker),
+		r.StorageClass,
+		tags.Encode(),
+	})
+}
+
+func (c *csvScanWriter) Close() error {
+	if !c.headerWritten {
+		c.headerWritten = true
+		c.w.Write(scanCSVHeader)
+	}
+	c.w.Flush()
+	return c.w.Error()
+}
+
+// jsonScanWriter writes a manifest of one JSON object per line.
+type jsonScanWriter struct {
+	bw  *bufio.Writer
+	enc *json.Encoder
+}
+
+func (j *jsonScanWriter) Write(r scanRecord) error {
+	return j.enc.Encode(r)
+}
+
+func (j *jsonScanWriter) Close() error {
+	return j.bw.Flush()
+}
@@ -0,0 +1,65 @@
+// Copyright (c) 2015-2024 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bytes"
+	"testing"
+	"time"
+)
+
+func TestScanWriter(t *testing.T) {
+	records := []scanRecord{
+		{Key: "a/1", Size: 4, LastModified: time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC), ETag: "e1", IsLatest: true, StorageClass: "STANDARD", Tags: map[string]string{"b": "2 3", "a": "1"}},
+		{Key: "a,2", VersionID: "v1", IsDeleteMarker: true, LastModified: time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)},
+	}
+	testCases := []struct {
+		format   string
+		expected string
+	}{
+		{scanFormatCSV, `key,size,lastModified,etag,versionId,isLatest,isDeleteMarker,storageClass,tags
+a/1,4,2024-01-02T03:04:05Z,e1,,true,false,STANDARD,a=1&b=2+3
+"a,2",0,2024-01-02T03:04:05Z,,v1,false,true,,
+`},
+		{scanFormatJSON, `{"key":"a/1","size":4,"lastModified":"2024-01-02T03:04:05Z","etag":"e1","isLatest":true,"isDeleteMarker":false,"storageClass":"STANDARD","tags":{"a":"1","b":"2 3"}}
+{"key":"a,2","size":0,"lastModified":"2024-01-02T03:04:05Z","etag":"","versionId":"v1","isLatest":false,"isDeleteMarker":true}
+`},
+	}
+	for _, tc := range testCases {
+		var buf bytes.Buffer
+		w, ok := newScanWriter(&buf, tc.format)
+		if !ok {
+			t.Fatalf("%s: unknown format", tc.format)
+		}
+		for _, r := range records {
+			if e := w.Write(r); e != nil {
+				t.Fatal(e)
+			}
+		}
+		if e := w.Close(); e != nil {
+			t.Fatal(e)
+		}
+		if buf.String() != tc.expected {
+			t.Errorf("%s: expected\n%s\ngot\n%s", tc.format, tc.expected, buf.String())
+		}
+	}
+
+	if _, ok := newScanWriter(&bytes.Buffer{}, "parquet"); ok {
+		t.Fatal("expected an unknown format")
+	}
+}
@@ -0,0 +1,415 @@
+// Copyright (c) 2015-2024 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/minio/cli"
+)
+
+// Status of the jobs handled by 'mc serve'.
+const (
+	serveJobQueued    = "queued"
+	serveJobRunning   = "running"
+	serveJobSucceeded = "succeeded"
+	serveJobFailed    = "failed"
+	serveJobCanceled  = "canceled"
+)
+
+const (
+	// Number of output messages kept for each job.
+	serveJobMaxMessages = 100
+	// Number of finished jobs kept in memory, the oldest are dropped.
+	serveMaxFinishedJobs = 1000
+	// Time given to a canceled job to stop before it is killed.
+	serveJobCancelDelay = 10 * time.Second
+)
+
+// serveJobCommands are the commands which can be submitted as jobs.
+var serveJobCommands = []string{"cp", "mirror", "rm"}
+
+// serveJobLocalFlags are the flags of the job commands which read or
+// write files, or listen, on the host of the server.
+var serveJobLocalFlags = []string{
+	"manifest", "manifest-sign-key", "enc-c-file", "state-file", "failure-journal", "retry-journal",
+	"install-service", "monitoring-address", "health-address", "event-source", "watch-events",
+}
+
+// serveJobCommandFlags returns the flags of a job command.
+func serveJobCommandFlags(command string) []cli.Flag {
+	switch command {
+	case "cp":
+		return cpCmd.Flags
+	case "mirror":
+		return mirrorCmd.Flags
+	case "rm":
+		return rmCmd.Flags
+	}
+	return nil
+}
+
+// serveJobPositionalArgs returns the arguments of a job which are not
+// flags or values of flags.
+func serveJobPositionalArgs(flags []cli.Flag, args []string) []string {
+	takesValue := make(map[string]bool)
+	for _, f := range flags {
+		switch f.(type) {
+		case cli.BoolFlag, cli.BoolTFlag:
+			continue
+		}
+		for _, name := range strings.Split(f.GetName(), ",") {
+			takesValue[strings.TrimSpace(name)] = true
+		}
+	}
+	var positional []string
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if arg == "--" {
+			return append(positional, args[i+1:]...)
+		}
+		if !strings.HasPrefix(arg, "-") || arg == "-" {
+			positional = append(positional, arg)
+			continue
+		}
+		if name := strings.TrimLeft(arg, "-"); !strings.Contains(name, "=") && takesValue[name] {
+			// The value is the next argument.
+			i++
+		}
+	}
+	return positional
+}
+
+// serveJobRequest is the body of a job submission.
+type serveJobRequest struct {
+	Command string   `json:"command"`
+	Args    []string `json:"args"`
+}
+
+// validate checks the job can be run, jobs only access the aliases of
+// the server unless allowLocal is set.
+func (r serveJobRequest) validate(allowLocal bool) error {
+	found := false
+	for _, cmd := range serveJobCommands {
+		if r.Command == cmd {
+			found = true
+			break
+		}
+	}
+	if !found {
+		return fmt.Errorf("unsupported command `%s`, valid options are %v", r.Command, serveJobCommands)
+	}
+	if len(r.Args) == 0 {
+		return errors.New("missing arguments")
+	}
+	for _, arg := range r.Args {
+		if !strings.HasPrefix(arg, "-") {
+			continue
+		}
+		// Jobs use the configuration and the output format of the server.
+		name := strings.SplitN(strings.TrimLeft(arg, "-"), "=", 2)[0]
+		switch name {
+		case "config-dir", "C", "json", "help", "h":
+			return fmt.Errorf("flag `%s` is not allowed in a job", arg)
+		}
+		if !allowLocal {
+			for _, localFlag := range serveJobLocalFlags {
+				if name == localFlag {
+					return fmt.Errorf("flag `%s` uses the local filesystem of the server, which is not allowed without --allow-local", arg)
+				}
+			}
+		}
+	}
+	if allowLocal {
+		return nil
+	}
+	for _, arg := range serveJobPositionalArgs(serveJobCommandFlags(r.Command), r.Args) {
+		if alias, _ := url2Alias(arg); mustGetHostConfig(alias) == nil {
+			return fmt.Errorf("`%s` is not an alias of the server, local paths are not allowed without --allow-local", arg)
+		}
+	}
+	return nil
+}
+
+// serveJob is a job submitted to 'mc serve'.
+type serveJob struct {
+	ID        string            `json:"id"`
+	Command   string            `json:"command"`
+	Args      []string          `json:"args"`
+	Status    string            `json:"status"`
+	ExitCode  int               `json:"exitCode"`
+	Error     string            `json:"error,omitempty"`
+	Submitted time.Time         `json:"submitted"`
+	Started   *time.Time        `json:"started,omitempty"`
+	Finished  *time.Time        `json:"finished,omitempty"`
+	Messages  []json.RawMessage `json:"messages,omitempty"`
+
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+// serveJobRunner runs a job, output is called for each message printed
+// by the job.
+type serveJobRunner func(ctx context.Context, job serveJob, output func(msg []byte)) (exitCode int, err error)
+
+// servePool runs the jobs submitted to 'mc serve' with a fixed number of
+// workers, jobs are run in submission order.
+type servePool struct {
+	mu       sync.Mutex
+	jobs     map[string]*serveJob
+	order    []string
+	queue    chan *serveJob
+	run      serveJobRunner
+	wg       sync.WaitGroup
+	finished int
+	// Jobs may access the local filesystem of the server.
+	allowLocal bool
+}
+
+func newServePool(ctx context.Context, workers int, allowLocal bool, run serveJobRunner) *servePool {
+	p := &servePool{
+		jobs:       make(map[string]*serveJob),
+		queue:      make(chan *serveJob, 10000),
+		run:        run,
+		allowLocal: allowLocal,
+	}
+	for i := 0; i < workers; i++ {
+		p.wg.Add(1)
+		go func() {
+			defer p.wg.Done()
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case job := <-p.queue:
+					p.runJob(job)
+				}
+			}
+		}()
+	}
+	return p
+}
+
+// submit queues a new job, the job is canceled with ctx.
+func (p *servePool) submit(ctx context.Context, req serveJobRequest) (serveJob, error) {
+	if e := req.validate(p.allowLocal); e != nil {
+		return serveJob{}, e
+	}
+	jobCtx, cancel := context.WithCancel(ctx)
+	job := &serveJob{
+		ID:        uuid.NewString(),
+		Command:   req.Command,
+		Args:      req.Args,
+		Status:    serveJobQueued,
+		Submitted: UTCNow(),
+		ctx:       jobCtx,
+		cancel:    cancel,
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	select {
+	case p.queue <- job:
+	default:
+		cancel()
+		return serveJob{}, errors.New("too many queued jobs")
+	}
+	p.jobs[job.ID] = job
+	p.order = append(p.order, job.ID)
+	return job.snapshot(), nil
+}
+
+func (p *servePool) runJob(job *serveJob) {
+	p.mu.Lock()
+	if job.Status != serveJobQueued {
+		// Canceled while it was queued.
+		p.mu.Unlock()
+		return
+	}
+	started := UTCNow()
+	job.Status = serveJobRunning
+	job.Started = &started
+	req := job.snapshot()
+	p.mu.Unlock()
+
+	exitCode, e := p.run(job.ctx, req, func(msg []byte) {
+		if !json.Valid(msg) {
+			msg, _ = json.Marshal(string(msg))
+		}
+		p.mu.Lock()
+		defer p.mu.Unlock()
+		if len(job.Messages) == serveJobMaxMessages {
+			job.Messages = job.Messages[1:]
+		}
+		job.Messages = append(job.Messages, json.RawMessage(msg))
+	})
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	finished := UTCNow()
+	job.Finished = &finished
+	job.ExitCode = exitCode
+	switch {
+	case job.ctx.Err() != nil:
+		job.Status = serveJobCanceled
+	case e != nil || exitCode != 0:
+		job.Status = serveJobFailed
+	default:
+		job.Status = serveJobSucceeded
+	}
+	if e != nil {
+		job.Error = e.Error()
+	}
+	job.cancel()
+	p.finished++
+	p.dropFinished()
+}
+
+// dropFinished forgets the oldest finished jobs, must be called with
+// the lock held.
+func (p *servePool) dropFinished() {
+	if p.finished <= serveMaxFinishedJobs {
+		return
+	}
+	order := p.order[:0]
+	for _, id := range p.order {
+		job := p.jobs[id]
+		if p.finished > serveMaxFinishedJobs && job.Finished != nil {
+			delete(p.jobs, id)
+			p.finished--
+			continue
+		}
+		order = append(order, id)
+	}
+	p.order = order
+}
+
+// get returns a job by its id.
+func (p *servePool) get(id string) (serveJob, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	job, ok := p.jobs[id]
+	if !ok {
+		return serveJob{}, false
+	}
+	return job.snapshot(), true
+}
+
+// list returns all the jobs in submission order, without their messages.
+func (p *servePool) list() []serveJob {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	jobs := make([]serveJob, 0, len(p.order))
+	for _, id := range p.order {
+		job := p.jobs[id].snapshot()
+		job.Messages = nil
+		jobs = append(jobs, job)
+	}
+	return jobs
+}
+
+// cancel stops a queued or running job.
+func (p *servePool) cancel(id string) (serveJob, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	job, ok := p.jobs[id]
+	if !ok {
+		return serveJob{}, false
+	}
+	if job.Status == serveJobQueued {
+		finished := UTCNow()
+		job.Status = serveJobCanceled
+		job.Finished = &finished
+		p.finished++
+	}
+	job.cancel()
+	return job.snapshot(), true
+}
+
+// wait waits for the workers to stop once the pool context is canceled.
+func (p *servePool) wait() {
+	p.wg.Wait()
+}
+
+// snapshot returns a copy of the job safe to use without the pool lock.
+func (j *serveJob) snapshot() serveJob {
+	job := *j
+	job.Args = append([]string(nil), j.Args...)
+	job.Messages = append([]json.RawMessage(nil), j.Messages...)
+	return job
+}
+
+// runServeJobProcess runs a job in a child mc process with JSON output,
+// a failing job cannot take the server down.
+func runServeJobProcess(ctx context.Context, job serveJob, output func(msg []byte)) (int, error) {
+	self, e := os.Executable()
+	if e != nil {
+		return -1, e
+	}
+	args := append([]string{job.Command, "--json", "--no-color", "--disable-pager", "--config-dir", mustGetMcConfigDir()}, job.Args...)
+	cmd := exec.CommandContext(ctx, self, args...)
+	cmd.Cancel = func() error {
+		// Give the job a chance to stop cleanly.
+		return cmd.Process.Signal(os.Interrupt)
+	}
+	cmd.WaitDelay = serveJobCancelDelay
+
+	stdout, e := cmd.StdoutPipe()
+	if e != nil {
+		return -1, e
+	}
+	var stderr strings.Builder
+	cmd.Stderr = &stderr
+	if e = cmd.Start(); e != nil {
+		return -1, e
+	}
+
+	scanner := bufio.NewScanner(stdout)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		if line := scanner.Bytes(); len(line) > 0 {
+			output(append([]byte(nil), line...))
+		}
+	}
+	// Drain the output in case of a too long line.
+	io.Copy(io.Discard, stdout)
+
+	e = cmd.Wait()
+	var exitErr *exec.ExitError
+	if errors.As(e, &exitErr) {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return exitErr.ExitCode(), errors.New(msg)
+		}
+		return exitErr.ExitCode(), nil
+	}
+	if e != nil {
+		return -1, e
+	}
+	return 0, nil
+}
@@ -0,0 +1,271 @@
+// Copyright (c) 2015-2024 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"crypto/rand"
+	cryptosubtle "crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	jsoncolor "github.com/minio/colorjson"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/pkg/v3/console"
+)
+
+var serveFlags = []cli.Flag{
+	cli.StringFlag{
+		Name:  "listen",
+		Value: "127.0.0.1:9099",
+		Usage: "address to listen on for the job API, only reachable from the local host by default",
+	},
+	cli.StringFlag{
+		Name:  "tls-cert",
+		Usage: "serve the job API over HTTPS with this PEM encoded certificate, requires --tls-key",
+	},
+	cli.StringFlag{
+		Name:  "tls-key",
+		Usage: "PEM encoded private key of --tls-cert",
+	},
+	cli.BoolFlag{
+		Name:  "allow-local",
+		Usage: "allow jobs to read and write the local filesystem of the server, by default jobs only access its aliases",
+	},
+	cli.StringFlag{
+		Name:   "auth-token",
+		Usage:  "bearer token required by the job API, a random token is generated when not set",
+		EnvVar: "MC_SERVE_AUTH_TOKEN",
+	},
+	cli.IntFlag{
+		Name:  "workers",
+		Value: 4,
+		Usage: "number of jobs run concurrently",
+	},
+}
+
+// run mc as a daemon handling transfer jobs.
+var serveCmd = cli.Command{
+	Name:         "serve",
+	Usage:        "run a daemon handling cp, mirror and rm jobs submitted over a REST API",
+	Action:       mainServe,
+	OnUsageError: onUsageError,
+	Before:       setGlobalsFromContext,
+	Flags:        append(serveFlags, globalFlags...),
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} [FLAGS]
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+API:
+  All the requests require the 'Authorization: Bearer TOKEN' header.
+
+  POST   /v1/jobs       submit a job, e.g. {"command": "mirror", "args": ["--overwrite", "src/bucket", "dst/bucket"]}
+  GET    /v1/jobs       list the jobs
+  GET    /v1/jobs/{id}  status of a job with its last JSON messages
+  DELETE /v1/jobs/{id}  cancel a queued or running job
+
+DESCRIPTION:
+  Jobs are run by a pool of workers in submission order, each job runs in its own mc process
+  with the aliases of the server and reports the messages of 'mc --json'. The status of a job
+  is one of 'queued', 'running', 'succeeded', 'failed' or 'canceled'.
+
+  Jobs can only use the aliases of the server as arguments, local paths and the flags reading or
+  writing local files are refused unless --allow-local is set. The job API is served over plain
+  HTTP on the local interface by default, use --tls-cert and --tls-key before listening on other
+  interfaces, the auth token is sent with every request.
+
+EXAMPLES:
+  1. Serve the job API on the local interface with 8 workers.
+     {{.Prompt}} {{.HelpName}} --workers 8 --auth-token "$TOKEN"
+
+  2. Serve the job API over HTTPS on all the interfaces.
+     {{.Prompt}} {{.HelpName}} --listen :9099 --tls-cert public.crt --tls-key private.key --auth-token "$TOKEN"
+
+  3. Submit a copy job and check its status.
+     {{.Prompt}} curl -H "Authorization: Bearer $TOKEN" -d '{"command": "cp", "args": ["-r", "play/photos/", "s3/photos/"]}' \
+                 http://127.0.0.1:9099/v1/jobs
+     {{.Prompt}} curl -H "Authorization: Bearer $TOKEN" http://127.0.0.1:9099/v1/jobs/7d9c3a1e-96f2-4a4b-8d8a-5d0c2b2f1a7e
+`,
+}
+
+// serveMessage is printed when the server starts.
+type serveMessage struct {
+	Status  string `json:"status"`
+	Listen  string `json:"listen"`
+	Token   string `json:"token,omitempty"`
+	Workers int    `json:"workers"`
+}
+
+func (s serveMessage) String() string {
+	msg := console.Colorize("Serve", fmt.Sprintf("Serving the job API on `%s` with %d workers.", s.Listen, s.Workers))
+	if s.Token != "" {
+		msg += "\n" + console.Colorize("Serve", "Generated auth token: "+s.Token)
+	}
+	return msg
+}
+
+func (s serveMessage) JSON() string {
+	s.Status = "success"
+	serveMessageBytes, e := jsoncolor.MarshalIndent(s, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+
+	return string(serveMessageBytes)
+}
+
+// writeServeJSON writes a JSON response of the job API.
+func writeServeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+func writeServeError(w http.ResponseWriter, status int, e error) {
+	writeServeJSON(w, status, map[string]string{"error": e.Error()})
+}
+
+// newServeHandler returns the handler of the job API.
+func newServeHandler(ctx context.Context, pool *servePool, token string) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /v1/jobs", func(w http.ResponseWriter, r *http.Request) {
+		var req serveJobRequest
+		dec := json.NewDecoder(http.MaxBytesReader(w, r.Body, 1<<20))
+		dec.DisallowUnknownFields()
+		if e := dec.Decode(&req); e != nil {
+			writeServeError(w, http.StatusBadRequest, e)
+			return
+		}
+		job, e := pool.submit(ctx, req)
+		if e != nil {
+			writeServeError(w, http.StatusBadRequest, e)
+			return
+		}
+		writeServeJSON(w, http.StatusCreated, job)
+	})
+	mux.HandleFunc("GET /v1/jobs", func(w http.ResponseWriter, _ *http.Request) {
+		writeServeJSON(w, http.StatusOK, pool.list())
+	})
+	mux.HandleFunc("GET /v1/jobs/{id}", func(w http.ResponseWriter, r *http.Request) {
+		job, ok := pool.get(r.PathValue("id"))
+		if !ok {
+			writeServeError(w, http.StatusNotFound, errors.New("job not found"))
+			return
+		}
+		writeServeJSON(w, http.StatusOK, job)
+	})
+	mux.HandleFunc("DELETE /v1/jobs/{id}", func(w http.ResponseWriter, r *http.Request) {
+		job, ok := pool.cancel(r.PathValue("id"))
+		if !ok {
+			writeServeError(w, http.StatusNotFound, errors.New("job not found"))
+			return
+		}
+		writeServeJSON(w, http.StatusAccepted, job)
+	})
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !ok || cryptosubtle.ConstantTimeCompare([]byte(auth), []byte(token)) != 1 {
+			writeServeError(w, http.StatusUnauthorized, errors.New("invalid or missing bearer token"))
+			return
+		}
+		mux.ServeHTTP(w, r)
+	})
+}
+
+// newServeToken generates a random auth token.
+func newServeToken() string {
+	b := make([]byte, 24)
+	_, e := rand.Read(b)
+	fatalIf(probe.NewError(e), "Unable to generate an auth token.")
+	return hex.EncodeToString(b)
+}
+
+// checkServeSyntax - validate all the passed arguments
+func checkServeSyntax(ctx *cli.Context) {
+	if len(ctx.Args()) != 0 {
+		showCommandHelpAndExit(ctx, 1) // last argument is exit code
+	}
+	if ctx.Int("workers") < 1 {
+		fatalIf(errInvalidArgument().Trace(ctx.String("workers")), "--workers must be at least 1.")
+	}
+	if _, _, e := net.SplitHostPort(ctx.String("listen")); e != nil {
+		fatalIf(probe.NewError(e).Trace(ctx.String("listen")), "Invalid listen address.")
+	}
+	if (ctx.String("tls-cert") == "") != (ctx.String("tls-key") == "") {
+		fatalIf(errInvalidArgument().Trace(ctx.String("tls-cert"), ctx.String("tls-key")), "--tls-cert and --tls-key must be used together.")
+	}
+}
+
+func mainServe(cliCtx *cli.Context) error {
+	checkServeSyntax(cliCtx)
+	console.SetColor("Serve", color.New(color.FgGreen))
+
+	token := cliCtx.String("auth-token")
+	msg := serveMessage{
+		Listen:  cliCtx.String("listen"),
+		Workers: cliCtx.Int("workers"),
+	}
+	if token == "" {
+		token = newServeToken()
+		msg.Token = token
+	}
+
+	ctx, cancel := context.WithCancel(globalContext)
+	defer cancel()
+
+	pool := newServePool(ctx, msg.Workers, cliCtx.Bool("allow-local"), runServeJobProcess)
+	listener, e := net.Listen("tcp", msg.Listen)
+	fatalIf(probe.NewError(e).Trace(msg.Listen), "Unable to listen for the job API.")
+
+	server := &http.Server{
+		Handler:           newServeHandler(ctx, pool, token),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), serveJobCancelDelay)
+		defer cancel()
+		server.Shutdown(shutdownCtx)
+	}()
+
+	printMsg(msg)
+	if certFile := cliCtx.String("tls-cert"); certFile != "" {
+		e = server.ServeTLS(listener, certFile, cliCtx.String("tls-key"))
+	} else {
+		e = server.Serve(listener)
+	}
+	if e != nil && !errors.Is(e, http.ErrServerClosed) {
+		fatalIf(probe.NewError(e), "Unable to serve the job API.")
+	}
+
+	// Running jobs were canceled with the context, wait for them to stop.
+	pool.wait()
+	return nil
+}
@@ -19,11 +19,15 @@ package cmd
 
 import (
 	"context"
+	"fmt"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/minio/cli"
+	json "github.com/minio/colorjson"
 	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/pkg/v3/console"
 )
 
 var shareDownloadFlags = []cli.Flag{
@@ -36,6 +40,20 @@ var shareDownloadFlags = []cli.Flag{
 		Usage: "share a particular object version",
 	},
 	shareFlagExpire,
+	cli.StringFlag{
+		Name:  "manifest",
+		Usage: "write the URLs to a manifest file instead of printing them, '-' writes to stdout",
+	},
+	cli.StringFlag{
+		Name:  "format",
+		Usage: "format of the manifest, one of 'csv' or 'json'",
+		Value: "csv",
+	},
+	cli.IntFlag{
+		Name:  "workers",
+		Usage: "number of URLs generated in parallel with --manifest",
+		Value: 8,
+	},
 }
 
 // Share documents via URL.
@@ -67,6 +85,12 @@ EXAMPLES:
 
   4. Share all objects under this bucket and all its folders and sub-folders with 5 days expiry.
      {{.Prompt}} {{.HelpName}} --recursive --expire=120h s3/backup/
+
+  5. Write the URLs of all objects under this bucket with 1 day expiry to a CSV manifest.
+     {{.Prompt}} {{.HelpName}} --recursive --expire=24h --manifest urls.csv s3/backup/
+
+  6. Write the URLs of all objects under this folder as JSON lines to stdout, generating 32 URLs at a time.
+     {{.Prompt}} {{.HelpName}} --recursive --manifest - --format json --workers 32 s3/backup/2006-Mar-1/
 `,
 }
 
@@ -96,6 +120,21 @@ func checkShareDownloadSyntax(ctx context.Context, cliCtx *cli.Context, encKeyDB
 
 	isRecursive := cliCtx.Bool("recursive")
 
+	if cliCtx.String("manifest") == "" {
+		for _, flag := range []string{"format", "workers"} {
+			if cliCtx.IsSet(flag) {
+				fatalIf(errInvalidArgument().Trace(flag), "--"+flag+" requires --manifest.")
+			}
+		}
+	} else {
+		if format := strings.ToLower(cliCtx.String("format")); format != "csv" && format != "json" {
+			fatalIf(errInvalidArgument().Trace(format), "--format must be one of 'csv' or 'json'.")
+		}
+		if cliCtx.Int("workers") < 1 {
+			fatalIf(errInvalidArgument().Trace(), "--workers must be at least 1.")
+		}
+	}
+
 	versionID := cliCtx.String("version-id")
 	if versionID != "" && isRecursive {
 		fatalIf(errDummy().Trace(), "--version-id cannot be specified with --recursive flag.")
@@ -112,23 +151,16 @@ func checkShareDownloadSyntax(ctx context.Context, cliCtx *cli.Context, encKeyDB
 	}
 }
 
-// doShareURL share files from target.
-func doShareDownloadURL(ctx context.Context, targetURL, versionID string, isRecursive bool, expiry time.Duration) *probe.Error {
+// listShareDownloadObjects sends the objects of targetURL whose URLs need
+// to be shared, all the objects under it when it is a folder.
+func listShareDownloadObjects(ctx context.Context, targetURL, versionID string, isRecursive bool) (string, Client, <-chan *ClientContent, *probe.Error) {
 	targetAlias, targetURLFull, _, err := expandAlias(targetURL)
 	if err != nil {
-		return err.Trace(targetURL)
+		return "", nil, nil, err.Trace(targetURL)
 	}
 	clnt, err := newClientFromAlias(targetAlias, targetURLFull)
 	if err != nil {
-		return err.Trace(targetURL)
-	}
-
-	// Load previously saved upload-shares. Add new entries and write it back.
-	shareDB := newShareDBV1()
-	shareDownloadsFile := getShareDownloadsFile()
-	err = shareDB.Load(shareDownloadsFile)
-	if err != nil {
-		return err.Trace(shareDownloadsFile)
+		return "", nil, nil, err.Trace(targetURL)
 	}
 
 	// Channel which will receive objects whose URLs need to be shared
@@ -136,7 +168,7 @@ func doShareDownloadURL(ctx context.Context, targetURL, versionID string, isRecu
 
 	content, err := clnt.Stat(ctx, StatOptions{versionID: versionID})
 	if err != nil {
-		return err.Trace(clnt.GetURL().String())
+		return "", nil, nil, err.Trace(clnt.GetURL().String())
 	}
 
 	if !content.Type.IsDir() {
@@ -150,16 +182,37 @@ func doShareDownloadURL(ctx context.Context, targetURL, versionID string, isRecu
 		}
 		clnt, err = newClientFromAlias(targetAlias, targetURLFull)
 		if err != nil {
-			return err.Trace(targetURLFull)
+			return "", nil, nil, err.Trace(targetURLFull)
 		}
 		// Recursive mode: Share list of objects
 		go func() {
 			defer close(objectsCh)
 			for content := range clnt.List(ctx, ListOptions{Recursive: isRecursive, ShowDir: DirNone}) {
-				objectsCh <- content
+				select {
+				case objectsCh <- content:
+				case <-ctx.Done():
+					return
+				}
 			}
 		}()
 	}
+	return targetAlias, clnt, objectsCh, nil
+}
+
+// doShareURL share files from target.
+func doShareDownloadURL(ctx context.Context, targetURL, versionID string, isRecursive bool, expiry time.Duration) *probe.Error {
+	// Load previously saved upload-shares. Add new entries and write it back.
+	shareDB := newShareDBV1()
+	shareDownloadsFile := getShareDownloadsFile()
+	err := shareDB.Load(shareDownloadsFile)
+	if err != nil {
+		return err.Trace(shareDownloadsFile)
+	}
+
+	targetAlias, clnt, objectsCh, err := listShareDownloadObjects(ctx, targetURL, versionID, isRecursive)
+	if err != nil {
+		return err
+	}
 
 	// Iterate over all objects to generate share URL
 	for content := range objectsCh {
@@ -199,6 +252,94 @@ func doShareDownloadURL(ctx context.Context, targetURL, versionID string, isRecu
 	return shareDB.Save(shareDownloadsFile)
 }
 
+// doShareDownloadManifest writes the URLs of targetURL to the manifest,
+// generating up to workers URLs in parallel. Manifest URLs are not saved
+// to the list of shared URLs. Returns the number of URLs written.
+func doShareDownloadManifest(ctx context.Context, targetURL, versionID string, isRecursive bool, expiry time.Duration, mw *shareManifestWriter, workers int) (int, *probe.Error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	targetAlias, clnt, objectsCh, err := listShareDownloadObjects(ctx, targetURL, versionID, isRecursive)
+	if err != nil {
+		return 0, err
+	}
+
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		firstErr *probe.Error
+		count    int
+	)
+	setErr := func(err *probe.Error) {
+		mu.Lock()
+		if firstErr == nil {
+			firstErr = err
+		}
+		mu.Unlock()
+		cancel()
+	}
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for content := range objectsCh {
+				if content.Err != nil {
+					setErr(content.Err.Trace(clnt.GetURL().String()))
+					continue
+				}
+				if content.Type.IsDir() || ctx.Err() != nil {
+					continue
+				}
+				objectURL := content.URL.String()
+				newClnt, err := newClientFromAlias(targetAlias, objectURL)
+				if err != nil {
+					setErr(err.Trace(objectURL))
+					continue
+				}
+				expires := time.Now().Add(expiry)
+				shareURL, err := newClnt.ShareDownload(ctx, content.VersionID, expiry)
+				if err != nil {
+					setErr(err.Trace(objectURL, "expiry="+expiry.String()))
+					continue
+				}
+				if e := mw.Write(shareManifestEntry{
+					Object:    objectURL,
+					VersionID: content.VersionID,
+					URL:       shareURL,
+					Expires:   expires,
+				}); e != nil {
+					setErr(probe.NewError(e))
+					continue
+				}
+				mu.Lock()
+				count++
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+	return count, firstErr
+}
+
+// shareManifestMessage is printed once a manifest was written.
+type shareManifestMessage struct {
+	Status   string        `json:"status"`
+	Manifest string        `json:"manifest"`
+	URLs     int           `json:"urls"`
+	TimeLeft time.Duration `json:"timeLeft"`
+}
+
+func (s shareManifestMessage) String() string {
+	return console.Colorize("Share", fmt.Sprintf("Wrote %d URLs expiring in %s to %s.", s.URLs, timeDurationToHumanizedDuration(s.TimeLeft), s.Manifest))
+}
+
+func (s shareManifestMessage) JSON() string {
+	s.Status = "success"
+	jsonMessageBytes, e := json.MarshalIndent(s, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+	return string(jsonMessageBytes)
+}
+
 // main for share download.
 func mainShareDownload(cliCtx *cli.Context) error {
 	ctx, cancelShareDownload := context.WithCancel(globalContext)
@@ -227,6 +368,32 @@ func mainShareDownload(cliCtx *cli.Context) error {
 		fatalIf(probe.NewError(e), "Unable to parse expire=`"+cliCtx.String("expire")+"`.")
 	}
 
+	if manifest := cliCtx.String("manifest"); manifest != "" {
+		mw, e := newShareManifestWriter(manifest, cliCtx.String("format"))
+		fatalIf(probe.NewError(e).Trace(manifest), "Unable to create the manifest.")
+		var count int
+		for _, targetURL := range cliCtx.Args() {
+			n, err := doShareDownloadManifest(ctx, targetURL, versionID, isRecursive, expiry, mw, cliCtx.Int("workers"))
+			count += n
+			if err != nil {
+				mw.Close()
+				if _, ok := err.ToGoError().(APINotImplemented); ok {
+					fatalIf(err.Trace(), "Unable to share a non S3 url `"+targetURL+"`.")
+				}
+				fatalIf(err.Trace(targetURL), "Unable to share target `"+targetURL+"`.")
+			}
+		}
+		fatalIf(probe.NewError(mw.Close()).Trace(manifest), "Unable to write the manifest.")
+		if manifest != "-" {
+			printMsg(shareManifestMessage{
+				Manifest: manifest,
+				URLs:     count,
+				TimeLeft: expiry,
+			})
+		}
+		return nil
+	}
+
 	for _, targetURL := range cliCtx.Args() {
 		err := doShareDownloadURL(ctx, targetURL, versionID, isRecursive, expiry)
 		if err != nil {
@@ -0,0 +1,111 @@
+// Copyright (c) 2015-2024 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bufio"
+	"encoding/csv"
+	gojson "encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// shareManifestEntry is one presigned URL written to a share manifest.
+type shareManifestEntry struct {
+	Object    string    `json:"object"`
+	VersionID string    `json:"versionId,omitempty"`
+	URL       string    `json:"url"`
+	Expires   time.Time `json:"expires"`
+}
+
+// shareManifestWriter writes presigned URLs as CSV or JSON lines, it is
+// safe to use from several goroutines.
+type shareManifestWriter struct {
+	mu     sync.Mutex
+	closer io.Closer
+	buf    *bufio.Writer
+	csv    *csv.Writer
+	json   *gojson.Encoder
+}
+
+// newShareManifestWriter creates the manifest at path, "-" writes to stdout.
+func newShareManifestWriter(path, format string) (*shareManifestWriter, error) {
+	format = strings.ToLower(format)
+	if format != "csv" && format != "json" {
+		return nil, fmt.Errorf("unsupported manifest format `%s`, expected csv or json", format)
+	}
+	if path == "-" {
+		return newShareManifestWriterTo(os.Stdout, nil, format), nil
+	}
+	file, e := os.Create(path)
+	if e != nil {
+		return nil, e
+	}
+	return newShareManifestWriterTo(file, file, format), nil
+}
+
+// newShareManifestWriterTo writes the manifest to w, closer is closed
+// along with the manifest when not nil.
+func newShareManifestWriterTo(w io.Writer, closer io.Closer, format string) *shareManifestWriter {
+	mw := &shareManifestWriter{closer: closer, buf: bufio.NewWriter(w)}
+	switch format {
+	case "csv":
+		mw.csv = csv.NewWriter(mw.buf)
+		mw.csv.Write([]string{"object", "versionId", "url", "expires"})
+	default:
+		mw.json = gojson.NewEncoder(mw.buf)
+		// Presigned URLs must stay usable as they are.
+		mw.json.SetEscapeHTML(false)
+	}
+	return mw
+}
+
+// Write adds an entry to the manifest.
+func (mw *shareManifestWriter) Write(entry shareManifestEntry) error {
+	mw.mu.Lock()
+	defer mw.mu.Unlock()
+	if mw.csv != nil {
+		mw.csv.Write([]string{entry.Object, entry.VersionID, entry.URL, entry.Expires.UTC().Format(time.RFC3339)})
+		return mw.csv.Error()
+	}
+	return mw.json.Encode(entry)
+}
+
+// Close flushes the manifest and closes its file.
+func (mw *shareManifestWriter) Close() error {
+	mw.mu.Lock()
+	defer mw.mu.Unlock()
+	var e error
+	if mw.csv != nil {
+		mw.csv.Flush()
+		e = mw.csv.Error()
+	}
+	if fe := mw.buf.Flush(); e == nil {
+		e = fe
+	}
+	if mw.closer != nil {
+		if ce := mw.closer.Close(); e == nil {
+			e = ce
+		}
+	}
+	return e
+}
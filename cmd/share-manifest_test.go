@@ -0,0 +1,64 @@
+// Copyright (c) 2015-2024 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bytes"
+	"testing"
+	"time"
+)
+
+func TestShareManifestWriter(t *testing.T) {
+	expires := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	entry := shareManifestEntry{
+		Object:  "https://s3.example.com/bucket/a,b.txt",
+		URL:     "https://s3.example.com/bucket/a%2Cb.txt?X-Amz-Expires=60&X-Amz-Signature=abc",
+		Expires: expires,
+	}
+	testCases := []struct {
+		format   string
+		expected string
+	}{
+		{
+			"csv",
+			"object,versionId,url,expires\n" +
+				`"https://s3.example.com/bucket/a,b.txt",,https://s3.example.com/bucket/a%2Cb.txt?X-Amz-Expires=60&X-Amz-Signature=abc,2026-01-02T03:04:05Z` + "\n",
+		},
+		{
+			"json",
+			`{"object":"https://s3.example.com/bucket/a,b.txt","url":"https://s3.example.com/bucket/a%2Cb.txt?X-Amz-Expires=60&X-Amz-Signature=abc","expires":"2026-01-02T03:04:05Z"}` + "\n",
+		},
+	}
+	for _, tc := range testCases {
+		var buf bytes.Buffer
+		mw := newShareManifestWriterTo(&buf, nil, tc.format)
+		if err := mw.Write(entry); err != nil {
+			t.Fatal(err)
+		}
+		if err := mw.Close(); err != nil {
+			t.Fatal(err)
+		}
+		if buf.String() != tc.expected {
+			t.Errorf("%s: expected\n%s\ngot\n%s", tc.format, tc.expected, buf.String())
+		}
+	}
+
+	if _, err := newShareManifestWriter("-", "xml"); err == nil {
+		t.Error("expected an error for an unsupported format")
+	}
+}
@@ -20,8 +20,24 @@ package cmd
 import (
 	"os"
 	"os/signal"
+	"sync"
 )
 
+var (
+	shutdownHandlerMu sync.Mutex
+	shutdownHandler   func(os.Signal) bool
+)
+
+// setShutdownHandler registers a handler invoked on the first trapped
+// signal. If it returns true the command is expected to stop on its own
+// and the global context is left untouched, a second signal terminates
+// the process immediately.
+func setShutdownHandler(fn func(os.Signal) bool) {
+	shutdownHandlerMu.Lock()
+	defer shutdownHandlerMu.Unlock()
+	shutdownHandler = fn
+}
+
 // trapSignals traps the registered signals and cancel the global context.
 func trapSignals(sig ...os.Signal) {
 	// channel to receive signals.
@@ -38,6 +54,13 @@ func trapSignals(sig ...os.Signal) {
 	// Once signal has been received stop signal Notify handler.
 	signal.Stop(sigCh)
 
+	shutdownHandlerMu.Lock()
+	handler := shutdownHandler
+	shutdownHandlerMu.Unlock()
+	if handler != nil && s != os.Kill && handler(s) {
+		return
+	}
+
 	// Stop profiling if enabled, this needs to be before canceling the
 	// global context to check for any unusual cpu/mem/goroutines usage
 	stopProfiling()
@@ -45,6 +68,9 @@ func trapSignals(sig ...os.Signal) {
 	// Cancel the global context
 	globalCancel()
 
+	// Flush any pending spans
+	shutdownTelemetry()
+
 	var exitCode int
 	switch s.String() {
 	case "interrupt":
@@ -77,6 +77,7 @@ type PerfTestResult struct {
 	NetResult             *madmin.NetperfResult         `json:"network,omitempty"`
 	SiteReplicationResult *madmin.SiteNetPerfResult     `json:"siteReplication,omitempty"`
 	ClientResult          *madmin.ClientPerfResult      `json:"client,omitempty"`
+	ClientResults         []perfClientReport            `json:"clients,omitempty"`
 	DriveResult           []madmin.DriveSpeedTestResult `json:"drive,omitempty"`
 	Err                   string                        `json:"err,omitempty"`
 	Final                 bool                          `json:"final,omitempty"`
@@ -71,6 +71,14 @@ var sqlFlags = []cli.Flag{
 		Name:  "json-output",
 		Usage: "json output serialization option",
 	},
+	cli.StringFlag{
+		Name:  "output, o",
+		Usage: "write the query results to a local file or an object instead of stdout",
+	},
+	cli.StringFlag{
+		Name:  "output-format",
+		Usage: "format of the query results, 'csv' or 'json'",
+	},
 }
 
 // Display contents of a file.
@@ -80,7 +88,7 @@ var sqlCmd = cli.Command{
 	Action:       mainSQL,
 	OnUsageError: onUsageError,
 	Before:       setGlobalsFromContext,
-	Flags:        append(append(sqlFlags, encCFlag), globalFlags...),
+	Flags:        append(append(sqlFlags, encCFlags...), globalFlags...),
 	CustomHelpTemplate: `NAME:
   {{.HelpName}} - {{.Usage}}
 
@@ -120,6 +128,10 @@ EXAMPLES:
      {{.Prompt}} {{.HelpName}} --compression GZIP --csv-input "rd=\n,fh=USE,fd=;" \
          --csv-output "rd=\n" --csv-output-header "device_id,uptime,lat,lon" \
          --query "select * from S3Object" myminio/iot-devices/data.csv
+
+  7. Run a query on a set of objects recursively and upload the results as JSON to another bucket.
+     {{.Prompt}} {{.HelpName}} --recursive --output-format json --query "select * from S3Object s where s.power > 100" \
+         --output s3/reports/high-power.json myminio/iot-devices/
 `,
 }
 
@@ -279,6 +291,24 @@ func getOutputSerializationOpts(ctx *cli.Context, csvHdrs []string) (opts map[st
 		fatalIf(errInvalidArgument(), "Only one of --csv-output, or --json-output can be specified as output serialization option")
 	}
 
+	switch format := ctx.String("output-format"); format {
+	case "":
+		// JSON output is the default with --json.
+		jsonType = jsonType || globalJSON && !csvType
+	case sqlOutputFormatCSV:
+		if jsonType {
+			fatalIf(errInvalidArgument(), "--json-output incompatible with --output-format csv")
+		}
+		csvType = true
+	case sqlOutputFormatJSON:
+		if csvType {
+			fatalIf(errInvalidArgument(), "--csv-output incompatible with --output-format json")
+		}
+		jsonType = true
+	default:
+		fatalIf(errInvalidArgument().Trace(format), "--output-format must be either 'csv' or 'json', S3 Select does not return other formats")
+	}
+
 	if jsonType && len(csvHdrs) > 0 {
 		fatalIf(errInvalidArgument(), "--csv-output-header incompatible with --json-output option")
 	}
@@ -290,7 +320,7 @@ func getOutputSerializationOpts(ctx *cli.Context, csvHdrs []string) (opts map[st
 		m["csv"] = kv
 	}
 
-	if jsonType || globalJSON {
+	if jsonType {
 		kv, err := parseSerializationOpts(ojson, validJSONCSVCommonOutputKeys, validJSONOutputAbbrKeys)
 		fatalIf(err, "Invalid value(s) specified for --json-output flag")
 		m["json"] = kv
@@ -358,6 +388,21 @@ func getCSVOutputHeaders(ctx *cli.Context, url string, encKeyDB map[string][]pre
 	return
 }
 
+// sqlOutputFormat returns the format of the query results, JSON input
+// returns JSON results unless CSV output is requested.
+func sqlOutputFormat(ctx *cli.Context) string {
+	if format := ctx.String("output-format"); format != "" {
+		return format
+	}
+	if ctx.IsSet("csv-output") {
+		return sqlOutputFormatCSV
+	}
+	if ctx.IsSet("json-output") || ctx.IsSet("json-input") || globalJSON {
+		return sqlOutputFormatJSON
+	}
+	return sqlOutputFormatCSV
+}
+
 // get the Select options for sql select API
 func getSQLOpts(ctx *cli.Context, csvHdrs []string) (s SelectObjectOpts) {
 	is := getInputSerializationOpts(ctx)
@@ -380,7 +425,7 @@ func isCSVOrJSON(inOpts map[string]map[string]string) bool {
 	return false
 }
 
-func sqlSelect(targetURL, expression string, encKeyDB map[string][]prefixSSEPair, selOpts SelectObjectOpts, csvHdrs []string, writeHdr bool) *probe.Error {
+func sqlSelect(w io.Writer, targetURL, expression string, encKeyDB map[string][]prefixSSEPair, selOpts SelectObjectOpts, csvHdrs []string, writeHdr bool) *probe.Error {
 	ctx, cancelSelect := context.WithCancel(globalContext)
 	defer cancelSelect()
 
@@ -401,11 +446,13 @@ func sqlSelect(targetURL, expression string, encKeyDB map[string][]prefixSSEPair
 	}
 	defer outputer.Close()
 
-	// write csv header to stdout
+	// write csv header to the output
 	if len(csvHdrs) > 0 && writeHdr {
-		fmt.Println(strings.Join(csvHdrs, ","))
+		if _, e := fmt.Fprintln(w, strings.Join(csvHdrs, ",")); e != nil {
+			return probe.NewError(e)
+		}
 	}
-	_, e := io.Copy(os.Stdout, outputer)
+	_, e := io.Copy(w, outputer)
 	return probe.NewError(e)
 }
 
@@ -448,31 +495,49 @@ func mainSQL(cliCtx *cli.Context) error {
 
 	// validate sql input arguments.
 	checkSQLSyntax(cliCtx)
+
+	var w io.Writer = os.Stdout
+	var output *sqlOutput
+	outputURL := cliCtx.String("output")
+	if outputURL != "" {
+		output, err = newSQLOutput(ctx, outputURL, sqlOutputContentType(sqlOutputFormat(cliCtx)), encKeyDB)
+		fatalIf(err, "Unable to write to `%s`.", outputURL)
+		w = output
+	}
+	// errors of the queries, the results are not uploaded if any query fails.
+	var failed *probe.Error
+	sqlErrorIf := func(err *probe.Error, msg string, data ...interface{}) {
+		if err != nil {
+			failed = err
+		}
+		errorIf(err, msg, data...)
+	}
+
 	// extract URLs.
 	URLs := cliCtx.Args()
 	writeHdr := true
 	for _, url := range URLs {
 		if _, targetContent, err := url2Stat(ctx, url2StatOptions{urlStr: url, versionID: "", fileAttr: false, encKeyDB: encKeyDB, timeRef: time.Time{}, isZip: false, ignoreBucketExistsCheck: false}); err != nil {
-			errorIf(err.Trace(url), "Unable to run sql for %s.", url)
+			sqlErrorIf(err.Trace(url), "Unable to run sql for %s.", url)
 			continue
 		} else if !targetContent.Type.IsDir() {
 			if writeHdr {
 				query, csvHdrs, selOpts = getAndValidateArgs(cliCtx, encKeyDB, url)
 			}
-			errorIf(sqlSelect(url, query, encKeyDB, selOpts, csvHdrs, writeHdr).Trace(url), "Unable to run sql")
+			sqlErrorIf(sqlSelect(w, url, query, encKeyDB, selOpts, csvHdrs, writeHdr).Trace(url), "Unable to run sql")
 			writeHdr = false
 			continue
 		}
 		targetAlias, targetURL, _ := mustExpandAlias(url)
 		clnt, err := newClientFromAlias(targetAlias, targetURL)
 		if err != nil {
-			errorIf(err.Trace(url), "Unable to initialize target `%s`.", url)
+			sqlErrorIf(err.Trace(url), "Unable to initialize target `%s`.", url)
 			continue
 		}
 
 		for content := range clnt.List(ctx, ListOptions{Recursive: cliCtx.Bool("recursive"), WithMetadata: true, ShowDir: DirNone}) {
 			if content.Err != nil {
-				errorIf(content.Err.Trace(url), "Unable to list on target `%s`.", url)
+				sqlErrorIf(content.Err.Trace(url), "Unable to list on target `%s`.", url)
 				continue
 			}
 			if writeHdr {
@@ -484,7 +549,7 @@ func mainSQL(cliCtx *cli.Context) error {
 			}
 			for _, cTypeSuffix := range supportedContentTypes {
 				if strings.Contains(contentType, cTypeSuffix) {
-					errorIf(sqlSelect(targetAlias+content.URL.Path, query,
+					sqlErrorIf(sqlSelect(w, targetAlias+content.URL.Path, query,
 						encKeyDB, selOpts, csvHdrs, writeHdr).Trace(content.URL.String()), "Unable to run sql")
 				}
 				writeHdr = false
@@ -492,6 +557,16 @@ func mainSQL(cliCtx *cli.Context) error {
 		}
 	}
 
+	if output != nil {
+		if failed != nil {
+			output.abort(failed.ToGoError())
+			fatalIf(failed.Trace(outputURL), "Unable to write the query results to `%s`, a query failed.", outputURL)
+		}
+		size, err := output.close()
+		fatalIf(err.Trace(outputURL), "Unable to write the query results to `%s`.", outputURL)
+		printMsg(sqlOutputMessage{Output: outputURL, Size: size})
+	}
+
 	// Done.
 	return nil
 }
@@ -0,0 +1,115 @@
+// Copyright (c) 2015-2024 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/dustin/go-humanize"
+	json "github.com/minio/colorjson"
+	"github.com/minio/mc/pkg/probe"
+)
+
+// Formats of the query results written with --output-format.
+const (
+	sqlOutputFormatCSV  = "csv"
+	sqlOutputFormatJSON = "json"
+)
+
+// sqlOutputContentType returns the content type of the query results
+// written in format.
+func sqlOutputContentType(format string) string {
+	if format == sqlOutputFormatJSON {
+		return "application/json"
+	}
+	return "text/csv"
+}
+
+// sqlOutput streams the query results to a local file or an object,
+// large results are uploaded with multipart.
+type sqlOutput struct {
+	pw     *io.PipeWriter
+	doneCh chan sqlOutputResult
+}
+
+type sqlOutputResult struct {
+	size int64
+	err  *probe.Error
+}
+
+// newSQLOutput starts the upload of the query results to targetURL.
+func newSQLOutput(ctx context.Context, targetURL, contentType string, encKeyDB map[string][]prefixSSEPair) (*sqlOutput, *probe.Error) {
+	alias, _ := url2Alias(targetURL)
+	clnt, err := newClient(targetURL)
+	if err != nil {
+		return nil, err.Trace(targetURL)
+	}
+
+	pr, pw := io.Pipe()
+	o := &sqlOutput{pw: pw, doneCh: make(chan sqlOutputResult, 1)}
+	go func() {
+		n, err := clnt.Put(ctx, pr, -1, nil, PutOptions{
+			sse:      getSSE(targetURL, encKeyDB[alias]),
+			metadata: map[string]string{"Content-Type": contentType},
+		})
+		if err != nil {
+			pr.CloseWithError(err.ToGoError())
+		} else {
+			pr.Close()
+		}
+		o.doneCh <- sqlOutputResult{size: n, err: err}
+	}()
+	return o, nil
+}
+
+func (o *sqlOutput) Write(p []byte) (int, error) {
+	return o.pw.Write(p)
+}
+
+// abort cancels the upload, nothing is written to the target.
+func (o *sqlOutput) abort(e error) {
+	o.pw.CloseWithError(e)
+	<-o.doneCh
+}
+
+// close completes the upload and returns the size of the results.
+func (o *sqlOutput) close() (int64, *probe.Error) {
+	o.pw.Close()
+	res := <-o.doneCh
+	return res.size, res.err
+}
+
+// sqlOutputMessage is printed once the query results are written to --output.
+type sqlOutputMessage struct {
+	Status string `json:"status"`
+	Output string `json:"output"`
+	Size   int64  `json:"size"`
+}
+
+func (s sqlOutputMessage) String() string {
+	return fmt.Sprintf("Wrote query results (%s) to `%s`.", humanize.IBytes(uint64(s.Size)), s.Output)
+}
+
+func (s sqlOutputMessage) JSON() string {
+	s.Status = "success"
+	jsonMessageBytes, e := json.MarshalIndent(s, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+	return string(jsonMessageBytes)
+}
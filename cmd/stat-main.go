@@ -55,6 +55,19 @@ var (
 			Name:  "no-list",
 			Usage: "disable all LIST operations for stat",
 		},
+		cli.BoolFlag{
+			Name:  "raw",
+			Usage: "show the raw response headers of the HEAD request of an object",
+		},
+		cli.BoolFlag{
+			Name:  "stdin",
+			Usage: "read object keys from STDIN and stat them, printing one JSON line per key",
+		},
+		cli.IntFlag{
+			Name:  "workers",
+			Usage: "number of HEAD requests sent in parallel with --stdin",
+			Value: 32,
+		},
 	}
 )
 
@@ -65,12 +78,13 @@ var statCmd = cli.Command{
 	Action:       mainStat,
 	OnUsageError: onUsageError,
 	Before:       setGlobalsFromContext,
-	Flags:        append(append(statFlags, encCFlag), globalFlags...),
+	Flags:        append(append(statFlags, encCFlags...), globalFlags...),
 	CustomHelpTemplate: `NAME:
   {{.HelpName}} - {{.Usage}}
 
 USAGE:
   {{.HelpName}} [FLAGS] TARGET [TARGET ...]
+  {{.HelpName}} --stdin [FLAGS] [TARGET]
 
 FLAGS:
   {{range .VisibleFlags}}{{.}}
@@ -98,9 +112,31 @@ EXAMPLES:
 
   7. Stat all objects versions recursively created before 1st January 2020.
      {{.Prompt}} {{.HelpName}} --versions --rewind 2020.01.01T00:00 s3/personal-docs/
+
+  8. Show the raw response headers, including vendor specific headers, of an object.
+     {{.Prompt}} {{.HelpName}} --raw s3/personal-docs/2018-account_report.docx
+
+  9. Stat every key listed in a migration manifest under "s3/personal-docs/", 64 keys at a time.
+     {{.Prompt}} cat manifest.txt | {{.HelpName}} --stdin --workers 64 s3/personal-docs/
 `,
 }
 
+// checkStatStdinSyntax - validate the arguments of stat --stdin
+func checkStatStdinSyntax(cliCtx *cli.Context) {
+	args := cliCtx.Args()
+	if len(args) > 1 {
+		fatalIf(errInvalidArgument().Trace(args...), "You cannot specify more than one TARGET with --stdin.")
+	}
+	for _, flag := range []string{"rewind", "versions", "version-id", "recursive", "verbose", "no-list", "raw"} {
+		if cliCtx.IsSet(flag) {
+			fatalIf(errInvalidArgument().Trace(args...), "You cannot specify --"+flag+" with --stdin.")
+		}
+	}
+	if cliCtx.Int("workers") < 1 {
+		fatalIf(errInvalidArgument().Trace(args...), "--workers must be at least 1.")
+	}
+}
+
 // parseAndCheckStatSyntax - parse and validate all the passed arguments
 func parseAndCheckStatSyntax(ctx context.Context, cliCtx *cli.Context) ([]string, bool, string, time.Time, bool) {
 	if !cliCtx.Args().Present() {
@@ -114,6 +150,10 @@ func parseAndCheckStatSyntax(ctx context.Context, cliCtx *cli.Context) ([]string
 		}
 	}
 
+	if cliCtx.IsSet("workers") {
+		fatalIf(errInvalidArgument().Trace(args...), "--workers requires --stdin.")
+	}
+
 	recursive := cliCtx.Bool("recursive")
 	versionID := cliCtx.String("version-id")
 	withVersions := cliCtx.Bool("versions")
@@ -135,6 +175,10 @@ func parseAndCheckStatSyntax(ctx context.Context, cliCtx *cli.Context) ([]string
 		fatalIf(errInvalidArgument().Trace(args...), "You cannot specify --no-list with either --versions or --recursive.")
 	}
 
+	if cliCtx.Bool("raw") && (recursive || withVersions || !rewind.IsZero() || cliCtx.Bool("verbose")) {
+		fatalIf(errInvalidArgument().Trace(args...), "You cannot specify --raw with either --rewind, --versions, --recursive or --verbose.")
+	}
+
 	var targetUrls []string
 	for _, url := range URLs {
 		_, path := url2Alias(url)
@@ -180,6 +224,11 @@ func mainStat(cliCtx *cli.Context) error {
 	encKeyDB, err := validateAndCreateEncryptionKeys(cliCtx)
 	fatalIf(err, "Unable to parse encryption keys.")
 
+	if cliCtx.Bool("stdin") {
+		checkStatStdinSyntax(cliCtx)
+		return statStdin(ctx, cliCtx.Args().First(), cliCtx.Int("workers"), encKeyDB)
+	}
+
 	// check 'stat' cli arguments.
 	args, isRecursive, versionID, rewind, withVersions := parseAndCheckStatSyntax(ctx, cliCtx)
 	// mimic operating system tool behavior.
@@ -187,6 +236,13 @@ func mainStat(cliCtx *cli.Context) error {
 		args = []string{"."}
 	}
 
+	if cliCtx.Bool("raw") {
+		for _, targetURL := range args {
+			fatalIf(statRawURL(ctx, targetURL, versionID, encKeyDB), "Unable to stat `"+targetURL+"`.")
+		}
+		return nil
+	}
+
 	headOnly := cliCtx.Bool("no-list")
 	for _, targetURL := range args {
 		fatalIf(statURL(ctx, targetURL, versionID, rewind, withVersions, false, isRecursive, headOnly, encKeyDB), "Unable to stat `"+targetURL+"`.")
@@ -0,0 +1,90 @@
+// Copyright (c) 2015-2024 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	json "github.com/minio/colorjson"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/pkg/v3/console"
+)
+
+// statRawMessage holds the response of the HEAD request of an object.
+type statRawMessage struct {
+	Status   string      `json:"status"`
+	Key      string      `json:"name"`
+	Response string      `json:"response"`
+	Headers  http.Header `json:"headers"`
+}
+
+func (s statRawMessage) String() string {
+	var b strings.Builder
+	b.WriteString(console.Colorize("Name", s.Key) + "\n")
+	b.WriteString(console.Colorize("Title", s.Response))
+	keys := make([]string, 0, len(s.Headers))
+	for k := range s.Headers {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		for _, v := range s.Headers[k] {
+			b.WriteString("\n" + console.Colorize("Key", k+": ") + console.Colorize("Value", v))
+		}
+	}
+	return b.String()
+}
+
+func (s statRawMessage) JSON() string {
+	s.Status = "success"
+	jsonMessageBytes, e := json.MarshalIndent(s, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+
+	return string(jsonMessageBytes)
+}
+
+// statRawURL prints the raw response headers of the HEAD request of an object.
+func statRawURL(ctx context.Context, targetURL, versionID string, encKeyDB map[string][]prefixSSEPair) *probe.Error {
+	clnt, err := newClient(targetURL)
+	if err != nil {
+		return err
+	}
+	s3Clnt, ok := clnt.(*S3Client)
+	if !ok {
+		return probe.NewError(fmt.Errorf("`%s` is not an S3 object, --raw requires an S3 target", targetURL))
+	}
+
+	alias, _ := url2Alias(targetURL)
+	response, headers, err := s3Clnt.StatRaw(ctx, StatOptions{
+		versionID: versionID,
+		sse:       getSSE(targetURL, encKeyDB[alias]),
+	})
+	if err != nil {
+		return err
+	}
+	printMsg(statRawMessage{
+		Key:      targetURL,
+		Response: response,
+		Headers:  headers,
+	})
+	return nil
+}
@@ -0,0 +1,143 @@
+// Copyright (c) 2015-2024 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"os"
+	"strings"
+	"sync"
+
+	json "github.com/minio/colorjson"
+	"github.com/minio/mc/pkg/probe"
+)
+
+// statLineMessage is the result of one key read with --stdin, it is
+// always printed as a single JSON line so the output can be streamed
+// into other tools.
+type statLineMessage struct {
+	Status string `json:"status"`
+	Key    string `json:"name"`
+	URL    string `json:"url"`
+	Error  string `json:"error,omitempty"`
+	*statMessage
+}
+
+func (s statLineMessage) JSON() string {
+	if s.Error == "" {
+		s.Status = "success"
+	} else {
+		s.Status = "error"
+	}
+	jsonMessageBytes, e := json.Marshal(s)
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+	return string(jsonMessageBytes)
+}
+
+func (s statLineMessage) String() string {
+	return s.JSON()
+}
+
+// statKeysFromReader reads one key per line from r and passes each of
+// them to stat, running up to workers calls in parallel. Results are
+// passed to emit in the order they complete, the number of keys which
+// could not be statted is returned.
+func statKeysFromReader(ctx context.Context, r io.Reader, workers int, stat func(key string) statLineMessage, emit func(statLineMessage)) (int, error) {
+	keysCh := make(chan string, workers)
+	var (
+		wg     sync.WaitGroup
+		mu     sync.Mutex
+		failed int
+	)
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for key := range keysCh {
+				msg := stat(key)
+				mu.Lock()
+				if msg.Error != "" {
+					failed++
+				}
+				emit(msg)
+				mu.Unlock()
+			}
+		}()
+	}
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		key := strings.TrimSuffix(scanner.Text(), "\r")
+		if key == "" {
+			continue
+		}
+		select {
+		case keysCh <- key:
+		case <-ctx.Done():
+		}
+		if ctx.Err() != nil {
+			break
+		}
+	}
+	close(keysCh)
+	wg.Wait()
+
+	if e := scanner.Err(); e != nil {
+		return failed, e
+	}
+	return failed, ctx.Err()
+}
+
+// statKey stats a single key read with --stdin using a HEAD request.
+// The key is joined to target when it is not empty, otherwise it is
+// expected to be a full URL.
+func statKey(ctx context.Context, target, key string, encKeyDB map[string][]prefixSSEPair) statLineMessage {
+	url := key
+	if target != "" {
+		url = urlJoinPath(target, key)
+	}
+	_, content, err := url2Stat(ctx, url2StatOptions{
+		urlStr:                  url,
+		fileAttr:                true,
+		encKeyDB:                encKeyDB,
+		ignoreBucketExistsCheck: true,
+		headOnly:                true,
+	})
+	if err != nil {
+		return statLineMessage{Key: key, URL: url, Error: err.ToGoError().Error()}
+	}
+	stat := parseStat(content)
+	return statLineMessage{Key: key, URL: url, statMessage: &stat}
+}
+
+// statStdin is the handler of mc stat --stdin.
+func statStdin(ctx context.Context, target string, workers int, encKeyDB map[string][]prefixSSEPair) error {
+	stat := func(key string) statLineMessage {
+		return statKey(ctx, target, key, encKeyDB)
+	}
+	failed, e := statKeysFromReader(ctx, os.Stdin, workers, stat, func(msg statLineMessage) {
+		printMsg(msg)
+	})
+	fatalIf(probe.NewError(e), "Unable to read the keys from STDIN.")
+	if failed > 0 {
+		return exitStatus(globalErrorExitStatus)
+	}
+	return nil
+}
@@ -0,0 +1,76 @@
+// Copyright (c) 2015-2024 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"testing"
+)
+
+func TestStatKeysFromReader(t *testing.T) {
+	stat := func(key string) statLineMessage {
+		if strings.HasPrefix(key, "missing") {
+			return statLineMessage{Key: key, Error: "Object does not exist"}
+		}
+		return statLineMessage{Key: key, statMessage: &statMessage{Size: int64(len(key))}}
+	}
+
+	var got []statLineMessage
+	input := strings.NewReader("a.txt\r\n\nmissing.txt\nbb.txt\n")
+	failed, err := statKeysFromReader(context.Background(), input, 2, stat, func(msg statLineMessage) {
+		got = append(got, msg)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if failed != 1 {
+		t.Errorf("expected 1 failed key, got %d", failed)
+	}
+	if len(got) != 3 {
+		t.Fatalf("expected 3 results, got %d", len(got))
+	}
+	sort.Slice(got, func(i, j int) bool { return got[i].Key < got[j].Key })
+	for i, key := range []string{"a.txt", "bb.txt", "missing.txt"} {
+		if got[i].Key != key {
+			t.Errorf("expected key %q, got %q", key, got[i].Key)
+		}
+	}
+
+	for _, tc := range []struct {
+		msg  statLineMessage
+		want []string
+	}{
+		{got[0], []string{`"status":"success"`, `"name":"a.txt"`, `"size":5`}},
+		{got[2], []string{`"status":"error"`, `"name":"missing.txt"`, `"error":"Object does not exist"`}},
+	} {
+		line := tc.msg.JSON()
+		if strings.ContainsRune(line, '\n') {
+			t.Errorf("expected a single line, got %s", line)
+		}
+		for _, want := range tc.want {
+			if !strings.Contains(line, want) {
+				t.Errorf("expected %s in %s", want, line)
+			}
+		}
+	}
+	if strings.Contains(got[2].JSON(), `"size"`) {
+		t.Errorf("unexpected object fields in %s", got[2].JSON())
+	}
+}
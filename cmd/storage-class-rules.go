@@ -0,0 +1,101 @@
+// Copyright (c) 2015-2024 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	humanize "github.com/dustin/go-humanize"
+	"github.com/minio/mc/pkg/probe"
+)
+
+// storageClassRule assigns a storage class to objects whose size matches.
+type storageClassRule struct {
+	op    string
+	size  int64
+	class string
+}
+
+// matches returns true if an object of the given size satisfies the rule.
+func (r storageClassRule) matches(size int64) bool {
+	switch r.op {
+	case "<":
+		return size < r.size
+	case "<=":
+		return size <= r.size
+	case ">":
+		return size > r.size
+	case ">=":
+		return size >= r.size
+	}
+	return size == r.size
+}
+
+// storageClassRules is an ordered list of rules, the first matching rule wins.
+type storageClassRules []storageClassRule
+
+// parseStorageClassRules parses rules of the form '<1MiB=STANDARD,>=1MiB=REDUCED_REDUNDANCY'.
+func parseStorageClassRules(s string) (storageClassRules, *probe.Error) {
+	var rules storageClassRules
+	for _, token := range strings.Split(s, ",") {
+		token = strings.TrimSpace(token)
+		if token == "" {
+			continue
+		}
+
+		// Split at the last '=', the first one may be part of the operator.
+		idx := strings.LastIndex(token, "=")
+		if idx <= 0 || idx == len(token)-1 {
+			return nil, probe.NewError(fmt.Errorf("invalid storage class rule `%s`, expected SIZE-CONDITION=STORAGE-CLASS", token))
+		}
+		cond, class := strings.TrimSpace(token[:idx]), strings.TrimSpace(token[idx+1:])
+
+		var op string
+		for _, o := range []string{"<=", ">=", "<", ">", "="} {
+			if strings.HasPrefix(cond, o) {
+				op = o
+				break
+			}
+		}
+		if op == "" {
+			return nil, probe.NewError(fmt.Errorf("invalid storage class rule `%s`, size condition must start with one of <, <=, >, >=, =", token))
+		}
+
+		size, e := humanize.ParseBytes(strings.TrimSpace(strings.TrimPrefix(cond, op)))
+		if e != nil {
+			return nil, probe.NewError(e).Trace(token)
+		}
+		rules = append(rules, storageClassRule{op: op, size: int64(size), class: class})
+	}
+	if len(rules) == 0 {
+		return nil, probe.NewError(fmt.Errorf("no storage class rules found in `%s`", s))
+	}
+	return rules, nil
+}
+
+// storageClass returns the storage class of the first rule matching size,
+// an empty string if none matches.
+func (rules storageClassRules) storageClass(size int64) string {
+	for _, r := range rules {
+		if r.matches(size) {
+			return r.class
+		}
+	}
+	return ""
+}
@@ -0,0 +1,57 @@
+// Copyright (c) 2015-2024 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"testing"
+)
+
+func TestStorageClassRules(t *testing.T) {
+	rules, err := parseStorageClassRules("<1MiB=STANDARD, >=1MiB=REDUCED_REDUNDANCY")
+	if err != nil {
+		t.Fatal(err)
+	}
+	testCases := []struct {
+		size  int64
+		class string
+	}{
+		{0, "STANDARD"},
+		{1<<20 - 1, "STANDARD"},
+		{1 << 20, "REDUCED_REDUNDANCY"},
+		{5 << 30, "REDUCED_REDUNDANCY"},
+	}
+	for i, testCase := range testCases {
+		if class := rules.storageClass(testCase.size); class != testCase.class {
+			t.Fatalf("Test %d: expected %s, got %s", i+1, testCase.class, class)
+		}
+	}
+
+	rules, err = parseStorageClassRules(">10MB=REDUCED_REDUNDANCY")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if class := rules.storageClass(1000); class != "" {
+		t.Fatalf("expected no storage class, got %s", class)
+	}
+
+	for _, invalid := range []string{"", ",", "1MiB=STANDARD", "<1MiB", "<1MiB=", "<abc=STANDARD", "=STANDARD"} {
+		if _, err := parseStorageClassRules(invalid); err == nil {
+			t.Fatalf("expected error for rule `%s`", invalid)
+		}
+	}
+}
@@ -46,11 +46,22 @@ const (
 	defaultPublicKey = "MIIBCgKCAQEAs/128UFS9A8YSJY1XqYKt06dLVQQCGDee69T+0Tip/1jGAB4z0/3QMpH0MiS8Wjs4BRWV51qvkfAHzwwdU7y6jxU05ctb/H/WzRj3FYdhhHKdzear9TLJftlTs+xwj2XaADjbLXCV1jGLS889A7f7z5DgABlVZMQd9BjVAR8ED3xRJ2/ZCNuQVJ+A8r7TYPGMY3wWvhhPgPk3Lx4WDZxDiDNlFs4GQSaESSsiVTb9vyGe/94CsCTM6Cw9QG6ifHKCa/rFszPYdKCabAfHcS3eTr0GM+TThSsxO7KfuscbmLJkfQev1srfL2Ii2RbnysqIJVWKEwdW05ID8ryPkuTuwIDAQAB"
 )
 
+// Suffix of the internal name of the objects ending with a slash.
+const inspectDirObjectSuffix = "__XLDIR__"
+
 var supportInspectFlags = append(subnetCommonFlags,
 	cli.BoolFlag{
 		Name:  "legacy",
 		Usage: "use the older inspect format",
 	},
+	cli.BoolFlag{
+		Name:  "volume-style",
+		Usage: "inspect the object at TARGET, its internal paths on the drives are constructed by mc",
+	},
+	cli.BoolFlag{
+		Name:  "data",
+		Usage: "with --volume-style, include the data of all the versions of the object along with its metadata",
+	},
 )
 
 var supportInspectCmd = cli.Command{
@@ -79,6 +90,12 @@ EXAMPLES:
 
   3. Download 'xl.meta' of a specific object from all the drives locally, and upload to SUBNET manually
      {{.Prompt}} {{.HelpName}} myminio/bucket/test*/xl.meta --airgap
+
+  4. Upload the metadata of all the versions of an object, without knowing where it is stored on the drives.
+     {{.Prompt}} {{.HelpName}} --volume-style myminio/bucket/dir/object.csv
+
+  5. Upload the metadata and the data of all the versions of an object.
+     {{.Prompt}} {{.HelpName}} --volume-style --data myminio/bucket/dir/object.csv
 `,
 }
 
@@ -120,6 +137,30 @@ func checkSupportInspectSyntax(ctx *cli.Context) {
 	if len(ctx.Args()) != 1 {
 		showCommandHelpAndExit(ctx, 1) // last argument is exit code
 	}
+	if ctx.Bool("data") && !ctx.Bool("volume-style") {
+		fatalIf(errInvalidArgument(), "--data requires --volume-style.")
+	}
+}
+
+// inspectObjectPath returns the path, relative to its bucket, of the
+// internal files of object on the drives. The metadata of all the
+// versions of an object is in its xl.meta, their data in a folder
+// per version next to it.
+func inspectObjectPath(object string, withData bool) (string, *probe.Error) {
+	if object == "" || object == "/" {
+		return "", errInvalidArgument().Trace(object)
+	}
+	if strings.ContainsAny(object, "*?[") {
+		return "", probe.NewError(errors.New("patterns are not supported with --volume-style")).Trace(object)
+	}
+	// Objects ending with a slash are stored with a suffix.
+	if strings.HasSuffix(object, "/") {
+		object = strings.TrimSuffix(object, "/") + inspectDirObjectSuffix
+	}
+	if withData {
+		return object + "/**", nil
+	}
+	return object + "/xl.meta", nil
 }
 
 // mainSupportInspect - the entry function of inspect command
@@ -158,6 +199,11 @@ func mainSupportInspect(ctx *cli.Context) error {
 	if runtime.GOOS != "windows" && shellName != "bash" && strings.Contains(prefix, "*") {
 		console.Infoln("Your shell is auto determined as '" + shellName + "', wildcard patterns are only supported with 'bash' SHELL.")
 	}
+	if ctx.Bool("volume-style") {
+		prefix, err = inspectObjectPath(prefix, ctx.Bool("data"))
+		fatalIf(err.Trace(aliasedURL), "Unable to inspect object.")
+		splits[2] = prefix
+	}
 
 	var publicKey []byte
 	if !ctx.Bool("legacy") {
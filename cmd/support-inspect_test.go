@@ -0,0 +1,45 @@
+// Copyright (c) 2015-2024 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import "testing"
+
+func TestInspectObjectPath(t *testing.T) {
+	testCases := []struct {
+		object   string
+		withData bool
+		path     string
+		wantErr  bool
+	}{
+		{"dir/object.csv", false, "dir/object.csv/xl.meta", false},
+		{"dir/object.csv", true, "dir/object.csv/**", false},
+		{"dir/", false, "dir__XLDIR__/xl.meta", false},
+		{"", false, "", true},
+		{"dir/object*", false, "", true},
+	}
+
+	for i, testCase := range testCases {
+		path, err := inspectObjectPath(testCase.object, testCase.withData)
+		if testCase.wantErr != (err != nil) {
+			t.Fatalf("Test %d: expected error %v, got %v", i+1, testCase.wantErr, err)
+		}
+		if path != testCase.path {
+			t.Fatalf("Test %d: expected %q, got %q", i+1, testCase.path, path)
+		}
+	}
+}
@@ -0,0 +1,413 @@
+// Copyright (c) 2015-2024 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	gojson "encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	humanize "github.com/dustin/go-humanize"
+	"github.com/minio/madmin-go/v3"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/pkg/v3/console"
+)
+
+// perfClientStartDelay is the time given to all the clients to receive
+// the plan of a coordinated client perf test before it starts.
+const perfClientStartDelay = 2 * time.Second
+
+// perfClientResultGrace is how long the coordinator waits for results
+// after the end of the test before giving up on a client.
+const perfClientResultGrace = 30 * time.Second
+
+// perfClientJoin is sent by a client joining a coordinated run.
+type perfClientJoin struct {
+	Name         string `json:"name"`
+	DeploymentID string `json:"deploymentID"`
+}
+
+// perfClientPlan is returned to a client once all the clients joined.
+type perfClientPlan struct {
+	Name     string        `json:"name"`
+	Duration time.Duration `json:"duration"`
+	StartIn  time.Duration `json:"startIn"`
+}
+
+// perfClientReport is sent by a client once its test is done.
+type perfClientReport struct {
+	Name   string                  `json:"name"`
+	Result madmin.ClientPerfResult `json:"result"`
+}
+
+// perfClientCoordinator synchronizes the client perf test of several mc
+// instances running on different machines against the same cluster.
+type perfClientCoordinator struct {
+	clients      int
+	duration     time.Duration
+	deploymentID string
+
+	mu      sync.Mutex
+	names   map[string]bool
+	start   time.Time
+	ready   chan struct{}
+	results chan perfClientReport
+}
+
+func newPerfClientCoordinator(clients int, duration time.Duration, deploymentID string) *perfClientCoordinator {
+	c := &perfClientCoordinator{
+		clients:      clients,
+		duration:     duration,
+		deploymentID: deploymentID,
+		names:        make(map[string]bool),
+		ready:        make(chan struct{}),
+		results:      make(chan perfClientReport, clients),
+	}
+	// The coordinator takes part in the test as well.
+	c.join("")
+	return c
+}
+
+// join registers a new client and returns its unique name, the plan is
+// ready once the expected number of clients joined.
+func (c *perfClientCoordinator) join(name string) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if len(c.names) == c.clients {
+		return "", errors.New("all the expected clients already joined")
+	}
+	unique := name
+	for i := 2; c.names[unique]; i++ {
+		unique = fmt.Sprintf("%s#%d", name, i)
+	}
+	c.names[unique] = true
+	if len(c.names) == c.clients {
+		c.start = time.Now().Add(perfClientStartDelay)
+		close(c.ready)
+	}
+	return unique, nil
+}
+
+// leave unregisters a client which went away before the run started.
+func (c *perfClientCoordinator) leave(name string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	select {
+	case <-c.ready:
+	default:
+		delete(c.names, name)
+	}
+}
+
+func (c *perfClientCoordinator) startTime() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.start
+}
+
+func (c *perfClientCoordinator) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	switch r.URL.Path {
+	case "/join":
+		var req perfClientJoin
+		if e := gojson.NewDecoder(r.Body).Decode(&req); e != nil {
+			http.Error(w, e.Error(), http.StatusBadRequest)
+			return
+		}
+		if req.DeploymentID != c.deploymentID {
+			http.Error(w, "the client is not connected to the same cluster as the coordinator", http.StatusConflict)
+			return
+		}
+		name, e := c.join(req.Name)
+		if e != nil {
+			http.Error(w, e.Error(), http.StatusConflict)
+			return
+		}
+		select {
+		case <-c.ready:
+		case <-r.Context().Done():
+			c.leave(name)
+			return
+		}
+		gojson.NewEncoder(w).Encode(perfClientPlan{
+			Name:     name,
+			Duration: c.duration,
+			StartIn:  time.Until(c.startTime()),
+		})
+	case "/result":
+		var report perfClientReport
+		if e := gojson.NewDecoder(r.Body).Decode(&report); e != nil {
+			http.Error(w, e.Error(), http.StatusBadRequest)
+			return
+		}
+		select {
+		case c.results <- report:
+		default:
+			http.Error(w, "unexpected result", http.StatusConflict)
+		}
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+// collect waits for the results of all the other clients until the
+// deadline, clients which did not report are returned with an error.
+func (c *perfClientCoordinator) collect(ctx context.Context, deadline time.Time) []perfClientReport {
+	timer := time.NewTimer(time.Until(deadline))
+	defer timer.Stop()
+
+	var reports []perfClientReport
+	received := make(map[string]bool)
+	for len(reports) < c.clients-1 {
+		select {
+		case report := <-c.results:
+			if received[report.Name] {
+				continue
+			}
+			received[report.Name] = true
+			reports = append(reports, report)
+		case <-timer.C:
+			return c.addMissing(reports, received)
+		case <-ctx.Done():
+			return c.addMissing(reports, received)
+		}
+	}
+	return reports
+}
+
+func (c *perfClientCoordinator) addMissing(reports []perfClientReport, received map[string]bool) []perfClientReport {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for name := range c.names {
+		if name != "" && !received[name] {
+			reports = append(reports, perfClientReport{
+				Name:   name,
+				Result: madmin.ClientPerfResult{Error: "no result received from the client"},
+			})
+		}
+	}
+	return reports
+}
+
+// mergeClientPerfResults sums up the data sent by all the clients of a
+// coordinated run, the time spent is the one of the slowest client since
+// all of them started at the same time.
+func mergeClientPerfResults(results []madmin.ClientPerfResult) madmin.ClientPerfResult {
+	var (
+		merged madmin.ClientPerfResult
+		errs   []string
+	)
+	for _, r := range results {
+		if r.Error != "" {
+			errs = append(errs, r.Error)
+			continue
+		}
+		if merged.Endpoint == "" {
+			merged.Endpoint = r.Endpoint
+		}
+		merged.BytesSend += r.BytesSend
+		if r.TimeSpent > merged.TimeSpent {
+			merged.TimeSpent = r.TimeSpent
+		}
+	}
+	if len(errs) > 0 {
+		merged.Error = fmt.Sprintf("%d of %d clients failed: %s", len(errs), len(results), strings.Join(errs, "; "))
+	}
+	return merged
+}
+
+// perfClientHostname returns the name used to identify this client.
+func perfClientHostname() string {
+	hostname, e := os.Hostname()
+	if e != nil || hostname == "" {
+		return "client"
+	}
+	return hostname
+}
+
+// perfClientCoordinatedMessage is the merged result of a coordinated
+// client perf test.
+type perfClientCoordinatedMessage struct {
+	Merged  madmin.ClientPerfResult
+	Reports []perfClientReport
+}
+
+func (m perfClientCoordinatedMessage) JSON() string {
+	result := PerfTestResult{
+		Type:          ClientPerfTest,
+		ClientResult:  &m.Merged,
+		ClientResults: m.Reports,
+		Final:         true,
+	}
+	return convertPerfResult(result).JSON()
+}
+
+func (m perfClientCoordinatedMessage) String() string {
+	var s strings.Builder
+	for _, report := range m.Reports {
+		if report.Result.Error != "" {
+			fmt.Fprintf(&s, "%s: %s\n", report.Name, console.Colorize("Error", report.Result.Error))
+			continue
+		}
+		fmt.Fprintf(&s, "%s: %s/s\n", report.Name, humanize.IBytes(clientPerfThroughput(report.Result)))
+	}
+	fmt.Fprintf(&s, "Total (%d clients): %s/s", len(m.Reports), humanize.IBytes(clientPerfThroughput(m.Merged)))
+	if m.Merged.Error != "" {
+		fmt.Fprintf(&s, "\n%s", console.Colorize("Error", m.Merged.Error))
+	}
+	return s.String()
+}
+
+// clientPerfThroughput returns the throughput in bytes per second.
+func clientPerfThroughput(r madmin.ClientPerfResult) uint64 {
+	if r.TimeSpent <= 0 {
+		return 0
+	}
+	return uint64(float64(r.BytesSend) / time.Duration(r.TimeSpent).Seconds())
+}
+
+// runPerfClientCoordinator runs the client perf test from this machine
+// and from clients-1 other mc instances joining with --coordinator.
+func runPerfClientCoordinator(ctx context.Context, aliasedURL, listenAddr string, clients int, duration time.Duration, outCh chan<- PerfTestResult) {
+	client, perr := newAdminClient(aliasedURL)
+	fatalIf(perr.Trace(aliasedURL), "Unable to initialize admin client.")
+
+	info, e := client.ServerInfo(ctx)
+	fatalIf(probe.NewError(e).Trace(aliasedURL), "Unable to fetch cluster info.")
+
+	coordinator := newPerfClientCoordinator(clients, duration, info.DeploymentID)
+	listener, e := net.Listen("tcp", listenAddr)
+	fatalIf(probe.NewError(e).Trace(listenAddr), "Unable to listen for the clients.")
+	server := &http.Server{Handler: coordinator, ReadHeaderTimeout: 10 * time.Second}
+	go server.Serve(listener)
+	defer server.Close()
+
+	if !globalJSON {
+		console.Infof("Waiting for %d more clients to join on %s\n", clients-1, listener.Addr())
+	}
+	select {
+	case <-coordinator.ready:
+	case <-ctx.Done():
+		fatalIf(probe.NewError(ctx.Err()), "Unable to start the coordinated client perf test.")
+	}
+
+	time.Sleep(time.Until(coordinator.startTime()))
+	own, e := client.ClientPerf(ctx, duration)
+	if e != nil {
+		own = madmin.ClientPerfResult{Error: e.Error()}
+	}
+	reports := []perfClientReport{{Name: perfClientHostname(), Result: own}}
+	deadline := coordinator.startTime().Add(madmin.MaxClientPerfTimeout + perfClientResultGrace)
+	reports = append(reports, coordinator.collect(ctx, deadline)...)
+
+	results := make([]madmin.ClientPerfResult, 0, len(reports))
+	for _, report := range reports {
+		results = append(results, report.Result)
+	}
+	msg := perfClientCoordinatedMessage{
+		Merged:  mergeClientPerfResults(results),
+		Reports: reports,
+	}
+	printMsg(msg)
+	if outCh != nil && !globalJSON {
+		// The result is only read once the test returned.
+		go func() {
+			outCh <- PerfTestResult{
+				Type:          ClientPerfTest,
+				ClientResult:  &msg.Merged,
+				ClientResults: reports,
+				Final:         true,
+			}
+		}()
+	}
+}
+
+// joinPerfClientCoordinator takes part in a coordinated client perf
+// test started with --coordinator-listen on another machine.
+func joinPerfClientCoordinator(ctx context.Context, aliasedURL, coordinatorURL string) {
+	client, perr := newAdminClient(aliasedURL)
+	fatalIf(perr.Trace(aliasedURL), "Unable to initialize admin client.")
+
+	info, e := client.ServerInfo(ctx)
+	fatalIf(probe.NewError(e).Trace(aliasedURL), "Unable to fetch cluster info.")
+
+	coordinatorURL = strings.TrimSuffix(coordinatorURL, "/")
+	if !globalJSON {
+		console.Infof("Waiting for the coordinator %s to start the test\n", coordinatorURL)
+	}
+	var plan perfClientPlan
+	e = postPerfClientCoordinator(ctx, coordinatorURL+"/join", perfClientJoin{
+		Name:         perfClientHostname(),
+		DeploymentID: info.DeploymentID,
+	}, &plan)
+	fatalIf(probe.NewError(e).Trace(coordinatorURL), "Unable to join the coordinator.")
+
+	time.Sleep(plan.StartIn)
+	result, e := client.ClientPerf(ctx, plan.Duration)
+	if e != nil {
+		result = madmin.ClientPerfResult{Error: e.Error()}
+	}
+	e = postPerfClientCoordinator(ctx, coordinatorURL+"/result", perfClientReport{
+		Name:   plan.Name,
+		Result: result,
+	}, nil)
+	fatalIf(probe.NewError(e).Trace(coordinatorURL), "Unable to send the result to the coordinator.")
+
+	printMsg(perfClientCoordinatedMessage{
+		Merged:  result,
+		Reports: []perfClientReport{{Name: plan.Name, Result: result}},
+	})
+}
+
+func postPerfClientCoordinator(ctx context.Context, url string, in, out interface{}) error {
+	body, e := gojson.Marshal(in)
+	if e != nil {
+		return e
+	}
+	req, e := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if e != nil {
+		return e
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, e := http.DefaultClient.Do(req)
+	if e != nil {
+		return e
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		var msg bytes.Buffer
+		msg.ReadFrom(resp.Body)
+		return fmt.Errorf("%s: %s", resp.Status, strings.TrimSpace(msg.String()))
+	}
+	if out == nil {
+		return nil
+	}
+	return gojson.NewDecoder(resp.Body).Decode(out)
+}
@@ -0,0 +1,117 @@
+// Copyright (c) 2015-2024 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/minio/madmin-go/v3"
+)
+
+func TestMergeClientPerfResults(t *testing.T) {
+	merged := mergeClientPerfResults([]madmin.ClientPerfResult{
+		{Endpoint: "minio:9000", BytesSend: 100, TimeSpent: int64(2 * time.Second)},
+		{Endpoint: "minio:9000", BytesSend: 300, TimeSpent: int64(3 * time.Second)},
+		{Error: "connection refused"},
+	})
+	if merged.BytesSend != 400 || merged.TimeSpent != int64(3*time.Second) || merged.Endpoint != "minio:9000" {
+		t.Errorf("unexpected merged result %+v", merged)
+	}
+	if !strings.HasPrefix(merged.Error, "1 of 3 clients failed") {
+		t.Errorf("unexpected error %q", merged.Error)
+	}
+	if tput := clientPerfThroughput(merged); tput != 133 {
+		t.Errorf("expected a throughput of 133, got %d", tput)
+	}
+}
+
+func TestPerfClientCoordinator(t *testing.T) {
+	coordinator := newPerfClientCoordinator(3, 5*time.Second, "deployment")
+	server := httptest.NewServer(coordinator)
+	defer server.Close()
+	ctx := context.Background()
+
+	err := postPerfClientCoordinator(ctx, server.URL+"/join", perfClientJoin{Name: "other", DeploymentID: "other-deployment"}, nil)
+	if err == nil || !strings.Contains(err.Error(), "409") {
+		t.Fatalf("expected a conflict for another cluster, got %v", err)
+	}
+
+	plans := make(chan perfClientPlan, 2)
+	for i := 0; i < 2; i++ {
+		go func() {
+			var plan perfClientPlan
+			if err := postPerfClientCoordinator(ctx, server.URL+"/join", perfClientJoin{Name: "host", DeploymentID: "deployment"}, &plan); err != nil {
+				t.Error(err)
+			}
+			plans <- plan
+		}()
+	}
+	names := make(map[string]bool)
+	for i := 0; i < 2; i++ {
+		plan := <-plans
+		if plan.Duration != 5*time.Second || plan.StartIn <= 0 || plan.StartIn > perfClientStartDelay {
+			t.Errorf("unexpected plan %+v", plan)
+		}
+		names[plan.Name] = true
+	}
+	if !names["host"] || !names["host#2"] {
+		t.Errorf("expected unique client names, got %v", names)
+	}
+
+	err = postPerfClientCoordinator(ctx, server.URL+"/join", perfClientJoin{Name: "late", DeploymentID: "deployment"}, nil)
+	if err == nil || !strings.Contains(err.Error(), "already joined") {
+		t.Fatalf("expected late clients to be rejected, got %v", err)
+	}
+
+	report := perfClientReport{Name: "host", Result: madmin.ClientPerfResult{BytesSend: 10, TimeSpent: 1}}
+	if err = postPerfClientCoordinator(ctx, server.URL+"/result", report, nil); err != nil {
+		t.Fatal(err)
+	}
+	reports := coordinator.collect(ctx, time.Now().Add(100*time.Millisecond))
+	if len(reports) != 2 {
+		t.Fatalf("expected 2 reports, got %+v", reports)
+	}
+	for _, r := range reports {
+		switch r.Name {
+		case "host":
+			if r.Result.BytesSend != 10 {
+				t.Errorf("unexpected report %+v", r)
+			}
+		case "host#2":
+			if r.Result.Error == "" {
+				t.Errorf("expected an error for the missing client, got %+v", r)
+			}
+		default:
+			t.Errorf("unexpected client %q", r.Name)
+		}
+	}
+
+	resp, err := http.Get(server.URL + "/join")
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusMethodNotAllowed {
+		t.Errorf("expected %d, got %d", http.StatusMethodNotAllowed, resp.StatusCode)
+	}
+}
@@ -19,7 +19,6 @@ package cmd
 
 import (
 	"context"
-	"os"
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
@@ -48,6 +47,11 @@ func mainAdminSpeedTestClientPerf(ctx *cli.Context, aliasedURL string, outCh cha
 		return nil
 	}
 
+	if listen := ctx.String("coordinator-listen"); listen != "" {
+		runPerfClientCoordinator(ctxt, aliasedURL, listen, ctx.Int("clients"), duration, outCh)
+		return nil
+	}
+
 	resultCh := make(chan madmin.ClientPerfResult)
 	errorCh := make(chan error)
 	go func() {
@@ -84,7 +88,7 @@ func mainAdminSpeedTestClientPerf(ctx *cli.Context, aliasedURL string, outCh cha
 	p := tea.NewProgram(initSpeedTestUI())
 	go func() {
 		if _, e := p.Run(); e != nil {
-			os.Exit(1)
+			cli.OsExiter(1)
 		}
 		close(done)
 	}()
@@ -19,7 +19,6 @@ package cmd
 
 import (
 	"context"
-	"os"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/dustin/go-humanize"
@@ -97,7 +96,7 @@ func mainAdminSpeedTestDrive(ctx *cli.Context, aliasedURL string, outCh chan<- P
 	p := tea.NewProgram(initSpeedTestUI())
 	go func() {
 		if _, e := p.Run(); e != nil {
-			os.Exit(1)
+			cli.OsExiter(1)
 		}
 		close(done)
 	}()
@@ -19,7 +19,6 @@ package cmd
 
 import (
 	"context"
-	"os"
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
@@ -124,7 +123,7 @@ func mainAdminSpeedTestObject(ctx *cli.Context, aliasedURL string, outCh chan<-
 	p := tea.NewProgram(initSpeedTestUI())
 	go func() {
 		if _, e := p.Run(); e != nil {
-			os.Exit(1)
+			cli.OsExiter(1)
 		}
 		close(done)
 	}()
@@ -19,7 +19,6 @@ package cmd
 
 import (
 	"context"
-	"os"
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
@@ -84,7 +83,7 @@ func mainAdminSpeedTestSiteReplication(ctx *cli.Context, aliasedURL string, outC
 	p := tea.NewProgram(initSpeedTestUI())
 	go func() {
 		if _, e := p.Run(); e != nil {
-			os.Exit(1)
+			cli.OsExiter(1)
 		}
 		close(done)
 	}()
@@ -83,6 +83,20 @@ var supportPerfFlags = append([]cli.Flag{
 		Usage:  "run tests on drive(s) one-by-one",
 		Hidden: true,
 	},
+	// Client test specific flags.
+	cli.StringFlag{
+		Name:  "coordinator-listen",
+		Usage: "coordinate a client test run from several machines, listening on this address for the other clients",
+	},
+	cli.IntFlag{
+		Name:  "clients",
+		Usage: "total number of clients of a coordinated client test, including the coordinator",
+		Value: 1,
+	},
+	cli.StringFlag{
+		Name:  "coordinator",
+		Usage: "join the coordinated client test of the coordinator listening at this URL",
+	},
 }, subnetCommonFlags...)
 
 var supportPerfCmd = cli.Command{
@@ -108,6 +122,10 @@ EXAMPLES:
 
   2. Run object storage, network, and drive performance tests on cluster with alias 'myminio', save and upload to SUBNET manually
      {{.Prompt}} {{.HelpName}} myminio --airgap
+
+  3. Run the client test from three machines at the same time against cluster with alias 'myminio' and merge their results
+     {{.Prompt}} {{.HelpName}} client myminio --coordinator-listen :9999 --clients 3
+     {{.Prompt}} {{.HelpName}} client myminio --coordinator http://coordinator-host:9999
 `,
 }
 
@@ -199,10 +217,12 @@ type NetTestResults struct {
 
 // ClientResult - result of the network from client to server
 type ClientResult struct {
-	BytesSent uint64 `json:"bytesSent"`
-	TimeSpent int64  `json:"timeSpent"`
-	Endpoint  string `json:"endpoint"`
-	Error     string `json:"error"`
+	Name      string         `json:"name,omitempty"`
+	BytesSent uint64         `json:"bytesSent"`
+	TimeSpent int64          `json:"timeSpent"`
+	Endpoint  string         `json:"endpoint"`
+	Error     string         `json:"error"`
+	Clients   []ClientResult `json:"clients,omitempty"`
 }
 
 // SiteNetStats - status for siteNet
@@ -291,12 +311,43 @@ func mainSupportPerf(ctx *cli.Context) error {
 		showCommandHelpAndExit(ctx, 1) // last argument is exit code
 	}
 
+	checkSupportPerfClientSyntax(ctx, perfType)
+	if coordinatorURL := ctx.String("coordinator"); coordinatorURL != "" {
+		// Only the coordinator saves or uploads the merged results.
+		joinPerfClientCoordinator(globalContext, aliasedURL, coordinatorURL)
+		return nil
+	}
+
 	// Main execution
 	execSupportPerf(ctx, aliasedURL, perfType)
 
 	return nil
 }
 
+// checkSupportPerfClientSyntax - validate the flags of a coordinated client test
+func checkSupportPerfClientSyntax(ctx *cli.Context, perfType string) {
+	listen := ctx.String("coordinator-listen")
+	coordinatorURL := ctx.String("coordinator")
+	if listen == "" && coordinatorURL == "" {
+		if ctx.IsSet("clients") {
+			fatalIf(errInvalidArgument(), "--clients requires --coordinator-listen.")
+		}
+		return
+	}
+	if perfType != "client" {
+		fatalIf(errInvalidArgument(), "--coordinator-listen and --coordinator are only supported by the client test.")
+	}
+	if listen != "" && coordinatorURL != "" {
+		fatalIf(errInvalidArgument(), "You cannot specify --coordinator-listen with --coordinator.")
+	}
+	if coordinatorURL != "" && ctx.IsSet("clients") {
+		fatalIf(errInvalidArgument(), "--clients requires --coordinator-listen.")
+	}
+	if listen != "" && ctx.Int("clients") < 2 {
+		fatalIf(errInvalidArgument(), "--clients must be at least 2 with --coordinator-listen.")
+	}
+}
+
 func convertDriveTestResult(dr madmin.DriveSpeedTestResult) DriveTestResult {
 	return DriveTestResult{
 		Endpoint: dr.Endpoint,
@@ -331,6 +382,30 @@ func convertClientResult(result *madmin.ClientPerfResult) *ClientResult {
 	}
 }
 
+// convertClientResults - converts the merged result of a coordinated
+// client perf test along with the result of each client.
+func convertClientResults(merged *madmin.ClientPerfResult, reports []perfClientReport) *ClientResult {
+	if merged == nil {
+		return nil
+	}
+	out := ClientResult{
+		BytesSent: merged.BytesSend,
+		TimeSpent: merged.TimeSpent,
+		Endpoint:  merged.Endpoint,
+		Error:     merged.Error,
+	}
+	for _, report := range reports {
+		out.Clients = append(out.Clients, ClientResult{
+			Name:      report.Name,
+			BytesSent: report.Result.BytesSend,
+			TimeSpent: report.Result.TimeSpent,
+			Endpoint:  report.Result.Endpoint,
+			Error:     report.Result.Error,
+		})
+	}
+	return &out
+}
+
 func convertSiteReplicationTestResults(netResults *madmin.SiteNetPerfResult) *SiteReplicationTestResults {
 	if netResults == nil {
 		return nil
@@ -440,7 +515,11 @@ func updatePerfOutput(r PerfTestResult, out *PerfTestOutput) {
 	case SiteReplicationPerfTest:
 		out.SiteReplicationResults = convertSiteReplicationTestResults(r.SiteReplicationResult)
 	case ClientPerfTest:
-		out.ClientResults = convertClientResult(r.ClientResult)
+		if len(r.ClientResults) > 0 {
+			out.ClientResults = convertClientResults(r.ClientResult, r.ClientResults)
+		} else {
+			out.ClientResults = convertClientResult(r.ClientResult)
+		}
 	default:
 		fatalIf(errDummy().Trace(), fmt.Sprintf("Invalid test type %d", r.Type))
 	}
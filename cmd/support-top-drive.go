@@ -33,6 +33,10 @@ var supportTopDriveFlags = []cli.Flag{
 		Usage: "show up to N drives",
 		Value: 10,
 	},
+	cli.BoolFlag{
+		Name:  "heatmap",
+		Usage: "show drives grouped by erasure set, highlighting latency outliers",
+	},
 }
 
 var supportTopDriveCmd = cli.Command{
@@ -57,6 +61,9 @@ FLAGS:
 EXAMPLES:
    1. Display drive metrics
       {{.Prompt}} {{.HelpName}} myminio/
+
+   2. Display a per erasure set heat map of drives, highlighting drives slower than their peers
+      {{.Prompt}} {{.HelpName}} --heatmap myminio/
 `,
 }
 
@@ -100,7 +107,7 @@ func mainSupportTopDrive(ctx *cli.Context) error {
 		N:        ctx.Int("count"),
 	}
 
-	p := tea.NewProgram(initTopDriveUI(disks, ctx.Int("count")))
+	p := tea.NewProgram(initTopDriveUI(disks, ctx.Int("count"), ctx.Bool("heatmap")))
 	go func() {
 		out := func(m madmin.RealtimeMetrics) {
 			for name, metric := range m.ByDisk {
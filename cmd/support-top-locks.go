@@ -18,6 +18,7 @@
 package cmd
 
 import (
+	"fmt"
 	"time"
 
 	humanize "github.com/dustin/go-humanize"
@@ -41,6 +42,10 @@ var supportTopLocksFlag = []cli.Flag{
 		Hidden: true,
 		Value:  10,
 	},
+	cli.DurationFlag{
+		Name:  "older-than",
+		Usage: "highlight locks held for longer than this duration as likely stuck",
+	},
 }
 
 var supportTopLocksCmd = cli.Command{
@@ -62,6 +67,9 @@ FLAGS:
 EXAMPLES:
   1. List oldest locks on a MinIO cluster.
      {{.Prompt}} {{.HelpName}} myminio/
+
+  2. List oldest locks, highlighting the ones held for more than 10 minutes.
+     {{.Prompt}} {{.HelpName}} --older-than 10m myminio/
 `,
 }
 
@@ -69,21 +77,37 @@ EXAMPLES:
 type lockMessage struct {
 	Status string           `json:"status"`
 	Lock   madmin.LockEntry `json:"locks"`
+	// Locks held for longer than olderThan are reported as stuck.
+	olderThan time.Duration
 }
 
-// String colorized oldest locks message.
-func (u lockMessage) String() string {
-	elapsed := u.Lock.Elapsed
+// lockElapsed returns for how long a lock has been held.
+func lockElapsed(lock madmin.LockEntry) time.Duration {
 	// elapsed can be zero with older MinIO versions,
 	// so this code is deprecated and can be removed later.
-	if elapsed == 0 {
-		elapsed = time.Now().UTC().Sub(u.Lock.Timestamp)
+	if lock.Elapsed == 0 {
+		return time.Now().UTC().Sub(lock.Timestamp)
 	}
+	return lock.Elapsed
+}
+
+// isStuck returns true when the lock was held for longer than the
+// --older-than threshold.
+func (u lockMessage) isStuck() bool {
+	return u.olderThan > 0 && lockElapsed(u.Lock) >= u.olderThan
+}
+
+// String colorized oldest locks message.
+func (u lockMessage) String() string {
+	elapsed := lockElapsed(u.Lock)
 
 	stale := u.Lock.Quorum > len(u.Lock.ServerList)
 	lockState := "Lock"
-	if stale {
+	switch {
+	case stale:
 		lockState = "StaleLock"
+	case u.isStuck():
+		lockState = "StuckLock"
 	}
 
 	return console.Colorize(lockState, newPrettyTable("  ",
@@ -108,6 +132,7 @@ func (u lockMessage) JSON() string {
 		// Represents quorum number of servers required to hold this lock, used to look for stale locks.
 		Quorum int  `json:"quorum"`
 		Stale  bool // Represents if the lock is stale.
+		Stuck  bool `json:"stuck,omitempty"` // Held for longer than --older-than.
 	}
 
 	le := lockEntry{
@@ -121,6 +146,7 @@ func (u lockMessage) JSON() string {
 		ID:         u.Lock.ID,
 		Quorum:     u.Lock.Quorum,
 		Stale:      u.Lock.Quorum > len(u.Lock.ServerList),
+		Stuck:      u.isStuck(),
 	}
 	statusJSONBytes, e := json.MarshalIndent(le, "", " ")
 	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
@@ -143,6 +169,7 @@ func mainSupportTopLocks(ctx *cli.Context) error {
 	validateClusterRegistered(alias, false)
 
 	console.SetColor("StaleLock", color.New(color.FgRed, color.Bold))
+	console.SetColor("StuckLock", color.New(color.FgYellow, color.Bold))
 	console.SetColor("Lock", color.New(color.FgBlue, color.Bold))
 	console.SetColor("Headers", color.New(color.FgGreen, color.Bold))
 
@@ -158,7 +185,7 @@ func mainSupportTopLocks(ctx *cli.Context) error {
 	fatalIf(probe.NewError(e), "Unable to get server locks list.")
 
 	// Print
-	printLocks(entries)
+	printLocks(entries, ctx.Duration("older-than"))
 	return nil
 }
 
@@ -178,11 +205,19 @@ func printHeaders() {
 }
 
 // Prints oldest locks.
-func printLocks(locks madmin.LockEntries) {
+func printLocks(locks madmin.LockEntries, olderThan time.Duration) {
 	if !globalJSON {
 		printHeaders()
 	}
+	var stuck int
 	for _, entry := range locks {
-		printMsg(lockMessage{Lock: entry})
+		msg := lockMessage{Lock: entry, olderThan: olderThan}
+		if msg.isStuck() {
+			stuck++
+		}
+		printMsg(msg)
+	}
+	if stuck > 0 && !globalJSON {
+		console.Println(console.Colorize("StuckLock", fmt.Sprintf("\n%d lock(s) held for more than %s, if their owner crashed they can be released with 'mc admin lock clear'.", stuck, olderThan)))
 	}
 }
@@ -39,6 +39,10 @@ var supportTopNetFlags = []cli.Flag{
 		Usage: "number of requests to run before exiting. 0 for endless (default)",
 		Value: 0,
 	},
+	cli.BoolFlag{
+		Name:  "peers",
+		Usage: "show internode traffic and errors between each pair of servers",
+	},
 }
 
 var supportTopNetCmd = cli.Command{
@@ -63,6 +67,9 @@ FLAGS:
 EXAMPLES:
    1. Display net metrics
       {{.Prompt}} {{.HelpName}} myminio/
+
+   2. Display the internode traffic matrix, press <tab> to list the traffic of each pair of servers
+      {{.Prompt}} {{.HelpName}} --peers myminio/
 `,
 }
 
@@ -90,6 +97,10 @@ func mainSupportTopNet(ctx *cli.Context) error {
 	ctxt, cancel := context.WithCancel(globalContext)
 	defer cancel()
 
+	if ctx.Bool("peers") {
+		return topNetPeers(ctxt, cancel, ctx, client, aliasedURL)
+	}
+
 	// MetricsOptions are options provided to Metrics call.
 	opts := madmin.MetricsOptions{
 		Type:     madmin.MetricNet,
@@ -139,3 +150,49 @@ func mainSupportTopNet(ctx *cli.Context) error {
 
 	return nil
 }
+
+// topNetPeers shows the internode traffic between each pair of servers,
+// computed from the RPC metrics each server reports for its peers.
+func topNetPeers(ctxt context.Context, cancel context.CancelFunc, ctx *cli.Context, client *madmin.AdminClient, aliasedURL string) error {
+	opts := madmin.MetricsOptions{
+		Type:     madmin.MetricsRPC,
+		Interval: time.Duration(ctx.Int("interval")) * time.Second,
+		N:        ctx.Int("n"),
+		ByHost:   true,
+	}
+	if globalJSON {
+		var prev map[string]madmin.Metrics
+		e := client.Metrics(ctxt, opts, func(metrics madmin.RealtimeMetrics) {
+			if len(metrics.ByHost) == 0 {
+				return
+			}
+			msg := topNetPeersMessage{Peers: topNetPeerRates(prev, metrics.ByHost)}
+			if metrics.Aggregated.RPC != nil {
+				msg.CollectedAt = metrics.Aggregated.RPC.CollectedAt
+			}
+			prev = metrics.ByHost
+			printMsg(msg)
+		})
+		if e != nil && !errors.Is(e, context.Canceled) {
+			fatalIf(probe.NewError(e).Trace(aliasedURL), "Unable to fetch internode metrics")
+		}
+		return nil
+	}
+
+	p := tea.NewProgram(initTopNetPeersUI())
+	go func() {
+		e := client.Metrics(ctxt, opts, func(m madmin.RealtimeMetrics) {
+			p.Send(m)
+		})
+		if e != nil && !errors.Is(e, context.Canceled) {
+			fatalIf(probe.NewError(e), "Unable to fetch internode metrics")
+		}
+		p.Quit()
+	}()
+
+	if _, e := p.Run(); e != nil {
+		cancel()
+		fatalIf(probe.NewError(e).Trace(aliasedURL), "Unable to fetch internode metrics")
+	}
+	return nil
+}
@@ -141,7 +141,7 @@ func mainSupportTopRPC(ctx *cli.Context) error {
 			ui.Send(metrics)
 			lastTime = metrics.Aggregated.RPC.CollectedAt
 		}
-		os.Exit(0)
+		cli.OsExiter(0)
 	}
 
 	aliasedURL := ctx.Args().Get(0)
@@ -0,0 +1,452 @@
+// Copyright (c) 2015-2024 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/dustin/go-humanize"
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	json "github.com/minio/colorjson"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/pkg/v3/console"
+)
+
+var syncFlags = []cli.Flag{
+	cli.BoolFlag{
+		Name:  "remove",
+		Usage: "remove the objects of the target missing from the source",
+	},
+	cli.IntFlag{
+		Name:  "max-delete",
+		Usage: "abort without changing anything if more than this number of objects would be removed",
+	},
+	cli.StringFlag{
+		Name:  "backup-dir",
+		Usage: "move the objects of the target replaced or removed by the sync to this location",
+	},
+	cli.BoolFlag{
+		Name:  "dry-run",
+		Usage: "print the changes and their summary without performing them",
+	},
+	cli.StringFlag{
+		Name:  "compare",
+		Usage: "compare objects by 'size' and modification time, or by 'checksum'",
+		Value: compareSize,
+	},
+	cli.StringSliceFlag{
+		Name:  "exclude",
+		Usage: "exclude object(s) that match specified object name pattern",
+	},
+	cli.IntFlag{
+		Name:  "workers",
+		Usage: "number of objects copied or removed at the same time",
+		Value: 4,
+	},
+}
+
+var syncCmd = cli.Command{
+	Name:         "sync",
+	Usage:        "synchronize a target with a source once, with protections against unwanted deletions",
+	Action:       mainSync,
+	OnUsageError: onUsageError,
+	Before:       setGlobalsFromContext,
+	Flags:        append(append(syncFlags, encFlags...), globalFlags...),
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} [FLAGS] SOURCE TARGET
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+DESCRIPTION:
+  Sync compares SOURCE and TARGET and copies the objects missing from TARGET or differing from
+  SOURCE. Unlike mirror, all the changes are planned before any of them is performed: with
+  --max-delete the sync aborts without changing anything when it would remove too many objects,
+  and --dry-run prints the plan and its summary. With --backup-dir the objects of TARGET replaced
+  or removed by the sync are first copied under the backup location, keeping their relative path.
+
+EXAMPLES:
+  1. Sync a local folder to a bucket, without removing anything from the bucket.
+     {{.Prompt}} {{.HelpName}} ~/photos play/photos
+
+  2. Print what a sync removing the extra objects of the target would do, without doing it.
+     {{.Prompt}} {{.HelpName}} --remove --dry-run play/photos backup/photos
+
+  3. Sync two buckets, aborting if more than 100 objects would be removed.
+     {{.Prompt}} {{.HelpName}} --remove --max-delete 100 play/photos backup/photos
+
+  4. Sync two buckets, keeping a copy of every replaced or removed object of the target.
+     {{.Prompt}} {{.HelpName}} --remove --backup-dir backup/photos-history/2024-06-01 play/photos backup/photos
+`,
+}
+
+// Actions performed by sync.
+const (
+	syncActionCopy    = "copy"
+	syncActionReplace = "replace"
+	syncActionRemove  = "remove"
+)
+
+// syncMessage is printed for every object copied, replaced or removed.
+type syncMessage struct {
+	Status string `json:"status"`
+	Action string `json:"action"`
+	Source string `json:"source,omitempty"`
+	Target string `json:"target"`
+	Backup string `json:"backup,omitempty"`
+	Size   int64  `json:"size"`
+	DryRun bool   `json:"dryRun,omitempty"`
+}
+
+func (s syncMessage) String() string {
+	var msg string
+	switch s.Action {
+	case syncActionRemove:
+		msg = console.Colorize("SyncRemove", fmt.Sprintf("Removed `%s`", s.Target))
+	case syncActionReplace:
+		msg = console.Colorize("SyncReplace", fmt.Sprintf("`%s` -> `%s` (replaced)", s.Source, s.Target))
+	default:
+		msg = console.Colorize("SyncCopy", fmt.Sprintf("`%s` -> `%s`", s.Source, s.Target))
+	}
+	if s.Backup != "" {
+		msg += fmt.Sprintf(", previous version moved to `%s`", s.Backup)
+	}
+	if s.DryRun {
+		msg = "[dry-run] " + msg
+	}
+	return msg
+}
+
+func (s syncMessage) JSON() string {
+	s.Status = "success"
+	jsonMessageBytes, e := json.MarshalIndent(s, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+	return string(jsonMessageBytes)
+}
+
+// syncSummaryMessage sums up the changes of a sync.
+type syncSummaryMessage struct {
+	Status      string `json:"status"`
+	DryRun      bool   `json:"dryRun,omitempty"`
+	Copied      int64  `json:"copied"`
+	Replaced    int64  `json:"replaced"`
+	Removed     int64  `json:"removed"`
+	Transferred int64  `json:"transferred"`
+	Failed      int64  `json:"failed"`
+}
+
+func (s syncSummaryMessage) String() string {
+	verb := "Synced"
+	if s.DryRun {
+		verb = "Would sync"
+	}
+	msg := fmt.Sprintf("%s: %d copied, %d replaced, %d removed, %s transferred",
+		verb, s.Copied, s.Replaced, s.Removed, humanize.IBytes(uint64(s.Transferred)))
+	if s.Failed > 0 {
+		msg += fmt.Sprintf(", %d failed", s.Failed)
+	}
+	return console.Colorize("SyncSummary", msg)
+}
+
+func (s syncSummaryMessage) JSON() string {
+	s.Status = "success"
+	jsonMessageBytes, e := json.MarshalIndent(s, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+	return string(jsonMessageBytes)
+}
+
+// syncTarget is the expanded form of an URL argument of sync.
+type syncTarget struct {
+	alias, url string
+}
+
+// newSyncTarget expands the alias of urlStr, the returned URL always ends
+// with a separator.
+func newSyncTarget(urlStr string) syncTarget {
+	separator := string(newClientURL(urlStr).Separator)
+	if !strings.HasSuffix(urlStr, separator) {
+		urlStr += separator
+	}
+	alias, expanded, _ := mustExpandAlias(urlStr)
+	if alias == "" {
+		if abs, e := filepath.Abs(expanded); e == nil {
+			expanded = abs + separator
+		}
+	}
+	return syncTarget{alias: alias, url: expanded}
+}
+
+// path returns the aliased path of content, as printed to the user.
+func (t syncTarget) path(content *ClientContent) string {
+	return filepath.ToSlash(filepath.Join(t.alias, content.URL.Path))
+}
+
+// checkSyncSyntax - validate all the passed arguments
+func checkSyncSyntax(cliCtx *cli.Context) {
+	if len(cliCtx.Args()) != 2 {
+		showCommandHelpAndExit(cliCtx, 1) // last argument is exit code
+	}
+	if mode := cliCtx.String("compare"); !isValidCompareMode(mode) {
+		fatalIf(errInvalidArgument().Trace(mode), "Invalid --compare value, must be one of '"+compareSize+"' or '"+compareChecksum+"'.")
+	}
+	if cliCtx.IsSet("max-delete") {
+		if !cliCtx.Bool("remove") {
+			fatalIf(errInvalidArgument().Trace(), "--max-delete requires --remove.")
+		}
+		if cliCtx.Int("max-delete") < 0 {
+			fatalIf(errInvalidArgument().Trace(cliCtx.String("max-delete")), "--max-delete cannot be negative.")
+		}
+	}
+	if cliCtx.Int("workers") < 1 {
+		fatalIf(errInvalidArgument().Trace(cliCtx.String("workers")), "--workers must be at least 1.")
+	}
+	if backupDir := cliCtx.String("backup-dir"); backupDir != "" {
+		target := newSyncTarget(cliCtx.Args().Get(1))
+		backup := newSyncTarget(backupDir)
+		if backup.alias == target.alias && strings.HasPrefix(backup.url, target.url) {
+			fatalIf(errInvalidArgument().Trace(backupDir), "--backup-dir cannot be inside the target.")
+		}
+	}
+}
+
+// syncJob performs the changes of a plan.
+type syncJob struct {
+	target, backup syncTarget
+	encKeyDB       map[string][]prefixSSEPair
+	dryRun         bool
+
+	mu      sync.Mutex
+	summary syncSummaryMessage
+}
+
+// backupPath returns the location content of the target is moved to
+// before being replaced or removed.
+func (j *syncJob) backupPath(content *ClientContent) string {
+	rel := strings.TrimPrefix(content.URL.String(), j.target.url)
+	return urlJoinPath(j.backup.url, rel)
+}
+
+// moveToBackup copies content of the target under the backup location.
+func (j *syncJob) moveToBackup(ctx context.Context, content *ClientContent) (string, *probe.Error) {
+	backupURL := j.backupPath(content)
+	backupContent := &ClientContent{URL: *newClientURL(backupURL)}
+	if j.dryRun {
+		return j.backup.path(backupContent), nil
+	}
+	urls := uploadSourceToTargetURL(ctx, uploadSourceToTargetURLOpts{
+		urls: URLs{
+			SourceAlias:   j.target.alias,
+			SourceContent: content,
+			TargetAlias:   j.backup.alias,
+			TargetContent: backupContent,
+		},
+		progress: newAccounter(content.Size),
+		encKeyDB: j.encKeyDB,
+		preserve: true,
+	})
+	if urls.Error != nil {
+		return "", urls.Error.Trace(content.URL.String(), backupURL)
+	}
+	return j.backup.path(backupContent), nil
+}
+
+// doCopy copies or replaces an object of the target.
+func (j *syncJob) doCopy(ctx context.Context, sURLs URLs) *probe.Error {
+	msg := syncMessage{
+		Action: syncActionCopy,
+		Source: filepath.ToSlash(filepath.Join(sURLs.SourceAlias, sURLs.SourceContent.URL.Path)),
+		Target: filepath.ToSlash(filepath.Join(sURLs.TargetAlias, sURLs.TargetContent.URL.Path)),
+		Size:   sURLs.SourceContent.Size,
+		DryRun: j.dryRun,
+	}
+	if sURLs.overwritten != nil {
+		msg.Action = syncActionReplace
+		if j.backup.url != "" {
+			backup, err := j.moveToBackup(ctx, sURLs.overwritten)
+			if err != nil {
+				return err
+			}
+			msg.Backup = backup
+		}
+	}
+	if !j.dryRun {
+		urls := uploadSourceToTargetURL(ctx, uploadSourceToTargetURLOpts{
+			urls:     sURLs,
+			progress: newAccounter(sURLs.SourceContent.Size),
+			encKeyDB: j.encKeyDB,
+			preserve: true,
+		})
+		if urls.Error != nil {
+			return urls.Error.Trace(sURLs.SourceContent.URL.String())
+		}
+	}
+	printMsg(msg)
+
+	j.mu.Lock()
+	if msg.Action == syncActionReplace {
+		j.summary.Replaced++
+	} else {
+		j.summary.Copied++
+	}
+	j.summary.Transferred += msg.Size
+	j.mu.Unlock()
+	return nil
+}
+
+// doRemove removes an object of the target.
+func (j *syncJob) doRemove(ctx context.Context, sURLs URLs) *probe.Error {
+	content := sURLs.TargetContent
+	msg := syncMessage{
+		Action: syncActionRemove,
+		Target: j.target.path(content),
+		Size:   content.Size,
+		DryRun: j.dryRun,
+	}
+	if j.backup.url != "" {
+		backup, err := j.moveToBackup(ctx, content)
+		if err != nil {
+			return err
+		}
+		msg.Backup = backup
+	}
+	if !j.dryRun {
+		clnt, err := newClientFromAlias(sURLs.TargetAlias, content.URL.String())
+		if err != nil {
+			return err.Trace(msg.Target)
+		}
+		contentCh := make(chan *ClientContent, 1)
+		contentCh <- &ClientContent{URL: content.URL}
+		close(contentCh)
+		isRemoveBucket := false
+		for result := range clnt.Remove(ctx, false, isRemoveBucket, false, false, contentCh) {
+			if result.Err != nil {
+				return result.Err.Trace(msg.Target)
+			}
+		}
+	}
+	printMsg(msg)
+
+	j.mu.Lock()
+	j.summary.Removed++
+	j.mu.Unlock()
+	return nil
+}
+
+// run performs the changes of plan with the given number of workers,
+// copies are done before removals.
+func (j *syncJob) run(ctx context.Context, plan *syncPlan, workers int) *probe.Error {
+	for _, step := range []struct {
+		list *syncPlanList
+		do   func(context.Context, URLs) *probe.Error
+	}{
+		{plan.copies, j.doCopy},
+		{plan.removes, j.doRemove},
+	} {
+		urlsCh := make(chan URLs)
+		var wg sync.WaitGroup
+		for i := 0; i < workers; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for sURLs := range urlsCh {
+					if err := step.do(ctx, sURLs); err != nil {
+						errorIf(err, "Unable to sync `%s`.", sURLs.TargetContent.URL.String())
+						j.mu.Lock()
+						j.summary.Failed++
+						j.mu.Unlock()
+					}
+				}
+			}()
+		}
+		err := step.list.each(func(sURLs URLs) bool {
+			select {
+			case urlsCh <- sURLs:
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		})
+		close(urlsCh)
+		wg.Wait()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func mainSync(cliCtx *cli.Context) error {
+	ctx, cancelSync := context.WithCancel(globalContext)
+	defer cancelSync()
+
+	console.SetColor("SyncCopy", color.New(color.FgGreen))
+	console.SetColor("SyncReplace", color.New(color.FgYellow))
+	console.SetColor("SyncRemove", color.New(color.FgRed))
+	console.SetColor("SyncSummary", color.New(color.Bold))
+
+	checkSyncSyntax(cliCtx)
+
+	encKeyDB, err := validateAndCreateEncryptionKeys(cliCtx)
+	fatalIf(err, "Unable to parse encryption keys.")
+
+	source, target := cliCtx.Args().Get(0), cliCtx.Args().Get(1)
+	dryRun := cliCtx.Bool("dry-run")
+
+	plan, err := planSync(ctx, source, target, mirrorOptions{
+		isOverwrite:    true,
+		isRemove:       cliCtx.Bool("remove"),
+		compare:        cliCtx.String("compare"),
+		excludeOptions: cliCtx.StringSlice("exclude"),
+		encKeyDB:       encKeyDB,
+	})
+	fatalIf(err, "Unable to compare `%s` and `%s`.", source, target)
+
+	if cliCtx.IsSet("max-delete") {
+		if err = plan.checkMaxDelete(cliCtx.Int("max-delete")); err != nil {
+			plan.close()
+			fatalIf(err.Trace(source, target), "Sync aborted, nothing was changed.")
+		}
+	}
+
+	job := &syncJob{
+		target:   newSyncTarget(target),
+		encKeyDB: encKeyDB,
+		dryRun:   dryRun,
+	}
+	if backupDir := cliCtx.String("backup-dir"); backupDir != "" {
+		job.backup = newSyncTarget(backupDir)
+	}
+	job.summary.DryRun = dryRun
+	err = job.run(ctx, plan, cliCtx.Int("workers"))
+	plan.close()
+	fatalIf(err, "Unable to read the sync plan.")
+
+	printMsg(job.summary)
+	if job.summary.Failed > 0 {
+		return exitStatus(globalErrorExitStatus)
+	}
+	return nil
+}
@@ -0,0 +1,212 @@
+// Copyright (c) 2015-2024 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"maps"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+)
+
+func TestSyncBackupPath(t *testing.T) {
+	testCases := []struct {
+		target, backup syncTarget
+		content        string
+		want           string
+	}{
+		{
+			syncTarget{url: "/data/photos/"},
+			syncTarget{url: "/backup/2024/"},
+			"/data/photos/a/b.jpg",
+			"/backup/2024/a/b.jpg",
+		},
+		{
+			syncTarget{alias: "play", url: "https://play.min.io/photos/"},
+			syncTarget{alias: "play", url: "https://play.min.io/history/2024/"},
+			"https://play.min.io/photos/2024/x.jpg",
+			"https://play.min.io/history/2024/2024/x.jpg",
+		},
+		{
+			syncTarget{alias: "play", url: "https://play.min.io/photos/"},
+			syncTarget{url: "/backup/"},
+			"https://play.min.io/photos/x.jpg",
+			"/backup/x.jpg",
+		},
+	}
+	for i, tc := range testCases {
+		j := &syncJob{target: tc.target, backup: tc.backup}
+		content := &ClientContent{URL: *newClientURL(tc.content)}
+		if got := j.backupPath(content); got != tc.want {
+			t.Errorf("test %d: expected %q, got %q", i+1, tc.want, got)
+		}
+	}
+}
+
+// newSyncTestDirs returns a source with a.txt and b.txt and a target with
+// an older b.txt, c.txt and d.txt, each with a trailing separator.
+func newSyncTestDirs(t *testing.T) (source, target string) {
+	dir := t.TempDir()
+	source = filepath.Join(dir, "source") + string(filepath.Separator)
+	target = filepath.Join(dir, "target") + string(filepath.Separator)
+	files := map[string]string{
+		filepath.Join(source, "a.txt"): "a",
+		filepath.Join(source, "b.txt"): "new b",
+		filepath.Join(target, "b.txt"): "b",
+		filepath.Join(target, "c.txt"): "c",
+		filepath.Join(target, "d.txt"): "d",
+	}
+	for name, data := range files {
+		if e := os.MkdirAll(filepath.Dir(name), 0o700); e != nil {
+			t.Fatal(e)
+		}
+		if e := os.WriteFile(name, []byte(data), 0o600); e != nil {
+			t.Fatal(e)
+		}
+	}
+	return source, target
+}
+
+// listSyncTestDir returns the files under dir with their content.
+func listSyncTestDir(t *testing.T, dir string) map[string]string {
+	files := make(map[string]string)
+	e := filepath.Walk(dir, func(name string, info os.FileInfo, e error) error {
+		if e != nil || info.IsDir() {
+			return e
+		}
+		data, e := os.ReadFile(name)
+		if e != nil {
+			return e
+		}
+		rel, _ := filepath.Rel(dir, name)
+		files[filepath.ToSlash(rel)] = string(data)
+		return nil
+	})
+	if e != nil && !os.IsNotExist(e) {
+		t.Fatal(e)
+	}
+	return files
+}
+
+func TestSyncPlanMaxDelete(t *testing.T) {
+	useTestMcConfig(t)
+	source, target := newSyncTestDirs(t)
+
+	for _, remove := range []bool{false, true} {
+		plan, err := planSync(context.Background(), source, target, mirrorOptions{
+			isOverwrite: true,
+			isRemove:    remove,
+			compare:     compareSize,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		wantRemoves := 0
+		if remove {
+			wantRemoves = 2
+		}
+		if plan.copies.count != 2 || plan.removes.count != wantRemoves || plan.bytes != 6 {
+			t.Errorf("remove=%v: expected 2 copies, %d removals and 6 bytes, got %d copies, %d removals and %d bytes",
+				remove, wantRemoves, plan.copies.count, plan.removes.count, plan.bytes)
+		}
+
+		var removes []string
+		if err = plan.removes.each(func(sURLs URLs) bool {
+			removes = append(removes, filepath.Base(sURLs.TargetContent.URL.Path))
+			return true
+		}); err != nil {
+			t.Fatal(err)
+		}
+		sort.Strings(removes)
+		if remove && (len(removes) != 2 || removes[0] != "c.txt" || removes[1] != "d.txt") {
+			t.Errorf("expected the removal of c.txt and d.txt, got %v", removes)
+		}
+
+		for maxDelete := 0; maxDelete <= 3; maxDelete++ {
+			err = plan.checkMaxDelete(maxDelete)
+			if abort := maxDelete < wantRemoves; abort != (err != nil) {
+				t.Errorf("remove=%v, max-delete %d: expected abort %v, got %v", remove, maxDelete, abort, err)
+			}
+		}
+		plan.close()
+	}
+}
+
+func TestSyncJobRun(t *testing.T) {
+	useTestMcConfig(t)
+	defer func(quiet bool) { globalQuiet = quiet }(globalQuiet)
+	globalQuiet = true
+
+	testCases := []struct {
+		dryRun     bool
+		backup     bool
+		wantTarget map[string]string
+		wantBackup map[string]string
+	}{
+		{
+			dryRun:     true,
+			backup:     true,
+			wantTarget: map[string]string{"b.txt": "b", "c.txt": "c", "d.txt": "d"},
+			wantBackup: map[string]string{},
+		},
+		{
+			wantTarget: map[string]string{"a.txt": "a", "b.txt": "new b"},
+			wantBackup: map[string]string{},
+		},
+		{
+			backup:     true,
+			wantTarget: map[string]string{"a.txt": "a", "b.txt": "new b"},
+			wantBackup: map[string]string{"b.txt": "b", "c.txt": "c", "d.txt": "d"},
+		},
+	}
+	for i, tc := range testCases {
+		source, target := newSyncTestDirs(t)
+		backup := filepath.Join(t.TempDir(), "backup") + string(filepath.Separator)
+
+		plan, err := planSync(context.Background(), source, target, mirrorOptions{
+			isOverwrite: true,
+			isRemove:    true,
+			compare:     compareSize,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		j := &syncJob{target: newSyncTarget(target), dryRun: tc.dryRun}
+		if tc.backup {
+			j.backup = newSyncTarget(backup)
+		}
+		err = j.run(context.Background(), plan, 2)
+		plan.close()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		s := j.summary
+		if s.Copied != 1 || s.Replaced != 1 || s.Removed != 2 || s.Transferred != 6 || s.Failed != 0 {
+			t.Errorf("test %d: unexpected summary %+v", i+1, s)
+		}
+		if got := listSyncTestDir(t, target); !maps.Equal(got, tc.wantTarget) {
+			t.Errorf("test %d: expected target %v, got %v", i+1, tc.wantTarget, got)
+		}
+		if got := listSyncTestDir(t, backup); !maps.Equal(got, tc.wantBackup) {
+			t.Errorf("test %d: expected backup %v, got %v", i+1, tc.wantBackup, got)
+		}
+	}
+}
@@ -0,0 +1,170 @@
+// Copyright (c) 2015-2024 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/minio/mc/pkg/probe"
+)
+
+// syncPlanEntry is a change of a sync plan, as stored in its temporary file.
+type syncPlanEntry struct {
+	SourceAlias string         `json:"sourceAlias,omitempty"`
+	Source      *ClientContent `json:"source,omitempty"`
+	TargetAlias string         `json:"targetAlias"`
+	Target      *ClientContent `json:"target"`
+	Overwritten *ClientContent `json:"overwritten,omitempty"`
+}
+
+// syncPlanList stores changes of a sync plan in a temporary file, one JSON
+// object per line, the difference of source and target is never held in
+// memory.
+type syncPlanList struct {
+	f     *os.File
+	w     *bufio.Writer
+	enc   *json.Encoder
+	count int
+}
+
+func newSyncPlanList() (*syncPlanList, *probe.Error) {
+	f, e := os.CreateTemp("", "mc-sync-plan-*.json")
+	if e != nil {
+		return nil, probe.NewError(e)
+	}
+	w := bufio.NewWriter(f)
+	return &syncPlanList{f: f, w: w, enc: json.NewEncoder(w)}, nil
+}
+
+// add appends the change of sURLs to the list.
+func (l *syncPlanList) add(sURLs URLs) *probe.Error {
+	e := l.enc.Encode(syncPlanEntry{
+		SourceAlias: sURLs.SourceAlias,
+		Source:      sURLs.SourceContent,
+		TargetAlias: sURLs.TargetAlias,
+		Target:      sURLs.TargetContent,
+		Overwritten: sURLs.overwritten,
+	})
+	if e != nil {
+		return probe.NewError(e).Trace(l.f.Name())
+	}
+	l.count++
+	return nil
+}
+
+// each calls fn for every change of the list in the order they were added,
+// until fn returns false.
+func (l *syncPlanList) each(fn func(URLs) bool) *probe.Error {
+	if e := l.w.Flush(); e != nil {
+		return probe.NewError(e).Trace(l.f.Name())
+	}
+	if _, e := l.f.Seek(0, io.SeekStart); e != nil {
+		return probe.NewError(e).Trace(l.f.Name())
+	}
+	dec := json.NewDecoder(bufio.NewReader(l.f))
+	for {
+		var entry syncPlanEntry
+		e := dec.Decode(&entry)
+		if errors.Is(e, io.EOF) {
+			return nil
+		}
+		if e != nil {
+			return probe.NewError(e).Trace(l.f.Name())
+		}
+		if !fn(URLs{
+			SourceAlias:   entry.SourceAlias,
+			SourceContent: entry.Source,
+			TargetAlias:   entry.TargetAlias,
+			TargetContent: entry.Target,
+			overwritten:   entry.Overwritten,
+		}) {
+			return nil
+		}
+	}
+}
+
+// close removes the temporary file of the list.
+func (l *syncPlanList) close() {
+	l.f.Close()
+	os.Remove(l.f.Name())
+}
+
+// syncPlan holds the changes of a sync, computed before any is performed.
+type syncPlan struct {
+	copies  *syncPlanList
+	removes *syncPlanList
+	bytes   int64
+}
+
+// close removes the temporary files of the plan.
+func (p *syncPlan) close() {
+	p.copies.close()
+	p.removes.close()
+}
+
+// checkMaxDelete fails when the plan removes more than maxDelete objects.
+func (p *syncPlan) checkMaxDelete(maxDelete int) *probe.Error {
+	if p.removes.count > maxDelete {
+		return probe.NewError(fmt.Errorf("sync would remove %d objects, more than --max-delete %d", p.removes.count, maxDelete))
+	}
+	return nil
+}
+
+// planSync compares source and target and returns the changes needed to
+// synchronize them. Any error aborts the planning, a sync never runs with
+// a partial plan.
+func planSync(ctx context.Context, source, target string, opts mirrorOptions) (*syncPlan, *probe.Error) {
+	copies, err := newSyncPlanList()
+	if err != nil {
+		return nil, err
+	}
+	removes, err := newSyncPlanList()
+	if err != nil {
+		copies.close()
+		return nil, err
+	}
+	plan := &syncPlan{copies: copies, removes: removes}
+
+	URLsCh := make(chan URLs, 10000)
+	go deltaSourceTarget(ctx, source, target, opts, URLsCh)
+
+	for sURLs := range URLsCh {
+		switch {
+		case err != nil:
+			// Drain the channel.
+		case sURLs.Error != nil:
+			err = sURLs.Error
+		case sURLs.SourceContent != nil:
+			err = plan.copies.add(sURLs)
+			plan.bytes += sURLs.SourceContent.Size
+		case sURLs.TargetContent != nil:
+			err = plan.removes.add(sURLs)
+		}
+	}
+	if err != nil {
+		plan.close()
+		return nil, err
+	}
+	return plan, nil
+}
@@ -0,0 +1,392 @@
+// Copyright (c) 2015-2024 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/pkg/v3/env"
+)
+
+const (
+	// Endpoint of an OpenTelemetry collector accepting OTLP/HTTP, enables tracing of mc operations.
+	mcEnvOTelEndpoint = "MC_OTEL_ENDPOINT"
+	// W3C trace context of the caller, spans emitted by mc become its children.
+	mcEnvTraceParent = "TRACEPARENT"
+
+	telemetryFlushInterval = 5 * time.Second
+	telemetryMaxBatch      = 512
+	// Number of full batches queued for export, spans are dropped beyond it.
+	telemetryMaxQueuedBatches = 8
+)
+
+// OTLP span kinds and status codes.
+const (
+	otlpSpanKindInternal = 1
+	otlpSpanKindClient   = 3
+
+	otlpStatusOK    = 1
+	otlpStatusError = 2
+)
+
+// globalTelemetry is nil unless tracing of mc operations is enabled.
+var globalTelemetry *telemetryExporter
+
+type telemetrySpanKey struct{}
+
+// telemetrySpan is a single traced operation.
+type telemetrySpan struct {
+	traceID  [16]byte
+	spanID   [8]byte
+	parentID [8]byte
+	name     string
+	kind     int
+	start    time.Time
+
+	mu    sync.Mutex
+	attrs map[string]string
+	ended bool
+}
+
+// telemetryExporter batches finished spans and sends them to an OTLP/HTTP collector.
+type telemetryExporter struct {
+	endpoint string
+	client   *http.Client
+	root     *telemetrySpan
+
+	mu      sync.Mutex
+	pending []otlpSpan
+	dropped int
+
+	// Full batches are exported by run(), spans never wait for the collector.
+	batchCh chan []otlpSpan
+
+	shutdownOnce sync.Once
+	stopCh       chan struct{}
+	doneCh       chan struct{}
+}
+
+// initTelemetry enables tracing if MC_OTEL_ENDPOINT is set, a root span named
+// after the command is attached to the global context.
+func initTelemetry(command string) {
+	endpoint := env.Get(mcEnvOTelEndpoint, "")
+	if endpoint == "" || globalTelemetry != nil {
+		return
+	}
+	if !strings.Contains(endpoint, "://") {
+		endpoint = "http://" + endpoint
+	}
+	u, e := url.Parse(endpoint)
+	fatalIf(probe.NewError(e).Trace(endpoint), "Unable to parse %s.", mcEnvOTelEndpoint)
+	if u.Path == "" || u.Path == "/" {
+		u.Path = "/v1/traces"
+	}
+
+	t := &telemetryExporter{
+		endpoint: u.String(),
+		client:   httpClient(10 * time.Second),
+		batchCh:  make(chan []otlpSpan, telemetryMaxQueuedBatches),
+		stopCh:   make(chan struct{}),
+		doneCh:   make(chan struct{}),
+	}
+
+	root := newTelemetrySpan(strings.TrimSpace("mc "+command), otlpSpanKindInternal, nil)
+	if traceID, parentID, ok := parseTraceParent(env.Get(mcEnvTraceParent, "")); ok {
+		root.traceID = traceID
+		root.parentID = parentID
+	}
+	t.root = root
+
+	globalTelemetry = t
+	globalContext = context.WithValue(globalContext, telemetrySpanKey{}, root)
+	go t.run()
+}
+
+// shutdownTelemetry ends the root span and flushes all pending spans.
+func shutdownTelemetry() {
+	t := globalTelemetry
+	if t == nil {
+		return
+	}
+	t.shutdownOnce.Do(func() {
+		t.root.End(nil)
+		close(t.stopCh)
+		<-t.doneCh
+	})
+}
+
+// parseTraceParent parses a W3C traceparent value, e.g.
+// 00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01
+func parseTraceParent(s string) (traceID [16]byte, parentID [8]byte, ok bool) {
+	parts := strings.Split(strings.TrimSpace(s), "-")
+	if len(parts) != 4 || len(parts[1]) != 32 || len(parts[2]) != 16 {
+		return traceID, parentID, false
+	}
+	if _, e := hex.Decode(traceID[:], []byte(parts[1])); e != nil {
+		return traceID, parentID, false
+	}
+	if _, e := hex.Decode(parentID[:], []byte(parts[2])); e != nil {
+		return traceID, parentID, false
+	}
+	return traceID, parentID, traceID != [16]byte{}
+}
+
+func newTelemetrySpan(name string, kind int, parent *telemetrySpan) *telemetrySpan {
+	s := &telemetrySpan{
+		name:  name,
+		kind:  kind,
+		start: time.Now(),
+		attrs: make(map[string]string),
+	}
+	rand.Read(s.spanID[:])
+	if parent != nil {
+		s.traceID = parent.traceID
+		s.parentID = parent.spanID
+	} else {
+		rand.Read(s.traceID[:])
+	}
+	return s
+}
+
+// startSpan starts a child span of the span found in ctx, returns a nil span
+// if tracing is disabled. All telemetrySpan methods are safe on nil spans.
+func startSpan(ctx context.Context, name string, attrs map[string]string) (context.Context, *telemetrySpan) {
+	if globalTelemetry == nil {
+		return ctx, nil
+	}
+	parent, _ := ctx.Value(telemetrySpanKey{}).(*telemetrySpan)
+	if parent == nil {
+		parent = globalTelemetry.root
+	}
+	s := newTelemetrySpan(name, otlpSpanKindClient, parent)
+	for k, v := range attrs {
+		s.attrs[k] = v
+	}
+	return context.WithValue(ctx, telemetrySpanKey{}, s), s
+}
+
+// SetAttr sets an attribute on the span.
+func (s *telemetrySpan) SetAttr(key, value string) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	s.attrs[key] = value
+	s.mu.Unlock()
+}
+
+// traceParent returns the W3C traceparent header value of the span.
+func (s *telemetrySpan) traceParent() string {
+	return fmt.Sprintf("00-%s-%s-01", hex.EncodeToString(s.traceID[:]), hex.EncodeToString(s.spanID[:]))
+}
+
+// End finishes the span, marking it failed if err is set.
+func (s *telemetrySpan) End(err *probe.Error) {
+	if s == nil || globalTelemetry == nil {
+		return
+	}
+	s.mu.Lock()
+	if s.ended {
+		s.mu.Unlock()
+		return
+	}
+	s.ended = true
+
+	span := otlpSpan{
+		TraceID:           hex.EncodeToString(s.traceID[:]),
+		SpanID:            hex.EncodeToString(s.spanID[:]),
+		Name:              s.name,
+		Kind:              s.kind,
+		StartTimeUnixNano: strconv.FormatInt(s.start.UnixNano(), 10),
+		EndTimeUnixNano:   strconv.FormatInt(time.Now().UnixNano(), 10),
+		Attributes:        otlpAttributes(s.attrs),
+		Status:            otlpSpanStatus{Code: otlpStatusOK},
+	}
+	s.mu.Unlock()
+	if s.parentID != [8]byte{} {
+		span.ParentSpanID = hex.EncodeToString(s.parentID[:])
+	}
+	if err != nil {
+		span.Status = otlpSpanStatus{Code: otlpStatusError, Message: err.ToGoError().Error()}
+	}
+	globalTelemetry.add(span)
+}
+
+// endSpanOnClose forwards the values of ch to the returned channel and ends
+// span when ch is closed, with the first error found by errOf. Values are
+// drained without being forwarded once done is closed.
+func endSpanOnClose[T any](span *telemetrySpan, done <-chan struct{}, ch <-chan T, errOf func(T) *probe.Error) <-chan T {
+	if span == nil {
+		return ch
+	}
+	outCh := make(chan T)
+	go func() {
+		defer close(outCh)
+		var err *probe.Error
+		for v := range ch {
+			if err == nil {
+				err = errOf(v)
+			}
+			select {
+			case outCh <- v:
+			case <-done:
+			}
+		}
+		span.End(err)
+	}()
+	return outCh
+}
+
+type otlpSpanStatus struct {
+	Code    int    `json:"code"`
+	Message string `json:"message,omitempty"`
+}
+
+type otlpSpan struct {
+	TraceID           string         `json:"traceId"`
+	SpanID            string         `json:"spanId"`
+	ParentSpanID      string         `json:"parentSpanId,omitempty"`
+	Name              string         `json:"name"`
+	Kind              int            `json:"kind"`
+	StartTimeUnixNano string         `json:"startTimeUnixNano"`
+	EndTimeUnixNano   string         `json:"endTimeUnixNano"`
+	Attributes        []otlpKeyValue `json:"attributes,omitempty"`
+	Status            otlpSpanStatus `json:"status"`
+}
+
+type otlpTracesRequest struct {
+	ResourceSpans []otlpResourceSpans `json:"resourceSpans"`
+}
+
+type otlpResourceSpans struct {
+	Resource   otlpResource     `json:"resource"`
+	ScopeSpans []otlpScopeSpans `json:"scopeSpans"`
+}
+
+type otlpScopeSpans struct {
+	Scope otlpScope  `json:"scope"`
+	Spans []otlpSpan `json:"spans"`
+}
+
+// add queues a finished span, full batches are handed to run() and are
+// dropped when the collector is too slow to keep up.
+func (t *telemetryExporter) add(span otlpSpan) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.pending = append(t.pending, span)
+	if len(t.pending) < telemetryMaxBatch {
+		return
+	}
+	select {
+	case t.batchCh <- t.pending:
+	default:
+		t.dropped += len(t.pending)
+	}
+	t.pending = nil
+}
+
+func (t *telemetryExporter) run() {
+	defer close(t.doneCh)
+	ticker := time.NewTicker(telemetryFlushInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case spans := <-t.batchCh:
+			t.send(spans)
+		case <-ticker.C:
+			t.flush()
+		case <-t.stopCh:
+			// Only run() receives from batchCh.
+			for len(t.batchCh) > 0 {
+				t.send(<-t.batchCh)
+			}
+			t.flush()
+			return
+		}
+	}
+}
+
+// flush exports the spans of the incomplete batch.
+func (t *telemetryExporter) flush() {
+	t.mu.Lock()
+	spans := t.pending
+	t.pending = nil
+	dropped := t.dropped
+	t.dropped = 0
+	t.mu.Unlock()
+	if dropped > 0 {
+		errorIf(probe.NewError(fmt.Errorf("%d span(s) dropped", dropped)).Trace(t.endpoint), "OpenTelemetry collector too slow to receive all spans.")
+	}
+	t.send(spans)
+}
+
+// send exports spans to the collector.
+func (t *telemetryExporter) send(spans []otlpSpan) {
+	if len(spans) == 0 {
+		return
+	}
+
+	req := otlpTracesRequest{ResourceSpans: []otlpResourceSpans{{
+		Resource: otlpResource{
+			Attributes: otlpAttributes(map[string]string{"service.name": "mc", "service.version": ReleaseTag}),
+		},
+		ScopeSpans: []otlpScopeSpans{{
+			Scope: otlpScope{Name: "github.com/minio/mc", Version: ReleaseTag},
+			Spans: spans,
+		}},
+	}}}
+
+	body, e := json.Marshal(req)
+	if e != nil {
+		return
+	}
+	resp, e := t.client.Post(t.endpoint, "application/json", bytes.NewReader(body))
+	if e != nil {
+		errorIf(probe.NewError(e).Trace(t.endpoint), "Unable to export spans.")
+		return
+	}
+	resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		errorIf(probe.NewError(fmt.Errorf("collector responded with %s", resp.Status)).Trace(t.endpoint), "Unable to export spans.")
+	}
+}
+
+// telemetryTransport propagates the W3C trace context of the current span to the server.
+type telemetryTransport struct {
+	transport http.RoundTripper
+}
+
+func (t telemetryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if s, ok := req.Context().Value(telemetrySpanKey{}).(*telemetrySpan); ok && s != nil {
+		req = req.Clone(req.Context())
+		req.Header.Set("traceparent", s.traceParent())
+	}
+	return t.transport.RoundTrip(req)
+}
@@ -0,0 +1,204 @@
+// Copyright (c) 2015-2024 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/minio/mc/pkg/probe"
+)
+
+func TestParseTraceParent(t *testing.T) {
+	testCases := []struct {
+		value    string
+		traceID  string
+		parentID string
+		ok       bool
+	}{
+		{"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01", "4bf92f3577b34da6a3ce929d0e0e4736", "00f067aa0ba902b7", true},
+		{" 00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-00 ", "4bf92f3577b34da6a3ce929d0e0e4736", "00f067aa0ba902b7", true},
+		{"00-00000000000000000000000000000000-00f067aa0ba902b7-01", "", "", false},
+		{"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7", "", "", false},
+		{"00-zzf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01", "", "", false},
+		{"", "", "", false},
+	}
+	for i, testCase := range testCases {
+		traceID, parentID, ok := parseTraceParent(testCase.value)
+		if ok != testCase.ok {
+			t.Fatalf("Test %d: expected %v, got %v", i+1, testCase.ok, ok)
+		}
+		if !ok {
+			continue
+		}
+		if hex.EncodeToString(traceID[:]) != testCase.traceID || hex.EncodeToString(parentID[:]) != testCase.parentID {
+			t.Fatalf("Test %d: unexpected trace %x and parent %x", i+1, traceID, parentID)
+		}
+	}
+}
+
+// newTestTelemetry enables tracing to a collector served by handler.
+func newTestTelemetry(t *testing.T, handler http.HandlerFunc) *telemetryExporter {
+	srv := httptest.NewServer(handler)
+	tel := &telemetryExporter{
+		endpoint: srv.URL + "/v1/traces",
+		client:   srv.Client(),
+		batchCh:  make(chan []otlpSpan, telemetryMaxQueuedBatches),
+		stopCh:   make(chan struct{}),
+		doneCh:   make(chan struct{}),
+	}
+	tel.root = newTelemetrySpan("mc test", otlpSpanKindInternal, nil)
+	globalTelemetry = tel
+	go tel.run()
+	t.Cleanup(func() {
+		globalTelemetry = nil
+		srv.Close()
+	})
+	return tel
+}
+
+func TestTelemetrySpansExported(t *testing.T) {
+	var mu sync.Mutex
+	spans := make(map[string]otlpSpan)
+	tel := newTestTelemetry(t, func(w http.ResponseWriter, r *http.Request) {
+		var req otlpTracesRequest
+		if e := json.NewDecoder(r.Body).Decode(&req); e != nil {
+			t.Error(e)
+		}
+		mu.Lock()
+		for _, rs := range req.ResourceSpans {
+			for _, ss := range rs.ScopeSpans {
+				for _, s := range ss.Spans {
+					spans[s.Name] = s
+				}
+			}
+		}
+		mu.Unlock()
+	})
+
+	ctx := context.WithValue(context.Background(), telemetrySpanKey{}, tel.root)
+	_, ok := startSpan(ctx, "ok", map[string]string{"bucket": "photos"})
+	ok.End(nil)
+	_, failed := startSpan(ctx, "failed", nil)
+	failed.End(errInvalidArgument())
+	// Ending twice exports the span once.
+	failed.End(nil)
+	shutdownTelemetry()
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(spans) != 3 {
+		t.Fatalf("expected 3 spans, got %d", len(spans))
+	}
+	rootID := hex.EncodeToString(tel.root.spanID[:])
+	if spans["ok"].ParentSpanID != rootID || spans["ok"].Status.Code != otlpStatusOK {
+		t.Errorf("unexpected span %+v", spans["ok"])
+	}
+	if spans["failed"].Status.Code != otlpStatusError {
+		t.Errorf("expected a failed span, got %+v", spans["failed"])
+	}
+}
+
+func TestTelemetrySlowCollector(t *testing.T) {
+	unblock := make(chan struct{})
+	tel := newTestTelemetry(t, func(http.ResponseWriter, *http.Request) {
+		<-unblock
+	})
+
+	// Ending spans must not wait for the collector, even when many more
+	// batches than can be queued are ended.
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < (telemetryMaxQueuedBatches+4)*telemetryMaxBatch; i++ {
+			_, s := startSpan(context.Background(), "put", nil)
+			s.End(nil)
+		}
+	}()
+	select {
+	case <-done:
+	case <-time.After(10 * time.Second):
+		t.Fatal("ending spans blocked on a slow collector")
+	}
+	tel.mu.Lock()
+	dropped := tel.dropped
+	tel.mu.Unlock()
+	if dropped == 0 {
+		t.Fatal("expected spans to be dropped")
+	}
+	close(unblock)
+	shutdownTelemetry()
+}
+
+func TestEndSpanOnClose(t *testing.T) {
+	var mu sync.Mutex
+	spans := make(map[string]otlpSpan)
+	newTestTelemetry(t, func(w http.ResponseWriter, r *http.Request) {
+		var req otlpTracesRequest
+		if e := json.NewDecoder(r.Body).Decode(&req); e != nil {
+			t.Error(e)
+		}
+		mu.Lock()
+		for _, rs := range req.ResourceSpans {
+			for _, ss := range rs.ScopeSpans {
+				for _, s := range ss.Spans {
+					spans[s.Name] = s
+				}
+			}
+		}
+		mu.Unlock()
+	})
+
+	errOf := func(result RemoveResult) *probe.Error { return result.Err }
+	for _, name := range []string{"ok", "failed"} {
+		_, span := startSpan(context.Background(), name, nil)
+		resultCh := make(chan RemoveResult)
+		go func() {
+			defer close(resultCh)
+			resultCh <- RemoveResult{BucketName: "photos"}
+			if name == "failed" {
+				resultCh <- RemoveResult{Err: probe.NewError(errors.New("first error"))}
+				resultCh <- RemoveResult{Err: probe.NewError(errors.New("second error"))}
+			}
+		}()
+		var n int
+		for range endSpanOnClose(span, nil, resultCh, errOf) {
+			n++
+		}
+		if name == "failed" && n != 3 || name == "ok" && n != 1 {
+			t.Fatalf("%s: unexpected number of results %d", name, n)
+		}
+	}
+	shutdownTelemetry()
+
+	mu.Lock()
+	defer mu.Unlock()
+	if spans["ok"].Status.Code != otlpStatusOK {
+		t.Errorf("unexpected span %+v", spans["ok"])
+	}
+	if spans["failed"].Status.Code != otlpStatusError || spans["failed"].Status.Message != "first error" {
+		t.Errorf("expected a span failed with the first error, got %+v", spans["failed"])
+	}
+}
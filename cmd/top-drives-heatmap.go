@@ -0,0 +1,201 @@
+// Copyright (c) 2015-2024 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/minio/madmin-go/v3"
+)
+
+const (
+	// Number of await samples kept per drive to compute latency percentiles.
+	driveLatencyWindow = 60
+	// A drive is an outlier when its p95 latency exceeds its peers median p95 by this factor.
+	driveOutlierFactor = 2.0
+	// Drives with a p95 latency below this value (in ms) are never reported as outliers.
+	driveOutlierMinAwait = 5.0
+)
+
+var (
+	heatCold    = lipgloss.NewStyle().Foreground(lipgloss.Color("#5f87ff"))
+	heatWarm    = lipgloss.NewStyle().Foreground(lipgloss.Color("#00d700"))
+	heatHot     = lipgloss.NewStyle().Foreground(lipgloss.Color("#ffaf00"))
+	heatBurning = lipgloss.NewStyle().Foreground(lipgloss.Color("#ff0000"))
+	heatOutlier = lipgloss.NewStyle().Foreground(lipgloss.Color("#ff00ff")).Bold(true)
+)
+
+// driveLatency is the p95 latency of a drive over the sampled window.
+type driveLatency struct {
+	endpoint string
+	p95      float64
+}
+
+// driveOutlier is a drive whose latency stands out from its erasure set peers.
+type driveOutlier struct {
+	endpoint  string
+	pool, set int
+	p95       float64
+	peersP95  float64
+}
+
+// percentile returns the p-th percentile (0-100) of samples using the nearest rank method.
+func percentile(samples []float64, p float64) float64 {
+	if len(samples) == 0 {
+		return 0
+	}
+	sorted := make([]float64, len(samples))
+	copy(sorted, samples)
+	sort.Float64s(sorted)
+	rank := int(math.Ceil(p/100*float64(len(sorted)))) - 1
+	if rank < 0 {
+		rank = 0
+	}
+	return sorted[rank]
+}
+
+// findSetOutliers returns the drives of a single erasure set whose p95 latency
+// is well above the median p95 latency of the other drives in the same set.
+func findSetOutliers(drives []driveLatency) (outliers []driveLatency, peers []float64) {
+	for i, d := range drives {
+		others := make([]float64, 0, len(drives)-1)
+		for j, o := range drives {
+			if i != j {
+				others = append(others, o.p95)
+			}
+		}
+		peer := percentile(others, 50)
+		peers = append(peers, peer)
+		if len(others) == 0 || d.p95 < driveOutlierMinAwait {
+			continue
+		}
+		if d.p95 > peer*driveOutlierFactor {
+			outliers = append(outliers, d)
+		}
+	}
+	return outliers, peers
+}
+
+// recordAwait appends the latest await sample of a drive to its history.
+func (m *topDriveUI) recordAwait(diskName string) {
+	disk, ok := m.drivesInfo[diskName]
+	if !ok {
+		return
+	}
+	d := generateDriveStat(disk, m.currTopMap[diskName], m.prevTopMap[diskName], 1000)
+	h := append(m.awaitHistory[diskName], d.await)
+	if len(h) > driveLatencyWindow {
+		h = h[len(h)-driveLatencyWindow:]
+	}
+	m.awaitHistory[diskName] = h
+}
+
+// heatCell renders a single drive cell colored by its utilization.
+func heatCell(util float64, outlier bool) string {
+	const cell = "■"
+	switch {
+	case outlier:
+		return heatOutlier.Render("▲")
+	case util >= 90:
+		return heatBurning.Render(cell)
+	case util >= 60:
+		return heatHot.Render(cell)
+	case util >= 20:
+		return heatWarm.Render(cell)
+	}
+	return heatCold.Render(cell)
+}
+
+// heatMapView renders the drives of the current pool grouped by erasure set,
+// each drive is a cell colored by utilization and outliers are highlighted.
+func (m *topDriveUI) heatMapView() string {
+	sets := make(map[int][]madmin.Disk)
+	for endpoint, disk := range m.drivesInfo {
+		if disk.PoolIndex != m.pool {
+			continue
+		}
+		if _, ok := m.currTopMap[endpoint]; !ok {
+			continue
+		}
+		sets[disk.SetIndex] = append(sets[disk.SetIndex], disk)
+	}
+
+	setIdxs := make([]int, 0, len(sets))
+	for idx := range sets {
+		setIdxs = append(setIdxs, idx)
+	}
+	sort.Ints(setIdxs)
+
+	var s strings.Builder
+	var outliers []driveOutlier
+	for _, idx := range setIdxs {
+		disks := sets[idx]
+		sort.Slice(disks, func(i, j int) bool { return disks[i].DiskIndex < disks[j].DiskIndex })
+
+		latencies := make([]driveLatency, 0, len(disks))
+		for _, disk := range disks {
+			latencies = append(latencies, driveLatency{
+				endpoint: disk.Endpoint,
+				p95:      percentile(m.awaitHistory[disk.Endpoint], 95),
+			})
+		}
+		setOutliers, peers := findSetOutliers(latencies)
+		isOutlier := make(map[string]bool, len(setOutliers))
+		for _, o := range setOutliers {
+			isOutlier[o.endpoint] = true
+		}
+		for i, l := range latencies {
+			if isOutlier[l.endpoint] {
+				outliers = append(outliers, driveOutlier{
+					endpoint: l.endpoint,
+					pool:     m.pool,
+					set:      idx,
+					p95:      l.p95,
+					peersP95: peers[i],
+				})
+			}
+		}
+
+		var totalUtil float64
+		cells := make([]string, 0, len(disks))
+		for _, disk := range disks {
+			d := generateDriveStat(disk, m.currTopMap[disk.Endpoint], m.prevTopMap[disk.Endpoint], 1000)
+			totalUtil += d.util
+			cells = append(cells, heatCell(d.util, isOutlier[disk.Endpoint]))
+		}
+		s.WriteString(fmt.Sprintf("  Set %-4d %s  %s\n", idx+1, strings.Join(cells, " "),
+			whiteStyle.Render(fmt.Sprintf("avg util %.1f%%", totalUtil/float64(len(disks))))))
+	}
+
+	s.WriteString("\n  " + heatCold.Render("■") + " <20%  " + heatWarm.Render("■") + " <60%  " +
+		heatHot.Render("■") + " <90%  " + heatBurning.Render("■") + " >=90% util  " +
+		heatOutlier.Render("▲") + " p95 latency outlier\n")
+
+	if len(outliers) > 0 {
+		s.WriteString("\n")
+		for _, o := range outliers {
+			s.WriteString(heatOutlier.Render(fmt.Sprintf("  %s (pool %d, set %d): p95 await %.1f ms vs %.1f ms for peers\n",
+				o.endpoint, o.pool+1, o.set+1, o.p95, o.peersP95)))
+		}
+	}
+	return s.String()
+}
@@ -0,0 +1,62 @@
+// Copyright (c) 2015-2024 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"testing"
+)
+
+func TestFindSetOutliers(t *testing.T) {
+	testCases := []struct {
+		drives   []driveLatency
+		outliers []string
+	}{
+		{nil, nil},
+		{[]driveLatency{{"d1", 50}}, nil},
+		{[]driveLatency{{"d1", 2}, {"d2", 2}, {"d3", 2}, {"d4", 2}}, nil},
+		// Slow but below the minimum latency worth reporting.
+		{[]driveLatency{{"d1", 0.5}, {"d2", 0.5}, {"d3", 4}, {"d4", 0.5}}, nil},
+		{[]driveLatency{{"d1", 3}, {"d2", 4}, {"d3", 40}, {"d4", 3}}, []string{"d3"}},
+		{[]driveLatency{{"d1", 10}, {"d2", 12}, {"d3", 15}, {"d4", 11}}, nil},
+	}
+
+	for i, testCase := range testCases {
+		outliers, _ := findSetOutliers(testCase.drives)
+		if len(outliers) != len(testCase.outliers) {
+			t.Fatalf("Test %d: expected %v outliers, got %v", i+1, testCase.outliers, outliers)
+		}
+		for j := range outliers {
+			if outliers[j].endpoint != testCase.outliers[j] {
+				t.Fatalf("Test %d: expected %v outliers, got %v", i+1, testCase.outliers, outliers)
+			}
+		}
+	}
+}
+
+func TestPercentile(t *testing.T) {
+	samples := []float64{5, 1, 4, 2, 3, 10, 6, 7, 9, 8}
+	if p := percentile(samples, 95); p != 10 {
+		t.Fatalf("expected p95 10, got %v", p)
+	}
+	if p := percentile(samples, 50); p != 5 {
+		t.Fatalf("expected p50 5, got %v", p)
+	}
+	if p := percentile(nil, 95); p != 0 {
+		t.Fatalf("expected 0 for no samples, got %v", p)
+	}
+}
@@ -38,9 +38,12 @@ type topDriveUI struct {
 	sortAsc       bool
 	count         int
 	pool, maxPool int
+	heatMap       bool
 
 	drivesInfo map[string]madmin.Disk
 
+	awaitHistory map[string][]float64
+
 	prevTopMap map[string]madmin.DiskIOStats
 	currTopMap map[string]madmin.DiskIOStats
 }
@@ -51,7 +54,7 @@ type topDriveResult struct {
 	stats    madmin.DiskIOStats
 }
 
-func initTopDriveUI(disks []madmin.Disk, count int) *topDriveUI {
+func initTopDriveUI(disks []madmin.Disk, count int, heatMap bool) *topDriveUI {
 	maxPool := 0
 	drivesInfo := make(map[string]madmin.Disk)
 	for i := range disks {
@@ -65,14 +68,16 @@ func initTopDriveUI(disks []madmin.Disk, count int) *topDriveUI {
 	s.Spinner = spinner.Points
 	s.Style = lipgloss.NewStyle().Foreground(lipgloss.Color("205"))
 	return &topDriveUI{
-		count:      count,
-		sortBy:     sortByName,
-		pool:       0,
-		maxPool:    maxPool,
-		drivesInfo: drivesInfo,
-		spinner:    s,
-		prevTopMap: make(map[string]madmin.DiskIOStats),
-		currTopMap: make(map[string]madmin.DiskIOStats),
+		count:        count,
+		sortBy:       sortByName,
+		pool:         0,
+		maxPool:      maxPool,
+		heatMap:      heatMap,
+		drivesInfo:   drivesInfo,
+		spinner:      s,
+		awaitHistory: make(map[string][]float64),
+		prevTopMap:   make(map[string]madmin.DiskIOStats),
+		currTopMap:   make(map[string]madmin.DiskIOStats),
 	}
 }
 
@@ -113,12 +118,15 @@ func (m *topDriveUI) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.sortBy = sortByUtil
 		case "o", "O":
 			m.sortAsc = !m.sortAsc
+		case "h":
+			m.heatMap = !m.heatMap
 		}
 
 		return m, nil
 	case topDriveResult:
 		m.prevTopMap[msg.diskName] = m.currTopMap[msg.diskName]
 		m.currTopMap[msg.diskName] = msg.stats
+		m.recordAwait(msg.diskName)
 		if msg.final {
 			m.quitting = true
 			return m, tea.Quit
@@ -235,6 +243,14 @@ func (m *topDriveUI) View() string {
 	var s strings.Builder
 	s.WriteString("\n")
 
+	if m.heatMap {
+		s.WriteString(m.heatMapView())
+		if !m.quitting {
+			s.WriteString(fmt.Sprintf("\n%s \u25C0 Pool %d \u25B6 | (h) table view ", m.spinner.View(), m.pool+1))
+		}
+		return s.String() + "\n"
+	}
+
 	// Set table header
 	table := tablewriter.NewWriter(&s)
 	table.SetAutoWrapText(false)
@@ -302,7 +318,7 @@ func (m *topDriveUI) View() string {
 			order = "ASC"
 		}
 
-		s.WriteString(fmt.Sprintf("\n%s \u25C0 Pool %d \u25B6 | Sort By: %s (u,t,r,w,d,a,U) | (O)rder: %s ", m.spinner.View(), m.pool+1, m.sortBy, order))
+		s.WriteString(fmt.Sprintf("\n%s \u25C0 Pool %d \u25B6 | Sort By: %s (u,t,r,w,d,a,U) | (O)rder: %s | (h) heat map ", m.spinner.View(), m.pool+1, m.sortBy, order))
 	}
 	return s.String() + "\n"
 }
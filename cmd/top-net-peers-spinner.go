@@ -0,0 +1,261 @@
+// Copyright (c) 2015-2024 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/spinner"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/dustin/go-humanize"
+	json "github.com/minio/colorjson"
+	"github.com/minio/madmin-go/v3"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/olekukonko/tablewriter"
+)
+
+// topNetPeerStat is the internode traffic from a server to one of its peers.
+type topNetPeerStat struct {
+	Source       string  `json:"source"`
+	Target       string  `json:"target"`
+	TxRate       uint64  `json:"txBytesPerSec"`
+	RxRate       uint64  `json:"rxBytesPerSec"`
+	Connected    int     `json:"connected"`
+	Disconnected int     `json:"disconnected"`
+	Reconnects   int     `json:"reconnects"`
+	LastPingMS   float64 `json:"lastPingMS"`
+}
+
+// topNetPeersMessage is a sample of the internode traffic of all servers.
+type topNetPeersMessage struct {
+	Status      string           `json:"status"`
+	CollectedAt time.Time        `json:"collected"`
+	Peers       []topNetPeerStat `json:"peers"`
+}
+
+func (t topNetPeersMessage) JSON() string {
+	t.Status = "success"
+	jsonMessageBytes, e := json.MarshalIndent(t, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+	return string(jsonMessageBytes)
+}
+
+func (t topNetPeersMessage) String() string {
+	return t.JSON()
+}
+
+// trimScheme returns the host of an RPC destination.
+func trimScheme(host string) string {
+	host = strings.TrimPrefix(host, "http://")
+	return strings.TrimPrefix(host, "https://")
+}
+
+// topNetPeerRates returns the internode traffic between two samples of the
+// RPC metrics of each server, the rates are zero for the first sample.
+func topNetPeerRates(prev, curr map[string]madmin.Metrics) []topNetPeerStat {
+	var stats []topNetPeerStat
+	for source, metrics := range curr {
+		if metrics.RPC == nil {
+			continue
+		}
+		var prevDest map[string]madmin.RPCMetrics
+		if p, ok := prev[source]; ok && p.RPC != nil {
+			prevDest = make(map[string]madmin.RPCMetrics, len(p.RPC.ByDestination))
+			for target, v := range p.RPC.ByDestination {
+				prevDest[trimScheme(target)] = v
+			}
+		}
+		for target, v := range metrics.RPC.ByDestination {
+			target = trimScheme(target)
+			stat := topNetPeerStat{
+				Source:       trimScheme(source),
+				Target:       target,
+				Connected:    v.Connected,
+				Disconnected: v.Disconnected,
+				LastPingMS:   v.LastPingMS,
+			}
+			if p, ok := prevDest[target]; ok {
+				if dur := v.CollectedAt.Sub(p.CollectedAt); dur > 0 {
+					stat.TxRate = counterRate(p.OutgoingBytes, v.OutgoingBytes, dur)
+					stat.RxRate = counterRate(p.IncomingBytes, v.IncomingBytes, dur)
+				}
+				if v.ReconnectCount > p.ReconnectCount {
+					stat.Reconnects = v.ReconnectCount - p.ReconnectCount
+				}
+			}
+			stats = append(stats, stat)
+		}
+	}
+	sort.Slice(stats, func(i, j int) bool {
+		if stats[i].Source != stats[j].Source {
+			return stats[i].Source < stats[j].Source
+		}
+		return stats[i].Target < stats[j].Target
+	})
+	return stats
+}
+
+// counterRate returns the rate per second of a counter, a counter reset
+// by a server restart counts from zero.
+func counterRate(prev, curr int64, dur time.Duration) uint64 {
+	if curr < prev {
+		prev = 0
+	}
+	return uint64(float64(curr-prev) / dur.Seconds())
+}
+
+type topNetPeersUI struct {
+	spinner  spinner.Model
+	quitting bool
+	showList bool
+
+	prev, curr map[string]madmin.Metrics
+	peers      []topNetPeerStat
+}
+
+func (m *topNetPeersUI) Init() tea.Cmd {
+	return m.spinner.Tick
+}
+
+func (m *topNetPeersUI) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "ctrl+c", "q", "esc":
+			m.quitting = true
+			return m, tea.Quit
+		case "tab":
+			m.showList = !m.showList
+		}
+		return m, nil
+	case madmin.RealtimeMetrics:
+		if len(msg.ByHost) > 0 {
+			m.prev, m.curr = m.curr, msg.ByHost
+			m.peers = topNetPeerRates(m.prev, m.curr)
+		}
+		if msg.Final {
+			m.quitting = true
+			return m, tea.Quit
+		}
+		return m, nil
+	case spinner.TickMsg:
+		var cmd tea.Cmd
+		m.spinner, cmd = m.spinner.Update(msg)
+		return m, cmd
+	default:
+		return m, nil
+	}
+}
+
+func (m *topNetPeersUI) View() string {
+	var s strings.Builder
+	table := tablewriter.NewWriter(&s)
+	table.SetAutoWrapText(false)
+	table.SetAutoFormatHeaders(false)
+	table.SetHeaderAlignment(tablewriter.ALIGN_CENTER)
+	table.SetAlignment(tablewriter.ALIGN_CENTER)
+	table.SetCenterSeparator("")
+	table.SetColumnSeparator("")
+	table.SetRowSeparator("")
+	table.SetHeaderLine(false)
+	table.SetBorder(false)
+	table.SetTablePadding("\t") // pad with tabs
+	table.SetNoWhiteSpace(true)
+
+	if len(m.peers) == 0 {
+		s.WriteString(m.spinner.View() + " waiting for internode metrics...\n")
+		return s.String()
+	}
+
+	hosts := make([]string, 0)
+	seen := make(map[string]bool)
+	for _, p := range m.peers {
+		for _, h := range []string{p.Source, p.Target} {
+			if !seen[h] {
+				seen[h] = true
+				hosts = append(hosts, h)
+			}
+		}
+	}
+	sort.Strings(hosts)
+
+	if m.showList {
+		table.SetHeader([]string{"SOURCE", "TARGET", "TRANSMIT", "RECEIVE", "PING", "RECONNS", "DISCONN"})
+		for _, p := range m.peers {
+			table.Append([]string{
+				p.Source,
+				p.Target,
+				whiteStyle.Render(fmt.Sprintf("%s/s", humanize.IBytes(p.TxRate))),
+				whiteStyle.Render(fmt.Sprintf("%s/s", humanize.IBytes(p.RxRate))),
+				fmt.Sprintf("%0.1fms", p.LastPingMS),
+				topNetPeerErrors(p.Reconnects),
+				topNetPeerErrors(p.Disconnected),
+			})
+		}
+	} else {
+		// Traffic matrix, each cell is the traffic sent by the server
+		// of the row to the server of the column.
+		index := make(map[string]int, len(hosts))
+		header := []string{"FROM \\ TO"}
+		for i, h := range hosts {
+			index[h] = i
+			header = append(header, fmt.Sprintf("[%d]", i+1))
+		}
+		table.SetHeader(header)
+		rows := make([][]string, len(hosts))
+		for i, h := range hosts {
+			rows[i] = make([]string, len(hosts)+1)
+			rows[i][0] = fmt.Sprintf("[%d] %s", i+1, h)
+			for j := range hosts {
+				rows[i][j+1] = "-"
+			}
+		}
+		for _, p := range m.peers {
+			cell := fmt.Sprintf("%s/s", humanize.IBytes(p.TxRate))
+			if p.Reconnects > 0 || p.Disconnected > 0 {
+				cell += " " + topNetPeerErrors(p.Reconnects+p.Disconnected)
+			}
+			rows[index[p.Source]][index[p.Target]+1] = whiteStyle.Render(cell)
+		}
+		table.AppendBulk(rows)
+	}
+	table.Render()
+	s.WriteString("\n<tab>=MATRIX/LIST, !N counts the reconnects and disconnected connections.")
+	return s.String()
+}
+
+// topNetPeerErrors renders a count of internode errors.
+func topNetPeerErrors(n int) string {
+	if n == 0 {
+		return "0"
+	}
+	return lipgloss.NewStyle().Foreground(lipgloss.Color("#FF0000")).Render(fmt.Sprintf("!%d", n))
+}
+
+func initTopNetPeersUI() *topNetPeersUI {
+	s := spinner.New()
+	s.Spinner = spinner.Points
+	s.Style = lipgloss.NewStyle().Foreground(lipgloss.Color("205"))
+	return &topNetPeersUI{
+		spinner: s,
+	}
+}
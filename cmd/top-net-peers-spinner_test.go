@@ -0,0 +1,65 @@
+// Copyright (c) 2015-2024 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/minio/madmin-go/v3"
+)
+
+func TestTopNetPeerRates(t *testing.T) {
+	t0 := time.Now()
+	sample := func(at time.Time, out, in int64, reconnects int) map[string]madmin.Metrics {
+		return map[string]madmin.Metrics{
+			"node1:9000": {RPC: &madmin.RPCMetrics{ByDestination: map[string]madmin.RPCMetrics{
+				"http://node2:9000": {CollectedAt: at, OutgoingBytes: out, IncomingBytes: in, ReconnectCount: reconnects, Connected: 1},
+			}}},
+			"node2:9000": {RPC: &madmin.RPCMetrics{ByDestination: map[string]madmin.RPCMetrics{
+				"http://node1:9000": {CollectedAt: at, OutgoingBytes: in, IncomingBytes: out, Connected: 1},
+			}}},
+		}
+	}
+
+	// No rates without a previous sample.
+	got := topNetPeerRates(nil, sample(t0, 1000, 500, 0))
+	want := []topNetPeerStat{
+		{Source: "node1:9000", Target: "node2:9000", Connected: 1},
+		{Source: "node2:9000", Target: "node1:9000", Connected: 1},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+
+	got = topNetPeerRates(sample(t0, 1000, 500, 0), sample(t0.Add(2*time.Second), 5000, 700, 3))
+	want = []topNetPeerStat{
+		{Source: "node1:9000", Target: "node2:9000", TxRate: 2000, RxRate: 100, Connected: 1, Reconnects: 3},
+		{Source: "node2:9000", Target: "node1:9000", TxRate: 100, RxRate: 2000, Connected: 1},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+
+	// Counters reset by a restart count from zero.
+	got = topNetPeerRates(sample(t0, 1000, 500, 0), sample(t0.Add(time.Second), 300, 500, 0))
+	if got[0].TxRate != 300 || got[0].RxRate != 0 {
+		t.Fatalf("unexpected rates after a restart %v", got[0])
+	}
+}
@@ -0,0 +1,185 @@
+// Copyright (c) 2015-2024 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"aead.dev/minisign"
+	"github.com/minio/cli"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/pkg/v3/env"
+	"golang.org/x/term"
+)
+
+// Environment variable holding the password of an encrypted manifest
+// signing key.
+const envManifestSignKeyPassword = "MC_MANIFEST_SIGN_KEY_PASSWORD"
+
+// Extension of the signature of a signed manifest.
+const manifestSignatureExt = ".minisig"
+
+// Flags of the commands writing a transfer manifest.
+var transferManifestFlags = []cli.Flag{
+	cli.StringFlag{
+		Name:  "manifest",
+		Usage: "write the SHA256 of every transferred object to a local manifest file",
+	},
+	cli.StringFlag{
+		Name:  "manifest-sign-key",
+		Usage: "sign the manifest with a minisign private key, the signature is written to MANIFEST.minisig",
+	},
+}
+
+// checkTransferManifestSyntax validates the manifest flags.
+func checkTransferManifestSyntax(cliCtx *cli.Context) {
+	if cliCtx.String("manifest-sign-key") != "" && cliCtx.String("manifest") == "" {
+		fatalIf(errInvalidArgument().Trace(cliCtx.String("manifest-sign-key")), "--manifest-sign-key requires --manifest.")
+	}
+}
+
+// transferManifest collects the SHA256 of the objects transferred to
+// a target, it is written in the 'sha256sum' format read by `mc verify`
+// and `mc verify-manifest` once the transfer completes.
+type transferManifest struct {
+	path string
+	// Target of the transfer, the paths of the manifest are relative to it.
+	target  string
+	signKey *minisign.PrivateKey
+
+	mu sync.Mutex
+	// SHA256 of the transferred objects by path, an object transferred
+	// again is only listed once.
+	sums map[string]string
+}
+
+// newTransferManifest returns a manifest of the objects transferred to
+// target, signed with the minisign private key at signKeyPath if not empty.
+func newTransferManifest(manifestPath, signKeyPath, target string) (*transferManifest, *probe.Error) {
+	m := &transferManifest{
+		path:   manifestPath,
+		target: strings.TrimSuffix(filepath.ToSlash(target), "/"),
+		sums:   make(map[string]string),
+	}
+	if signKeyPath != "" {
+		key, err := loadManifestSignKey(signKeyPath)
+		if err != nil {
+			return nil, err.Trace(signKeyPath)
+		}
+		m.signKey = &key
+	}
+	// Fail before the transfer starts if the manifest cannot be written.
+	f, e := os.OpenFile(manifestPath, os.O_CREATE|os.O_WRONLY, 0o644)
+	if e != nil {
+		return nil, probe.NewError(e).Trace(manifestPath)
+	}
+	f.Close()
+	return m, nil
+}
+
+// loadManifestSignKey reads a minisign private key, an encrypted key is
+// decrypted with the password of MC_MANIFEST_SIGN_KEY_PASSWORD, or with
+// a password read from the terminal.
+func loadManifestSignKey(keyPath string) (minisign.PrivateKey, *probe.Error) {
+	var key minisign.PrivateKey
+	b, e := os.ReadFile(keyPath)
+	if e != nil {
+		return key, probe.NewError(e)
+	}
+	if !minisign.IsEncrypted(b) {
+		if e = key.UnmarshalText(bytes.TrimSpace(b)); e != nil {
+			return key, probe.NewError(e)
+		}
+		return key, nil
+	}
+	password := env.Get(envManifestSignKeyPassword, "")
+	if password == "" && term.IsTerminal(int(os.Stdin.Fd())) {
+		fmt.Printf("Enter the password of the manifest signing key: ")
+		bytePassword, _ := term.ReadPassword(int(os.Stdin.Fd()))
+		fmt.Printf("\n")
+		password = string(bytePassword)
+	}
+	if key, e = minisign.DecryptKey(password, b); e != nil {
+		return key, probe.NewError(e)
+	}
+	return key, nil
+}
+
+// key returns the path of targetPath relative to the target of the
+// transfer, or its base name when the target is the object itself.
+func (m *transferManifest) key(targetPath string) string {
+	if rel, ok := strings.CutPrefix(targetPath, m.target+"/"); ok && rel != "" {
+		return rel
+	}
+	return path.Base(targetPath)
+}
+
+// add reads back the target of a completed transfer and records its SHA256.
+func (m *transferManifest) add(ctx context.Context, urls URLs, encKeyDB map[string][]prefixSSEPair) *probe.Error {
+	targetPath := filepath.ToSlash(filepath.Join(urls.TargetAlias, urls.TargetContent.URL.Path))
+	targetClnt, err := newClientFromAlias(urls.TargetAlias, urls.TargetContent.URL.String())
+	if err != nil {
+		return err.Trace(targetPath)
+	}
+	sum, err := readBackChecksum(ctx, targetClnt, "", getSSE(targetPath, encKeyDB[urls.TargetAlias]))
+	if err != nil {
+		return err.Trace(targetPath)
+	}
+	m.mu.Lock()
+	m.sums[m.key(targetPath)] = sum
+	m.mu.Unlock()
+	return nil
+}
+
+// close writes the manifest sorted by path, followed by its signature.
+func (m *transferManifest) close() *probe.Error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	keys := make([]string, 0, len(m.sums))
+	for key := range m.sums {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	var b bytes.Buffer
+	for _, key := range keys {
+		fmt.Fprintf(&b, "%s  %s\n", m.sums[key], key)
+	}
+	if e := os.WriteFile(m.path, b.Bytes(), 0o644); e != nil {
+		return probe.NewError(e).Trace(m.path)
+	}
+	if m.signKey == nil {
+		return nil
+	}
+	trustedComment := fmt.Sprintf("timestamp:%d\tfile:%s", time.Now().Unix(), filepath.Base(m.path))
+	untrustedComment := "signature of a transfer manifest written by mc"
+	signature := minisign.SignWithComments(*m.signKey, b.Bytes(), trustedComment, untrustedComment)
+	if e := os.WriteFile(m.path+manifestSignatureExt, signature, 0o644); e != nil {
+		return probe.NewError(e).Trace(m.path + manifestSignatureExt)
+	}
+	return nil
+}
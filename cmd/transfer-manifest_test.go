@@ -0,0 +1,107 @@
+// Copyright (c) 2015-2024 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bytes"
+	"crypto/rand"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"aead.dev/minisign"
+)
+
+func TestTransferManifestKey(t *testing.T) {
+	m := &transferManifest{target: "myminio/backups/2024"}
+	testCases := []struct {
+		targetPath string
+		want       string
+	}{
+		{"myminio/backups/2024/a.txt", "a.txt"},
+		{"myminio/backups/2024/sub/b.txt", "sub/b.txt"},
+		// The target is the object itself.
+		{"myminio/backups/2024", "2024"},
+		{"myminio/backups/2024-old/c.txt", "c.txt"},
+	}
+	for _, tc := range testCases {
+		if got := m.key(tc.targetPath); got != tc.want {
+			t.Errorf("%s: expected %q, got %q", tc.targetPath, tc.want, got)
+		}
+	}
+}
+
+func TestTransferManifestSignature(t *testing.T) {
+	dir := t.TempDir()
+	pub, priv, e := minisign.GenerateKey(rand.Reader)
+	if e != nil {
+		t.Fatal(e)
+	}
+	// Encrypted keys are slow to decrypt, the key is stored in plain text.
+	privText, e := priv.MarshalText()
+	if e != nil {
+		t.Fatal(e)
+	}
+	keyPath := filepath.Join(dir, "minisign.key")
+	if e = os.WriteFile(keyPath, privText, 0o600); e != nil {
+		t.Fatal(e)
+	}
+
+	manifestPath := filepath.Join(dir, "manifest.sha256")
+	m, err := newTransferManifest(manifestPath, keyPath, "myminio/backups/")
+	if err != nil {
+		t.Fatal(err)
+	}
+	m.sums["sub/b.txt"] = "0263829989b6fd954f72baaf2fc64bc2e2f01d692d4de72986ea808f6e99813f"
+	m.sums["a.txt"] = "87428fc522803d31065e7bce3cf03fe475096631e5e07bbd7a0fde60c4cf25c7"
+	if err = m.close(); err != nil {
+		t.Fatal(err)
+	}
+
+	manifest, e := os.ReadFile(manifestPath)
+	if e != nil {
+		t.Fatal(e)
+	}
+	entries, e := parseVerifyManifest(bytes.NewReader(manifest))
+	if e != nil {
+		t.Fatal(e)
+	}
+	if len(entries) != 2 || entries[0].Key != "a.txt" || entries[1].Key != "sub/b.txt" {
+		t.Fatalf("unexpected manifest entries %+v", entries)
+	}
+
+	signature, e := os.ReadFile(manifestPath + manifestSignatureExt)
+	if e != nil {
+		t.Fatal(e)
+	}
+	if e = verifyManifestSignature(manifest, signature, pub); e != nil {
+		t.Fatalf("expected a valid signature, got %v", e)
+	}
+	tampered := bytes.Replace(manifest, []byte("0263"), []byte("1263"), 1)
+	if e = verifyManifestSignature(tampered, signature, pub); e != errManifestSignature {
+		t.Fatalf("expected %v for a tampered manifest, got %v", errManifestSignature, e)
+	}
+
+	key, err := loadManifestPublicKey(pub.String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !key.Equal(pub) {
+		t.Fatal("expected the public key parsed from its base64 encoding")
+	}
+}
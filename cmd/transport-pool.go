@@ -0,0 +1,47 @@
+// Copyright (c) 2015-2024 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// Idle connections kept per host when --conns-per-host is not set.
+const defaultMaxIdleConnsPerHost = 1024
+
+// parseConnPoolSize validates the value of a connection pool flag, zero
+// keeps the default of the transport.
+func parseConnPoolSize(flag string, size int) (int, error) {
+	if size < 0 {
+		return 0, fmt.Errorf("invalid value %d for --%s, expected a positive number of connections", size, flag)
+	}
+	return size, nil
+}
+
+// tuneTransportPool applies --max-idle-conns and --conns-per-host to the
+// transport shared by the S3 and admin clients.
+func tuneTransportPool(tr *http.Transport) {
+	tr.MaxIdleConns = globalMaxIdleConns
+	tr.MaxIdleConnsPerHost = defaultMaxIdleConnsPerHost
+	if globalConnsPerHost > 0 {
+		tr.MaxConnsPerHost = globalConnsPerHost
+		// Idle connections above the host limit could never be used.
+		tr.MaxIdleConnsPerHost = min(globalConnsPerHost, defaultMaxIdleConnsPerHost)
+	}
+}
@@ -0,0 +1,51 @@
+// Copyright (c) 2015-2024 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestParseConnPoolSize(t *testing.T) {
+	if _, e := parseConnPoolSize("max-idle-conns", -1); e == nil {
+		t.Fatal("expected an error for a negative pool size")
+	}
+	if size, e := parseConnPoolSize("max-idle-conns", 0); e != nil || size != 0 {
+		t.Fatalf("unexpected result %d, %v", size, e)
+	}
+}
+
+func TestTuneTransportPool(t *testing.T) {
+	defer func() {
+		globalMaxIdleConns, globalConnsPerHost = 0, 0
+	}()
+
+	tr := &http.Transport{}
+	tuneTransportPool(tr)
+	if tr.MaxIdleConns != 0 || tr.MaxConnsPerHost != 0 || tr.MaxIdleConnsPerHost != defaultMaxIdleConnsPerHost {
+		t.Fatalf("unexpected default pool %d/%d/%d", tr.MaxIdleConns, tr.MaxConnsPerHost, tr.MaxIdleConnsPerHost)
+	}
+
+	globalMaxIdleConns, globalConnsPerHost = 256, 64
+	tr = &http.Transport{}
+	tuneTransportPool(tr)
+	if tr.MaxIdleConns != 256 || tr.MaxConnsPerHost != 64 || tr.MaxIdleConnsPerHost != 64 {
+		t.Fatalf("unexpected tuned pool %d/%d/%d", tr.MaxIdleConns, tr.MaxConnsPerHost, tr.MaxIdleConnsPerHost)
+	}
+}
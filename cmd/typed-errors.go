@@ -21,6 +21,7 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/minio/mc/pkg/probe"
 )
@@ -83,6 +84,13 @@ var errInvalidAPISignature = func(api, url string) *probe.Error {
 	return probe.NewError(invalidAPISignatureErr(errors.New(msg)))
 }
 
+type aliasExpiredErr error
+
+var errAliasExpired = func(alias string, expiry time.Time) *probe.Error {
+	msg := "Alias `" + alias + "` expired on " + expiry.Local().Format(printDate) + "."
+	return probe.NewError(aliasExpiredErr(errors.New(msg))).Untrace()
+}
+
 type noMatchingHostErr error
 
 var errNoMatchingHost = func(URL string) *probe.Error {
@@ -125,6 +133,15 @@ var errTargetNotFound = func(URL string) *probe.Error {
 	return probe.NewError(targetNotFoundErr(errors.New(msg))).Untrace()
 }
 
+type checksumMismatchErr struct {
+	error
+}
+
+var errChecksumMismatch = func(source, target string) *probe.Error {
+	msg := "Checksum of `" + target + "` does not match the checksum of `" + source + "`."
+	return probe.NewError(checksumMismatchErr{errors.New(msg)})
+}
+
 type overwriteNotAllowedErr struct {
 	error
 }
@@ -184,6 +201,14 @@ var errSSEKMSKeyFormat = func(msg string) *probe.Error {
 	return probe.NewError(sseKMSKeyFormatErr(errors.New(m))).Untrace()
 }
 
+type sseKMSContextErr error
+
+var errSSEKMSContext = func(msg string) *probe.Error {
+	m := "SSE-KMS encryption context error. "
+	m += msg
+	return probe.NewError(sseKMSContextErr(errors.New(m))).Untrace()
+}
+
 type sseClientKeyFormatErr error
 
 var errSSEClientKeyFormat = func(msg string) *probe.Error {
@@ -191,3 +216,11 @@ var errSSEClientKeyFormat = func(msg string) *probe.Error {
 	m += msg
 	return probe.NewError(sseClientKeyFormatErr(errors.New(m))).Untrace()
 }
+
+type sseClientKeyFileErr error
+
+var errSSEClientKeyFile = func(msg string) *probe.Error {
+	m := "SSE-C key file error. "
+	m += msg
+	return probe.NewError(sseClientKeyFileErr(errors.New(m))).Untrace()
+}
@@ -0,0 +1,67 @@
+// Copyright (c) 2015-2024 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"strings"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// Values of the --unicode-normalize flag of cp and mirror.
+const (
+	unicodeNormalizeNFC  = "nfc"
+	unicodeNormalizeNFD  = "nfd"
+	unicodeNormalizeNone = "none"
+)
+
+// isValidUnicodeNormalize returns true if form is a valid --unicode-normalize value.
+func isValidUnicodeNormalize(form string) bool {
+	switch strings.ToLower(form) {
+	case "", unicodeNormalizeNFC, unicodeNormalizeNFD, unicodeNormalizeNone:
+		return true
+	}
+	return false
+}
+
+// normalizeKey returns the object name to upload, converted to the
+// requested normalization form. Names are kept as they are listed when
+// no form or 'none' is requested.
+func normalizeKey(form, key string) string {
+	switch strings.ToLower(form) {
+	case unicodeNormalizeNFC:
+		return norm.NFC.String(key)
+	case unicodeNormalizeNFD:
+		return norm.NFD.String(key)
+	}
+	return key
+}
+
+// compareKey returns the form of an object name used to compare source
+// and target names. Names are compared in NFC unless another form is
+// requested, so that 'ä' as precomposed U+00E4 and decomposed U+0061
+// U+0308 are the same object.
+func compareKey(form, key string) string {
+	switch strings.ToLower(form) {
+	case unicodeNormalizeNone:
+		return key
+	case unicodeNormalizeNFD:
+		return norm.NFD.String(key)
+	}
+	return norm.NFC.String(key)
+}
@@ -0,0 +1,53 @@
+// Copyright (c) 2015-2024 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import "testing"
+
+func TestUnicodeNormalize(t *testing.T) {
+	const (
+		nfc = "dir/\u00e4.txt"  // precomposed
+		nfd = "dir/a\u0308.txt" // decomposed
+	)
+	testCases := []struct {
+		form               string
+		key                string
+		expectedKey        string
+		expectedCompareKey string
+		expectedValid      bool
+	}{
+		{"", nfd, nfd, nfc, true},
+		{"nfc", nfd, nfc, nfc, true},
+		{"NFC", nfd, nfc, nfc, true},
+		{"nfd", nfc, nfd, nfd, true},
+		{"none", nfd, nfd, nfd, true},
+		{"none", nfc, nfc, nfc, true},
+		{"nfkc", nfd, nfd, nfc, false},
+	}
+	for i, testCase := range testCases {
+		if valid := isValidUnicodeNormalize(testCase.form); valid != testCase.expectedValid {
+			t.Errorf("Test %d: expected valid %v, got %v", i+1, testCase.expectedValid, valid)
+		}
+		if key := normalizeKey(testCase.form, testCase.key); key != testCase.expectedKey {
+			t.Errorf("Test %d: expected key %q, got %q", i+1, testCase.expectedKey, key)
+		}
+		if key := compareKey(testCase.form, testCase.key); key != testCase.expectedCompareKey {
+			t.Errorf("Test %d: expected compare key %q, got %q", i+1, testCase.expectedCompareKey, key)
+		}
+	}
+}
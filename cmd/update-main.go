@@ -61,7 +61,10 @@ var updateCmd = cli.Command{
    {{.HelpName}} - {{.Usage}}
 
 USAGE:
-   {{.HelpName}}{{if .VisibleFlags}} [FLAGS]{{end}}
+   {{.HelpName}}{{if .VisibleFlags}} [FLAGS]{{end}} [RELEASE-INFO-URL | PATH]
+
+   PATH is a local mc binary, verified with the checksum in PATH.sha256sum
+   and the signature in PATH.minisig when MC_UPDATE_MINISIGN_PUBKEY is set.
 {{if .VisibleFlags}}
 FLAGS:
   {{range .VisibleFlags}}{{.}}
@@ -74,6 +77,9 @@ EXIT STATUS:
 EXAMPLES:
   1. Check and update mc:
      {{.Prompt}} {{.HelpName}}
+
+  2. Update mc from a binary downloaded to an air-gapped host, next to its mc.RELEASE.2024-10-08T09-37-26Z.sha256sum file:
+     {{.Prompt}} {{.HelpName}} /mnt/artifacts/mc.RELEASE.2024-10-08T09-37-26Z
 `,
 }
 
@@ -252,8 +258,35 @@ func downloadReleaseURL(releaseChecksumURL string, timeout time.Duration) (conte
 	return string(contentBytes), nil
 }
 
+// localUpdatePath returns the path of the mc binary to update from when
+// customReleaseURL is a local path or a file:// URL.
+func localUpdatePath(customReleaseURL string) (string, bool) {
+	if customReleaseURL == "" {
+		return "", false
+	}
+	if strings.HasPrefix(customReleaseURL, "file://") {
+		u, e := url.Parse(customReleaseURL)
+		if e != nil {
+			return "", false
+		}
+		return filepath.FromSlash(u.Path), true
+	}
+	if strings.Contains(customReleaseURL, "://") {
+		return "", false
+	}
+	return customReleaseURL, true
+}
+
 // DownloadReleaseData - downloads release data from mc official server.
 func DownloadReleaseData(customReleaseURL string, timeout time.Duration) (data string, err *probe.Error) {
+	if binPath, ok := localUpdatePath(customReleaseURL); ok {
+		// Release data of a local binary is next to it.
+		dataBytes, e := os.ReadFile(binPath + ".sha256sum")
+		if e != nil {
+			return data, probe.NewError(e).Trace(binPath)
+		}
+		return string(dataBytes), nil
+	}
 	releaseURL := mcReleaseInfoURL
 	if runtime.GOOS == "windows" {
 		releaseURL = mcReleaseWindowsInfoURL
@@ -323,6 +356,10 @@ func getDownloadURL(customReleaseURL, releaseTag string) (downloadURL string) {
 		return mcReleaseURL + "archive/mc." + releaseTag
 	}
 
+	if binPath, ok := localUpdatePath(customReleaseURL); ok {
+		return binPath
+	}
+
 	u, e := url.Parse(customReleaseURL)
 	if e != nil {
 		return mcReleaseURL + "archive/mc." + releaseTag
@@ -423,6 +460,19 @@ func getUpdateReaderFromURL(u *url.URL, transport http.RoundTripper) (io.ReadClo
 	return newProgressReader(resp.Body, "mc", resp.ContentLength), nil
 }
 
+func getUpdateReaderFromFile(binPath string) (io.ReadCloser, error) {
+	f, e := os.Open(binPath)
+	if e != nil {
+		return nil, e
+	}
+	st, e := f.Stat()
+	if e != nil {
+		f.Close()
+		return nil, e
+	}
+	return newProgressReader(f, "mc", st.Size()), nil
+}
+
 func doUpdate(customReleaseURL, sha256Hex string, latestReleaseTime time.Time, releaseTag string, ok bool) (updateStatusMsg string, err *probe.Error) {
 	fmtReleaseTime := latestReleaseTime.Format(mcReleaseTagTimeLayout)
 	if !ok {
@@ -436,33 +486,50 @@ func doUpdate(customReleaseURL, sha256Hex string, latestReleaseTime time.Time, r
 		return updateStatusMsg, probe.NewError(e)
 	}
 
-	u, e := url.Parse(getDownloadURL(customReleaseURL, releaseTag))
-	if err != nil {
-		return updateStatusMsg, probe.NewError(e)
-	}
-
-	transport := getUpdateTransport(30 * time.Second)
-
-	rc, e := getUpdateReaderFromURL(u, transport)
-	if e != nil {
-		return updateStatusMsg, probe.NewError(e)
-	}
-	defer rc.Close()
-
 	opts := selfupdate.Options{
 		Hash:     crypto.SHA256,
 		Checksum: sha256Sum,
 	}
-
 	minisignPubkey := env.Get(envMinisignPubKey, "")
-	if minisignPubkey != "" {
-		v := selfupdate.NewVerifier()
-		u.Path = path.Dir(u.Path) + "/mc." + releaseTag + ".minisig"
-		if e = v.LoadFromURL(u.String(), minisignPubkey, transport); e != nil {
+
+	var rc io.ReadCloser
+	if binPath, ok := localUpdatePath(customReleaseURL); ok {
+		rc, e = getUpdateReaderFromFile(binPath)
+		if e != nil {
 			return updateStatusMsg, probe.NewError(e)
 		}
-		opts.Verifier = v
+		if minisignPubkey != "" {
+			v := selfupdate.NewVerifier()
+			if e = v.LoadFromFile(binPath+".minisig", minisignPubkey); e != nil {
+				rc.Close()
+				return updateStatusMsg, probe.NewError(e)
+			}
+			opts.Verifier = v
+		}
+	} else {
+		u, e := url.Parse(getDownloadURL(customReleaseURL, releaseTag))
+		if e != nil {
+			return updateStatusMsg, probe.NewError(e)
+		}
+
+		transport := getUpdateTransport(30 * time.Second)
+
+		rc, e = getUpdateReaderFromURL(u, transport)
+		if e != nil {
+			return updateStatusMsg, probe.NewError(e)
+		}
+
+		if minisignPubkey != "" {
+			v := selfupdate.NewVerifier()
+			u.Path = path.Dir(u.Path) + "/mc." + releaseTag + ".minisig"
+			if e = v.LoadFromURL(u.String(), minisignPubkey, transport); e != nil {
+				rc.Close()
+				return updateStatusMsg, probe.NewError(e)
+			}
+			opts.Verifier = v
+		}
 	}
+	defer rc.Close()
 
 	if e := opts.CheckPermissions(); e != nil {
 		permErrMsg := fmt.Sprintf(" failed with: %s", e)
@@ -524,7 +591,7 @@ func mainUpdate(ctx *cli.Context) {
 	updateMsg, sha256Hex, _, latestReleaseTime, releaseTag, err := getUpdateInfo(customReleaseURL, 10*time.Second)
 	if err != nil {
 		errorIf(err, "Unable to update ‘mc’.")
-		os.Exit(-1)
+		cli.OsExiter(-1)
 	}
 
 	// Nothing to update running the latest release.
@@ -534,7 +601,7 @@ func mainUpdate(ctx *cli.Context) {
 			Status:  "success",
 			Message: colorGreenBold("You are already running the most recent version of ‘mc’."),
 		})
-		os.Exit(0)
+		cli.OsExiter(0)
 	}
 
 	printMsg(updateMessage{
@@ -549,9 +616,9 @@ func mainUpdate(ctx *cli.Context) {
 		updateStatusMsg, err = doUpdate(customReleaseURL, sha256Hex, latestReleaseTime, releaseTag, true)
 		if err != nil {
 			errorIf(err, "Unable to update ‘mc’.")
-			os.Exit(-1)
+			cli.OsExiter(-1)
 		}
 		printMsg(updateMessage{Status: "success", Message: updateStatusMsg})
-		os.Exit(1)
+		cli.OsExiter(1)
 	}
 }
@@ -0,0 +1,44 @@
+// Copyright (c) 2015-2024 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestLocalUpdatePath(t *testing.T) {
+	testCases := []struct {
+		arg     string
+		binPath string
+		local   bool
+	}{
+		{"", "", false},
+		{"https://dl.min.io/client/mc/release/linux-amd64/mc.sha256sum", "", false},
+		{"http://artifacts.local/mc/mc.sha256sum", "", false},
+		{"/mnt/artifacts/mc.RELEASE.2024-10-08T09-37-26Z", "/mnt/artifacts/mc.RELEASE.2024-10-08T09-37-26Z", true},
+		{"mc.RELEASE.2024-10-08T09-37-26Z", "mc.RELEASE.2024-10-08T09-37-26Z", true},
+		{"file:///mnt/artifacts/mc", filepath.FromSlash("/mnt/artifacts/mc"), true},
+	}
+	for i, testCase := range testCases {
+		binPath, local := localUpdatePath(testCase.arg)
+		if binPath != testCase.binPath || local != testCase.local {
+			t.Errorf("Test %d: expected (%q, %v), got (%q, %v)", i+1, testCase.binPath, testCase.local, binPath, local)
+		}
+	}
+}
@@ -0,0 +1,144 @@
+// Copyright (c) 2015-2024 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/minio/mc/pkg/probe"
+)
+
+// hasGlobMeta returns true if s has a glob pattern character.
+func hasGlobMeta(s string) bool {
+	return strings.ContainsAny(s, "*?[")
+}
+
+// splitGlobURL splits urlStr at the first path element with a glob
+// pattern, the prefix ends with the separator. ok is false if urlStr has
+// no pattern outside of its alias.
+func splitGlobURL(urlStr string, separator byte) (prefix string, patterns []string, ok bool) {
+	alias, _ := url2Alias(urlStr)
+	rest := strings.TrimPrefix(urlStr, alias)
+	elems := strings.Split(rest, string(separator))
+	for i, elem := range elems {
+		if !hasGlobMeta(elem) {
+			continue
+		}
+		prefix = alias + strings.Join(elems[:i], string(separator))
+		if !strings.HasSuffix(prefix, string(separator)) {
+			prefix += string(separator)
+		}
+		return prefix, elems[i:], true
+	}
+	return "", nil, false
+}
+
+// expandGlobURL expands the glob patterns of the path elements of urlStr
+// against the listings of the target, patterns use the syntax of
+// path.Match. Folders are returned with a trailing separator. URLs
+// without patterns, or whose literal path exists, are returned as is.
+func expandGlobURL(ctx context.Context, urlStr string) ([]string, *probe.Error) {
+	clnt, err := newClient(urlStr)
+	if err != nil {
+		return nil, err.Trace(urlStr)
+	}
+	separator := byte(clnt.GetURL().Separator)
+	prefix, patterns, ok := splitGlobURL(urlStr, separator)
+	if !ok {
+		return []string{urlStr}, nil
+	}
+	// Keys may have pattern characters.
+	if _, err = clnt.Stat(ctx, StatOptions{}); err == nil {
+		return []string{urlStr}, nil
+	}
+
+	matches, err := globMatches(ctx, prefix, patterns, separator)
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(matches)
+	return matches, nil
+}
+
+// globMatches lists prefix and returns the entries matching the first
+// pattern, folders are listed for the next patterns.
+func globMatches(ctx context.Context, prefix string, patterns []string, separator byte) ([]string, *probe.Error) {
+	clnt, err := newClient(prefix)
+	if err != nil {
+		return nil, err.Trace(prefix)
+	}
+	pattern, next := patterns[0], patterns[1:]
+	// A trailing separator only matches folders.
+	dirsOnly := len(next) == 1 && next[0] == ""
+	if dirsOnly {
+		next = nil
+	}
+
+	var matches []string
+	for content := range clnt.List(ctx, ListOptions{ShowDir: DirNone}) {
+		if content.Err != nil {
+			if _, ok := content.Err.ToGoError().(PathNotFound); ok {
+				continue
+			}
+			return nil, content.Err.Trace(prefix)
+		}
+		name := strings.TrimSuffix(content.URL.Path, string(separator))
+		name = name[strings.LastIndexByte(name, separator)+1:]
+		if matched, e := path.Match(pattern, name); e != nil {
+			return nil, probe.NewError(e).Trace(pattern)
+		} else if !matched {
+			continue
+		}
+		isDir := content.Type.IsDir()
+		switch {
+		case len(next) > 0:
+			if !isDir {
+				continue
+			}
+			nextPrefix := prefix + name + string(separator)
+			if !hasGlobMeta(strings.Join(next, string(separator))) {
+				// No patterns left, the rest of the path is literal.
+				matches = append(matches, nextPrefix+strings.Join(next, string(separator)))
+				continue
+			}
+			m, err := globNextMatches(ctx, nextPrefix, next, separator)
+			if err != nil {
+				return nil, err
+			}
+			matches = append(matches, m...)
+		case isDir:
+			matches = append(matches, prefix+name+string(separator))
+		case !dirsOnly:
+			matches = append(matches, prefix+name)
+		}
+	}
+	return matches, nil
+}
+
+// globNextMatches matches the patterns below prefix, literal elements
+// before the next pattern are added to the prefix.
+func globNextMatches(ctx context.Context, prefix string, patterns []string, separator byte) ([]string, *probe.Error) {
+	for len(patterns) > 1 && !hasGlobMeta(patterns[0]) {
+		prefix += patterns[0] + string(separator)
+		patterns = patterns[1:]
+	}
+	return globMatches(ctx, prefix, patterns, separator)
+}
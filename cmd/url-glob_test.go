@@ -0,0 +1,45 @@
+// Copyright (c) 2015-2024 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSplitGlobURL(t *testing.T) {
+	testCases := []struct {
+		urlStr   string
+		prefix   string
+		patterns []string
+		ok       bool
+	}{
+		{"play/mybucket/logs/", "", nil, false},
+		{"play/mybucket/logs/2024-0[1-6]-*/", "play/mybucket/logs/", []string{"2024-0[1-6]-*", ""}, true},
+		{"play/mybucket/*/2024/x.log", "play/mybucket/", []string{"*", "2024", "x.log"}, true},
+		{"play/logs-*", "play/", []string{"logs-*"}, true},
+		{"/tmp/a?/b", "/tmp/", []string{"a?", "b"}, true},
+	}
+	for i, testCase := range testCases {
+		prefix, patterns, ok := splitGlobURL(testCase.urlStr, '/')
+		if prefix != testCase.prefix || !reflect.DeepEqual(patterns, testCase.patterns) || ok != testCase.ok {
+			t.Errorf("Test %d: expected (%q, %q, %v), got (%q, %q, %v)", i+1,
+				testCase.prefix, testCase.patterns, testCase.ok, prefix, patterns, ok)
+		}
+	}
+}
@@ -36,6 +36,10 @@ type URLs struct {
 	encKeyDB         map[string][]prefixSSEPair
 	Error            *probe.Error `json:"-"`
 	ErrorCond        differType   `json:"-"`
+
+	// overwritten is the target object replaced by the copy, only set
+	// when planning a mirror.
+	overwritten *ClientContent
 }
 
 // WithError sets the error and returns object
@@ -40,6 +40,7 @@ import (
 	jwtgo "github.com/golang-jwt/jwt/v4"
 	"github.com/minio/mc/pkg/probe"
 	"github.com/minio/pkg/v3/console"
+	"github.com/minio/pkg/v3/env"
 )
 
 func isErrIgnored(err *probe.Error) (ignored bool) {
@@ -150,10 +151,22 @@ func NewS3Config(alias, urlStr string, aliasCfg *aliasConfigV10) *Config {
 		s3Config.SessionToken = aliasCfg.SessionToken
 		s3Config.Signature = aliasCfg.API
 		s3Config.Lookup = getLookupType(aliasCfg.Path)
+		s3Config.STS = aliasCfg.STS
 	}
+	s3Config.Proxy = getAliasProxy(alias, aliasCfg)
 	return s3Config
 }
 
+// getAliasProxy returns the proxy of an alias, MC_PROXY_<alias> takes
+// precedence over the proxy saved in the configuration.
+func getAliasProxy(alias string, aliasCfg *aliasConfigV10) string {
+	proxy := ""
+	if aliasCfg != nil {
+		proxy = aliasCfg.Proxy
+	}
+	return env.Get("MC_PROXY_"+alias, proxy)
+}
+
 // lineTrunc - truncates a string to the given maximum length by
 // adding ellipsis in the middle
 func lineTrunc(content string, maxLen int) string {
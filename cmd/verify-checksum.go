@@ -0,0 +1,232 @@
+// Copyright (c) 2015-2024 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/encrypt"
+)
+
+// The ETag of an object which is not encrypted with SSE-C or SSE-KMS is
+// the MD5 of its content, or of the MD5 of its parts followed by their count.
+var md5ETagRegex = regexp.MustCompile(`^[0-9a-f]{32}(-[0-9]+)?$`)
+
+// storedChecksum returns the stored checksum of an object to verify, in
+// the order of preference of compareChecksumTypes, and its number of parts.
+func storedChecksum(sums map[string]string) (checksumType, checksum string, parts int) {
+	for _, t := range compareChecksumTypes {
+		if sums[t] == "" || t == "ETag" && !md5ETagRegex.MatchString(sums[t]) {
+			continue
+		}
+		checksum = sums[t]
+		if i := strings.LastIndexByte(checksum, '-'); i > 0 {
+			parts, _ = strconv.Atoi(checksum[i+1:])
+		}
+		return t, checksum, parts
+	}
+	return "", "", 0
+}
+
+// compositeChecksum returns the checksum of a multipart object from the
+// checksums of its parts.
+func compositeChecksum(checksumType string, partSums [][]byte) string {
+	h := newChecksumHash(checksumType)
+	for _, sum := range partSums {
+		h.Write(sum)
+	}
+	return fmt.Sprintf("%s-%d", encodeChecksum(checksumType, h.Sum(nil)), len(partSums))
+}
+
+// isETagEncrypted returns true if the ETag of an object is not its MD5.
+func isETagEncrypted(info minio.ObjectInfo) bool {
+	return info.Metadata.Get("X-Amz-Server-Side-Encryption") == "aws:kms" ||
+		info.Metadata.Get("X-Amz-Server-Side-Encryption-Customer-Algorithm") != ""
+}
+
+// computeChecksum downloads an object and computes its checksum, the parts
+// of a multipart object are downloaded one at a time.
+func (c *S3Client) computeChecksum(ctx context.Context, content *ClientContent, checksumType string, parts int, sse encrypt.ServerSide) (checksum string, etagEncrypted bool, err *probe.Error) {
+	bucket, object := c.splitPath(content.URL.Path)
+	sumPart := func(partNumber int) ([]byte, *probe.Error) {
+		opts := minio.GetObjectOptions{
+			VersionID:            content.VersionID,
+			PartNumber:           partNumber,
+			ServerSideEncryption: sse,
+		}
+		reader, e := c.api.GetObject(ctx, bucket, object, opts)
+		if e != nil {
+			return nil, probe.NewError(e)
+		}
+		defer reader.Close()
+		if partNumber <= 1 {
+			info, e := reader.Stat()
+			if e != nil {
+				return nil, probe.NewError(e)
+			}
+			etagEncrypted = isETagEncrypted(info)
+		}
+		h := newChecksumHash(checksumType)
+		if _, e = io.Copy(h, reader); e != nil {
+			return nil, probe.NewError(e)
+		}
+		return h.Sum(nil), nil
+	}
+
+	if parts == 0 {
+		sum, err := sumPart(0)
+		if err != nil {
+			return "", false, err
+		}
+		return encodeChecksum(checksumType, sum), etagEncrypted, nil
+	}
+	partSums := make([][]byte, 0, parts)
+	for partNumber := 1; partNumber <= parts; partNumber++ {
+		sum, err := sumPart(partNumber)
+		if err != nil {
+			return "", false, err.Trace(fmt.Sprintf("part %d", partNumber))
+		}
+		partSums = append(partSums, sum)
+	}
+	return compositeChecksum(checksumType, partSums), etagEncrypted, nil
+}
+
+// verifyStoredChecksum verifies an object against the checksum stored
+// by the server.
+func verifyStoredChecksum(ctx context.Context, clnt *S3Client, alias string, content *ClientContent, sse encrypt.ServerSide) verifyMessage {
+	msg := verifyMessage{
+		Key:       path.Join(alias, content.URL.Path),
+		VersionID: content.VersionID,
+	}
+	sums, err := clnt.objectChecksums(ctx, content, sse)
+	if err != nil {
+		msg.Status = verifyStatusError
+		msg.Error = err.ToGoError().Error()
+		return msg
+	}
+	checksumType, expected, parts := storedChecksum(sums)
+	if checksumType == "" {
+		msg.Status = verifyStatusSkipped
+		msg.Error = "no checksum stored"
+		return msg
+	}
+	actual, etagEncrypted, err := clnt.computeChecksum(ctx, content, checksumType, parts, sse)
+	if err != nil {
+		msg.Status = verifyStatusError
+		msg.Error = err.ToGoError().Error()
+		return msg
+	}
+	if checksumType == "ETag" && etagEncrypted {
+		msg.Status = verifyStatusSkipped
+		msg.Error = "no checksum stored, the ETag of an object encrypted with SSE-C or SSE-KMS is not its MD5"
+		return msg
+	}
+	msg.Checksum = checksumType
+	msg.Expected = expected
+	msg.Actual = actual
+	msg.Status = verifyStatusOK
+	if actual != expected {
+		msg.Status = verifyStatusMismatch
+	}
+	return msg
+}
+
+// verifyStoredChecksums verifies the objects found at targetURL against
+// their stored checksums and returns the number of failures.
+func verifyStoredChecksums(ctx context.Context, targetURL string, recursive, versions bool, workers int, encKeyDB map[string][]prefixSSEPair, report io.Writer) (failed int64) {
+	clnt, err := newClient(targetURL)
+	fatalIf(err.Trace(targetURL), "Unable to initialize target `"+targetURL+"`.")
+	s3Clnt, ok := clnt.(*S3Client)
+	if !ok {
+		fatalIf(errInvalidArgument().Trace(targetURL), "Local files have no stored checksums, verify them against a manifest.")
+	}
+	alias, _ := url2Alias(targetURL)
+	sse := getSSE(targetURL, encKeyDB[alias])
+
+	// Errors of the listing count as failures, they are only read once
+	// all the results are collected.
+	var listErrs int64
+	contentCh := make(chan *ClientContent)
+	resultCh := make(chan verifyMessage)
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for content := range contentCh {
+				resultCh <- verifyStoredChecksum(ctx, s3Clnt, alias, content, sse)
+			}
+		}()
+	}
+
+	go func() {
+		defer close(contentCh)
+		if !recursive && !versions {
+			content, err := clnt.Stat(ctx, StatOptions{sse: sse})
+			if err != nil {
+				errorIf(err.Trace(targetURL), "Unable to stat `%s`.", targetURL)
+				listErrs++
+				return
+			}
+			if content.Type.IsDir() {
+				errorIf(errInvalidArgument().Trace(targetURL), "`%s` is a folder, verify it with --recursive.", targetURL)
+				listErrs++
+				return
+			}
+			contentCh <- content
+			return
+		}
+		for content := range clnt.List(ctx, ListOptions{
+			Recursive:         recursive,
+			WithOlderVersions: versions,
+			ShowDir:           DirNone,
+		}) {
+			if content.Err != nil {
+				errorIf(content.Err.Trace(targetURL), "Unable to list `%s`.", targetURL)
+				listErrs++
+				continue
+			}
+			if content.Type.IsDir() || content.IsDeleteMarker {
+				continue
+			}
+			select {
+			case contentCh <- content:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	go func() {
+		wg.Wait()
+		close(resultCh)
+	}()
+
+	verified, skipped, failed := collectVerifyResults(resultCh, report)
+	printMsg(newVerifySummaryMessage(verified+skipped+failed+listErrs, verified, skipped, failed+listErrs))
+	return failed + listErrs
+}
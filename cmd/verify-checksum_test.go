@@ -0,0 +1,60 @@
+// Copyright (c) 2015-2024 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"crypto/md5"
+	"encoding/hex"
+	"testing"
+)
+
+func TestStoredChecksum(t *testing.T) {
+	const etag = "d41d8cd98f00b204e9800998ecf8427e"
+	testCases := []struct {
+		sums         map[string]string
+		checksumType string
+		checksum     string
+		parts        int
+	}{
+		{map[string]string{}, "", "", 0},
+		{map[string]string{"ETag": etag}, "ETag", etag, 0},
+		{map[string]string{"ETag": etag + "-12"}, "ETag", etag + "-12", 12},
+		// The ETag of an encrypted object is not its MD5.
+		{map[string]string{"ETag": "4e1a6e76b7ba7a3c0f5e1b8b2b0b7e6e9b"}, "", "", 0},
+		{map[string]string{"ETag": etag, "CRC32C": "yZRlqg==-3"}, "CRC32C", "yZRlqg==-3", 3},
+		{map[string]string{"SHA256": "47DEQpj8HBSa+/TImW+5JCeuQeRkm5NMpJWZG3hSuFU=", "CRC32": "AAAAAA=="}, "SHA256", "47DEQpj8HBSa+/TImW+5JCeuQeRkm5NMpJWZG3hSuFU=", 0},
+	}
+
+	for i, testCase := range testCases {
+		checksumType, checksum, parts := storedChecksum(testCase.sums)
+		if checksumType != testCase.checksumType || checksum != testCase.checksum || parts != testCase.parts {
+			t.Fatalf("Test %d: expected (%q, %q, %d), got (%q, %q, %d)", i+1,
+				testCase.checksumType, testCase.checksum, testCase.parts, checksumType, checksum, parts)
+		}
+	}
+}
+
+func TestCompositeChecksum(t *testing.T) {
+	part1 := md5.Sum([]byte("part 1"))
+	part2 := md5.Sum([]byte("part 2"))
+	all := md5.Sum(append(part1[:], part2[:]...))
+	expected := hex.EncodeToString(all[:]) + "-2"
+	if got := compositeChecksum("ETag", [][]byte{part1[:], part2[:]}); got != expected {
+		t.Fatalf("expected %s, got %s", expected, got)
+	}
+}
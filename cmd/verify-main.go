@@ -0,0 +1,505 @@
+// Copyright (c) 2015-2024 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	json "github.com/minio/colorjson"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/pkg/v3/console"
+)
+
+var verifyFlags = []cli.Flag{
+	cli.BoolFlag{
+		Name:  "recursive, r",
+		Usage: "verify all objects under TARGET against their stored checksums",
+	},
+	cli.BoolFlag{
+		Name:  "versions",
+		Usage: "verify all the versions of the objects against their stored checksums",
+	},
+	cli.BoolFlag{
+		Name:  "generate",
+		Usage: "generate a new manifest from the objects found at TARGET instead of verifying",
+	},
+	cli.IntFlag{
+		Name:  "workers",
+		Usage: "number of objects verified concurrently",
+		Value: 4,
+	},
+	cli.StringFlag{
+		Name:  "report",
+		Usage: "write failed verifications as JSON lines to a local file",
+	},
+}
+
+// Verify objects against their stored checksums or a local checksum manifest.
+var verifyCmd = cli.Command{
+	Name:         "verify",
+	Usage:        "verify object(s) against their stored checksums or a local checksum manifest",
+	Action:       mainVerify,
+	OnUsageError: onUsageError,
+	Before:       setGlobalsFromContext,
+	Flags:        append(append(verifyFlags, encCFlags...), globalFlags...),
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} [FLAGS] TARGET [MANIFEST]
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+STORED CHECKSUMS:
+  Without MANIFEST, the objects are downloaded and verified against the checksum stored by the
+  server, SHA256, CRC32C, CRC32 or SHA1, or against their ETag when it is the MD5 of the object.
+  The parts of multipart objects are downloaded and verified one at a time.
+
+MANIFEST:
+  The manifest uses the 'sha256sum' format, one '<sha256>  <path>' entry per line
+  where path is relative to TARGET.
+
+EXAMPLES:
+  1. Generate a manifest for all objects under a prefix.
+     {{.Prompt}} {{.HelpName}} --generate myminio/backups/2024 manifest.sha256
+
+  2. Verify all objects under a prefix against a previously generated manifest.
+     {{.Prompt}} {{.HelpName}} myminio/backups/2024 manifest.sha256
+
+  3. Verify a local folder against a manifest, 16 objects at a time, saving failures to a report.
+     {{.Prompt}} {{.HelpName}} --workers 16 --report failed.json /mnt/backups/2024 manifest.sha256
+
+  4. Verify all the versions of the objects of a bucket after a migration against their stored checksums.
+     {{.Prompt}} {{.HelpName}} --recursive --versions --report corrupted.json myminio/backups
+`,
+}
+
+const (
+	verifyStatusOK       = "ok"
+	verifyStatusMismatch = "mismatch"
+	verifyStatusMissing  = "missing"
+	verifyStatusSkipped  = "skipped"
+	verifyStatusError    = "error"
+)
+
+// verifyMessage container for a single object verification.
+type verifyMessage struct {
+	Status    string `json:"status"`
+	Key       string `json:"key"`
+	VersionID string `json:"versionId,omitempty"`
+	Checksum  string `json:"checksum,omitempty"`
+	Expected  string `json:"expected,omitempty"`
+	Actual    string `json:"actual,omitempty"`
+	Error     string `json:"error,omitempty"`
+}
+
+// String colorized verify message.
+func (v verifyMessage) String() string {
+	key := v.Key
+	if v.VersionID != "" {
+		key += " (versionId=" + v.VersionID + ")"
+	}
+	switch v.Status {
+	case verifyStatusOK:
+		return console.Colorize("VerifyOK", "OK       ") + key
+	case verifyStatusMissing:
+		return console.Colorize("VerifyFailed", "MISSING  ") + key
+	case verifyStatusSkipped:
+		return console.Colorize("VerifySkipped", "SKIPPED  ") + key + ": " + v.Error
+	case verifyStatusMismatch:
+		checksum := ""
+		if v.Checksum != "" {
+			checksum = v.Checksum + " "
+		}
+		return console.Colorize("VerifyFailed", "MISMATCH ") + key +
+			fmt.Sprintf(" (expected %s%s, got %s)", checksum, v.Expected, v.Actual)
+	}
+	return console.Colorize("VerifyFailed", "ERROR    ") + key + ": " + v.Error
+}
+
+// JSON jsonified verify message.
+func (v verifyMessage) JSON() string {
+	msgBytes, e := json.MarshalIndent(v, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+	return string(msgBytes)
+}
+
+// verifySummaryMessage container for the final verification summary.
+type verifySummaryMessage struct {
+	Status   string `json:"status"`
+	Total    int64  `json:"total"`
+	Verified int64  `json:"verified"`
+	Skipped  int64  `json:"skipped,omitempty"`
+	Failed   int64  `json:"failed"`
+}
+
+// String colorized verify summary message.
+func (v verifySummaryMessage) String() string {
+	msg := fmt.Sprintf("Verified %d of %d object(s)", v.Verified, v.Total)
+	if v.Skipped > 0 {
+		msg += fmt.Sprintf(", %d skipped", v.Skipped)
+	}
+	if v.Failed > 0 {
+		return console.Colorize("VerifyFailed", msg+fmt.Sprintf(", %d failed.", v.Failed))
+	}
+	return console.Colorize("VerifyOK", msg+".")
+}
+
+// newVerifySummaryMessage returns the summary of a verification, its
+// status is an error when any object failed verification.
+func newVerifySummaryMessage(total, verified, skipped, failed int64) verifySummaryMessage {
+	status := "success"
+	if failed > 0 {
+		status = "error"
+	}
+	return verifySummaryMessage{
+		Status:   status,
+		Total:    total,
+		Verified: verified,
+		Skipped:  skipped,
+		Failed:   failed,
+	}
+}
+
+// JSON jsonified verify summary message.
+func (v verifySummaryMessage) JSON() string {
+	msgBytes, e := json.MarshalIndent(v, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+	return string(msgBytes)
+}
+
+// verifyManifestEntry is a single line of a checksum manifest.
+type verifyManifestEntry struct {
+	Key      string
+	Checksum string
+}
+
+// parseVerifyManifest reads a 'sha256sum' formatted manifest.
+func parseVerifyManifest(r io.Reader) ([]verifyManifestEntry, error) {
+	var entries []verifyManifestEntry
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		sum, key, ok := strings.Cut(text, " ")
+		if !ok {
+			return nil, fmt.Errorf("invalid manifest entry at line %d", line)
+		}
+		// Binary mode entries are prefixed with '*' by sha256sum.
+		key = strings.TrimPrefix(strings.TrimLeft(key, " "), "*")
+		if _, e := hex.DecodeString(sum); e != nil || len(sum) != sha256.Size*2 || key == "" {
+			return nil, fmt.Errorf("invalid manifest entry at line %d", line)
+		}
+		entries = append(entries, verifyManifestEntry{Key: key, Checksum: strings.ToLower(sum)})
+	}
+	return entries, scanner.Err()
+}
+
+// checkVerifySyntax - validate all the passed arguments
+func checkVerifySyntax(ctx *cli.Context) {
+	if len(ctx.Args()) != 1 && len(ctx.Args()) != 2 {
+		showCommandHelpAndExit(ctx, 1) // last argument is exit code
+	}
+	if len(ctx.Args()) == 1 && ctx.Bool("generate") {
+		fatalIf(errInvalidArgument().Trace(), "--generate requires a MANIFEST.")
+	}
+	if len(ctx.Args()) == 2 && (ctx.Bool("recursive") || ctx.Bool("versions")) {
+		fatalIf(errInvalidArgument().Trace(), "--recursive and --versions cannot be used with a MANIFEST, all its entries are verified.")
+	}
+	if ctx.Int("workers") <= 0 {
+		fatalIf(errInvalidArgument().Trace(), "--workers must be a positive number.")
+	}
+}
+
+// sha256URL streams the object at urlStr and returns its hex encoded SHA256.
+func sha256URL(ctx context.Context, urlStr string, encKeyDB map[string][]prefixSSEPair) (string, *probe.Error) {
+	reader, err := getSourceStreamFromURL(ctx, urlStr, encKeyDB, getSourceOpts{})
+	if err != nil {
+		return "", err.Trace(urlStr)
+	}
+	defer reader.Close()
+
+	h := sha256.New()
+	if _, e := io.Copy(h, reader); e != nil {
+		return "", probe.NewError(e).Trace(urlStr)
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// verifyObject verifies a single manifest entry against the object found under targetURL.
+func verifyObject(ctx context.Context, targetURL string, entry verifyManifestEntry, encKeyDB map[string][]prefixSSEPair) verifyMessage {
+	msg := verifyMessage{Key: entry.Key, Expected: entry.Checksum}
+	sum, err := sha256URL(ctx, urlJoinPath(targetURL, entry.Key), encKeyDB)
+	if err != nil {
+		switch err.ToGoError().(type) {
+		case ObjectMissing, PathNotFound:
+			msg.Status = verifyStatusMissing
+		default:
+			msg.Status = verifyStatusError
+			msg.Error = err.ToGoError().Error()
+		}
+		return msg
+	}
+	msg.Actual = sum
+	msg.Status = verifyStatusOK
+	if sum != entry.Checksum {
+		msg.Status = verifyStatusMismatch
+	}
+	return msg
+}
+
+// verifyManifest verifies all manifest entries concurrently and returns the number of failures.
+func verifyManifest(ctx context.Context, targetURL string, entries []verifyManifestEntry, workers int, encKeyDB map[string][]prefixSSEPair, report io.Writer) (failed int64) {
+	entriesCh := make(chan verifyManifestEntry)
+	resultCh := make(chan verifyMessage)
+
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for entry := range entriesCh {
+				resultCh <- verifyObject(ctx, targetURL, entry, encKeyDB)
+			}
+		}()
+	}
+
+	go func() {
+		defer close(entriesCh)
+		for _, entry := range entries {
+			select {
+			case entriesCh <- entry:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	go func() {
+		wg.Wait()
+		close(resultCh)
+	}()
+
+	verified, _, failed := collectVerifyResults(resultCh, report)
+	printMsg(newVerifySummaryMessage(int64(len(entries)), verified, 0, failed))
+	return failed
+}
+
+// collectVerifyResults prints the verification results, failures are also
+// written to report if any.
+func collectVerifyResults(resultCh <-chan verifyMessage, report io.Writer) (verified, skipped, failed int64) {
+	for msg := range resultCh {
+		switch msg.Status {
+		case verifyStatusOK:
+			verified++
+			if !globalQuiet {
+				printMsg(msg)
+			}
+			continue
+		case verifyStatusSkipped:
+			skipped++
+			printMsg(msg)
+			continue
+		}
+		failed++
+		printMsg(msg)
+		if report != nil {
+			b, e := json.Marshal(msg)
+			fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+			_, e = report.Write(append(b, '\n'))
+			fatalIf(probe.NewError(e), "Unable to write to the report file.")
+		}
+	}
+	return verified, skipped, failed
+}
+
+// generateManifest lists targetURL recursively and writes a manifest of all objects found.
+func generateManifest(ctx context.Context, targetURL string, workers int, encKeyDB map[string][]prefixSSEPair, w io.Writer) *probe.Error {
+	clnt, err := newClient(targetURL)
+	if err != nil {
+		return err.Trace(targetURL)
+	}
+	prefix := clnt.GetURL().String()
+	separator := string(clnt.GetURL().Separator)
+	if !strings.HasSuffix(prefix, separator) {
+		prefix += separator
+	}
+
+	var (
+		mu      sync.Mutex
+		entries []verifyManifestEntry
+		wg      sync.WaitGroup
+		errs    []*probe.Error
+	)
+
+	keysCh := make(chan string)
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for key := range keysCh {
+				sum, err := sha256URL(ctx, urlJoinPath(targetURL, key), encKeyDB)
+				mu.Lock()
+				if err != nil {
+					errs = append(errs, err.Trace(key))
+				} else {
+					entries = append(entries, verifyManifestEntry{Key: key, Checksum: sum})
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+
+	// A manifest missing the objects that could not be listed would
+	// verify successfully, listing errors fail the generation.
+	listErrs := 0
+	for content := range clnt.List(ctx, ListOptions{Recursive: true, ShowDir: DirNone}) {
+		if content.Err != nil {
+			errorIf(content.Err.Trace(targetURL), "Unable to list `%s`.", targetURL)
+			listErrs++
+			continue
+		}
+		if content.Type.IsDir() {
+			continue
+		}
+		keysCh <- strings.TrimPrefix(content.URL.String(), prefix)
+	}
+	close(keysCh)
+	wg.Wait()
+
+	for _, err := range errs {
+		errorIf(err, "Unable to compute checksum.")
+	}
+	if len(errs) > 0 {
+		return probe.NewError(fmt.Errorf("unable to compute checksum for %d object(s)", len(errs)))
+	}
+	if listErrs > 0 {
+		return probe.NewError(fmt.Errorf("unable to list %d object(s) or prefix(es)", listErrs))
+	}
+	if e := ctx.Err(); e != nil {
+		return probe.NewError(e)
+	}
+
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Key < entries[j].Key })
+	bw := bufio.NewWriter(w)
+	for _, entry := range entries {
+		if _, e := fmt.Fprintf(bw, "%s  %s\n", entry.Checksum, entry.Key); e != nil {
+			return probe.NewError(e)
+		}
+	}
+	if e := bw.Flush(); e != nil {
+		return probe.NewError(e)
+	}
+	if !globalQuiet && !globalJSON {
+		console.Infof("Generated manifest with %d object(s).\n", len(entries))
+	}
+	return nil
+}
+
+// writeGeneratedManifest writes the manifest produced by generate to a
+// temporary file next to manifestPath, which replaces an existing manifest
+// only once the generation succeeded.
+func writeGeneratedManifest(manifestPath string, generate func(io.Writer) *probe.Error) *probe.Error {
+	f, e := os.CreateTemp(filepath.Dir(manifestPath), "."+filepath.Base(manifestPath)+".tmp-*")
+	if e != nil {
+		return probe.NewError(e)
+	}
+	err := generate(f)
+	if err == nil {
+		err = probe.NewError(f.Chmod(0o644))
+	}
+	if e = f.Close(); e != nil && err == nil {
+		err = probe.NewError(e)
+	}
+	if err == nil {
+		err = probe.NewError(os.Rename(f.Name(), manifestPath))
+	}
+	if err != nil {
+		os.Remove(f.Name())
+		return err
+	}
+	return nil
+}
+
+// mainVerify is the main entry point for verify command.
+func mainVerify(cliCtx *cli.Context) error {
+	ctx, cancelVerify := context.WithCancel(globalContext)
+	defer cancelVerify()
+
+	checkVerifySyntax(cliCtx)
+
+	console.SetColor("VerifyOK", color.New(color.FgGreen, color.Bold))
+	console.SetColor("VerifyFailed", color.New(color.FgRed, color.Bold))
+	console.SetColor("VerifySkipped", color.New(color.FgYellow, color.Bold))
+
+	encKeyDB, err := validateAndCreateEncryptionKeys(cliCtx)
+	fatalIf(err, "Unable to parse encryption keys.")
+
+	args := cliCtx.Args()
+	targetURL, manifestPath := args.Get(0), args.Get(1)
+	workers := cliCtx.Int("workers")
+
+	var report io.Writer
+	if reportPath := cliCtx.String("report"); reportPath != "" && !cliCtx.Bool("generate") {
+		rf, e := os.Create(reportPath)
+		fatalIf(probe.NewError(e).Trace(reportPath), "Unable to create report file.")
+		defer rf.Close()
+		report = rf
+	}
+
+	if manifestPath == "" {
+		if verifyStoredChecksums(ctx, targetURL, cliCtx.Bool("recursive"), cliCtx.Bool("versions"), workers, encKeyDB, report) > 0 {
+			return exitStatus(globalErrorExitStatus)
+		}
+		return nil
+	}
+
+	if cliCtx.Bool("generate") {
+		err = writeGeneratedManifest(manifestPath, func(w io.Writer) *probe.Error {
+			return generateManifest(ctx, targetURL, workers, encKeyDB, w)
+		})
+		fatalIf(err.Trace(targetURL, manifestPath), "Unable to generate manifest.")
+		return nil
+	}
+
+	f, e := os.Open(manifestPath)
+	fatalIf(probe.NewError(e).Trace(manifestPath), "Unable to open manifest.")
+	entries, e := parseVerifyManifest(f)
+	f.Close()
+	fatalIf(probe.NewError(e).Trace(manifestPath), "Unable to parse manifest.")
+
+	if verifyManifest(ctx, targetURL, entries, workers, encKeyDB, report) > 0 {
+		return exitStatus(globalErrorExitStatus)
+	}
+	return nil
+}
@@ -0,0 +1,136 @@
+// Copyright (c) 2015-2024 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/minio/mc/pkg/probe"
+)
+
+func TestParseVerifyManifest(t *testing.T) {
+	const sum = "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+	testCases := []struct {
+		manifest string
+		entries  []verifyManifestEntry
+		wantErr  bool
+	}{
+		{"", nil, false},
+		{"# comment\n\n", nil, false},
+		{sum + "  a/b.txt\n", []verifyManifestEntry{{Key: "a/b.txt", Checksum: sum}}, false},
+		// Binary mode marker.
+		{sum + " *a/b.txt\n", []verifyManifestEntry{{Key: "a/b.txt", Checksum: sum}}, false},
+		// Uppercase checksums are normalized.
+		{strings.ToUpper(sum) + "  c d\n", []verifyManifestEntry{{Key: "c d", Checksum: sum}}, false},
+		{"abcd  a/b.txt\n", nil, true},
+		{sum + "\n", nil, true},
+	}
+
+	for i, testCase := range testCases {
+		entries, err := parseVerifyManifest(strings.NewReader(testCase.manifest))
+		if testCase.wantErr != (err != nil) {
+			t.Fatalf("Test %d: expected error %v, got %v", i+1, testCase.wantErr, err)
+		}
+		if !testCase.wantErr && !reflect.DeepEqual(entries, testCase.entries) {
+			t.Fatalf("Test %d: expected %v, got %v", i+1, testCase.entries, entries)
+		}
+	}
+}
+
+func TestVerifySummaryMessageStatus(t *testing.T) {
+	testCases := []struct {
+		verified, failed int64
+		status           string
+	}{
+		{3, 0, "success"},
+		{2, 1, "error"},
+		{0, 3, "error"},
+	}
+	for i, testCase := range testCases {
+		msg := newVerifySummaryMessage(testCase.verified+testCase.failed, testCase.verified, 0, testCase.failed)
+		if msg.Status != testCase.status {
+			t.Fatalf("Test %d: expected status %q, got %q", i+1, testCase.status, msg.Status)
+		}
+		if !strings.Contains(msg.JSON(), `"status":"`+testCase.status+`"`) {
+			t.Fatalf("Test %d: expected status %q in %s", i+1, testCase.status, msg.JSON())
+		}
+	}
+}
+
+func TestWriteGeneratedManifest(t *testing.T) {
+	useTestMcConfig(t)
+	defer func(quiet bool) { globalQuiet = quiet }(globalQuiet)
+	globalQuiet = true
+
+	dir := t.TempDir()
+	target := filepath.Join(dir, "target")
+	if e := os.MkdirAll(filepath.Join(target, "sub"), 0o700); e != nil {
+		t.Fatal(e)
+	}
+	for name, data := range map[string]string{"a.txt": "", "sub/b.txt": "b"} {
+		if e := os.WriteFile(filepath.Join(target, name), []byte(data), 0o600); e != nil {
+			t.Fatal(e)
+		}
+	}
+	manifestPath := filepath.Join(dir, "manifest.sha256")
+	const previous = "previous manifest\n"
+	if e := os.WriteFile(manifestPath, []byte(previous), 0o644); e != nil {
+		t.Fatal(e)
+	}
+
+	// A failed generation keeps the previous manifest.
+	err := writeGeneratedManifest(manifestPath, func(w io.Writer) *probe.Error {
+		io.WriteString(w, "partial")
+		return probe.NewError(errors.New("checksum failure"))
+	})
+	if err == nil {
+		t.Fatal("expected the generation to fail")
+	}
+	if b, _ := os.ReadFile(manifestPath); string(b) != previous {
+		t.Fatalf("expected the previous manifest to be kept, got %q", b)
+	}
+
+	err = writeGeneratedManifest(manifestPath, func(w io.Writer) *probe.Error {
+		return generateManifest(context.Background(), target, 2, nil, w)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, e := os.ReadFile(manifestPath)
+	if e != nil {
+		t.Fatal(e)
+	}
+	want := "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855  a.txt\n" +
+		"3e23e8160039594a33894f6564e1b1348bbd7a0088d42c4acb73eeaed59c009d  sub/b.txt\n"
+	if string(b) != want {
+		t.Fatalf("expected manifest %q, got %q", want, b)
+	}
+
+	// No temporary file is left behind.
+	files, _ := os.ReadDir(dir)
+	if len(files) != 2 {
+		t.Fatalf("expected only the target and the manifest, got %d files", len(files))
+	}
+}
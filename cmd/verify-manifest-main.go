@@ -0,0 +1,171 @@
+// Copyright (c) 2015-2024 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"os"
+	"strings"
+
+	"aead.dev/minisign"
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/pkg/v3/console"
+)
+
+var verifyManifestFlags = []cli.Flag{
+	cli.StringFlag{
+		Name:  "pubkey",
+		Usage: "minisign public key verifying the signature of MANIFEST, as a file or as a base64 string",
+	},
+	cli.StringFlag{
+		Name:  "signature",
+		Usage: "signature of MANIFEST, defaults to MANIFEST.minisig",
+	},
+	cli.IntFlag{
+		Name:  "workers",
+		Usage: "number of objects verified concurrently",
+		Value: 4,
+	},
+	cli.StringFlag{
+		Name:  "report",
+		Usage: "write failed verifications as JSON lines to a local file",
+	},
+}
+
+// Verify objects against a signed manifest.
+var verifyManifestCmd = cli.Command{
+	Name:         "verify-manifest",
+	Usage:        "verify object(s) against a signed transfer manifest",
+	Action:       mainVerifyManifest,
+	OnUsageError: onUsageError,
+	Before:       setGlobalsFromContext,
+	Flags:        append(append(verifyManifestFlags, encCFlags...), globalFlags...),
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} --pubkey KEY [FLAGS] TARGET MANIFEST
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+DESCRIPTION:
+  Check the minisign signature of a manifest written by 'mc cp --manifest' or 'mc mirror --manifest',
+  then verify the objects found under TARGET against the SHA256 listed in the manifest. Nothing is
+  verified when the signature does not match, the manifest may have been tampered with.
+
+EXAMPLES:
+  1. Verify a backup against the manifest signed when it was mirrored.
+     {{.Prompt}} {{.HelpName}} --pubkey backup.pub myminio/backups/2024 backup.sha256
+
+  2. Verify a backup with a public key given as a string and a signature stored elsewhere.
+     {{.Prompt}} {{.HelpName}} --pubkey RWTx5Zr1tiHQLwG9keckT0c45M3AGeHD6IvimQHpyRywVWGbP1aVSGav \
+           --signature /mnt/signatures/backup.sha256.minisig myminio/backups/2024 backup.sha256
+
+  3. Verify a backup 16 objects at a time, saving the failures to a report.
+     {{.Prompt}} {{.HelpName}} --pubkey backup.pub --workers 16 --report failed.json myminio/backups/2024 backup.sha256
+`,
+}
+
+// errManifestSignature is returned when the signature of a manifest does
+// not match its content.
+var errManifestSignature = errors.New("signature does not match, the manifest or its signature was modified or signed with another key")
+
+// loadManifestPublicKey reads a minisign public key from a file or from
+// its base64 encoding.
+func loadManifestPublicKey(pubkey string) (minisign.PublicKey, *probe.Error) {
+	var key minisign.PublicKey
+	if _, e := os.Stat(pubkey); e == nil {
+		key, e = minisign.PublicKeyFromFile(pubkey)
+		return key, probe.NewError(e)
+	}
+	return key, probe.NewError(key.UnmarshalText([]byte(strings.TrimSpace(pubkey))))
+}
+
+// verifyManifestSignature checks the minisign signature of a manifest.
+func verifyManifestSignature(manifest, signature []byte, key minisign.PublicKey) error {
+	if !minisign.Verify(key, manifest, signature) {
+		return errManifestSignature
+	}
+	return nil
+}
+
+// checkVerifyManifestSyntax - validate all the passed arguments
+func checkVerifyManifestSyntax(ctx *cli.Context) {
+	if len(ctx.Args()) != 2 {
+		showCommandHelpAndExit(ctx, 1) // last argument is exit code
+	}
+	if ctx.String("pubkey") == "" {
+		fatalIf(errInvalidArgument().Trace(), "--pubkey is required to check the signature of the manifest.")
+	}
+	if ctx.Int("workers") <= 0 {
+		fatalIf(errInvalidArgument().Trace(), "--workers must be a positive number.")
+	}
+}
+
+// mainVerifyManifest is the main entry point for verify-manifest command.
+func mainVerifyManifest(cliCtx *cli.Context) error {
+	ctx, cancelVerify := context.WithCancel(globalContext)
+	defer cancelVerify()
+
+	checkVerifyManifestSyntax(cliCtx)
+
+	console.SetColor("VerifyOK", color.New(color.FgGreen, color.Bold))
+	console.SetColor("VerifyFailed", color.New(color.FgRed, color.Bold))
+	console.SetColor("VerifySkipped", color.New(color.FgYellow, color.Bold))
+
+	encKeyDB, err := validateAndCreateEncryptionKeys(cliCtx)
+	fatalIf(err, "Unable to parse encryption keys.")
+
+	args := cliCtx.Args()
+	targetURL, manifestPath := args.Get(0), args.Get(1)
+	signaturePath := cliCtx.String("signature")
+	if signaturePath == "" {
+		signaturePath = manifestPath + manifestSignatureExt
+	}
+
+	key, err := loadManifestPublicKey(cliCtx.String("pubkey"))
+	fatalIf(err.Trace(cliCtx.String("pubkey")), "Unable to read the public key.")
+	manifest, e := os.ReadFile(manifestPath)
+	fatalIf(probe.NewError(e).Trace(manifestPath), "Unable to read manifest.")
+	signature, e := os.ReadFile(signaturePath)
+	fatalIf(probe.NewError(e).Trace(signaturePath), "Unable to read the signature of the manifest.")
+	fatalIf(probe.NewError(verifyManifestSignature(manifest, signature, key)).Trace(manifestPath, signaturePath),
+		"Unable to verify the signature of the manifest.")
+
+	entries, e := parseVerifyManifest(bytes.NewReader(manifest))
+	fatalIf(probe.NewError(e).Trace(manifestPath), "Unable to parse manifest.")
+
+	var report io.Writer
+	if reportPath := cliCtx.String("report"); reportPath != "" {
+		rf, e := os.Create(reportPath)
+		fatalIf(probe.NewError(e).Trace(reportPath), "Unable to create report file.")
+		defer rf.Close()
+		report = rf
+	}
+
+	if verifyManifest(ctx, targetURL, entries, cliCtx.Int("workers"), encKeyDB, report) > 0 {
+		return exitStatus(globalErrorExitStatus)
+	}
+	return nil
+}
@@ -0,0 +1,111 @@
+// Copyright (c) 2015-2024 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"path/filepath"
+	"strings"
+)
+
+// Events watched when --events is not set.
+const watchDefaultEvents = "put,delete,get"
+
+// watchFilter selects the events of the objects matching any of the
+// prefixes and any of the suffixes of the repeated --prefix and --suffix
+// flags, except the objects under one of the --exclude-prefix prefixes.
+// Object names are relative to the bucket, or to the folder, watched.
+type watchFilter struct {
+	prefixes        []string
+	suffixes        []string
+	excludePrefixes []string
+}
+
+// parseWatchEvents returns the events of the repeated --events flags,
+// each of them a comma separated list.
+func parseWatchEvents(values []string) (events []string) {
+	if len(values) == 0 {
+		values = []string{watchDefaultEvents}
+	}
+	for _, value := range values {
+		for _, event := range strings.Split(value, ",") {
+			if event = strings.TrimSpace(event); event != "" {
+				events = append(events, event)
+			}
+		}
+	}
+	return events
+}
+
+// serverFilter returns the prefix and suffix filtering the events on the
+// server, which accepts only one of each. The events are filtered by
+// match when there are more.
+func (f watchFilter) serverFilter() (prefix, suffix string) {
+	if len(f.prefixes) > 1 || len(f.suffixes) > 1 {
+		return "", ""
+	}
+	if len(f.prefixes) == 1 {
+		prefix = f.prefixes[0]
+	}
+	if len(f.suffixes) == 1 {
+		suffix = f.suffixes[0]
+	}
+	return prefix, suffix
+}
+
+// match reports whether the events of object name are selected.
+func (f watchFilter) match(name string) bool {
+	for _, prefix := range f.excludePrefixes {
+		if strings.HasPrefix(name, prefix) {
+			return false
+		}
+	}
+	return matchAnyAffix(name, f.prefixes, strings.HasPrefix) && matchAnyAffix(name, f.suffixes, strings.HasSuffix)
+}
+
+func matchAnyAffix(name string, affixes []string, has func(string, string) bool) bool {
+	if len(affixes) == 0 {
+		return true
+	}
+	for _, affix := range affixes {
+		if has(name, affix) {
+			return true
+		}
+	}
+	return false
+}
+
+// watchBaseURL returns the URL the object names of the events of clnt
+// are relative to, the bucket of an object storage or the watched folder.
+func watchBaseURL(clnt Client) string {
+	u := clnt.GetURL()
+	switch c := clnt.(type) {
+	case *S3Client:
+		bucket, _ := c.url2BucketAndObject()
+		u.Path = "/"
+		if bucket != "" {
+			u.Path += bucket + "/"
+		}
+		return u.String()
+	default:
+		base := u.Path
+		if abs, e := filepath.Abs(base); e == nil {
+			base = abs
+		}
+		return strings.TrimSuffix(base, string(u.Separator)) + string(u.Separator)
+	}
+}
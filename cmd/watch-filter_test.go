@@ -0,0 +1,67 @@
+// Copyright (c) 2015-2024 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseWatchEvents(t *testing.T) {
+	if events := parseWatchEvents(nil); !reflect.DeepEqual(events, []string{"put", "delete", "get"}) {
+		t.Fatalf("unexpected default events %v", events)
+	}
+	if events := parseWatchEvents([]string{"put", "delete, ilm"}); !reflect.DeepEqual(events, []string{"put", "delete", "ilm"}) {
+		t.Fatalf("unexpected events %v", events)
+	}
+}
+
+func TestWatchFilter(t *testing.T) {
+	filter := watchFilter{
+		prefixes:        []string{"photos/", "videos/"},
+		suffixes:        []string{".jpg", ".mp4"},
+		excludePrefixes: []string{"photos/thumbnails/"},
+	}
+	testCases := []struct {
+		name  string
+		match bool
+	}{
+		{"photos/a.jpg", true},
+		{"videos/b.mp4", true},
+		{"videos/b.mov", false},
+		{"docs/c.jpg", false},
+		{"photos/thumbnails/a.jpg", false},
+	}
+	for _, testCase := range testCases {
+		if match := filter.match(testCase.name); match != testCase.match {
+			t.Errorf("%s: expected %t, got %t", testCase.name, testCase.match, match)
+		}
+	}
+	if prefix, suffix := filter.serverFilter(); prefix != "" || suffix != "" {
+		t.Fatalf("expected no server filter, got %q and %q", prefix, suffix)
+	}
+
+	// A single prefix and suffix are still filtered by the server.
+	filter = watchFilter{prefixes: []string{"photos/"}, suffixes: []string{".jpg"}}
+	if prefix, suffix := filter.serverFilter(); prefix != "photos/" || suffix != ".jpg" {
+		t.Fatalf("unexpected server filter %q and %q", prefix, suffix)
+	}
+	if !(watchFilter{}).match("any/object") {
+		t.Fatal("expected an empty filter to match all objects")
+	}
+}
@@ -0,0 +1,98 @@
+// Copyright (c) 2015-2024 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"time"
+
+	"github.com/minio/minio-go/v7/pkg/notification"
+)
+
+// Type of the synthetic records written by --heartbeat.
+const watchHeartbeatEvent notification.EventType = "mc:Heartbeat"
+
+// watchSilence tracks the silence of a notification stream, it fires a
+// heartbeat after every heartbeat interval without events, and fails once
+// the stream has been silent for failAfter. Zero durations are disabled.
+type watchSilence struct {
+	heartbeat, failAfter time.Duration
+
+	heartbeatTimer, failTimer *time.Timer
+}
+
+// newWatchSilence starts tracking the silence of a stream.
+func newWatchSilence(heartbeat, failAfter time.Duration) *watchSilence {
+	s := &watchSilence{heartbeat: heartbeat, failAfter: failAfter}
+	if heartbeat > 0 {
+		s.heartbeatTimer = time.NewTimer(heartbeat)
+	}
+	if failAfter > 0 {
+		s.failTimer = time.NewTimer(failAfter)
+	}
+	return s
+}
+
+// heartbeatC returns the channel receiving the time of the heartbeats,
+// nil when heartbeats are disabled.
+func (s *watchSilence) heartbeatC() <-chan time.Time {
+	if s.heartbeatTimer == nil {
+		return nil
+	}
+	return s.heartbeatTimer.C
+}
+
+// failC returns the channel receiving the time when the stream has been
+// silent for too long, nil when silence is allowed.
+func (s *watchSilence) failC() <-chan time.Time {
+	if s.failTimer == nil {
+		return nil
+	}
+	return s.failTimer.C
+}
+
+// received restarts the silence after events were received.
+func (s *watchSilence) received() {
+	if s.heartbeatTimer != nil {
+		s.heartbeatTimer.Reset(s.heartbeat)
+	}
+	if s.failTimer != nil {
+		s.failTimer.Reset(s.failAfter)
+	}
+}
+
+// heartbeatMessage returns the heartbeat record of path sent at t, and
+// waits for the next heartbeat.
+func (s *watchSilence) heartbeatMessage(path string, t time.Time) watchMessage {
+	s.heartbeatTimer.Reset(s.heartbeat)
+	msg := watchMessage{}
+	// Same layout as the times of the events.
+	msg.Event.Time = t.UTC().Format("2006-01-02T15:04:05.000Z")
+	msg.Event.Type = watchHeartbeatEvent
+	msg.Event.Path = path
+	return msg
+}
+
+// stop stops the timers.
+func (s *watchSilence) stop() {
+	if s.heartbeatTimer != nil {
+		s.heartbeatTimer.Stop()
+	}
+	if s.failTimer != nil {
+		s.failTimer.Stop()
+	}
+}
@@ -0,0 +1,60 @@
+// Copyright (c) 2015-2024 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"testing"
+	"time"
+)
+
+func TestWatchSilence(t *testing.T) {
+	s := newWatchSilence(0, 0)
+	if s.heartbeatC() != nil || s.failC() != nil {
+		t.Fatal("expected no heartbeat and no failure when disabled")
+	}
+	s.received()
+	s.stop()
+
+	s = newWatchSilence(20*time.Millisecond, 200*time.Millisecond)
+	defer s.stop()
+	start := time.Now()
+	var heartbeats int
+	for heartbeats < 3 {
+		select {
+		case tm := <-s.heartbeatC():
+			msg := s.heartbeatMessage("play/testbucket", tm)
+			if msg.Event.Type != watchHeartbeatEvent || msg.Event.Path != "play/testbucket" {
+				t.Fatalf("unexpected heartbeat %+v", msg)
+			}
+			heartbeats++
+		case <-s.failC():
+			t.Fatal("expected heartbeats before the failure")
+		}
+	}
+
+	// Events postpone the failure.
+	s.received()
+	select {
+	case <-s.failC():
+		if time.Since(start) < 200*time.Millisecond {
+			t.Fatal("failure received before the stream was silent for long enough")
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected a failure after the silence")
+	}
+}
@@ -22,6 +22,7 @@ import (
 	"fmt"
 	"strings"
 	"sync"
+	"time"
 
 	humanize "github.com/dustin/go-humanize"
 	"github.com/fatih/color"
@@ -33,23 +34,58 @@ import (
 )
 
 var watchFlags = []cli.Flag{
-	cli.StringFlag{
+	cli.StringSliceFlag{
 		Name:  "events",
-		Value: "put,delete,get",
-		Usage: "filter specific types of events; defaults to all events by default",
+		Usage: "filter specific types of events, may be repeated; defaults to \"" + watchDefaultEvents + "\"",
 	},
-	cli.StringFlag{
+	cli.StringSliceFlag{
 		Name:  "prefix",
-		Usage: "filter events for a prefix",
+		Usage: "filter events for a prefix, may be repeated to match any of the prefixes",
 	},
-	cli.StringFlag{
+	cli.StringSliceFlag{
 		Name:  "suffix",
-		Usage: "filter events for a suffix",
+		Usage: "filter events for a suffix, may be repeated to match any of the suffixes",
+	},
+	cli.StringSliceFlag{
+		Name:  "exclude-prefix",
+		Usage: "ignore the events of the objects under a prefix, may be repeated",
 	},
 	cli.BoolFlag{
 		Name:  "recursive",
 		Usage: "recursively watch for events",
 	},
+	cli.StringFlag{
+		Name:  "event-source, watch-events",
+		Usage: "receive the events from a webhook or an SQS queue instead of the server, e.g. 'webhook://:8080/events' or 'sqs://sqs.us-east-1.amazonaws.com/123456789012/events'",
+	},
+	cli.StringFlag{
+		Name:  "out",
+		Usage: "write the events to a file instead of stdout",
+	},
+	cli.StringFlag{
+		Name:  "format",
+		Usage: "write the events one per line as 'jsonl' or 'csv'",
+	},
+	cli.StringFlag{
+		Name:  "rotate-size",
+		Usage: "rotate the file of --out once it grows beyond this size, e.g. '100MiB'",
+	},
+	cli.StringFlag{
+		Name:  "rotate-interval",
+		Usage: "rotate the file of --out once it has been written to for this long, e.g. '1h' or '1d'",
+	},
+	cli.IntFlag{
+		Name:  "rotate-keep",
+		Usage: "number of rotated files to keep, all are kept when 0",
+	},
+	cli.DurationFlag{
+		Name:  "heartbeat",
+		Usage: "write a '" + string(watchHeartbeatEvent) + "' record after this long without events, e.g. '30s'",
+	},
+	cli.DurationFlag{
+		Name:  "fail-on-silence",
+		Usage: "exit with an error when no events are received for this long, e.g. '10m'",
+	},
 }
 
 var watchCmd = cli.Command{
@@ -86,6 +122,30 @@ EXAMPLES:
 
   6. Watch for events on local directory.
      {{.Prompt}} {{.HelpName}} /usr/share
+
+  7. Watch events sent to a webhook listening on port 8080, by the webhook target of the server or by
+     a bridge forwarding the events of a Kafka topic.
+     {{.Prompt}} {{.HelpName}} --event-source "webhook://:8080/events?token=secret" ceph/testbucket
+
+  8. Watch events of an AWS S3 bucket sent to an SQS queue, the AWS credentials are read from the
+     environment, the AWS credentials file or IAM.
+     {{.Prompt}} {{.HelpName}} --watch-events "sqs://sqs.us-east-1.amazonaws.com/123456789012/testbucket-events" s3/testbucket
+
+  9. Write the events to a file as JSON lines, rotating it every hour or when it reaches 100MiB and keeping
+     the last 24 rotated files.
+     {{.Prompt}} {{.HelpName}} --out /var/log/mc/events.jsonl --rotate-interval 1h --rotate-size 100MiB --rotate-keep 24 play/testbucket
+
+  10. Write the events to stdout as CSV.
+     {{.Prompt}} {{.HelpName}} --format csv play/testbucket
+
+  11. Watch the uploads and removals of images and videos under "photos/" and "videos/", except
+     the thumbnails.
+     {{.Prompt}} {{.HelpName}} --events put --events delete --prefix "photos/" --prefix "videos/" \
+         --suffix ".jpg" --suffix ".mp4" --exclude-prefix "photos/thumbnails/" play/testbucket
+
+  12. Watch a bucket for a monitoring system, writing a heartbeat record after every 30 seconds without
+     events and exiting with an error when the notification stream stays silent for 10 minutes.
+     {{.Prompt}} {{.HelpName}} --json --heartbeat 30s --fail-on-silence 10m play/testbucket
 `,
 }
 
@@ -94,6 +154,48 @@ func checkWatchSyntax(ctx *cli.Context) {
 	if len(ctx.Args()) != 1 {
 		showCommandHelpAndExit(ctx, 1) // last argument is exit code
 	}
+	if ctx.String("out") == "" {
+		for _, flag := range []string{"rotate-size", "rotate-interval", "rotate-keep"} {
+			if ctx.IsSet(flag) {
+				fatalIf(errInvalidArgument().Trace(flag), "--"+flag+" requires --out.")
+			}
+		}
+	}
+	if ctx.Int("rotate-keep") < 0 {
+		fatalIf(errInvalidArgument().Trace(ctx.String("rotate-keep")), "--rotate-keep cannot be negative.")
+	}
+	for _, flag := range []string{"heartbeat", "fail-on-silence"} {
+		if ctx.Duration(flag) < 0 {
+			fatalIf(errInvalidArgument().Trace(ctx.String(flag)), "--"+flag+" cannot be negative.")
+		}
+	}
+}
+
+// newWatchWriterFromContext returns the writer of the events when --out or
+// --format is set, nil otherwise.
+func newWatchWriterFromContext(ctx *cli.Context) *watchWriter {
+	out, format := ctx.String("out"), ctx.String("format")
+	if out == "" && format == "" {
+		return nil
+	}
+	if format == "" {
+		format = watchFormatJSONL
+	}
+	var maxSize uint64
+	if s := ctx.String("rotate-size"); s != "" {
+		var e error
+		maxSize, e = humanize.ParseBytes(s)
+		fatalIf(probe.NewError(e).Trace(s), "Unable to parse --rotate-size.")
+	}
+	var interval time.Duration
+	if s := ctx.String("rotate-interval"); s != "" {
+		d, e := ParseDuration(s)
+		fatalIf(probe.NewError(e).Trace(s), "Unable to parse --rotate-interval.")
+		interval = time.Duration(d)
+	}
+	ww, e := newWatchWriter(out, format, int64(maxSize), interval, ctx.Int("rotate-keep"))
+	fatalIf(probe.NewError(e).Trace(out), "Unable to open the output of the events.")
+	return ww
 }
 
 // watchMessage container to hold one event notification
@@ -142,9 +244,13 @@ func mainWatch(cliCtx *cli.Context) error {
 	args := cliCtx.Args()
 	path := args[0]
 
-	prefix := cliCtx.String("prefix")
-	suffix := cliCtx.String("suffix")
-	events := strings.Split(cliCtx.String("events"), ",")
+	filter := watchFilter{
+		prefixes:        cliCtx.StringSlice("prefix"),
+		suffixes:        cliCtx.StringSlice("suffix"),
+		excludePrefixes: cliCtx.StringSlice("exclude-prefix"),
+	}
+	prefix, suffix := filter.serverFilter()
+	events := parseWatchEvents(cliCtx.StringSlice("events"))
 	recursive := cliCtx.Bool("recursive")
 
 	s3Client, pErr := newClient(path)
@@ -157,6 +263,14 @@ func mainWatch(cliCtx *cli.Context) error {
 		Events:    events,
 		Prefix:    prefix,
 		Suffix:    suffix,
+		Source:    cliCtx.String("event-source"),
+	}
+
+	baseURL := watchBaseURL(s3Client)
+
+	ww := newWatchWriterFromContext(cliCtx)
+	if ww != nil {
+		defer ww.Close()
 	}
 
 	ctx, cancelWatch := context.WithCancel(globalContext)
@@ -166,6 +280,25 @@ func mainWatch(cliCtx *cli.Context) error {
 	wo, err := s3Client.Watch(ctx, options)
 	fatalIf(err, "Unable to watch on the specified bucket.")
 
+	silence := newWatchSilence(cliCtx.Duration("heartbeat"), cliCtx.Duration("fail-on-silence"))
+	defer silence.stop()
+
+	// Set when the notification stream appears to be broken.
+	var broken bool
+
+	// emit writes a record, it returns false if it cannot be written.
+	emit := func(msg watchMessage) bool {
+		if ww == nil {
+			printMsg(msg)
+			return true
+		}
+		if e := ww.Write(msg); e != nil {
+			errorIf(probe.NewError(e), "Unable to write the event.")
+			return false
+		}
+		return true
+	}
+
 	// Initialize.. waitgroup to track the go-routine.
 	var wg sync.WaitGroup
 
@@ -183,11 +316,31 @@ func mainWatch(cliCtx *cli.Context) error {
 				// Signal received we are done.
 				close(wo.DoneChan)
 				return
+			case t := <-silence.heartbeatC():
+				if !emit(silence.heartbeatMessage(path, t)) {
+					close(wo.DoneChan)
+					return
+				}
+			case <-silence.failC():
+				errorIf(errDummy().Trace(path), "No events received for %s, the notification stream of `%s` may be broken.",
+					cliCtx.Duration("fail-on-silence"), path)
+				broken = true
+				close(wo.DoneChan)
+				return
 			case events, ok := <-wo.Events():
 				if !ok {
+					if globalContext.Err() == nil {
+						errorIf(errDummy().Trace(path), "The notification stream of `%s` was closed.", path)
+						broken = true
+					}
 					return
 				}
+				// Filtered out events still show the stream is alive.
+				silence.received()
 				for _, event := range events {
+					if !filter.match(strings.TrimPrefix(event.Path, baseURL)) {
+						continue
+					}
 					msg := watchMessage{}
 					msg.Event.Path = event.Path
 					msg.Event.Size = event.Size
@@ -196,7 +349,10 @@ func mainWatch(cliCtx *cli.Context) error {
 					msg.Source.Host = event.Host
 					msg.Source.Port = event.Port
 					msg.Source.UserAgent = event.UserAgent
-					printMsg(msg)
+					if !emit(msg) {
+						close(wo.DoneChan)
+						return
+					}
 				}
 			case err, ok := <-wo.Errors():
 				if !ok {
@@ -204,6 +360,7 @@ func mainWatch(cliCtx *cli.Context) error {
 				}
 				if err != nil {
 					errorIf(err, "Unable to watch for events.")
+					broken = true
 					return
 				}
 			}
@@ -213,5 +370,8 @@ func mainWatch(cliCtx *cli.Context) error {
 	// Wait on the routine to be finished or exit.
 	wg.Wait()
 
+	if broken {
+		return exitStatus(globalErrorExitStatus)
+	}
 	return nil
 }
@@ -0,0 +1,254 @@
+// Copyright (c) 2015-2024 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+	"github.com/minio/minio-go/v7/pkg/notification"
+	"github.com/minio/minio-go/v7/pkg/signer"
+)
+
+// Seconds a ReceiveMessage request waits for messages, the maximum of SQS.
+const sqsWaitTimeSeconds = 20
+
+// sqsQueue receives the event notifications sent to an SQS queue by S3,
+// directly or through an SNS topic.
+type sqsQueue struct {
+	queueURL string
+	region   string
+	creds    *credentials.Credentials
+	client   *http.Client
+}
+
+type sqsMessage struct {
+	MessageID     string `json:"MessageId"`
+	ReceiptHandle string `json:"ReceiptHandle"`
+	Body          string `json:"Body"`
+}
+
+// newSQSQueue returns the queue of an event source
+// 'sqs://HOST/ACCOUNT/QUEUE[?region=REGION]'. The credentials are read
+// from the AWS environment variables, the AWS credentials file or IAM.
+func newSQSQueue(u *url.URL) *sqsQueue {
+	scheme := "https"
+	if u.Query().Get("tls") == "false" {
+		scheme = "http"
+	}
+	region := u.Query().Get("region")
+	if region == "" {
+		region = "us-east-1"
+		// sqs.REGION.amazonaws.com
+		if labels := strings.Split(u.Hostname(), "."); len(labels) > 2 && labels[0] == "sqs" {
+			region = labels[1]
+		}
+	}
+	return &sqsQueue{
+		queueURL: scheme + "://" + u.Host + u.Path,
+		region:   region,
+		creds: credentials.NewChainCredentials([]credentials.Provider{
+			&credentials.EnvAWS{},
+			&credentials.FileAWSCredentials{},
+			&credentials.IAM{},
+		}),
+		client: httpClient((sqsWaitTimeSeconds + 10) * time.Second),
+	}
+}
+
+// do sends an action of the JSON protocol of SQS and decodes its response.
+func (q *sqsQueue) do(ctx context.Context, action string, input, output interface{}) error {
+	body, e := json.Marshal(input)
+	if e != nil {
+		return e
+	}
+	req, e := http.NewRequestWithContext(ctx, http.MethodPost, q.queueURL, bytes.NewReader(body))
+	if e != nil {
+		return e
+	}
+	req.Header.Set("Content-Type", "application/x-amz-json-1.0")
+	req.Header.Set("X-Amz-Target", "AmazonSQS."+action)
+	creds, e := q.creds.Get()
+	if e != nil {
+		return e
+	}
+	// The payload is signed, SQS does not accept unsigned payloads.
+	payloadHash := sha256.Sum256(body)
+	req.Header.Set("X-Amz-Content-Sha256", hex.EncodeToString(payloadHash[:]))
+	req = signer.SignV4(*req, creds.AccessKeyID, creds.SecretAccessKey, creds.SessionToken, q.region)
+
+	resp, e := q.client.Do(req)
+	if e != nil {
+		return e
+	}
+	defer resp.Body.Close()
+	respBody, e := io.ReadAll(io.LimitReader(resp.Body, webhookEventMaxSize))
+	if e != nil {
+		return e
+	}
+	if resp.StatusCode != http.StatusOK {
+		var apiErr struct {
+			Type    string `json:"__type"`
+			Message string `json:"message"`
+		}
+		if json.Unmarshal(respBody, &apiErr) == nil && apiErr.Type != "" {
+			return fmt.Errorf("%s failed: %s: %s", action, apiErr.Type, apiErr.Message)
+		}
+		return fmt.Errorf("%s failed: %s", action, resp.Status)
+	}
+	if output == nil {
+		return nil
+	}
+	return json.Unmarshal(respBody, output)
+}
+
+// receive waits for the next messages of the queue.
+func (q *sqsQueue) receive(ctx context.Context) ([]sqsMessage, error) {
+	var output struct {
+		Messages []sqsMessage `json:"Messages"`
+	}
+	e := q.do(ctx, "ReceiveMessage", map[string]interface{}{
+		"QueueUrl":            q.queueURL,
+		"MaxNumberOfMessages": 10,
+		"WaitTimeSeconds":     sqsWaitTimeSeconds,
+	}, &output)
+	return output.Messages, e
+}
+
+// delete acknowledges a message, it is not received again.
+func (q *sqsQueue) delete(ctx context.Context, m sqsMessage) error {
+	return q.do(ctx, "DeleteMessage", map[string]interface{}{
+		"QueueUrl":      q.queueURL,
+		"ReceiptHandle": m.ReceiptHandle,
+	}, nil)
+}
+
+// sqsMessageRecords returns the event records of the body of a message,
+// messages forwarded by SNS wrap the event notification. Test events have
+// no records.
+func sqsMessageRecords(body string) ([]notification.Event, error) {
+	var msg struct {
+		Type    string               `json:"Type"`
+		Message string               `json:"Message"`
+		Records []notification.Event `json:"Records"`
+	}
+	if e := json.Unmarshal([]byte(body), &msg); e != nil {
+		return nil, e
+	}
+	if msg.Type == "Notification" && msg.Message != "" {
+		return sqsMessageRecords(msg.Message)
+	}
+	for i := range msg.Records {
+		// AWS event names have no 's3:' prefix.
+		if !strings.HasPrefix(msg.Records[i].EventName, "s3:") {
+			msg.Records[i].EventName = "s3:" + msg.Records[i].EventName
+		}
+	}
+	return msg.Records, nil
+}
+
+// watchSQSSource receives the events of the watched bucket from an SQS
+// queue, messages are deleted once their events are handed over. Messages
+// without any watched event are left in the queue.
+func (c *S3Client) watchSQSSource(ctx context.Context, bucket string, u *url.URL, options WatchOptions, events []string) (*WatchObject, *probe.Error) {
+	q := newSQSQueue(u)
+
+	wo := &WatchObject{
+		EventInfoChan: make(chan []EventInfo),
+		ErrorChan:     make(chan *probe.Error),
+		DoneChan:      make(chan struct{}),
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	go func() {
+		select {
+		case <-wo.DoneChan:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	sendError := func(e error) bool {
+		select {
+		case wo.Errors() <- probe.NewError(e).Trace(options.Source):
+			return true
+		case <-ctx.Done():
+			return false
+		}
+	}
+
+	go func() {
+		defer close(wo.EventInfoChan)
+		defer close(wo.ErrorChan)
+		defer cancel()
+
+		for ctx.Err() == nil {
+			msgs, e := q.receive(ctx)
+			if e != nil {
+				if ctx.Err() != nil || !sendError(e) {
+					return
+				}
+				select {
+				case <-time.After(5 * time.Second):
+				case <-ctx.Done():
+					return
+				}
+				continue
+			}
+			for _, m := range msgs {
+				records, e := sqsMessageRecords(m.Body)
+				if e != nil {
+					if !sendError(fmt.Errorf("invalid event notification in message %s: %w", m.MessageID, e)) {
+						return
+					}
+					continue
+				}
+				if len(records) > 0 {
+					records = filterEventRecords(records, bucket, options.Prefix, options.Suffix, events)
+					if len(records) == 0 {
+						// The queue may be shared with other consumers, leave
+						// the events which are not watched in the queue.
+						continue
+					}
+					select {
+					case wo.Events() <- c.notificationToEventsInfo(notification.Info{Records: records}):
+					case <-ctx.Done():
+						// Not deleted, the message is received again.
+						return
+					}
+				}
+				if e = q.delete(ctx, m); e != nil && !sendError(e) {
+					return
+				}
+			}
+		}
+	}()
+
+	return wo, nil
+}
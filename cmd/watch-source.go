@@ -0,0 +1,218 @@
+// Copyright (c) 2015-2024 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"crypto/hmac"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/minio-go/v7/pkg/notification"
+)
+
+// Maximum size of an event notification received by the webhook event source.
+const webhookEventMaxSize = 8 << 20
+
+// webhookEvent is the body of an event notification sent to a webhook,
+// by the webhook target of MinIO or by a bridge forwarding the events of
+// a queue such as Kafka.
+type webhookEvent struct {
+	EventName string               `json:"EventName"`
+	Key       string               `json:"Key"`
+	Records   []notification.Event `json:"Records"`
+}
+
+// parseEventSource validates an external event source, either a webhook
+// listener 'webhook://[HOST]:PORT[/PATH][?token=TOKEN]', or 'webhook:PORT',
+// or an SQS queue 'sqs://HOST/ACCOUNT/QUEUE[?region=REGION]'.
+func parseEventSource(source string) (*url.URL, *probe.Error) {
+	u, e := url.Parse(source)
+	if e != nil {
+		return nil, probe.NewError(e)
+	}
+	if u.Scheme == "webhook" && u.Opaque != "" {
+		// webhook:PORT
+		u = &url.URL{Scheme: u.Scheme, Host: ":" + u.Opaque, RawQuery: u.RawQuery}
+	}
+	switch u.Scheme {
+	case "webhook":
+	case "sqs":
+		if u.Host == "" || strings.Trim(u.Path, "/") == "" {
+			return nil, probe.NewError(fmt.Errorf("missing queue in event source `%s`, use sqs://HOST/ACCOUNT/QUEUE", source))
+		}
+		return u, nil
+	case "kafka":
+		return nil, probe.NewError(fmt.Errorf("unsupported event source `%s`, forward the events of the topic to a webhook:// event source with a bridge", source))
+	default:
+		return nil, probe.NewError(fmt.Errorf("unsupported event source `%s`, use webhook://[HOST]:PORT[/PATH] or sqs://HOST/ACCOUNT/QUEUE", source))
+	}
+	if u.Port() == "" {
+		return nil, probe.NewError(fmt.Errorf("missing port in event source `%s`", source))
+	}
+	if u.Path == "" {
+		u.Path = "/"
+	}
+	return u, nil
+}
+
+// matchEventName returns true if name matches one of the event patterns,
+// patterns ending with '*' match all events with the same prefix.
+func matchEventName(patterns []string, name string) bool {
+	for _, p := range patterns {
+		if prefix, ok := strings.CutSuffix(p, "*"); ok && strings.HasPrefix(name, prefix) || p == name {
+			return true
+		}
+	}
+	return false
+}
+
+// filterEventRecords returns the records of the events of bucket, matching
+// prefix and suffix, an empty bucket matches all buckets.
+func filterEventRecords(records []notification.Event, bucket, prefix, suffix string, events []string) (filtered []notification.Event) {
+	for _, record := range records {
+		if bucket != "" && record.S3.Bucket.Name != bucket {
+			continue
+		}
+		key := record.S3.Object.Key
+		if unescaped, e := url.QueryUnescape(key); e == nil {
+			key = unescaped
+		}
+		if !strings.HasPrefix(key, prefix) || !strings.HasSuffix(key, suffix) {
+			continue
+		}
+		if !matchEventName(events, record.EventName) {
+			continue
+		}
+		filtered = append(filtered, record)
+	}
+	return filtered
+}
+
+// watchEventSource receives the events of the watched bucket from an
+// external source instead of listening for the notifications of the server.
+func (c *S3Client) watchEventSource(ctx context.Context, bucket string, options WatchOptions, events []string) (*WatchObject, *probe.Error) {
+	u, err := parseEventSource(options.Source)
+	if err != nil {
+		return nil, err
+	}
+	if u.Scheme == "sqs" {
+		return c.watchSQSSource(ctx, bucket, u, options, events)
+	}
+	token := u.Query().Get("token")
+
+	listener, e := net.Listen("tcp", u.Host)
+	if e != nil {
+		return nil, probe.NewError(e).Trace(options.Source)
+	}
+
+	wo := &WatchObject{
+		EventInfoChan: make(chan []EventInfo),
+		ErrorChan:     make(chan *probe.Error),
+		DoneChan:      make(chan struct{}),
+	}
+
+	// Closed when the source stops, before the events channel is closed.
+	stopCh := make(chan struct{})
+
+	mux := http.NewServeMux()
+	mux.HandleFunc(u.Path, func(w http.ResponseWriter, r *http.Request) {
+		if token != "" {
+			auth := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+			if !hmac.Equal([]byte(auth), []byte(token)) {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+		}
+		if r.Method != http.MethodPost {
+			// Webhook targets may check if the endpoint is online.
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+		body, e := io.ReadAll(io.LimitReader(r.Body, webhookEventMaxSize))
+		if e != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		var evs []webhookEvent
+		if e = json.Unmarshal(body, &evs); e != nil {
+			var ev webhookEvent
+			if e = json.Unmarshal(body, &ev); e != nil {
+				http.Error(w, "invalid event notification: "+e.Error(), http.StatusBadRequest)
+				return
+			}
+			evs = []webhookEvent{ev}
+		}
+		var records []notification.Event
+		for _, ev := range evs {
+			records = append(records, filterEventRecords(ev.Records, bucket, options.Prefix, options.Suffix, events)...)
+		}
+		if len(records) == 0 {
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+		// Acknowledge the events only once they are handed over, the
+		// sender retries the events which were not acknowledged.
+		select {
+		case wo.Events() <- c.notificationToEventsInfo(notification.Info{Records: records}):
+			w.WriteHeader(http.StatusOK)
+		case <-stopCh:
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	})
+	server := &http.Server{
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	go func() {
+		defer close(wo.EventInfoChan)
+		defer close(wo.ErrorChan)
+
+		errCh := make(chan error, 1)
+		go func() {
+			errCh <- server.Serve(listener)
+		}()
+
+		select {
+		case e := <-errCh:
+			if !errors.Is(e, http.ErrServerClosed) {
+				select {
+				case wo.Errors() <- probe.NewError(e).Trace(options.Source):
+				case <-wo.DoneChan:
+				}
+			}
+		case <-wo.DoneChan:
+		case <-ctx.Done():
+		}
+		close(stopCh)
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		server.Shutdown(shutdownCtx)
+	}()
+
+	return wo, nil
+}
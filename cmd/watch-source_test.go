@@ -0,0 +1,215 @@
+// Copyright (c) 2015-2024 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/minio/minio-go/v7/pkg/notification"
+)
+
+func TestFilterEventRecords(t *testing.T) {
+	record := func(event, bucket, key string) notification.Event {
+		var r notification.Event
+		r.EventName = event
+		r.S3.Bucket.Name = bucket
+		r.S3.Object.Key = key
+		return r
+	}
+	records := []notification.Event{
+		record("s3:ObjectCreated:Put", "photos", "2024/a.jpg"),
+		record("s3:ObjectCreated:Put", "photos", "2024%2Fb.png"),
+		record("s3:ObjectRemoved:Delete", "photos", "2024/c.jpg"),
+		record("s3:ObjectAccessed:Get", "photos", "2024/d.jpg"),
+		record("s3:ObjectCreated:Put", "videos", "2024/e.jpg"),
+	}
+	events := []string{string(notification.ObjectCreatedAll), string(notification.ObjectRemovedAll)}
+
+	testCases := []struct {
+		bucket, prefix, suffix string
+		keys                   []string
+	}{
+		{"photos", "", "", []string{"2024/a.jpg", "2024%2Fb.png", "2024/c.jpg"}},
+		{"photos", "2024/", ".jpg", []string{"2024/a.jpg", "2024/c.jpg"}},
+		{"photos", "2024/b", "", []string{"2024%2Fb.png"}},
+		{"", "", ".jpg", []string{"2024/a.jpg", "2024/c.jpg", "2024/e.jpg"}},
+		{"music", "", "", nil},
+	}
+	for i, testCase := range testCases {
+		var keys []string
+		for _, r := range filterEventRecords(records, testCase.bucket, testCase.prefix, testCase.suffix, events) {
+			keys = append(keys, r.S3.Object.Key)
+		}
+		if len(keys) != len(testCase.keys) {
+			t.Fatalf("Test %d: expected %v, got %v", i+1, testCase.keys, keys)
+		}
+		for j := range keys {
+			if keys[j] != testCase.keys[j] {
+				t.Fatalf("Test %d: expected %v, got %v", i+1, testCase.keys, keys)
+			}
+		}
+	}
+}
+
+func TestParseEventSource(t *testing.T) {
+	testCases := []struct {
+		source string
+		scheme string
+		host   string
+		ok     bool
+	}{
+		{"webhook://:8080/events?token=secret", "webhook", ":8080", true},
+		{"webhook:8080", "webhook", ":8080", true},
+		{"webhook://localhost", "", "", false},
+		{"sqs://sqs.us-east-1.amazonaws.com/123456789012/events", "sqs", "sqs.us-east-1.amazonaws.com", true},
+		{"sqs://sqs.us-east-1.amazonaws.com", "", "", false},
+		{"kafka://broker:9092/events", "", "", false},
+		{"amqp://broker:5672", "", "", false},
+	}
+	for i, testCase := range testCases {
+		u, err := parseEventSource(testCase.source)
+		if (err == nil) != testCase.ok {
+			t.Fatalf("Test %d: %s: expected ok %v, got %v", i+1, testCase.source, testCase.ok, err)
+		}
+		if err == nil && (u.Scheme != testCase.scheme || u.Host != testCase.host) {
+			t.Fatalf("Test %d: %s: expected %s://%s, got %s://%s", i+1, testCase.source, testCase.scheme, testCase.host, u.Scheme, u.Host)
+		}
+	}
+}
+
+func TestSQSMessageRecords(t *testing.T) {
+	direct := `{"Records":[{"eventName":"ObjectCreated:Put","s3":{"bucket":{"name":"photos"},"object":{"key":"a.jpg"}}}]}`
+	sns := `{"Type":"Notification","Message":` + strconv.Quote(direct) + `}`
+	for _, body := range []string{direct, sns} {
+		records, e := sqsMessageRecords(body)
+		if e != nil {
+			t.Fatal(e)
+		}
+		if len(records) != 1 || records[0].S3.Object.Key != "a.jpg" || records[0].EventName != "s3:ObjectCreated:Put" {
+			t.Fatalf("%s: unexpected records %v", body, records)
+		}
+	}
+	records, e := sqsMessageRecords(`{"Service":"Amazon S3","Event":"s3:TestEvent","Bucket":"photos"}`)
+	if e != nil || len(records) != 0 {
+		t.Fatalf("test event: unexpected records %v, %v", records, e)
+	}
+}
+
+func TestWatchSQSSource(t *testing.T) {
+	t.Setenv("AWS_ACCESS_KEY_ID", "AKIDEXAMPLE")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "wJalrXUtnFEMI/K7MDENG+bPxRfiCYEXAMPLEKEY")
+	t.Setenv("AWS_SESSION_TOKEN", "")
+
+	message := func(id, body string) map[string]string {
+		return map[string]string{"MessageId": id, "ReceiptHandle": "handle-" + id, "Body": body}
+	}
+	watched := `{"Records":[{"eventName":"ObjectCreated:Put","s3":{"bucket":{"name":"photos"},"object":{"key":"a.jpg"}}}]}`
+	other := `{"Records":[{"eventName":"ObjectCreated:Put","s3":{"bucket":{"name":"videos"},"object":{"key":"b.mp4"}}}]}`
+	testEvent := `{"Service":"Amazon S3","Event":"s3:TestEvent","Bucket":"photos"}`
+
+	var (
+		mu       sync.Mutex
+		receives int
+		deleted  []string
+		received = make(chan struct{})
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		sum := sha256.Sum256(body)
+		auth := r.Header.Get("Authorization")
+		if r.Header.Get("X-Amz-Content-Sha256") != hex.EncodeToString(sum[:]) ||
+			!strings.HasPrefix(auth, "AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/") || !strings.Contains(auth, "/eu-west-1/") {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		var input map[string]interface{}
+		if e := json.Unmarshal(body, &input); e != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		switch r.Header.Get("X-Amz-Target") {
+		case "AmazonSQS.ReceiveMessage":
+			receives++
+			if receives == 1 {
+				json.NewEncoder(w).Encode(map[string]interface{}{
+					"Messages": []map[string]string{message("1", watched), message("2", other), message("3", testEvent)},
+				})
+				return
+			}
+			if receives == 2 {
+				// The first messages were all handled.
+				close(received)
+			}
+			w.Write([]byte("{}"))
+		case "AmazonSQS.DeleteMessage":
+			deleted = append(deleted, input["ReceiptHandle"].(string))
+			w.Write([]byte("{}"))
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+	defer server.Close()
+
+	u, err := parseEventSource("sqs://" + strings.TrimPrefix(server.URL, "http://") + "/123456789012/events?region=eu-west-1&tls=false")
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := &S3Client{targetURL: newClientURL("http://localhost:9000/photos")}
+	wo, err := c.watchSQSSource(context.Background(), "photos", u, WatchOptions{Source: u.String()}, []string{string(notification.ObjectCreatedAll)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case events := <-wo.Events():
+		if len(events) != 1 || !strings.HasSuffix(events[0].Path, "/photos/a.jpg") {
+			t.Fatalf("unexpected events %+v", events)
+		}
+	case err := <-wo.Errors():
+		t.Fatal(err)
+	case <-time.After(10 * time.Second):
+		t.Fatal("timed out waiting for the events")
+	}
+	select {
+	case <-received:
+	case err := <-wo.Errors():
+		t.Fatal(err)
+	case <-time.After(10 * time.Second):
+		t.Fatal("timed out waiting for the messages to be handled")
+	}
+	close(wo.DoneChan)
+
+	mu.Lock()
+	defer mu.Unlock()
+	// The message of the other bucket is left to the other consumers.
+	if len(deleted) != 2 || deleted[0] != "handle-1" || deleted[1] != "handle-3" {
+		t.Fatalf("expected messages 1 and 3 to be deleted, got %v", deleted)
+	}
+}
@@ -0,0 +1,241 @@
+// Copyright (c) 2015-2024 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Formats of the events written by watch.
+const (
+	watchFormatJSONL = "jsonl"
+	watchFormatCSV   = "csv"
+)
+
+// watchRotateTimeFormat is the layout of the timestamp added to the name
+// of rotated files, it sorts lexically in time order.
+const watchRotateTimeFormat = "2006-01-02T15-04-05.000"
+
+var watchCSVHeader = []string{"time", "type", "path", "size", "host", "port", "userAgent"}
+
+// watchWriter writes events to stdout or to a file, one event per line.
+// The file is rotated once it grows beyond maxSize bytes or once it has
+// been written to for longer than interval, rotated files are renamed
+// with the time of the rotation and only the keep most recent are kept.
+// Rotation happens when an event is written, an idle file is not rotated.
+type watchWriter struct {
+	format   string
+	path     string
+	maxSize  int64
+	interval time.Duration
+	keep     int
+
+	w      io.Writer
+	f      *os.File
+	size   int64
+	opened time.Time
+
+	now func() time.Time
+}
+
+// newWatchWriter returns a writer of events in format to path, or to
+// stdout when path is empty.
+func newWatchWriter(path, format string, maxSize int64, interval time.Duration, keep int) (*watchWriter, error) {
+	switch format {
+	case watchFormatJSONL, watchFormatCSV:
+	default:
+		return nil, fmt.Errorf("unknown format `%s`, must be one of %s, %s", format, watchFormatJSONL, watchFormatCSV)
+	}
+	ww := &watchWriter{
+		format:   format,
+		path:     path,
+		maxSize:  maxSize,
+		interval: interval,
+		keep:     keep,
+		w:        os.Stdout,
+		now:      time.Now,
+	}
+	if path == "" {
+		if format == watchFormatCSV {
+			if _, e := ww.w.Write(ww.encode(watchCSVHeader)); e != nil {
+				return nil, e
+			}
+		}
+		return ww, nil
+	}
+	if e := ww.open(); e != nil {
+		return nil, e
+	}
+	return ww, nil
+}
+
+// open opens the output file for appending, a CSV header is written to
+// new files.
+func (ww *watchWriter) open() error {
+	f, e := os.OpenFile(ww.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+	if e != nil {
+		return e
+	}
+	st, e := f.Stat()
+	if e != nil {
+		f.Close()
+		return e
+	}
+	ww.f, ww.w, ww.size, ww.opened = f, f, st.Size(), ww.now()
+	if ww.size == 0 && ww.format == watchFormatCSV {
+		n, e := ww.w.Write(ww.encode(watchCSVHeader))
+		ww.size += int64(n)
+		return e
+	}
+	return nil
+}
+
+// Write writes the event of msg, rotating the output file beforehand if
+// needed.
+func (ww *watchWriter) Write(msg watchMessage) error {
+	var line []byte
+	if ww.format == watchFormatCSV {
+		line = ww.encode([]string{
+			msg.Event.Time,
+			string(msg.Event.Type),
+			msg.Event.Path,
+			strconv.FormatInt(msg.Event.Size, 10),
+			msg.Source.Host,
+			msg.Source.Port,
+			msg.Source.UserAgent,
+		})
+	} else {
+		msg.Status = "success"
+		b, e := json.Marshal(msg)
+		if e != nil {
+			return e
+		}
+		line = append(b, '\n')
+	}
+	if ww.f != nil && ww.needsRotation(int64(len(line))) {
+		if e := ww.rotate(); e != nil {
+			return e
+		}
+	}
+	n, e := ww.w.Write(line)
+	ww.size += int64(n)
+	return e
+}
+
+// needsRotation tells whether writing n more bytes must go to a new file.
+func (ww *watchWriter) needsRotation(n int64) bool {
+	if ww.size == 0 {
+		return false
+	}
+	if ww.maxSize > 0 && ww.size+n > ww.maxSize {
+		return true
+	}
+	return ww.interval > 0 && ww.now().Sub(ww.opened) >= ww.interval
+}
+
+// rotate renames the current file, opens a new one and removes the
+// rotated files beyond the number to keep.
+func (ww *watchWriter) rotate() error {
+	if e := ww.f.Close(); e != nil {
+		return e
+	}
+	ww.f = nil
+	// Never overwrite a file rotated within the same millisecond.
+	t := ww.now()
+	name := watchRotatedName(ww.path, t)
+	for {
+		if _, e := os.Lstat(name); os.IsNotExist(e) {
+			break
+		}
+		t = t.Add(time.Millisecond)
+		name = watchRotatedName(ww.path, t)
+	}
+	if e := os.Rename(ww.path, name); e != nil {
+		return e
+	}
+	if e := ww.open(); e != nil {
+		return e
+	}
+	return ww.prune()
+}
+
+// prune removes the oldest rotated files, keeping the most recent ww.keep.
+func (ww *watchWriter) prune() error {
+	if ww.keep <= 0 {
+		return nil
+	}
+	dir, name := filepath.Split(ww.path)
+	ext := filepath.Ext(name)
+	base := strings.TrimSuffix(name, ext)
+	entries, e := os.ReadDir(filepath.Clean(dir))
+	if e != nil {
+		return e
+	}
+	var rotated []string
+	for _, entry := range entries {
+		stamp, ok := strings.CutPrefix(entry.Name(), base+"-")
+		if !ok || !strings.HasSuffix(stamp, ext) {
+			continue
+		}
+		if _, e := time.Parse(watchRotateTimeFormat, strings.TrimSuffix(stamp, ext)); e == nil {
+			rotated = append(rotated, filepath.Join(dir, entry.Name()))
+		}
+	}
+	sort.Strings(rotated)
+	for len(rotated) > ww.keep {
+		if e := os.Remove(rotated[0]); e != nil {
+			return e
+		}
+		rotated = rotated[1:]
+	}
+	return nil
+}
+
+// Close closes the output file.
+func (ww *watchWriter) Close() error {
+	if ww.f == nil {
+		return nil
+	}
+	return ww.f.Close()
+}
+
+// encode returns record as a CSV line.
+func (ww *watchWriter) encode(record []string) []byte {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	w.Write(record)
+	w.Flush()
+	return buf.Bytes()
+}
+
+// watchRotatedName returns the name path is renamed to when rotated at t,
+// e.g. events.jsonl becomes events-2024-01-02T15-04-05.000.jsonl.
+func watchRotatedName(path string, t time.Time) string {
+	ext := filepath.Ext(path)
+	return strings.TrimSuffix(path, ext) + "-" + t.UTC().Format(watchRotateTimeFormat) + ext
+}
@@ -0,0 +1,80 @@
+// Copyright (c) 2015-2024 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWatchWriterRotate(t *testing.T) {
+	dir := t.TempDir()
+	out := filepath.Join(dir, "events.csv")
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	ww, e := newWatchWriter(out, watchFormatCSV, 0, time.Hour, 2)
+	if e != nil {
+		t.Fatal(e)
+	}
+	ww.now = func() time.Time { return now }
+	ww.opened = now
+	for i := 0; i < 4; i++ {
+		msg := watchMessage{}
+		msg.Event.Path = "bucket/object"
+		msg.Event.Size = int64(i)
+		if e = ww.Write(msg); e != nil {
+			t.Fatal(e)
+		}
+		now = now.Add(time.Hour)
+	}
+	if e = ww.Close(); e != nil {
+		t.Fatal(e)
+	}
+
+	entries, e := os.ReadDir(dir)
+	if e != nil {
+		t.Fatal(e)
+	}
+	var names []string
+	for _, entry := range entries {
+		names = append(names, entry.Name())
+	}
+	expected := []string{
+		"events-2024-01-02T05-04-05.000.csv",
+		"events-2024-01-02T06-04-05.000.csv",
+		"events.csv",
+	}
+	if strings.Join(names, ",") != strings.Join(expected, ",") {
+		t.Fatalf("expected files %v, got %v", expected, names)
+	}
+
+	// Every file starts with the CSV header and holds a single event.
+	for _, name := range names {
+		data, e := os.ReadFile(filepath.Join(dir, name))
+		if e != nil {
+			t.Fatal(e)
+		}
+		lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+		if len(lines) != 2 || lines[0] != strings.Join(watchCSVHeader, ",") {
+			t.Errorf("%s: unexpected content %q", name, data)
+		}
+	}
+}
@@ -31,6 +31,8 @@ import (
 type EventInfo struct {
 	Time         string
 	Size         int64
+	ETag         string
+	VersionID    string
 	UserMetadata map[string]string
 	Path         string
 	Host         string
@@ -45,6 +47,8 @@ type WatchOptions struct {
 	Suffix    string
 	Events    []string
 	Recursive bool
+	// Source of the events when not the server, see parseEventSource.
+	Source string
 }
 
 // WatchObject captures watch channels to read and listen on.
@@ -112,10 +116,11 @@ func (w *Watcher) Stop() {
 }
 
 // Join the watcher with client
-func (w *Watcher) Join(ctx context.Context, client Client, recursive bool) *probe.Error {
+func (w *Watcher) Join(ctx context.Context, client Client, recursive bool, source string) *probe.Error {
 	wo, err := client.Watch(ctx, WatchOptions{
 		Recursive: recursive,
 		Events:    []string{"put", "delete", "bucket-creation", "bucket-removal"},
+		Source:    source,
 	})
 	if err != nil {
 		return err